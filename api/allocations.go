@@ -123,6 +123,19 @@ func (a *Allocations) Stats(alloc *Allocation, q *QueryOptions) (*AllocResourceU
 	return &resp, err
 }
 
+// StatsHistory gets the resource usage history of each task of the
+// allocation, oldest sample first. The "task" query parameter limits the
+// history to a single task.
+//
+// Note: for cluster topologies where API consumers don't have network access to
+// Nomad clients, set api.ClientConnTimeout to a small value (ex 1ms) to avoid
+// long pauses on this API call.
+func (a *Allocations) StatsHistory(alloc *Allocation, q *QueryOptions) (map[string][]*StatsSample, error) {
+	var resp map[string][]*StatsSample
+	_, err := a.client.query("/v1/client/allocation/"+alloc.ID+"/stats/history", &resp, q)
+	return resp, err
+}
+
 // Checks gets status information for nomad service checks that exist in the allocation.
 //
 // Note: for cluster topologies where API consumers don't have network access to
@@ -320,6 +333,7 @@ type AllocationMetric struct {
 	AllocationTime    time.Duration
 	CoalescedFailures int
 	ScoreMetaData     []*NodeScoreMeta
+	GangUnsatisfied   string
 }
 
 // NodeScoreMeta is used to serialize node scoring metadata
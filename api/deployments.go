@@ -211,6 +211,9 @@ type DeploymentState struct {
 	PlacedAllocs      int
 	HealthyAllocs     int
 	UnhealthyAllocs   int
+	RolloutSteps      int
+	CurrentStep       int
+	StepPercent       int
 }
 
 // DeploymentIndexSort is a wrapper to sort deployments by CreateIndex. We
@@ -259,6 +259,30 @@ func queryClientNode(c *Client, alloc *Allocation, reqPath string, q *QueryOptio
 // long pauses on this API call.
 func (a *AllocFS) Logs(alloc *Allocation, follow bool, task, logType, origin string,
 	offset int64, cancel <-chan struct{}, q *QueryOptions) (<-chan *StreamFrame, <-chan error) {
+	return a.LogsWithFilter(alloc, follow, task, logType, origin, offset, nil, cancel, q)
+}
+
+// LogFilter filters the log lines streamed by LogsWithFilter. The filtering
+// happens on the client node so that only matching lines are sent.
+type LogFilter struct {
+	// Include is a regular expression that log lines must match.
+	Include string
+
+	// Exclude is a regular expression that log lines must not match.
+	Exclude string
+
+	// Since and Until bound the timestamps of the log lines. Only lines that
+	// start with an RFC3339 timestamp are filtered by time; lines without
+	// one inherit the timestamp of the previous line. The zero value leaves
+	// the bound open.
+	Since time.Time
+	Until time.Time
+}
+
+// LogsWithFilter is like Logs but only streams the log lines that pass the
+// filter. A nil filter streams all the log lines.
+func (a *AllocFS) LogsWithFilter(alloc *Allocation, follow bool, task, logType, origin string,
+	offset int64, filter *LogFilter, cancel <-chan struct{}, q *QueryOptions) (<-chan *StreamFrame, <-chan error) {
 
 	errCh := make(chan error, 1)
 
@@ -270,6 +294,21 @@ func (a *AllocFS) Logs(alloc *Allocation, follow bool, task, logType, origin str
 			q.Params["type"] = logType
 			q.Params["origin"] = origin
 			q.Params["offset"] = strconv.FormatInt(offset, 10)
+			if filter == nil {
+				return
+			}
+			if filter.Include != "" {
+				q.Params["include"] = filter.Include
+			}
+			if filter.Exclude != "" {
+				q.Params["exclude"] = filter.Exclude
+			}
+			if !filter.Since.IsZero() {
+				q.Params["since"] = filter.Since.Format(time.RFC3339Nano)
+			}
+			if !filter.Until.IsZero() {
+				q.Params["until"] = filter.Until.Format(time.RFC3339Nano)
+			}
 		})
 	if err != nil {
 		errCh <- err
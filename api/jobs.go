@@ -484,6 +484,17 @@ func (j *Jobs) PeriodicForce(jobID string, q *WriteOptions) (string, *WriteMeta,
 	return resp.EvalID, wm, nil
 }
 
+// PeriodicHistory returns the outcomes of the recent launches of the periodic
+// job, most recent launch first.
+func (j *Jobs) PeriodicHistory(jobID string, q *QueryOptions) ([]*PeriodicRun, *QueryMeta, error) {
+	var resp []*PeriodicRun
+	qm, err := j.client.query("/v1/job/"+url.PathEscape(jobID)+"/periodic/history", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
 // PlanOptions is used to pass through job planning parameters
 type PlanOptions struct {
 	Diff           bool
@@ -529,6 +540,27 @@ func (j *Jobs) Summary(jobID string, q *QueryOptions) (*JobSummary, *QueryMeta,
 	return &resp, qm, nil
 }
 
+// Stats summarizes the resource usage history of the allocations of a job into
+// percentiles per task, to help right-size the resources of the tasks. Only
+// running allocations are summarized unless terminal is set.
+func (j *Jobs) Stats(jobID string, terminal bool, q *QueryOptions) (*JobStats, *QueryMeta, error) {
+	var resp JobStats
+	u, err := url.Parse("/v1/job/" + url.PathEscape(jobID) + "/stats")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v := u.Query()
+	v.Add("terminal", strconv.FormatBool(terminal))
+	u.RawQuery = v.Encode()
+
+	qm, err := j.client.query(u.String(), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 func (j *Jobs) Dispatch(jobID string, meta map[string]string,
 	payload []byte, idPrefixTemplate string, q *WriteOptions) (*JobDispatchResponse, *WriteMeta, error) {
 	var resp JobDispatchResponse
@@ -545,6 +577,17 @@ func (j *Jobs) Dispatch(jobID string, meta map[string]string,
 	return &resp, wm, nil
 }
 
+// DispatchQueue is used to list the queued dispatches of a parameterized job,
+// in the order they will be released.
+func (j *Jobs) DispatchQueue(jobID string, q *QueryOptions) ([]*QueuedDispatch, *QueryMeta, error) {
+	var resp []*QueuedDispatch
+	qm, err := j.client.query("/v1/job/"+url.PathEscape(jobID)+"/dispatch/queue", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
 // Revert is used to revert the given job to the passed version. If
 // enforceVersion is set, the job is only reverted if the current version is at
 // the passed version.
@@ -596,15 +639,17 @@ type periodicForceResponse struct {
 
 // UpdateStrategy defines a task groups update strategy.
 type UpdateStrategy struct {
-	Stagger          *time.Duration `mapstructure:"stagger" hcl:"stagger,optional"`
-	MaxParallel      *int           `mapstructure:"max_parallel" hcl:"max_parallel,optional"`
-	HealthCheck      *string        `mapstructure:"health_check" hcl:"health_check,optional"`
-	MinHealthyTime   *time.Duration `mapstructure:"min_healthy_time" hcl:"min_healthy_time,optional"`
-	HealthyDeadline  *time.Duration `mapstructure:"healthy_deadline" hcl:"healthy_deadline,optional"`
-	ProgressDeadline *time.Duration `mapstructure:"progress_deadline" hcl:"progress_deadline,optional"`
-	Canary           *int           `mapstructure:"canary" hcl:"canary,optional"`
-	AutoRevert       *bool          `mapstructure:"auto_revert" hcl:"auto_revert,optional"`
-	AutoPromote      *bool          `mapstructure:"auto_promote" hcl:"auto_promote,optional"`
+	Stagger          *time.Duration  `mapstructure:"stagger" hcl:"stagger,optional"`
+	MaxParallel      *int            `mapstructure:"max_parallel" hcl:"max_parallel,optional"`
+	HealthCheck      *string         `mapstructure:"health_check" hcl:"health_check,optional"`
+	MinHealthyTime   *time.Duration  `mapstructure:"min_healthy_time" hcl:"min_healthy_time,optional"`
+	HealthyDeadline  *time.Duration  `mapstructure:"healthy_deadline" hcl:"healthy_deadline,optional"`
+	ProgressDeadline *time.Duration  `mapstructure:"progress_deadline" hcl:"progress_deadline,optional"`
+	Canary           *int            `mapstructure:"canary" hcl:"canary,optional"`
+	AutoRevert       *bool           `mapstructure:"auto_revert" hcl:"auto_revert,optional"`
+	AutoPromote      *bool           `mapstructure:"auto_promote" hcl:"auto_promote,optional"`
+	Analysis         *CanaryAnalysis `mapstructure:"analysis" hcl:"analysis,block"`
+	Steps            []*RolloutStep  `mapstructure:"step" hcl:"step,block"`
 }
 
 // DefaultUpdateStrategy provides a baseline that can be used to upgrade
@@ -666,6 +711,11 @@ func (u *UpdateStrategy) Copy() *UpdateStrategy {
 		copy.AutoPromote = pointerOf(*u.AutoPromote)
 	}
 
+	copy.Analysis = u.Analysis.Copy()
+	for _, s := range u.Steps {
+		copy.Steps = append(copy.Steps, s.Copy())
+	}
+
 	return copy
 }
 
@@ -709,6 +759,17 @@ func (u *UpdateStrategy) Merge(o *UpdateStrategy) {
 	if o.AutoPromote != nil {
 		u.AutoPromote = pointerOf(*o.AutoPromote)
 	}
+
+	if o.Analysis != nil {
+		u.Analysis = o.Analysis.Copy()
+	}
+
+	if len(o.Steps) != 0 {
+		u.Steps = nil
+		for _, s := range o.Steps {
+			u.Steps = append(u.Steps, s.Copy())
+		}
+	}
 }
 
 func (u *UpdateStrategy) Canonicalize() {
@@ -749,6 +810,14 @@ func (u *UpdateStrategy) Canonicalize() {
 	if u.AutoPromote == nil {
 		u.AutoPromote = d.AutoPromote
 	}
+
+	if u.Analysis != nil {
+		u.Analysis.Canonicalize()
+	}
+
+	for _, s := range u.Steps {
+		s.Canonicalize()
+	}
 }
 
 // Empty returns whether the UpdateStrategy is empty or has user defined values.
@@ -793,9 +862,120 @@ func (u *UpdateStrategy) Empty() bool {
 		return false
 	}
 
+	if u.Analysis != nil {
+		return false
+	}
+
+	if len(u.Steps) != 0 {
+		return false
+	}
+
 	return true
 }
 
+// CanaryAnalysis configures the automated analysis of the canaries of a task
+// group, by comparing the metrics of the canary and stable allocations
+// reported by a Prometheus-compatible metrics source.
+type CanaryAnalysis struct {
+	Source         *string         `mapstructure:"source" hcl:"source,optional"`
+	Interval       *time.Duration  `mapstructure:"interval" hcl:"interval,optional"`
+	Window         *time.Duration  `mapstructure:"window" hcl:"window,optional"`
+	SuccessfulRuns *int            `mapstructure:"successful_runs" hcl:"successful_runs,optional"`
+	FailureLimit   *int            `mapstructure:"failure_limit" hcl:"failure_limit,optional"`
+	OnFailure      *string         `mapstructure:"on_failure" hcl:"on_failure,optional"`
+	Metrics        []*CanaryMetric `mapstructure:"metric" hcl:"metric,block"`
+}
+
+func (a *CanaryAnalysis) Copy() *CanaryAnalysis {
+	if a == nil {
+		return nil
+	}
+
+	copy := &CanaryAnalysis{
+		Source:         a.Source,
+		Interval:       a.Interval,
+		Window:         a.Window,
+		SuccessfulRuns: a.SuccessfulRuns,
+		FailureLimit:   a.FailureLimit,
+		OnFailure:      a.OnFailure,
+	}
+	for _, m := range a.Metrics {
+		copy.Metrics = append(copy.Metrics, m.Copy())
+	}
+
+	return copy
+}
+
+func (a *CanaryAnalysis) Canonicalize() {
+	if a.Source == nil {
+		a.Source = pointerOf("")
+	}
+	if a.Interval == nil {
+		a.Interval = pointerOf(1 * time.Minute)
+	}
+	if a.Window == nil {
+		a.Window = pointerOf(5 * time.Minute)
+	}
+	if a.SuccessfulRuns == nil {
+		a.SuccessfulRuns = pointerOf(3)
+	}
+	if a.FailureLimit == nil {
+		a.FailureLimit = pointerOf(0)
+	}
+	if a.OnFailure == nil {
+		a.OnFailure = pointerOf("fail")
+	}
+}
+
+// CanaryMetric is a metric compared between the canary and stable allocations
+// of a task group. Lower values are considered better.
+type CanaryMetric struct {
+	Name        string   `hcl:"name,label"`
+	Query       string   `mapstructure:"query" hcl:"query,optional"`
+	Max         *float64 `mapstructure:"max" hcl:"max,optional"`
+	MaxIncrease *float64 `mapstructure:"max_increase" hcl:"max_increase,optional"`
+}
+
+func (m *CanaryMetric) Copy() *CanaryMetric {
+	if m == nil {
+		return nil
+	}
+
+	copy := *m
+	return &copy
+}
+
+// RolloutStep is a step of the progressive rollout of a task group, which
+// rolls the new version out to a percentage of its allocations.
+type RolloutStep struct {
+	Percent *int           `mapstructure:"percent" hcl:"percent,optional"`
+	Pause   *time.Duration `mapstructure:"pause" hcl:"pause,optional"`
+}
+
+func (s *RolloutStep) Copy() *RolloutStep {
+	if s == nil {
+		return nil
+	}
+
+	copy := new(RolloutStep)
+	if s.Percent != nil {
+		copy.Percent = pointerOf(*s.Percent)
+	}
+	if s.Pause != nil {
+		copy.Pause = pointerOf(*s.Pause)
+	}
+	return copy
+}
+
+func (s *RolloutStep) Canonicalize() {
+	if s.Percent == nil {
+		s.Percent = pointerOf(0)
+	}
+	if s.Pause == nil {
+		s.Pause = pointerOf(time.Duration(0))
+	}
+}
+
 type Multiregion struct {
 	Strategy *MultiregionStrategy `hcl:"strategy,block"`
 	Regions  []*MultiregionRegion `hcl:"region,block"`
@@ -869,14 +1049,37 @@ type MultiregionRegion struct {
 	Meta        map[string]string `hcl:"meta,block"`
 }
 
+const (
+	JobDependencyConditionRunning  = "running"
+	JobDependencyConditionHealthy  = "healthy"
+	JobDependencyConditionComplete = "complete"
+)
+
+// JobDependency is a job that must reach the given condition before
+// allocations for the dependent job are placed.
+type JobDependency struct {
+	JobID     string  `hcl:",label"`
+	Condition *string `hcl:"condition,optional"`
+}
+
+func (d *JobDependency) Canonicalize() {
+	if d.Condition == nil {
+		d.Condition = pointerOf(JobDependencyConditionRunning)
+	}
+}
+
 // PeriodicConfig is for serializing periodic config for a job.
 type PeriodicConfig struct {
 	Enabled         *bool    `hcl:"enabled,optional"`
 	Spec            *string  `hcl:"cron,optional"`
 	Specs           []string `hcl:"crons,optional"`
 	SpecType        *string
-	ProhibitOverlap *bool   `mapstructure:"prohibit_overlap" hcl:"prohibit_overlap,optional"`
-	TimeZone        *string `mapstructure:"time_zone" hcl:"time_zone,optional"`
+	ProhibitOverlap *bool            `mapstructure:"prohibit_overlap" hcl:"prohibit_overlap,optional"`
+	TimeZone        *string          `mapstructure:"time_zone" hcl:"time_zone,optional"`
+	Catchup         *PeriodicCatchup `hcl:"catchup,block"`
+
+	SuccessfulJobsHistoryLimit *int `mapstructure:"successful_jobs_history_limit" hcl:"successful_jobs_history_limit,optional"`
+	FailedJobsHistoryLimit     *int `mapstructure:"failed_jobs_history_limit" hcl:"failed_jobs_history_limit,optional"`
 }
 
 func (p *PeriodicConfig) Canonicalize() {
@@ -898,6 +1101,41 @@ func (p *PeriodicConfig) Canonicalize() {
 	if p.TimeZone == nil || *p.TimeZone == "" {
 		p.TimeZone = pointerOf("UTC")
 	}
+	if p.Catchup != nil {
+		p.Catchup.Canonicalize()
+	}
+	if p.SuccessfulJobsHistoryLimit == nil {
+		p.SuccessfulJobsHistoryLimit = pointerOf(3)
+	}
+	if p.FailedJobsHistoryLimit == nil {
+		p.FailedJobsHistoryLimit = pointerOf(1)
+	}
+}
+
+const (
+	PeriodicCatchupNone   = "none"
+	PeriodicCatchupLatest = "latest"
+	PeriodicCatchupAll    = "all"
+)
+
+// PeriodicCatchup configures how a periodic job catches up on the launches
+// that were missed while no leader was running the periodic dispatcher.
+type PeriodicCatchup struct {
+	Policy           *string        `hcl:"policy,optional"`
+	Limit            *int           `hcl:"limit,optional"`
+	StartingDeadline *time.Duration `mapstructure:"starting_deadline" hcl:"starting_deadline,optional"`
+}
+
+func (c *PeriodicCatchup) Canonicalize() {
+	if c.Policy == nil {
+		c.Policy = pointerOf(PeriodicCatchupLatest)
+	}
+	if c.Limit == nil {
+		c.Limit = pointerOf(0)
+	}
+	if c.StartingDeadline == nil {
+		c.StartingDeadline = pointerOf(time.Duration(0))
+	}
 }
 
 // Next returns the closest time instant matching the spec that is after the
@@ -954,9 +1192,25 @@ func (p *PeriodicConfig) GetLocation() (*time.Location, error) {
 
 // ParameterizedJobConfig is used to configure the parameterized job.
 type ParameterizedJobConfig struct {
-	Payload      string   `hcl:"payload,optional"`
-	MetaRequired []string `mapstructure:"meta_required" hcl:"meta_required,optional"`
-	MetaOptional []string `mapstructure:"meta_optional" hcl:"meta_optional,optional"`
+	Payload       string               `hcl:"payload,optional"`
+	MetaRequired  []string             `mapstructure:"meta_required" hcl:"meta_required,optional"`
+	MetaOptional  []string             `mapstructure:"meta_optional" hcl:"meta_optional,optional"`
+	MaxConcurrent *int                 `mapstructure:"max_concurrent" hcl:"max_concurrent,optional"`
+	Queue         *DispatchQueueConfig `hcl:"queue,block"`
+	RateLimit     *DispatchRateLimit   `mapstructure:"rate_limit" hcl:"rate_limit,block"`
+}
+
+// DispatchQueueConfig is used to queue the dispatches that exceed the limits
+// of a parameterized job.
+type DispatchQueueConfig struct {
+	MaxDepth *int `mapstructure:"max_depth" hcl:"max_depth,optional"`
+}
+
+// DispatchRateLimit is used to limit the rate at which the dispatched jobs of
+// a parameterized job are started.
+type DispatchRateLimit struct {
+	Limit    *int           `hcl:"limit,optional"`
+	Interval *time.Duration `hcl:"interval,optional"`
 }
 
 // JobSubmission is used to hold information about the original content of a job
@@ -1097,6 +1351,7 @@ type Job struct {
 	Spreads          []*Spread               `hcl:"spread,block"`
 	Periodic         *PeriodicConfig         `hcl:"periodic,block"`
 	ParameterizedJob *ParameterizedJobConfig `hcl:"parameterized,block"`
+	DependsOn        []*JobDependency        `hcl:"depends_on,block"`
 	Reschedule       *ReschedulePolicy       `hcl:"reschedule,block"`
 	Migrate          *MigrateStrategy        `hcl:"migrate,block"`
 	Meta             map[string]string       `hcl:"meta,block"`
@@ -1210,6 +1465,9 @@ func (j *Job) Canonicalize() {
 	if j.Multiregion != nil {
 		j.Multiregion.Canonicalize()
 	}
+	for _, dep := range j.DependsOn {
+		dep.Canonicalize()
+	}
 
 	for _, tg := range j.TaskGroups {
 		tg.Canonicalize(j)
@@ -1276,6 +1534,46 @@ type TaskGroupSummary struct {
 	Unknown  int
 }
 
+// JobStats summarizes the resource usage history of the allocations of a job.
+type JobStats struct {
+	// TaskGroups is the resource usage of each task group, keyed by name
+	TaskGroups map[string]*TaskGroupUsage
+
+	// Errors contains the reason the history of an allocation couldn't be
+	// read, keyed by allocation ID
+	Errors map[string]string
+}
+
+// TaskGroupUsage summarizes the resource usage of the tasks of a task group
+// across its allocations.
+type TaskGroupUsage struct {
+	// Allocs is the number of allocations summarized
+	Allocs int
+
+	// Tasks is the resource usage of each task, keyed by name
+	Tasks map[string]*TaskUsage
+}
+
+// TaskUsage summarizes the resource usage history of a task.
+type TaskUsage struct {
+	// Samples is the number of history samples summarized
+	Samples int
+
+	// CPU is the CPU usage in MHz
+	CPU UsagePercentiles
+
+	// MemoryMB is the memory usage in MB
+	MemoryMB UsagePercentiles
+}
+
+// UsagePercentiles are the percentiles of the mean usage of each sample,
+// weighted by the duration of the samples, and the maximum usage observed.
+type UsagePercentiles struct {
+	P50 float64
+	P95 float64
+	Max float64
+}
+
 // JobListStub is used to return a subset of information about
 // jobs during list operations.
 type JobListStub struct {
@@ -1564,6 +1862,10 @@ type DesiredUpdates struct {
 	DestructiveUpdate uint64
 	Canary            uint64
 	Preemptions       uint64
+
+	// InPlaceResize is the number of in-place updates that resize the cpu or
+	// memory of running tasks.
+	InPlaceResize uint64
 }
 
 type JobDispatchRequest struct {
@@ -1578,9 +1880,50 @@ type JobDispatchResponse struct {
 	EvalID          string
 	EvalCreateIndex uint64
 	JobCreateIndex  uint64
+	Queued          bool
 	WriteMeta
 }
 
+// QueuedDispatch is a dispatch of a parameterized job that is waiting for the
+// limits of the job to allow it to start.
+type QueuedDispatch struct {
+	ID          string
+	Namespace   string
+	ParentID    string
+	Meta        map[string]string
+	EnqueueTime int64
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+const (
+	PeriodicRunStatusSuccessful = "successful"
+	PeriodicRunStatusFailed     = "failed"
+)
+
+// PeriodicRun is the outcome of a job launched by a periodic job.
+type PeriodicRun struct {
+	Namespace   string
+	ParentID    string
+	JobID       string
+	LaunchTime  time.Time
+	FinishTime  time.Time
+	Status      string
+	Tasks       []*PeriodicRunTask
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// PeriodicRunTask is the outcome of a task of a job launched by a periodic
+// job.
+type PeriodicRunTask struct {
+	AllocID   string
+	TaskGroup string
+	Task      string
+	Failed    bool
+	ExitCode  int
+}
+
 // JobVersionsResponse is used for a job get versions request
 type JobVersionsResponse struct {
 	Versions []*Job
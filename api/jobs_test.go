@@ -816,6 +816,9 @@ func TestJobs_Canonicalize(t *testing.T) {
 					SpecType:        pointerOf(PeriodicSpecCron),
 					ProhibitOverlap: pointerOf(false),
 					TimeZone:        pointerOf("UTC"),
+
+					SuccessfulJobsHistoryLimit: pointerOf(3),
+					FailedJobsHistoryLimit:     pointerOf(1),
 				},
 			},
 		},
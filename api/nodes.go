@@ -472,6 +472,18 @@ func (n *Nodes) Stats(nodeID string, q *QueryOptions) (*HostStats, error) {
 	return &resp, nil
 }
 
+// StatsHistory gets the resource usage history of the host, oldest sample
+// first.
+func (n *Nodes) StatsHistory(nodeID string, q *QueryOptions) ([]*StatsSample, error) {
+	var resp []*StatsSample
+	path := fmt.Sprintf("/v1/client/stats/history?node_id=%s", nodeID)
+	if _, err := n.client.query(path, &resp, q); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
 func (n *Nodes) GC(nodeID string, q *QueryOptions) error {
 	path := fmt.Sprintf("/v1/client/gc?node_id=%s", nodeID)
 	_, err := n.client.query(path, nil, q)
@@ -174,6 +174,25 @@ func TestNode_Stats(t *testing.T) {
 	must.NonZero(t, stats.AllocDirStats.Size)
 }
 
+func TestNode_StatsHistory(t *testing.T) {
+	testutil.Parallel(t)
+
+	c, s := makeClient(t, nil, func(c *testutil.TestServerConfig) {
+		c.DevMode = true
+	})
+	defer s.Stop()
+	nodesAPI := c.Nodes()
+	nodeID := oneNodeFromNodeList(t, nodesAPI).ID
+
+	// the history only has samples once the first window is complete
+	history, err := nodesAPI.StatsHistory(nodeID, nil)
+	must.NoError(t, err)
+	must.NotNil(t, history)
+	for _, sample := range history {
+		must.Positive(t, sample.Window)
+	}
+}
+
 func TestNodes_NoSecretID(t *testing.T) {
 	testutil.Parallel(t)
 
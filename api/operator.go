@@ -180,11 +180,42 @@ type SchedulerConfiguration struct {
 	// until the configuration is updated and written to the Nomad servers.
 	PauseEvalBroker bool
 
+	// RankIterators is the list of additional ranking iterators, registered
+	// with the scheduler by name, that are inserted into the ranking stage of
+	// the scheduler in the order given.
+	RankIterators []*SchedulerRankIterator
+
+	// FairShareConfig specifies whether evaluations and preemption should
+	// account for how much of the cluster each namespace is using relative to
+	// its configured share.
+	FairShareConfig FairShareConfig
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
 }
 
+// FairShareConfig configures weighted fair-share scheduling across namespaces.
+type FairShareConfig struct {
+	// Enabled specifies if fair-share scheduling is enabled.
+	Enabled bool
+
+	// Weights maps namespace names to their relative share of the cluster.
+	// Namespaces that are not listed have a weight of 1.
+	Weights map[string]int
+}
+
+// SchedulerRankIterator configures an additional ranking iterator to be
+// inserted into the scheduler.
+type SchedulerRankIterator struct {
+	// Name is the name the iterator was registered with.
+	Name string
+
+	// Weight scales the scores produced by the iterator relative to the
+	// built-in scorers. Defaults to 1 if unset, and 0 disables the iterator.
+	Weight *float64
+}
+
 // SchedulerConfigurationResponse is the response object that wraps SchedulerConfiguration
 type SchedulerConfigurationResponse struct {
 	// SchedulerConfig contains scheduler config options
@@ -211,6 +242,7 @@ type SchedulerAlgorithm string
 const (
 	SchedulerAlgorithmBinpack SchedulerAlgorithm = "binpack"
 	SchedulerAlgorithmSpread  SchedulerAlgorithm = "spread"
+	SchedulerAlgorithmCost    SchedulerAlgorithm = "cost"
 )
 
 // PreemptionConfig specifies whether preemption is enabled based on scheduler type
@@ -254,6 +286,81 @@ func (op *Operator) SchedulerCASConfiguration(conf *SchedulerConfiguration, q *W
 	return &out, wm, nil
 }
 
+// SchedulerSimulation is a set of changes to make to a copy of the cluster
+// state before running the scheduler against it.
+type SchedulerSimulation struct {
+	// AddNodes adds copies of existing nodes to the cluster.
+	AddNodes []*SimulatedNodes
+
+	// DrainNodePools drains every node of the node pools.
+	DrainNodePools []string
+
+	// Jobs are registered after the nodes are updated.
+	Jobs []*Job
+}
+
+// SimulatedNodes adds Count copies of an existing node to a simulation.
+type SimulatedNodes struct {
+	// NodeID is the ID or a unique ID prefix of the node to copy.
+	NodeID string
+	Count  int
+}
+
+// SchedulerSimulationResult is the outcome of a scheduler simulation.
+type SchedulerSimulationResult struct {
+	Evaluations []*SimulatedEvaluation
+	NodePools   []*SimulatedNodePool
+}
+
+// SimulatedEvaluation is the outcome of an evaluation processed by a
+// scheduler simulation.
+type SimulatedEvaluation struct {
+	Namespace        string
+	JobID            string
+	TriggeredBy      string
+	Status           string
+	DesiredTGUpdates map[string]*DesiredUpdates
+	FailedTGAllocs   map[string]*AllocationMetric
+	PreemptedAllocs  int
+}
+
+// SimulatedNodePool is the utilization of a node pool before and after a
+// scheduler simulation.
+type SimulatedNodePool struct {
+	Name   string
+	Before *NodePoolUtilization
+	After  *NodePoolUtilization
+}
+
+// NodePoolUtilization is the capacity of the schedulable nodes of a node pool
+// and how much of it is allocated.
+type NodePoolUtilization struct {
+	Nodes         int
+	CPUShares     int64
+	CPUSharesUsed int64
+	MemoryMB      int64
+	MemoryMBUsed  int64
+}
+
+// SchedulerSimulateResponse is the response object that wraps the result of a
+// scheduler simulation.
+type SchedulerSimulateResponse struct {
+	Result *SchedulerSimulationResult
+
+	QueryMeta
+}
+
+// SchedulerSimulate runs the scheduler against a copy of the cluster state
+// with the changes of the simulation applied. The cluster is not modified.
+func (op *Operator) SchedulerSimulate(sim *SchedulerSimulation, q *QueryOptions) (*SchedulerSimulationResult, *QueryMeta, error) {
+	var resp SchedulerSimulateResponse
+	qm, err := op.c.putQuery("/v1/operator/scheduler/simulate", sim, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Result, qm, nil
+}
+
 // Snapshot is used to capture a snapshot state of a running cluster.
 // The returned reader that must be consumed fully
 func (op *Operator) Snapshot(q *QueryOptions) (io.ReadCloser, error) {
@@ -20,6 +20,8 @@ type Resources struct {
 	Devices     []*RequestedDevice `hcl:"device,block"`
 	NUMA        *NUMAResource      `hcl:"numa,block"`
 	SecretsMB   *int               `mapstructure:"secrets" hcl:"secrets,optional"`
+	IOWeight    *int               `mapstructure:"io_weight" hcl:"io_weight,optional"`
+	IOMax       []*IOLimit         `mapstructure:"io_max" hcl:"io_max,block"`
 
 	// COMPAT(0.10)
 	// XXX Deprecated. Please do not use. The field will be removed in Nomad
@@ -108,6 +110,25 @@ func (r *Resources) Merge(other *Resources) {
 	if other.SecretsMB != nil {
 		r.SecretsMB = other.SecretsMB
 	}
+	if other.IOWeight != nil {
+		r.IOWeight = other.IOWeight
+	}
+	if len(other.IOMax) != 0 {
+		r.IOMax = other.IOMax
+	}
+}
+
+// IOLimit limits the block I/O of a task to a block device. Only the limits
+// that are set are enforced.
+type IOLimit struct {
+	// Device is the kernel name of the block device, such as "sda" or
+	// "nvme0n1".
+	Device string `hcl:",label"`
+
+	ReadBps   int64 `mapstructure:"read_bps" hcl:"read_bps,optional"`
+	WriteBps  int64 `mapstructure:"write_bps" hcl:"write_bps,optional"`
+	ReadIOps  int64 `mapstructure:"read_iops" hcl:"read_iops,optional"`
+	WriteIOps int64 `mapstructure:"write_iops" hcl:"write_iops,optional"`
 }
 
 // NUMAResource contains the NUMA affinity request for scheduling purposes.
@@ -172,6 +193,7 @@ type NetworkResource struct {
 	ReservedPorts []Port     `hcl:"reserved_ports,block"`
 	DynamicPorts  []Port     `hcl:"port,block"`
 	Hostname      string     `hcl:"hostname,optional"`
+	EgressMBits   int        `mapstructure:"egress_mbits" hcl:"egress_mbits,optional"`
 
 	// COMPAT(0.13)
 	// XXX Deprecated. Please do not use. The field will be removed in Nomad
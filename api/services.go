@@ -59,6 +59,13 @@ type ServiceRegistration struct {
 	// is determined by a combination of factors on the client.
 	Port int
 
+	// Weight is the share of traffic the registration should receive,
+	// relative to the other registrations of the service, with 100 being an
+	// even share. It is 100 unless the task group of the allocation is being
+	// rolled out in steps, in which case the share of each job version is split
+	// between its registrations.
+	Weight int
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -77,6 +77,27 @@ type AllocResourceUsage struct {
 	Timestamp     int64
 }
 
+// StatsSample summarizes the resource usage of a task or host over a window of
+// time. Recent samples cover short windows, while older samples are
+// downsampled into longer windows.
+type StatsSample struct {
+	// Timestamp is the end of the window, in UnixNano
+	Timestamp int64
+
+	// Window is the duration summarized by the sample
+	Window time.Duration
+
+	// CPUTicks and CPUTicksMax are the mean and maximum CPU usage in MHz over
+	// the window
+	CPUTicks    float64
+	CPUTicksMax float64
+
+	// MemoryBytes and MemoryBytesMax are the mean and maximum memory usage in
+	// bytes over the window
+	MemoryBytes    uint64
+	MemoryBytesMax uint64
+}
+
 // AllocCheckStatus contains the current status of a nomad service discovery check.
 type AllocCheckStatus struct {
 	ID         string
@@ -154,6 +175,18 @@ func (ds *DisconnectStrategy) Canonicalize() {
 	}
 }
 
+// Gang configures all-or-nothing placement for a task group. Task groups of a
+// job that share a gang name are placed together or not at all.
+type Gang struct {
+	Name *string `mapstructure:"name" hcl:"name,optional"`
+}
+
+func (g *Gang) Canonicalize() {
+	if g.Name == nil {
+		g.Name = pointerOf("")
+	}
+}
+
 // Reschedule configures how Tasks are rescheduled  when they crash or fail.
 type ReschedulePolicy struct {
 	// Attempts limits the number of rescheduling attempts that can occur in an interval.
@@ -504,6 +537,7 @@ type TaskGroup struct {
 	Volumes          map[string]*VolumeRequest `hcl:"volume,block"`
 	RestartPolicy    *RestartPolicy            `hcl:"restart,block"`
 	Disconnect       *DisconnectStrategy       `hcl:"disconnect,block"`
+	Gang             *Gang                     `hcl:"gang,block"`
 	ReschedulePolicy *ReschedulePolicy         `hcl:"reschedule,block"`
 	EphemeralDisk    *EphemeralDisk            `hcl:"ephemeral_disk,block"`
 	Update           *UpdateStrategy           `hcl:"update,block"`
@@ -642,6 +676,10 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 	if g.Disconnect != nil {
 		g.Disconnect.Canonicalize()
 	}
+
+	if g.Gang != nil {
+		g.Gang.Canonicalize()
+	}
 }
 
 // These needs to be in sync with DefaultServiceJobRestartPolicy in
@@ -717,13 +755,20 @@ type LogConfig struct {
 	Enabled *bool `mapstructure:"enabled" hcl:"enabled,optional"`
 
 	Disabled *bool `mapstructure:"disabled" hcl:"disabled,optional"`
+
+	Compression      *string        `mapstructure:"compression" hcl:"compression,optional"`
+	RotationInterval *time.Duration `mapstructure:"rotation_interval" hcl:"rotation_interval,optional"`
+	MaxTotalSizeMB   *int           `mapstructure:"max_total_size" hcl:"max_total_size,optional"`
 }
 
 func DefaultLogConfig() *LogConfig {
 	return &LogConfig{
-		MaxFiles:      pointerOf(10),
-		MaxFileSizeMB: pointerOf(10),
-		Disabled:      pointerOf(false),
+		MaxFiles:         pointerOf(10),
+		MaxFileSizeMB:    pointerOf(10),
+		Disabled:         pointerOf(false),
+		Compression:      pointerOf(""),
+		RotationInterval: pointerOf(time.Duration(0)),
+		MaxTotalSizeMB:   pointerOf(0),
 	}
 }
 
@@ -737,6 +782,15 @@ func (l *LogConfig) Canonicalize() {
 	if l.Disabled == nil {
 		l.Disabled = pointerOf(false)
 	}
+	if l.Compression == nil {
+		l.Compression = pointerOf("")
+	}
+	if l.RotationInterval == nil {
+		l.RotationInterval = pointerOf(time.Duration(0))
+	}
+	if l.MaxTotalSizeMB == nil {
+		l.MaxTotalSizeMB = pointerOf(0)
+	}
 }
 
 // DispatchPayloadConfig configures how a task gets its input from a job dispatch
@@ -181,6 +181,37 @@ func (a *Allocations) Stats(args *cstructs.AllocStatsRequest, reply *cstructs.Al
 	return nil
 }
 
+// StatsHistory is used to collect the resource usage history of a given
+// allocation
+func (a *Allocations) StatsHistory(args *cstructs.AllocStatsHistoryRequest, reply *cstructs.AllocStatsHistoryResponse) error {
+	defer metrics.MeasureSince([]string{"client", "allocations", "stats_history"}, time.Now())
+
+	alloc, err := a.c.GetAlloc(args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	// Check read-job permission.
+	if aclObj, err := a.c.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadJob) {
+		return nstructs.ErrPermissionDenied
+	}
+
+	aStats, err := a.c.StatsReporter().GetAllocStats(args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	history, err := aStats.StatsHistory(args.Task)
+	if err != nil {
+		return err
+	}
+
+	reply.Tasks = history
+	return nil
+}
+
 // Checks is used to retrieve nomad service discovery check status information.
 func (a *Allocations) Checks(args *cstructs.AllocChecksRequest, reply *cstructs.AllocChecksResponse) error {
 	defer metrics.MeasureSince([]string{"client", "allocations", "checks"}, time.Now())
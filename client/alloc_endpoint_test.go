@@ -507,6 +507,32 @@ func TestAllocations_Stats(t *testing.T) {
 	})
 }
 
+func TestAllocations_StatsHistory(t *testing.T) {
+	ci.Parallel(t)
+
+	client, cleanup := TestClient(t, nil)
+	defer cleanup()
+
+	a := mock.Alloc()
+	must.NoError(t, client.addAlloc(a, ""))
+
+	// Try with bad alloc
+	req := &cstructs.AllocStatsHistoryRequest{}
+	var resp cstructs.AllocStatsHistoryResponse
+	must.Error(t, client.ClientRPC("Allocations.StatsHistory", &req, &resp))
+
+	// Try with good alloc
+	req.AllocID = a.ID
+	must.NoError(t, client.ClientRPC("Allocations.StatsHistory", &req, &resp))
+	must.MapContainsKey(t, resp.Tasks, "web")
+
+	// Try with a task that doesn't exist
+	req.Task = "missing"
+	var resp2 cstructs.AllocStatsHistoryResponse
+	must.NoError(t, client.ClientRPC("Allocations.StatsHistory", &req, &resp2))
+	must.MapEmpty(t, resp2.Tasks)
+}
+
 func TestAllocations_Stats_ACL(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -76,6 +76,11 @@ var (
 	// directory
 	TaskPrivate = "private"
 
+	// AllocCheckpointDir is the name of the directory inside each alloc
+	// directory that tasks are checkpointed to when they are migrated. It is
+	// not mounted into any task, so tasks can't tamper with their checkpoint.
+	AllocCheckpointDir = "checkpoints"
+
 	// TaskDirs is the set of directories created in each tasks directory.
 	TaskDirs = map[string]os.FileMode{TmpDirName: os.ModeSticky | fileMode777}
 
@@ -188,7 +193,7 @@ func (d *AllocDir) NewTaskDir(task *structs.Task) *TaskDir {
 }
 
 // Snapshot creates an archive of the files and directories in the data dir of
-// the allocation and the task local directories
+// the allocation, the task local directories and the task checkpoints
 //
 // Since a valid tar may have been written even when an error occurs, a special
 // file "NOMAD-${ALLOC_ID}-ERROR.log" will be appended to the tar with the
@@ -202,6 +207,10 @@ func (d *AllocDir) Snapshot(w io.Writer) error {
 	for _, taskdir := range d.TaskDirs {
 		rootPaths = append(rootPaths, taskdir.LocalDir)
 	}
+	checkpointDir := filepath.Join(d.AllocDir, AllocCheckpointDir)
+	if _, err := os.Stat(checkpointDir); err == nil {
+		rootPaths = append(rootPaths, checkpointDir)
+	}
 
 	tw := tar.NewWriter(w)
 	defer tw.Close()
@@ -272,7 +281,8 @@ func (d *AllocDir) Snapshot(w io.Writer) error {
 	return nil
 }
 
-// Move other alloc directory's shared path and local dir to this alloc dir.
+// Move other alloc directory's shared path, local dir and task checkpoints to
+// this alloc dir.
 func (d *AllocDir) Move(other Interface, tasks []*structs.Task) error {
 	d.mu.RLock()
 	if !d.built {
@@ -314,6 +324,16 @@ func (d *AllocDir) Move(other Interface, tasks []*structs.Task) error {
 		}
 	}
 
+	// Move the task checkpoints
+	otherCheckpointDir := filepath.Join(other.AllocDirPath(), AllocCheckpointDir)
+	if fileInfo, err := os.Stat(otherCheckpointDir); fileInfo != nil && err == nil {
+		checkpointDir := filepath.Join(d.AllocDir, AllocCheckpointDir)
+		os.RemoveAll(checkpointDir)
+		if err := os.Rename(otherCheckpointDir, checkpointDir); err != nil {
+			return fmt.Errorf("error moving checkpoint dir: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -181,6 +181,10 @@ func TestAllocDir_Snapshot(t *testing.T) {
 	link1 := "baz"
 	must.NoError(t, os.Symlink("bar", filepath.Join(td1.LocalDir, link1)))
 
+	// Write a task checkpoint
+	must.NoError(t, os.MkdirAll(filepath.Dir(td2.CheckpointPath), 0o700))
+	must.NoError(t, os.WriteFile(td2.CheckpointPath, exp, 0o600))
+
 	var b bytes.Buffer
 	must.NoError(t, d.Snapshot(&b))
 
@@ -202,7 +206,7 @@ func TestAllocDir_Snapshot(t *testing.T) {
 		}
 	}
 
-	must.SliceLen(t, 2, files)
+	must.SliceLen(t, 3, files)
 	must.SliceLen(t, 2, links)
 }
 
@@ -240,6 +244,10 @@ func TestAllocDir_Move(t *testing.T) {
 	file2 := "lol"
 	must.NoError(t, os.WriteFile(filepath.Join(td1.LocalDir, file2), exp2, 0o666))
 
+	// Write a task checkpoint
+	must.NoError(t, os.MkdirAll(filepath.Dir(td1.CheckpointPath), 0o700))
+	must.NoError(t, os.WriteFile(td1.CheckpointPath, exp2, 0o600))
+
 	// Move the d1 allocdir to d2
 	must.NoError(t, d2.Move(d1, []*structs.Task{t1}))
 
@@ -251,6 +259,10 @@ func TestAllocDir_Move(t *testing.T) {
 	fi, err = os.Stat(filepath.Join(d2.TaskDirs[t1.Name].LocalDir, file2))
 	must.NoError(t, err)
 	must.NotNil(t, fi)
+
+	fi, err = os.Stat(d2.TaskDirs[t1.Name].CheckpointPath)
+	must.NoError(t, err)
+	must.NotNil(t, fi)
 }
 
 func TestAllocDir_EscapeChecking(t *testing.T) {
@@ -86,6 +86,12 @@ type TaskDir struct {
 	// <task_dir>/private/
 	PrivateDir string
 
+	// CheckpointPath is the path to the archive the task is checkpointed to
+	// when it is migrated.
+	//
+	// <alloc_dir>/checkpoints/<task>.tar.gz
+	CheckpointPath string
+
 	// skip embedding these paths in chroots. Used for avoiding embedding
 	// client.alloc_dir and client.mounts_dir recursively.
 	skip *set.Set[string]
@@ -115,6 +121,7 @@ func (d *AllocDir) newTaskDir(taskName string, secretsInMB int) *TaskDir {
 		LocalDir:         filepath.Join(taskDir, TaskLocal),
 		SecretsDir:       filepath.Join(taskDir, TaskSecrets),
 		PrivateDir:       filepath.Join(taskDir, TaskPrivate),
+		CheckpointPath:   filepath.Join(d.AllocDir, AllocCheckpointDir, taskName+".tar.gz"),
 		MountsAllocDir:   filepath.Join(d.clientAllocMountsDir, taskUnique, "alloc"),
 		MountsTaskDir:    filepath.Join(d.clientAllocMountsDir, taskUnique),
 		MountsSecretsDir: filepath.Join(d.clientAllocMountsDir, taskUnique, "secrets"),
@@ -347,6 +347,7 @@ func (ar *allocRunner) initTaskRunners(tasks []*structs.Task) error {
 			StartConditionMetCh: ar.taskCoordinator.StartConditionForTask(task),
 			ShutdownDelayCtx:    ar.shutdownDelayCtx,
 			ServiceRegWrapper:   ar.serviceRegWrapper,
+			CheckStore:          ar.checkStore,
 			Getter:              ar.getter,
 			Wranglers:           ar.wranglers,
 			AllocHookResources:  ar.hookResources,
@@ -1280,6 +1281,14 @@ func (ar *allocRunner) IsMigrating() bool {
 	return ar.prevAllocMigrator.IsMigrating()
 }
 
+// markCheckpointsMigrated marks the tasks whose checkpoint was migrated from
+// the previous alloc so that they are restored from it.
+func (ar *allocRunner) markCheckpointsMigrated() {
+	for _, tr := range ar.tasks {
+		tr.MarkCheckpointMigrated()
+	}
+}
+
 func (ar *allocRunner) StatsReporter() interfaces.AllocStatsReporter {
 	return ar
 }
@@ -1314,6 +1323,20 @@ func (ar *allocRunner) LatestAllocStats(taskFilter string) (*cstructs.AllocResou
 	return astat, nil
 }
 
+// StatsHistory returns the resource usage history of the tasks of the
+// allocation. If taskFilter is set, only the history of that task -- if it
+// exists -- is returned.
+func (ar *allocRunner) StatsHistory(taskFilter string) (map[string][]*cstructs.StatsSample, error) {
+	history := make(map[string][]*cstructs.StatsSample, len(ar.tasks))
+	for name, tr := range ar.tasks {
+		if taskFilter != "" && taskFilter != name {
+			continue
+		}
+		history[name] = tr.StatsHistory()
+	}
+	return history, nil
+}
+
 func (ar *allocRunner) GetTaskEventHandler(taskName string) drivermanager.EventHandler {
 	if tr, ok := ar.tasks[taskName]; ok {
 		return func(ev *drivers.TaskEvent) {
@@ -136,7 +136,7 @@ func (ar *allocRunner) initRunnerHooks(config *clientconfig.Config) error {
 			logger:                  hookLogger,
 		}),
 		newUpstreamAllocsHook(hookLogger, ar.prevAllocWatcher),
-		newDiskMigrationHook(hookLogger, ar.prevAllocMigrator, ar.allocDir),
+		newDiskMigrationHook(hookLogger, ar.prevAllocMigrator, ar.allocDir, ar.markCheckpointsMigrated),
 		newCPUPartsHook(hookLogger, ar.partitions, alloc),
 		newAllocHealthWatcherHook(hookLogger, alloc, newEnvBuilder, hs, ar.Listener(), ar.consulServicesHandler, ar.checkStore),
 		newNetworkHook(hookLogger, ns, alloc, nm, nc, ar, builtTaskEnv),
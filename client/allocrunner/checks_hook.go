@@ -162,6 +162,19 @@ func (h *checksHook) observe(alloc *structs.Allocation, services []*structs.Serv
 				continue
 			}
 
+			// script checks must be executed within their task, so they are
+			// observed by the script checks hook of the task once it is
+			// running; only insert the pending result here
+			if check.Type == structs.ServiceCheckScript {
+				if _, exists := h.shim.List(h.allocID)[id]; !exists {
+					result := checks.Stub(id, structs.GetCheckMode(check), now, alloc.Name, service.TaskName, service.Name, check.Name)
+					if err := h.shim.Set(h.allocID, result); err != nil {
+						h.logger.Error("failed to set initial check status", "id", h.allocID, "error", err)
+					}
+				}
+				continue
+			}
+
 			ctx, cancel := context.WithCancel(h.ctx)
 
 			// create the observer for this check
@@ -247,8 +260,10 @@ func (h *checksHook) Update(request *interfaces.RunnerUpdateRequest) error {
 	// stop the observers of the checks we are removing
 	remove := h.shim.Difference(request.Alloc.ID, next)
 	for _, id := range remove {
-		h.observers[id].stop()
-		delete(h.observers, id)
+		if o, exists := h.observers[id]; exists {
+			o.stop()
+			delete(h.observers, id)
+		}
 	}
 
 	// remove checks that are no longer part of the allocation
@@ -318,3 +318,49 @@ func TestCheckHook_Checks_UpdateSet(t *testing.T) {
 	results := shim.List(alloc.ID)
 	must.MapEmpty(t, results)
 }
+
+func TestCheckHook_Checks_ScriptStubbed(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	checkStore := makeCheckStore(logger)
+
+	alloc := mock.Alloc()
+	group := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	group.Tasks[0].Services = []*structs.Service{{
+		Name:     "service-one",
+		TaskName: "web",
+		Provider: "nomad",
+		Checks: []*structs.ServiceCheck{{
+			Name:     "check-script",
+			Type:     "script",
+			Command:  "/bin/true",
+			Interval: 250 * time.Millisecond,
+			Timeout:  1 * time.Second,
+			TaskName: "web",
+		}},
+	}}
+
+	network := mock.NewNetworkStatus("127.0.0.1")
+	envBuilder := taskenv.NewBuilder(mock.Node(), alloc, nil, alloc.Job.Region)
+	h := newChecksHook(logger, alloc, checkStore, network, envBuilder.Build())
+
+	must.NoError(t, h.Prerun())
+
+	// script checks are run by the task, so there is no observer but the
+	// pending result is stored
+	must.MapEmpty(t, h.observers)
+
+	id := structs.NomadCheckID(alloc.ID, alloc.TaskGroup, group.Tasks[0].Services[0].Checks[0])
+	results := checkStore.List(alloc.ID)
+	must.MapLen(t, 1, results)
+	must.Eq(t, structs.CheckPending, results[id].Status)
+
+	// removing the check removes the result without an observer to stop
+	alloc2 := alloc.Copy()
+	alloc2.Job.LookupTaskGroup(alloc2.TaskGroup).Tasks[0].Services = nil
+	must.NoError(t, h.Update(&interfaces.RunnerUpdateRequest{Alloc: alloc2}))
+	must.MapEmpty(t, checkStore.List(alloc.ID))
+
+	h.PreKill()
+}
@@ -25,6 +25,7 @@ type NomadBridgeConfig struct {
 	IPv6Subnet     string
 	HairpinMode    bool
 	ConsulCNI      bool
+	Bandwidth      bool
 }
 
 // NewNomadBridgeConflist produces a full Conflist from the config.
@@ -77,6 +78,14 @@ func NewNomadBridgeConflist(conf NomadBridgeConfig) Conflist {
 			Snat: true,
 		},
 	}
+	if conf.Bandwidth {
+		plugins = append(plugins, Bandwidth{
+			Type: "bandwidth",
+			Capabilities: BandwidthCapabilities{
+				Bandwidth: true,
+			},
+		})
+	}
 	if conf.ConsulCNI {
 		plugins = append(plugins, ConsulCNI{
 			Type:     "consul-cni",
@@ -57,3 +57,14 @@ type ConsulCNI struct {
 	Type     string `json:"type"`
 	LogLevel string `json:"log_level"`
 }
+
+// Bandwidth is the "bandwidth" plugin used to limit the egress traffic of
+// allocations, with the limits passed as runtime config.
+// https://www.cni.dev/plugins/current/meta/bandwidth/
+type Bandwidth struct {
+	Type         string                `json:"type"`
+	Capabilities BandwidthCapabilities `json:"capabilities"`
+}
+type BandwidthCapabilities struct {
+	Bandwidth bool `json:"bandwidth"`
+}
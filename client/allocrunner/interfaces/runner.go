@@ -66,6 +66,10 @@ type TaskStateHandler interface {
 // allocation
 type AllocStatsReporter interface {
 	LatestAllocStats(taskFilter string) (*cstructs.AllocResourceUsage, error)
+
+	// StatsHistory returns the resource usage history of each task. If
+	// taskFilter is set, only the history of that task is returned.
+	StatsHistory(taskFilter string) (map[string][]*cstructs.StatsSample, error)
 }
 
 // HookResourceSetter is used to communicate between alloc hooks and task hooks
@@ -18,16 +18,22 @@ type diskMigrationHook struct {
 	allocDir     allocdir.Interface
 	allocWatcher config.PrevAllocMigrator
 	logger       log.Logger
+
+	// migrated is called once the data of the previous alloc has been
+	// migrated, so that tasks whose checkpoint was migrated are restored
+	migrated func()
 }
 
 func newDiskMigrationHook(
 	logger log.Logger,
 	allocWatcher config.PrevAllocMigrator,
 	allocDir allocdir.Interface,
+	migrated func(),
 ) *diskMigrationHook {
 	h := &diskMigrationHook{
 		allocDir:     allocDir,
 		allocWatcher: allocWatcher,
+		migrated:     migrated,
 	}
 	h.logger = logger.Named(h.Name())
 	return h
@@ -59,7 +65,9 @@ func (h *diskMigrationHook) Prerun() error {
 		if err := h.allocDir.Build(); err != nil {
 			return fmt.Errorf("failed to clean task directories after failed migration: %v", err)
 		}
+		return nil
 	}
 
+	h.migrated()
 	return nil
 }
@@ -36,6 +36,7 @@ type bridgeNetworkConfigurator struct {
 	allocSubnetIPv4 string
 	bridgeName      string
 	hairpinMode     bool
+	egressLimit     bool
 
 	newIPTables func(structs.NodeNetworkAF) (IPTablesChain, error)
 
@@ -60,25 +61,20 @@ func newBridgeNetworkConfigurator(log hclog.Logger, alloc *structs.Allocation, b
 		b.allocSubnetIPv4 = defaultNomadAllocSubnet
 	}
 
-	var netCfg []byte
-	var err error
-
 	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+
+	var withConsulCNI bool
 	for _, svc := range tg.Services {
 		if svc.Connect.HasTransparentProxy() {
-			netCfg, err = buildNomadBridgeNetConfig(*b, true)
-			if err != nil {
-				return nil, err
-			}
-
+			withConsulCNI = true
 			break
 		}
 	}
-	if netCfg == nil {
-		netCfg, err = buildNomadBridgeNetConfig(*b, false)
-		if err != nil {
-			return nil, err
-		}
+	b.egressLimit = getBandwidth(tg.Networks) != nil
+
+	netCfg, err := buildNomadBridgeNetConfig(*b, withConsulCNI)
+	if err != nil {
+		return nil, err
 	}
 
 	parser := &cniConfParser{
@@ -141,6 +137,7 @@ func buildNomadBridgeNetConfig(b bridgeNetworkConfigurator, withConsulCNI bool)
 		IPv6Subnet:     b.allocSubnetIPv6,
 		HairpinMode:    b.hairpinMode,
 		ConsulCNI:      withConsulCNI,
+		Bandwidth:      b.egressLimit,
 	})
 	return conf.Json()
 }
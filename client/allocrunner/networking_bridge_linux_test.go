@@ -64,6 +64,14 @@ func Test_buildNomadBridgeNetConfig(t *testing.T) {
 				hairpinMode:     true,
 			},
 		},
+		{
+			name: "bandwidth",
+			b: &bridgeNetworkConfigurator{
+				bridgeName:      defaultNomadBridgeName,
+				allocSubnetIPv4: defaultNomadAllocSubnet,
+				egressLimit:     true,
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -171,6 +171,14 @@ func (c *cniNetworkConfigurator) Setup(ctx context.Context, alloc *structs.Alloc
 
 	portMaps := getPortMapping(alloc, c.ignorePortMappingHostIP)
 
+	opts := []cni.NamespaceOpts{
+		c.nsOpts.withCapabilityPortMap(portMaps.ports),
+		c.nsOpts.withArgs(cniArgs),
+	}
+	if bandwidth := getBandwidth(tg.Networks); bandwidth != nil {
+		opts = append(opts, c.nsOpts.withCapabilityBandwidth(*bandwidth))
+	}
+
 	tproxyArgs, err := c.setupTransparentProxyArgs(alloc, spec, portMaps)
 	if err != nil {
 		return nil, err
@@ -193,10 +201,7 @@ func (c *cniNetworkConfigurator) Setup(ctx context.Context, alloc *structs.Alloc
 		// case of a host reboot with docker-created netns there.
 		cniVersion, err := version.NewSemver(c.nodeAttrs["plugins.cni.version.bridge"])
 		if err == nil && supportsCNICheck.Check(cniVersion) {
-			err := c.cni.Check(ctx, alloc.ID, spec.Path,
-				c.nsOpts.withCapabilityPortMap(portMaps.ports),
-				c.nsOpts.withArgs(cniArgs),
-			)
+			err := c.cni.Check(ctx, alloc.ID, spec.Path, opts...)
 			if err != nil {
 				return nil, fmt.Errorf("%w: %w", ErrCNICheckFailed, err)
 			}
@@ -216,10 +221,7 @@ func (c *cniNetworkConfigurator) Setup(ctx context.Context, alloc *structs.Alloc
 	var res *cni.Result
 	for attempt := 1; ; attempt++ {
 		var err error
-		if res, err = c.cni.Setup(ctx, alloc.ID, spec.Path,
-			c.nsOpts.withCapabilityPortMap(portMaps.ports),
-			c.nsOpts.withArgs(cniArgs),
-		); err != nil {
+		if res, err = c.cni.Setup(ctx, alloc.ID, spec.Path, opts...); err != nil {
 			c.logger.Warn("failed to configure network", "error", err, "attempt", attempt)
 			switch attempt {
 			case 1:
@@ -704,8 +706,9 @@ func (c *cniNetworkConfigurator) ensureCNIInitialized() error {
 
 // nsOpts keeps track of NamespaceOpts usage, mainly for test assertions.
 type nsOpts struct {
-	args  map[string]string
-	ports []cni.PortMapping
+	args      map[string]string
+	ports     []cni.PortMapping
+	bandwidth *cni.BandWidth
 }
 
 func (o *nsOpts) withArgs(args map[string]string) cni.NamespaceOpts {
@@ -718,6 +721,27 @@ func (o *nsOpts) withCapabilityPortMap(ports []cni.PortMapping) cni.NamespaceOpt
 	return cni.WithCapabilityPortMap(ports)
 }
 
+func (o *nsOpts) withCapabilityBandwidth(bandwidth cni.BandWidth) cni.NamespaceOpts {
+	o.bandwidth = &bandwidth
+	return cni.WithCapabilityBandWidth(bandwidth)
+}
+
+// getBandwidth returns the egress limit of the bridge network of the group to
+// pass to the bandwidth plugin, or nil if it isn't limited. The plugin expects
+// rates and bursts in bits, and the burst allows one second of traffic.
+func getBandwidth(networks structs.Networks) *cni.BandWidth {
+	for _, n := range networks {
+		if n.Mode == "bridge" && n.EgressMBits > 0 {
+			rate := uint64(n.EgressMBits) * 1_000_000
+			return &cni.BandWidth{
+				EgressRate:  rate,
+				EgressBurst: rate,
+			}
+		}
+	}
+	return nil
+}
+
 // portMappings is a wrapper around a slice of cni.PortMapping that lets us
 // index via the port's label, which isn't otherwise included in the
 // cni.PortMapping struct
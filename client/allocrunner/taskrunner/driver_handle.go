@@ -64,6 +64,26 @@ func (h *DriverHandle) Signal(s string) error {
 	return h.driver.SignalTask(h.taskID, s)
 }
 
+// Checkpoint writes the state of the task to an archive at path and stops the
+// task. The driver must support checkpointing tasks.
+func (h *DriverHandle) Checkpoint(path string) error {
+	d, ok := h.driver.(drivers.CheckpointableDriver)
+	if !ok {
+		return fmt.Errorf("task driver does not support checkpoint")
+	}
+	return d.CheckpointTask(h.taskID, path)
+}
+
+// UpdateResources applies new cpu and memory limits to the running task. The
+// driver must support resizing tasks.
+func (h *DriverHandle) UpdateResources(resources *drivers.Resources) error {
+	d, ok := h.driver.(drivers.ResizableDriver)
+	if !ok {
+		return fmt.Errorf("task driver does not support resizing tasks")
+	}
+	return d.UpdateTaskResources(h.taskID, resources)
+}
+
 // Exec is the handled used by client endpoint handler to invoke the appropriate task driver exec.
 func (h *DriverHandle) Exec(timeout time.Duration, cmd string, args []string) ([]byte, int, error) {
 	if h == nil {
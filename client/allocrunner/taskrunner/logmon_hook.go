@@ -16,6 +16,8 @@ import (
 	plugin "github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
 	"github.com/hashicorp/nomad/client/logmon"
+	"github.com/hashicorp/nomad/client/logmon/sink"
+	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
 	bstructs "github.com/hashicorp/nomad/plugins/base/structs"
@@ -48,6 +50,9 @@ type logmonHookConfig struct {
 	disabled   bool
 	stdoutFifo string
 	stderrFifo string
+
+	// sinks are the client's log sinks the task's log lines are shipped to
+	sinks []*sink.Config
 }
 
 func newLogMonHook(tr *TaskRunner, logger hclog.Logger) *logmonHook {
@@ -200,6 +205,13 @@ func (h *logmonHook) prestartOneLoop(ctx context.Context, req *interfaces.TaskPr
 		StderrFifo:    h.config.stderrFifo,
 		MaxFiles:      req.Task.LogConfig.MaxFiles,
 		MaxFileSizeMB: req.Task.LogConfig.MaxFileSizeMB,
+
+		Compression:      req.Task.LogConfig.Compression,
+		RotationInterval: req.Task.LogConfig.RotationInterval,
+		MaxTotalSizeMB:   req.Task.LogConfig.MaxTotalSizeMB,
+
+		Sinks:        h.config.sinks,
+		SinkMetadata: logSinkMetadata(req.TaskEnv),
 	})
 	if err != nil {
 		h.logger.Error("failed to start logmon", "error", err)
@@ -209,6 +221,23 @@ func (h *logmonHook) prestartOneLoop(ctx context.Context, req *interfaces.TaskPr
 	return nil
 }
 
+// logSinkMetadata returns the metadata that tags the task's log lines shipped
+// to the log sinks.
+func logSinkMetadata(env *taskenv.TaskEnv) map[string]string {
+	if env == nil {
+		return nil
+	}
+
+	envMap := env.EnvMap
+	return map[string]string{
+		sink.MetaNamespace: envMap[taskenv.Namespace],
+		sink.MetaJob:       envMap[taskenv.JobID],
+		sink.MetaGroup:     envMap[taskenv.GroupName],
+		sink.MetaTask:      envMap[taskenv.TaskName],
+		sink.MetaAllocID:   envMap[taskenv.AllocID],
+	}
+}
+
 func (h *logmonHook) Stop(_ context.Context, req *interfaces.TaskStopRequest, _ *interfaces.TaskStopResponse) error {
 	if h.isLoggingDisabled() {
 		return nil
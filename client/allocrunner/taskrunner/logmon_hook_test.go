@@ -13,6 +13,8 @@ import (
 	plugin "github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/logmon/sink"
+	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
 	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
@@ -153,3 +155,23 @@ func TestTaskRunner_LogmonHook_Disabled(t *testing.T) {
 	}
 	must.NoError(t, hook.Stop(context.Background(), &stopReq, nil))
 }
+
+// TestTaskRunner_LogmonHook_SinkMetadata asserts the log lines shipped to the
+// log sinks are tagged with the task's metadata.
+func TestTaskRunner_LogmonHook_SinkMetadata(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	env := taskenv.NewBuilder(mock.Node(), alloc, task, "global").Build()
+
+	must.Eq(t, map[string]string{
+		sink.MetaNamespace: alloc.Namespace,
+		sink.MetaJob:       alloc.JobID,
+		sink.MetaGroup:     alloc.TaskGroup,
+		sink.MetaTask:      task.Name,
+		sink.MetaAllocID:   alloc.ID,
+	}, logSinkMetadata(env))
+
+	must.Nil(t, logSinkMetadata(nil))
+}
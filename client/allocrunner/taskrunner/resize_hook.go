@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const resizeHookName = "resize"
+
+// resizeHook applies the new cpu and memory of in-place updated allocations to
+// the running task. If the driver fails to resize the task, it keeps running
+// with its previous limits until it is restarted.
+type resizeHook struct {
+	tr     *TaskRunner
+	logger log.Logger
+}
+
+func newResizeHook(tr *TaskRunner, logger log.Logger) *resizeHook {
+	h := &resizeHook{
+		tr: tr,
+	}
+	h.logger = logger.Named(h.Name())
+	return h
+}
+
+func (*resizeHook) Name() string {
+	return resizeHookName
+}
+
+func (h *resizeHook) Update(ctx context.Context, req *interfaces.TaskUpdateRequest, _ *interfaces.TaskUpdateResponse) error {
+	task := req.Alloc.LookupTask(h.tr.taskName)
+	if task == nil || req.Alloc.AllocatedResources == nil {
+		return nil
+	}
+	tres, ok := req.Alloc.AllocatedResources.Tasks[h.tr.taskName]
+	if !ok {
+		return nil
+	}
+
+	// The task runner only accounts the memory of the task without its
+	// secrets directory
+	memoryMB := tres.Memory.MemoryMB - int64(task.Resources.SecretsMB)
+
+	prev := h.tr.getTaskResources()
+	if prev.Cpu.CpuShares == tres.Cpu.CpuShares &&
+		prev.Memory.MemoryMB == memoryMB &&
+		prev.Memory.MemoryMaxMB == tres.Memory.MemoryMaxMB {
+		return nil
+	}
+
+	resized := prev.Copy()
+	resized.Cpu.CpuShares = tres.Cpu.CpuShares
+	resized.Memory.MemoryMB = memoryMB
+	resized.Memory.MemoryMaxMB = tres.Memory.MemoryMaxMB
+	h.tr.setTaskResources(resized)
+
+	// Tasks that aren't running use the new resources when started
+	handle := h.tr.getDriverHandle()
+	if handle == nil {
+		return nil
+	}
+
+	// The scheduler only resizes allocations in-place on nodes where the
+	// driver supports it, so the task is never restarted to apply the new
+	// resources: that would bypass the update strategy of the job.
+	if h.tr.driverCapabilities == nil || !h.tr.driverCapabilities.Resize {
+		h.logger.Warn("task driver does not support resizing tasks, new resources apply on the next restart")
+		return nil
+	}
+
+	if err := handle.UpdateResources(h.tr.buildTaskResources(resized)); err != nil {
+		h.logger.Warn("failed to resize task, new resources apply on the next restart", "error", err)
+		h.tr.EmitEvent(structs.NewTaskEvent(structs.TaskResizeFailed).
+			SetDisplayMessage(fmt.Sprintf("Failed to resize task: %v", err)))
+		return nil
+	}
+
+	h.logger.Debug("resized task", "cpu", resized.Cpu.CpuShares, "memory", resized.Memory.MemoryMB)
+	h.tr.EmitEvent(structs.NewTaskEvent(structs.TaskResized).
+		SetDisplayMessage(fmt.Sprintf("Task resized to %d MHz cpu and %d MB memory",
+			resized.Cpu.CpuShares, resized.Memory.MemoryMB)))
+	return nil
+}
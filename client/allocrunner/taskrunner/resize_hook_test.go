@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+// Statically assert the resize hook implements the expected interfaces
+var _ interfaces.TaskUpdateHook = (*resizeHook)(nil)
+
+// hasTaskEvent returns whether the task received an event of the given type
+func hasTaskEvent(tr *TaskRunner, eventType string) bool {
+	for _, e := range tr.TaskState().Events {
+		if e.Type == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTaskRunner_ResizeHook(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name        string
+		resizeErr   string
+		expectEvent string
+	}{
+		{
+			name:        "resized",
+			expectEvent: structs.TaskResized,
+		},
+		{
+			name:        "resize fails",
+			resizeErr:   "cannot resize",
+			expectEvent: structs.TaskResizeFailed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			alloc := mock.BatchAlloc()
+			task := alloc.Job.TaskGroups[0].Tasks[0]
+			task.Driver = "mock_driver"
+			task.Config = map[string]interface{}{
+				"run_for":      "10s",
+				"resize_error": tc.resizeErr,
+			}
+
+			tr, _, cleanup := runTestTaskRunner(t, alloc, task.Name)
+			defer cleanup()
+			testWaitForTaskToStart(t, tr)
+
+			update := alloc.Copy()
+			tres := update.AllocatedResources.Tasks[task.Name]
+			tres.Cpu.CpuShares += 100
+			tres.Memory.MemoryMB += 64
+			tr.Update(update)
+
+			must.Wait(t, wait.InitialSuccess(
+				wait.BoolFunc(func() bool {
+					return hasTaskEvent(tr, tc.expectEvent)
+				}),
+				wait.Timeout(5*time.Second),
+				wait.Gap(10*time.Millisecond),
+			))
+
+			resources := tr.getTaskResources()
+			must.Eq(t, tres.Cpu.CpuShares, resources.Cpu.CpuShares)
+			must.Eq(t, tres.Memory.MemoryMB, resources.Memory.MemoryMB)
+
+			// The task is never restarted to apply the new resources
+			must.Zero(t, tr.TaskState().Restarts)
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
 	tinterfaces "github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
 	"github.com/hashicorp/nomad/client/serviceregistration"
+	"github.com/hashicorp/nomad/client/serviceregistration/checks/checkstore"
 	"github.com/hashicorp/nomad/client/taskenv"
 	agentconsul "github.com/hashicorp/nomad/command/agent/consul"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -26,10 +27,16 @@ var _ interfaces.TaskStopHook = &scriptCheckHook{}
 // default max amount of time to wait for all scripts on shutdown.
 const defaultShutdownWait = time.Minute
 
+// nomadScriptCheckOutputLimit is the maximum number of bytes of script check
+// output stored for checks of services using the Nomad provider, matching the
+// limit applied to the output of Nomad http checks.
+const nomadScriptCheckOutputLimit = 3 * 1024
+
 type scriptCheckHookConfig struct {
 	alloc        *structs.Allocation
 	task         *structs.Task
 	consul       serviceregistration.Handler
+	checkStore   checkstore.Shim
 	logger       log.Logger
 	shutdownWait time.Duration
 }
@@ -39,6 +46,10 @@ type scriptCheckHookConfig struct {
 type scriptCheckHook struct {
 	consul serviceregistration.Handler
 
+	// checkStore receives the results of script checks of services using the
+	// Nomad provider, which are not reported to Consul
+	checkStore checkstore.Shim
+
 	// a script check hook can create checks for both group-level and task-level
 	// services, so we track both possible namespaces we require
 	groupConsulNamespace string
@@ -69,6 +80,7 @@ type scriptCheckHook struct {
 func newScriptCheckHook(c scriptCheckHookConfig) *scriptCheckHook {
 	h := &scriptCheckHook{
 		consul:               c.consul,
+		checkStore:           c.checkStore,
 		groupConsulNamespace: c.alloc.ConsulNamespace(),
 		taskConsulNamespace:  c.alloc.ConsulNamespaceForTask(c.task.Name),
 		alloc:                c.alloc,
@@ -193,6 +205,12 @@ func (h *scriptCheckHook) newScriptChecks() map[string]*scriptCheck {
 			if check.Type != structs.ServiceCheckScript {
 				continue
 			}
+			if service.Provider == structs.ServiceProviderNomad {
+				if sc := h.newNomadScriptCheck(service, check); sc != nil {
+					scriptChecks[sc.id] = sc
+				}
+				continue
+			}
 			serviceID := serviceregistration.MakeAllocServiceID(
 				h.alloc.ID, h.task.Name, service)
 			sc := newScriptCheck(&scriptCheckConfig{
@@ -232,6 +250,12 @@ func (h *scriptCheckHook) newScriptChecks() map[string]*scriptCheck {
 			if !h.associated(h.task.Name, service.TaskName, check.TaskName) {
 				continue
 			}
+			if service.Provider == structs.ServiceProviderNomad {
+				if sc := h.newNomadScriptCheck(service, check); sc != nil {
+					scriptChecks[sc.id] = sc
+				}
+				continue
+			}
 			groupTaskName := "group-" + tg.Name
 			serviceID := serviceregistration.MakeAllocServiceID(
 				h.alloc.ID, groupTaskName, service)
@@ -256,6 +280,40 @@ func (h *scriptCheckHook) newScriptChecks() map[string]*scriptCheck {
 	return scriptChecks
 }
 
+// newNomadScriptCheck returns a scriptCheck for a check of a service using the
+// Nomad provider. Its results are written to the client check store under the
+// same ID used by the allocation checks hook, rather than heartbeating a TTL
+// check in Consul.
+func (h *scriptCheckHook) newNomadScriptCheck(service *structs.Service, check *structs.ServiceCheck) *scriptCheck {
+	if h.checkStore == nil {
+		return nil
+	}
+
+	sc := newScriptCheck(&scriptCheckConfig{
+		allocID:    h.alloc.ID,
+		taskName:   h.task.Name,
+		check:      check,
+		driverExec: h.driverExec,
+		taskEnv:    h.taskEnv,
+		logger:     h.logger,
+		shutdownCh: h.shutdownCh,
+	})
+	if sc == nil {
+		return nil
+	}
+
+	sc.id = string(structs.NomadCheckID(h.alloc.ID, h.alloc.TaskGroup, check))
+	sc.callback = newNomadScriptCheckCallback(h.checkStore, &structs.CheckQueryResult{
+		ID:      structs.CheckID(sc.id),
+		Mode:    structs.GetCheckMode(check),
+		Group:   h.alloc.Name,
+		Task:    service.TaskName,
+		Service: service.Name,
+		Check:   check.Name,
+	}, h.alloc.ID)
+	return sc
+}
+
 // associated returns true if the script check is associated with the task. This
 // would be the case if the check.task is the same as task, or if the service.task
 // is the same as the task _and_ check.task is not configured (i.e. the check
@@ -402,6 +460,46 @@ func newScriptCheckCallback(s *scriptCheck) taskletCallback {
 	}
 }
 
+// newNomadScriptCheckCallback returns the taskletCallback which stores the
+// result of each execution in the check store. Nomad checks have no warning
+// state, so any non-zero exit code is a failure.
+func newNomadScriptCheckCallback(store checkstore.Shim, template *structs.CheckQueryResult, allocID string) taskletCallback {
+	return func(ctx context.Context, params execResult) {
+		result := *template
+		result.Timestamp = time.Now().UTC().Unix()
+		result.StatusCode = params.code
+
+		switch {
+		case params.err != nil:
+			result.Status = structs.CheckFailure
+			result.Output = fmt.Sprintf("nomad: %s", params.err.Error())
+		case params.code == 0:
+			result.Status = structs.CheckSuccess
+			result.Output = string(truncateOutput(params.output))
+		default:
+			result.Status = structs.CheckFailure
+			result.Output = string(truncateOutput(params.output))
+		}
+
+		select {
+		case <-ctx.Done():
+			// check has been removed; don't store its result
+			return
+		default:
+		}
+
+		// put the result into the store (already logged)
+		_ = store.Set(allocID, &result)
+	}
+}
+
+func truncateOutput(output []byte) []byte {
+	if len(output) > nomadScriptCheckOutputLimit {
+		return output[:nomadScriptCheckOutputLimit]
+	}
+	return output
+}
+
 const (
 	updateTTLBackoffBaseline = 1 * time.Second
 	updateTTLBackoffLimit    = 3 * time.Second
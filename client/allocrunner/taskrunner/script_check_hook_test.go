@@ -15,8 +15,10 @@ import (
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
 	"github.com/hashicorp/nomad/client/serviceregistration"
+	"github.com/hashicorp/nomad/client/serviceregistration/checks/checkstore"
 	regMock "github.com/hashicorp/nomad/client/serviceregistration/mock"
 	"github.com/hashicorp/nomad/client/serviceregistration/wrapper"
+	"github.com/hashicorp/nomad/client/state"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/client/taskenv"
 	agentconsul "github.com/hashicorp/nomad/command/agent/consul"
@@ -24,6 +26,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
 	"github.com/stretchr/testify/require"
 )
 
@@ -306,6 +309,78 @@ func TestScript_TaskEnvInterpolation(t *testing.T) {
 	must.Eq(t, "my-job-backend-check", check.check.Name)
 }
 
+// TestScript_NomadProvider asserts script checks of services using the Nomad
+// provider store their results in the check store instead of updating Consul
+func TestScript_NomadProvider(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	consulClient := regMock.NewServiceRegistrationHandler(logger)
+	store := checkstore.NewStore(logger, state.NewMemDB(logger))
+	exec := newScriptedExec([]execResult{
+		{[]byte("not yet"), 1, nil},
+		{[]byte("ok"), 0, nil},
+	})
+
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Services = []*structs.Service{{
+		Name:     "web",
+		Provider: structs.ServiceProviderNomad,
+		Checks: []*structs.ServiceCheck{{
+			Name:     "script-check",
+			Type:     structs.ServiceCheckScript,
+			Command:  "/bin/true",
+			Interval: 50 * time.Millisecond,
+			Timeout:  time.Second,
+		}},
+	}}
+	alloc.Job.Canonicalize()
+
+	scHook := newScriptCheckHook(scriptCheckHookConfig{
+		alloc:        alloc,
+		task:         task,
+		consul:       consulClient,
+		checkStore:   store,
+		logger:       logger,
+		shutdownWait: time.Hour,
+	})
+	scHook.taskEnv = taskenv.NewBuilder(mock.Node(), alloc, task, "global").Build()
+	scHook.driverExec = exec
+
+	id := structs.NomadCheckID(alloc.ID, alloc.TaskGroup, task.Services[0].Checks[0])
+
+	scripts := scHook.newScriptChecks()
+	must.MapLen(t, 1, scripts)
+	must.MapContainsKey(t, scripts, string(id))
+
+	must.NoError(t, scHook.upsertChecks())
+	t.Cleanup(func() {
+		for _, handle := range scHook.runningScripts {
+			handle.cancel()
+		}
+	})
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			result, ok := store.List(alloc.ID)[id]
+			return ok && result.Status == structs.CheckSuccess
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	result := store.List(alloc.ID)[id]
+	must.Eq(t, "ok", result.Output)
+	must.Eq(t, "web", result.Service)
+	must.Eq(t, "script-check", result.Check)
+	must.Eq(t, task.Name, result.Task)
+	must.Eq(t, structs.Healthiness, result.Mode)
+
+	// nothing is reported to consul
+	must.SliceEmpty(t, consulClient.GetOps())
+}
+
 func TestScript_associated(t *testing.T) {
 	ci.Parallel(t)
 
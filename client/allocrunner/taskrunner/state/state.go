@@ -25,6 +25,12 @@ type LocalState struct {
 	// It is used to distinguish between a dead task that could be restarted
 	// and one that will never run again.
 	RunComplete bool
+
+	// CheckpointMigrated is set to true when a checkpoint of the task was
+	// migrated from the previous allocation. The task is only restored from
+	// its checkpoint when it is set, and it is cleared once the restore has
+	// been attempted.
+	CheckpointMigrated bool
 }
 
 func NewLocalState() *LocalState {
@@ -58,10 +64,11 @@ func (s *LocalState) Copy() *LocalState {
 
 	// Create a copy
 	c := &LocalState{
-		Hooks:         make(map[string]*HookState, len(s.Hooks)),
-		DriverNetwork: s.DriverNetwork.Copy(),
-		TaskHandle:    s.TaskHandle.Copy(),
-		RunComplete:   s.RunComplete,
+		Hooks:              make(map[string]*HookState, len(s.Hooks)),
+		DriverNetwork:      s.DriverNetwork.Copy(),
+		TaskHandle:         s.TaskHandle.Copy(),
+		RunComplete:        s.RunComplete,
+		CheckpointMigrated: s.CheckpointMigrated,
 	}
 
 	// Copy the hook state
@@ -7,6 +7,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 	"sync"
@@ -27,9 +29,11 @@ import (
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
 	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	"github.com/hashicorp/nomad/client/lib/statshistory"
 	"github.com/hashicorp/nomad/client/pluginmanager/csimanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
 	"github.com/hashicorp/nomad/client/serviceregistration"
+	"github.com/hashicorp/nomad/client/serviceregistration/checks/checkstore"
 	"github.com/hashicorp/nomad/client/serviceregistration/wrapper"
 	cstate "github.com/hashicorp/nomad/client/state"
 	cstructs "github.com/hashicorp/nomad/client/structs"
@@ -76,12 +80,16 @@ const (
 )
 
 type TaskRunner struct {
-	// allocID, taskName, taskLeader, and taskResources are immutable so these fields may
+	// allocID, taskName, and taskLeader are immutable so these fields may
 	// be accessed without locks
-	allocID       string
-	taskName      string
-	taskLeader    bool
-	taskResources *structs.AllocatedTaskResources
+	allocID    string
+	taskName   string
+	taskLeader bool
+
+	// taskResources are the resources of the task. They are replaced when the
+	// cpu or memory of the running task is resized. Use getTaskResources.
+	taskResources     *structs.AllocatedTaskResources
+	taskResourcesLock sync.Mutex
 
 	alloc     *structs.Allocation
 	allocLock sync.Mutex
@@ -227,6 +235,10 @@ type TaskRunner struct {
 	resourceUsage     *cstructs.TaskResourceUsage
 	resourceUsageLock sync.Mutex
 
+	// statsHistory keeps a downsampled history of the resource usage passed
+	// to UpdateStats
+	statsHistory *statshistory.History
+
 	// deviceStatsReporter is used to lookup resource usage for alloc devices
 	deviceStatsReporter cinterfaces.DeviceStatsReporter
 
@@ -273,6 +285,10 @@ type TaskRunner struct {
 	// to perform service and check registration and deregistration.
 	serviceRegWrapper *wrapper.HandlerWrapper
 
+	// checkStore is used to store the results of Nomad service script checks
+	// executed within the task.
+	checkStore checkstore.Shim
+
 	// getter is an interface for retrieving artifacts.
 	getter cinterfaces.ArtifactGetter
 
@@ -356,6 +372,9 @@ type Config struct {
 	// to perform service and check registration and deregistration.
 	ServiceRegWrapper *wrapper.HandlerWrapper
 
+	// CheckStore is used to store the results of Nomad service checks.
+	CheckStore checkstore.Shim
+
 	// Getter is an interface for retrieving artifacts.
 	Getter cinterfaces.ArtifactGetter
 
@@ -414,6 +433,7 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 		stateDB:                 config.StateDB,
 		stateUpdater:            config.StateUpdater,
 		deviceStatsReporter:     config.DeviceStatsReporter,
+		statsHistory:            statshistory.New(statshistory.DefaultTiers),
 		killCtx:                 killCtx,
 		killCtxCancel:           killCancel,
 		shutdownCtx:             trCtx,
@@ -430,6 +450,7 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 		shutdownDelayCtx:        config.ShutdownDelayCtx,
 		shutdownDelayCancelFn:   config.ShutdownDelayCancelFn,
 		serviceRegWrapper:       config.ServiceRegWrapper,
+		checkStore:              config.CheckStore,
 		getter:                  config.Getter,
 		wranglers:               config.Wranglers,
 		widmgr:                  config.WIDMgr,
@@ -914,7 +935,7 @@ func (tr *TaskRunner) shouldRestart() (bool, time.Duration) {
 }
 
 func (tr *TaskRunner) assignCgroup(taskConfig *drivers.TaskConfig) {
-	reserveCores := len(tr.taskResources.Cpu.ReservedCores) > 0
+	reserveCores := len(tr.getTaskResources().Cpu.ReservedCores) > 0
 	p := cgroupslib.LinuxResourcesPath(taskConfig.AllocID, taskConfig.Name, reserveCores)
 	taskConfig.Resources.LinuxResources.CpusetCgroupPath = p
 }
@@ -972,8 +993,13 @@ func (tr *TaskRunner) runDriver() error {
 		return nil
 	}
 
-	// Start the job if there's no existing handle (or if RecoverTask failed)
-	handle, net, err := tr.driver.StartTask(taskConfig)
+	// Restore the task if it was checkpointed when its previous allocation
+	// migrated, otherwise start the job if there's no existing handle (or if
+	// RecoverTask failed)
+	handle, net, restored := tr.restoreCheckpoint(taskConfig)
+	if !restored {
+		handle, net, err = tr.driver.StartTask(taskConfig)
+	}
 	if err != nil {
 		// The plugin has died, try relaunching it
 		if err == bstructs.ErrPluginShutdown {
@@ -1092,6 +1118,13 @@ func (tr *TaskRunner) handleKill(resultCh <-chan *drivers.ExitResult) *drivers.E
 		return nil
 	}
 
+	// Checkpoint the task if it is migrating so it can be restored by the
+	// replacement allocation. The checkpoint stops the task, but it is still
+	// killed below to clean up after it or in case the checkpoint failed.
+	if tr.shouldCheckpoint() {
+		tr.checkpointTask(handle)
+	}
+
 	// Kill the task using an exponential backoff in-case of failures.
 	result, killErr := tr.killTask(handle, resultCh)
 	if killErr != nil {
@@ -1129,6 +1162,99 @@ func (tr *TaskRunner) handleKill(resultCh <-chan *drivers.ExitResult) *drivers.E
 	}
 }
 
+// shouldCheckpoint returns true if the task should be checkpointed before it
+// is killed, which is when the allocation is migrating with its ephemeral disk
+// and the driver supports checkpointing tasks.
+func (tr *TaskRunner) shouldCheckpoint() bool {
+	if tr.driverCapabilities == nil || !tr.driverCapabilities.Checkpoint {
+		return false
+	}
+	if _, ok := tr.driver.(drivers.CheckpointableDriver); !ok {
+		return false
+	}
+
+	alloc := tr.Alloc()
+	if !alloc.DesiredTransition.ShouldMigrate() {
+		return false
+	}
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	return tg != nil && tg.EphemeralDisk != nil && tg.EphemeralDisk.Migrate
+}
+
+// checkpointTask checkpoints the task to the archive in the checkpoint dir of
+// the allocation, which is migrated along with the ephemeral disk.
+func (tr *TaskRunner) checkpointTask(handle *DriverHandle) {
+	path := tr.taskDir.CheckpointPath
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		tr.logger.Warn("failed to create checkpoint dir", "error", err)
+		return
+	}
+	if err := handle.Checkpoint(path); err != nil {
+		tr.logger.Warn("failed to checkpoint task", "error", err)
+		os.Remove(path)
+		return
+	}
+
+	tr.logger.Debug("checkpointed task", "path", path)
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskCheckpointed).
+		SetDisplayMessage("Task checkpointed to be restored by the replacement allocation"))
+}
+
+// MarkCheckpointMigrated is called once the data of the previous allocation
+// has been migrated. If a checkpoint of the task was migrated along with it,
+// the task is restored from the checkpoint the next time it starts.
+func (tr *TaskRunner) MarkCheckpointMigrated() {
+	if _, err := os.Stat(tr.taskDir.CheckpointPath); err != nil {
+		return
+	}
+
+	tr.stateLock.Lock()
+	tr.localState.CheckpointMigrated = true
+	tr.stateLock.Unlock()
+
+	if err := tr.persistLocalState(); err != nil {
+		tr.logger.Warn("error persisting migrated checkpoint", "error", err)
+	}
+}
+
+// restoreCheckpoint restores the task from the archive written when the task
+// of the previous allocation was checkpointed, if one was migrated. It returns
+// false if the task should be started instead. The archive is removed once
+// the restore has been attempted so that later restarts start the task from
+// scratch.
+func (tr *TaskRunner) restoreCheckpoint(taskConfig *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, bool) {
+	tr.stateLock.Lock()
+	migrated := tr.localState.CheckpointMigrated
+	tr.localState.CheckpointMigrated = false
+	tr.stateLock.Unlock()
+
+	if !migrated {
+		return nil, nil, false
+	}
+
+	path := tr.taskDir.CheckpointPath
+	defer os.Remove(path)
+	if err := tr.persistLocalState(); err != nil {
+		tr.logger.Warn("error persisting migrated checkpoint", "error", err)
+	}
+
+	d, ok := tr.driver.(drivers.CheckpointableDriver)
+	if !ok || tr.driverCapabilities == nil || !tr.driverCapabilities.Checkpoint {
+		tr.logger.Warn("ignoring checkpoint as the driver does not support checkpoint", "path", path)
+		return nil, nil, false
+	}
+
+	handle, net, err := d.RestoreTask(taskConfig, path)
+	if err != nil {
+		tr.logger.Warn("failed to restore task from checkpoint, starting it instead", "error", err)
+		return nil, nil, false
+	}
+
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskRestoredFromCheckpoint).
+		SetDisplayMessage("Task restored from the checkpoint of the previous allocation"))
+	return handle, net, true
+}
+
 // killTask kills the task handle. In the case that killing fails,
 // killTask will retry with an exponential backoff and will give up at a
 // given limit. Returns an error if the task could not be killed.
@@ -1176,8 +1302,6 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 	task := tr.Task()
 	alloc := tr.Alloc()
 	invocationid := uuid.Short()
-	taskResources := tr.taskResources
-	ports := tr.Alloc().AllocatedResources.Shared.Ports
 	env := tr.envBuilder.Build()
 	tr.networkIsolationLock.Lock()
 	defer tr.networkIsolationLock.Unlock()
@@ -1207,36 +1331,17 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 		}
 	}
 
-	memoryLimit := taskResources.Memory.MemoryMB
-	if max := taskResources.Memory.MemoryMaxMB; max > memoryLimit {
-		memoryLimit = max
-	}
-
-	cpusetCpus := make([]string, len(taskResources.Cpu.ReservedCores))
-	for i, v := range taskResources.Cpu.ReservedCores {
-		cpusetCpus[i] = fmt.Sprintf("%d", v)
-	}
-
 	return &drivers.TaskConfig{
-		ID:            fmt.Sprintf("%s/%s/%s", alloc.ID, task.Name, invocationid),
-		Name:          task.Name,
-		JobName:       alloc.Job.Name,
-		JobID:         alloc.Job.ID,
-		TaskGroupName: alloc.TaskGroup,
-		Namespace:     alloc.Namespace,
-		NodeName:      alloc.NodeName,
-		NodeID:        alloc.NodeID,
-		ParentJobID:   alloc.Job.ParentID,
-		Resources: &drivers.Resources{
-			NomadResources: taskResources,
-			LinuxResources: &drivers.LinuxResources{
-				MemoryLimitBytes: memoryLimit * 1024 * 1024,
-				CPUShares:        taskResources.Cpu.CpuShares,
-				CpusetCpus:       strings.Join(cpusetCpus, ","),
-				PercentTicks:     float64(taskResources.Cpu.CpuShares) / float64(tr.clientConfig.Node.NodeResources.Processors.Topology.UsableCompute()),
-			},
-			Ports: &ports,
-		},
+		ID:               fmt.Sprintf("%s/%s/%s", alloc.ID, task.Name, invocationid),
+		Name:             task.Name,
+		JobName:          alloc.Job.Name,
+		JobID:            alloc.Job.ID,
+		TaskGroupName:    alloc.TaskGroup,
+		Namespace:        alloc.Namespace,
+		NodeName:         alloc.NodeName,
+		NodeID:           alloc.NodeID,
+		ParentJobID:      alloc.Job.ParentID,
+		Resources:        tr.buildTaskResources(tr.getTaskResources()),
 		Devices:          tr.hookResources.getDevices(),
 		Mounts:           tr.hookResources.getMounts(),
 		Env:              env.Map(),
@@ -1251,6 +1356,33 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 	}
 }
 
+// buildTaskResources builds the drivers.Resources of the task from its
+// allocated resources.
+func (tr *TaskRunner) buildTaskResources(taskResources *structs.AllocatedTaskResources) *drivers.Resources {
+	ports := tr.Alloc().AllocatedResources.Shared.Ports
+
+	memoryLimit := taskResources.Memory.MemoryMB
+	if max := taskResources.Memory.MemoryMaxMB; max > memoryLimit {
+		memoryLimit = max
+	}
+
+	cpusetCpus := make([]string, len(taskResources.Cpu.ReservedCores))
+	for i, v := range taskResources.Cpu.ReservedCores {
+		cpusetCpus[i] = fmt.Sprintf("%d", v)
+	}
+
+	return &drivers.Resources{
+		NomadResources: taskResources,
+		LinuxResources: &drivers.LinuxResources{
+			MemoryLimitBytes: memoryLimit * 1024 * 1024,
+			CPUShares:        taskResources.Cpu.CpuShares,
+			CpusetCpus:       strings.Join(cpusetCpus, ","),
+			PercentTicks:     float64(taskResources.Cpu.CpuShares) / float64(tr.clientConfig.Node.NodeResources.Processors.Topology.UsableCompute()),
+		},
+		Ports: &ports,
+	}
+}
+
 // Restore task runner state. Called by AllocRunner.Restore after NewTaskRunner
 // but before Run so no locks need to be acquired.
 func (tr *TaskRunner) Restore() error {
@@ -1551,7 +1683,7 @@ func (tr *TaskRunner) LatestResourceUsage() *cstructs.TaskResourceUsage {
 
 	// Look up device statistics lazily when fetched, as currently we do not emit any stats for them yet
 	if ru != nil && tr.deviceStatsReporter != nil {
-		deviceResources := tr.taskResources.Devices
+		deviceResources := tr.getTaskResources().Devices
 		ru.ResourceUsage.DeviceStats = tr.deviceStatsReporter.LatestDeviceResourceStats(deviceResources)
 	}
 	return ru
@@ -1563,10 +1695,34 @@ func (tr *TaskRunner) UpdateStats(ru *cstructs.TaskResourceUsage) {
 	tr.resourceUsage = ru
 	tr.resourceUsageLock.Unlock()
 	if ru != nil {
+		tr.recordStatsHistory(ru)
 		tr.emitStats(ru)
 	}
 }
 
+// recordStatsHistory adds the CPU and memory usage to the stats history of the
+// task. Like "nomad alloc status", the RSS is used as the memory usage unless
+// it isn't measured (e.g. with cgroup-v2).
+func (tr *TaskRunner) recordStatsHistory(ru *cstructs.TaskResourceUsage) {
+	if ru.ResourceUsage == nil || ru.ResourceUsage.CpuStats == nil ||
+		ru.ResourceUsage.MemoryStats == nil {
+		return
+	}
+	ms := ru.ResourceUsage.MemoryStats
+	memory := ms.RSS
+	if memory == 0 && !slices.Contains(ms.Measured, "RSS") {
+		memory = ms.Usage
+	}
+	tr.statsHistory.Add(time.Unix(0, ru.Timestamp),
+		ru.ResourceUsage.CpuStats.TotalTicks, memory)
+}
+
+// StatsHistory returns the resource usage history of the task, oldest sample
+// first.
+func (tr *TaskRunner) StatsHistory() []*cstructs.StatsSample {
+	return tr.statsHistory.Samples()
+}
+
 // TODO Remove Backwardscompat or use tr.Alloc()?
 func (tr *TaskRunner) setGaugeForMemory(ru *cstructs.TaskResourceUsage) {
 	alloc := tr.Alloc()
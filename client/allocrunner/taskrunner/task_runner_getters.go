@@ -95,6 +95,21 @@ func (tr *TaskRunner) setNomadToken(token string) {
 	}
 }
 
+// getTaskResources returns the current resources of the task.
+func (tr *TaskRunner) getTaskResources() *structs.AllocatedTaskResources {
+	tr.taskResourcesLock.Lock()
+	defer tr.taskResourcesLock.Unlock()
+	return tr.taskResources
+}
+
+// setTaskResources replaces the resources of the task. The resources must not
+// be modified afterwards as they are read without locks.
+func (tr *TaskRunner) setTaskResources(resources *structs.AllocatedTaskResources) {
+	tr.taskResourcesLock.Lock()
+	defer tr.taskResourcesLock.Unlock()
+	tr.taskResources = resources
+}
+
 // getDriverHandle returns a driver handle.
 func (tr *TaskRunner) getDriverHandle() *DriverHandle {
 	tr.handleLock.Lock()
@@ -54,6 +54,7 @@ func (tr *TaskRunner) initHooks() {
 	task := tr.Task()
 
 	tr.logmonHookConfig = newLogMonHookConfig(task.Name, task.LogConfig, tr.taskDir.LogDir)
+	tr.logmonHookConfig.sinks = tr.clientConfig.LogSinks
 
 	// Add the hook resources
 	tr.hookResources = &hookResources{}
@@ -71,6 +72,7 @@ func (tr *TaskRunner) initHooks() {
 		newVolumeHook(tr, hookLogger),
 		newArtifactHook(tr, tr.getter, hookLogger),
 		newStatsHook(tr, tr.clientConfig.StatsCollectionInterval, hookLogger),
+		newResizeHook(tr, hookLogger),
 		newDeviceHook(tr.devicemanager, hookLogger),
 		newAPIHook(tr.shutdownCtx, tr.clientConfig.APIListenerRegistrar, hookLogger),
 		newWranglerHook(tr.wranglers, task.Name, alloc.ID, task.UsesCores(), hookLogger),
@@ -180,10 +182,11 @@ func (tr *TaskRunner) initHooks() {
 	// initial registration may be updated to include script checks, which must
 	// be handled with this hook.
 	tr.runnerHooks = append(tr.runnerHooks, newScriptCheckHook(scriptCheckHookConfig{
-		alloc:  tr.Alloc(),
-		task:   tr.Task(),
-		consul: tr.consulServiceClient,
-		logger: hookLogger,
+		alloc:      tr.Alloc(),
+		task:       tr.Task(),
+		consul:     tr.consulServiceClient,
+		checkStore: tr.checkStore,
+		logger:     hookLogger,
 	}))
 
 	// If this task has a pause schedule, initialize the pause (Enterprise)
@@ -243,7 +246,7 @@ func (tr *TaskRunner) prestart() error {
 			Task:          tr.Task(),
 			TaskDir:       tr.taskDir,
 			TaskEnv:       tr.envBuilder.Build(),
-			TaskResources: tr.taskResources,
+			TaskResources: tr.getTaskResources(),
 		}
 
 		origHookState := tr.hookState(name)
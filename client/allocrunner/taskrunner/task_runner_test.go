@@ -3092,3 +3092,39 @@ func TestTaskRunner_setHookStatsHandler(t *testing.T) {
 	must.True(t, ok)
 	must.NotNil(t, noopHandler)
 }
+
+// TestTaskRunner_RestoreCheckpoint asserts that a task is only restored from a
+// checkpoint that was migrated from the previous alloc, and that the
+// checkpoint is removed once the restore has been attempted.
+func TestTaskRunner_RestoreCheckpoint(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Driver = "mock_driver"
+
+	conf, cleanup := testTaskRunnerConfig(t, alloc, task.Name, nil)
+	defer cleanup()
+
+	tr, err := NewTaskRunner(conf)
+	must.NoError(t, err)
+
+	// A checkpoint that wasn't migrated is ignored
+	path := conf.TaskDir.CheckpointPath
+	must.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	must.NoError(t, os.WriteFile(path, []byte("checkpoint"), 0o600))
+
+	_, _, restored := tr.restoreCheckpoint(nil)
+	must.False(t, restored)
+	must.FileExists(t, path)
+
+	// A migrated checkpoint is removed after the restore is attempted, even
+	// though the driver can't restore it
+	tr.MarkCheckpointMigrated()
+	must.True(t, tr.localState.CheckpointMigrated)
+
+	_, _, restored = tr.restoreCheckpoint(nil)
+	must.False(t, restored)
+	must.FileNotExists(t, path)
+	must.False(t, tr.localState.CheckpointMigrated)
+}
@@ -84,15 +84,18 @@ func newMigratorForAlloc(c Config, tg *structs.TaskGroup, watchedAllocID string,
 
 	if m != nil {
 		// Local Allocation because there's an alloc runner
+		prevAlloc := m.Alloc()
 		return &localPrevAlloc{
-			allocID:      c.Alloc.ID,
-			prevAllocID:  watchedAllocID,
-			tasks:        tasks,
-			sticky:       sticky,
-			prevAllocDir: m.GetAllocDir(),
-			prevListener: m.Listener(),
-			prevStatus:   m.Alloc(),
-			logger:       logger,
+			allocID:        c.Alloc.ID,
+			prevAllocID:    watchedAllocID,
+			tasks:          tasks,
+			sticky:         sticky,
+			jobVersion:     c.Alloc.Job.Version,
+			prevJobVersion: prevAlloc.Job.Version,
+			prevAllocDir:   m.GetAllocDir(),
+			prevListener:   m.Listener(),
+			prevStatus:     prevAlloc,
+			logger:         logger,
 		}
 	}
 
@@ -102,6 +105,7 @@ func newMigratorForAlloc(c Config, tg *structs.TaskGroup, watchedAllocID string,
 		tasks:        tasks,
 		config:       c.Config,
 		migrate:      migrate,
+		jobVersion:   c.Alloc.Job.Version,
 		rpc:          c.RPC,
 		migrateToken: c.MigrateToken,
 		logger:       logger,
@@ -192,6 +196,12 @@ type localPrevAlloc struct {
 	// sticky is true if data should be moved
 	sticky bool
 
+	// jobVersion and prevJobVersion are the versions of the job run by the
+	// new and previous alloc. Task checkpoints are only restored if they
+	// match.
+	jobVersion     uint64
+	prevJobVersion uint64
+
 	// prevAllocDir is the alloc dir for the previous alloc
 	prevAllocDir allocdir.Interface
 
@@ -280,7 +290,14 @@ func (p *localPrevAlloc) Migrate(ctx context.Context, dest allocdir.Interface) e
 
 	p.logger.Debug("copying previous alloc")
 
-	return dest.Move(p.prevAllocDir, p.tasks)
+	if err := dest.Move(p.prevAllocDir, p.tasks); err != nil {
+		return err
+	}
+
+	if p.jobVersion != p.prevJobVersion {
+		removeCheckpoints(dest, p.logger)
+	}
+	return nil
 }
 
 // remotePrevAlloc is a prevAllocWatcher for previous allocations on remote
@@ -301,6 +318,14 @@ type remotePrevAlloc struct {
 	// migrate is true if data should be moved between nodes
 	migrate bool
 
+	// jobVersion is the version of the job run by the new alloc. Task
+	// checkpoints are only restored if it matches prevJobVersion.
+	jobVersion uint64
+
+	// prevJobVersion is the version of the job run by the previous alloc.
+	// Set by Wait() alongside nodeID.
+	prevJobVersion uint64
+
 	// rpc provides an RPC method for watching for updates to the previous
 	// alloc and determining what node it was on.
 	rpc RPCer
@@ -411,6 +436,9 @@ func (p *remotePrevAlloc) Wait(ctx context.Context) error {
 		}
 		if resp.Alloc.Terminated() || resp.Alloc.ClientStatus == structs.AllocClientStatusUnknown {
 			p.nodeID = resp.Alloc.NodeID
+			if resp.Alloc.Job != nil {
+				p.prevJobVersion = resp.Alloc.Job.Version
+			}
 			return nil
 		}
 
@@ -465,6 +493,10 @@ func (p *remotePrevAlloc) Migrate(ctx context.Context, dest allocdir.Interface)
 		return err
 	}
 
+	if p.jobVersion != p.prevJobVersion {
+		removeCheckpoints(dest, p.logger)
+	}
+
 	if err := prevAllocDir.Destroy(); err != nil {
 		p.logger.Error("error destroying alloc dir",
 			"error", err, "previous_alloc_dir", prevAllocDir.AllocDir)
@@ -691,6 +723,16 @@ func (p *remotePrevAlloc) streamAllocDir(ctx context.Context, resp io.ReadCloser
 	return nil
 }
 
+// removeCheckpoints removes the task checkpoint archives migrated from a
+// previous alloc that ran a different version of the job, so that the tasks
+// are started from scratch instead of being restored.
+func removeCheckpoints(dest allocdir.Interface, logger hclog.Logger) {
+	path := filepath.Join(dest.AllocDirPath(), allocdir.AllocCheckpointDir)
+	if err := os.RemoveAll(path); err != nil {
+		logger.Warn("failed to remove checkpoints of previous job version", "error", err)
+	}
+}
+
 // NoopPrevAlloc does not block or migrate on a previous allocation and never
 // returns an error.
 type NoopPrevAlloc struct{}
@@ -206,6 +206,47 @@ func TestPrevAlloc_LocalPrevAlloc_Terminated(t *testing.T) {
 	require.NoError(t, waiter.Wait(ctx))
 }
 
+// TestPrevAlloc_LocalPrevAlloc_Migrate_Checkpoint asserts that task
+// checkpoints are migrated only if the previous alloc ran the same version of
+// the job.
+func TestPrevAlloc_LocalPrevAlloc_Migrate_Checkpoint(t *testing.T) {
+	ci.Parallel(t)
+
+	migrate := func(t *testing.T, versionDiff uint64) bool {
+		conf, cleanup := newConfig(t)
+		defer cleanup()
+
+		prevAlloc := conf.PreviousRunner.Alloc()
+		prevAlloc.Job.Version = conf.Alloc.Job.Version - versionDiff
+		prevAlloc.ClientStatus = structs.AllocClientStatusComplete
+
+		// Write a checkpoint to the previous alloc's checkpoint dir
+		task := conf.Alloc.Job.TaskGroups[0].Tasks[0]
+		prevCheckpointDir := filepath.Join(conf.PreviousRunner.GetAllocDir().AllocDirPath(), allocdir.AllocCheckpointDir)
+		must.NoError(t, os.MkdirAll(prevCheckpointDir, 0o700))
+		must.NoError(t, os.WriteFile(filepath.Join(prevCheckpointDir, task.Name+".tar.gz"), []byte("checkpoint"), 0o600))
+
+		dir := t.TempDir()
+		dest := allocdir.NewAllocDir(conf.Logger, dir, dir, conf.Alloc.ID)
+		must.NoError(t, dest.Build())
+		defer dest.Destroy()
+
+		_, migrator := NewAllocWatcher(conf)
+		must.NoError(t, migrator.Migrate(context.Background(), dest))
+
+		_, err := os.Stat(dest.NewTaskDir(task).CheckpointPath)
+		return err == nil
+	}
+
+	t.Run("same job version", func(t *testing.T) {
+		must.True(t, migrate(t, 0))
+	})
+
+	t.Run("different job version", func(t *testing.T) {
+		must.False(t, migrate(t, 1))
+	})
+}
+
 // TestPrevAlloc_StreamAllocDir_Error asserts that errors encountered while
 // streaming a tar cause the migration to be cancelled and no files are written
 // (migrations are atomic).
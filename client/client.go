@@ -38,10 +38,12 @@ import (
 	"github.com/hashicorp/nomad/client/lib/cgroupslib"
 	"github.com/hashicorp/nomad/client/lib/numalib"
 	"github.com/hashicorp/nomad/client/lib/proclib"
+	"github.com/hashicorp/nomad/client/lib/statshistory"
 	"github.com/hashicorp/nomad/client/pluginmanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/csimanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
 	"github.com/hashicorp/nomad/client/servers"
+	"github.com/hashicorp/nomad/client/servicedns"
 	"github.com/hashicorp/nomad/client/serviceregistration"
 	"github.com/hashicorp/nomad/client/serviceregistration/checks/checkstore"
 	"github.com/hashicorp/nomad/client/serviceregistration/nsd"
@@ -132,6 +134,10 @@ type ClientStatsReporter interface {
 
 	// LatestHostStats returns the latest resource usage stats for the host
 	LatestHostStats() *hoststats.HostStats
+
+	// HostStatsHistory returns the resource usage history of the host, oldest
+	// sample first
+	HostStatsHistory() []*cstructs.StatsSample
 }
 
 // Client is used to implement the client interaction with Nomad. Clients
@@ -231,6 +237,11 @@ type Client struct {
 	// this without needing to identify which backend provider should be used.
 	serviceRegWrapper *wrapper.HandlerWrapper
 
+	// serviceDNS answers DNS queries for Nomad service registrations using
+	// serviceDNSCache. Both are nil if the DNS server is not enabled.
+	serviceDNS      *servicedns.Server
+	serviceDNSCache *servicedns.Cache
+
 	// consulProxiesFunc gets an interface to Nomad's custom Consul client for
 	// looking up supported envoy versions
 	consulProxiesFunc consulApiShim.SupportedProxiesAPIFunc
@@ -242,6 +253,9 @@ type Client struct {
 	// HostStatsCollector collects host resource usage stats
 	hostStatsCollector *hoststats.HostStatsCollector
 
+	// hostStatsHistory keeps a downsampled history of the host stats
+	hostStatsHistory *statshistory.History
+
 	// shutdown is true when the Client has been shutdown. Must hold
 	// shutdownLock to access.
 	shutdown bool
@@ -548,6 +562,11 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	c.setupNomadServiceRegistrationHandler()
 	c.serviceRegWrapper = wrapper.NewHandlerWrapper(c.logger, c.consulServices, c.nomadService)
 
+	// Start the DNS server for Nomad service registrations if enabled
+	if err := c.setupServiceDNS(); err != nil {
+		return nil, fmt.Errorf("failed to setup service DNS server: %v", err)
+	}
+
 	// Batching of initial fingerprints is done to reduce the number of node
 	// updates sent to the server on startup.
 	go c.batchFirstFingerprints()
@@ -563,6 +582,7 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	// Add the stats collector
 	statsCollector := hoststats.NewHostStatsCollector(c.logger, c.topology, c.GetConfig().AllocDir, c.devicemanager.AllStats)
 	c.hostStatsCollector = statsCollector
+	c.hostStatsHistory = statshistory.New(statshistory.DefaultTiers)
 
 	// Add the garbage collector
 	gcConfig := &GCConfig{
@@ -950,6 +970,12 @@ func (c *Client) Shutdown() error {
 		h.Shutdown()
 	}
 
+	// Stop answering DNS queries for service registrations
+	if c.serviceDNS != nil {
+		c.serviceDNS.Shutdown()
+		c.serviceDNSCache.Shutdown()
+	}
+
 	// Shutdown the plugin managers
 	c.pluginManagers.Shutdown()
 
@@ -1102,6 +1128,11 @@ func (c *Client) LatestHostStats() *hoststats.HostStats {
 	return c.hostStatsCollector.Stats()
 }
 
+// HostStatsHistory returns the resource usage history of the Nomad client.
+func (c *Client) HostStatsHistory() []*cstructs.StatsSample {
+	return c.hostStatsHistory.Samples()
+}
+
 func (c *Client) LatestDeviceResourceStats(devices []*structs.AllocatedDeviceResource) []*device.DeviceGroupStats {
 	return c.computeAllocatedDeviceGroupStats(devices, c.LatestHostStats().DeviceStats)
 }
@@ -2897,6 +2928,59 @@ func (c *Client) setupNomadServiceRegistrationHandler() {
 	c.nomadService = nsd.NewServiceRegistrationHandler(c.logger, &cfg)
 }
 
+// setupServiceDNS starts the DNS server for Nomad service registrations if it
+// is enabled.
+func (c *Client) setupServiceDNS() error {
+	conf := c.GetConfig()
+	if conf.ServiceDNS == nil {
+		return nil
+	}
+
+	logger := c.logger.Named("service_dns")
+	c.serviceDNSCache = servicedns.NewCache(logger, &servicedns.CacheConfig{
+		Region:   c.Region(),
+		Token:    conf.ServiceDNS.Token,
+		RPC:      c.RPC,
+		Checks:   c.serviceDNSChecks,
+		CheckTTL: conf.ServiceDNS.TTL,
+	})
+	c.serviceDNS = servicedns.NewServer(logger, &servicedns.Config{
+		Addr:   conf.ServiceDNS.Addr,
+		Domain: conf.ServiceDNS.Domain,
+		TTL:    conf.ServiceDNS.TTL,
+	}, c.serviceDNSCache)
+
+	if err := c.serviceDNS.Start(); err != nil {
+		c.serviceDNSCache.Shutdown()
+		c.serviceDNS, c.serviceDNSCache = nil, nil
+		return err
+	}
+	return nil
+}
+
+// serviceDNSChecks returns the Nomad check results of an allocation for the
+// service DNS server. Results of allocations running on this client are read
+// from the local check store, and all others from the client running them.
+func (c *Client) serviceDNSChecks(allocID string) (map[structs.CheckID]*structs.CheckQueryResult, error) {
+	if _, err := c.getAllocRunner(allocID); err == nil {
+		return c.checkStore.List(allocID), nil
+	}
+
+	args := cstructs.AllocChecksRequest{
+		AllocID: allocID,
+		QueryOptions: structs.QueryOptions{
+			Region:     c.Region(),
+			AuthToken:  c.GetConfig().ServiceDNS.Token,
+			AllowStale: true,
+		},
+	}
+	var reply cstructs.AllocChecksResponse
+	if err := c.RPC("ClientAllocations.Checks", &args, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Results, nil
+}
+
 // verifiedTasks asserts each task in taskNames actually exists in the given alloc,
 // otherwise an error is returned.
 func verifiedTasks(logger hclog.Logger, alloc *structs.Allocation, taskNames []string) ([]string, error) {
@@ -3086,9 +3170,12 @@ func (c *Client) emitStats() {
 			next.Reset(config.StatsCollectionInterval)
 			if err != nil {
 				c.logger.Warn("error fetching host resource usage stats", "error", err)
-			} else if config.PublishNodeMetrics {
-				// Publish Node metrics if operator has opted in
-				c.emitHostStats()
+			} else {
+				c.recordHostStatsHistory()
+				if config.PublishNodeMetrics {
+					// Publish Node metrics if operator has opted in
+					c.emitHostStats()
+				}
 			}
 
 			c.emitClientMetrics()
@@ -3098,6 +3185,17 @@ func (c *Client) emitStats() {
 	}
 }
 
+// recordHostStatsHistory adds the latest CPU and memory usage of the host to
+// its stats history
+func (c *Client) recordHostStatsHistory() {
+	hStats := c.hostStatsCollector.Stats()
+	if hStats == nil || hStats.Memory == nil {
+		return
+	}
+	c.hostStatsHistory.Add(time.Unix(0, hStats.Timestamp),
+		hStats.CPUTicksConsumed, hStats.Memory.Used)
+}
+
 // setGaugeForMemoryStats proxies metrics for memory specific statistics
 func (c *Client) setGaugeForMemoryStats(hStats *hoststats.HostStats, baseLabels []metrics.Label) {
 	metrics.SetGaugeWithLabels([]string{"client", "host", "memory", "total"}, float32(hStats.Memory.Total), baseLabels)
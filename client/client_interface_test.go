@@ -166,6 +166,11 @@ func (ar *emptyAllocRunner) LatestAllocStats(taskFilter string) (*cstructs.Alloc
 	}, nil
 }
 
+// StatsHistory lets this empty runner implement AllocStatsReporter
+func (ar *emptyAllocRunner) StatsHistory(taskFilter string) (map[string][]*cstructs.StatsSample, error) {
+	return map[string][]*cstructs.StatsSample{}, nil
+}
+
 func (ar *emptyAllocRunner) SetTaskPauseState(taskName string, ps structs.TaskScheduleState) error {
 	return nil
 }
@@ -31,3 +31,18 @@ func (s *ClientStats) Stats(args *nstructs.NodeSpecificRequest, reply *structs.C
 	reply.HostStats = clientStats.LatestHostStats()
 	return nil
 }
+
+// History is used to retrieve the resource usage history of the Client.
+func (s *ClientStats) History(args *nstructs.NodeSpecificRequest, reply *structs.ClientStatsHistoryResponse) error {
+	defer metrics.MeasureSince([]string{"client", "client_stats", "history"}, time.Now())
+
+	// Check node read permissions
+	if aclObj, err := s.c.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if !aclObj.AllowNodeRead() {
+		return nstructs.ErrPermissionDenied
+	}
+
+	reply.HostStats = s.c.StatsReporter().HostStatsHistory()
+	return nil
+}
@@ -5,6 +5,7 @@ package client
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/ci"
@@ -12,6 +13,7 @@ import (
 	"github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/nomad/mock"
 	nstructs "github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/require"
 )
 
@@ -30,6 +32,24 @@ func TestClientStats_Stats(t *testing.T) {
 	require.NotZero(resp.HostStats.Uptime)
 }
 
+func TestClientStats_History(t *testing.T) {
+	ci.Parallel(t)
+
+	client, cleanup := TestClient(t, nil)
+	defer cleanup()
+
+	now := time.Now()
+	client.hostStatsHistory.Add(now.Add(-time.Minute), 1000, 1024)
+	client.hostStatsHistory.Add(now, 1000, 1024)
+
+	req := &nstructs.NodeSpecificRequest{}
+	var resp structs.ClientStatsHistoryResponse
+	must.NoError(t, client.ClientRPC("ClientStats.History", &req, &resp))
+	must.SliceNotEmpty(t, resp.HostStats)
+	must.Eq(t, 1000, resp.HostStats[0].CPUTicks)
+	must.Eq(t, 1024, resp.HostStats[0].MemoryBytes)
+}
+
 func TestClientStats_Stats_ACL(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -20,6 +20,7 @@ import (
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
 	"github.com/hashicorp/nomad/client/lib/numalib"
 	"github.com/hashicorp/nomad/client/lib/numalib/hw"
+	"github.com/hashicorp/nomad/client/logmon/sink"
 	"github.com/hashicorp/nomad/client/state"
 	"github.com/hashicorp/nomad/command/agent/host"
 	"github.com/hashicorp/nomad/helper"
@@ -51,6 +52,7 @@ var (
 		"exec",
 		"qemu",
 		"java",
+		"firecracker",
 	}, ",")
 
 	// DefaultChrootEnv is a mapping of directories on the host OS to attempt to embed inside each
@@ -366,6 +368,14 @@ type Config struct {
 	// Drain configuration from the agent's config file.
 	Drain *DrainConfig
 
+	// ServiceDNS configures the DNS server for Nomad native service
+	// discovery. It is nil if the DNS server is disabled.
+	ServiceDNS *ServiceDNSConfig
+
+	// LogSinks are the sinks that task log lines are shipped to in addition
+	// to the allocation's log files.
+	LogSinks []*sink.Config
+
 	// Uesrs configuration from the agent's config file.
 	Users *UsersConfig
 
@@ -872,6 +882,7 @@ func (c *Config) Copy() *Config {
 	nc.ReservableCores = slices.Clone(c.ReservableCores)
 	nc.Artifact = c.Artifact.Copy()
 	nc.Users = c.Users.Copy()
+	nc.LogSinks = helper.CopySlice(c.LogSinks)
 	return &nc
 }
 
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/hashicorp/nomad/client/logmon/sink"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+)
+
+// LogSinksFromAgent creates the internal copies of the client agent's log
+// sinks, returning an error if a sink is invalid or its name is not unique.
+func LogSinksFromAgent(c []*config.LogSinkConfig) ([]*sink.Config, error) {
+	if len(c) == 0 {
+		return nil, nil
+	}
+
+	sinks := make([]*sink.Config, 0, len(c))
+	names := make(map[string]struct{}, len(c))
+	for _, s := range c {
+		if _, ok := names[s.Name]; ok {
+			return nil, fmt.Errorf("duplicate log sink %q", s.Name)
+		}
+		names[s.Name] = struct{}{}
+
+		sc := &sink.Config{
+			Name:       s.Name,
+			Type:       s.Type,
+			Address:    s.Address,
+			Facility:   s.Facility,
+			Headers:    maps.Clone(s.Headers),
+			BufferSize: s.BufferSize,
+		}
+		if err := sc.Validate(); err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sc)
+	}
+	return sinks, nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/logmon/sink"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/shoenig/test/must"
+)
+
+func TestLogSinksFromAgent(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name   string
+		config []*config.LogSinkConfig
+		exp    []*sink.Config
+		expErr string
+	}{
+		{
+			name:   "nil config",
+			config: nil,
+			exp:    nil,
+		},
+		{
+			name: "valid",
+			config: []*config.LogSinkConfig{
+				{Name: "syslog", Type: "syslog", Address: "tcp://127.0.0.1:514"},
+				{
+					Name:       "otel",
+					Type:       "otlp",
+					Address:    "http://127.0.0.1:4318",
+					Headers:    map[string]string{"X-Scope-OrgID": "nomad"},
+					BufferSize: 64,
+				},
+			},
+			exp: []*sink.Config{
+				{Name: "syslog", Type: "syslog", Address: "tcp://127.0.0.1:514"},
+				{
+					Name:       "otel",
+					Type:       "otlp",
+					Address:    "http://127.0.0.1:4318",
+					Headers:    map[string]string{"X-Scope-OrgID": "nomad"},
+					BufferSize: 64,
+				},
+			},
+		},
+		{
+			name: "duplicate name",
+			config: []*config.LogSinkConfig{
+				{Name: "local", Type: "unix", Address: "/run/a.sock"},
+				{Name: "local", Type: "unix", Address: "/run/b.sock"},
+			},
+			expErr: `duplicate log sink "local"`,
+		},
+		{
+			name: "invalid sink",
+			config: []*config.LogSinkConfig{
+				{Name: "syslog", Type: "syslog", Address: "ftp://127.0.0.1:514"},
+			},
+			expErr: "address scheme must be one of",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := LogSinksFromAgent(tc.config)
+			if tc.expErr != "" {
+				must.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.exp, got)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs/config"
+)
+
+const (
+	// DefaultServiceDNSAddress is the default address the service DNS server
+	// binds to.
+	DefaultServiceDNSAddress = "127.0.0.1"
+
+	// DefaultServiceDNSPort is the default port the service DNS server binds
+	// to.
+	DefaultServiceDNSPort = 8653
+
+	// DefaultServiceDNSDomain is the default domain the service DNS server is
+	// authoritative for.
+	DefaultServiceDNSDomain = "nomad"
+
+	// DefaultServiceDNSTTL is the default TTL of records returned by the
+	// service DNS server.
+	DefaultServiceDNSTTL = 5 * time.Second
+)
+
+// ServiceDNSConfig configures the client DNS server for Nomad native service
+// discovery.
+type ServiceDNSConfig struct {
+	// Addr is the host:port the DNS server listens on for UDP and TCP.
+	Addr string
+
+	// Domain is the fully qualified domain the DNS server is authoritative
+	// for, including the trailing dot.
+	Domain string
+
+	// TTL is the time-to-live of answered records.
+	TTL time.Duration
+
+	// Token is the ACL token used to read service registrations and checks.
+	Token string
+}
+
+// ServiceDNSConfigFromAgent creates the internal read-only copy of the client
+// agent's ServiceDNSConfig. It returns nil if the DNS server is disabled.
+func ServiceDNSConfigFromAgent(c *config.ServiceDNSConfig) (*ServiceDNSConfig, error) {
+	if c == nil || c.Enabled == nil || !*c.Enabled {
+		return nil, nil
+	}
+
+	address := DefaultServiceDNSAddress
+	port := DefaultServiceDNSPort
+	domain := DefaultServiceDNSDomain
+	ttl := DefaultServiceDNSTTL
+	token := ""
+
+	if c.Address != nil {
+		address = *c.Address
+	}
+	if net.ParseIP(address) == nil {
+		return nil, fmt.Errorf("error parsing Address: %q is not an IP address", address)
+	}
+	if c.Port != nil {
+		port = *c.Port
+	}
+	if port < 1 || port > 65535 {
+		return nil, fmt.Errorf("invalid Port: %d", port)
+	}
+	if c.Domain != nil {
+		domain = strings.Trim(*c.Domain, ".")
+	}
+	if domain == "" {
+		return nil, fmt.Errorf("Domain must not be empty")
+	}
+	if c.TTL != nil {
+		var err error
+		ttl, err = time.ParseDuration(*c.TTL)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing TTL: %w", err)
+		}
+		if ttl < 0 {
+			return nil, fmt.Errorf("TTL must not be negative")
+		}
+	}
+	if c.Token != nil {
+		token = *c.Token
+	}
+
+	return &ServiceDNSConfig{
+		Addr:   net.JoinHostPort(address, strconv.Itoa(port)),
+		Domain: strings.ToLower(domain) + ".",
+		TTL:    ttl,
+		Token:  token,
+	}, nil
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/shoenig/test/must"
+)
+
+func TestServiceDNSConfigFromAgent(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name   string
+		config *config.ServiceDNSConfig
+		exp    *ServiceDNSConfig
+		expErr string
+	}{
+		{
+			name:   "nil config",
+			config: nil,
+			exp:    nil,
+		},
+		{
+			name:   "disabled",
+			config: &config.ServiceDNSConfig{Enabled: pointer.Of(false)},
+			exp:    nil,
+		},
+		{
+			name:   "defaults",
+			config: &config.ServiceDNSConfig{Enabled: pointer.Of(true)},
+			exp: &ServiceDNSConfig{
+				Addr:   "127.0.0.1:8653",
+				Domain: "nomad.",
+				TTL:    5 * time.Second,
+			},
+		},
+		{
+			name: "full config",
+			config: &config.ServiceDNSConfig{
+				Enabled: pointer.Of(true),
+				Address: pointer.Of("::1"),
+				Port:    pointer.Of(53),
+				Domain:  pointer.Of("Example.Internal."),
+				TTL:     pointer.Of("30s"),
+				Token:   pointer.Of("secret"),
+			},
+			exp: &ServiceDNSConfig{
+				Addr:   "[::1]:53",
+				Domain: "example.internal.",
+				TTL:    30 * time.Second,
+				Token:  "secret",
+			},
+		},
+		{
+			name: "invalid address",
+			config: &config.ServiceDNSConfig{
+				Enabled: pointer.Of(true),
+				Address: pointer.Of("localhost"),
+			},
+			expErr: "is not an IP address",
+		},
+		{
+			name: "invalid port",
+			config: &config.ServiceDNSConfig{
+				Enabled: pointer.Of(true),
+				Port:    pointer.Of(70000),
+			},
+			expErr: "invalid Port",
+		},
+		{
+			name: "empty domain",
+			config: &config.ServiceDNSConfig{
+				Enabled: pointer.Of(true),
+				Domain:  pointer.Of("."),
+			},
+			expErr: "Domain must not be empty",
+		},
+		{
+			name: "invalid ttl",
+			config: &config.ServiceDNSConfig{
+				Enabled: pointer.Of(true),
+				TTL:     pointer.Of("forever"),
+			},
+			expErr: "error parsing TTL",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ServiceDNSConfigFromAgent(tc.config)
+			if tc.expErr != "" {
+				must.ErrorContains(t, err, tc.expErr)
+			} else {
+				must.NoError(t, err)
+				must.Eq(t, tc.exp, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package fingerprint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// blockDeviceAttrPrefix is the prefix of the attributes of each block
+	// device, such as "storage.block.sda.dev"
+	blockDeviceAttrPrefix = "storage.block."
+
+	// sysBlockDir lists the block devices of the node
+	sysBlockDir = "/sys/block"
+)
+
+// virtualBlockDevices are the prefixes of the names of block devices that
+// aren't backed by a disk
+var virtualBlockDevices = []string{"loop", "ram", "zram"}
+
+// BlockDevicesFingerprint is used to fingerprint the disks of the node, which
+// the io_max limits of tasks refer to by name.
+type BlockDevicesFingerprint struct {
+	StaticFingerprinter
+	logger hclog.Logger
+	dir    string
+}
+
+func NewBlockDevicesFingerprint(logger hclog.Logger) Fingerprint {
+	return &BlockDevicesFingerprint{
+		logger: logger.Named("block_devices"),
+		dir:    sysBlockDir,
+	}
+}
+
+func (f *BlockDevicesFingerprint) Fingerprint(_ *FingerprintRequest, resp *FingerprintResponse) error {
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		f.logger.Warn("failed to list block devices", "error", err)
+		return nil
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if isVirtualBlockDevice(name) {
+			continue
+		}
+
+		dev, err := f.read(name, "dev")
+		if err != nil {
+			f.logger.Debug("failed to read block device", "device", name, "error", err)
+			continue
+		}
+		resp.AddAttribute(blockDeviceAttrPrefix+name+".dev", dev)
+
+		if rotational, err := f.read(name, "queue", "rotational"); err == nil {
+			resp.AddAttribute(blockDeviceAttrPrefix+name+".rotational", fmt.Sprintf("%t", rotational == "1"))
+		}
+	}
+
+	resp.Detected = true
+	return nil
+}
+
+func (f *BlockDevicesFingerprint) read(name string, path ...string) (string, error) {
+	b, err := os.ReadFile(filepath.Join(append([]string{f.dir, name}, path...)...))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+func isVirtualBlockDevice(name string) bool {
+	for _, prefix := range virtualBlockDevices {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package fingerprint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/shoenig/test/must"
+)
+
+func TestBlockDevicesFingerprint(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	writeDevice := func(name, dev, rotational string) {
+		must.NoError(t, os.MkdirAll(filepath.Join(dir, name, "queue"), 0755))
+		must.NoError(t, os.WriteFile(filepath.Join(dir, name, "dev"), []byte(dev+"\n"), 0644))
+		must.NoError(t, os.WriteFile(filepath.Join(dir, name, "queue", "rotational"), []byte(rotational+"\n"), 0644))
+	}
+	writeDevice("sda", "8:0", "1")
+	writeDevice("nvme0n1", "259:0", "0")
+	writeDevice("loop0", "7:0", "1")
+
+	f := NewBlockDevicesFingerprint(testlog.HCLogger(t))
+	f.(*BlockDevicesFingerprint).dir = dir
+
+	var response FingerprintResponse
+	must.NoError(t, f.Fingerprint(nil, &response))
+	must.True(t, response.Detected)
+	must.Eq(t, map[string]string{
+		"storage.block.sda.dev":            "8:0",
+		"storage.block.sda.rotational":     "true",
+		"storage.block.nvme0n1.dev":        "259:0",
+		"storage.block.nvme0n1.rotational": "false",
+	}, response.Attributes)
+}
+
+func TestBlockDevicesFingerprint_missing(t *testing.T) {
+	ci.Parallel(t)
+
+	f := NewBlockDevicesFingerprint(testlog.HCLogger(t))
+	f.(*BlockDevicesFingerprint).dir = filepath.Join(t.TempDir(), "missing")
+
+	var response FingerprintResponse
+	must.NoError(t, f.Fingerprint(nil, &response))
+	must.False(t, response.Detected)
+	must.MapEmpty(t, response.Attributes)
+}
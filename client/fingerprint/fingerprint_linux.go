@@ -6,4 +6,5 @@ package fingerprint
 func initPlatformFingerprints(fps map[string]Factory) {
 	fps["cgroup"] = NewCgroupFingerprint
 	fps["bridge"] = NewBridgeFingerprint
+	fps["block_devices"] = NewBlockDevicesFingerprint
 }
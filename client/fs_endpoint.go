@@ -13,7 +13,6 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
-	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -25,6 +24,7 @@ import (
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/client/allocdir"
 	sframer "github.com/hashicorp/nomad/client/lib/streamframer"
+	"github.com/hashicorp/nomad/client/logmon/logging"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -411,6 +411,11 @@ func (f *FileSystem) logs(conn io.ReadWriteCloser) {
 		handleStreamResultError(invalidOrigin, pointer.Of(int64(http.StatusBadRequest)), encoder)
 		return
 	}
+	filter, err := newLogFilter(&req)
+	if err != nil {
+		handleStreamResultError(err, pointer.Of(int64(http.StatusBadRequest)), encoder)
+		return
+	}
 
 	fs, err := f.c.GetAllocFS(req.AllocID)
 	if err != nil {
@@ -490,6 +495,27 @@ func (f *FileSystem) logs(conn io.ReadWriteCloser) {
 	var streamErr error
 	buf := new(bytes.Buffer)
 	frameCodec := codec.NewEncoder(buf, structs.JsonHandle)
+	send := func(frame *sframer.StreamFrame) error {
+		var resp cstructs.StreamErrWrapper
+		if req.PlainText {
+			resp.Payload = frame.Data
+		} else {
+			if err := frameCodec.Encode(frame); err != nil {
+				return err
+			}
+			frameCodec.Reset(buf)
+
+			resp.Payload = buf.Bytes()
+			buf.Reset()
+		}
+
+		if err := encoder.Encode(resp); err != nil {
+			return err
+		}
+		encoder.Reset(conn)
+		return nil
+	}
+
 OUTER:
 	for {
 		select {
@@ -506,28 +532,26 @@ OUTER:
 					// No error, continue on
 				}
 
+				// Send the trailing line the filter held back
+				if streamErr == nil && filter != nil {
+					if flushed := filter.flush(); flushed != nil {
+						streamErr = send(flushed)
+					}
+				}
 				break OUTER
 			}
 
-			var resp cstructs.StreamErrWrapper
-			if req.PlainText {
-				resp.Payload = frame.Data
-			} else {
-				if err = frameCodec.Encode(frame); err != nil {
-					streamErr = err
+			// Heartbeats are sent as is and frames without matching
+			// lines are dropped by the filter
+			toSend := []*sframer.StreamFrame{frame}
+			if filter != nil && !frame.IsHeartbeat() {
+				toSend = filter.filter(frame)
+			}
+			for _, frame := range toSend {
+				if streamErr = send(frame); streamErr != nil {
 					break OUTER
 				}
-				frameCodec.Reset(buf)
-
-				resp.Payload = buf.Bytes()
-				buf.Reset()
-			}
-
-			if err := encoder.Encode(resp); err != nil {
-				streamErr = err
-				break OUTER
 			}
-			encoder.Reset(conn)
 		}
 	}
 
@@ -580,6 +604,7 @@ func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset in
 		if err != nil {
 			return fmt.Errorf("failed to list entries: %v", err)
 		}
+		entries = f.uncompressedLogSizes(fs, logPath, entries, task, logType)
 
 		// If we are not following logs, determine the max index for the logs we are
 		// interested in so we can stop there.
@@ -597,6 +622,10 @@ func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset in
 			return err
 		}
 
+		// Compressed log files have been rotated and will not change, so
+		// they are streamed to the end without waiting for the next log
+		_, compression, _ := logging.ParseLogFileName(logBaseName(task, logType), logEntry.Name)
+
 		var eofCancelCh chan error
 		cancelAfterFirstEof := false
 		exitAfter := false
@@ -607,12 +636,16 @@ func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset in
 			// At the end
 			cancelAfterFirstEof = true
 			exitAfter = true
-		} else {
+		} else if compression == "" {
 			eofCancelCh = blockUntilNextLog(ctx, fs, logPath, task, logType, idx+1)
 		}
 
 		p := filepath.Join(logPath, logEntry.Name)
-		err = f.streamFile(ctx, openOffset, p, 0, fs, framer, eofCancelCh, cancelAfterFirstEof)
+		if compression != "" {
+			err = f.streamCompressedFile(ctx, openOffset, p, compression, fs, framer)
+		} else {
+			err = f.streamFile(ctx, openOffset, p, 0, fs, framer, eofCancelCh, cancelAfterFirstEof)
+		}
 
 		// Check if the context is cancelled
 		select {
@@ -802,6 +835,91 @@ OUTER:
 	}
 }
 
+// streamCompressedFile streams the decompressed contents of a rotated log
+// file, starting at the offset into the decompressed contents. Compressed log
+// files are never written to, so the stream ends at EOF. If the connection is
+// broken an EPIPE error is returned.
+func (f *FileSystem) streamCompressedFile(ctx context.Context, offset int64, path, compression string,
+	fs allocdir.AllocDirFS, framer *sframer.StreamFramer) error {
+
+	file, err := fs.ReadAt(path, 0)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	r, err := logging.NewDecompressReader(compression, file)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if _, err := io.CopyN(io.Discard, r, offset); err != nil && err != io.EOF {
+		return err
+	}
+
+	data := make([]byte, streamFrameSize)
+	for {
+		n, readErr := io.ReadFull(r, data)
+		if readErr != nil && readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+			return readErr
+		}
+
+		offset += int64(n)
+		if n != 0 {
+			if err := framer.Send(path, "", data[:n], offset); err != nil {
+				return parseFramerErr(err)
+			}
+		}
+
+		if readErr != nil {
+			return nil
+		}
+
+		select {
+		case <-framer.ExitCh():
+			return nil
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+	}
+}
+
+// uncompressedLogSizes returns the entries with the size of compressed log
+// files of the task replaced by the size of their decompressed contents, as
+// log offsets refer to the decompressed contents. If the size cannot be
+// determined, the compressed size is kept.
+func (f *FileSystem) uncompressedLogSizes(fs allocdir.AllocDirFS, logPath string,
+	entries []*cstructs.AllocFileInfo, task, logType string) []*cstructs.AllocFileInfo {
+
+	out := make([]*cstructs.AllocFileInfo, len(entries))
+	for i, entry := range entries {
+		out[i] = entry
+		if entry.IsDir {
+			continue
+		}
+		_, compression, ok := logging.ParseLogFileName(logBaseName(task, logType), entry.Name)
+		if !ok || compression == "" {
+			continue
+		}
+
+		p := filepath.Join(logPath, entry.Name)
+		size, err := logging.UncompressedSize(compression, entry.Size, func(offset int64) (io.ReadCloser, error) {
+			return fs.ReadAt(p, offset)
+		})
+		if err != nil {
+			f.c.logger.Warn("failed to determine size of compressed log file", "file", p, "error", err)
+			continue
+		}
+
+		resolved := *entry
+		resolved.Size = size
+		out[i] = &resolved
+	}
+	return out
+}
+
 // blockUntilNextLog returns a channel that will have data sent when the next
 // log index or anything greater is created.
 func blockUntilNextLog(ctx context.Context, fs allocdir.AllocDirFS, logPath, task, logType string, nextIndex int64) chan error {
@@ -837,12 +955,7 @@ func blockUntilNextLog(ctx context.Context, fs allocdir.AllocDirFS, logPath, tas
 					return
 				}
 
-				indexes, err := logIndexes(entries, task, logType)
-				if err != nil {
-					next <- err
-					close(next)
-					return
-				}
+				indexes := logIndexes(entries, task, logType)
 
 				// Scan and see if there are any entries larger than what we are
 				// waiting for.
@@ -873,33 +986,39 @@ func (a indexTupleArray) Len() int           { return len(a) }
 func (a indexTupleArray) Less(i, j int) bool { return a[i].idx < a[j].idx }
 func (a indexTupleArray) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 
+// logBaseName returns the base file name of the rotated log files of a task
+func logBaseName(task, logType string) string {
+	return fmt.Sprintf("%s.%s", task, logType)
+}
+
 // logIndexes takes a set of entries and returns a indexTupleArray of
-// the desired log file entries. If the indexes could not be determined, an
-// error is returned.
-func logIndexes(entries []*cstructs.AllocFileInfo, task, logType string) (indexTupleArray, error) {
+// the desired log file entries. Rotated log files may be compressed, and
+// while a file is being compressed both the uncompressed and compressed file
+// exist; the uncompressed file is preferred as it is complete.
+func logIndexes(entries []*cstructs.AllocFileInfo, task, logType string) indexTupleArray {
 	var indexes []indexTuple
-	prefix := fmt.Sprintf("%s.%s.", task, logType)
+	seen := map[int]int{}
 	for _, entry := range entries {
 		if entry.IsDir {
 			continue
 		}
 
-		// If nothing was trimmed, then it is not a match
-		idxStr := strings.TrimPrefix(entry.Name, prefix)
-		if idxStr == entry.Name {
+		idx, compression, ok := logging.ParseLogFileName(logBaseName(task, logType), entry.Name)
+		if !ok {
 			continue
 		}
 
-		// Convert to an int
-		idx, err := strconv.Atoi(idxStr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to convert %q to a log index: %v", idxStr, err)
+		if i, ok := seen[idx]; ok {
+			if compression == "" {
+				indexes[i].entry = entry
+			}
+			continue
 		}
-
+		seen[idx] = len(indexes)
 		indexes = append(indexes, indexTuple{idx: int64(idx), entry: entry})
 	}
 
-	return indexTupleArray(indexes), nil
+	return indexTupleArray(indexes)
 }
 
 // notFoundErr is returned when a log is requested but cannot be found.
@@ -927,10 +1046,7 @@ func findClosest(entries []*cstructs.AllocFileInfo, desiredIdx, desiredOffset in
 	task, logType string) (*cstructs.AllocFileInfo, int64, int64, error) {
 
 	// Build the matching indexes
-	indexes, err := logIndexes(entries, task, logType)
-	if err != nil {
-		return nil, 0, 0, err
-	}
+	indexes := logIndexes(entries, task, logType)
 	if len(indexes) == 0 {
 		return nil, 0, 0, notFoundErr{taskName: task, logType: logType}
 	}
@@ -4,6 +4,8 @@
 package client
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
@@ -32,6 +34,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
+	"github.com/klauspost/compress/zstd"
 	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/require"
 )
@@ -1437,6 +1440,99 @@ OUTER:
 	}
 }
 
+func TestFS_Logs_Filter(t *testing.T) {
+	ci.Parallel(t)
+
+	// Start a server and client
+	s, cleanupS := nomad.TestServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+
+	c, cleanupC := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s.GetConfig().RPCAddr.String()}
+	})
+	defer cleanupC()
+
+	job := mock.BatchJob()
+	job.TaskGroups[0].Count = 1
+	job.TaskGroups[0].Tasks[0].Config = map[string]interface{}{
+		"run_for":       "2s",
+		"stdout_string": "2024-03-01T12:00:00Z INFO started\n2024-03-01T12:00:01Z ERROR failed\n  at main.go:10\n2024-03-01T12:00:02Z ERROR retried\n",
+	}
+
+	// Wait for client to be running job
+	alloc := testutil.WaitForRunning(t, s.RPC, job)[0]
+
+	handler, err := c.StreamingRpcHandler("FileSystem.Logs")
+	must.NoError(t, err)
+
+	// streamLogs returns the streamed logs once stream stops producing them
+	streamLogs := func(req *cstructs.FsLogsRequest) (string, error) {
+		p1, p2 := net.Pipe()
+		defer p1.Close()
+		defer p2.Close()
+
+		go handler(p2)
+
+		encoder := codec.NewEncoder(p1, structs.MsgpackHandle)
+		if err := encoder.Encode(req); err != nil {
+			return "", err
+		}
+
+		received := ""
+		decoder := codec.NewDecoder(p1, structs.MsgpackHandle)
+		for {
+			var msg cstructs.StreamErrWrapper
+			if err := decoder.Decode(&msg); err != nil {
+				if err == io.EOF || strings.Contains(err.Error(), "closed") {
+					return received, nil
+				}
+				return "", err
+			}
+			if msg.Error != nil {
+				return "", msg.Error
+			}
+			received += string(msg.Payload)
+		}
+	}
+
+	newReq := func() *cstructs.FsLogsRequest {
+		return &cstructs.FsLogsRequest{
+			AllocID:      alloc.ID,
+			Task:         job.TaskGroups[0].Tasks[0].Name,
+			LogType:      "stdout",
+			Origin:       "start",
+			PlainText:    true,
+			QueryOptions: structs.QueryOptions{Region: "global"},
+		}
+	}
+
+	t.Run("include and exclude", func(t *testing.T) {
+		req := newReq()
+		req.Include = "ERROR"
+		req.Exclude = "retried"
+		out, err := streamLogs(req)
+		must.NoError(t, err)
+		must.Eq(t, "2024-03-01T12:00:01Z ERROR failed\n", out)
+	})
+
+	t.Run("time bounds", func(t *testing.T) {
+		req := newReq()
+		req.Since = time.Date(2024, 3, 1, 12, 0, 1, 0, time.UTC)
+		req.Until = time.Date(2024, 3, 1, 12, 0, 1, 0, time.UTC)
+		out, err := streamLogs(req)
+		must.NoError(t, err)
+		must.Eq(t, "2024-03-01T12:00:01Z ERROR failed\n  at main.go:10\n", out)
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		req := newReq()
+		req.Include = "("
+		_, err := streamLogs(req)
+		must.ErrorContains(t, err, "invalid include expression")
+	})
+}
+
 func TestFS_Logs_Follow(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -2085,6 +2181,116 @@ func TestFS_logsImpl_NoFollow(t *testing.T) {
 	}
 }
 
+func TestFS_logsImpl_Compressed(t *testing.T) {
+	ci.Parallel(t)
+
+	c, cleanup := TestClient(t, nil)
+	defer cleanup()
+
+	// Get a temp alloc dir and create the log dir
+	ad := tempAllocDir(t)
+	must.NoError(t, ad.Build())
+	defer ad.Destroy()
+
+	logDir := filepath.Join(ad.SharedDir, allocdir.LogDirName)
+	must.NoError(t, os.MkdirAll(logDir, 0777))
+
+	// Create rotated log files compressed with each algorithm followed by
+	// the uncompressed current file
+	task := "foo"
+	logType := "stdout"
+	segments := []string{"abc\n", "def\n", "ghi\n"}
+
+	var gzBuf bytes.Buffer
+	gz := gzip.NewWriter(&gzBuf)
+	_, err := gz.Write([]byte(segments[0]))
+	must.NoError(t, err)
+	must.NoError(t, gz.Close())
+	must.NoError(t, os.WriteFile(filepath.Join(logDir, "foo.stdout.0.gz"), gzBuf.Bytes(), 0777))
+
+	enc, err := zstd.NewWriter(nil)
+	must.NoError(t, err)
+	zstdData := enc.EncodeAll([]byte(segments[1]), nil)
+	must.NoError(t, enc.Close())
+	must.NoError(t, os.WriteFile(filepath.Join(logDir, "foo.stdout.1.zst"), zstdData, 0777))
+
+	must.NoError(t, os.WriteFile(filepath.Join(logDir, "foo.stdout.2"), []byte(segments[2]), 0777))
+
+	cases := []struct {
+		name     string
+		origin   string
+		offset   int64
+		expected string
+	}{
+		{
+			name:     "from start",
+			origin:   OriginStart,
+			expected: "abc\ndef\nghi\n",
+		},
+		{
+			name:     "from start with offset",
+			origin:   OriginStart,
+			offset:   5,
+			expected: "ef\nghi\n",
+		},
+		{
+			name:     "from end",
+			origin:   OriginEnd,
+			offset:   6,
+			expected: "f\nghi\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			frames := make(chan *sframer.StreamFrame, 32)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			must.NoError(t, c.endpoints.FileSystem.logsImpl(
+				ctx, false, false, tc.offset,
+				tc.origin, task, logType, ad, frames))
+
+			var received []byte
+			timeout := time.After(10 * time.Duration(testutil.TestMultiplier()) * streamBatchWindow)
+			for string(received) != tc.expected {
+				select {
+				case frame, ok := <-frames:
+					if !ok {
+						t.Fatalf("expected %q, got %q", tc.expected, string(received))
+					}
+					received = append(received, frame.Data...)
+				case <-timeout:
+					t.Fatalf("did not receive data: got %q", string(received))
+				}
+			}
+		})
+	}
+}
+
+func TestFS_logIndexes_Compressed(t *testing.T) {
+	ci.Parallel(t)
+
+	entries := []*cstructs.AllocFileInfo{
+		{Name: "foo.stdout.0.gz", Size: 10},
+		{Name: "foo.stdout.1.zst", Size: 10},
+		{Name: "foo.stdout.2.gz", Size: 5},
+		{Name: "foo.stdout.2", Size: 100},
+		{Name: ".foo.stdout.3.gz.tmp", Size: 5},
+		{Name: "foo.stdout.3", Size: 100},
+		{Name: "foo.stderr.0.gz", Size: 10},
+	}
+
+	indexes := logIndexes(entries, "foo", "stdout")
+	must.Len(t, 4, indexes)
+
+	names := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		names = append(names, index.entry.Name)
+	}
+	must.Eq(t, []string{"foo.stdout.0.gz", "foo.stdout.1.zst", "foo.stdout.2", "foo.stdout.3"}, names)
+}
+
 func TestFS_logsImpl_Follow(t *testing.T) {
 	ci.Parallel(t)
 
@@ -0,0 +1,206 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	sframer "github.com/hashicorp/nomad/client/lib/streamframer"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+const (
+	// maxFilterLineSize is the size at which a partial line is filtered
+	// without waiting for the rest of the line
+	maxFilterLineSize = 64 * 1024
+)
+
+// logTimeLayouts are the layouts of the timestamps log lines may start with.
+// Timestamps without a time zone are assumed to be UTC.
+var logTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// logFilter filters the lines of a log stream so that only matching lines are
+// sent over the wire. Frames may split lines, so the trailing partial line of
+// a frame is held back until the rest of the line is read.
+type logFilter struct {
+	include *regexp.Regexp
+	exclude *regexp.Regexp
+	since   time.Time
+	until   time.Time
+
+	// lastTime is the timestamp of the last line that started with one. Lines
+	// without a timestamp, such as stack traces, inherit it.
+	lastTime time.Time
+
+	// pending is the partial line read from file at offset
+	pending []byte
+	file    string
+	offset  int64
+}
+
+// newLogFilter returns the filter for the logs request, or nil if the request
+// does not filter the logs.
+func newLogFilter(req *cstructs.FsLogsRequest) (*logFilter, error) {
+	if req.Include == "" && req.Exclude == "" && req.Since.IsZero() && req.Until.IsZero() {
+		return nil, nil
+	}
+
+	f := &logFilter{
+		since: req.Since,
+		until: req.Until,
+	}
+	if !f.since.IsZero() && !f.until.IsZero() && f.until.Before(f.since) {
+		return nil, fmt.Errorf("until time %s is before since time %s",
+			f.until.Format(time.RFC3339), f.since.Format(time.RFC3339))
+	}
+
+	var err error
+	if req.Include != "" {
+		if f.include, err = regexp.Compile(req.Include); err != nil {
+			return nil, fmt.Errorf("invalid include expression: %v", err)
+		}
+	}
+	if req.Exclude != "" {
+		if f.exclude, err = regexp.Compile(req.Exclude); err != nil {
+			return nil, fmt.Errorf("invalid exclude expression: %v", err)
+		}
+	}
+	return f, nil
+}
+
+// filter returns the frames to send in place of the passed frame. The data of
+// the returned frames only contains complete, matching lines.
+func (f *logFilter) filter(frame *sframer.StreamFrame) []*sframer.StreamFrame {
+	var out []*sframer.StreamFrame
+
+	// Lines do not span files, so the partial line of the previous file is
+	// complete
+	if f.file != frame.File && len(f.pending) > 0 {
+		if flushed := f.flush(); flushed != nil {
+			out = append(out, flushed)
+		}
+	}
+
+	f.file = frame.File
+	data := append(f.pending, frame.Data...)
+
+	var matched []byte
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		line := data[:i+1]
+		data = data[i+1:]
+		if f.match(line) {
+			matched = append(matched, line...)
+		}
+	}
+
+	// Overly long lines are filtered without waiting for their end
+	if len(data) >= maxFilterLineSize {
+		if f.match(data) {
+			matched = append(matched, data...)
+		}
+		data = nil
+	}
+	f.pending = append([]byte(nil), data...)
+
+	if len(matched) > 0 || frame.FileEvent != "" {
+		out = append(out, &sframer.StreamFrame{
+			Offset:    frame.Offset,
+			Data:      matched,
+			File:      frame.File,
+			FileEvent: frame.FileEvent,
+		})
+	}
+
+	// Track where the partial line starts unless it started in an earlier
+	// frame
+	if n := len(f.pending); n > 0 && n <= len(frame.Data) {
+		f.offset = frame.Offset + int64(len(frame.Data)-n)
+	}
+	return out
+}
+
+// flush returns a frame with the pending partial line if it matches, or nil.
+func (f *logFilter) flush() *sframer.StreamFrame {
+	line := f.pending
+	f.pending = nil
+	if len(line) == 0 || !f.match(line) {
+		return nil
+	}
+	return &sframer.StreamFrame{
+		Offset: f.offset,
+		Data:   line,
+		File:   f.file,
+	}
+}
+
+// match returns whether the line passes the filter.
+func (f *logFilter) match(line []byte) bool {
+	if !f.since.IsZero() || !f.until.IsZero() {
+		if t, ok := parseLogTime(line); ok {
+			f.lastTime = t
+		}
+		if !f.lastTime.IsZero() {
+			if !f.since.IsZero() && f.lastTime.Before(f.since) {
+				return false
+			}
+			if !f.until.IsZero() && f.lastTime.After(f.until) {
+				return false
+			}
+		}
+	}
+
+	line = bytes.TrimRight(line, "\r\n")
+	if f.include != nil && !f.include.Match(line) {
+		return false
+	}
+	if f.exclude != nil && f.exclude.Match(line) {
+		return false
+	}
+	return true
+}
+
+// parseLogTime returns the timestamp a log line starts with. The timestamp
+// may be wrapped in square brackets and its date and time may be separated by
+// a space instead of a T.
+func parseLogTime(line []byte) (time.Time, bool) {
+	// The longest supported timestamp is 35 characters
+	if len(line) > 40 {
+		line = line[:40]
+	}
+	s := strings.TrimPrefix(string(line), "[")
+
+	// Consider the first field, or the first two if the date and time are
+	// separated by a space
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return time.Time{}, false
+	}
+	candidates := []string{fields[0]}
+	if len(fields) > 1 {
+		candidates = append(candidates, fields[0]+" "+fields[1])
+	}
+
+	for _, c := range candidates {
+		c = strings.TrimSuffix(c, "]")
+		c = strings.Replace(c, ",", ".", 1)
+		for _, layout := range logTimeLayouts {
+			if t, err := time.Parse(layout, c); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	sframer "github.com/hashicorp/nomad/client/lib/streamframer"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestLogFilter_New(t *testing.T) {
+	ci.Parallel(t)
+
+	f, err := newLogFilter(&cstructs.FsLogsRequest{})
+	must.NoError(t, err)
+	must.Nil(t, f)
+
+	_, err = newLogFilter(&cstructs.FsLogsRequest{Exclude: "[a-"})
+	must.ErrorContains(t, err, "invalid exclude expression")
+
+	now := time.Now()
+	_, err = newLogFilter(&cstructs.FsLogsRequest{Since: now, Until: now.Add(-time.Minute)})
+	must.ErrorContains(t, err, "is before since time")
+}
+
+func TestLogFilter_SplitLines(t *testing.T) {
+	ci.Parallel(t)
+
+	f, err := newLogFilter(&cstructs.FsLogsRequest{Include: "keep"})
+	must.NoError(t, err)
+
+	var out []string
+	collect := func(frames []*sframer.StreamFrame) {
+		for _, frame := range frames {
+			out = append(out, frame.File+":"+string(frame.Data))
+		}
+	}
+
+	// Lines split across frames are filtered once complete
+	collect(f.filter(&sframer.StreamFrame{File: "a.0", Offset: 0, Data: []byte("keep 1\ndrop 1\nke")}))
+	collect(f.filter(&sframer.StreamFrame{File: "a.0", Offset: 16, Data: []byte("ep 2\ndrop")}))
+	must.Eq(t, []string{"a.0:keep 1\n", "a.0:keep 2\n"}, out)
+	must.Eq(t, 21, f.offset)
+
+	// Switching files completes the partial line of the previous file
+	out = nil
+	collect(f.filter(&sframer.StreamFrame{File: "a.0", Offset: 21, Data: []byte(" keep")}))
+	collect(f.filter(&sframer.StreamFrame{File: "a.1", Offset: 0, Data: []byte("keep 3\nkeep 4")}))
+	must.Eq(t, []string{"a.0:drop keep", "a.1:keep 3\n"}, out)
+	must.Eq(t, "keep 4", string(f.flush().Data))
+	must.Nil(t, f.flush())
+
+	// File events are sent even without matching lines
+	frames := f.filter(&sframer.StreamFrame{File: "a.1", FileEvent: "file truncated", Data: []byte("drop\n")})
+	must.Len(t, 1, frames)
+	must.Eq(t, "file truncated", frames[0].FileEvent)
+	must.SliceEmpty(t, frames[0].Data)
+}
+
+func TestLogFilter_Match(t *testing.T) {
+	ci.Parallel(t)
+
+	f, err := newLogFilter(&cstructs.FsLogsRequest{
+		Since:   time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		Until:   time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC),
+		Exclude: "debug",
+	})
+	must.NoError(t, err)
+
+	cases := []struct {
+		line  string
+		match bool
+	}{
+		// Lines before the first timestamp are kept
+		{"starting up\n", true},
+		{"2024-03-01T11:59:59Z too early\n", false},
+		{"  continuation of an early line\n", false},
+		{"2024-03-01T12:00:00.5+00:00 in range\n", true},
+		{"2024-03-01T12:30:00Z debug excluded\n", false},
+		{"[2024-03-01 12:45:00,123] bracketed\n", true},
+		{"  continuation of a line in range\n", true},
+		{"2024-03-01 13:00:01 too late\n", false},
+		{"2024-03-01T14:00:00+02:00 in range with zone\n", true},
+	}
+	for _, tc := range cases {
+		must.Eq(t, tc.match, f.match([]byte(tc.line)), must.Sprint(tc.line))
+	}
+}
+
+func TestLogFilter_parseLogTime(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		line string
+		exp  time.Time
+		ok   bool
+	}{
+		{"2024-03-01T12:30:45.123456789Z msg", time.Date(2024, 3, 1, 12, 30, 45, 123456789, time.UTC), true},
+		{"2024-03-01 12:30:45 msg", time.Date(2024, 3, 1, 12, 30, 45, 0, time.UTC), true},
+		{"[2024-03-01T12:30:45Z] msg", time.Date(2024, 3, 1, 12, 30, 45, 0, time.UTC), true},
+		{"INFO 2024-03-01T12:30:45Z msg", time.Time{}, false},
+		{"", time.Time{}, false},
+	}
+	for _, tc := range cases {
+		got, ok := parseLogTime([]byte(tc.line))
+		must.Eq(t, tc.ok, ok, must.Sprint(tc.line))
+		must.True(t, tc.exp.Equal(got), must.Sprint(tc.line))
+	}
+}
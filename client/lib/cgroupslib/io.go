@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package cgroupslib
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// sysBlock is the directory of the block devices known to the kernel, each
+// with a "dev" file containing its "major:minor" device numbers
+var sysBlock = "/sys/class/block"
+
+// BlockDevice returns the major and minor numbers of the block device with the
+// given kernel name, such as "sda" or "nvme0n1".
+func BlockDevice(name string) (int64, int64, error) {
+	if name == "" || strings.ContainsAny(name, "/.") {
+		return 0, 0, fmt.Errorf("invalid block device name %q", name)
+	}
+	b, err := os.ReadFile(filepath.Join(sysBlock, name, "dev"))
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to find block device %q: %w", name, err)
+	}
+	return parseDeviceNumbers(strings.TrimSpace(string(b)))
+}
+
+func parseDeviceNumbers(s string) (int64, int64, error) {
+	maj, min, ok := strings.Cut(s, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid device numbers %q", s)
+	}
+	major, err := strconv.ParseInt(maj, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid device major number %q: %w", maj, err)
+	}
+	minor, err := strconv.ParseInt(min, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid device minor number %q: %w", min, err)
+	}
+	return major, minor, nil
+}
+
+// IOMax returns the line of the cgroups v2 io.max interface file limiting the
+// block I/O to a device. Limits that are 0 are written as "max", removing any
+// previous limit.
+func IOMax(major, minor, rbps, wbps, riops, wiops int64) string {
+	limit := func(v int64) string {
+		if v <= 0 {
+			return "max"
+		}
+		return strconv.FormatInt(v, 10)
+	}
+	return fmt.Sprintf("%d:%d rbps=%s wbps=%s riops=%s wiops=%s",
+		major, minor, limit(rbps), limit(wbps), limit(riops), limit(wiops))
+}
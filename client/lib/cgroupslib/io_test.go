@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package cgroupslib
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestBlockDevice(t *testing.T) {
+	dir := t.TempDir()
+	must.NoError(t, os.MkdirAll(filepath.Join(dir, "sda"), 0755))
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "sda", "dev"), []byte("8:0\n"), 0644))
+	must.NoError(t, os.MkdirAll(filepath.Join(dir, "bad"), 0755))
+	must.NoError(t, os.WriteFile(filepath.Join(dir, "bad", "dev"), []byte("8\n"), 0644))
+
+	orig := sysBlock
+	sysBlock = dir
+	t.Cleanup(func() { sysBlock = orig })
+
+	major, minor, err := BlockDevice("sda")
+	must.NoError(t, err)
+	must.Eq(t, 8, major)
+	must.Eq(t, 0, minor)
+
+	_, _, err = BlockDevice("nvme0n1")
+	must.ErrorContains(t, err, `failed to find block device "nvme0n1"`)
+
+	_, _, err = BlockDevice("bad")
+	must.ErrorContains(t, err, "invalid device numbers")
+
+	_, _, err = BlockDevice("../sda")
+	must.ErrorContains(t, err, "invalid block device name")
+}
+
+func TestIOMax(t *testing.T) {
+	must.Eq(t, "8:16 rbps=1048576 wbps=max riops=max wiops=100",
+		IOMax(8, 16, 1048576, 0, 0, 100))
+	must.Eq(t, "259:0 rbps=max wbps=max riops=max wiops=max",
+		IOMax(259, 0, 0, 0, 0, 0))
+}
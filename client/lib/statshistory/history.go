@@ -0,0 +1,164 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package statshistory keeps a compact in-memory history of the resource usage
+// of tasks and hosts, so that usage can be reviewed without an external
+// metrics stack.
+package statshistory
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// Tier is one level of the history. Usage added to the history is averaged
+// into a sample per Resolution, and samples are kept for Retention.
+type Tier struct {
+	Resolution time.Duration
+	Retention  time.Duration
+}
+
+// DefaultTiers keeps 10 second samples for the last hour, and 5 minute samples
+// for the last day.
+var DefaultTiers = []Tier{
+	{Resolution: 10 * time.Second, Retention: time.Hour},
+	{Resolution: 5 * time.Minute, Retention: 24 * time.Hour},
+}
+
+// History is a set of ring buffers of resource usage samples, one per tier,
+// from the finest to the coarsest resolution. It is safe for concurrent use.
+type History struct {
+	mu    sync.Mutex
+	tiers []*tier
+}
+
+// New returns an empty History with the given tiers, which must be ordered
+// from the finest to the coarsest resolution.
+func New(tiers []Tier) *History {
+	h := &History{tiers: make([]*tier, 0, len(tiers))}
+	for _, t := range tiers {
+		size := int(t.Retention / t.Resolution)
+		if size < 1 {
+			size = 1
+		}
+		h.tiers = append(h.tiers, &tier{
+			resolution: t.Resolution,
+			samples:    make([]cstructs.StatsSample, size),
+		})
+	}
+	return h
+}
+
+// Add records the CPU usage in MHz and the memory usage in bytes measured at
+// the given time. Usage older than the current window of a tier is ignored by
+// that tier.
+func (h *History) Add(at time.Time, cpuTicks float64, memoryBytes uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, t := range h.tiers {
+		t.add(at, cpuTicks, memoryBytes)
+	}
+}
+
+// Samples returns the completed samples of the history, oldest first. The
+// finest tier covers the most recent usage and each coarser tier only covers
+// the usage before it.
+func (h *History) Samples() []*cstructs.StatsSample {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var out []*cstructs.StatsSample
+
+	// until is the start of the usage covered by the finer tiers
+	until := int64(math.MaxInt64)
+	for _, t := range h.tiers {
+		samples := t.list()
+		n := 0
+		for n < len(samples) && samples[n].Timestamp <= until {
+			n++
+		}
+		samples = samples[:n]
+		if len(samples) > 0 {
+			until = samples[0].Timestamp - int64(samples[0].Window)
+		}
+		out = append(samples, out...)
+	}
+	return out
+}
+
+// tier is a ring buffer of the samples of one resolution, and the window
+// currently being accumulated.
+type tier struct {
+	resolution time.Duration
+
+	samples []cstructs.StatsSample
+	next    int
+	full    bool
+
+	// the window currently being accumulated
+	start     time.Time
+	count     int
+	cpuSum    float64
+	cpuMax    float64
+	memorySum float64
+	memoryMax uint64
+}
+
+func (t *tier) add(at time.Time, cpuTicks float64, memoryBytes uint64) {
+	start := at.Truncate(t.resolution)
+	switch {
+	case t.count == 0:
+		t.start = start
+	case start.Before(t.start):
+		return
+	case start.After(t.start):
+		t.flush()
+		t.start = start
+	}
+
+	t.count++
+	t.cpuSum += cpuTicks
+	t.cpuMax = max(t.cpuMax, cpuTicks)
+	t.memorySum += float64(memoryBytes)
+	t.memoryMax = max(t.memoryMax, memoryBytes)
+}
+
+// flush closes the current window into a sample
+func (t *tier) flush() {
+	t.samples[t.next] = cstructs.StatsSample{
+		Timestamp:      t.start.Add(t.resolution).UnixNano(),
+		Window:         t.resolution,
+		CPUTicks:       t.cpuSum / float64(t.count),
+		CPUTicksMax:    t.cpuMax,
+		MemoryBytes:    uint64(t.memorySum / float64(t.count)),
+		MemoryBytesMax: t.memoryMax,
+	}
+	t.next = (t.next + 1) % len(t.samples)
+	if t.next == 0 {
+		t.full = true
+	}
+
+	t.count = 0
+	t.cpuSum, t.cpuMax = 0, 0
+	t.memorySum, t.memoryMax = 0, 0
+}
+
+// list returns copies of the samples of the tier, oldest first
+func (t *tier) list() []*cstructs.StatsSample {
+	var ordered []cstructs.StatsSample
+	if t.full {
+		ordered = append(ordered, t.samples[t.next:]...)
+	}
+	ordered = append(ordered, t.samples[:t.next]...)
+
+	out := make([]*cstructs.StatsSample, len(ordered))
+	for i := range ordered {
+		s := ordered[i]
+		out[i] = &s
+	}
+	return out
+}
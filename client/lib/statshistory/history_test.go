@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package statshistory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestHistory_Samples(t *testing.T) {
+	ci.Parallel(t)
+
+	h := New([]Tier{{Resolution: 10 * time.Second, Retention: time.Minute}})
+	must.SliceEmpty(t, h.Samples())
+
+	start := time.Unix(1000, 0)
+	h.Add(start, 100, 1024)
+	h.Add(start.Add(5*time.Second), 300, 3072)
+
+	// the current window isn't complete yet
+	must.SliceEmpty(t, h.Samples())
+
+	h.Add(start.Add(10*time.Second), 50, 512)
+	must.Eq(t, []*cstructs.StatsSample{{
+		Timestamp:      start.Add(10 * time.Second).UnixNano(),
+		Window:         10 * time.Second,
+		CPUTicks:       200,
+		CPUTicksMax:    300,
+		MemoryBytes:    2048,
+		MemoryBytesMax: 3072,
+	}}, h.Samples())
+
+	// usage older than the current window is ignored
+	h.Add(start, 1000, 1024)
+	h.Add(start.Add(20*time.Second), 50, 512)
+	samples := h.Samples()
+	must.Len(t, 2, samples)
+	must.Eq(t, 50, samples[1].CPUTicksMax)
+}
+
+func TestHistory_Retention(t *testing.T) {
+	ci.Parallel(t)
+
+	h := New([]Tier{{Resolution: 10 * time.Second, Retention: 30 * time.Second}})
+
+	start := time.Unix(1000, 0)
+	for i := 0; i <= 5; i++ {
+		h.Add(start.Add(time.Duration(i)*10*time.Second), float64(i), uint64(i))
+	}
+
+	// only the last 3 completed windows are kept, oldest first
+	samples := h.Samples()
+	must.Len(t, 3, samples)
+	for i, s := range samples {
+		must.Eq(t, float64(i+2), s.CPUTicks)
+		must.Eq(t, start.Add(time.Duration(i+3)*10*time.Second).UnixNano(), s.Timestamp)
+	}
+}
+
+func TestHistory_Tiers(t *testing.T) {
+	ci.Parallel(t)
+
+	h := New([]Tier{
+		{Resolution: 10 * time.Second, Retention: 30 * time.Second},
+		{Resolution: time.Minute, Retention: 10 * time.Minute},
+	})
+
+	start := time.Unix(600, 0)
+	for i := 0; i < 30; i++ {
+		h.Add(start.Add(time.Duration(i)*10*time.Second), float64(i/6), 0)
+	}
+
+	// the completed minutes older than the fine samples are followed by the
+	// last three fine samples
+	samples := h.Samples()
+	must.Len(t, 7, samples)
+	for i, s := range samples[:4] {
+		must.Eq(t, time.Minute, s.Window)
+		must.Eq(t, float64(i), s.CPUTicks)
+	}
+	for _, s := range samples[4:] {
+		must.Eq(t, 10*time.Second, s.Window)
+		must.Eq(t, 4, s.CPUTicks)
+	}
+	must.Eq(t, start.Add(290*time.Second).UnixNano(), samples[6].Timestamp)
+}
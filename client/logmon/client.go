@@ -22,13 +22,27 @@ const logmonRPCTimeout = 1 * time.Minute
 
 func (c *logmonClient) Start(cfg *LogConfig) error {
 	req := &proto.StartRequest{
-		LogDir:         cfg.LogDir,
-		StdoutFileName: cfg.StdoutLogFile,
-		StderrFileName: cfg.StderrLogFile,
-		MaxFiles:       uint32(cfg.MaxFiles),
-		MaxFileSizeMb:  uint32(cfg.MaxFileSizeMB),
-		StdoutFifo:     cfg.StdoutFifo,
-		StderrFifo:     cfg.StderrFifo,
+		LogDir:           cfg.LogDir,
+		StdoutFileName:   cfg.StdoutLogFile,
+		StderrFileName:   cfg.StderrLogFile,
+		MaxFiles:         uint32(cfg.MaxFiles),
+		MaxFileSizeMb:    uint32(cfg.MaxFileSizeMB),
+		StdoutFifo:       cfg.StdoutFifo,
+		StderrFifo:       cfg.StderrFifo,
+		Compression:      cfg.Compression,
+		RotationInterval: int64(cfg.RotationInterval),
+		MaxTotalSizeMb:   uint32(cfg.MaxTotalSizeMB),
+		SinkMetadata:     cfg.SinkMetadata,
+	}
+	for _, s := range cfg.Sinks {
+		req.Sinks = append(req.Sinks, &proto.LogSink{
+			Name:       s.Name,
+			Type:       s.Type,
+			Address:    s.Address,
+			Facility:   s.Facility,
+			Headers:    s.Headers,
+			BufferSize: uint32(s.BufferSize),
+		})
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), logmonRPCTimeout)
 	defer cancel()
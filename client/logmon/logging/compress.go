@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logging
+
+import (
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// CompressionGzip compresses rotated log files with gzip
+	CompressionGzip = "gzip"
+
+	// CompressionZstd compresses rotated log files with zstd
+	CompressionZstd = "zstd"
+)
+
+// compressionExtensions maps each supported compression to the extension
+// appended to the name of the log files it compresses.
+var compressionExtensions = map[string]string{
+	CompressionGzip: ".gz",
+	CompressionZstd: ".zst",
+}
+
+// rotatedFileName returns the name of the log file with the given index. An
+// empty compression returns the name of the uncompressed file.
+func rotatedFileName(baseFileName string, idx int, compression string) string {
+	return fmt.Sprintf("%s.%d%s", baseFileName, idx, compressionExtensions[compression])
+}
+
+// ParseLogFileName parses the name of a file written by a FileRotator with the
+// given base file name. It returns the index of the file and the compression
+// of the file, which is empty for uncompressed files. If the name does not
+// belong to the base file name, ok is false.
+func ParseLogFileName(baseFileName, name string) (idx int, compression string, ok bool) {
+	idxStr, found := strings.CutPrefix(name, baseFileName+".")
+	if !found {
+		return 0, "", false
+	}
+
+	for c, ext := range compressionExtensions {
+		if trimmed, found := strings.CutSuffix(idxStr, ext); found {
+			idxStr = trimmed
+			compression = c
+			break
+		}
+	}
+
+	idx, err := strconv.Atoi(idxStr)
+	if err != nil || idx < 0 {
+		return 0, "", false
+	}
+	return idx, compression, true
+}
+
+// NewDecompressReader returns a reader of the decompressed contents of a log
+// file compressed with the given compression.
+func NewDecompressReader(compression string, r io.Reader) (io.ReadCloser, error) {
+	switch compression {
+	case CompressionGzip:
+		return gzip.NewReader(r)
+	case CompressionZstd:
+		dec, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return dec.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("unsupported log compression %q", compression)
+	}
+}
+
+// UncompressedSize returns the size of the decompressed contents of a log
+// file of the given compressed size. The size is read from the gzip trailer
+// or the zstd frame header written when the file was compressed, using
+// readAt to open the file at an offset.
+func UncompressedSize(compression string, size int64, readAt func(offset int64) (io.ReadCloser, error)) (int64, error) {
+	switch compression {
+	case CompressionGzip:
+		// The last 4 bytes of a gzip member are the uncompressed size, modulo
+		// 2^32. Rotated log files are well below that.
+		if size < 4 {
+			return 0, fmt.Errorf("gzip log file too small")
+		}
+		r, err := readAt(size - 4)
+		if err != nil {
+			return 0, err
+		}
+		defer r.Close()
+
+		var trailer [4]byte
+		if _, err := io.ReadFull(r, trailer[:]); err != nil {
+			return 0, err
+		}
+		return int64(binary.LittleEndian.Uint32(trailer[:])), nil
+
+	case CompressionZstd:
+		r, err := readAt(0)
+		if err != nil {
+			return 0, err
+		}
+		defer r.Close()
+
+		buf := make([]byte, zstd.HeaderMaxSize)
+		n, err := io.ReadFull(r, buf)
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return 0, err
+		}
+
+		var hdr zstd.Header
+		if err := hdr.Decode(buf[:n]); err != nil {
+			return 0, err
+		}
+		if hdr.HasFCS {
+			return int64(hdr.FrameContentSize), nil
+		}
+
+		// The content size is omitted from the header for files smaller than
+		// 256 bytes, which are cheap to decompress and count.
+		r2, err := readAt(0)
+		if err != nil {
+			return 0, err
+		}
+		defer r2.Close()
+
+		dec, err := NewDecompressReader(compression, r2)
+		if err != nil {
+			return 0, err
+		}
+		defer dec.Close()
+		return io.Copy(io.Discard, dec)
+
+	default:
+		return 0, fmt.Errorf("unsupported log compression %q", compression)
+	}
+}
+
+// compressFile writes a compressed copy of the log file at src next to it
+// and then removes src. The compressed file is written under a temporary
+// name that readers ignore and renamed into place once complete, so readers
+// always see either the uncompressed or the whole compressed file.
+func compressFile(src, compression string) error {
+	ext, ok := compressionExtensions[compression]
+	if !ok {
+		return fmt.Errorf("unsupported log compression %q", compression)
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	fi, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	dst := src + ext
+	tmp := filepath.Join(filepath.Dir(dst), "."+filepath.Base(dst)+".tmp")
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	defer out.Close()
+
+	var w io.WriteCloser
+	switch compression {
+	case CompressionGzip:
+		w = gzip.NewWriter(out)
+	case CompressionZstd:
+		enc, err := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return err
+		}
+		// Record the content size in the frame header so readers can find
+		// offsets without decompressing the file.
+		enc.ResetContentSize(out, fi.Size())
+		w = enc
+	}
+
+	if _, err := io.Copy(w, in); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		return err
+	}
+	return os.Remove(src)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logging
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestParseLogFileName(t *testing.T) {
+	cases := []struct {
+		name        string
+		idx         int
+		compression string
+		ok          bool
+	}{
+		{name: "redis.stdout.0", idx: 0, ok: true},
+		{name: "redis.stdout.12", idx: 12, ok: true},
+		{name: "redis.stdout.3.gz", idx: 3, compression: CompressionGzip, ok: true},
+		{name: "redis.stdout.4.zst", idx: 4, compression: CompressionZstd, ok: true},
+		{name: "redis.stderr.0"},
+		{name: "redis.stdout.gz"},
+		{name: "redis.stdout.1.tar"},
+		{name: ".redis.stdout.1.gz.tmp"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			idx, compression, ok := ParseLogFileName(baseFileName, tc.name)
+			must.Eq(t, tc.ok, ok)
+			must.Eq(t, tc.idx, idx)
+			must.Eq(t, tc.compression, compression)
+		})
+	}
+}
+
+func TestCompressFile(t *testing.T) {
+	for _, compression := range []string{CompressionGzip, CompressionZstd} {
+		for name, contents := range map[string]string{
+			"small": "a log line\n",
+			"large": strings.Repeat("a log line\n", 100),
+		} {
+			t.Run(compression+"/"+name, func(t *testing.T) {
+				path := t.TempDir()
+				src := filepath.Join(path, "redis.stdout.0")
+				must.NoError(t, os.WriteFile(src, []byte(contents), 0644))
+
+				must.NoError(t, compressFile(src, compression))
+				must.FileNotExists(t, src)
+
+				dst := src + compressionExtensions[compression]
+				b, err := readSegment(dst, compression)
+				must.NoError(t, err)
+				must.Eq(t, contents, string(b))
+
+				// The uncompressed size is available without the caller
+				// decompressing the file
+				fi, err := os.Stat(dst)
+				must.NoError(t, err)
+				size, err := UncompressedSize(compression, fi.Size(), func(offset int64) (io.ReadCloser, error) {
+					f, err := os.Open(dst)
+					if err != nil {
+						return nil, err
+					}
+					_, err = f.Seek(offset, io.SeekStart)
+					return f, err
+				})
+				must.NoError(t, err)
+				must.Eq(t, int64(len(contents)), size)
+
+				entries, err := os.ReadDir(path)
+				must.NoError(t, err)
+				must.Len(t, 1, entries)
+			})
+		}
+	}
+}
@@ -10,8 +10,6 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
-	"strconv"
-	"strings"
 	"sync"
 	"time"
 
@@ -35,11 +33,29 @@ const (
 	newLineDelimiter = '\n'
 )
 
+// FileRotatorOptions configures the optional rotation behaviours of a
+// FileRotator
+type FileRotatorOptions struct {
+	// Compression is the algorithm used to compress rotated files. Rotated
+	// files are left uncompressed if empty.
+	Compression string
+
+	// RotationInterval rotates the current file on the first write after it
+	// has been open for the interval. Zero disables time based rotation.
+	RotationInterval time.Duration
+
+	// MaxTotalSize caps the total size in bytes of the rotated files in a
+	// path. Zero disables the cap.
+	MaxTotalSize int64
+}
+
 // FileRotator writes bytes to a rotated set of files
 type FileRotator struct {
 	MaxFiles int   // MaxFiles is the maximum number of rotated files allowed in a path
 	FileSize int64 // FileSize is the size a rotated file is allowed to grow
 
+	FileRotatorOptions
+
 	path         string // path is the path on the file system where the rotated set of files are opened
 	baseFileName string // baseFileName is the base file name of the rotated files
 	logFileIdx   int    // logFileIdx is the current index of the rotated files
@@ -50,6 +66,7 @@ type FileRotator struct {
 
 	currentFile *os.File // currentFile is the file that is currently getting written
 	currentWr   int64    // currentWr is the number of bytes written to the current file
+	currentOpen time.Time
 	bufw        *bufio.Writer
 	bufLock     sync.Mutex
 
@@ -62,11 +79,24 @@ type FileRotator struct {
 // NewFileRotator returns a new file rotator
 func NewFileRotator(path string, baseFile string, maxFiles int,
 	fileSize int64, logger hclog.Logger) (*FileRotator, error) {
+	return NewFileRotatorWithOptions(path, baseFile, maxFiles, fileSize, FileRotatorOptions{}, logger)
+}
+
+// NewFileRotatorWithOptions returns a new file rotator with optional
+// compression, time based rotation or a total size cap
+func NewFileRotatorWithOptions(path string, baseFile string, maxFiles int,
+	fileSize int64, opts FileRotatorOptions, logger hclog.Logger) (*FileRotator, error) {
+	if _, ok := compressionExtensions[opts.Compression]; opts.Compression != "" && !ok {
+		return nil, fmt.Errorf("unsupported log compression %q", opts.Compression)
+	}
+
 	logger = logger.Named("rotator")
 	rotator := &FileRotator{
 		MaxFiles: maxFiles,
 		FileSize: fileSize,
 
+		FileRotatorOptions: opts,
+
 		path:         path,
 		baseFileName: baseFile,
 
@@ -79,6 +109,10 @@ func NewFileRotator(path string, baseFile string, maxFiles int,
 	if err := rotator.lastFile(); err != nil {
 		return nil, err
 	}
+	if rotator.purgeOnRotate() {
+		// Compress or remove files rotated before a restart
+		rotator.purgeCh <- struct{}{}
+	}
 	go rotator.purgeOldFiles()
 	go rotator.flushPeriodically()
 	return rotator, nil
@@ -93,7 +127,7 @@ func (f *FileRotator) Write(p []byte) (n int, err error) {
 	for n < len(p) {
 		// Check if we still have space in the current file, otherwise close and
 		// open the next file
-		if forceRotate || f.currentWr >= f.FileSize {
+		if forceRotate || f.currentWr >= f.FileSize || f.intervalElapsed() {
 			forceRotate = false
 			f.flushBuffer()
 			f.currentFile.Close()
@@ -165,12 +199,15 @@ func (f *FileRotator) nextFile() error {
 	nextFileIdx := f.logFileIdx
 	for {
 		nextFileIdx += 1
-		logFileName := filepath.Join(f.path, fmt.Sprintf("%s.%d", f.baseFileName, nextFileIdx))
+		logFileName := filepath.Join(f.path, rotatedFileName(f.baseFileName, nextFileIdx, ""))
 		if fi, err := os.Stat(logFileName); err == nil {
 			if fi.IsDir() || fi.Size() >= f.FileSize {
 				continue
 			}
 		}
+		if f.isCompressed(nextFileIdx) {
+			continue
+		}
 		f.logFileIdx = nextFileIdx
 		if err := f.createFile(); err != nil {
 			return err
@@ -180,7 +217,7 @@ func (f *FileRotator) nextFile() error {
 	// Purge old files if we have more files than MaxFiles
 	f.fileLock.Lock()
 	defer f.fileLock.Unlock()
-	if f.logFileIdx-f.oldestLogFileIdx >= f.MaxFiles && !f.closed {
+	if (f.purgeOnRotate() || f.logFileIdx-f.oldestLogFileIdx >= f.MaxFiles) && !f.closed {
 		select {
 		case f.purgeCh <- struct{}{}:
 		default:
@@ -196,20 +233,20 @@ func (f *FileRotator) lastFile() error {
 		return err
 	}
 
-	prefix := fmt.Sprintf("%s.", f.baseFileName)
 	for _, fi := range finfos {
 		if fi.IsDir() {
 			continue
 		}
-		if strings.HasPrefix(fi.Name(), prefix) {
-			fileIdx := strings.TrimPrefix(fi.Name(), prefix)
-			n, err := strconv.Atoi(fileIdx)
-			if err != nil {
-				continue
-			}
-			if n > f.logFileIdx {
-				f.logFileIdx = n
-			}
+		n, compression, ok := ParseLogFileName(f.baseFileName, fi.Name())
+		if !ok {
+			continue
+		}
+		if compression != "" {
+			// Compressed files are never written to again
+			n++
+		}
+		if n > f.logFileIdx {
+			f.logFileIdx = n
 		}
 	}
 	if err := f.createFile(); err != nil {
@@ -220,7 +257,7 @@ func (f *FileRotator) lastFile() error {
 
 // createFile opens a new or existing file for writing
 func (f *FileRotator) createFile() error {
-	logFileName := filepath.Join(f.path, fmt.Sprintf("%s.%d", f.baseFileName, f.logFileIdx))
+	logFileName := filepath.Join(f.path, rotatedFileName(f.baseFileName, f.logFileIdx, ""))
 	cFile, err := os.OpenFile(logFileName, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
 	if err != nil {
 		return err
@@ -232,6 +269,7 @@ func (f *FileRotator) createFile() error {
 		return err
 	}
 	f.currentWr = fi.Size()
+	f.currentOpen = time.Now()
 	f.createOrResetBuffer()
 	return nil
 }
@@ -270,51 +308,46 @@ func (f *FileRotator) Close() error {
 	return nil
 }
 
-// purgeOldFiles removes older files and keeps only the last N files rotated for
-// a file
+// purgeOldFiles compresses rotated files and removes older files, keeping
+// only the last N files rotated for a file and the total size of the rotated
+// files under the cap
 func (f *FileRotator) purgeOldFiles() {
 	for {
 		select {
 		case <-f.purgeCh:
-			var fIndexes []int
-			files, err := os.ReadDir(f.path)
+			segments, err := f.listSegments()
 			if err != nil {
 				f.logger.Error("error getting directory listing", "error", err)
 				return
 			}
-			// Inserting all the rotated files in a slice
-			for _, fi := range files {
-				if strings.HasPrefix(fi.Name(), f.baseFileName) {
-					fileIdx := strings.TrimPrefix(fi.Name(), fmt.Sprintf("%s.", f.baseFileName))
-					n, err := strconv.Atoi(fileIdx)
-					if err != nil {
-						f.logger.Error("error extracting file index", "error", err)
-						continue
-					}
-					fIndexes = append(fIndexes, n)
+
+			if f.Compression != "" {
+				f.compressSegments(segments)
+				if segments, err = f.listSegments(); err != nil {
+					f.logger.Error("error getting directory listing", "error", err)
+					return
 				}
 			}
 
 			// Not continuing to delete files if the number of files is not more
-			// than MaxFiles
-			if len(fIndexes) <= f.MaxFiles {
+			// than MaxFiles and the files fit in the total size
+			toDelete := f.segmentsToPurge(segments)
+			if len(toDelete) == 0 {
 				continue
 			}
 
-			// Sorting the file indexes so that we can purge the older files and keep
-			// only the number of files as configured by the user
-			sort.Ints(fIndexes)
-			toDelete := fIndexes[0 : len(fIndexes)-f.MaxFiles]
-			for _, fIndex := range toDelete {
-				fname := filepath.Join(f.path, fmt.Sprintf("%s.%d", f.baseFileName, fIndex))
-				err := os.RemoveAll(fname)
-				if err != nil {
-					f.logger.Error("error removing file", "filename", fname, "error", err)
+			for _, segment := range toDelete {
+				for _, name := range segment.names {
+					fname := filepath.Join(f.path, name)
+					err := os.RemoveAll(fname)
+					if err != nil {
+						f.logger.Error("error removing file", "filename", fname, "error", err)
+					}
 				}
 			}
 
 			f.fileLock.Lock()
-			f.oldestLogFileIdx = fIndexes[0]
+			f.oldestLogFileIdx = toDelete[len(toDelete)-1].idx + 1
 			f.fileLock.Unlock()
 		case <-f.doneCh:
 			return
@@ -322,6 +355,121 @@ func (f *FileRotator) purgeOldFiles() {
 	}
 }
 
+// logSegment is the set of files on disk for a single rotated file index.
+// An index can have both an uncompressed and a compressed file while it is
+// being compressed.
+type logSegment struct {
+	idx   int
+	names []string
+	plain bool
+	size  int64
+}
+
+// listSegments returns the rotated files in the path sorted by index
+func (f *FileRotator) listSegments() ([]*logSegment, error) {
+	files, err := os.ReadDir(f.path)
+	if err != nil {
+		return nil, err
+	}
+
+	byIdx := map[int]*logSegment{}
+	for _, fi := range files {
+		if fi.IsDir() {
+			continue
+		}
+		idx, compression, ok := ParseLogFileName(f.baseFileName, fi.Name())
+		if !ok {
+			continue
+		}
+		info, err := fi.Info()
+		if err != nil {
+			// The file was removed since listing the directory
+			continue
+		}
+
+		segment, ok := byIdx[idx]
+		if !ok {
+			segment = &logSegment{idx: idx}
+			byIdx[idx] = segment
+		}
+		segment.names = append(segment.names, fi.Name())
+		segment.size += info.Size()
+		if compression == "" {
+			segment.plain = true
+		}
+	}
+
+	segments := make([]*logSegment, 0, len(byIdx))
+	for _, segment := range byIdx {
+		segments = append(segments, segment)
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i].idx < segments[j].idx })
+	return segments, nil
+}
+
+// compressSegments compresses every uncompressed rotated file. The segment
+// with the highest index is the file currently being written and is skipped.
+func (f *FileRotator) compressSegments(segments []*logSegment) {
+	if len(segments) == 0 {
+		return
+	}
+	for _, segment := range segments[:len(segments)-1] {
+		if !segment.plain {
+			continue
+		}
+		fname := filepath.Join(f.path, rotatedFileName(f.baseFileName, segment.idx, ""))
+		if err := compressFile(fname, f.Compression); err != nil {
+			f.logger.Error("error compressing file", "filename", fname, "error", err)
+		}
+	}
+}
+
+// segmentsToPurge returns the oldest segments that must be removed to keep
+// MaxFiles segments and, if set, MaxTotalSize bytes. The file currently being
+// written is never purged.
+func (f *FileRotator) segmentsToPurge(segments []*logSegment) []*logSegment {
+	n := 0
+	if len(segments) > f.MaxFiles {
+		n = len(segments) - f.MaxFiles
+	}
+
+	if f.MaxTotalSize > 0 {
+		var total int64
+		for _, segment := range segments[n:] {
+			total += segment.size
+		}
+		for ; total > f.MaxTotalSize && n < len(segments)-1; n++ {
+			total -= segments[n].size
+		}
+	}
+
+	return segments[:n]
+}
+
+// purgeOnRotate returns true if every rotation needs to compress or purge
+// rotated files
+func (f *FileRotator) purgeOnRotate() bool {
+	return f.Compression != "" || f.MaxTotalSize > 0
+}
+
+// intervalElapsed returns true if the current file has been open for longer
+// than the rotation interval and has been written to
+func (f *FileRotator) intervalElapsed() bool {
+	return f.RotationInterval > 0 && f.currentWr > 0 &&
+		time.Since(f.currentOpen) >= f.RotationInterval
+}
+
+// isCompressed returns true if a compressed file exists for the index
+func (f *FileRotator) isCompressed(idx int) bool {
+	for compression := range compressionExtensions {
+		fname := filepath.Join(f.path, rotatedFileName(f.baseFileName, idx, compression))
+		if _, err := os.Stat(fname); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
 // flushBuffer flushes the buffer
 func (f *FileRotator) flushBuffer() error {
 	f.bufLock.Lock()
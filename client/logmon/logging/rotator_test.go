@@ -5,10 +5,13 @@ package logging
 
 import (
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/testutil"
@@ -275,6 +278,151 @@ func TestFileRotator_PurgeOldFiles(t *testing.T) {
 	})
 }
 
+func TestFileRotator_Compression(t *testing.T) {
+	for _, compression := range []string{CompressionGzip, CompressionZstd} {
+		t.Run(compression, func(t *testing.T) {
+			defer goleak.VerifyNone(t)
+
+			path := t.TempDir()
+
+			fr, err := NewFileRotatorWithOptions(path, baseFileName, 10, 5,
+				FileRotatorOptions{Compression: compression}, testlog.HCLogger(t))
+			must.NoError(t, err)
+			defer fr.Close()
+
+			str := "abcd\nefgh\nijkl\nmn"
+			nw, err := fr.Write([]byte(str))
+			must.NoError(t, err)
+			must.Eq(t, len(str), nw)
+			must.NoError(t, fr.flushBuffer())
+
+			// Every file but the current one is compressed and the
+			// segments decompress back to the written data
+			testutil.WaitForResult(func() (bool, error) {
+				files, err := os.ReadDir(path)
+				if err != nil {
+					return false, err
+				}
+				if len(files) != 4 {
+					return false, fmt.Errorf("expected 4 files, got: %v", files)
+				}
+
+				var contents []byte
+				for i, fi := range files {
+					idx, c, ok := ParseLogFileName(baseFileName, fi.Name())
+					if !ok || idx != i {
+						return false, fmt.Errorf("unexpected file %q", fi.Name())
+					}
+					if i < len(files)-1 && c != compression {
+						return false, fmt.Errorf("file %q not compressed", fi.Name())
+					}
+
+					b, err := readSegment(filepath.Join(path, fi.Name()), c)
+					if err != nil {
+						return false, err
+					}
+					contents = append(contents, b...)
+				}
+				if string(contents) != str {
+					return false, fmt.Errorf("expected %q, got %q", str, contents)
+				}
+				return true, nil
+			}, func(err error) {
+				must.NoError(t, err)
+			})
+
+			// Reopening the rotator continues after the compressed files
+			must.NoError(t, fr.Close())
+			fr, err = NewFileRotatorWithOptions(path, baseFileName, 10, 5,
+				FileRotatorOptions{Compression: compression}, testlog.HCLogger(t))
+			must.NoError(t, err)
+			defer fr.Close()
+			must.Eq(t, filepath.Join(path, "redis.stdout.3"), fr.currentFile.Name())
+		})
+	}
+}
+
+func TestFileRotator_RotationInterval(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path := t.TempDir()
+
+	fr, err := NewFileRotatorWithOptions(path, baseFileName, 10, 1024,
+		FileRotatorOptions{RotationInterval: 50 * time.Millisecond}, testlog.HCLogger(t))
+	must.NoError(t, err)
+	defer fr.Close()
+
+	_, err = fr.Write([]byte("abc\n"))
+	must.NoError(t, err)
+	must.Eq(t, filepath.Join(path, "redis.stdout.0"), fr.currentFile.Name())
+
+	// The first write after the interval elapses rotates the file
+	time.Sleep(100 * time.Millisecond)
+	_, err = fr.Write([]byte("def\n"))
+	must.NoError(t, err)
+	must.Eq(t, filepath.Join(path, "redis.stdout.1"), fr.currentFile.Name())
+
+	_, err = fr.Write([]byte("ghi\n"))
+	must.NoError(t, err)
+	must.Eq(t, filepath.Join(path, "redis.stdout.1"), fr.currentFile.Name())
+}
+
+func TestFileRotator_MaxTotalSize(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path := t.TempDir()
+
+	fr, err := NewFileRotatorWithOptions(path, baseFileName, 10, 5,
+		FileRotatorOptions{MaxTotalSize: 9}, testlog.HCLogger(t))
+	must.NoError(t, err)
+	defer fr.Close()
+
+	str := "abcd\nefgh\nijkl\nmnop\nqr"
+	nw, err := fr.Write([]byte(str))
+	must.NoError(t, err)
+	must.Eq(t, len(str), nw)
+	must.NoError(t, fr.flushBuffer())
+
+	// Only the newest files fitting in the total size are kept
+	testutil.WaitForResult(func() (bool, error) {
+		files, err := os.ReadDir(path)
+		if err != nil {
+			return false, err
+		}
+
+		var names []string
+		for _, fi := range files {
+			names = append(names, fi.Name())
+		}
+		expected := []string{"redis.stdout.3", "redis.stdout.4"}
+		if !slices.Equal(expected, names) {
+			return false, fmt.Errorf("expected files %v, got: %v", expected, names)
+		}
+		return true, nil
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+}
+
+// readSegment returns the decompressed contents of a rotated file
+func readSegment(path, compression string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if compression == "" {
+		return io.ReadAll(f)
+	}
+	r, err := NewDecompressReader(compression, f)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
 func BenchmarkRotator(b *testing.B) {
 	kb := 1024
 	for _, inputSize := range []int{kb, 2 * kb, 4 * kb, 8 * kb, 16 * kb, 32 * kb, 64 * kb, 128 * kb, 256 * kb} {
@@ -14,6 +14,7 @@ import (
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/lib/fifo"
 	"github.com/hashicorp/nomad/client/logmon/logging"
+	"github.com/hashicorp/nomad/client/logmon/sink"
 )
 
 const (
@@ -44,6 +45,23 @@ type LogConfig struct {
 
 	// MaxFileSizeMB is the max log file size in MB allowed before rotation occures
 	MaxFileSizeMB int
+
+	// Compression is the algorithm used to compress rotated log files
+	Compression string
+
+	// RotationInterval is the interval after which the log file is rotated
+	// regardless of its size
+	RotationInterval time.Duration
+
+	// MaxTotalSizeMB is the max total size in MB of the rotated log files
+	MaxTotalSizeMB int
+
+	// Sinks are the sinks log lines are shipped to in addition to the log
+	// files
+	Sinks []*sink.Config
+
+	// SinkMetadata tags the log lines shipped to the sinks
+	SinkMetadata map[string]string
 }
 
 type LogMon interface {
@@ -109,6 +127,9 @@ type TaskLogger struct {
 
 	// rotator for stderr
 	lre *logRotatorWrapper
+
+	// shippers send the log lines of both streams to the sinks
+	shippers []*sink.Shipper
 }
 
 // IsRunning will return true as long as one rotator wrapper is still running
@@ -136,33 +157,64 @@ func (tl *TaskLogger) Close() {
 		}()
 	}
 	wg.Wait()
+
+	// The shippers are closed once both streams stopped writing to them
+	for _, s := range tl.shippers {
+		s.Close()
+	}
 }
 
 func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 	tl := &TaskLogger{config: cfg}
 
 	logFileSize := int64(cfg.MaxFileSizeMB * 1024 * 1024)
-	lro, err := logging.NewFileRotator(cfg.LogDir, cfg.StdoutLogFile,
-		cfg.MaxFiles, logFileSize, logger)
+	rotatorOpts := logging.FileRotatorOptions{
+		Compression:      cfg.Compression,
+		RotationInterval: cfg.RotationInterval,
+		MaxTotalSize:     int64(cfg.MaxTotalSizeMB) * 1024 * 1024,
+	}
+	lro, err := logging.NewFileRotatorWithOptions(cfg.LogDir, cfg.StdoutLogFile,
+		cfg.MaxFiles, logFileSize, rotatorOpts, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create stdout logfile for %q: %v", cfg.StdoutLogFile, err)
 	}
 
-	wrapperOut, err := newLogRotatorWrapper(cfg.StdoutFifo, logger, lro)
+	// A sink that cannot be created must not prevent the task from logging
+	for _, sinkCfg := range cfg.Sinks {
+		shipper, err := sink.NewShipper(sinkCfg, cfg.SinkMetadata, logger)
+		if err != nil {
+			logger.Error("failed to create log sink", "sink", sinkCfg.Name, "error", err)
+			continue
+		}
+		tl.shippers = append(tl.shippers, shipper)
+	}
+
+	// The shippers must be closed if the task logger can't be created, to
+	// stop their goroutines and close their connections
+	closeShippers := func() {
+		for _, s := range tl.shippers {
+			s.Close()
+		}
+	}
+
+	wrapperOut, err := newLogRotatorWrapper(cfg.StdoutFifo, logger, tl.sinkWriter(lro, "stdout"))
 	if err != nil {
+		closeShippers()
 		return nil, err
 	}
 
 	tl.lro = wrapperOut
 
-	lre, err := logging.NewFileRotator(cfg.LogDir, cfg.StderrLogFile,
-		cfg.MaxFiles, logFileSize, logger)
+	lre, err := logging.NewFileRotatorWithOptions(cfg.LogDir, cfg.StderrLogFile,
+		cfg.MaxFiles, logFileSize, rotatorOpts, logger)
 	if err != nil {
+		closeShippers()
 		return nil, fmt.Errorf("failed to create stderr logfile for %q: %v", cfg.StderrLogFile, err)
 	}
 
-	wrapperErr, err := newLogRotatorWrapper(cfg.StderrFifo, logger, lre)
+	wrapperErr, err := newLogRotatorWrapper(cfg.StderrFifo, logger, tl.sinkWriter(lre, "stderr"))
 	if err != nil {
+		closeShippers()
 		return nil, err
 	}
 
@@ -172,6 +224,15 @@ func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 
 }
 
+// sinkWriter returns a writer that ships the lines written to the rotator to
+// the sinks, or the rotator itself if there are no sinks.
+func (tl *TaskLogger) sinkWriter(rotator io.WriteCloser, stream string) io.WriteCloser {
+	if len(tl.shippers) == 0 {
+		return rotator
+	}
+	return sink.NewWriter(rotator, stream, tl.shippers)
+}
+
 // logRotatorWrapper wraps our log rotator and exposes a pipe that can feed the
 // log rotator data. The processOutWriter should be attached to the process and
 // data will be copied from the reader to the rotator.
@@ -5,14 +5,18 @@ package logmon
 
 import (
 	"crypto/rand"
+	"encoding/json"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/lib/fifo"
+	"github.com/hashicorp/nomad/client/logmon/sink"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/testutil"
@@ -304,3 +308,86 @@ func TestLogmon_NewError(t *testing.T) {
 	must.Error(t, err)
 	must.Nil(t, w)
 }
+
+// asserts that log lines are shipped to the sinks in addition to the log files
+func TestLogmon_Start_sinks(t *testing.T) {
+	ci.Parallel(t)
+
+	if runtime.GOOS == "windows" {
+		t.Skip("windows does not support unix sockets")
+	}
+
+	dir := t.TempDir()
+	stdoutFifoPath := filepath.Join(dir, "stdout.fifo")
+	stderrFifoPath := filepath.Join(dir, "stderr.fifo")
+
+	sockPath := filepath.Join(dir, "sink.sock")
+	ln, err := net.Listen("unix", sockPath)
+	must.NoError(t, err)
+	defer ln.Close()
+
+	received := make(chan map[string]any, 2)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		dec := json.NewDecoder(conn)
+		for {
+			var line map[string]any
+			if err := dec.Decode(&line); err != nil {
+				return
+			}
+			received <- line
+		}
+	}()
+
+	cfg := &LogConfig{
+		LogDir:        dir,
+		StdoutLogFile: "stdout",
+		StdoutFifo:    stdoutFifoPath,
+		StderrLogFile: "stderr",
+		StderrFifo:    stderrFifoPath,
+		MaxFiles:      2,
+		MaxFileSizeMB: 1,
+		Sinks: []*sink.Config{{
+			Name:    "local",
+			Type:    sink.TypeUnix,
+			Address: sockPath,
+		}},
+		SinkMetadata: map[string]string{sink.MetaTask: "web"},
+	}
+
+	lm := NewLogMon(testlog.HCLogger(t))
+	must.NoError(t, lm.Start(cfg))
+
+	stdout, err := fifo.OpenWriter(stdoutFifoPath)
+	must.NoError(t, err)
+	_, err = stdout.Write([]byte("hello\n"))
+	must.NoError(t, err)
+
+	select {
+	case line := <-received:
+		must.Eq(t, "stdout", line["stream"])
+		must.Eq(t, "hello", line["message"])
+		must.Eq[any](t, map[string]any{sink.MetaTask: "web"}, line["metadata"])
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for shipped log line")
+	}
+
+	// The line is still written to the log file
+	testutil.WaitForResult(func() (bool, error) {
+		b, err := os.ReadFile(filepath.Join(dir, "stdout.0"))
+		if err != nil {
+			return false, err
+		}
+		return string(b) == "hello\n", fmt.Errorf("unexpected log file contents %q", b)
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+
+	must.NoError(t, stdout.Close())
+	must.NoError(t, lm.Stop())
+}
@@ -5,9 +5,11 @@ package logmon
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/client/logmon/proto"
+	"github.com/hashicorp/nomad/client/logmon/sink"
 )
 
 type logmonServer struct {
@@ -17,13 +19,27 @@ type logmonServer struct {
 
 func (s *logmonServer) Start(ctx context.Context, req *proto.StartRequest) (*proto.StartResponse, error) {
 	cfg := &LogConfig{
-		LogDir:        req.LogDir,
-		StdoutLogFile: req.StdoutFileName,
-		StderrLogFile: req.StderrFileName,
-		MaxFiles:      int(req.MaxFiles),
-		MaxFileSizeMB: int(req.MaxFileSizeMb),
-		StdoutFifo:    req.StdoutFifo,
-		StderrFifo:    req.StderrFifo,
+		LogDir:           req.LogDir,
+		StdoutLogFile:    req.StdoutFileName,
+		StderrLogFile:    req.StderrFileName,
+		MaxFiles:         int(req.MaxFiles),
+		MaxFileSizeMB:    int(req.MaxFileSizeMb),
+		StdoutFifo:       req.StdoutFifo,
+		StderrFifo:       req.StderrFifo,
+		Compression:      req.Compression,
+		RotationInterval: time.Duration(req.RotationInterval),
+		MaxTotalSizeMB:   int(req.MaxTotalSizeMb),
+		SinkMetadata:     req.SinkMetadata,
+	}
+	for _, s := range req.Sinks {
+		cfg.Sinks = append(cfg.Sinks, &sink.Config{
+			Name:       s.Name,
+			Type:       s.Type,
+			Address:    s.Address,
+			Facility:   s.Facility,
+			Headers:    s.Headers,
+			BufferSize: int(s.BufferSize),
+		})
 	}
 
 	err := s.impl.Start(cfg)
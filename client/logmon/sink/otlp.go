@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+const (
+	// otlpLogsPath is the default path of the OTLP/HTTP logs endpoint
+	otlpLogsPath = "/v1/logs"
+
+	// otlpAttributePrefix namespaces the metadata attributes
+	otlpAttributePrefix = "nomad."
+
+	otlpSeverityInfo  = 9
+	otlpSeverityError = 17
+)
+
+// otlpSink sends records to an OTLP/HTTP logs endpoint using the JSON
+// encoding of the OTLP protocol.
+type otlpSink struct {
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+	resource otlpResource
+}
+
+// The types below are the subset of the OTLP logs data model needed to ship
+// log lines, following the JSON encoding of the protobuf messages.
+
+type otlpRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano   string         `json:"timeUnixNano"`
+	SeverityNumber int            `json:"severityNumber"`
+	SeverityText   string         `json:"severityText"`
+	Body           otlpAnyValue   `json:"body"`
+	Attributes     []otlpKeyValue `json:"attributes"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+func newOTLPSink(cfg *Config, metadata map[string]string) (*otlpSink, error) {
+	u, err := url.Parse(cfg.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %v", err)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = otlpLogsPath
+	}
+
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var resource otlpResource
+	for _, k := range keys {
+		resource.Attributes = append(resource.Attributes, otlpKeyValue{
+			Key:   otlpAttributePrefix + k,
+			Value: otlpAnyValue{StringValue: metadata[k]},
+		})
+	}
+
+	return &otlpSink{
+		endpoint: u.String(),
+		headers:  cfg.Headers,
+		client:   &http.Client{Timeout: sinkTimeout},
+		resource: resource,
+	}, nil
+}
+
+func (s *otlpSink) send(records []*Record) error {
+	logRecords := make([]otlpLogRecord, 0, len(records))
+	for _, r := range records {
+		severity, severityText := otlpSeverityInfo, "INFO"
+		if r.Stream == "stderr" {
+			severity, severityText = otlpSeverityError, "ERROR"
+		}
+		logRecords = append(logRecords, otlpLogRecord{
+			TimeUnixNano:   strconv.FormatInt(r.Time.UnixNano(), 10),
+			SeverityNumber: severity,
+			SeverityText:   severityText,
+			Body:           otlpAnyValue{StringValue: string(r.Message)},
+			Attributes: []otlpKeyValue{{
+				Key:   "log.iostream",
+				Value: otlpAnyValue{StringValue: r.Stream},
+			}},
+		})
+	}
+
+	body, err := json.Marshal(&otlpRequest{
+		ResourceLogs: []otlpResourceLogs{{
+			Resource: s.resource,
+			ScopeLogs: []otlpScopeLogs{{
+				Scope:      otlpScope{Name: "nomad"},
+				LogRecords: logRecords,
+			}},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *otlpSink) close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,252 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package sink
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/helper"
+)
+
+const (
+	// maxBatchSize is the maximum number of records sent to a sink at once
+	maxBatchSize = 512
+
+	// maxLineSize is the size at which a line without a newline is shipped
+	// as a record of its own
+	maxLineSize = 64 * 1024
+
+	// closeTimeout is how long closing a shipper waits for buffered records
+	// to be sent
+	closeTimeout = 5 * time.Second
+
+	// warnInterval limits how often a failing sink is logged
+	warnInterval = 30 * time.Second
+)
+
+// Shipper buffers records and sends them to a sink in the background. Records
+// are dropped when the buffer is full, so shipping never blocks the task.
+type Shipper struct {
+	name   string
+	sink   sink
+	logger hclog.Logger
+
+	queue   chan *Record
+	dropped atomic.Uint64
+
+	closed   bool
+	lock     sync.RWMutex
+	lastWarn time.Time
+
+	// stopCh is closed to stop sending the buffered records, and doneCh once
+	// run has returned
+	stopCh       chan struct{}
+	doneCh       chan struct{}
+	closeTimeout time.Duration
+}
+
+// NewShipper returns a shipper for the sink config. The metadata tags every
+// record shipped.
+func NewShipper(cfg *Config, metadata map[string]string, logger hclog.Logger) (*Shipper, error) {
+	s, err := newSink(cfg, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	size := cfg.BufferSize
+	if size <= 0 {
+		size = DefaultBufferSize
+	}
+
+	shipper := &Shipper{
+		name:         cfg.Name,
+		sink:         s,
+		logger:       logger.Named("sink").With("sink", cfg.Name),
+		queue:        make(chan *Record, size),
+		stopCh:       make(chan struct{}),
+		doneCh:       make(chan struct{}),
+		closeTimeout: closeTimeout,
+	}
+	go shipper.run()
+	return shipper, nil
+}
+
+// Ship queues the record to be sent to the sink. It returns false if the
+// record was dropped because the buffer is full or the shipper is closed.
+func (s *Shipper) Ship(r *Record) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.closed {
+		return false
+	}
+
+	select {
+	case s.queue <- r:
+		return true
+	default:
+		s.dropped.Add(1)
+		return false
+	}
+}
+
+// Close stops accepting records and waits a bounded amount of time for the
+// buffered records to be sent. Once it times out, the remaining records are
+// dropped and it waits for the batch being sent, whose send is bounded by the
+// sink's timeout, before closing the sink.
+func (s *Shipper) Close() {
+	s.lock.Lock()
+	if s.closed {
+		s.lock.Unlock()
+		return
+	}
+	s.closed = true
+	close(s.queue)
+	s.lock.Unlock()
+
+	timer, stop := helper.NewSafeTimer(s.closeTimeout)
+	defer stop()
+
+	select {
+	case <-s.doneCh:
+	case <-timer.C:
+		s.logger.Warn("timed out sending buffered log lines")
+		close(s.stopCh)
+		<-s.doneCh
+	}
+	if err := s.sink.close(); err != nil {
+		s.logger.Debug("error closing sink", "error", err)
+	}
+}
+
+// run sends the queued records to the sink in batches until the queue is
+// closed or the shipper is stopped.
+func (s *Shipper) run() {
+	defer close(s.doneCh)
+
+	for r := range s.queue {
+		select {
+		case <-s.stopCh:
+			return
+		default:
+		}
+
+		batch := []*Record{r}
+	DRAIN:
+		for len(batch) < maxBatchSize {
+			select {
+			case r, ok := <-s.queue:
+				if !ok {
+					break DRAIN
+				}
+				batch = append(batch, r)
+			default:
+				break DRAIN
+			}
+		}
+
+		if err := s.sink.send(batch); err != nil {
+			s.dropped.Add(uint64(len(batch)))
+			s.warn("failed to send log lines", "error", err)
+		} else if dropped := s.dropped.Load(); dropped > 0 {
+			s.warn("sink is not keeping up with the task's logs")
+		}
+	}
+}
+
+// warn logs the message and the number of dropped records since the last
+// warning, at most once per warnInterval.
+func (s *Shipper) warn(msg string, args ...any) {
+	if time.Since(s.lastWarn) < warnInterval {
+		return
+	}
+	s.lastWarn = time.Now()
+	args = append(args, "dropped", s.dropped.Swap(0))
+	s.logger.Warn(msg, args...)
+}
+
+// Writer is an io.WriteCloser that passes writes through to the underlying
+// writer and ships every line written to the shippers.
+type Writer struct {
+	w        io.WriteCloser
+	stream   string
+	shippers []*Shipper
+
+	// partial is the data written after the last newline
+	partial []byte
+	lock    sync.Mutex
+}
+
+// NewWriter returns a writer that passes writes through to w and ships the
+// lines of the stream to the shippers.
+func NewWriter(w io.WriteCloser, stream string, shippers []*Shipper) *Writer {
+	return &Writer{
+		w:        w,
+		stream:   stream,
+		shippers: shippers,
+	}
+}
+
+// Write writes p to the underlying writer and ships the lines completed by
+// the written bytes.
+func (w *Writer) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	now := time.Now()
+	data := p[:n]
+	for len(data) > 0 {
+		idx := bytes.IndexByte(data, '\n')
+		if idx < 0 {
+			w.partial = append(w.partial, data...)
+			if len(w.partial) >= maxLineSize {
+				w.ship(now, w.partial)
+				w.partial = nil
+			}
+			break
+		}
+
+		line := data[:idx]
+		if len(w.partial) > 0 {
+			line = append(w.partial, line...)
+			w.partial = nil
+		}
+		w.ship(now, line)
+		data = data[idx+1:]
+	}
+
+	return n, err
+}
+
+// Close ships any partial line and closes the underlying writer.
+func (w *Writer) Close() error {
+	w.lock.Lock()
+	if len(w.partial) > 0 {
+		w.ship(time.Now(), w.partial)
+		w.partial = nil
+	}
+	w.lock.Unlock()
+
+	return w.w.Close()
+}
+
+func (w *Writer) ship(now time.Time, line []byte) {
+	line = bytes.TrimSuffix(line, []byte{'\r'})
+
+	// The record outlives the caller's buffer
+	r := &Record{
+		Time:    now,
+		Stream:  w.stream,
+		Message: bytes.Clone(line),
+	}
+	for _, s := range w.shippers {
+		s.Ship(r)
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package sink
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+// testSink records the records sent to it and blocks sends until unblocked
+type testSink struct {
+	lock    sync.Mutex
+	records []*Record
+	blockCh chan struct{}
+
+	// closed is set once the sink is closed, and sendAfterClose if a send
+	// completed after it was
+	closed         bool
+	sendAfterClose bool
+}
+
+func (s *testSink) send(records []*Record) error {
+	if s.blockCh != nil {
+		<-s.blockCh
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.records = append(s.records, records...)
+	s.sendAfterClose = s.sendAfterClose || s.closed
+	return nil
+}
+
+func (s *testSink) close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.closed = true
+	return nil
+}
+
+func (s *testSink) isClosed() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.closed
+}
+
+func (s *testSink) messages() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var msgs []string
+	for _, r := range s.records {
+		msgs = append(msgs, r.Stream+":"+string(r.Message))
+	}
+	return msgs
+}
+
+func newTestShipper(t *testing.T, s sink, size int) *Shipper {
+	shipper := &Shipper{
+		name:         "test",
+		sink:         s,
+		logger:       testlog.HCLogger(t),
+		queue:        make(chan *Record, size),
+		stopCh:       make(chan struct{}),
+		doneCh:       make(chan struct{}),
+		closeTimeout: closeTimeout,
+	}
+	go shipper.run()
+	return shipper
+}
+
+type nopCloser struct{ bytes.Buffer }
+
+func (*nopCloser) Close() error { return nil }
+
+func TestWriter_ShipsLines(t *testing.T) {
+	ci.Parallel(t)
+
+	s := &testSink{}
+	shipper := newTestShipper(t, s, 16)
+
+	var out nopCloser
+	w := NewWriter(&out, "stdout", []*Shipper{shipper})
+
+	for _, p := range []string{"first line\nsec", "ond line\r\n", "\nunterminated"} {
+		n, err := w.Write([]byte(p))
+		must.NoError(t, err)
+		must.Eq(t, len(p), n)
+	}
+	must.NoError(t, w.Close())
+	shipper.Close()
+
+	// Writes pass through unchanged
+	must.Eq(t, "first line\nsecond line\r\n\nunterminated", out.String())
+	must.Eq(t, []string{
+		"stdout:first line",
+		"stdout:second line",
+		"stdout:",
+		"stdout:unterminated",
+	}, s.messages())
+}
+
+func TestWriter_LongLine(t *testing.T) {
+	ci.Parallel(t)
+
+	s := &testSink{}
+	shipper := newTestShipper(t, s, 16)
+
+	var out nopCloser
+	w := NewWriter(&out, "stderr", []*Shipper{shipper})
+
+	long := strings.Repeat("a", maxLineSize)
+	_, err := w.Write([]byte(long + "b\n"))
+	must.NoError(t, err)
+	must.NoError(t, w.Close())
+	shipper.Close()
+
+	must.Eq(t, []string{"stderr:" + long + "b"}, s.messages())
+
+	// Lines without a newline are shipped once they reach the max size
+	s = &testSink{}
+	shipper = newTestShipper(t, s, 16)
+	w = NewWriter(&out, "stderr", []*Shipper{shipper})
+	_, err = w.Write([]byte(long))
+	must.NoError(t, err)
+	_, err = w.Write([]byte("b\n"))
+	must.NoError(t, err)
+	must.NoError(t, w.Close())
+	shipper.Close()
+
+	must.Eq(t, []string{"stderr:" + long, "stderr:b"}, s.messages())
+}
+
+func TestShipper_DropsWhenFull(t *testing.T) {
+	ci.Parallel(t)
+
+	s := &testSink{blockCh: make(chan struct{})}
+	shipper := newTestShipper(t, s, 2)
+
+	// The first record is taken by the blocked sender, the next two fill the
+	// buffer and the rest are dropped without blocking
+	must.True(t, shipper.Ship(&Record{Stream: "stdout", Message: []byte("0")}))
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return len(shipper.queue) == 0 }),
+		wait.Timeout(5*time.Second),
+	))
+	must.True(t, shipper.Ship(&Record{Stream: "stdout", Message: []byte("1")}))
+	must.True(t, shipper.Ship(&Record{Stream: "stdout", Message: []byte("2")}))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			shipper.Ship(&Record{Stream: "stdout", Message: []byte("dropped")})
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("shipping blocked on a slow sink")
+	}
+	must.Eq(t, 100, shipper.dropped.Load())
+
+	close(s.blockCh)
+	shipper.Close()
+	must.Eq(t, []string{"stdout:0", "stdout:1", "stdout:2"}, s.messages())
+
+	// Records shipped after closing are dropped
+	must.False(t, shipper.Ship(&Record{Stream: "stdout", Message: []byte("closed")}))
+}
+
+func TestShipper_CloseTimeout(t *testing.T) {
+	ci.Parallel(t)
+
+	s := &testSink{blockCh: make(chan struct{})}
+	shipper := newTestShipper(t, s, 2)
+	shipper.closeTimeout = 50 * time.Millisecond
+
+	// The first record is taken by the blocked sender and the second is
+	// buffered
+	must.True(t, shipper.Ship(&Record{Stream: "stdout", Message: []byte("0")}))
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return len(shipper.queue) == 0 }),
+		wait.Timeout(5*time.Second),
+	))
+	must.True(t, shipper.Ship(&Record{Stream: "stdout", Message: []byte("1")}))
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		shipper.Close()
+	}()
+
+	// The sink isn't closed while a batch is being sent, even once closing
+	// has timed out
+	time.Sleep(200 * time.Millisecond)
+	must.False(t, s.isClosed())
+
+	close(s.blockCh)
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("closing did not return once the send completed")
+	}
+	must.True(t, s.isClosed())
+	must.False(t, s.sendAfterClose)
+
+	// The buffered record is dropped once closing times out
+	must.Eq(t, []string{"stdout:0"}, s.messages())
+}
@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package sink ships the lines a task writes to stdout and stderr to external
+// log collectors, in addition to the log files written by logmon.
+package sink
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	// TypeSyslog ships log lines as RFC5424 syslog messages
+	TypeSyslog = "syslog"
+
+	// TypeOTLP ships log lines to an OTLP/HTTP logs endpoint
+	TypeOTLP = "otlp"
+
+	// TypeUnix ships log lines as newline delimited JSON to a unix socket
+	TypeUnix = "unix"
+
+	// DefaultBufferSize is the default number of log lines buffered for a
+	// sink before lines are dropped
+	DefaultBufferSize = 1024
+
+	// sinkTimeout is the timeout for connecting and sending a batch of log
+	// lines to a sink
+	sinkTimeout = 10 * time.Second
+)
+
+// Metadata keys that tag the log lines of a task. Sinks may prefix or
+// otherwise transform the keys to fit their format.
+const (
+	MetaNamespace = "namespace"
+	MetaJob       = "job"
+	MetaGroup     = "group"
+	MetaTask      = "task"
+	MetaAllocID   = "alloc_id"
+)
+
+// Config configures a sink that task log lines are shipped to.
+type Config struct {
+	// Name is the unique name of the sink
+	Name string
+
+	// Type is the type of the sink: syslog, otlp or unix
+	Type string
+
+	// Address is the address of the sink. For syslog sinks it is a URL with
+	// a tcp, udp, unix or unixgram scheme. For otlp sinks it is the http or
+	// https URL of the logs endpoint. For unix sinks it is the path to the
+	// socket.
+	Address string
+
+	// Facility is the syslog facility of the messages of syslog sinks
+	Facility string
+
+	// Headers are added to the requests made to otlp sinks
+	Headers map[string]string
+
+	// BufferSize is the number of log lines buffered for the sink. Lines are
+	// dropped once the buffer is full so that a slow sink never blocks the
+	// task.
+	BufferSize int
+}
+
+// Copy returns a deep copy of the sink config.
+func (c *Config) Copy() *Config {
+	if c == nil {
+		return nil
+	}
+	nc := new(Config)
+	*nc = *c
+	if c.Headers != nil {
+		nc.Headers = make(map[string]string, len(c.Headers))
+		for k, v := range c.Headers {
+			nc.Headers[k] = v
+		}
+	}
+	return nc
+}
+
+// Validate returns an error if the sink config is invalid.
+func (c *Config) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("log sink name must not be empty")
+	}
+	if c.Address == "" {
+		return fmt.Errorf("log sink %q: address must not be empty", c.Name)
+	}
+	if c.BufferSize < 0 {
+		return fmt.Errorf("log sink %q: buffer_size must not be negative", c.Name)
+	}
+
+	switch c.Type {
+	case TypeSyslog:
+		if _, _, err := parseSyslogAddress(c.Address); err != nil {
+			return fmt.Errorf("log sink %q: %v", c.Name, err)
+		}
+		if _, err := syslogFacility(c.Facility); err != nil {
+			return fmt.Errorf("log sink %q: %v", c.Name, err)
+		}
+	case TypeOTLP:
+		u, err := url.Parse(c.Address)
+		if err != nil {
+			return fmt.Errorf("log sink %q: invalid address: %v", c.Name, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("log sink %q: address must be an http or https URL", c.Name)
+		}
+	case TypeUnix:
+	default:
+		return fmt.Errorf("log sink %q: unsupported type %q; must be one of %q, %q or %q",
+			c.Name, c.Type, TypeSyslog, TypeOTLP, TypeUnix)
+	}
+	return nil
+}
+
+// Record is a single log line written by a task.
+type Record struct {
+	// Time is when the line was written
+	Time time.Time
+
+	// Stream is the stream the line was written to: stdout or stderr
+	Stream string
+
+	// Message is the line without its trailing newline
+	Message []byte
+}
+
+// sink is a transport for log records.
+type sink interface {
+	// send delivers a batch of records to the sink
+	send(records []*Record) error
+
+	// close releases the resources of the sink
+	close() error
+}
+
+// newSink returns the transport for the sink config. The metadata tags
+// every record sent.
+func newSink(cfg *Config, metadata map[string]string) (sink, error) {
+	switch cfg.Type {
+	case TypeSyslog:
+		return newSyslogSink(cfg, metadata)
+	case TypeOTLP:
+		return newOTLPSink(cfg, metadata)
+	case TypeUnix:
+		return newUnixSink(cfg, metadata), nil
+	default:
+		return nil, fmt.Errorf("unsupported log sink type %q", cfg.Type)
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package sink
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+var testMetadata = map[string]string{
+	MetaNamespace: "default",
+	MetaJob:       "example",
+	MetaGroup:     "cache",
+	MetaTask:      "redis",
+	MetaAllocID:   "f0ba3b3e-6fa6-4a6b-8c2b-bd1c6e5c1b2d",
+}
+
+var testTime = time.Date(2024, 3, 1, 12, 30, 45, 123456789, time.UTC)
+
+func TestConfig_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		config *Config
+		expErr string
+	}{
+		{
+			name:   "syslog tcp",
+			config: &Config{Name: "s", Type: TypeSyslog, Address: "tcp://127.0.0.1:514", Facility: "local3"},
+		},
+		{
+			name:   "syslog unix",
+			config: &Config{Name: "s", Type: TypeSyslog, Address: "unix:///dev/log"},
+		},
+		{
+			name:   "syslog bad scheme",
+			config: &Config{Name: "s", Type: TypeSyslog, Address: "http://127.0.0.1:514"},
+			expErr: "address scheme must be one of",
+		},
+		{
+			name:   "syslog bad facility",
+			config: &Config{Name: "s", Type: TypeSyslog, Address: "udp://127.0.0.1:514", Facility: "local9"},
+			expErr: "unknown syslog facility",
+		},
+		{
+			name:   "otlp",
+			config: &Config{Name: "o", Type: TypeOTLP, Address: "http://127.0.0.1:4318"},
+		},
+		{
+			name:   "otlp bad scheme",
+			config: &Config{Name: "o", Type: TypeOTLP, Address: "tcp://127.0.0.1:4318"},
+			expErr: "must be an http or https URL",
+		},
+		{
+			name:   "unix",
+			config: &Config{Name: "u", Type: TypeUnix, Address: "/run/logs.sock"},
+		},
+		{
+			name:   "missing address",
+			config: &Config{Name: "u", Type: TypeUnix},
+			expErr: "address must not be empty",
+		},
+		{
+			name:   "unknown type",
+			config: &Config{Name: "k", Type: "kafka", Address: "127.0.0.1:9092"},
+			expErr: "unsupported type",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.expErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestSyslogSink_Format(t *testing.T) {
+	ci.Parallel(t)
+
+	s, err := newSyslogSink(&Config{
+		Type:     TypeSyslog,
+		Address:  "udp://127.0.0.1:514",
+		Facility: "local0",
+	}, map[string]string{MetaTask: "redis", MetaJob: `quo"te]`})
+	must.NoError(t, err)
+	s.hostname = "node1"
+
+	msg := s.format(&Record{Time: testTime, Stream: "stderr", Message: []byte("oops")})
+	must.Eq(t, `<131>1 2024-03-01T12:30:45.123456Z node1 redis - stderr [nomad job="quo\"te\]" task="redis"] oops`, string(msg))
+
+	msg = s.format(&Record{Time: testTime, Stream: "stdout", Message: []byte("ok")})
+	must.StrHasPrefix(t, "<134>1 ", string(msg))
+}
+
+func TestSyslogSink_TCP(t *testing.T) {
+	ci.Parallel(t)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	must.NoError(t, err)
+	defer ln.Close()
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// Read two octet counted frames
+		r := bufio.NewReader(conn)
+		var msgs []string
+		for i := 0; i < 2; i++ {
+			lenStr, err := r.ReadString(' ')
+			if err != nil {
+				return
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(lenStr))
+			if err != nil {
+				return
+			}
+			buf := make([]byte, n)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return
+			}
+			msgs = append(msgs, string(buf))
+		}
+		received <- msgs
+	}()
+
+	s, err := newSyslogSink(&Config{Type: TypeSyslog, Address: "tcp://" + ln.Addr().String()}, testMetadata)
+	must.NoError(t, err)
+	defer s.close()
+
+	must.NoError(t, s.send([]*Record{
+		{Time: testTime, Stream: "stdout", Message: []byte("hello")},
+		{Time: testTime, Stream: "stdout", Message: []byte("multi word line")},
+	}))
+
+	select {
+	case msgs := <-received:
+		must.Len(t, 2, msgs)
+		must.StrHasSuffix(t, " hello", msgs[0])
+		must.StrHasSuffix(t, " multi word line", msgs[1])
+		must.StrContains(t, msgs[0], `alloc_id="f0ba3b3e-6fa6-4a6b-8c2b-bd1c6e5c1b2d"`)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for syslog messages")
+	}
+}
+
+func TestOTLPSink(t *testing.T) {
+	ci.Parallel(t)
+
+	received := make(chan *otlpRequest, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != otlpLogsPath || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var req otlpRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- &req
+	}))
+	defer srv.Close()
+
+	s, err := newOTLPSink(&Config{
+		Type:    TypeOTLP,
+		Address: srv.URL,
+		Headers: map[string]string{"Authorization": "Bearer token"},
+	}, testMetadata)
+	must.NoError(t, err)
+	defer s.close()
+
+	must.NoError(t, s.send([]*Record{
+		{Time: testTime, Stream: "stdout", Message: []byte("hello")},
+		{Time: testTime, Stream: "stderr", Message: []byte("oops")},
+	}))
+
+	req := <-received
+	must.Len(t, 1, req.ResourceLogs)
+	attrs := map[string]string{}
+	for _, kv := range req.ResourceLogs[0].Resource.Attributes {
+		attrs[kv.Key] = kv.Value.StringValue
+	}
+	must.Eq(t, "example", attrs["nomad.job"])
+	must.Eq(t, "redis", attrs["nomad.task"])
+
+	records := req.ResourceLogs[0].ScopeLogs[0].LogRecords
+	must.Len(t, 2, records)
+	must.Eq(t, "hello", records[0].Body.StringValue)
+	must.Eq(t, otlpSeverityInfo, records[0].SeverityNumber)
+	must.Eq(t, strconv.FormatInt(testTime.UnixNano(), 10), records[0].TimeUnixNano)
+	must.Eq(t, "oops", records[1].Body.StringValue)
+	must.Eq(t, otlpSeverityError, records[1].SeverityNumber)
+
+	// Non-2xx responses are errors
+	s.headers = nil
+	must.ErrorContains(t, s.send([]*Record{{Time: testTime, Stream: "stdout"}}), "unexpected response code 400")
+}
+
+func TestUnixSink(t *testing.T) {
+	ci.Parallel(t)
+
+	path := filepath.Join(t.TempDir(), "logs.sock")
+	ln, err := net.Listen("unix", path)
+	must.NoError(t, err)
+	defer ln.Close()
+
+	received := make(chan *unixRecord, 2)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		dec := json.NewDecoder(conn)
+		for {
+			var r unixRecord
+			if err := dec.Decode(&r); err != nil {
+				return
+			}
+			received <- &r
+		}
+	}()
+
+	s := newUnixSink(&Config{Type: TypeUnix, Address: path}, testMetadata)
+	defer s.close()
+
+	must.NoError(t, s.send([]*Record{
+		{Time: testTime, Stream: "stdout", Message: []byte("hello")},
+		{Time: testTime, Stream: "stderr", Message: []byte("oops")},
+	}))
+
+	for _, expected := range []string{"hello", "oops"} {
+		select {
+		case r := <-received:
+			must.Eq(t, expected, r.Message)
+			must.Eq(t, testMetadata, r.Metadata)
+			must.True(t, testTime.Equal(r.Time))
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for records")
+		}
+	}
+}
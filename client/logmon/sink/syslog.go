@@ -0,0 +1,224 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package sink
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// syslogTimeFormat is the RFC5424 timestamp format, which allows at most
+	// microsecond precision
+	syslogTimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+	// syslogSDID is the SD-ID of the structured data element holding the
+	// task metadata
+	syslogSDID = "nomad"
+
+	// syslogMaxAppName is the maximum length of the APP-NAME field
+	syslogMaxAppName = 48
+
+	syslogSeverityErr  = 3
+	syslogSeverityInfo = 6
+)
+
+// syslogFacilities maps the facility names to their codes
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3,
+	"auth": 4, "syslog": 5, "lpr": 6, "news": 7,
+	"uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19,
+	"local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+// syslogFacility returns the code of the named facility. The user facility
+// is used if the name is empty.
+func syslogFacility(name string) (int, error) {
+	if name == "" {
+		return syslogFacilities["user"], nil
+	}
+	f, ok := syslogFacilities[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown syslog facility %q", name)
+	}
+	return f, nil
+}
+
+// parseSyslogAddress returns the network and address to dial from a syslog
+// sink address such as tcp://127.0.0.1:514 or unix:///dev/log.
+func parseSyslogAddress(address string) (string, string, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid address: %v", err)
+	}
+
+	switch u.Scheme {
+	case "tcp", "udp":
+		if u.Host == "" {
+			return "", "", fmt.Errorf("address %q is missing a host", address)
+		}
+		return u.Scheme, u.Host, nil
+	case "unix", "unixgram":
+		if u.Path == "" {
+			return "", "", fmt.Errorf("address %q is missing a path", address)
+		}
+		return u.Scheme, u.Path, nil
+	default:
+		return "", "", fmt.Errorf("address scheme must be one of tcp, udp, unix or unixgram; got %q", u.Scheme)
+	}
+}
+
+// syslogSink sends records as RFC5424 messages. Messages sent over stream
+// connections are framed with octet counting as described in RFC6587.
+type syslogSink struct {
+	network  string
+	address  string
+	facility int
+
+	// header fields shared by all messages
+	hostname       string
+	appName        string
+	structuredData string
+
+	conn net.Conn
+}
+
+func newSyslogSink(cfg *Config, metadata map[string]string) (*syslogSink, error) {
+	network, address, err := parseSyslogAddress(cfg.Address)
+	if err != nil {
+		return nil, err
+	}
+	facility, err := syslogFacility(cfg.Facility)
+	if err != nil {
+		return nil, err
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+
+	return &syslogSink{
+		network:        network,
+		address:        address,
+		facility:       facility,
+		hostname:       syslogHeaderField(hostname, 255),
+		appName:        syslogHeaderField(metadata[MetaTask], syslogMaxAppName),
+		structuredData: syslogStructuredData(metadata),
+	}, nil
+}
+
+func (s *syslogSink) send(records []*Record) error {
+	if s.conn == nil {
+		conn, err := net.DialTimeout(s.network, s.address, sinkTimeout)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	if err := s.conn.SetWriteDeadline(time.Now().Add(sinkTimeout)); err != nil {
+		s.reset()
+		return err
+	}
+
+	stream := s.network == "tcp" || s.network == "unix"
+	var buf bytes.Buffer
+	for _, r := range records {
+		msg := s.format(r)
+		buf.Reset()
+		if stream {
+			fmt.Fprintf(&buf, "%d ", len(msg))
+		}
+		buf.Write(msg)
+
+		if _, err := s.conn.Write(buf.Bytes()); err != nil {
+			s.reset()
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *syslogSink) close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
+
+// reset closes the connection so the next send reconnects
+func (s *syslogSink) reset() {
+	s.conn.Close()
+	s.conn = nil
+}
+
+// format returns the RFC5424 message for the record
+func (s *syslogSink) format(r *Record) []byte {
+	severity := syslogSeverityInfo
+	if r.Stream == "stderr" {
+		severity = syslogSeverityErr
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<%d>1 %s %s %s - %s %s ",
+		s.facility*8+severity,
+		r.Time.UTC().Format(syslogTimeFormat),
+		s.hostname,
+		s.appName,
+		syslogHeaderField(r.Stream, 32),
+		s.structuredData,
+	)
+	buf.Write(r.Message)
+	return buf.Bytes()
+}
+
+// syslogHeaderField returns the value as a header field, which must be
+// printable ASCII without spaces. Empty values are replaced by the NILVALUE.
+func syslogHeaderField(value string, maxLen int) string {
+	value = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return -1
+		}
+		return r
+	}, value)
+	if len(value) > maxLen {
+		value = value[:maxLen]
+	}
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// syslogStructuredData returns the structured data element holding the
+// metadata, with the parameters sorted by name.
+func syslogStructuredData(metadata map[string]string) string {
+	if len(metadata) == 0 {
+		return "-"
+	}
+
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+	var b strings.Builder
+	b.WriteString("[" + syslogSDID)
+	for _, k := range keys {
+		fmt.Fprintf(&b, ` %s="%s"`, syslogHeaderField(k, 32), escaper.Replace(metadata[k]))
+	}
+	b.WriteString("]")
+	return b.String()
+}
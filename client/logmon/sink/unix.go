@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package sink
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"strings"
+	"time"
+)
+
+// unixSink sends records as newline delimited JSON objects over a unix stream
+// socket.
+type unixSink struct {
+	path     string
+	metadata map[string]string
+	conn     net.Conn
+}
+
+// unixRecord is the JSON encoding of a record sent to a unix sink
+type unixRecord struct {
+	Time     time.Time         `json:"time"`
+	Stream   string            `json:"stream"`
+	Message  string            `json:"message"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+func newUnixSink(cfg *Config, metadata map[string]string) *unixSink {
+	return &unixSink{
+		path:     strings.TrimPrefix(cfg.Address, "unix://"),
+		metadata: metadata,
+	}
+}
+
+func (s *unixSink) send(records []*Record) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, r := range records {
+		err := enc.Encode(&unixRecord{
+			Time:     r.Time,
+			Stream:   r.Stream,
+			Message:  string(r.Message),
+			Metadata: s.metadata,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.conn == nil {
+		conn, err := net.DialTimeout("unix", s.path, sinkTimeout)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	err := s.conn.SetWriteDeadline(time.Now().Add(sinkTimeout))
+	if err == nil {
+		_, err = s.conn.Write(buf.Bytes())
+	}
+	if err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return err
+	}
+	return nil
+}
+
+func (s *unixSink) close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
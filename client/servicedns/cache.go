@@ -0,0 +1,372 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package servicedns
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// serviceIdleTimeout is how long a service may go without being queried
+	// before its blocking query watcher is stopped and it is evicted from the
+	// cache.
+	serviceIdleTimeout = 10 * time.Minute
+
+	// negativeIdleTimeout is how long a service without any registrations may
+	// go without being queried before it is evicted from the cache, so that
+	// queries for names that don't exist don't keep watchers running.
+	negativeIdleTimeout = time.Minute
+
+	// serviceCacheLimit is the maximum number of services watched at once.
+	// Once reached, idle services are evicted, and services are looked up
+	// without being cached if none are.
+	serviceCacheLimit = 1024
+
+	// serviceMaxQueryTime is the maximum time a blocking query for a service
+	// waits for a change before returning.
+	serviceMaxQueryTime = 5 * time.Minute
+
+	// serviceReadyTimeout is how long a lookup waits for the first query of a
+	// service that is not yet cached to complete.
+	serviceReadyTimeout = 2 * time.Second
+
+	// watchBackoffBase and watchBackoffLimit bound the backoff applied when a
+	// blocking query fails.
+	watchBackoffBase  = 500 * time.Millisecond
+	watchBackoffLimit = 30 * time.Second
+)
+
+var errServiceNotReady = errors.New("timed out waiting for service registrations")
+
+// RPCFn is the function used to perform RPCs against the servers.
+type RPCFn func(method string, args, reply any) error
+
+// ChecksFn returns the latest Nomad check results of an allocation.
+type ChecksFn func(allocID string) (map[structs.CheckID]*structs.CheckQueryResult, error)
+
+// CacheConfig configures a Cache.
+type CacheConfig struct {
+	// Region is the region service registrations are read from.
+	Region string
+
+	// Token is the ACL token used for RPCs.
+	Token string
+
+	// RPC performs RPCs against the servers.
+	RPC RPCFn
+
+	// Checks returns the check results of an allocation.
+	Checks ChecksFn
+
+	// CheckTTL is how long check results of an allocation are cached.
+	CheckTTL time.Duration
+}
+
+// Cache keeps an up to date view of the service registrations that are being
+// queried using blocking queries, and of the check results of the allocations
+// providing them.
+type Cache struct {
+	logger hclog.Logger
+	config *CacheConfig
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	servicesLock sync.Mutex
+	services     map[serviceKey]*serviceWatch
+	serviceLimit int
+
+	checksLock sync.Mutex
+	checks     map[string]*allocChecks
+}
+
+type serviceKey struct {
+	namespace string
+	name      string
+}
+
+// serviceWatch tracks the registrations of a single service, kept up to date
+// by a blocking query.
+type serviceWatch struct {
+	key   serviceKey
+	ready chan struct{}
+
+	// stopCh is closed when the service is evicted from the cache
+	stopCh chan struct{}
+
+	lock     sync.RWMutex
+	regs     []*structs.ServiceRegistration
+	lastUsed time.Time
+}
+
+// allocChecks are the cached check results of an allocation.
+type allocChecks struct {
+	results map[structs.CheckID]*structs.CheckQueryResult
+	expires time.Time
+}
+
+// NewCache returns a new Cache. Shutdown must be called to stop the
+// background watchers.
+func NewCache(logger hclog.Logger, config *CacheConfig) *Cache {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Cache{
+		logger:       logger.Named("cache"),
+		config:       config,
+		ctx:          ctx,
+		cancel:       cancel,
+		services:     make(map[serviceKey]*serviceWatch),
+		serviceLimit: serviceCacheLimit,
+		checks:       make(map[string]*allocChecks),
+	}
+}
+
+// Shutdown stops all watchers.
+func (c *Cache) Shutdown() {
+	c.cancel()
+}
+
+// HealthyInstances returns the registrations of the named service whose Nomad
+// checks are all passing. Readiness checks are ignored.
+func (c *Cache) HealthyInstances(namespace, name string) ([]*structs.ServiceRegistration, error) {
+	regs, err := c.instances(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	healthy := make([]*structs.ServiceRegistration, 0, len(regs))
+	for _, reg := range regs {
+		if c.healthy(reg) {
+			healthy = append(healthy, reg)
+		}
+	}
+	return healthy, nil
+}
+
+// instances returns all registrations of the named service, starting a
+// watcher for the service if there isn't one already.
+func (c *Cache) instances(namespace, name string) ([]*structs.ServiceRegistration, error) {
+	key := serviceKey{namespace: namespace, name: name}
+
+	c.servicesLock.Lock()
+	watch, ok := c.services[key]
+	if !ok && len(c.services) >= c.serviceLimit {
+		c.evictIdleLocked()
+	}
+	if !ok && len(c.services) >= c.serviceLimit {
+		c.servicesLock.Unlock()
+		var reply structs.ServiceRegistrationByNameResponse
+		if err := c.query(key, 0, &reply); err != nil {
+			return nil, err
+		}
+		return reply.Services, nil
+	}
+	if !ok {
+		watch = &serviceWatch{
+			key:      key,
+			ready:    make(chan struct{}),
+			stopCh:   make(chan struct{}),
+			lastUsed: time.Now(),
+		}
+		c.services[key] = watch
+		go c.watch(watch)
+	}
+	c.servicesLock.Unlock()
+
+	timer, stop := helper.NewSafeTimer(serviceReadyTimeout)
+	defer stop()
+
+	select {
+	case <-watch.ready:
+	case <-timer.C:
+		return nil, errServiceNotReady
+	case <-c.ctx.Done():
+		return nil, c.ctx.Err()
+	}
+
+	watch.lock.Lock()
+	defer watch.lock.Unlock()
+	watch.lastUsed = time.Now()
+	return watch.regs, nil
+}
+
+// evictIdleLocked evicts the services that are idle, and stops their watchers.
+// The services lock must be held.
+func (c *Cache) evictIdleLocked() {
+	for key, watch := range c.services {
+		if watch.idle() {
+			delete(c.services, key)
+			close(watch.stopCh)
+		}
+	}
+}
+
+// idle returns true if the service has not been queried for long enough to be
+// evicted, which is shorter for services without any registrations.
+func (w *serviceWatch) idle() bool {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+
+	timeout := serviceIdleTimeout
+	if len(w.regs) == 0 {
+		timeout = negativeIdleTimeout
+	}
+	return time.Since(w.lastUsed) > timeout
+}
+
+// query reads the registrations of a service, blocking until they change
+// after index if it is set.
+func (c *Cache) query(key serviceKey, index uint64, reply *structs.ServiceRegistrationByNameResponse) error {
+	args := structs.ServiceRegistrationByNameRequest{
+		ServiceName: key.name,
+		QueryOptions: structs.QueryOptions{
+			Region:        c.config.Region,
+			Namespace:     key.namespace,
+			AuthToken:     c.config.Token,
+			MinQueryIndex: index,
+			MaxQueryTime:  serviceMaxQueryTime,
+			AllowStale:    true,
+		},
+	}
+	return c.config.RPC(structs.ServiceRegistrationGetServiceRPCMethod, &args, reply)
+}
+
+// watch runs blocking queries for the registrations of a service until the
+// service is idle, it is evicted or the cache is shut down.
+func (c *Cache) watch(watch *serviceWatch) {
+	logger := c.logger.With("namespace", watch.key.namespace, "service", watch.key.name)
+
+	var index, attempt uint64
+	for {
+		select {
+		case <-watch.stopCh:
+			logger.Trace("stopped watching evicted service")
+			return
+		default:
+		}
+
+		if watch.idle() {
+			c.servicesLock.Lock()
+			if c.services[watch.key] == watch {
+				delete(c.services, watch.key)
+			}
+			c.servicesLock.Unlock()
+			logger.Trace("stopped watching idle service")
+			return
+		}
+
+		var reply structs.ServiceRegistrationByNameResponse
+		err := c.query(watch.key, index, &reply)
+		if err != nil {
+			logger.Warn("failed to query service registrations", "error", err)
+			attempt++
+
+			timer, stop := helper.NewSafeTimer(helper.Backoff(watchBackoffBase, watchBackoffLimit, attempt))
+			select {
+			case <-timer.C:
+				stop()
+				continue
+			case <-watch.stopCh:
+				stop()
+				return
+			case <-c.ctx.Done():
+				stop()
+				return
+			}
+		}
+		attempt = 0
+
+		// The index going backwards means the state was restored or the
+		// request hit a lagging server, so reset it to avoid waiting for the
+		// full query time.
+		if reply.Index < index {
+			index = 0
+		} else {
+			index = reply.Index
+		}
+
+		watch.lock.Lock()
+		watch.regs = reply.Services
+		watch.lock.Unlock()
+
+		select {
+		case <-watch.ready:
+		default:
+			close(watch.ready)
+		}
+
+		select {
+		case <-c.ctx.Done():
+			return
+		default:
+		}
+	}
+}
+
+// healthy returns true if all of the healthiness checks of the registration
+// are passing. Checks that haven't run yet are pending, since the client of
+// the allocation stores a pending result for each of its Nomad checks before
+// registering its services, so their registrations are unhealthy.
+// Registrations without any check results have no checks and are always
+// healthy. If the check results cannot be read, the last known results are
+// used, and if there are none the registration is treated as unhealthy.
+func (c *Cache) healthy(reg *structs.ServiceRegistration) bool {
+	results, err := c.allocChecks(reg.AllocID)
+	if err != nil {
+		c.logger.Warn("failed to read allocation checks",
+			"alloc_id", reg.AllocID, "error", err)
+		return false
+	}
+
+	for _, result := range results {
+		if result.Service != reg.ServiceName || result.Mode != structs.Healthiness {
+			continue
+		}
+		if result.Status != structs.CheckSuccess {
+			return false
+		}
+	}
+	return true
+}
+
+// allocChecks returns the cached check results of the allocation, refreshing
+// them if they have expired.
+func (c *Cache) allocChecks(allocID string) (map[structs.CheckID]*structs.CheckQueryResult, error) {
+	c.checksLock.Lock()
+	defer c.checksLock.Unlock()
+
+	now := time.Now()
+	cached, ok := c.checks[allocID]
+	if ok && now.Before(cached.expires) {
+		return cached.results, nil
+	}
+
+	results, err := c.config.Checks(allocID)
+	if err != nil {
+		if ok {
+			return cached.results, nil
+		}
+		return nil, err
+	}
+
+	// Drop expired entries so allocations that no longer provide a queried
+	// service don't accumulate.
+	for id, entry := range c.checks {
+		if now.After(entry.expires) {
+			delete(c.checks, id)
+		}
+	}
+
+	c.checks[allocID] = &allocChecks{
+		results: results,
+		expires: now.Add(c.config.CheckTTL),
+	}
+	return results, nil
+}
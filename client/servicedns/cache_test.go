@@ -0,0 +1,266 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package servicedns
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+// fakeServers serves service registrations and check results for tests.
+type fakeServers struct {
+	lock   sync.Mutex
+	index  uint64
+	regs   map[serviceKey][]*structs.ServiceRegistration
+	checks map[string]map[structs.CheckID]*structs.CheckQueryResult
+
+	checksErr   error
+	checksCalls int
+}
+
+func newFakeServers() *fakeServers {
+	return &fakeServers{
+		index:  1,
+		regs:   make(map[serviceKey][]*structs.ServiceRegistration),
+		checks: make(map[string]map[structs.CheckID]*structs.CheckQueryResult),
+	}
+}
+
+func (f *fakeServers) setService(reg *structs.ServiceRegistration) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	key := serviceKey{namespace: reg.Namespace, name: reg.ServiceName}
+	f.regs[key] = append(f.regs[key], reg)
+	f.index++
+}
+
+func (f *fakeServers) setCheck(allocID string, result *structs.CheckQueryResult) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.checks[allocID] == nil {
+		f.checks[allocID] = make(map[structs.CheckID]*structs.CheckQueryResult)
+	}
+	f.checks[allocID][result.ID] = result
+}
+
+func (f *fakeServers) RPC(method string, args, reply any) error {
+	if method != structs.ServiceRegistrationGetServiceRPCMethod {
+		return errors.New("unexpected method " + method)
+	}
+
+	req := args.(*structs.ServiceRegistrationByNameRequest)
+	resp := reply.(*structs.ServiceRegistrationByNameResponse)
+
+	deadline := time.Now().Add(req.MaxQueryTime)
+	for {
+		f.lock.Lock()
+		if f.index > req.MinQueryIndex || time.Now().After(deadline) {
+			resp.Services = f.regs[serviceKey{namespace: req.Namespace, name: req.ServiceName}]
+			resp.Index = f.index
+			f.lock.Unlock()
+			return nil
+		}
+		f.lock.Unlock()
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func (f *fakeServers) Checks(allocID string) (map[structs.CheckID]*structs.CheckQueryResult, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.checksCalls++
+	if f.checksErr != nil {
+		return nil, f.checksErr
+	}
+	return f.checks[allocID], nil
+}
+
+func testCache(t *testing.T, servers *fakeServers, checkTTL time.Duration) *Cache {
+	cache := NewCache(testlog.HCLogger(t), &CacheConfig{
+		Region:   "global",
+		RPC:      servers.RPC,
+		Checks:   servers.Checks,
+		CheckTTL: checkTTL,
+	})
+	t.Cleanup(cache.Shutdown)
+	return cache
+}
+
+func TestCache_HealthyInstances(t *testing.T) {
+	ci.Parallel(t)
+
+	servers := newFakeServers()
+	servers.setService(&structs.ServiceRegistration{
+		ID: "healthy", ServiceName: "web", Namespace: "default", AllocID: "alloc1",
+	})
+	servers.setService(&structs.ServiceRegistration{
+		ID: "failing", ServiceName: "web", Namespace: "default", AllocID: "alloc2",
+	})
+	servers.setService(&structs.ServiceRegistration{
+		ID: "other-namespace", ServiceName: "web", Namespace: "prod", AllocID: "alloc3",
+	})
+	servers.setCheck("alloc1", &structs.CheckQueryResult{
+		ID: "c1", Service: "web", Mode: structs.Healthiness, Status: structs.CheckSuccess,
+	})
+	servers.setCheck("alloc1", &structs.CheckQueryResult{
+		ID: "c2", Service: "web", Mode: structs.Readiness, Status: structs.CheckFailure,
+	})
+	servers.setCheck("alloc2", &structs.CheckQueryResult{
+		ID: "c3", Service: "web", Mode: structs.Healthiness, Status: structs.CheckPending,
+	})
+
+	cache := testCache(t, servers, time.Minute)
+
+	regs, err := cache.HealthyInstances("default", "web")
+	must.NoError(t, err)
+	must.Len(t, 1, regs)
+	must.Eq(t, "healthy", regs[0].ID)
+
+	regs, err = cache.HealthyInstances("prod", "web")
+	must.NoError(t, err)
+	must.Len(t, 1, regs)
+	must.Eq(t, "other-namespace", regs[0].ID)
+
+	regs, err = cache.HealthyInstances("default", "missing")
+	must.NoError(t, err)
+	must.Len(t, 0, regs)
+}
+
+func TestCache_WatchUpdates(t *testing.T) {
+	ci.Parallel(t)
+
+	servers := newFakeServers()
+	cache := testCache(t, servers, time.Minute)
+
+	regs, err := cache.HealthyInstances("default", "web")
+	must.NoError(t, err)
+	must.Len(t, 0, regs)
+
+	servers.setService(&structs.ServiceRegistration{
+		ID: "new", ServiceName: "web", Namespace: "default", AllocID: "alloc1",
+	})
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			regs, err := cache.HealthyInstances("default", "web")
+			return err == nil && len(regs) == 1
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(50*time.Millisecond),
+	))
+}
+
+func TestCache_ChecksCached(t *testing.T) {
+	ci.Parallel(t)
+
+	servers := newFakeServers()
+	servers.setService(&structs.ServiceRegistration{
+		ID: "web", ServiceName: "web", Namespace: "default", AllocID: "alloc1",
+	})
+	cache := testCache(t, servers, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		regs, err := cache.HealthyInstances("default", "web")
+		must.NoError(t, err)
+		must.Len(t, 1, regs)
+	}
+	must.Eq(t, 1, servers.checksCalls)
+}
+
+func TestCache_ChecksError(t *testing.T) {
+	ci.Parallel(t)
+
+	servers := newFakeServers()
+	servers.setService(&structs.ServiceRegistration{
+		ID: "web", ServiceName: "web", Namespace: "default", AllocID: "alloc1",
+	})
+
+	// Without any previous results the instance is treated as unhealthy.
+	servers.checksErr = errors.New("node unreachable")
+	cache := testCache(t, servers, 0)
+
+	regs, err := cache.HealthyInstances("default", "web")
+	must.NoError(t, err)
+	must.Len(t, 0, regs)
+
+	// Once results have been read, they are used if refreshing them fails.
+	servers.lock.Lock()
+	servers.checksErr = nil
+	servers.lock.Unlock()
+
+	regs, err = cache.HealthyInstances("default", "web")
+	must.NoError(t, err)
+	must.Len(t, 1, regs)
+
+	servers.lock.Lock()
+	servers.checksErr = errors.New("node unreachable")
+	servers.lock.Unlock()
+
+	regs, err = cache.HealthyInstances("default", "web")
+	must.NoError(t, err)
+	must.Len(t, 1, regs)
+}
+
+func TestCache_ServiceLimit(t *testing.T) {
+	ci.Parallel(t)
+
+	servers := newFakeServers()
+	servers.setService(&structs.ServiceRegistration{
+		ID: "api", ServiceName: "api", Namespace: "default", AllocID: "alloc1",
+	})
+	cache := testCache(t, servers, time.Minute)
+	cache.serviceLimit = 2
+
+	cached := func(name string) bool {
+		cache.servicesLock.Lock()
+		defer cache.servicesLock.Unlock()
+		_, ok := cache.services[serviceKey{namespace: "default", name: name}]
+		return ok
+	}
+
+	for _, name := range []string{"missing1", "missing2"} {
+		regs, err := cache.HealthyInstances("default", name)
+		must.NoError(t, err)
+		must.SliceEmpty(t, regs)
+	}
+
+	// The cache is full and no service is idle, so the service is looked up
+	// without being cached
+	regs, err := cache.HealthyInstances("default", "api")
+	must.NoError(t, err)
+	must.Len(t, 1, regs)
+	must.False(t, cached("api"))
+
+	// Services without registrations are evicted once idle for a short time
+	cache.servicesLock.Lock()
+	evicted := cache.services[serviceKey{namespace: "default", name: "missing1"}]
+	cache.servicesLock.Unlock()
+	evicted.lock.Lock()
+	evicted.lastUsed = time.Now().Add(-2 * negativeIdleTimeout)
+	evicted.lock.Unlock()
+
+	regs, err = cache.HealthyInstances("default", "api")
+	must.NoError(t, err)
+	must.Len(t, 1, regs)
+	must.True(t, cached("api"))
+	must.False(t, cached("missing1"))
+	must.True(t, cached("missing2"))
+
+	select {
+	case <-evicted.stopCh:
+	default:
+		t.Fatal("expected the watcher of the evicted service to be stopped")
+	}
+}
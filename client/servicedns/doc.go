@@ -0,0 +1,7 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package servicedns provides an optional DNS server for Nomad clients which
+// answers queries for services registered with the Nomad service discovery
+// provider, so workloads can discover them without Consul.
+package servicedns
@@ -0,0 +1,355 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package servicedns
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/miekg/dns"
+)
+
+const (
+	// serviceLabel is the label identifying service lookups, placed directly
+	// before the domain.
+	serviceLabel = "service"
+
+	// addrLabel is the label identifying address lookups, placed directly
+	// before the domain. It is used as the target of SRV records.
+	addrLabel = "addr"
+)
+
+// Config configures a Server.
+type Config struct {
+	// Addr is the host:port the server listens on for UDP and TCP.
+	Addr string
+
+	// Domain is the fully qualified domain the server is authoritative for.
+	Domain string
+
+	// TTL is the time-to-live of answered records.
+	TTL time.Duration
+}
+
+// Resolver looks up the healthy instances of a service.
+type Resolver interface {
+	HealthyInstances(namespace, name string) ([]*structs.ServiceRegistration, error)
+}
+
+// Server is a DNS server answering A, AAAA and SRV queries for services
+// registered with the Nomad service discovery provider. Services are looked
+// up with names of the form:
+//
+//	[<tag>.]<service>[.<namespace>].service.<domain>
+//
+// If the namespace is omitted the default namespace is used.
+type Server struct {
+	logger   hclog.Logger
+	config   *Config
+	resolver Resolver
+
+	udp *dns.Server
+	tcp *dns.Server
+
+	shutdownOnce sync.Once
+}
+
+// NewServer returns a new Server. Start must be called to begin serving.
+func NewServer(logger hclog.Logger, config *Config, resolver Resolver) *Server {
+	return &Server{
+		logger:   logger,
+		config:   config,
+		resolver: resolver,
+	}
+}
+
+// Start binds the UDP and TCP listeners and begins serving queries.
+func (s *Server) Start() error {
+	packetConn, err := net.ListenPacket("udp", s.config.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on udp %s: %w", s.config.Addr, err)
+	}
+	listener, err := net.Listen("tcp", s.config.Addr)
+	if err != nil {
+		packetConn.Close()
+		return fmt.Errorf("failed to listen on tcp %s: %w", s.config.Addr, err)
+	}
+
+	mux := dns.NewServeMux()
+	mux.HandleFunc(s.config.Domain, s.handleQuery)
+
+	s.udp = &dns.Server{PacketConn: packetConn, Handler: mux}
+	s.tcp = &dns.Server{Listener: listener, Handler: mux}
+
+	go s.serve(s.udp)
+	go s.serve(s.tcp)
+
+	s.logger.Info("started service DNS server", "address", s.config.Addr, "domain", s.config.Domain)
+	return nil
+}
+
+func (s *Server) serve(server *dns.Server) {
+	if err := server.ActivateAndServe(); err != nil {
+		s.logger.Error("service DNS server stopped", "error", err)
+	}
+}
+
+// Shutdown stops the server.
+func (s *Server) Shutdown() {
+	s.shutdownOnce.Do(func() {
+		for _, server := range []*dns.Server{s.udp, s.tcp} {
+			if server == nil {
+				continue
+			}
+			if err := server.Shutdown(); err != nil {
+				s.logger.Warn("failed to shutdown service DNS server", "error", err)
+			}
+		}
+	})
+}
+
+// UDPAddr returns the address of the UDP listener, which may differ from the
+// configured address if the port was 0.
+func (s *Server) UDPAddr() net.Addr {
+	return s.udp.PacketConn.LocalAddr()
+}
+
+// TCPAddr returns the address of the TCP listener, which may differ from the
+// configured address if the port was 0.
+func (s *Server) TCPAddr() net.Addr {
+	return s.tcp.Listener.Addr()
+}
+
+func (s *Server) handleQuery(w dns.ResponseWriter, req *dns.Msg) {
+	resp := new(dns.Msg)
+	resp.SetReply(req)
+	resp.Authoritative = true
+	resp.RecursionAvailable = false
+
+	if len(req.Question) == 1 {
+		s.answer(resp, req.Question[0])
+	} else {
+		resp.SetRcode(req, dns.RcodeFormatError)
+	}
+
+	// Truncate UDP responses that exceed the client's buffer size so it
+	// retries over TCP.
+	size := dns.MinMsgSize
+	if _, ok := w.RemoteAddr().(*net.TCPAddr); ok {
+		size = dns.MaxMsgSize
+	} else if opt := req.IsEdns0(); opt != nil {
+		size = int(opt.UDPSize())
+	}
+	resp.Truncate(size)
+
+	if err := w.WriteMsg(resp); err != nil {
+		s.logger.Debug("failed to write DNS response", "error", err)
+	}
+}
+
+// answer populates resp with the answer to the question.
+func (s *Server) answer(resp *dns.Msg, q dns.Question) {
+	name := strings.ToLower(q.Name)
+	labels := dns.SplitDomainName(strings.TrimSuffix(name, s.config.Domain))
+	if len(labels) < 2 {
+		s.setNameError(resp)
+		return
+	}
+
+	switch labels[len(labels)-1] {
+	case addrLabel:
+		s.answerAddr(resp, q, labels[:len(labels)-1])
+	case serviceLabel:
+		s.answerService(resp, q, labels[:len(labels)-1])
+	default:
+		s.setNameError(resp)
+	}
+}
+
+// answerAddr answers a query for <hex-ip>.addr.<domain>, which are the
+// targets of SRV records.
+func (s *Server) answerAddr(resp *dns.Msg, q dns.Question, labels []string) {
+	if len(labels) != 1 {
+		s.setNameError(resp)
+		return
+	}
+
+	b, err := hex.DecodeString(labels[0])
+	if err != nil || (len(b) != net.IPv4len && len(b) != net.IPv6len) {
+		s.setNameError(resp)
+		return
+	}
+
+	if rr := s.addrRecord(q.Name, q.Qtype, net.IP(b)); rr != nil {
+		resp.Answer = append(resp.Answer, rr)
+	}
+}
+
+// serviceQuery is a lookup of the instances of a service, optionally filtered
+// by a tag.
+type serviceQuery struct {
+	tag       string
+	service   string
+	namespace string
+}
+
+// answerService answers a query for [<tag>.]<service>[.<namespace>].service.<domain>.
+// A name with two labels is ambiguous, so it is looked up as
+// <service>.<namespace> first, and as <tag>.<service> in the default namespace
+// if that has no instances.
+func (s *Server) answerService(resp *dns.Msg, q dns.Question, labels []string) {
+	var queries []serviceQuery
+	switch len(labels) {
+	case 1:
+		queries = []serviceQuery{
+			{service: labels[0], namespace: structs.DefaultNamespace},
+		}
+	case 2:
+		queries = []serviceQuery{
+			{service: labels[0], namespace: labels[1]},
+			{tag: labels[0], service: labels[1], namespace: structs.DefaultNamespace},
+		}
+	case 3:
+		queries = []serviceQuery{
+			{tag: labels[0], service: labels[1], namespace: labels[2]},
+		}
+	default:
+		s.setNameError(resp)
+		return
+	}
+
+	// A name only doesn't exist if none of its readings failed, so a failed
+	// lookup is only answered with a server failure once the other readings
+	// have been tried.
+	var regs []*structs.ServiceRegistration
+	var failed bool
+	for _, query := range queries {
+		var err error
+		regs, err = s.lookupService(query)
+		if err != nil {
+			s.logger.Warn("failed to lookup service",
+				"namespace", query.namespace, "service", query.service, "error", err)
+			failed = true
+			continue
+		}
+		if len(regs) > 0 {
+			break
+		}
+	}
+
+	switch {
+	case len(regs) > 0:
+	case failed:
+		resp.Rcode = dns.RcodeServerFailure
+		return
+	default:
+		s.setNameError(resp)
+		return
+	}
+
+	for _, reg := range regs {
+		switch q.Qtype {
+		case dns.TypeSRV:
+			target, ip := s.srvTarget(reg)
+			if target == "" {
+				continue
+			}
+			resp.Answer = append(resp.Answer, &dns.SRV{
+				Hdr:      s.header(q.Name, dns.TypeSRV),
+				Priority: 1,
+				Weight:   1,
+				Port:     uint16(reg.Port),
+				Target:   target,
+			})
+			if rr := s.addrRecord(target, dns.TypeANY, ip); rr != nil {
+				resp.Extra = append(resp.Extra, rr)
+			}
+		case dns.TypeA, dns.TypeAAAA, dns.TypeANY:
+			if rr := s.addrRecord(q.Name, q.Qtype, net.ParseIP(reg.Address)); rr != nil {
+				resp.Answer = append(resp.Answer, rr)
+			}
+		}
+	}
+}
+
+// lookupService returns the healthy instances of the service that have the tag
+// of the query, if any.
+func (s *Server) lookupService(query serviceQuery) ([]*structs.ServiceRegistration, error) {
+	regs, err := s.resolver.HealthyInstances(query.namespace, query.service)
+	if err != nil || query.tag == "" {
+		return regs, err
+	}
+
+	return slices.DeleteFunc(slices.Clone(regs), func(reg *structs.ServiceRegistration) bool {
+		return !slices.ContainsFunc(reg.Tags, func(t string) bool {
+			return strings.EqualFold(t, query.tag)
+		})
+	}), nil
+}
+
+// srvTarget returns the target name and IP of the SRV record of a
+// registration. The target name encodes the IP so it can be resolved by the
+// server without any further state.
+func (s *Server) srvTarget(reg *structs.ServiceRegistration) (string, net.IP) {
+	ip := net.ParseIP(reg.Address)
+	if ip == nil {
+		return "", nil
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	return hex.EncodeToString(ip) + "." + addrLabel + "." + s.config.Domain, ip
+}
+
+// addrRecord returns an A or AAAA record for the IP if it matches the query
+// type, or nil.
+func (s *Server) addrRecord(name string, qtype uint16, ip net.IP) dns.RR {
+	if ip == nil {
+		return nil
+	}
+
+	if ip4 := ip.To4(); ip4 != nil {
+		if qtype != dns.TypeA && qtype != dns.TypeANY {
+			return nil
+		}
+		return &dns.A{Hdr: s.header(name, dns.TypeA), A: ip4}
+	}
+
+	if qtype != dns.TypeAAAA && qtype != dns.TypeANY {
+		return nil
+	}
+	return &dns.AAAA{Hdr: s.header(name, dns.TypeAAAA), AAAA: ip}
+}
+
+func (s *Server) header(name string, rrtype uint16) dns.RR_Header {
+	return dns.RR_Header{
+		Name:   name,
+		Rrtype: rrtype,
+		Class:  dns.ClassINET,
+		Ttl:    uint32(s.config.TTL / time.Second),
+	}
+}
+
+// setNameError marks the response as NXDOMAIN and adds the SOA record of the
+// domain so resolvers can cache the negative answer.
+func (s *Server) setNameError(resp *dns.Msg) {
+	resp.Rcode = dns.RcodeNameError
+	resp.Ns = append(resp.Ns, &dns.SOA{
+		Hdr:     s.header(s.config.Domain, dns.TypeSOA),
+		Ns:      "ns." + s.config.Domain,
+		Mbox:    "hostmaster." + s.config.Domain,
+		Serial:  uint32(time.Now().Unix()),
+		Refresh: 3600,
+		Retry:   600,
+		Expire:  86400,
+		Minttl:  uint32(s.config.TTL / time.Second),
+	})
+}
@@ -0,0 +1,199 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package servicedns
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/miekg/dns"
+	"github.com/shoenig/test/must"
+)
+
+// staticResolver resolves services from a static set of registrations.
+type staticResolver struct {
+	regs []*structs.ServiceRegistration
+	err  error
+
+	// errNamespace is a namespace whose lookups fail
+	errNamespace string
+}
+
+func (r *staticResolver) HealthyInstances(namespace, name string) ([]*structs.ServiceRegistration, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if namespace == r.errNamespace {
+		return nil, errors.New("permission denied")
+	}
+
+	var regs []*structs.ServiceRegistration
+	for _, reg := range r.regs {
+		if reg.Namespace == namespace && reg.ServiceName == name {
+			regs = append(regs, reg)
+		}
+	}
+	return regs, nil
+}
+
+func testServer(t *testing.T, resolver Resolver) *Server {
+	server := NewServer(testlog.HCLogger(t), &Config{
+		Addr:   "127.0.0.1:0",
+		Domain: "nomad.",
+		TTL:    5 * time.Second,
+	}, resolver)
+	must.NoError(t, server.Start())
+	t.Cleanup(server.Shutdown)
+	return server
+}
+
+func query(t *testing.T, addr net.Addr, name string, qtype uint16) *dns.Msg {
+	t.Helper()
+
+	client := &dns.Client{Net: addr.Network(), Timeout: 5 * time.Second}
+	msg := new(dns.Msg)
+	msg.SetQuestion(name, qtype)
+
+	var resp *dns.Msg
+	var err error
+	for i := 0; i < 10; i++ {
+		resp, _, err = client.Exchange(msg, addr.String())
+		if err == nil {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	must.NoError(t, err)
+	return resp
+}
+
+func TestServer_Query(t *testing.T) {
+	ci.Parallel(t)
+
+	resolver := &staticResolver{regs: []*structs.ServiceRegistration{
+		{
+			ServiceName: "web", Namespace: "default", Address: "10.0.0.1", Port: 8080,
+			Tags: []string{"primary"},
+		},
+		{
+			ServiceName: "web", Namespace: "default", Address: "10.0.0.2", Port: 8081,
+		},
+		{
+			ServiceName: "web", Namespace: "prod", Address: "fd00::1", Port: 9090,
+		},
+	}}
+	server := testServer(t, resolver)
+
+	for _, addr := range []net.Addr{server.UDPAddr(), server.TCPAddr()} {
+		t.Run(addr.Network(), func(t *testing.T) {
+			resp := query(t, addr, "web.default.service.nomad.", dns.TypeA)
+			must.Eq(t, dns.RcodeSuccess, resp.Rcode)
+			must.Len(t, 2, resp.Answer)
+			must.Eq(t, "10.0.0.1", resp.Answer[0].(*dns.A).A.String())
+			must.Eq(t, uint32(5), resp.Answer[0].Header().Ttl)
+
+			// The namespace defaults to the default namespace.
+			resp = query(t, addr, "web.service.nomad.", dns.TypeA)
+			must.Len(t, 2, resp.Answer)
+
+			// Tags filter the instances.
+			resp = query(t, addr, "primary.web.default.service.nomad.", dns.TypeA)
+			must.Len(t, 1, resp.Answer)
+			must.Eq(t, "10.0.0.1", resp.Answer[0].(*dns.A).A.String())
+
+			// Without a namespace, the tag applies to the default namespace.
+			resp = query(t, addr, "primary.web.service.nomad.", dns.TypeA)
+			must.Eq(t, dns.RcodeSuccess, resp.Rcode)
+			must.Len(t, 1, resp.Answer)
+			must.Eq(t, "10.0.0.1", resp.Answer[0].(*dns.A).A.String())
+
+			resp = query(t, addr, "missing.web.service.nomad.", dns.TypeA)
+			must.Eq(t, dns.RcodeNameError, resp.Rcode)
+
+			resp = query(t, addr, "web.prod.service.nomad.", dns.TypeAAAA)
+			must.Len(t, 1, resp.Answer)
+			must.Eq(t, "fd00::1", resp.Answer[0].(*dns.AAAA).AAAA.String())
+
+			// The service exists but has no records of the requested type.
+			resp = query(t, addr, "web.prod.service.nomad.", dns.TypeA)
+			must.Eq(t, dns.RcodeSuccess, resp.Rcode)
+			must.Len(t, 0, resp.Answer)
+
+			resp = query(t, addr, "missing.default.service.nomad.", dns.TypeA)
+			must.Eq(t, dns.RcodeNameError, resp.Rcode)
+			must.Len(t, 1, resp.Ns)
+		})
+	}
+}
+
+func TestServer_QuerySRV(t *testing.T) {
+	ci.Parallel(t)
+
+	resolver := &staticResolver{regs: []*structs.ServiceRegistration{
+		{ServiceName: "db", Namespace: "default", Address: "10.0.0.3", Port: 5432},
+	}}
+	server := testServer(t, resolver)
+
+	resp := query(t, server.UDPAddr(), "db.default.service.nomad.", dns.TypeSRV)
+	must.Eq(t, dns.RcodeSuccess, resp.Rcode)
+	must.Len(t, 1, resp.Answer)
+
+	srv := resp.Answer[0].(*dns.SRV)
+	must.Eq(t, uint16(5432), srv.Port)
+	must.Eq(t, "0a000003.addr.nomad.", srv.Target)
+
+	must.Len(t, 1, resp.Extra)
+	must.Eq(t, "10.0.0.3", resp.Extra[0].(*dns.A).A.String())
+
+	// The target can be resolved directly.
+	resp = query(t, server.UDPAddr(), srv.Target, dns.TypeA)
+	must.Len(t, 1, resp.Answer)
+	must.Eq(t, "10.0.0.3", resp.Answer[0].(*dns.A).A.String())
+
+	resp = query(t, server.UDPAddr(), "zz.addr.nomad.", dns.TypeA)
+	must.Eq(t, dns.RcodeNameError, resp.Rcode)
+}
+
+func TestServer_QueryError(t *testing.T) {
+	ci.Parallel(t)
+
+	server := testServer(t, &staticResolver{err: errors.New("no servers")})
+
+	resp := query(t, server.UDPAddr(), "web.default.service.nomad.", dns.TypeA)
+	must.Eq(t, dns.RcodeServerFailure, resp.Rcode)
+
+	resp = query(t, server.UDPAddr(), "web.default.node.nomad.", dns.TypeA)
+	must.Eq(t, dns.RcodeNameError, resp.Rcode)
+}
+
+func TestServer_QueryTwoLabelsError(t *testing.T) {
+	ci.Parallel(t)
+
+	resolver := &staticResolver{
+		regs: []*structs.ServiceRegistration{
+			{
+				ServiceName: "web", Namespace: "default", Address: "10.0.0.1", Port: 8080,
+				Tags: []string{"primary"},
+			},
+		},
+		errNamespace: "web",
+	}
+	server := testServer(t, resolver)
+
+	// The lookup of the "primary" service in the "web" namespace fails, so the
+	// name is resolved as the tagged service
+	resp := query(t, server.UDPAddr(), "primary.web.service.nomad.", dns.TypeA)
+	must.Eq(t, dns.RcodeSuccess, resp.Rcode)
+	must.Len(t, 1, resp.Answer)
+	must.Eq(t, "10.0.0.1", resp.Answer[0].(*dns.A).A.String())
+
+	// Neither reading has instances but one failed, so the name may exist
+	resp = query(t, server.UDPAddr(), "missing.web.service.nomad.", dns.TypeA)
+	must.Eq(t, dns.RcodeServerFailure, resp.Rcode)
+}
@@ -5,6 +5,7 @@ package checks
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"maps"
@@ -21,6 +22,10 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/shoenig/test/must"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"oss.indeed.com/go/libtime/libtimetest"
 )
 
@@ -595,6 +600,160 @@ func TestChecker_Do_TCP(t *testing.T) {
 	}
 }
 
+func TestChecker_Do_GRPC(t *testing.T) {
+	ci.Parallel(t)
+
+	// create a mock clock so we can assert time is set
+	now := time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC)
+	clock := libtimetest.NewClockMock(t).NowMock.Return(now)
+
+	// start a grpc server with a health service
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	must.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("up", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("down", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	go func() {
+		_ = server.Serve(l)
+	}()
+	t.Cleanup(server.Stop)
+
+	qc := &QueryContext{
+		ID:               "abc123",
+		CustomAddress:    "127.0.0.1",
+		ServicePortLabel: fmt.Sprintf("%d", port),
+		NetworkStatus:    mock.NewNetworkStatus("127.0.0.1"),
+		Group:            "group",
+		Task:             "task",
+		Service:          "service",
+		Check:            "check",
+	}
+
+	makeQuery := func(service string) *Query {
+		return &Query{
+			Mode:        structs.Healthiness,
+			Type:        "grpc",
+			Timeout:     time.Second,
+			AddressMode: "auto",
+			PortLabel:   fmt.Sprintf("%d", port),
+			GRPCService: service,
+		}
+	}
+
+	makeExpResult := func(status structs.CheckStatus, output string) *structs.CheckQueryResult {
+		return &structs.CheckQueryResult{
+			ID:        "abc123",
+			Mode:      structs.Healthiness,
+			Status:    status,
+			Output:    output,
+			Timestamp: now.Unix(),
+			Group:     "group",
+			Task:      "task",
+			Service:   "service",
+			Check:     "check",
+		}
+	}
+
+	cases := []struct {
+		name      string
+		q         *Query
+		expResult *structs.CheckQueryResult
+	}{{
+		name:      "grpc server ok",
+		q:         makeQuery(""),
+		expResult: makeExpResult(structs.CheckSuccess, "nomad: grpc ok"),
+	}, {
+		name:      "grpc service ok",
+		q:         makeQuery("up"),
+		expResult: makeExpResult(structs.CheckSuccess, "nomad: grpc ok"),
+	}, {
+		name:      "grpc service not serving",
+		q:         makeQuery("down"),
+		expResult: makeExpResult(structs.CheckFailure, "nomad: grpc status NOT_SERVING"),
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := testlog.HCLogger(t)
+
+			c := New(logger)
+			c.(*checker).clock = clock
+
+			result := c.Do(context.Background(), qc, tc.q)
+			must.Eq(t, tc.expResult, result)
+		})
+	}
+
+	t.Run("grpc service unknown", func(t *testing.T) {
+		c := New(testlog.HCLogger(t))
+		c.(*checker).clock = clock
+
+		result := c.Do(context.Background(), qc, makeQuery("unknown"))
+		must.Eq(t, structs.CheckFailure, result.Status)
+		must.StrContains(t, result.Output, "NotFound")
+	})
+}
+
+func TestChecker_Do_GRPC_TLS(t *testing.T) {
+	ci.Parallel(t)
+
+	// borrow the certificate of an httptest server
+	ts := httptest.NewTLSServer(http.NotFoundHandler())
+	ts.Close()
+	cert := ts.TLS.Certificates[0]
+
+	// start a grpc server with TLS that records the requested server name
+	serverNames := make(chan string, 1)
+	creds := credentials.NewTLS(&tls.Config{
+		GetCertificate: func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			serverNames <- hello.ServerName
+			return &cert, nil
+		},
+	})
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	must.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+
+	server := grpc.NewServer(grpc.Creds(creds))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() {
+		_ = server.Serve(l)
+	}()
+	t.Cleanup(server.Stop)
+
+	qc := &QueryContext{
+		ID:               "abc123",
+		CustomAddress:    "127.0.0.1",
+		ServicePortLabel: fmt.Sprintf("%d", port),
+		NetworkStatus:    mock.NewNetworkStatus("127.0.0.1"),
+		Group:            "group",
+		Task:             "task",
+		Service:          "service",
+		Check:            "check",
+	}
+	q := &Query{
+		Mode:          structs.Healthiness,
+		Type:          "grpc",
+		Timeout:       time.Second,
+		AddressMode:   "auto",
+		PortLabel:     fmt.Sprintf("%d", port),
+		GRPCUseTLS:    true,
+		TLSSkipVerify: true,
+		TLSServerName: "example.com",
+	}
+
+	c := New(testlog.HCLogger(t))
+	result := c.Do(context.Background(), qc, q)
+	must.Eq(t, structs.CheckSuccess, result.Status)
+	must.Eq(t, "example.com", <-serverNames)
+}
+
 // tcpServer will start a tcp listener that accepts connections and closes them.
 // The caller can close the listener by cancelling ctx.
 func tcpServer(t *testing.T, ctx context.Context, port int) {
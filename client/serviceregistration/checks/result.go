@@ -29,6 +29,9 @@ func GetCheckQuery(c *structs.ServiceCheck) *Query {
 		Headers:       maps.Clone(c.Header),
 		Body:          c.Body,
 		TLSSkipVerify: c.TLSSkipVerify,
+		TLSServerName: c.TLSServerName,
+		GRPCService:   c.GRPCService,
+		GRPCUseTLS:    c.GRPCUseTLS,
 	}
 }
 
@@ -36,7 +39,7 @@ func GetCheckQuery(c *structs.ServiceCheck) *Query {
 // amount of information needed to actually execute that check.
 type Query struct {
 	Mode structs.CheckMode // readiness or healthiness
-	Type string            // tcp or http
+	Type string            // tcp, http, or grpc
 
 	Timeout time.Duration // connection / request timeout
 
@@ -48,7 +51,11 @@ type Query struct {
 	Method        string      // http checks only
 	Headers       http.Header // http checks only
 	Body          string      // http checks only
-	TLSSkipVerify bool        // http checks only, https protocol
+	TLSSkipVerify bool        // http checks with https protocol, grpc checks with tls
+	TLSServerName string      // http checks with https protocol, grpc checks with tls
+
+	GRPCService string // grpc checks only
+	GRPCUseTLS  bool   // grpc checks only
 }
 
 // A QueryContext contains allocation and service parameters necessary for
@@ -161,6 +161,21 @@ type FsLogsRequest struct {
 	// Follow follows logs.
 	Follow bool
 
+	// Include is a regular expression that log lines must match to be
+	// streamed.
+	Include string
+
+	// Exclude is a regular expression that log lines must not match to be
+	// streamed.
+	Exclude string
+
+	// Since and Until bound the timestamps of the streamed log lines. Lines
+	// are only filtered by time if they start with a timestamp; lines without
+	// one inherit the timestamp of the previous line. The zero value leaves
+	// the bound open.
+	Since time.Time
+	Until time.Time
+
 	structs.QueryOptions
 }
 
@@ -229,6 +244,56 @@ type AllocStatsResponse struct {
 	structs.QueryMeta
 }
 
+// AllocStatsHistoryRequest is used to request the resource usage history of a
+// given allocation, potentially filtering by task
+type AllocStatsHistoryRequest struct {
+	// AllocID is the allocation to retrieve the history for
+	AllocID string
+
+	// Task is an optional filter to only request the history of the task.
+	Task string
+
+	structs.QueryOptions
+}
+
+// AllocStatsHistoryResponse is used to return the resource usage history of a
+// given allocation.
+type AllocStatsHistoryResponse struct {
+	// Tasks contains the resource usage history of each task, oldest sample
+	// first
+	Tasks map[string][]*StatsSample
+	structs.QueryMeta
+}
+
+// ClientStatsHistoryResponse is used to return the resource usage history of
+// a node.
+type ClientStatsHistoryResponse struct {
+	// HostStats is the resource usage history of the host, oldest sample first
+	HostStats []*StatsSample
+	structs.QueryMeta
+}
+
+// StatsSample summarizes the resource usage of a task or host over a window of
+// time. Recent samples cover short windows, while older samples are
+// downsampled into longer windows.
+type StatsSample struct {
+	// Timestamp is the end of the window, in UnixNano
+	Timestamp int64
+
+	// Window is the duration summarized by the sample
+	Window time.Duration
+
+	// CPUTicks and CPUTicksMax are the mean and maximum CPU usage in MHz over
+	// the window
+	CPUTicks    float64
+	CPUTicksMax float64
+
+	// MemoryBytes and MemoryBytesMax are the mean and maximum memory usage in
+	// bytes over the window
+	MemoryBytes    uint64
+	MemoryBytesMax uint64
+}
+
 // MemoryStats holds memory usage related stats
 type MemoryStats struct {
 	RSS            uint64
@@ -9,6 +9,7 @@ import (
 	"io"
 	golog "log"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -565,6 +566,20 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		return nil, fmt.Errorf("deploy_query_rate_limit must be greater than 0")
 	}
 
+	// Set the metrics sources of canary analyses
+	if sources := agentConfig.Server.CanaryAnalysisSources; len(sources) != 0 {
+		conf.CanaryAnalysisSources = make(map[string]string, len(sources))
+		for _, source := range sources {
+			if _, ok := conf.CanaryAnalysisSources[source.Name]; ok {
+				return nil, fmt.Errorf("canary_analysis_source %q is defined more than once", source.Name)
+			}
+			if u, err := url.Parse(source.Address); err != nil || u.Scheme == "" || u.Host == "" {
+				return nil, fmt.Errorf("canary_analysis_source %q address must be an absolute URL: %q", source.Name, source.Address)
+			}
+			conf.CanaryAnalysisSources[source.Name] = source.Address
+		}
+	}
+
 	// Set plan rejection tracker configuration.
 	if planRejectConf := agentConfig.Server.PlanRejectionTracker; planRejectConf != nil {
 		if planRejectConf.Enabled != nil {
@@ -579,6 +594,21 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		}
 	}
 
+	// Set event log configuration.
+	if eventLogConf := agentConfig.Server.EventLog; eventLogConf != nil {
+		if eventLogConf.Enabled != nil {
+			conf.EventLogEnabled = *eventLogConf.Enabled
+		}
+		if eventLogConf.MaxAge < 0 {
+			return nil, fmt.Errorf("event_log.max_age must not be negative")
+		}
+		if eventLogConf.MaxSizeMB < 0 {
+			return nil, fmt.Errorf("event_log.max_size_mb must not be negative")
+		}
+		conf.EventLogMaxAge = eventLogConf.MaxAge
+		conf.EventLogMaxSize = int64(eventLogConf.MaxSizeMB) * 1024 * 1024
+	}
+
 	// Add Enterprise license configs
 	conf.LicenseConfig = &nomad.LicenseConfig{
 		BuildDate:         agentConfig.Version.BuildDate,
@@ -951,8 +981,20 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	}
 	conf.Drain = drainConfig
 
+	serviceDNSConfig, err := clientconfig.ServiceDNSConfigFromAgent(agentConfig.Client.ServiceDNS)
+	if err != nil {
+		return nil, fmt.Errorf("invalid service_dns config: %v", err)
+	}
+	conf.ServiceDNS = serviceDNSConfig
+
 	conf.Users = clientconfig.UsersConfigFromAgent(agentConfig.Client.Users)
 
+	logSinks, err := clientconfig.LogSinksFromAgent(agentConfig.Client.LogSinks)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log_sink config: %v", err)
+	}
+	conf.LogSinks = logSinks
+
 	return conf, nil
 }
 
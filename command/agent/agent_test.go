@@ -448,6 +448,33 @@ func TestAgent_ServerConfig_PlanRejectionTracker(t *testing.T) {
 	}
 }
 
+func TestAgent_ServerConfig_EventLog(t *testing.T) {
+	ci.Parallel(t)
+
+	config := DevConfig(nil)
+	must.NoError(t, config.normalizeAddrs())
+
+	// the event log is disabled by default
+	serverConfig, err := convertServerConfig(config)
+	must.NoError(t, err)
+	must.False(t, serverConfig.EventLogEnabled)
+
+	config.Server.EventLog = config.Server.EventLog.Merge(&EventLog{
+		Enabled:   pointer.Of(true),
+		MaxAge:    24 * time.Hour,
+		MaxSizeMB: 10,
+	})
+	serverConfig, err = convertServerConfig(config)
+	must.NoError(t, err)
+	must.True(t, serverConfig.EventLogEnabled)
+	must.Eq(t, 24*time.Hour, serverConfig.EventLogMaxAge)
+	must.Eq(t, 10*1024*1024, serverConfig.EventLogMaxSize)
+
+	config.Server.EventLog.MaxSizeMB = -1
+	_, err = convertServerConfig(config)
+	must.ErrorContains(t, err, "event_log.max_size_mb must not be negative")
+}
+
 func TestAgent_ServerConfig_RaftMultiplier_Ok(t *testing.T) {
 	ci.Parallel(t)
 
@@ -409,6 +409,14 @@ type ClientConfig struct {
 	// Users is used to configure parameters around operating system users.
 	Users *config.UsersConfig `hcl:"users"`
 
+	// ServiceDNS configures the DNS server for services registered with the
+	// Nomad service discovery provider.
+	ServiceDNS *config.ServiceDNSConfig `hcl:"service_dns"`
+
+	// LogSinks are the sinks that task log lines are shipped to in addition
+	// to the allocation's log files.
+	LogSinks []*config.LogSinkConfig `hcl:"log_sink"`
+
 	// ExtraKeysHCL is used by hcl to surface unexpected keys
 	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
 }
@@ -433,6 +441,8 @@ func (c *ClientConfig) Copy() *ClientConfig {
 	nc.Artifact = c.Artifact.Copy()
 	nc.Drain = c.Drain.Copy()
 	nc.Users = c.Users.Copy()
+	nc.ServiceDNS = c.ServiceDNS.Copy()
+	nc.LogSinks = helper.CopySlice(c.LogSinks)
 	nc.ExtraKeysHCL = slices.Clone(c.ExtraKeysHCL)
 	return &nc
 }
@@ -676,6 +686,16 @@ type ServerConfig struct {
 	// for the EventBufferSize is 1.
 	EventBufferSize *int `hcl:"event_buffer_size"`
 
+	// EventLog configures the durable event log, which stores events on disk
+	// so event stream subscribers can resume from indexes that are no longer
+	// held in memory.
+	EventLog *EventLog `hcl:"event_log"`
+
+	// CanaryAnalysisSources are the metrics sources that the canary analyses
+	// of jobs can query. Jobs refer to a source by name, so they can't make
+	// the leader query arbitrary addresses.
+	CanaryAnalysisSources []*CanaryAnalysisSource `hcl:"canary_analysis_source"`
+
 	// LicensePath is the path to search for an enterprise license.
 	LicensePath string `hcl:"license_path"`
 
@@ -755,6 +775,8 @@ func (s *ServerConfig) Copy() *ServerConfig {
 	ns.PlanRejectionTracker = s.PlanRejectionTracker.Copy()
 	ns.EnableEventBroker = pointer.Copy(s.EnableEventBroker)
 	ns.EventBufferSize = pointer.Copy(s.EventBufferSize)
+	ns.EventLog = s.EventLog.Copy()
+	ns.CanaryAnalysisSources = helper.CopySlice(s.CanaryAnalysisSources)
 	ns.JobMaxSourceSize = pointer.Copy(s.JobMaxSourceSize)
 	ns.licenseAdditionalPublicKeys = slices.Clone(s.licenseAdditionalPublicKeys)
 	ns.ExtraKeysHCL = slices.Clone(s.ExtraKeysHCL)
@@ -847,6 +869,94 @@ func (p *PlanRejectionTracker) Merge(b *PlanRejectionTracker) *PlanRejectionTrac
 	return &result
 }
 
+// CanaryAnalysisSource is used in servers to configure a metrics source that
+// the canary analyses of jobs can query.
+type CanaryAnalysisSource struct {
+	// Name is the unique name jobs refer to the source by
+	Name string `hcl:",key"`
+
+	// Address is the address of the Prometheus-compatible HTTP API of the
+	// source.
+	Address string `hcl:"address"`
+}
+
+func (c *CanaryAnalysisSource) Copy() *CanaryAnalysisSource {
+	if c == nil {
+		return nil
+	}
+
+	nc := *c
+	return &nc
+}
+
+// mergeCanaryAnalysisSources merges two lists of canary analysis sources by
+// name, the sources of b replacing those of a with the same name.
+func mergeCanaryAnalysisSources(a, b []*CanaryAnalysisSource) []*CanaryAnalysisSource {
+	n := helper.CopySlice(a)
+	for _, source := range b {
+		i := slices.IndexFunc(n, func(s *CanaryAnalysisSource) bool { return s.Name == source.Name })
+		if i >= 0 {
+			n[i] = source.Copy()
+			continue
+		}
+		n = append(n, source.Copy())
+	}
+	return n
+}
+
+// EventLog is used in servers to configure the durable event log.
+type EventLog struct {
+	// Enabled controls if events are written to the event log.
+	Enabled *bool `hcl:"enabled"`
+
+	// MaxAge is how long events are retained in the event log.
+	MaxAge    time.Duration
+	MaxAgeHCL string `hcl:"max_age" json:"-"`
+
+	// MaxSizeMB is the maximum size of the event log on disk in megabytes.
+	MaxSizeMB int `hcl:"max_size_mb"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+func (e *EventLog) Copy() *EventLog {
+	if e == nil {
+		return nil
+	}
+
+	ne := *e
+	ne.Enabled = pointer.Copy(e.Enabled)
+	ne.ExtraKeysHCL = slices.Clone(e.ExtraKeysHCL)
+	return &ne
+}
+
+func (e *EventLog) Merge(b *EventLog) *EventLog {
+	if e == nil {
+		return b.Copy()
+	}
+
+	result := e.Copy()
+
+	if b == nil {
+		return result
+	}
+
+	if b.Enabled != nil {
+		result.Enabled = pointer.Copy(b.Enabled)
+	}
+	if b.MaxAge != 0 {
+		result.MaxAge = b.MaxAge
+	}
+	if b.MaxAgeHCL != "" {
+		result.MaxAgeHCL = b.MaxAgeHCL
+	}
+	if b.MaxSizeMB != 0 {
+		result.MaxSizeMB = b.MaxSizeMB
+	}
+	return result
+}
+
 // Search is used in servers to configure search API options.
 type Search struct {
 	// FuzzyEnabled toggles whether the FuzzySearch API is enabled. If not
@@ -1442,6 +1552,11 @@ func DefaultConfig() *Config {
 				NodeThreshold: 100,
 				NodeWindow:    5 * time.Minute,
 			},
+			EventLog: &EventLog{
+				Enabled:   pointer.Of(false),
+				MaxAge:    72 * time.Hour,
+				MaxSizeMB: 1024,
+			},
 			ServerJoin: &ServerJoin{
 				RetryJoin:        []string{},
 				RetryInterval:    30 * time.Second,
@@ -2240,6 +2355,14 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 		result.EventBufferSize = b.EventBufferSize
 	}
 
+	if b.EventLog != nil {
+		result.EventLog = result.EventLog.Merge(b.EventLog)
+	}
+
+	if len(b.CanaryAnalysisSources) != 0 {
+		result.CanaryAnalysisSources = mergeCanaryAnalysisSources(s.CanaryAnalysisSources, b.CanaryAnalysisSources)
+	}
+
 	result.JobMaxSourceSize = pointer.Merge(s.JobMaxSourceSize, b.JobMaxSourceSize)
 
 	if b.PlanRejectionTracker != nil {
@@ -2485,6 +2608,12 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 		result.BindWildcardDefaultHostNetwork = true
 	}
 
+	result.LogSinks = a.LogSinks
+
+	if len(b.LogSinks) != 0 {
+		result.LogSinks = append(result.LogSinks, b.LogSinks...)
+	}
+
 	// This value is a pointer, therefore if it is not nil the user has
 	// supplied an override value.
 	if b.NomadServiceDiscovery != nil {
@@ -2498,6 +2627,7 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	result.Artifact = a.Artifact.Merge(b.Artifact)
 	result.Drain = a.Drain.Merge(b.Drain)
 	result.Users = a.Users.Merge(b.Users)
+	result.ServiceDNS = a.ServiceDNS.Merge(b.ServiceDNS)
 
 	return &result
 }
@@ -183,6 +183,12 @@ func ParseConfigFile(path string) (*Config, error) {
 			&c.Reporting.ExportInterval, &c.Reporting.ExportIntervalHCL, nil},
 	}
 
+	if c.Server.EventLog != nil {
+		tds = append(tds, durationConversionMap{
+			"server.event_log.max_age", &c.Server.EventLog.MaxAge, &c.Server.EventLog.MaxAgeHCL, nil,
+		})
+	}
+
 	// Parse durations for Consul and Vault config blocks if provided.
 	for _, consulConfig := range c.Consuls {
 
@@ -309,6 +315,13 @@ func extraKeys(c *Config) error {
 		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "host_network")
 	}
 
+	// Remove LogSink extra keys
+	for _, s := range c.Client.LogSinks {
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, s.Name)
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "log_sink")
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "headers")
+	}
+
 	// Remove Template extra keys
 	for _, t := range []string{"function_denylist", "disable_file_sandbox", "max_stale", "wait", "wait_bounds", "block_query_wait", "consul_retry", "vault_retry", "nomad_retry"} {
 		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, t)
@@ -331,10 +344,16 @@ func extraKeys(c *Config) error {
 		helper.RemoveEqualFold(&c.ExtraKeysHCL, "server")
 	}
 
-	for _, k := range []string{"preemption_config"} {
+	for _, k := range []string{"preemption_config", "rank_iterator", "fair_share_config"} {
 		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, k)
 	}
 
+	// Remove CanaryAnalysisSource extra keys
+	for _, s := range c.Server.CanaryAnalysisSources {
+		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, s.Name)
+		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, "canary_analysis_source")
+	}
+
 	for _, k := range []string{"datadog_tags"} {
 		helper.RemoveEqualFold(&c.ExtraKeysHCL, k)
 		helper.RemoveEqualFold(&c.ExtraKeysHCL, "telemetry")
@@ -1176,3 +1176,59 @@ func TestConfig_Template(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_LogSinks(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, suffix := range []string{"hcl", "json"} {
+		t.Run(suffix, func(t *testing.T) {
+			cfg := DefaultConfig()
+			fc, err := LoadConfig("testdata/log-sink." + suffix)
+			must.NoError(t, err)
+			must.SliceEmpty(t, fc.Client.ExtraKeysHCL)
+			cfg = cfg.Merge(fc)
+
+			must.Eq(t, []*config.LogSinkConfig{
+				{
+					Name:     "syslog",
+					Type:     "syslog",
+					Address:  "udp://127.0.0.1:514",
+					Facility: "local3",
+				},
+				{
+					Name:       "otel",
+					Type:       "otlp",
+					Address:    "https://otel.example.com:4318",
+					Headers:    map[string]string{"Authorization": "Bearer token"},
+					BufferSize: 4096,
+				},
+			}, cfg.Client.LogSinks)
+		})
+	}
+}
+
+func TestConfig_CanaryAnalysisSources(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, suffix := range []string{"hcl", "json"} {
+		t.Run(suffix, func(t *testing.T) {
+			cfg := DefaultConfig()
+			fc, err := LoadConfig("testdata/canary-analysis-source." + suffix)
+			must.NoError(t, err)
+			must.SliceEmpty(t, fc.Server.ExtraKeysHCL)
+			cfg = cfg.Merge(fc)
+
+			// Sources of later configs replace the sources of the same name
+			cfg = cfg.Merge(&Config{Server: &ServerConfig{
+				CanaryAnalysisSources: []*CanaryAnalysisSource{
+					{Name: "thanos", Address: "https://thanos.example.com:10902"},
+				},
+			}})
+
+			must.Eq(t, []*CanaryAnalysisSource{
+				{Name: "prometheus", Address: "http://prometheus.service.consul:9090"},
+				{Name: "thanos", Address: "https://thanos.example.com:10902"},
+			}, cfg.Server.CanaryAnalysisSources)
+		})
+	}
+}
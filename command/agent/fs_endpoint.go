@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/hashicorp/go-msgpack/v2/codec"
@@ -264,6 +265,10 @@ func (s *HTTPServer) Stream(resp http.ResponseWriter, req *http.Request) (interf
 //   - offset: The offset to start streaming data at, defaults to zero.
 //   - origin: Either "start" or "end" and defines from where the offset is
 //     applied. Defaults to "start".
+//   - include: A regular expression log lines must match.
+//   - exclude: A regular expression log lines must not match.
+//   - since: An RFC3339 time; lines with an earlier timestamp are skipped.
+//   - until: An RFC3339 time; lines with a later timestamp are skipped.
 func (s *HTTPServer) Logs(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	var allocID, task, logType string
 	var plain, follow bool
@@ -315,6 +320,18 @@ func (s *HTTPServer) Logs(resp http.ResponseWriter, req *http.Request) (interfac
 		return nil, invalidOrigin
 	}
 
+	var since, until time.Time
+	if sinceStr := q.Get("since"); sinceStr != "" {
+		if since, err = time.Parse(time.RFC3339Nano, sinceStr); err != nil {
+			return nil, CodedError(400, fmt.Sprintf("error parsing since: %v", err))
+		}
+	}
+	if untilStr := q.Get("until"); untilStr != "" {
+		if until, err = time.Parse(time.RFC3339Nano, untilStr); err != nil {
+			return nil, CodedError(400, fmt.Sprintf("error parsing until: %v", err))
+		}
+	}
+
 	// Create the request arguments
 	fsReq := &cstructs.FsLogsRequest{
 		AllocID:   allocID,
@@ -324,6 +341,10 @@ func (s *HTTPServer) Logs(resp http.ResponseWriter, req *http.Request) (interfac
 		Origin:    origin,
 		PlainText: plain,
 		Follow:    follow,
+		Include:   q.Get("include"),
+		Exclude:   q.Get("exclude"),
+		Since:     since,
+		Until:     until,
 	}
 	s.parse(resp, req, &fsReq.QueryOptions.Region, &fsReq.QueryOptions)
 
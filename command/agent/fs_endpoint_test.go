@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -544,6 +545,42 @@ func TestHTTP_FS_Logs(t *testing.T) {
 	})
 }
 
+func TestHTTP_FS_Logs_Filter(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		a := mockFSAlloc(s.client.NodeID(), nil)
+		addAllocToClient(s, a, terminalClientAlloc)
+
+		// Invalid time bounds are rejected
+		req, err := http.NewRequest(http.MethodGet,
+			"/v1/client/fs/logs/"+a.ID+"?type=stdout&task=web&since=yesterday", nil)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+		s.Server.mux.ServeHTTP(respW, req)
+		require.Equal(400, respW.Code)
+		require.Contains(respW.Body.String(), "error parsing since")
+
+		// Only matching lines are streamed
+		for include, expectation := range map[string]string{
+			"other":   defaultLoggerMockDriverStdout,
+			"^Hello$": "",
+		} {
+			path := fmt.Sprintf("/v1/client/fs/logs/%s?type=stdout&task=web&plain=true&include=%s",
+				a.ID, url.QueryEscape(include))
+			req, err := http.NewRequest(http.MethodGet, path, nil)
+			require.NoError(err)
+			respW := testutil.NewResponseRecorder()
+			_, err = s.Server.Logs(respW, req)
+			require.NoError(err)
+
+			output, err := io.ReadAll(respW)
+			require.NoError(err)
+			require.Equal(expectation, string(output))
+		}
+	})
+}
+
 // TestHTTP_FS_Logs_XSS asserts that the logs endpoint always returns
 // text/plain or application/json content regardless of whether the logs are
 // HTML+Javascript or not.
@@ -81,9 +81,15 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/periodic/force"):
 		jobID := strings.TrimSuffix(path, "/periodic/force")
 		return s.periodicForceRequest(resp, req, jobID)
+	case strings.HasSuffix(path, "/periodic/history"):
+		jobID := strings.TrimSuffix(path, "/periodic/history")
+		return s.periodicHistoryRequest(resp, req, jobID)
 	case strings.HasSuffix(path, "/plan"):
 		jobID := strings.TrimSuffix(path, "/plan")
 		return s.jobPlan(resp, req, jobID)
+	case strings.HasSuffix(path, "/stats"):
+		jobID := strings.TrimSuffix(path, "/stats")
+		return s.jobStatsRequest(resp, req, jobID)
 	case strings.HasSuffix(path, "/summary"):
 		jobID := strings.TrimSuffix(path, "/summary")
 		return s.jobSummaryRequest(resp, req, jobID)
@@ -93,6 +99,9 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/dispatch/payload"):
 		jobID := strings.TrimSuffix(path, "/dispatch/payload")
 		return s.jobDispatchPayloadRequest(resp, req, jobID)
+	case strings.HasSuffix(path, "/dispatch/queue"):
+		jobID := strings.TrimSuffix(path, "/dispatch/queue")
+		return s.jobDispatchQueue(resp, req, jobID)
 	case strings.HasSuffix(path, "/versions"):
 		jobID := strings.TrimSuffix(path, "/versions")
 		return s.jobVersions(resp, req, jobID)
@@ -257,6 +266,31 @@ func (s *HTTPServer) periodicForceRequest(resp http.ResponseWriter, req *http.Re
 	return out, nil
 }
 
+func (s *HTTPServer) periodicHistoryRequest(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.JobSpecificRequest{
+		JobID: jobName,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.PeriodicHistoryResponse
+	if err := s.agent.RPC("Periodic.History", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Runs == nil {
+		out.Runs = make([]*structs.PeriodicRun, 0)
+	}
+	return out.Runs, nil
+}
+
 func (s *HTTPServer) jobAllocations(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
 	if req.Method != http.MethodGet {
 		return nil, CodedError(405, ErrInvalidMethod)
@@ -884,6 +918,28 @@ func (s *HTTPServer) jobSummaryRequest(resp http.ResponseWriter, req *http.Reque
 	return out.JobSummary, nil
 }
 
+func (s *HTTPServer) jobStatsRequest(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+	terminal, _ := strconv.ParseBool(req.URL.Query().Get("terminal"))
+	args := structs.JobStatsRequest{
+		JobID:           jobID,
+		IncludeTerminal: terminal,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.JobStatsResponse
+	if err := s.agent.RPC("Job.Stats", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	return out.Stats, nil
+}
+
 func (s *HTTPServer) jobDispatchRequest(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
 	if req.Method != http.MethodPut && req.Method != http.MethodPost {
 		return nil, CodedError(405, ErrInvalidMethod)
@@ -933,6 +989,29 @@ func (s *HTTPServer) jobDispatchPayloadRequest(resp http.ResponseWriter, req *ht
 	return out, nil
 }
 
+func (s *HTTPServer) jobDispatchQueue(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+	args := structs.JobSpecificRequest{
+		JobID: jobID,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.JobDispatchQueueResponse
+	if err := s.agent.RPC("Job.DispatchQueue", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Dispatches == nil {
+		out.Dispatches = make([]*structs.QueuedDispatchStub, 0)
+	}
+	return out.Dispatches, nil
+}
+
 // JobsParseRequest parses a hcl jobspec and returns a api.Job
 func (s *HTTPServer) JobsParseRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	if req.Method != http.MethodPut && req.Method != http.MethodPost {
@@ -1181,6 +1260,9 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 			SpecType:        *job.Periodic.SpecType,
 			ProhibitOverlap: *job.Periodic.ProhibitOverlap,
 			TimeZone:        *job.Periodic.TimeZone,
+
+			SuccessfulJobsHistoryLimit: pointer.Copy(job.Periodic.SuccessfulJobsHistoryLimit),
+			FailedJobsHistoryLimit:     pointer.Copy(job.Periodic.FailedJobsHistoryLimit),
 		}
 
 		if job.Periodic.Spec != nil {
@@ -1190,6 +1272,14 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		if job.Periodic.Specs != nil {
 			j.Periodic.Specs = job.Periodic.Specs
 		}
+
+		if job.Periodic.Catchup != nil {
+			j.Periodic.Catchup = &structs.PeriodicCatchup{
+				Policy:           *job.Periodic.Catchup.Policy,
+				Limit:            *job.Periodic.Catchup.Limit,
+				StartingDeadline: *job.Periodic.Catchup.StartingDeadline,
+			}
+		}
 	}
 
 	if job.ParameterizedJob != nil {
@@ -1198,6 +1288,37 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 			MetaRequired: job.ParameterizedJob.MetaRequired,
 			MetaOptional: job.ParameterizedJob.MetaOptional,
 		}
+
+		if job.ParameterizedJob.MaxConcurrent != nil {
+			j.ParameterizedJob.MaxConcurrent = *job.ParameterizedJob.MaxConcurrent
+		}
+
+		if queue := job.ParameterizedJob.Queue; queue != nil {
+			j.ParameterizedJob.Queue = &structs.DispatchQueueConfig{}
+			if queue.MaxDepth != nil {
+				j.ParameterizedJob.Queue.MaxDepth = *queue.MaxDepth
+			}
+		}
+
+		if rate := job.ParameterizedJob.RateLimit; rate != nil {
+			j.ParameterizedJob.RateLimit = &structs.DispatchRateLimit{}
+			if rate.Limit != nil {
+				j.ParameterizedJob.RateLimit.Limit = *rate.Limit
+			}
+			if rate.Interval != nil {
+				j.ParameterizedJob.RateLimit.Interval = *rate.Interval
+			}
+		}
+	}
+
+	if l := len(job.DependsOn); l != 0 {
+		j.DependsOn = make([]*structs.JobDependency, l)
+		for i, dep := range job.DependsOn {
+			j.DependsOn[i] = &structs.JobDependency{
+				JobID:     dep.JobID,
+				Condition: *dep.Condition,
+			}
+		}
 	}
 
 	if job.Multiregion != nil {
@@ -1278,6 +1399,14 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		}
 	}
 
+	if taskGroup.Gang != nil {
+		tg.Gang = &structs.Gang{}
+
+		if taskGroup.Gang.Name != nil {
+			tg.Gang.Name = *taskGroup.Gang.Name
+		}
+	}
+
 	if taskGroup.Migrate != nil {
 		tg.Migrate = &structs.MigrateStrategy{
 			MaxParallel:     *taskGroup.Migrate.MaxParallel,
@@ -1355,6 +1484,17 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		if taskGroup.Update.AutoPromote != nil {
 			tg.Update.AutoPromote = *taskGroup.Update.AutoPromote
 		}
+
+		if taskGroup.Update.Analysis != nil {
+			tg.Update.Analysis = ApiCanaryAnalysisToStructs(taskGroup.Update.Analysis)
+		}
+
+		for _, step := range taskGroup.Update.Steps {
+			tg.Update.Steps = append(tg.Update.Steps, &structs.RolloutStep{
+				Percent: *step.Percent,
+				Pause:   *step.Pause,
+			})
+		}
 	}
 
 	if len(taskGroup.Tasks) > 0 {
@@ -1373,6 +1513,31 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 	}
 }
 
+// ApiCanaryAnalysisToStructs converts a canonicalized canary analysis.
+func ApiCanaryAnalysisToStructs(in *api.CanaryAnalysis) *structs.CanaryAnalysis {
+	if in == nil {
+		return nil
+	}
+
+	out := &structs.CanaryAnalysis{
+		Source:         *in.Source,
+		Interval:       *in.Interval,
+		Window:         *in.Window,
+		SuccessfulRuns: *in.SuccessfulRuns,
+		FailureLimit:   *in.FailureLimit,
+		OnFailure:      *in.OnFailure,
+	}
+	for _, m := range in.Metrics {
+		out.Metrics = append(out.Metrics, &structs.CanaryMetric{
+			Name:        m.Name,
+			Query:       m.Query,
+			Max:         pointer.Copy(m.Max),
+			MaxIncrease: pointer.Copy(m.MaxIncrease),
+		})
+	}
+	return out
+}
+
 // ApiTaskToStructsTask is a copy and type conversion between the API
 // representation of a task from a struct representation of a task.
 func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
@@ -1631,6 +1796,23 @@ func ApiResourcesToStructs(in *api.Resources) *structs.Resources {
 		out.SecretsMB = *in.SecretsMB
 	}
 
+	if in.IOWeight != nil {
+		out.IOWeight = *in.IOWeight
+	}
+
+	if len(in.IOMax) > 0 {
+		out.IOMax = make(structs.IOLimits, len(in.IOMax))
+		for i, l := range in.IOMax {
+			out.IOMax[i] = &structs.IOLimit{
+				Device:    l.Device,
+				ReadBps:   l.ReadBps,
+				WriteBps:  l.WriteBps,
+				ReadIOps:  l.ReadIOps,
+				WriteIOps: l.WriteIOps,
+			}
+		}
+	}
+
 	return out
 }
 
@@ -1642,11 +1824,12 @@ func ApiNetworkResourceToStructs(in []*api.NetworkResource) []*structs.NetworkRe
 	out = make([]*structs.NetworkResource, len(in))
 	for i, nw := range in {
 		out[i] = &structs.NetworkResource{
-			Mode:     nw.Mode,
-			CIDR:     nw.CIDR,
-			IP:       nw.IP,
-			Hostname: nw.Hostname,
-			MBits:    nw.Megabits(),
+			Mode:        nw.Mode,
+			CIDR:        nw.CIDR,
+			IP:          nw.IP,
+			Hostname:    nw.Hostname,
+			MBits:       nw.Megabits(),
+			EgressMBits: nw.EgressMBits,
 		}
 
 		if nw.DNS != nil {
@@ -2173,11 +2356,19 @@ func apiLogConfigToStructs(in *api.LogConfig) *structs.LogConfig {
 		return nil
 	}
 
-	return &structs.LogConfig{
-		Disabled:      dereferenceBool(in.Disabled),
-		MaxFiles:      dereferenceInt(in.MaxFiles),
-		MaxFileSizeMB: dereferenceInt(in.MaxFileSizeMB),
+	out := &structs.LogConfig{
+		Disabled:       dereferenceBool(in.Disabled),
+		MaxFiles:       dereferenceInt(in.MaxFiles),
+		MaxFileSizeMB:  dereferenceInt(in.MaxFileSizeMB),
+		MaxTotalSizeMB: dereferenceInt(in.MaxTotalSizeMB),
+	}
+	if in.Compression != nil {
+		out.Compression = *in.Compression
 	}
+	if in.RotationInterval != nil {
+		out.RotationInterval = *in.RotationInterval
+	}
+	return out
 }
 
 func dereferenceBool(in *bool) bool {
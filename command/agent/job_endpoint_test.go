@@ -2640,11 +2640,27 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 			SpecType:        pointer.Of("cron"),
 			ProhibitOverlap: pointer.Of(true),
 			TimeZone:        pointer.Of("test zone"),
+
+			SuccessfulJobsHistoryLimit: pointer.Of(5),
+			FailedJobsHistoryLimit:     pointer.Of(2),
+			Catchup: &api.PeriodicCatchup{
+				Policy:           pointer.Of("all"),
+				Limit:            pointer.Of(3),
+				StartingDeadline: pointer.Of(time.Hour),
+			},
 		},
 		ParameterizedJob: &api.ParameterizedJobConfig{
-			Payload:      "payload",
-			MetaRequired: []string{"a", "b"},
-			MetaOptional: []string{"c", "d"},
+			Payload:       "payload",
+			MetaRequired:  []string{"a", "b"},
+			MetaOptional:  []string{"c", "d"},
+			MaxConcurrent: pointer.Of(2),
+			Queue: &api.DispatchQueueConfig{
+				MaxDepth: pointer.Of(10),
+			},
+			RateLimit: &api.DispatchRateLimit{
+				Limit:    pointer.Of(5),
+				Interval: pointer.Of(time.Minute),
+			},
 		},
 		Payload: []byte("payload"),
 		Meta: map[string]string{
@@ -3076,11 +3092,27 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 			SpecType:        "cron",
 			ProhibitOverlap: true,
 			TimeZone:        "test zone",
+
+			SuccessfulJobsHistoryLimit: pointer.Of(5),
+			FailedJobsHistoryLimit:     pointer.Of(2),
+			Catchup: &structs.PeriodicCatchup{
+				Policy:           "all",
+				Limit:            3,
+				StartingDeadline: time.Hour,
+			},
 		},
 		ParameterizedJob: &structs.ParameterizedJobConfig{
-			Payload:      "payload",
-			MetaRequired: []string{"a", "b"},
-			MetaOptional: []string{"c", "d"},
+			Payload:       "payload",
+			MetaRequired:  []string{"a", "b"},
+			MetaOptional:  []string{"c", "d"},
+			MaxConcurrent: 2,
+			Queue: &structs.DispatchQueueConfig{
+				MaxDepth: 10,
+			},
+			RateLimit: &structs.DispatchRateLimit{
+				Limit:    5,
+				Interval: time.Minute,
+			},
 		},
 		Payload: []byte("payload"),
 		Meta: map[string]string{
@@ -3882,6 +3914,19 @@ func TestConversion_apiLogConfigToStructs(t *testing.T) {
 		MaxFiles:      pointer.Of(2),
 		MaxFileSizeMB: pointer.Of(8),
 	}))
+	must.Eq(t, &structs.LogConfig{
+		MaxFiles:         4,
+		MaxFileSizeMB:    8,
+		Compression:      structs.LogCompressionZstd,
+		RotationInterval: time.Hour,
+		MaxTotalSizeMB:   16,
+	}, apiLogConfigToStructs(&api.LogConfig{
+		MaxFiles:         pointer.Of(4),
+		MaxFileSizeMB:    pointer.Of(8),
+		Compression:      pointer.Of(structs.LogCompressionZstd),
+		RotationInterval: pointer.Of(time.Hour),
+		MaxTotalSizeMB:   pointer.Of(16),
+	}))
 
 	// COMPAT(1.6.0): verify backwards compatibility fixes
 	// Note: we're intentionally ignoring the Enabled: false case
@@ -3944,6 +3989,29 @@ func TestConversion_apiResourcesToStructs(t *testing.T) {
 				MemoryMaxMB: 300,
 			},
 		},
+		{
+			"with io limits",
+			&api.Resources{
+				CPU:      pointer.Of(100),
+				MemoryMB: pointer.Of(200),
+				IOWeight: pointer.Of(500),
+				IOMax: []*api.IOLimit{{
+					Device:    "sda",
+					ReadBps:   1048576,
+					WriteIOps: 100,
+				}},
+			},
+			&structs.Resources{
+				CPU:      100,
+				MemoryMB: 200,
+				IOWeight: 500,
+				IOMax: structs.IOLimits{{
+					Device:    "sda",
+					ReadBps:   1048576,
+					WriteIOps: 100,
+				}},
+			},
+		},
 		{
 			"with numa",
 			&api.Resources{
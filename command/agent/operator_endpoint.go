@@ -18,6 +18,7 @@ import (
 
 	"github.com/hashicorp/nomad/api"
 	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -328,6 +329,20 @@ func (s *HTTPServer) schedulerUpdateConfig(resp http.ResponseWriter, req *http.R
 			BatchSchedulerEnabled:    conf.PreemptionConfig.BatchSchedulerEnabled,
 			ServiceSchedulerEnabled:  conf.PreemptionConfig.ServiceSchedulerEnabled,
 		},
+		FairShareConfig: structs.FairShareConfig{
+			Enabled: conf.FairShareConfig.Enabled,
+			Weights: conf.FairShareConfig.Weights,
+		},
+	}
+
+	for _, ri := range conf.RankIterators {
+		if ri == nil {
+			continue
+		}
+		args.Config.RankIterators = append(args.Config.RankIterators, &structs.SchedulerRankIterator{
+			Name:   ri.Name,
+			Weight: pointer.Copy(ri.Weight),
+		})
 	}
 
 	if err := args.Config.Validate(); err != nil {
@@ -353,6 +368,54 @@ func (s *HTTPServer) schedulerUpdateConfig(resp http.ResponseWriter, req *http.R
 	return reply, nil
 }
 
+// OperatorSchedulerSimulate runs the scheduler against a copy of the cluster
+// state with the changes of the simulation in the request body applied.
+func (s *HTTPServer) OperatorSchedulerSimulate(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args structs.SchedulerSimulateRequest
+	if done := s.parse(resp, req, &args.Region, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var sim api.SchedulerSimulation
+	if err := decodeBody(req, &sim); err != nil {
+		return nil, CodedError(http.StatusBadRequest, fmt.Sprintf("Error parsing simulation: %v", err))
+	}
+
+	args.Simulation = &structs.SchedulerSimulation{
+		DrainNodePools: sim.DrainNodePools,
+	}
+	for _, nodes := range sim.AddNodes {
+		if nodes == nil {
+			continue
+		}
+		args.Simulation.AddNodes = append(args.Simulation.AddNodes, &structs.SimulatedNodes{
+			NodeID: nodes.NodeID,
+			Count:  nodes.Count,
+		})
+	}
+	for _, job := range sim.Jobs {
+		if job == nil {
+			continue
+		}
+		if job.ID == nil {
+			return nil, CodedError(http.StatusBadRequest, "Job must have a valid ID")
+		}
+		args.Simulation.Jobs = append(args.Simulation.Jobs, ApiJobToStructJob(job))
+	}
+
+	var reply structs.SchedulerSimulateResponse
+	if err := s.agent.RPC("Operator.SchedulerSimulate", &args, &reply); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &reply.QueryMeta)
+
+	return reply, nil
+}
+
 func (s *HTTPServer) SnapshotRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	switch req.Method {
 	case http.MethodGet:
@@ -0,0 +1,12 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: BUSL-1.1
+
+server {
+  canary_analysis_source "prometheus" {
+    address = "http://prometheus.service.consul:9090"
+  }
+
+  canary_analysis_source "thanos" {
+    address = "https://thanos.example.com"
+  }
+}
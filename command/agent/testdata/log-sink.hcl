@@ -0,0 +1,20 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: BUSL-1.1
+
+client {
+  log_sink "syslog" {
+    type     = "syslog"
+    address  = "udp://127.0.0.1:514"
+    facility = "local3"
+  }
+
+  log_sink "otel" {
+    type        = "otlp"
+    address     = "https://otel.example.com:4318"
+    buffer_size = 4096
+
+    headers {
+      Authorization = "Bearer token"
+    }
+  }
+}
@@ -5,10 +5,12 @@ package command
 
 import (
 	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"os"
 	"os/signal"
+	"regexp"
 	"strings"
 	"syscall"
 	"time"
@@ -27,6 +29,9 @@ type AllocLogsCommand struct {
 	numLines                                   int64
 	numBytes                                   int64
 	task                                       string
+
+	// filter is built from the -include, -exclude, -since and -until flags
+	filter *api.LogFilter
 }
 
 func (l *AllocLogsCommand) Help() string {
@@ -80,6 +85,8 @@ Logs Specific Options:
   -c
     Sets the tail location in number of bytes relative to the end of the logs.
 
+` + logFilterOptionsUsage + `
+
   Note that the -no-color option applies to Nomad's own output. If the task's
   logs include terminal escape sequences for color codes, Nomad will not
   remove them.
@@ -104,6 +111,10 @@ func (l *AllocLogsCommand) AutocompleteFlags() complete.Flags {
 			"-tail":    complete.PredictAnything,
 			"-n":       complete.PredictAnything,
 			"-c":       complete.PredictAnything,
+			"-include": complete.PredictAnything,
+			"-exclude": complete.PredictAnything,
+			"-since":   complete.PredictAnything,
+			"-until":   complete.PredictAnything,
 		})
 }
 
@@ -137,12 +148,20 @@ func (l *AllocLogsCommand) Run(args []string) int {
 	flags.Int64Var(&l.numLines, "n", -1, "")
 	flags.Int64Var(&l.numBytes, "c", -1, "")
 	flags.StringVar(&l.task, "task", "", "")
+	filterFlags := addLogFilterFlags(flags)
 
 	if err := flags.Parse(args); err != nil {
 		return 1
 	}
 	args = flags.Args()
 
+	var err error
+	if l.filter, err = filterFlags.filter(time.Now()); err != nil {
+		l.Ui.Error(err.Error())
+		l.Ui.Error(commandErrorText(l))
+		return 1
+	}
+
 	if numArgs := len(args); numArgs < 1 {
 		if l.job {
 			l.Ui.Error("A job ID is required")
@@ -314,7 +333,8 @@ func (l *AllocLogsCommand) followFile(client *api.Client, alloc *api.Allocation,
 	logType, origin string, offset int64) (io.ReadCloser, error) {
 
 	cancel := make(chan struct{})
-	frames, errCh := client.AllocFS().Logs(alloc, l.follow, l.task, logType, origin, offset, cancel, nil)
+	frames, errCh := client.AllocFS().LogsWithFilter(
+		alloc, l.follow, l.task, logType, origin, offset, l.filter, cancel, nil)
 
 	// Setting up the logs stream can fail, therefore we need to check the
 	// error channel before continuing further.
@@ -357,8 +377,8 @@ func (l *AllocLogsCommand) tailMultipleFiles(client *api.Client, alloc *api.Allo
 	// exit.
 	defer close(cancel)
 
-	stdoutFrames, stdoutErrCh := client.AllocFS().Logs(
-		alloc, true, l.task, api.FSLogNameStdout, api.OriginEnd, 0, cancel, nil)
+	stdoutFrames, stdoutErrCh := client.AllocFS().LogsWithFilter(
+		alloc, true, l.task, api.FSLogNameStdout, api.OriginEnd, 0, l.filter, cancel, nil)
 
 	// Setting up the logs stream can fail, therefore we need to check the
 	// error channel before continuing further.
@@ -368,8 +388,8 @@ func (l *AllocLogsCommand) tailMultipleFiles(client *api.Client, alloc *api.Allo
 	default:
 	}
 
-	stderrFrames, stderrErrCh := client.AllocFS().Logs(
-		alloc, true, l.task, api.FSLogNameStderr, api.OriginEnd, 0, cancel, nil)
+	stderrFrames, stderrErrCh := client.AllocFS().LogsWithFilter(
+		alloc, true, l.task, api.FSLogNameStderr, api.OriginEnd, 0, l.filter, cancel, nil)
 
 	// Setting up the logs stream can fail, therefore we need to check the
 	// error channel before continuing further.
@@ -434,3 +454,90 @@ func lookupAllocTask(alloc *api.Allocation) (string, error) {
 	fmt.Fprintf(&errStr, "\nPlease specify the task.")
 	return "", errors.New(errStr.String())
 }
+
+// logFilterOptionsUsage documents the log filtering flags shared by the logs
+// commands.
+const logFilterOptionsUsage = `  -include <regex>
+    Only show log lines matching the regular expression. Lines are filtered on
+    the client node, so lines that do not match are never sent.
+
+  -exclude <regex>
+    Do not show log lines matching the regular expression.
+
+  -since <time>
+    Only show log lines with a timestamp at or after the given time. Accepts
+    an RFC3339 timestamp or a duration relative to now, such as "15m". Only
+    lines starting with a timestamp are filtered by time; lines without one,
+    such as stack traces, follow the line before them.
+
+  -until <time>
+    Only show log lines with a timestamp at or before the given time. Accepts
+    the same values as -since.`
+
+// logFilterFlags holds the values of the log filtering flags.
+type logFilterFlags struct {
+	include, exclude string
+	since, until     string
+}
+
+// addLogFilterFlags registers the log filtering flags on the flag set.
+func addLogFilterFlags(flags *flag.FlagSet) *logFilterFlags {
+	f := &logFilterFlags{}
+	flags.StringVar(&f.include, "include", "", "")
+	flags.StringVar(&f.exclude, "exclude", "", "")
+	flags.StringVar(&f.since, "since", "", "")
+	flags.StringVar(&f.until, "until", "", "")
+	return f
+}
+
+// filter returns the log filter for the flags, or nil if no filtering flag
+// was set. Relative times are resolved against now.
+func (f *logFilterFlags) filter(now time.Time) (*api.LogFilter, error) {
+	if f.include == "" && f.exclude == "" && f.since == "" && f.until == "" {
+		return nil, nil
+	}
+
+	filter := &api.LogFilter{
+		Include: f.include,
+		Exclude: f.exclude,
+	}
+	if _, err := regexp.Compile(f.include); err != nil {
+		return nil, fmt.Errorf("Invalid -include expression: %v", err)
+	}
+	if _, err := regexp.Compile(f.exclude); err != nil {
+		return nil, fmt.Errorf("Invalid -exclude expression: %v", err)
+	}
+
+	var err error
+	if f.since != "" {
+		if filter.Since, err = parseLogFilterTime(f.since, now); err != nil {
+			return nil, fmt.Errorf("Invalid -since value: %v", err)
+		}
+	}
+	if f.until != "" {
+		if filter.Until, err = parseLogFilterTime(f.until, now); err != nil {
+			return nil, fmt.Errorf("Invalid -until value: %v", err)
+		}
+	}
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && filter.Until.Before(filter.Since) {
+		return nil, errors.New("The -until time must not be before the -since time")
+	}
+	return filter, nil
+}
+
+// parseLogFilterTime parses an RFC3339 timestamp or a duration, which is
+// subtracted from now.
+func parseLogFilterTime(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("duration %q must not be negative", s)
+		}
+		return now.Add(-d), nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither an RFC3339 timestamp nor a duration", s)
+	}
+	return t, nil
+}
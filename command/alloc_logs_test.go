@@ -5,8 +5,10 @@ package command
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -90,3 +92,35 @@ func TestLogsCommand_AutocompleteArgs(t *testing.T) {
 	must.Len(t, 1, res)
 	must.Eq(t, a.ID, res[0])
 }
+
+func TestLogFilterFlags(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	f := &logFilterFlags{}
+	filter, err := f.filter(now)
+	must.NoError(t, err)
+	must.Nil(t, filter)
+
+	f = &logFilterFlags{include: "err", since: "15m", until: "2024-03-01T12:00:00Z"}
+	filter, err = f.filter(now)
+	must.NoError(t, err)
+	must.Eq(t, &api.LogFilter{
+		Include: "err",
+		Since:   now.Add(-15 * time.Minute),
+		Until:   now,
+	}, filter)
+
+	f = &logFilterFlags{since: "1m", until: "2m"}
+	_, err = f.filter(now)
+	must.ErrorContains(t, err, "must not be before the -since time")
+
+	f = &logFilterFlags{until: "-1m"}
+	_, err = f.filter(now)
+	must.ErrorContains(t, err, "must not be negative")
+
+	f = &logFilterFlags{exclude: "[a-"}
+	_, err = f.filter(now)
+	must.ErrorContains(t, err, "Invalid -exclude expression")
+}
@@ -46,6 +46,11 @@ Alloc Status Options:
   -stats
     Display detailed resource usage statistics.
 
+  -history
+    Display the resource usage history of each task, kept by the client for
+    up to a day. Only the most recent samples are shown unless -verbose is
+    set.
+
   -verbose
     Show full information.
 
@@ -70,6 +75,8 @@ func (c *AllocStatusCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
 			"-short":   complete.PredictNothing,
+			"-stats":   complete.PredictNothing,
+			"-history": complete.PredictNothing,
 			"-verbose": complete.PredictNothing,
 			"-json":    complete.PredictNothing,
 			"-t":       complete.PredictAnything,
@@ -95,7 +102,7 @@ func (c *AllocStatusCommand) AutocompleteArgs() complete.Predictor {
 func (c *AllocStatusCommand) Name() string { return "alloc status" }
 
 func (c *AllocStatusCommand) Run(args []string) int {
-	var short, displayStats, verbose, json, openURL bool
+	var short, displayStats, displayHistory, verbose, json, openURL bool
 	var tmpl string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
@@ -103,6 +110,7 @@ func (c *AllocStatusCommand) Run(args []string) int {
 	flags.BoolVar(&short, "short", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
 	flags.BoolVar(&displayStats, "stats", false, "")
+	flags.BoolVar(&displayHistory, "history", false, "")
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&tmpl, "t", "", "")
 	flags.BoolVar(&openURL, "ui", false, "")
@@ -233,7 +241,17 @@ func (c *AllocStatusCommand) Run(args []string) int {
 				c.Ui.Output("Omitting resource statistics since the node is down.")
 			}
 		}
-		c.outputTaskDetails(alloc, stats, displayStats, verbose)
+
+		var history map[string][]*api.StatsSample
+		if displayHistory && statsErr == nil {
+			var historyErr error
+			history, historyErr = client.Allocations().StatsHistory(alloc, nil)
+			if historyErr != nil {
+				c.Ui.Output("")
+				c.Ui.Error(fmt.Sprintf("Couldn't retrieve stats history: %v", historyErr))
+			}
+		}
+		c.outputTaskDetails(alloc, stats, history, displayStats, verbose)
 	}
 
 	// Format the detailed status
@@ -417,7 +435,7 @@ func futureEvalTimePretty(evalID string, client *api.Client) string {
 
 // outputTaskDetails prints task details for each task in the allocation,
 // optionally printing verbose statistics if displayStats is set
-func (c *AllocStatusCommand) outputTaskDetails(alloc *api.Allocation, stats *api.AllocResourceUsage, displayStats bool, verbose bool) {
+func (c *AllocStatusCommand) outputTaskDetails(alloc *api.Allocation, stats *api.AllocResourceUsage, history map[string][]*api.StatsSample, displayStats bool, verbose bool) {
 	taskLifecycles := map[string]*api.TaskLifecycle{}
 	for _, t := range alloc.Job.LookupTaskGroup(alloc.TaskGroup).Tasks {
 		taskLifecycles[t.Name] = t.Lifecycle
@@ -433,6 +451,10 @@ func (c *AllocStatusCommand) outputTaskDetails(alloc *api.Allocation, stats *api
 
 		c.Ui.Output(c.Colorize().Color(fmt.Sprintf("\n[bold]Task %q%v is %q[reset]", task, lcIndicator, state.State)))
 		c.outputTaskResources(alloc, task, stats, displayStats)
+		if history != nil {
+			c.Ui.Output("")
+			c.outputTaskHistory(history[task], verbose)
+		}
 		c.Ui.Output("")
 		c.outputTaskVolumes(alloc, task, verbose)
 		c.outputTaskStatus(state)
@@ -754,6 +776,44 @@ func (c *AllocStatusCommand) outputVerboseResourceUsage(task string, resourceUsa
 	}
 }
 
+// historyDisplayLimit is the number of samples of the stats history shown when
+// not verbose
+const historyDisplayLimit = 10
+
+// outputTaskHistory outputs the resource usage history of a task, most recent
+// sample first
+func (c *AllocStatusCommand) outputTaskHistory(samples []*api.StatsSample, verbose bool) {
+	c.Ui.Output("Resource Usage History:")
+	if len(samples) == 0 {
+		c.Ui.Output("No resource usage history yet")
+		return
+	}
+
+	shown := samples
+	if !verbose && len(shown) > historyDisplayLimit {
+		shown = shown[len(shown)-historyDisplayLimit:]
+	}
+
+	out := make([]string, 0, len(shown)+1)
+	out = append(out, "Time|Window|CPU|CPU Max|Memory|Memory Max")
+	for i := len(shown) - 1; i >= 0; i-- {
+		s := shown[i]
+		out = append(out, fmt.Sprintf("%s|%s|%v MHz|%v MHz|%s|%s",
+			formatUnixNanoTime(s.Timestamp),
+			s.Window,
+			math.Floor(s.CPUTicks),
+			math.Floor(s.CPUTicksMax),
+			humanize.IBytes(s.MemoryBytes),
+			humanize.IBytes(s.MemoryBytesMax)))
+	}
+	c.Ui.Output(formatList(out))
+
+	if len(shown) < len(samples) {
+		c.Ui.Output(fmt.Sprintf("Showing the last %d of %d samples, use -verbose to show all",
+			len(shown), len(samples)))
+	}
+}
+
 // shortTaskStatus prints out the current state of each task.
 func (c *AllocStatusCommand) shortTaskStatus(alloc *api.Allocation) {
 	tasks := make([]string, 0, len(alloc.TaskStates)+1)
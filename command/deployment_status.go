@@ -629,7 +629,7 @@ func formatMultiregionDeployment(regions map[string]*api.Deployment, uuidLength
 
 func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 	// Detect if we need to add these columns
-	var canaries, autorevert, progressDeadline bool
+	var canaries, autorevert, steps, progressDeadline bool
 	tgNames := make([]string, 0, len(d.TaskGroups))
 	for name, state := range d.TaskGroups {
 		tgNames = append(tgNames, name)
@@ -639,6 +639,9 @@ func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 		if state.DesiredCanaries > 0 {
 			canaries = true
 		}
+		if state.RolloutSteps > 0 {
+			steps = true
+		}
 		if state.ProgressDeadline != 0 {
 			progressDeadline = true
 		}
@@ -655,6 +658,9 @@ func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 	if canaries {
 		rowString += "Promoted|"
 	}
+	if steps {
+		rowString += "Step|"
+	}
 	rowString += "Desired|"
 	if canaries {
 		rowString += "Canaries|"
@@ -680,6 +686,13 @@ func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 				row += fmt.Sprintf("%v|", "N/A")
 			}
 		}
+		if steps {
+			if state.RolloutSteps > 0 {
+				row += fmt.Sprintf("%d/%d (%d%%)|", state.CurrentStep+1, state.RolloutSteps, state.StepPercent)
+			} else {
+				row += fmt.Sprintf("%v|", "N/A")
+			}
+		}
 		row += fmt.Sprintf("%d|", state.DesiredTotal)
 		if canaries {
 			row += fmt.Sprintf("%d|", state.DesiredCanaries)
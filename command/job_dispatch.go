@@ -22,12 +22,18 @@ type JobDispatchCommand struct {
 func (c *JobDispatchCommand) Help() string {
 	helpText := `
 Usage: nomad job dispatch [options] <parameterized job> [input source]
+       nomad job dispatch -list-queue [options] <parameterized job>
 
   Dispatch creates an instance of a parameterized job. A data payload to the
   dispatched instance can be provided via stdin by using "-" or by specifying a
   path to a file. Metadata can be supplied by using the meta flag one or more
   times.
 
+  If the parameterized job limits its dispatched jobs and the limits are
+  reached, the dispatch is queued when the job has a dispatch queue, or
+  rejected otherwise. Queued dispatches are started in order as the limits
+  allow, and can be listed with the list-queue flag.
+
   An optional idempotency token can be used to prevent more than one instance
   of the job to be dispatched. If an instance with the same token already
   exists, the command returns without any action.
@@ -67,6 +73,10 @@ Dispatch Options:
   -id-prefix-template
     Optional prefix template for dispatched job IDs.
 
+  -list-queue
+    List the queued dispatches of the parameterized job instead of dispatching
+    it, in the order they will be started.
+
   -verbose
     Display full information.
 
@@ -86,6 +96,7 @@ func (c *JobDispatchCommand) AutocompleteFlags() complete.Flags {
 			"-meta":              complete.PredictAnything,
 			"-detach":            complete.PredictNothing,
 			"-idempotency-token": complete.PredictAnything,
+			"-list-queue":        complete.PredictNothing,
 			"-verbose":           complete.PredictNothing,
 			"-ui":                complete.PredictNothing,
 		})
@@ -118,7 +129,7 @@ func (c *JobDispatchCommand) AutocompleteArgs() complete.Predictor {
 func (c *JobDispatchCommand) Name() string { return "job dispatch" }
 
 func (c *JobDispatchCommand) Run(args []string) int {
-	var detach, verbose, openURL bool
+	var detach, verbose, openURL, listQueue bool
 	var idempotencyToken string
 	var meta []string
 	var idPrefixTemplate string
@@ -127,6 +138,7 @@ func (c *JobDispatchCommand) Run(args []string) int {
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&detach, "detach", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.BoolVar(&listQueue, "list-queue", false, "")
 	flags.StringVar(&idempotencyToken, "idempotency-token", "", "")
 	flags.Var((*flaghelper.StringFlag)(&meta), "meta", "")
 	flags.StringVar(&idPrefixTemplate, "id-prefix-template", "", "")
@@ -144,6 +156,11 @@ func (c *JobDispatchCommand) Run(args []string) int {
 
 	// Check that we got one or two arguments
 	args = flags.Args()
+	if listQueue && len(args) != 1 {
+		c.Ui.Error("This command takes one argument with -list-queue: <parameterized job>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
 	if l := len(args); l < 1 || l > 2 {
 		c.Ui.Error("This command takes one or two argument: <parameterized job> [input source]")
 		c.Ui.Error(commandErrorText(c))
@@ -196,6 +213,10 @@ func (c *JobDispatchCommand) Run(args []string) int {
 		return 1
 	}
 
+	if listQueue {
+		return c.listQueue(client, jobID, namespace)
+	}
+
 	// Dispatch the job
 	w := &api.WriteOptions{
 		IdempotencyToken: idempotencyToken,
@@ -217,6 +238,9 @@ func (c *JobDispatchCommand) Run(args []string) int {
 	if evalCreated {
 		basic = append(basic, fmt.Sprintf("Evaluation ID|%s", limit(resp.EvalID, length)))
 	}
+	if resp.Queued {
+		basic = append(basic, "Status|queued")
+	}
 	c.Ui.Output(formatKV(basic))
 
 	// Nothing to do
@@ -245,3 +269,29 @@ func (c *JobDispatchCommand) Run(args []string) int {
 	}
 	return mon.monitor(resp.EvalID)
 }
+
+// listQueue outputs the queued dispatches of the parameterized job.
+func (c *JobDispatchCommand) listQueue(client *api.Client, jobID, namespace string) int {
+	q := &api.QueryOptions{Namespace: namespace}
+	dispatches, _, err := client.Jobs().DispatchQueue(jobID, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving dispatch queue: %s", err))
+		return 1
+	}
+
+	if len(dispatches) == 0 {
+		c.Ui.Output("No queued dispatches")
+		return 0
+	}
+
+	out := make([]string, len(dispatches)+1)
+	out[0] = "Position|Dispatched Job ID|Queued At"
+	for i, dispatch := range dispatches {
+		out[i+1] = fmt.Sprintf("%d|%s|%s",
+			i+1,
+			dispatch.ID,
+			formatUnixNanoTime(dispatch.EnqueueTime))
+	}
+	c.Ui.Output(formatList(out))
+	return 0
+}
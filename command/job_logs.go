@@ -0,0 +1,364 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type JobLogsCommand struct {
+	Meta
+
+	// The fields below represent the commands flags.
+	verbose, all, stderr, follow, tail bool
+	numLines                           int64
+	group, task                        string
+	filter                             *api.LogFilter
+}
+
+// jobLogStream is the log stream of a single task of an allocation.
+type jobLogStream struct {
+	alloc  *api.Allocation
+	task   string
+	prefix string
+}
+
+func (l *JobLogsCommand) Help() string {
+	helpText := `
+Usage: nomad job logs [options] <job>
+
+  Streams the logs of the tasks of a job's running allocations merged into a
+  single stream. Each line is prefixed with the allocation ID and the name of
+  the task that wrote it. Lines of different tasks are interleaved in the
+  order they are received.
+
+  When ACLs are enabled, this command requires a token with the 'read-logs',
+  'read-job', and 'list-jobs' capabilities for the job's namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Logs Options:
+
+  -stderr
+    Display stderr logs instead of stdout logs.
+
+  -group <group-name>
+    Only display the logs of the allocations of the task group.
+
+  -task <task-name>
+    Only display the logs of the task.
+
+  -all
+    Display the logs of all the job's allocations that have started, not only
+    the running ones.
+
+  -verbose
+    Display full allocation IDs.
+
+  -f
+    Causes the output to not stop when the end of the logs are reached, but
+    rather to wait for additional output.
+
+  -tail
+    Show the logs contents with offsets relative to the end of the logs. If no
+    offset is given, -n is defaulted to 10.
+
+  -n
+    Sets the tail location in best-efforted number of lines relative to the end
+    of the logs of each task.
+
+` + logFilterOptionsUsage + `
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (l *JobLogsCommand) Synopsis() string {
+	return "Streams the merged logs of the tasks of a job"
+}
+
+func (l *JobLogsCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(l.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-stderr":  complete.PredictNothing,
+			"-group":   complete.PredictAnything,
+			"-task":    complete.PredictAnything,
+			"-all":     complete.PredictNothing,
+			"-verbose": complete.PredictNothing,
+			"-f":       complete.PredictNothing,
+			"-tail":    complete.PredictNothing,
+			"-n":       complete.PredictAnything,
+			"-include": complete.PredictAnything,
+			"-exclude": complete.PredictAnything,
+			"-since":   complete.PredictAnything,
+			"-until":   complete.PredictAnything,
+		})
+}
+
+func (l *JobLogsCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := l.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Jobs]
+	})
+}
+
+func (l *JobLogsCommand) Name() string { return "job logs" }
+
+func (l *JobLogsCommand) Run(args []string) int {
+	flags := l.Meta.FlagSet(l.Name(), FlagSetClient)
+	flags.Usage = func() { l.Ui.Output(l.Help()) }
+	flags.BoolVar(&l.verbose, "verbose", false, "")
+	flags.BoolVar(&l.all, "all", false, "")
+	flags.BoolVar(&l.stderr, "stderr", false, "")
+	flags.BoolVar(&l.follow, "f", false, "")
+	flags.BoolVar(&l.tail, "tail", false, "")
+	flags.Int64Var(&l.numLines, "n", -1, "")
+	flags.StringVar(&l.group, "group", "", "")
+	flags.StringVar(&l.task, "task", "", "")
+	filterFlags := addLogFilterFlags(flags)
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one job
+	args = flags.Args()
+	if len(args) != 1 {
+		l.Ui.Error("This command takes one argument: <job>")
+		l.Ui.Error(commandErrorText(l))
+		return 1
+	}
+
+	var err error
+	if l.filter, err = filterFlags.filter(time.Now()); err != nil {
+		l.Ui.Error(err.Error())
+		l.Ui.Error(commandErrorText(l))
+		return 1
+	}
+	if l.numLines != -1 && l.numLines <= 0 {
+		l.Ui.Error("The -n value must be greater than zero")
+		l.Ui.Error(commandErrorText(l))
+		return 1
+	}
+
+	client, err := l.Meta.Client()
+	if err != nil {
+		l.Ui.Error(fmt.Sprintf("Error initializing client: %v", err))
+		return 1
+	}
+
+	jobID, namespace, err := l.JobIDByPrefix(client, strings.TrimSpace(args[0]), nil)
+	if err != nil {
+		l.Ui.Error(err.Error())
+		return 1
+	}
+
+	streams, err := l.logStreams(client, jobID, namespace)
+	if err != nil {
+		l.Ui.Error(err.Error())
+		return 1
+	}
+	if len(streams) == 0 {
+		l.Ui.Error(fmt.Sprintf("No started tasks matching the flags found for job %q", jobID))
+		return 1
+	}
+
+	if !l.streamLogs(client, streams) {
+		return 1
+	}
+	return 0
+}
+
+// logStreams returns the log streams of the job's tasks matching the flags.
+func (l *JobLogsCommand) logStreams(client *api.Client, jobID, namespace string) ([]*jobLogStream, error) {
+	q := &api.QueryOptions{Namespace: namespace}
+	stubs, _, err := client.Jobs().Allocations(jobID, false, q)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving allocations: %v", err)
+	}
+
+	// Order the streams by task group, allocation and task
+	sort.Slice(stubs, func(i, j int) bool {
+		if stubs[i].TaskGroup != stubs[j].TaskGroup {
+			return stubs[i].TaskGroup < stubs[j].TaskGroup
+		}
+		return stubs[i].ID < stubs[j].ID
+	})
+
+	length := shortId
+	if l.verbose {
+		length = fullId
+	}
+
+	var streams []*jobLogStream
+	for _, stub := range stubs {
+		if l.group != "" && stub.TaskGroup != l.group {
+			continue
+		}
+		if !l.all && stub.ClientStatus != api.AllocClientStatusRunning {
+			continue
+		}
+
+		alloc, _, err := client.Allocations().Info(stub.ID, q)
+		if err != nil {
+			return nil, fmt.Errorf("Error querying allocation %s: %v", limit(stub.ID, shortId), err)
+		}
+		tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+		if tg == nil {
+			continue
+		}
+
+		for _, task := range tg.Tasks {
+			if l.task != "" && task.Name != l.task {
+				continue
+			}
+
+			// Tasks that have not started have no logs
+			state := alloc.TaskStates[task.Name]
+			if state == nil || state.StartedAt.IsZero() {
+				continue
+			}
+
+			streams = append(streams, &jobLogStream{
+				alloc:  alloc,
+				task:   task.Name,
+				prefix: fmt.Sprintf("[%s/%s] ", limit(alloc.ID, length), task.Name),
+			})
+		}
+	}
+	return streams, nil
+}
+
+// streamLogs outputs the lines of the log streams until all the streams end or
+// the command is interrupted. It returns false if any stream failed.
+func (l *JobLogsCommand) streamLogs(client *api.Client, streams []*jobLogStream) bool {
+	logType := api.FSLogNameStdout
+	if l.stderr {
+		logType = api.FSLogNameStderr
+	}
+
+	origin, offset := api.OriginStart, int64(0)
+	if l.tail || l.numLines != -1 {
+		if l.numLines == -1 {
+			l.numLines = defaultTailLines
+		}
+		origin, offset = api.OriginEnd, l.numLines*bytesToLines
+	}
+
+	// Use a single cancel channel for all the log streams, so we only have to
+	// close one.
+	cancel := make(chan struct{})
+	defer close(cancel)
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalCh)
+
+	type result struct {
+		stream *jobLogStream
+		err    error
+	}
+	lines := make(chan string)
+	doneCh := make(chan result)
+
+	for _, stream := range streams {
+		go func(stream *jobLogStream) {
+			err := l.streamTaskLogs(client, stream, logType, origin, offset, cancel, lines)
+			select {
+			case doneCh <- result{stream, err}:
+			case <-cancel:
+			}
+		}(stream)
+	}
+
+	ok := true
+	for remaining := len(streams); remaining > 0; {
+		select {
+		case <-signalCh:
+			return ok
+		case line := <-lines:
+			l.Ui.Output(line)
+		case res := <-doneCh:
+			remaining--
+			if res.err != nil {
+				ok = false
+				l.Ui.Error(fmt.Sprintf("%sError streaming logs: %v", res.stream.prefix, res.err))
+			}
+		}
+	}
+	return ok
+}
+
+// streamTaskLogs sends the prefixed lines of a task's logs on the lines
+// channel until the logs end or cancel is closed.
+func (l *JobLogsCommand) streamTaskLogs(client *api.Client, stream *jobLogStream,
+	logType, origin string, offset int64, cancel chan struct{}, lines chan<- string) error {
+
+	streamCancel := make(chan struct{})
+	frames, errCh := client.AllocFS().LogsWithFilter(stream.alloc, l.follow, stream.task,
+		logType, origin, offset, l.filter, streamCancel, nil)
+
+	// Setting up the logs stream can fail, therefore we need to check the
+	// error channel before continuing further.
+	select {
+	case err := <-errCh:
+		return err
+	default:
+	}
+
+	frameReader := api.NewFrameReader(frames, errCh, streamCancel)
+	frameReader.SetUnblockTime(500 * time.Millisecond)
+	go func() {
+		<-cancel
+		frameReader.Close()
+	}()
+
+	var r io.Reader = frameReader
+	if l.numLines != -1 {
+		r = NewLineLimitReader(frameReader, int(l.numLines), int(l.numLines*bytesToLines), 1*time.Second)
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		select {
+		case lines <- stream.prefix + scanner.Text():
+		case <-cancel:
+			return nil
+		}
+	}
+
+	// Closing the reader after an interrupt is not an error
+	select {
+	case <-cancel:
+		return nil
+	default:
+	}
+	if err := scanner.Err(); err != nil && err != io.ErrClosedPipe {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+var _ cli.Command = (*JobLogsCommand)(nil)
+
+func TestJobLogsCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &JobLogsCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails on invalid filters
+	cmd = &JobLogsCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-include=(", "example"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Invalid -include expression")
+	ui.ErrorWriter.Reset()
+
+	cmd = &JobLogsCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-since=yesterday", "example"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Invalid -since value")
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	cmd = &JobLogsCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=nope", "example"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Error querying job prefix")
+	ui.ErrorWriter.Reset()
+
+	// Fails on missing job
+	cmd = &JobLogsCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "example"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "No job(s) with prefix or ID")
+}
+
+func TestJobLogsCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	// Wait for a node to be ready
+	waitForNodes(t, client)
+
+	job := testJob("job_logs")
+	job.TaskGroups[0].Count = pointer.Of(2)
+	job.TaskGroups[0].Tasks[0].Config = map[string]any{
+		"run_for":       "30s",
+		"stdout_string": "hello\nworld\n",
+	}
+	_, _, err := client.Jobs().Register(job, nil)
+	must.NoError(t, err)
+	waitForJobAllocsStatus(t, client, "job_logs", api.AllocClientStatusRunning, "")
+
+	// Only the matching lines of both allocations are displayed
+	var out string
+	testutil.WaitForResult(func() (bool, error) {
+		ui := cli.NewMockUi()
+		cmd := &JobLogsCommand{Meta: Meta{Ui: ui}}
+		if code := cmd.Run([]string{"-address=" + url, "-include=^wor", "job_logs"}); code != 0 {
+			return false, fmt.Errorf("unexpected exit code %d: %s", code, ui.ErrorWriter.String())
+		}
+		out = ui.OutputWriter.String()
+		if n := strings.Count(out, "/task1] world\n"); n != 2 {
+			return false, fmt.Errorf("expected 2 lines, got %q", out)
+		}
+		return true, nil
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+	must.StrNotContains(t, out, "hello")
+
+	// Tasks can be selected by name
+	ui := cli.NewMockUi()
+	cmd := &JobLogsCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, "-task=nope", "job_logs"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "No started tasks matching the flags")
+}
@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type JobPeriodicHistoryCommand struct {
+	Meta
+}
+
+func (c *JobPeriodicHistoryCommand) Help() string {
+	helpText := `
+Usage: nomad job periodic history [options] <job id>
+
+  This command is used to display the outcomes of the recent launches of a
+  periodic job, most recent launch first. The history is kept after the
+  launched jobs are garbage collected, up to the successful_jobs_history_limit
+  and failed_jobs_history_limit of the job.
+
+  When ACLs are enabled, this command requires a token with the 'read-job'
+  capability for the job's namespace. The 'list-jobs' capability is required to
+  run the command with a job prefix instead of the exact job ID.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Periodic History Options:
+
+  -json
+    Output the periodic history in its JSON format.
+
+  -t
+    Format and display the periodic history using a Go template.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobPeriodicHistoryCommand) Synopsis() string {
+	return "Display the history of a periodic job"
+}
+
+func (c *JobPeriodicHistoryCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json": complete.PredictNothing,
+			"-t":    complete.PredictAnything,
+		})
+}
+
+func (c *JobPeriodicHistoryCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Jobs().PrefixList(a.Last)
+		if err != nil {
+			return []string{}
+		}
+
+		// filter this by periodic jobs
+		matches := make([]string, 0, len(resp))
+		for _, job := range resp {
+			if job.Periodic {
+				matches = append(matches, job.ID)
+			}
+		}
+		return matches
+	})
+}
+
+func (c *JobPeriodicHistoryCommand) Name() string { return "job periodic history" }
+
+func (c *JobPeriodicHistoryCommand) Run(args []string) int {
+	var json bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if l := len(args); l != 1 {
+		c.Ui.Error("This command takes one argument: <job id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Check if the job exists
+	jobIDPrefix := strings.TrimSpace(args[0])
+	jobID, namespace, err := c.JobIDByPrefix(client, jobIDPrefix, func(j *api.JobListStub) bool {
+		return j.Periodic
+	})
+	if err != nil {
+		var noPrefixErr *NoJobWithPrefixError
+		if errors.As(err, &noPrefixErr) {
+			err = fmt.Errorf("No periodic job(s) with prefix or ID %q found", jobIDPrefix)
+		}
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	q := &api.QueryOptions{Namespace: namespace}
+
+	runs, _, err := client.Jobs().PeriodicHistory(jobID, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving periodic history: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, runs)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	if len(runs) == 0 {
+		c.Ui.Output("No periodic history")
+		return 0
+	}
+
+	out := make([]string, len(runs)+1)
+	out[0] = "ID|Launch Time|Finish Time|Status|Exit Codes"
+	for i, run := range runs {
+		out[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s",
+			run.JobID,
+			formatTime(run.LaunchTime),
+			formatTime(run.FinishTime),
+			run.Status,
+			formatPeriodicRunExitCodes(run))
+	}
+	c.Ui.Output(formatList(out))
+	return 0
+}
+
+// formatPeriodicRunExitCodes returns the exit codes of the tasks of a periodic
+// run, marking the tasks that failed.
+func formatPeriodicRunExitCodes(run *api.PeriodicRun) string {
+	codes := make([]string, 0, len(run.Tasks))
+	for _, task := range run.Tasks {
+		code := fmt.Sprintf("%s=%d", task.Task, task.ExitCode)
+		if task.Failed {
+			code += " (failed)"
+		}
+		codes = append(codes, code)
+	}
+	return strings.Join(codes, ", ")
+}
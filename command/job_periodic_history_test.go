@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobPeriodicHistoryCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &JobPeriodicHistoryCommand{}
+}
+
+func TestJobPeriodicHistoryCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &JobPeriodicHistoryCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	must.One(t, code)
+	out := ui.ErrorWriter.String()
+	must.StrContains(t, out, commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=nope", "12"})
+	must.One(t, code)
+	out = ui.ErrorWriter.String()
+	must.StrContains(t, out, "Error querying job prefix")
+}
+
+func TestJobPeriodicHistoryCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &JobPeriodicHistoryCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// Create a periodic job that keeps the history of one failed launch
+	state := srv.Agent.Server().State()
+	parent := mock.PeriodicJob()
+	parent.Periodic.FailedJobsHistoryLimit = pointer.Of(1)
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, parent))
+
+	code := cmd.Run([]string{"-address=" + url, parent.ID})
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), "No periodic history")
+	ui.OutputWriter.Reset()
+
+	// Stopping a launched job records it as failed
+	child := parent.Copy()
+	child.ID = parent.ID + structs.PeriodicLaunchSuffix + "1700000000"
+	child.ParentID = parent.ID
+	child.Periodic = nil
+	child.Stop = true
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, nil, child))
+
+	code = cmd.Run([]string{"-address=" + url, parent.ID})
+	must.Zero(t, code)
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, child.ID)
+	must.StrContains(t, out, structs.PeriodicRunStatusFailed)
+}
@@ -498,7 +498,7 @@ func formatTaskGroupDiff(tg *api.TaskGroupDiff, tgPrefix int, verbose bool) stri
 				color = "[red]"
 			case scheduler.UpdateTypeMigrate:
 				color = "[blue]"
-			case scheduler.UpdateTypeInplaceUpdate:
+			case scheduler.UpdateTypeInplaceUpdate, scheduler.UpdateTypeInplaceResize:
 				color = "[cyan]"
 			case scheduler.UpdateTypeDestructiveUpdate:
 				color = "[yellow]"
@@ -703,7 +703,7 @@ func colorAnnotations(annotations []string) string {
 			colored[i] = fmt.Sprintf("[green]%s[reset]", annotation)
 		case "forces destroy":
 			colored[i] = fmt.Sprintf("[red]%s[reset]", annotation)
-		case "forces in-place update":
+		case "forces in-place update", "forces in-place resize":
 			colored[i] = fmt.Sprintf("[cyan]%s[reset]", annotation)
 		case "forces create/destroy update":
 			colored[i] = fmt.Sprintf("[yellow]%s[reset]", annotation)
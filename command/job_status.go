@@ -5,6 +5,7 @@ package command
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -472,6 +473,9 @@ func (c *JobStatusCommand) outputJobInfo(client *api.Client, job *api.Job) error
 	var latestFailedPlacement *api.Evaluation
 	blockedEval := false
 
+	// Determine the latest evaluation waiting on job dependencies
+	var dependencyEval *api.Evaluation
+
 	// Format the evals
 	evals := make([]string, len(jobEvals)+1)
 	evals[0] = "ID|Priority|Triggered By|Status|Placement Failures"
@@ -487,6 +491,11 @@ func (c *JobStatusCommand) outputJobInfo(client *api.Client, job *api.Job) error
 
 		if eval.Status == "blocked" {
 			blockedEval = true
+
+			if len(eval.UnmetDependencies) != 0 &&
+				(dependencyEval == nil || dependencyEval.CreateIndex < eval.CreateIndex) {
+				dependencyEval = eval
+			}
 		}
 
 		if len(eval.FailedTGAllocs) == 0 {
@@ -508,6 +517,11 @@ func (c *JobStatusCommand) outputJobInfo(client *api.Client, job *api.Job) error
 		c.outputFailedPlacements(latestFailedPlacement)
 	}
 
+	if len(job.DependsOn) > 0 {
+		c.Ui.Output(c.Colorize().Color("\n[bold]Dependencies[reset]"))
+		c.Ui.Output(formatJobDependencies(client, job, dependencyEval))
+	}
+
 	c.outputReschedulingEvals(client, job, jobAllocs, c.length)
 
 	if latestDeployment != nil {
@@ -554,6 +568,37 @@ func (c *JobStatusCommand) formatDeployment(client *api.Client, d *api.Deploymen
 	return base
 }
 
+// formatJobDependencies formats the jobs a job depends on. Dependencies listed
+// by the blocked evaluation waiting on them are reported as waiting, or as not
+// registered if the job they depend on doesn't exist.
+func formatJobDependencies(client *api.Client, job *api.Job, waitingEval *api.Evaluation) string {
+	var waiting []string
+	if waitingEval != nil {
+		waiting = waitingEval.UnmetDependencies
+	}
+
+	rows := make([]string, len(job.DependsOn)+1)
+	rows[0] = "Job ID|Condition|Status"
+	for i, dep := range job.DependsOn {
+		status := "met"
+		if slices.Contains(waiting, dep.JobID) {
+			status = "waiting"
+
+			q := &api.QueryOptions{Namespace: *job.Namespace}
+			if _, _, err := client.Jobs().Info(dep.JobID, q); err != nil && strings.Contains(err.Error(), "404") {
+				status = "not registered"
+			}
+		}
+
+		condition := ""
+		if dep.Condition != nil {
+			condition = *dep.Condition
+		}
+		rows[i+1] = fmt.Sprintf("%s|%s|%s", dep.JobID, condition, status)
+	}
+	return formatList(rows)
+}
+
 func formatJobActions(actions []map[string]string) string {
 	if len(actions) == 0 {
 		return "No actions configured"
@@ -238,6 +238,13 @@ func (m *monitor) monitor(evalID string) int {
 			if len(eval.FailedTGAllocs) == 0 {
 				m.ui.Info(fmt.Sprintf("%s: Evaluation %q finished with status %q",
 					formatTime(time.Now()), limit(eval.ID, m.length), eval.Status))
+
+				// The evaluation was deferred, such as to wait on job
+				// dependencies
+				if eval.BlockedEval != "" && eval.StatusDescription != "" {
+					m.ui.Output(fmt.Sprintf("%s: Evaluation %q %s",
+						formatTime(time.Now()), limit(eval.BlockedEval, m.length), eval.StatusDescription))
+				}
 			} else {
 				// There were failures making the allocations
 				schedFailure = true
@@ -359,6 +366,11 @@ func formatAllocMetrics(metrics *api.AllocationMetric, scores bool, prefix strin
 		out += fmt.Sprintf("%s* Quota limit hit %q\n", prefix, dim)
 	}
 
+	// Print gang info
+	if gang := metrics.GangUnsatisfied; gang != "" {
+		out += fmt.Sprintf("%s* Gang %q not satisfiable: could not place all of its allocations\n", prefix, gang)
+	}
+
 	// Print scores
 	if scores {
 		if len(metrics.ScoreMetaData) > 0 {
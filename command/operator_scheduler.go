@@ -31,6 +31,10 @@ Usage: nomad operator scheduler <subcommand> [options]
 
       $ nomad operator scheduler set-config -scheduler-algorithm=spread
 
+  Simulate adding three copies of a node to the cluster:
+
+      $ nomad operator scheduler simulate -add-nodes=f7476465:3
+
   Please see the individual subcommand help for detailed usage information.
 `
 	return strings.TrimSpace(helpText)
@@ -5,6 +5,8 @@ package command
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/cli"
@@ -86,8 +88,34 @@ func (o *OperatorSchedulerGetConfig) Run(args []string) int {
 		fmt.Sprintf("Preemption Service Scheduler|%v", schedConfig.PreemptionConfig.ServiceSchedulerEnabled),
 		fmt.Sprintf("Preemption Batch Scheduler|%v", schedConfig.PreemptionConfig.BatchSchedulerEnabled),
 		fmt.Sprintf("Preemption SysBatch Scheduler|%v", schedConfig.PreemptionConfig.SysBatchSchedulerEnabled),
+		fmt.Sprintf("Fair Share|%v", schedConfig.FairShareConfig.Enabled),
 		fmt.Sprintf("Modify Index|%v", resp.SchedulerConfig.ModifyIndex),
 	}))
+
+	if len(schedConfig.RankIterators) > 0 {
+		rankIterators := make([]string, 0, len(schedConfig.RankIterators)+1)
+		rankIterators = append(rankIterators, "Name|Weight")
+		for _, ri := range schedConfig.RankIterators {
+			weight := 1.0
+			if ri.Weight != nil {
+				weight = *ri.Weight
+			}
+			rankIterators = append(rankIterators, fmt.Sprintf("%s|%v", ri.Name, weight))
+		}
+		o.Ui.Output(o.Colorize().Color("\n[bold]Rank Iterators[reset]"))
+		o.Ui.Output(formatList(rankIterators))
+	}
+
+	if len(schedConfig.FairShareConfig.Weights) > 0 {
+		namespaces := slices.Sorted(maps.Keys(schedConfig.FairShareConfig.Weights))
+		weights := make([]string, 0, len(namespaces)+1)
+		weights = append(weights, "Namespace|Weight")
+		for _, namespace := range namespaces {
+			weights = append(weights, fmt.Sprintf("%s|%d", namespace, schedConfig.FairShareConfig.Weights[namespace]))
+		}
+		o.Ui.Output(o.Colorize().Color("\n[bold]Fair Share Weights[reset]"))
+		o.Ui.Output(formatList(weights))
+	}
 	return 0
 }
 
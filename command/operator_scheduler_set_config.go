@@ -5,6 +5,7 @@ package command
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/cli"
@@ -31,6 +32,8 @@ type OperatorSchedulerSetConfig struct {
 	preemptServiceScheduler  flagHelper.BoolValue
 	preemptSysBatchScheduler flagHelper.BoolValue
 	preemptSystemScheduler   flagHelper.BoolValue
+	fairShare                flagHelper.BoolValue
+	fairShareWeights         flagHelper.StringFlag
 }
 
 func (o *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
@@ -40,6 +43,7 @@ func (o *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
 			"-scheduler-algorithm": complete.PredictSet(
 				string(api.SchedulerAlgorithmBinpack),
 				string(api.SchedulerAlgorithmSpread),
+				string(api.SchedulerAlgorithmCost),
 			),
 			"-memory-oversubscription":    complete.PredictSet("true", "false"),
 			"-reject-job-registration":    complete.PredictSet("true", "false"),
@@ -48,6 +52,8 @@ func (o *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
 			"-preempt-service-scheduler":  complete.PredictSet("true", "false"),
 			"-preempt-sysbatch-scheduler": complete.PredictSet("true", "false"),
 			"-preempt-system-scheduler":   complete.PredictSet("true", "false"),
+			"-fair-share":                 complete.PredictSet("true", "false"),
+			"-fair-share-weight":          complete.PredictAnything,
 		},
 	)
 }
@@ -72,6 +78,8 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	flags.Var(&o.preemptServiceScheduler, "preempt-service-scheduler", "")
 	flags.Var(&o.preemptSysBatchScheduler, "preempt-sysbatch-scheduler", "")
 	flags.Var(&o.preemptSystemScheduler, "preempt-system-scheduler", "")
+	flags.Var(&o.fairShare, "fair-share", "")
+	flags.Var(&o.fairShareWeights, "fair-share-weight", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -134,6 +142,20 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	o.preemptServiceScheduler.Merge(&schedulerConfig.PreemptionConfig.ServiceSchedulerEnabled)
 	o.preemptSysBatchScheduler.Merge(&schedulerConfig.PreemptionConfig.SysBatchSchedulerEnabled)
 	o.preemptSystemScheduler.Merge(&schedulerConfig.PreemptionConfig.SystemSchedulerEnabled)
+	o.fairShare.Merge(&schedulerConfig.FairShareConfig.Enabled)
+
+	for _, w := range o.fairShareWeights {
+		namespace, weightStr, ok := strings.Cut(w, "=")
+		weight, err := strconv.Atoi(weightStr)
+		if !ok || namespace == "" || err != nil {
+			o.Ui.Error(fmt.Sprintf("Error parsing fair-share-weight value %q: must be in the format <namespace>=<weight>", w))
+			return 1
+		}
+		if schedulerConfig.FairShareConfig.Weights == nil {
+			schedulerConfig.FairShareConfig.Weights = make(map[string]int)
+		}
+		schedulerConfig.FairShareConfig.Weights[namespace] = weight
+	}
 
 	// Check-and-set the new configuration.
 	result, _, err := client.Operator().SchedulerCASConfiguration(schedulerConfig, nil)
@@ -173,9 +195,10 @@ Scheduler Set Config Options:
     matches the current server side version. If a non-zero value is passed, it
     ensures that the scheduler config is being updated from a known state.
 
-  -scheduler-algorithm=["binpack"|"spread"]
+  -scheduler-algorithm=["binpack"|"spread"|"cost"]
     Specifies whether scheduler binpacks or spreads allocations on available
-    nodes.
+    nodes, or binpacks them onto the cheapest nodes first using the
+    "node_cost" meta value of each node or its node pool.
 
   -memory-oversubscription=[true|false]
     When true, tasks may exceed their reserved memory limit, if the client has
@@ -208,6 +231,18 @@ Scheduler Set Config Options:
   -preempt-system-scheduler=[true|false]
     Specifies whether preemption for system jobs is enabled. Note that if this
     is set to true, then system jobs can preempt any other jobs.
+
+  -fair-share=[true|false]
+    Specifies whether weighted fair-share scheduling across namespaces is
+    enabled. When true, evaluations of namespaces using less of the cluster
+    than their share are processed first, and jobs of those namespaces may
+    preempt allocations of namespaces using more than their share if
+    preemption is enabled for the job's scheduler.
+
+  -fair-share-weight=<namespace>=<weight>
+    Sets the relative share of the cluster of a namespace for fair-share
+    scheduling. Namespaces without a weight have a weight of 1. This flag can
+    be specified multiple times.
 `
 	return strings.TrimSpace(helpText)
 }
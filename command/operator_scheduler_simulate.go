@@ -0,0 +1,314 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/command/agent"
+	flaghelper "github.com/hashicorp/nomad/helper/flags"
+	"github.com/hashicorp/nomad/helper/raftutil"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
+	"github.com/posener/complete"
+)
+
+// Ensure OperatorSchedulerSimulate satisfies the cli.Command interface.
+var _ cli.Command = &OperatorSchedulerSimulate{}
+
+type OperatorSchedulerSimulate struct {
+	Meta
+	JobGetter
+
+	snapshot       string
+	addNodes       flaghelper.StringFlag
+	drainNodePools flaghelper.StringFlag
+	json           bool
+	tmpl           string
+}
+
+func (o *OperatorSchedulerSimulate) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(o.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-snapshot":        complete.PredictFiles("*.snap"),
+			"-add-nodes":       complete.PredictAnything,
+			"-drain-node-pool": complete.PredictAnything,
+			"-var":             complete.PredictAnything,
+			"-var-file":        complete.PredictFiles("*.var"),
+			"-json":            complete.PredictNothing,
+			"-t":               complete.PredictAnything,
+		},
+	)
+}
+
+func (o *OperatorSchedulerSimulate) AutocompleteArgs() complete.Predictor {
+	return complete.PredictOr(
+		complete.PredictFiles("*.nomad"),
+		complete.PredictFiles("*.hcl"),
+	)
+}
+
+func (o *OperatorSchedulerSimulate) Name() string { return "operator scheduler simulate" }
+
+func (o *OperatorSchedulerSimulate) Run(args []string) int {
+
+	flags := o.Meta.FlagSet(o.Name(), FlagSetClient)
+	flags.StringVar(&o.snapshot, "snapshot", "", "")
+	flags.Var(&o.addNodes, "add-nodes", "")
+	flags.Var(&o.drainNodePools, "drain-node-pool", "")
+	flags.Var(&o.JobGetter.Vars, "var", "")
+	flags.Var(&o.JobGetter.VarFiles, "var-file", "")
+	flags.BoolVar(&o.json, "json", false, "")
+	flags.StringVar(&o.tmpl, "t", "", "")
+	flags.Usage = func() { o.Ui.Output(o.Help()) }
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	o.JobGetter.Strict = true
+
+	sim := &api.SchedulerSimulation{
+		DrainNodePools: o.drainNodePools,
+	}
+	for _, add := range o.addNodes {
+		nodeID, count, ok := strings.Cut(add, ":")
+		if !ok {
+			count = "1"
+		}
+		n, err := strconv.Atoi(count)
+		if err != nil || nodeID == "" {
+			o.Ui.Error(fmt.Sprintf("Invalid -add-nodes value %q: must be <node-id>[:<count>]", add))
+			return 1
+		}
+		sim.AddNodes = append(sim.AddNodes, &api.SimulatedNodes{NodeID: nodeID, Count: n})
+	}
+	for _, path := range flags.Args() {
+		_, job, err := o.JobGetter.Get(path)
+		if err != nil {
+			o.Ui.Error(fmt.Sprintf("Error getting job struct: %s", err))
+			return 1
+		}
+		sim.Jobs = append(sim.Jobs, job)
+	}
+
+	var result *api.SchedulerSimulationResult
+	var err error
+	if o.snapshot != "" {
+		result, err = o.simulateSnapshot(sim)
+	} else {
+		result, err = o.simulateCluster(sim)
+	}
+	if err != nil {
+		o.Ui.Error(fmt.Sprintf("Error running scheduler simulation: %s", err))
+		return 1
+	}
+
+	if o.json || len(o.tmpl) > 0 {
+		out, err := Format(o.json, o.tmpl, result)
+		if err != nil {
+			o.Ui.Error(err.Error())
+			return 1
+		}
+		o.Ui.Output(out)
+		return 0
+	}
+
+	return o.outputResult(result)
+}
+
+// simulateCluster runs the simulation on the servers against the current
+// state of the cluster.
+func (o *OperatorSchedulerSimulate) simulateCluster(sim *api.SchedulerSimulation) (*api.SchedulerSimulationResult, error) {
+	client, err := o.Meta.Client()
+	if err != nil {
+		return nil, fmt.Errorf("Error initializing client: %w", err)
+	}
+	result, _, err := client.Operator().SchedulerSimulate(sim, nil)
+	return result, err
+}
+
+// simulateSnapshot runs the simulation locally against the state of a
+// snapshot archive, without contacting the cluster.
+func (o *OperatorSchedulerSimulate) simulateSnapshot(sim *api.SchedulerSimulation) (*api.SchedulerSimulationResult, error) {
+	f, err := os.Open(o.snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("Error opening snapshot file: %w", err)
+	}
+	defer f.Close()
+
+	_, state, _, err := raftutil.RestoreFromArchive(f, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read archive file: %w", err)
+	}
+
+	args := &structs.SchedulerSimulation{
+		DrainNodePools: sim.DrainNodePools,
+	}
+	for _, add := range sim.AddNodes {
+		args.AddNodes = append(args.AddNodes, &structs.SimulatedNodes{
+			NodeID: add.NodeID,
+			Count:  add.Count,
+		})
+	}
+	for _, aj := range sim.Jobs {
+		job := agent.ApiJobToStructJob(aj)
+		job.Canonicalize()
+		if err := job.Validate(); err != nil {
+			return nil, fmt.Errorf("Job %q is invalid: %w", job.ID, err)
+		}
+		args.Jobs = append(args.Jobs, job)
+	}
+
+	result, err := scheduler.Simulate(hclog.NewNullLogger(), state, args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert the result to its API representation, which has the same JSON
+	// encoding.
+	buf, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	var out api.SchedulerSimulationResult
+	if err := json.Unmarshal(buf, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (o *OperatorSchedulerSimulate) outputResult(result *api.SchedulerSimulationResult) int {
+	var failures bool
+
+	evals := make([]string, 0, len(result.Evaluations)+1)
+	evals = append(evals, "Job ID|Namespace|Triggered By|Status|Placed|Failed|Preempted")
+	for _, eval := range result.Evaluations {
+		var placed, failed uint64
+		for _, update := range eval.DesiredTGUpdates {
+			placed += update.Place + update.Migrate + update.DestructiveUpdate + update.Canary
+		}
+		for _, metrics := range eval.FailedTGAllocs {
+			failed += uint64(metrics.CoalescedFailures + 1)
+		}
+		if failed > 0 {
+			failures = true
+		}
+		evals = append(evals, fmt.Sprintf("%s|%s|%s|%s|%d|%d|%d",
+			eval.JobID, eval.Namespace, eval.TriggeredBy, eval.Status,
+			placed-min(placed, failed), failed, eval.PreemptedAllocs))
+	}
+	o.Ui.Output(o.Colorize().Color("[bold]Evaluations[reset]"))
+	o.Ui.Output(formatList(evals))
+
+	pools := make([]string, 0, len(result.NodePools)+1)
+	pools = append(pools, "Node Pool|Nodes|CPU Used|Memory Used")
+	for _, pool := range result.NodePools {
+		pools = append(pools, fmt.Sprintf("%s|%d -> %d|%s -> %s|%s -> %s",
+			pool.Name, pool.Before.Nodes, pool.After.Nodes,
+			formatUtilization(pool.Before.CPUSharesUsed, pool.Before.CPUShares),
+			formatUtilization(pool.After.CPUSharesUsed, pool.After.CPUShares),
+			formatUtilization(pool.Before.MemoryMBUsed, pool.Before.MemoryMB),
+			formatUtilization(pool.After.MemoryMBUsed, pool.After.MemoryMB)))
+	}
+	o.Ui.Output(o.Colorize().Color("\n[bold]Node Pools[reset]"))
+	o.Ui.Output(formatList(pools))
+
+	if failures {
+		o.Ui.Output(o.Colorize().Color("\n[bold]Placement Failures[reset]"))
+		for _, eval := range result.Evaluations {
+			groups := make([]string, 0, len(eval.FailedTGAllocs))
+			for tg := range eval.FailedTGAllocs {
+				groups = append(groups, tg)
+			}
+			sort.Strings(groups)
+			for _, tg := range groups {
+				metrics := eval.FailedTGAllocs[tg]
+				o.Ui.Output(fmt.Sprintf("Job %q, Task Group %q (failed to place %d allocation(s)):",
+					eval.JobID, tg, metrics.CoalescedFailures+1))
+				o.Ui.Output(formatAllocMetrics(metrics, false, "  "))
+			}
+		}
+		return 2
+	}
+	return 0
+}
+
+// formatUtilization formats the used fraction of the capacity as a
+// percentage.
+func formatUtilization(used, capacity int64) string {
+	if capacity == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", float64(used)/float64(capacity)*100)
+}
+
+func (o *OperatorSchedulerSimulate) Synopsis() string {
+	return "Simulate scheduling changes against the cluster state"
+}
+
+func (o *OperatorSchedulerSimulate) Help() string {
+	helpText := `
+Usage: nomad operator scheduler simulate [options] [<jobspec>...]
+
+  Runs the scheduler against a copy of the cluster state with a set of
+  changes applied, and reports the placements, placement failures and node
+  pool utilization that would result. Nodes are added first, then node pools
+  are drained, and then the jobs in the given jobspec files are registered.
+  The cluster is not modified.
+
+  By default the simulation runs on the servers against the current state of
+  the cluster. With the -snapshot flag the simulation runs locally against the
+  state saved by "nomad operator snapshot save", without contacting the
+  cluster. Jobs simulated against a snapshot are not processed by the server's
+  job admission controllers.
+
+  Only the evaluations created by the changes are processed, so the
+  simulation shows the first placements of a rolling update or migration
+  rather than its completion.
+
+  If ACLs are enabled, this command requires a token with the 'operator:write'
+  capability, unless the -snapshot flag is used.
+
+  The exit code is 2 if any allocations failed to place.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Scheduler Simulate Options:
+
+  -snapshot=<file>
+    Run the simulation locally against the state saved in a snapshot file.
+
+  -add-nodes=<node-id>[:<count>]
+    Add count copies of the node with the given ID or ID prefix to the
+    cluster. Defaults to a single copy. May be specified multiple times.
+
+  -drain-node-pool=<pool>
+    Drain every node of the node pool, migrating their allocations. May be
+    specified multiple times.
+
+  -var 'key=value'
+    Variable for template, can be used multiple times.
+
+  -var-file=path
+    Path to HCL2 file containing user variables.
+
+  -json
+    Output the simulation result in its JSON format.
+
+  -t
+    Format and display the simulation result using a Go template.
+`
+
+	return strings.TrimSpace(helpText)
+}
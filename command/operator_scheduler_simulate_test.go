@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/cli"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestOperatorSchedulerSimulate_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorSchedulerSimulate{}
+}
+
+func TestOperatorSchedulerSimulate_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	c := &OperatorSchedulerSimulate{Meta: Meta{Ui: ui}}
+
+	must.One(t, c.Run([]string{"-add-nodes=abcd:many"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "Invalid -add-nodes value")
+
+	ui = cli.NewMockUi()
+	c = &OperatorSchedulerSimulate{Meta: Meta{Ui: ui}}
+	must.One(t, c.Run([]string{"-snapshot=/does/not/exist", "-drain-node-pool=default"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "Error opening snapshot file")
+}
+
+func TestOperatorSchedulerSimulate_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, addr := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	jobFile := filepath.Join(t.TempDir(), "example.nomad.hcl")
+	must.NoError(t, os.WriteFile(jobFile, []byte(`
+job "example" {
+  group "web" {
+    task "web" {
+      driver = "exec"
+      config {
+        command = "/bin/sleep"
+      }
+    }
+  }
+}`), 0o644))
+
+	ui := cli.NewMockUi()
+	c := &OperatorSchedulerSimulate{Meta: Meta{Ui: ui}}
+
+	// The server has no client nodes, so the job can't be placed.
+	must.Eq(t, 2, c.Run([]string{"-address=" + addr, jobFile}))
+	s := ui.OutputWriter.String()
+	must.StrContains(t, s, "Evaluations")
+	must.StrContains(t, s, "Placement Failures")
+	must.StrContains(t, s, `Job "example", Task Group "web"`)
+
+	ui = cli.NewMockUi()
+	c = &OperatorSchedulerSimulate{Meta: Meta{Ui: ui}}
+	must.Zero(t, c.Run([]string{"-address=" + addr, "-json", jobFile}))
+	var result api.SchedulerSimulationResult
+	must.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &result))
+	must.Len(t, 1, result.Evaluations)
+	must.Eq(t, "example", result.Evaluations[0].JobID)
+	must.MapContainsKey(t, result.Evaluations[0].FailedTGAllocs, "web")
+}
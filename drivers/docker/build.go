@@ -0,0 +1,281 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package docker
+
+import (
+	"archive/tar"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/registry"
+	"github.com/hashicorp/nomad/helper/escapingfs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+const (
+	// dockerLabelBuildAllocID is the label of images built by the driver,
+	// used to find the built images no task references anymore
+	dockerLabelBuildAllocID = "com.hashicorp.nomad.build_alloc_id"
+
+	// dockerIgnoreFile lists the paths excluded from the build context
+	dockerIgnoreFile = ".dockerignore"
+)
+
+// buildImage creates an image by building it from a build context in the task
+// directory
+func (d *Driver) buildImage(task *drivers.TaskConfig, driverConfig *TaskConfig) (id, user string, err error) {
+	build := driverConfig.Build
+
+	taskDir := task.TaskDir().Dir
+	contextDir := filepath.Join(taskDir, build.Context)
+	if escapingfs.PathEscapesSandbox(taskDir, contextDir) {
+		return "", "", fmt.Errorf("build context %q must be within the task directory", build.Context)
+	}
+
+	dockerfile := build.Dockerfile
+	if dockerfile == "" {
+		dockerfile = "Dockerfile"
+	}
+	if escapingfs.PathEscapesSandbox(contextDir, filepath.Join(contextDir, dockerfile)) {
+		return "", "", fmt.Errorf("dockerfile %q must be within the build context", dockerfile)
+	}
+
+	buildDur, err := time.ParseDuration(build.Timeout)
+	if err != nil {
+		return "", "", fmt.Errorf("Failed to parse build timeout: %v", err)
+	}
+
+	buildArgs := make(map[string]*string, len(build.Args))
+	for k, v := range build.Args {
+		buildArgs[k] = &v
+	}
+
+	opts := types.ImageBuildOptions{
+		Dockerfile:  filepath.ToSlash(filepath.Clean(dockerfile)),
+		BuildArgs:   buildArgs,
+		Target:      build.Target,
+		PullParent:  build.Pull,
+		NoCache:     build.NoCache,
+		Remove:      true,
+		ForceRemove: true,
+		Labels: map[string]string{
+			dockerLabelBuildAllocID: task.AllocID,
+		},
+		Version: types.BuilderBuildKit,
+	}
+
+	// Base images are pulled with the credentials of the task
+	if driverConfig.Auth.Username != "" {
+		opts.AuthConfigs = map[string]registry.AuthConfig{
+			driverConfig.Auth.ServerAddr: {
+				Username:      driverConfig.Auth.Username,
+				Password:      driverConfig.Auth.Password,
+				Email:         driverConfig.Auth.Email,
+				ServerAddress: driverConfig.Auth.ServerAddr,
+			},
+		}
+	}
+
+	d.eventer.EmitEvent(&drivers.TaskEvent{
+		TaskID:    task.ID,
+		AllocID:   task.AllocID,
+		TaskName:  task.Name,
+		Timestamp: time.Now(),
+		Message:   "Building image",
+		Annotations: map[string]string{
+			"image": driverConfig.Image,
+		},
+	})
+
+	d.logger.Debug("building image", "image_name", driverConfig.Image, "context", contextDir)
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeBuildContext(pw, contextDir, dockerfile))
+	}()
+	defer pr.Close()
+
+	return d.coordinator.BuildImage(driverConfig.Image, pr, opts, task.ID, d.emitEventFunc(task),
+		buildDur, d.config.pullActivityTimeoutDuration)
+}
+
+// writeBuildContext writes the tar archive of the build context directory to
+// w, skipping the paths excluded by its .dockerignore file. Like the docker
+// CLI, the Dockerfile and .dockerignore file are always sent.
+func writeBuildContext(w io.Writer, dir, dockerfile string) error {
+	ignore, err := readDockerIgnore(filepath.Join(dir, dockerIgnoreFile))
+	if err != nil {
+		return err
+	}
+	keep := map[string]struct{}{
+		filepath.Clean(dockerfile): {},
+		dockerIgnoreFile:           {},
+	}
+
+	tw := tar.NewWriter(w)
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+
+		if _, ok := keep[rel]; !ok && ignore.excludes(rel) {
+			if entry.IsDir() && !ignore.hasExceptions() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		var link string
+		switch {
+		case info.Mode()&fs.ModeSymlink != 0:
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		case info.Mode().IsDir(), info.Mode().IsRegular():
+		default:
+			// Sockets, devices and pipes can't be sent to the builder
+			return nil
+		}
+
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		hdr.Uid, hdr.Gid, hdr.Uname, hdr.Gname = 0, 0, "", ""
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to archive build context: %w", err)
+	}
+	return tw.Close()
+}
+
+// dockerIgnorePattern is a pattern of a .dockerignore file
+type dockerIgnorePattern struct {
+	regex     *regexp.Regexp
+	exception bool
+}
+
+// dockerIgnore is the list of patterns of a .dockerignore file, see
+// https://docs.docker.com/build/concepts/context/#dockerignore-files
+type dockerIgnore []dockerIgnorePattern
+
+// readDockerIgnore reads the .dockerignore file at path, if any.
+func readDockerIgnore(path string) (dockerIgnore, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var patterns dockerIgnore
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var p dockerIgnorePattern
+		if strings.HasPrefix(line, "!") {
+			p.exception = true
+			line = strings.TrimSpace(line[1:])
+		}
+		if p.regex, err = compileIgnorePattern(line); err != nil {
+			return nil, fmt.Errorf("invalid %s pattern %q: %w", dockerIgnoreFile, line, err)
+		}
+		patterns = append(patterns, p)
+	}
+	return patterns, scanner.Err()
+}
+
+// compileIgnorePattern compiles a pattern where "**" matches any number of
+// directories, "*" any sequence of characters but "/" and "?" any character
+// but "/". Patterns also match the content of the directories they match.
+func compileIgnorePattern(pattern string) (*regexp.Regexp, error) {
+	pattern = strings.TrimPrefix(filepath.ToSlash(filepath.Clean(pattern)), "/")
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			// "**/" also matches no directory at all
+			expr.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("(/.*)?$")
+	return regexp.Compile(expr.String())
+}
+
+// excludes returns whether the path relative to the build context is
+// excluded. The last matching pattern wins.
+func (di dockerIgnore) excludes(rel string) bool {
+	rel = filepath.ToSlash(rel)
+	excluded := false
+	for _, p := range di {
+		if p.regex.MatchString(rel) {
+			excluded = !p.exception
+		}
+	}
+	return excluded
+}
+
+// hasExceptions returns whether the file has exception patterns, which may
+// include paths within excluded directories.
+func (di dockerIgnore) hasExceptions() bool {
+	for _, p := range di {
+		if p.exception {
+			return true
+		}
+	}
+	return false
+}
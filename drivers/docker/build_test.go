@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestWriteBuildContext(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"Dockerfile":          "FROM busybox\nCOPY . /app\n",
+		".dockerignore":       "# comment\n*.log\nbuild\n**/secret\n!keep.log\nDockerfile\n",
+		"main.go":             "package main",
+		"debug.log":           "debug",
+		"keep.log":            "keep",
+		"build/out":           "binary",
+		"config/secret":       "secret",
+		"config/nested/value": "value",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		must.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		must.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	must.NoError(t, os.Symlink("main.go", filepath.Join(dir, "link")))
+
+	var buf bytes.Buffer
+	must.NoError(t, writeBuildContext(&buf, dir, "Dockerfile"))
+
+	entries := map[string]string{}
+	tr := tar.NewReader(&buf)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		must.NoError(t, err)
+		must.Zero(t, hdr.Uid)
+
+		content, err := io.ReadAll(tr)
+		must.NoError(t, err)
+		if hdr.Typeflag == tar.TypeSymlink {
+			content = []byte("-> " + hdr.Linkname)
+		}
+		entries[hdr.Name] = string(content)
+	}
+
+	must.Eq(t, map[string]string{
+		// the Dockerfile and .dockerignore are sent even if ignored
+		"Dockerfile":          files["Dockerfile"],
+		".dockerignore":       files[".dockerignore"],
+		"main.go":             files["main.go"],
+		"keep.log":            files["keep.log"],
+		"link":                "-> main.go",
+		"config/":             "",
+		"config/nested/":      "",
+		"config/nested/value": files["config/nested/value"],
+	}, entries)
+}
+
+func TestDockerIgnore_excludes(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		pattern string
+		path    string
+		exp     bool
+	}{
+		{pattern: "*.md", path: "README.md", exp: true},
+		{pattern: "*.md", path: "docs/README.md", exp: false},
+		{pattern: "*/*.md", path: "docs/README.md", exp: true},
+		{pattern: "**/*.md", path: "README.md", exp: true},
+		{pattern: "**/*.md", path: "docs/api/README.md", exp: true},
+		{pattern: "docs", path: "docs/api/README.md", exp: true},
+		{pattern: "/docs", path: "docs", exp: true},
+		{pattern: "doc?", path: "docs", exp: true},
+		{pattern: "docs", path: "docsite", exp: false},
+		{pattern: "node_modules/**", path: "node_modules/a/b", exp: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.pattern+" "+tc.path, func(t *testing.T) {
+			regex, err := compileIgnorePattern(tc.pattern)
+			must.NoError(t, err)
+			di := dockerIgnore{{regex: regex}}
+			must.Eq(t, tc.exp, di.excludes(tc.path))
+		})
+	}
+}
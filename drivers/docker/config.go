@@ -362,6 +362,18 @@ var (
 			"server_address": hclspec.NewAttr("server_address", "string", false),
 		})),
 		"auth_soft_fail": hclspec.NewAttr("auth_soft_fail", "bool", false),
+		"build": hclspec.NewBlock("build", false, hclspec.NewObject(map[string]*hclspec.Spec{
+			"context":    hclspec.NewAttr("context", "string", true),
+			"dockerfile": hclspec.NewAttr("dockerfile", "string", false),
+			"args":       hclspec.NewAttr("args", "list(map(string))", false),
+			"target":     hclspec.NewAttr("target", "string", false),
+			"pull":       hclspec.NewAttr("pull", "bool", false),
+			"no_cache":   hclspec.NewAttr("no_cache", "bool", false),
+			"timeout": hclspec.NewDefault(
+				hclspec.NewAttr("timeout", "string", false),
+				hclspec.NewLiteral(`"30m"`),
+			),
+		})),
 		"cap_add":        hclspec.NewAttr("cap_add", "list(string)", false),
 		"cap_drop":       hclspec.NewAttr("cap_drop", "list(string)", false),
 		"command":        hclspec.NewAttr("command", "string", false),
@@ -446,6 +458,7 @@ var (
 		},
 		MustInitiateNetwork: true,
 		MountConfigs:        drivers.MountConfigSupportAll,
+		Resize:              true,
 	}
 )
 
@@ -455,6 +468,7 @@ type TaskConfig struct {
 	Args                    []string           `codec:"args"`
 	Auth                    DockerAuth         `codec:"auth"`
 	AuthSoftFail            bool               `codec:"auth_soft_fail"`
+	Build                   DockerBuild        `codec:"build"`
 	CapAdd                  []string           `codec:"cap_add"`
 	CapDrop                 []string           `codec:"cap_drop"`
 	Command                 string             `codec:"command"`
@@ -518,6 +532,23 @@ type DockerAuth struct {
 	ServerAddr string `codec:"server_address"`
 }
 
+// DockerBuild configures building the task's image from a build context in
+// the task directory
+type DockerBuild struct {
+	Context    string             `codec:"context"`
+	Dockerfile string             `codec:"dockerfile"`
+	Args       hclutils.MapStrStr `codec:"args"`
+	Target     string             `codec:"target"`
+	Pull       bool               `codec:"pull"`
+	NoCache    bool               `codec:"no_cache"`
+	Timeout    string             `codec:"timeout"`
+}
+
+// enabled returns whether the task builds its image
+func (b *DockerBuild) enabled() bool {
+	return b.Context != ""
+}
+
 type DockerDevice struct {
 	HostPath          string `codec:"host_path"`
 	ContainerPath     string `codec:"container_path"`
@@ -212,6 +212,17 @@ config {
   }
 
   auth_soft_fail = true
+  build {
+    context = "local/src"
+    dockerfile = "build/Dockerfile"
+    args = {
+      VERSION = "1.2.3"
+    }
+    target = "release"
+    pull = true
+    no_cache = true
+    timeout = "1h"
+  }
   cap_add = ["CAP_SYS_NICE"]
   cap_drop = ["CAP_SYS_ADMIN", "CAP_SYS_TIME"]
   command = "/bin/bash"
@@ -361,7 +372,16 @@ config {
 			Email:      "myemail@example.com",
 			ServerAddr: "https://example.com",
 		},
-		AuthSoftFail:            true,
+		AuthSoftFail: true,
+		Build: DockerBuild{
+			Context:    "local/src",
+			Dockerfile: "build/Dockerfile",
+			Args:       map[string]string{"VERSION": "1.2.3"},
+			Target:     "release",
+			Pull:       true,
+			NoCache:    true,
+			Timeout:    "1h",
+		},
 		CapAdd:                  []string{"CAP_SYS_NICE"},
 		CapDrop:                 []string{"CAP_SYS_ADMIN", "CAP_SYS_TIME"},
 		Command:                 "/bin/bash",
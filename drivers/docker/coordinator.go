@@ -17,6 +17,7 @@ import (
 	"github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/errdefs"
 	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-set/v3"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -72,6 +73,7 @@ func (p *pullFuture) set(imageID, imageUser string, err error) {
 // DockerImageClient provides the methods required to do CRUD operations on the
 // Docker images
 type DockerImageClient interface {
+	ImageBuild(ctx context.Context, buildContext io.Reader, opts types.ImageBuildOptions) (types.ImageBuildResponse, error)
 	ImagePull(ctx context.Context, refStr string, opts image.PullOptions) (io.ReadCloser, error)
 	ImageInspectWithRaw(ctx context.Context, id string) (types.ImageInspect, []byte, error)
 	ImageRemove(ctx context.Context, id string, opts image.RemoveOptions) ([]image.DeleteResponse, error)
@@ -128,6 +130,10 @@ type dockerCoordinator struct {
 
 	// deleteFuture is indexed by image ID and has a cancelable delete future
 	deleteFuture map[string]context.CancelFunc
+
+	// buildLock is held for reading by builds until their image is
+	// referenced, and for writing while untracked built images are removed
+	buildLock sync.RWMutex
 }
 
 // newDockerCoordinator returns a new Docker coordinator
@@ -244,6 +250,100 @@ func (d *dockerCoordinator) pullImageImpl(imageID string, authOptions *registry.
 	return dockerImage.ID, imageUser, err
 }
 
+// BuildImage is used to build an image from the build context and tag it with
+// the image name. It returns the built image ID or an error that occurred
+// during the build. Unlike pulls, builds of the same image name are not shared
+// as their build contexts may differ.
+func (d *dockerCoordinator) BuildImage(image string, buildContext io.Reader, opts types.ImageBuildOptions, callerID string,
+	emitFn LogEventFn, buildTimeout, buildActivityTimeout time.Duration) (imageID, imageUser string, err error) {
+	if emitFn == nil {
+		emitFn = noopLogEventFn
+	}
+
+	d.buildLock.RLock()
+	defer d.buildLock.RUnlock()
+
+	buildCtx, cancel := context.WithTimeout(d.ctx, buildTimeout)
+	defer cancel()
+
+	progress := newBuildProgress()
+	pm := newProgressManager(image, progress, cancel, buildActivityTimeout, d.handleBuildInactivity,
+		d.handleBuildProgressReport, func(image, msg string, _ time.Time) {
+			emitFn(fmt.Sprintf("Docker image build progress: %s", msg), map[string]string{
+				"image": image,
+			})
+		})
+	defer pm.stop()
+
+	opts.Tags = []string{image}
+	resp, err := d.client.ImageBuild(buildCtx, buildContext, opts)
+	if err != nil {
+		d.logger.Error("failed building image", "image_name", image, "error", err)
+		return "", "", recoverableErrTimeouts(fmt.Errorf("Failed to build `%s`: %w", image, err))
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(pm, resp.Body); err != nil && !errors.Is(err, io.EOF) {
+		d.logger.Error("failed building image", "image_name", image, "error", err)
+		if buildCtx.Err() != nil {
+			return "", "", structs.NewRecoverableError(fmt.Errorf("Failed to build `%s`: %w", image, buildCtx.Err()), true)
+		}
+		return "", "", fmt.Errorf("Failed to build `%s`: %w", image, err)
+	}
+
+	d.logger.Debug("docker build succeeded", "image_name", image)
+
+	// Prefer the ID reported by the build, as concurrent builds may have
+	// tagged another image with the same name since
+	ref := progress.imageID
+	if ref == "" {
+		ref = image
+	}
+	dockerImage, _, err := d.client.ImageInspectWithRaw(d.ctx, ref)
+	if err != nil {
+		d.logger.Error("failed getting image id", "image_name", image, "error", err)
+		return "", "", recoverableErrTimeouts(err)
+	}
+
+	if dockerImage.Config != nil {
+		imageUser = dockerImage.Config.User
+	}
+
+	d.imageLock.Lock()
+	defer d.imageLock.Unlock()
+	if d.cleanup {
+		d.incrementImageReferenceImpl(dockerImage.ID, image, callerID)
+	}
+	return dockerImage.ID, imageUser, nil
+}
+
+// tryLockBuilds prevents builds from starting while the built images that are
+// not referenced are removed, and returns the function releasing them. It
+// returns false if builds are in flight, since their image may already exist
+// without being referenced yet.
+func (d *dockerCoordinator) tryLockBuilds() (func(), bool) {
+	if !d.buildLock.TryLock() {
+		return nil, false
+	}
+	return d.buildLock.Unlock, true
+}
+
+// referencedImages returns the IDs of the images with references or pending
+// removals
+func (d *dockerCoordinator) referencedImages() *set.Set[string] {
+	d.imageLock.Lock()
+	defer d.imageLock.Unlock()
+
+	ids := set.New[string](len(d.imageRefCount) + len(d.deleteFuture))
+	for id := range d.imageRefCount {
+		ids.Insert(id)
+	}
+	for id := range d.deleteFuture {
+		ids.Insert(id)
+	}
+	return ids
+}
+
 // IncrementImageReference is used to increment an image reference count
 func (d *dockerCoordinator) IncrementImageReference(imageID, imageName, callerID string) {
 	d.imageLock.Lock()
@@ -420,6 +520,15 @@ func (d *dockerCoordinator) handleSlowPullProgressReport(image, msg string, _ ti
 	})
 }
 
+func (d *dockerCoordinator) handleBuildInactivity(image, msg string, timestamp time.Time) {
+	d.logger.Error("image build aborted due to inactivity", "image_name", image,
+		"last_event_timestamp", timestamp.String(), "last_event", msg)
+}
+
+func (d *dockerCoordinator) handleBuildProgressReport(image, msg string, _ time.Time) {
+	d.logger.Debug("image build progress", "image_name", image, "message", msg)
+}
+
 // recoverablePullError wraps the error gotten when trying to pull and image if
 // the error is recoverable.
 func recoverablePullError(err error, image string) error {
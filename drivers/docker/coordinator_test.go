@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -23,16 +24,19 @@ import (
 )
 
 type mockImageClient struct {
-	pulled     map[string]int
-	idToName   map[string]string
-	removed    map[string]int
-	pullDelay  time.Duration
-	pullReader io.ReadCloser
-	lock       sync.Mutex
+	built       map[string]int
+	buildOutput string
+	pulled      map[string]int
+	idToName    map[string]string
+	removed     map[string]int
+	pullDelay   time.Duration
+	pullReader  io.ReadCloser
+	lock        sync.Mutex
 }
 
 func newMockImageClient(idToName map[string]string, pullDelay time.Duration) *mockImageClient {
 	return &mockImageClient{
+		built:     make(map[string]int),
 		pulled:    make(map[string]int),
 		removed:   make(map[string]int),
 		idToName:  idToName,
@@ -40,6 +44,18 @@ func newMockImageClient(idToName map[string]string, pullDelay time.Duration) *mo
 	}
 }
 
+func (m *mockImageClient) ImageBuild(ctx context.Context, buildContext io.Reader, opts types.ImageBuildOptions) (types.ImageBuildResponse, error) {
+	if _, err := io.Copy(io.Discard, buildContext); err != nil {
+		return types.ImageBuildResponse{}, err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.built[opts.Tags[0]]++
+	return types.ImageBuildResponse{
+		Body: io.NopCloser(strings.NewReader(m.buildOutput)),
+	}, nil
+}
+
 func (m *mockImageClient) ImagePull(ctx context.Context, refStr string, opts image.PullOptions) (io.ReadCloser, error) {
 	select {
 	case <-ctx.Done():
@@ -435,3 +451,105 @@ func TestDockerCoordinator_PullImage_Timeouts(t *testing.T) {
 		})
 	}
 }
+
+func TestDockerCoordinator_BuildImage(t *testing.T) {
+	ci.Parallel(t)
+
+	image := "app:ci"
+	imageID := "sha256:" + strings.Repeat("a", 64)
+	mock := newMockImageClient(map[string]string{imageID: imageID}, 0)
+	mock.buildOutput = `{"stream":"Step 1/1 : FROM busybox"}
+{"id":"moby.image.id","aux":{"ID":"` + imageID + `"}}
+`
+	coordinator := newDockerCoordinator(&dockerCoordinatorConfig{
+		ctx:         context.Background(),
+		logger:      testlog.HCLogger(t),
+		cleanup:     true,
+		client:      mock,
+		removeDelay: 1 * time.Millisecond,
+	})
+
+	callerIDs := []string{uuid.Generate(), uuid.Generate()}
+	for _, callerID := range callerIDs {
+		id, _, err := coordinator.BuildImage(image, strings.NewReader("context"), types.ImageBuildOptions{},
+			callerID, nil, time.Minute, time.Minute)
+		must.NoError(t, err)
+		must.Eq(t, imageID, id)
+	}
+
+	// Builds are not shared, but the built image is reference counted
+	must.Eq(t, 2, mock.built[image])
+	must.MapLen(t, 2, coordinator.imageRefCount[imageID])
+	must.True(t, coordinator.referencedImages().Contains(imageID))
+
+	for _, callerID := range callerIDs {
+		coordinator.RemoveImage(imageID, callerID)
+	}
+	testutil.WaitForResult(func() (bool, error) {
+		mock.lock.Lock()
+		defer mock.lock.Unlock()
+		removes := mock.removed[imageID]
+		return removes == 1, fmt.Errorf("Wrong number of removes: %d", removes)
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	// Build errors are returned
+	mock.buildOutput = `{"errorDetail":{"message":"failed to solve"},"error":"failed to solve"}
+`
+	_, _, err := coordinator.BuildImage(image, strings.NewReader("context"), types.ImageBuildOptions{},
+		uuid.Generate(), nil, time.Minute, time.Minute)
+	must.ErrorContains(t, err, "failed to solve")
+}
+
+func TestDockerCoordinator_BuildImage_LocksBuilds(t *testing.T) {
+	ci.Parallel(t)
+
+	imageID := "sha256:" + strings.Repeat("b", 64)
+	mock := newMockImageClient(map[string]string{imageID: imageID}, 0)
+	mock.buildOutput = `{"id":"moby.image.id","aux":{"ID":"` + imageID + `"}}
+`
+	coordinator := newDockerCoordinator(&dockerCoordinatorConfig{
+		ctx:         context.Background(),
+		logger:      testlog.HCLogger(t),
+		cleanup:     true,
+		client:      mock,
+		removeDelay: 1 * time.Millisecond,
+	})
+
+	// The build is in flight until its context is sent
+	pr, pw := io.Pipe()
+	built := make(chan error, 1)
+	go func() {
+		_, _, err := coordinator.BuildImage("app:ci", pr, types.ImageBuildOptions{},
+			uuid.Generate(), nil, time.Minute, time.Minute)
+		built <- err
+	}()
+	_, err := pw.Write([]byte("context"))
+	must.NoError(t, err)
+
+	_, ok := coordinator.tryLockBuilds()
+	must.False(t, ok)
+
+	must.NoError(t, pw.Close())
+	must.NoError(t, <-built)
+
+	// Once the build completes its image is referenced
+	unlock, ok := coordinator.tryLockBuilds()
+	must.True(t, ok)
+	must.True(t, coordinator.referencedImages().Contains(imageID))
+
+	// Builds wait for the removal of untracked images to complete
+	go func() {
+		_, _, err := coordinator.BuildImage("app:ci", strings.NewReader("context"), types.ImageBuildOptions{},
+			uuid.Generate(), nil, time.Minute, time.Minute)
+		built <- err
+	}()
+	select {
+	case <-built:
+		t.Fatal("build started while builds were locked")
+	case <-time.After(100 * time.Millisecond):
+	}
+	unlock()
+	must.NoError(t, <-built)
+}
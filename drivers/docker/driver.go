@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/blkiodev"
 	containerapi "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/mount"
@@ -608,8 +609,8 @@ START:
 	return recoverableErrTimeouts(startErr)
 }
 
-// createImage creates a docker image either by pulling it from a registry or by
-// loading it from the file system
+// createImage creates a docker image either by pulling it from a registry, by
+// loading it from the file system or by building it
 func (d *Driver) createImage(task *drivers.TaskConfig, driverConfig *TaskConfig, client *client.Client) (string, string, error) {
 	image := driverConfig.Image
 	repo, tag, err := parseDockerImage(image)
@@ -617,6 +618,15 @@ func (d *Driver) createImage(task *drivers.TaskConfig, driverConfig *TaskConfig,
 		return "", "", fmt.Errorf("unable to create local docker image %q: %w", image, err)
 	}
 
+	// Build the image if specified. Builds always run, as the build context
+	// may have changed since an image with the same name was built.
+	if driverConfig.Build.enabled() {
+		if driverConfig.LoadImage != "" {
+			return "", "", fmt.Errorf("only one of build and load may be set")
+		}
+		return d.buildImage(task, driverConfig)
+	}
+
 	// We're going to check whether the image is already downloaded. If the tag
 	// is "latest", or ForcePull is set, we have to check for a new version every time so we don't
 	// bother to check and cache the id here. We'll download first, then cache.
@@ -946,6 +956,27 @@ func memoryLimits(driverHardLimitMB int64, taskMemory drivers.MemoryResources) (
 	return hard * 1024 * 1024, softBytes
 }
 
+// setBlkioResources sets the block io weight and per device limits of the
+// task. Docker finds the devices by their path.
+func setBlkioResources(res *containerapi.Resources, io nstructs.AllocatedIOResources) {
+	res.BlkioWeight = uint16(io.Weight)
+	for _, limit := range io.Max {
+		throttle := func(devices []*blkiodev.ThrottleDevice, rate int64) []*blkiodev.ThrottleDevice {
+			if rate <= 0 {
+				return devices
+			}
+			return append(devices, &blkiodev.ThrottleDevice{
+				Path: "/dev/" + limit.Device,
+				Rate: uint64(rate),
+			})
+		}
+		res.BlkioDeviceReadBps = throttle(res.BlkioDeviceReadBps, limit.ReadBps)
+		res.BlkioDeviceWriteBps = throttle(res.BlkioDeviceWriteBps, limit.WriteBps)
+		res.BlkioDeviceReadIOps = throttle(res.BlkioDeviceReadIOps, limit.ReadIOps)
+		res.BlkioDeviceWriteIOps = throttle(res.BlkioDeviceWriteIOps, limit.WriteIOps)
+	}
+}
+
 func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *TaskConfig,
 	imageID string) (createContainerOptions, error) {
 
@@ -1091,6 +1122,9 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 		} else {
 			hostConfig.MemorySwappiness = nil
 		}
+
+		// Windows does not support block io limits either
+		setBlkioResources(&hostConfig.Resources, task.Resources.NomadResources.IO)
 	}
 
 	loggingDriver := driverConfig.Logging.Type
@@ -1764,6 +1798,56 @@ func (d *Driver) SignalTask(taskID string, signal string) error {
 	return h.dockerClient.ContainerKill(d.ctx, h.containerID, signal)
 }
 
+// UpdateTaskResources updates the cpu and memory limits of a running
+// container the same way they are computed when the container is created.
+func (d *Driver) UpdateTaskResources(taskID string, resources *drivers.Resources) error {
+	h, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+	if resources == nil || resources.NomadResources == nil || resources.LinuxResources == nil {
+		return nil
+	}
+
+	var driverConfig TaskConfig
+	if err := h.task.DecodeDriverConfig(&driverConfig); err != nil {
+		return fmt.Errorf("failed to decode driver config: %v", err)
+	}
+
+	memory, memoryReservation := memoryLimits(driverConfig.MemoryHardLimit, resources.NomadResources.Memory)
+	update := containerapi.UpdateConfig{
+		Resources: containerapi.Resources{
+			Memory:            memory,
+			MemoryReservation: memoryReservation,
+			CPUShares:         resources.LinuxResources.CPUShares,
+		},
+	}
+
+	// Keep swap disabled, the swap limit must not be lower than the memory
+	// limit
+	if runtime.GOOS != "windows" {
+		update.MemorySwap = memory
+	}
+
+	if driverConfig.CPUHardLimit {
+		period := driverConfig.CPUCFSPeriod
+		if period == 0 {
+			period = resources.LinuxResources.CPUPeriod
+		}
+		update.CPUPeriod = period
+		update.CPUQuota = int64(resources.LinuxResources.PercentTicks*float64(period)) * int64(runtime.NumCPU())
+	}
+
+	h.logger.Debug("updating container resources", "memory", memory,
+		"memory_reservation", memoryReservation, "cpu_shares", update.CPUShares,
+		"cpu_quota", update.CPUQuota)
+
+	if _, err := h.dockerClient.ContainerUpdate(d.ctx, h.containerID, update); err != nil {
+		return fmt.Errorf("failed to update container resources: %v", err)
+	}
+	return nil
+}
+
 func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*drivers.ExecTaskResult, error) {
 	h, ok := d.tasks.Get(taskID)
 	if !ok {
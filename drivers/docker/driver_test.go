@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/blkiodev"
 	"github.com/docker/docker/api/types/container"
 	containerapi "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/events"
@@ -1101,6 +1102,29 @@ func TestDockerDriver_CreateContainerConfig(t *testing.T) {
 	must.Eq(t, containerName, c.Name)
 }
 
+func TestDockerDriver_setBlkioResources(t *testing.T) {
+	ci.Parallel(t)
+
+	var res containerapi.Resources
+	setBlkioResources(&res, structs.AllocatedIOResources{
+		Weight: 500,
+		Max: structs.IOLimits{{
+			Device:   "sda",
+			ReadBps:  1048576,
+			WriteBps: 2097152,
+		}, {
+			Device:    "nvme0n1",
+			WriteIOps: 100,
+		}},
+	})
+
+	must.Eq(t, 500, res.BlkioWeight)
+	must.Eq(t, []*blkiodev.ThrottleDevice{{Path: "/dev/sda", Rate: 1048576}}, res.BlkioDeviceReadBps)
+	must.Eq(t, []*blkiodev.ThrottleDevice{{Path: "/dev/sda", Rate: 2097152}}, res.BlkioDeviceWriteBps)
+	must.SliceEmpty(t, res.BlkioDeviceReadIOps)
+	must.Eq(t, []*blkiodev.ThrottleDevice{{Path: "/dev/nvme0n1", Rate: 100}}, res.BlkioDeviceWriteIOps)
+}
+
 func TestDockerDriver_CreateContainerConfig_RuntimeConflict(t *testing.T) {
 	ci.Parallel(t)
 
@@ -130,6 +130,7 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 	d.setDetected(true)
 	fp.Attributes["driver.docker"] = pstructs.NewBoolAttribute(true)
 	fp.Attributes["driver.docker.version"] = pstructs.NewStringAttribute(env.Version)
+	fp.Attributes["driver.docker.resize"] = pstructs.NewBoolAttribute(true)
 	if d.config.AllowPrivileged {
 		fp.Attributes["driver.docker.privileged.enabled"] = pstructs.NewBoolAttribute(true)
 	}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/docker/docker/pkg/jsonmessage"
 	units "github.com/docker/go-units"
+	"google.golang.org/protobuf/encoding/protowire"
 )
 
 const (
@@ -175,18 +176,209 @@ func (p *imageProgress) totalBytes() int64 {
 	return b
 }
 
+const (
+	// buildkitTraceID is the ID of the status messages carrying the
+	// protobuf encoded BuildKit solve status
+	buildkitTraceID = "moby.buildkit.trace"
+)
+
+// buildStep tracks the state of a single vertex of a BuildKit build graph
+type buildStep struct {
+	name      string
+	cached    bool
+	started   bool
+	completed bool
+}
+
+// buildProgress tracks the status of each step of an image build
+type buildProgress struct {
+	sync.RWMutex
+	timestamp time.Time
+	steps     map[string]*buildStep
+
+	// current is the name of the last started step
+	current string
+
+	// imageID is the ID of the built image once the build completed
+	imageID string
+}
+
+func newBuildProgress() *buildProgress {
+	return &buildProgress{
+		timestamp: time.Now(),
+		steps:     make(map[string]*buildStep),
+	}
+}
+
+// get returns a status message and the timestamp of the last status update
+func (p *buildProgress) get() (string, time.Time) {
+	p.RLock()
+	defer p.RUnlock()
+
+	if len(p.steps) == 0 {
+		return "No progress", p.timestamp
+	}
+
+	var completed, cached int
+	for _, s := range p.steps {
+		if s.completed {
+			completed++
+		}
+		if s.cached {
+			cached++
+		}
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "Built %d/%d steps (%d cached)", completed, len(p.steps), cached)
+	if p.current != "" {
+		fmt.Fprintf(&msg, ": %s", p.current)
+	}
+	return msg.String(), p.timestamp
+}
+
+// set takes a status message received from the docker engine api during an image
+// build and updates the status of the corresponding steps
+func (p *buildProgress) set(msg *jsonmessage.JSONMessage) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.timestamp = time.Now()
+	if msg.Aux == nil {
+		return
+	}
+
+	if msg.ID == buildkitTraceID {
+		var buf []byte
+		if err := json.Unmarshal(*msg.Aux, &buf); err != nil {
+			return
+		}
+		vertexes, err := decodeBuildkitVertexes(buf)
+		if err != nil {
+			return
+		}
+		for _, v := range vertexes {
+			step, ok := p.steps[v.digest]
+			if !ok {
+				step = &buildStep{}
+				p.steps[v.digest] = step
+			}
+			if v.name != "" {
+				step.name = v.name
+			}
+			step.cached = step.cached || v.cached
+			step.started = step.started || v.started
+			step.completed = step.completed || v.completed
+			if step.started && !step.completed {
+				p.current = step.name
+			}
+		}
+		return
+	}
+
+	// The ID of the built image is sent as an auxiliary message
+	var result struct {
+		ID string
+	}
+	if err := json.Unmarshal(*msg.Aux, &result); err == nil && strings.HasPrefix(result.ID, "sha256:") {
+		p.imageID = result.ID
+	}
+}
+
+// buildkitVertex is the subset of a BuildKit Vertex message tracked by
+// buildProgress, see
+// https://github.com/moby/buildkit/blob/master/api/services/control/control.proto
+type buildkitVertex struct {
+	digest    string
+	name      string
+	cached    bool
+	started   bool
+	completed bool
+}
+
+// decodeBuildkitVertexes decodes the vertexes of a protobuf encoded BuildKit
+// StatusResponse message.
+func decodeBuildkitVertexes(buf []byte) ([]buildkitVertex, error) {
+	var vertexes []buildkitVertex
+	err := walkProto(buf, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		// StatusResponse.vertexes
+		if num != 1 || typ != protowire.BytesType {
+			return nil
+		}
+		msg, _ := protowire.ConsumeBytes(value)
+
+		var v buildkitVertex
+		err := walkProto(msg, func(num protowire.Number, typ protowire.Type, value []byte) error {
+			switch {
+			case num == 1 && typ == protowire.BytesType:
+				digest, _ := protowire.ConsumeBytes(value)
+				v.digest = string(digest)
+			case num == 3 && typ == protowire.BytesType:
+				name, _ := protowire.ConsumeBytes(value)
+				v.name = string(name)
+			case num == 4 && typ == protowire.VarintType:
+				cached, _ := protowire.ConsumeVarint(value)
+				v.cached = cached != 0
+			case num == 5:
+				v.started = true
+			case num == 6:
+				v.completed = true
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		vertexes = append(vertexes, v)
+		return nil
+	})
+	return vertexes, err
+}
+
+// walkProto calls fn with the number, type and encoded value of each field of
+// the protobuf message.
+func walkProto(buf []byte, fn func(num protowire.Number, typ protowire.Type, value []byte) error) error {
+	for len(buf) > 0 {
+		num, typ, n := protowire.ConsumeTag(buf)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		buf = buf[n:]
+
+		n = protowire.ConsumeFieldValue(num, typ, buf)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		if err := fn(num, typ, buf[:n]); err != nil {
+			return err
+		}
+		buf = buf[n:]
+	}
+	return nil
+}
+
+// progressTracker tracks the progress of an image operation from the status
+// messages of the docker engine api
+type progressTracker interface {
+	// get returns a status message and the timestamp of the last status update
+	get() (string, time.Time)
+
+	// set updates the progress from a status message
+	set(msg *jsonmessage.JSONMessage)
+}
+
 // progressReporterFunc defines the method for handling inactivity and report
 // events from the imageProgressManager. The image name, current status message
 // and timestamp of last received status update are passed in.
 type progressReporterFunc func(image string, msg string, timestamp time.Time)
 
 // imageProgressManager tracks the progress of pulling a docker image from an
-// image repository.
+// image repository or of building it.
 // It also implemented the io.Writer interface so as to be passed to the docker
 // client pull image method in order to receive status updates from the docker
 // engine api.
 type imageProgressManager struct {
-	imageProgress      *imageProgress
+	progress           progressTracker
 	image              string
 	activityDeadline   time.Duration
 	inactivityFunc     progressReporterFunc
@@ -204,20 +396,32 @@ func newImageProgressManager(
 	image string, cancel context.CancelFunc,
 	pullActivityTimeout time.Duration, inactivityFunc, reporter, slowReporter progressReporterFunc) *imageProgressManager {
 
+	now := time.Now()
+	progress := &imageProgress{
+		timestamp: now,
+		pullStart: now,
+		layers:    make(map[string]*layerProgress),
+	}
+	return newProgressManager(image, progress, cancel, pullActivityTimeout, inactivityFunc, reporter, slowReporter)
+}
+
+// newProgressManager returns a started manager reporting the progress of the
+// tracker.
+func newProgressManager(
+	image string, progress progressTracker, cancel context.CancelFunc,
+	activityTimeout time.Duration, inactivityFunc, reporter, slowReporter progressReporterFunc) *imageProgressManager {
+
 	pm := &imageProgressManager{
+		progress:           progress,
 		image:              image,
-		activityDeadline:   pullActivityTimeout,
+		activityDeadline:   activityTimeout,
 		inactivityFunc:     inactivityFunc,
 		reportInterval:     dockerImageProgressReportInterval,
 		reporter:           reporter,
 		slowReportInterval: dockerImageSlowProgressReportInterval,
 		slowReporter:       slowReporter,
-		imageProgress: &imageProgress{
-			timestamp: time.Now(),
-			layers:    make(map[string]*layerProgress),
-		},
-		cancel: cancel,
-		stopCh: make(chan struct{}),
+		cancel:             cancel,
+		stopCh:             make(chan struct{}),
 	}
 
 	pm.start()
@@ -226,15 +430,13 @@ func newImageProgressManager(
 
 // start intiates the ticker to trigger the inactivity and reporter handlers
 func (pm *imageProgressManager) start() {
-	now := time.Now()
-	pm.imageProgress.pullStart = now
-	pm.lastSlowReport = now
+	pm.lastSlowReport = time.Now()
 	go func() {
 		ticker := time.NewTicker(dockerImageProgressReportInterval)
 		for {
 			select {
 			case <-ticker.C:
-				msg, lastStatusTime := pm.imageProgress.get()
+				msg, lastStatusTime := pm.progress.get()
 				t := time.Now()
 				if t.Sub(lastStatusTime) > pm.activityDeadline {
 					pm.inactivityFunc(pm.image, msg, lastStatusTime)
@@ -259,9 +461,9 @@ func (pm *imageProgressManager) stop() {
 
 func (pm *imageProgressManager) Write(p []byte) (n int, err error) {
 	n, err = pm.buf.Write(p)
-	var msg jsonmessage.JSONMessage
 
 	for {
+		var msg jsonmessage.JSONMessage
 		line, err := pm.buf.ReadBytes('\n')
 		if err == io.EOF {
 			// Partial write of line; push back onto buffer and break until full line
@@ -281,7 +483,7 @@ func (pm *imageProgressManager) Write(p []byte) (n int, err error) {
 			return n, msg.Error
 		}
 
-		pm.imageProgress.set(&msg)
+		pm.progress.set(&msg)
 	}
 
 	return
@@ -4,54 +4,129 @@
 package docker
 
 import (
+	"encoding/base64"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/shoenig/test/must"
+	"google.golang.org/protobuf/encoding/protowire"
 )
 
 func Test_DockerImageProgressManager(t *testing.T) {
 	ci.Parallel(t)
 
-	pm := &imageProgressManager{
-		imageProgress: &imageProgress{
-			timestamp: time.Now(),
-			layers:    make(map[string]*layerProgress),
-		},
+	progress := &imageProgress{
+		timestamp: time.Now(),
+		layers:    make(map[string]*layerProgress),
 	}
+	pm := &imageProgressManager{progress: progress}
 
 	_, err := pm.Write([]byte(`{"status":"Pulling from library/golang","id":"1.9.5"}
 {"status":"Pulling fs layer","progressDetail":{},"id":"c73ab1c6897b"}
 {"status":"Pulling fs layer","progressDetail":{},"id":"1ab373b3deae"}
 `))
 	must.NoError(t, err)
-	must.Eq(t, 2, len(pm.imageProgress.layers), must.Sprint("number of layers should be 2"))
+	must.Eq(t, 2, len(progress.layers), must.Sprint("number of layers should be 2"))
 
-	cur := pm.imageProgress.currentBytes()
+	cur := progress.currentBytes()
 	must.Zero(t, cur)
-	tot := pm.imageProgress.totalBytes()
+	tot := progress.totalBytes()
 	must.Zero(t, tot)
 
 	_, err = pm.Write([]byte(`{"status":"Pulling fs layer","progress`))
 	must.NoError(t, err)
-	must.Eq(t, 2, len(pm.imageProgress.layers), must.Sprint("number of layers should be 2"))
+	must.Eq(t, 2, len(progress.layers), must.Sprint("number of layers should be 2"))
 
 	_, err = pm.Write([]byte(`Detail":{},"id":"b542772b4177"}` + "\n"))
 	must.NoError(t, err)
-	must.Eq(t, 3, len(pm.imageProgress.layers), must.Sprint("number of layers should be 3"))
+	must.Eq(t, 3, len(progress.layers), must.Sprint("number of layers should be 3"))
 
 	_, err = pm.Write([]byte(`{"status":"Downloading","progressDetail":{"current":45800,"total":4335495},"progress":"[\u003e                                                  ]   45.8kB/4.335MB","id":"b542772b4177"}
 {"status":"Downloading","progressDetail":{"current":113576,"total":11108010},"progress":"[\u003e                                                  ]  113.6kB/11.11MB","id":"1ab373b3deae"}
 {"status":"Downloading","progressDetail":{"current":694257,"total":4335495},"progress":"[========\u003e                                          ]  694.3kB/4.335MB","id":"b542772b4177"}` + "\n"))
 	must.NoError(t, err)
-	must.Eq(t, 3, len(pm.imageProgress.layers), must.Sprint("number of layers should be 3"))
-	must.Eq(t, int64(807833), pm.imageProgress.currentBytes())
-	must.Eq(t, int64(15443505), pm.imageProgress.totalBytes())
+	must.Eq(t, 3, len(progress.layers), must.Sprint("number of layers should be 3"))
+	must.Eq(t, int64(807833), progress.currentBytes())
+	must.Eq(t, int64(15443505), progress.totalBytes())
 
 	_, err = pm.Write([]byte(`{"status":"Download complete","progressDetail":{},"id":"b542772b4177"}` + "\n"))
 	must.NoError(t, err)
-	must.Eq(t, 3, len(pm.imageProgress.layers), must.Sprint("number of layers should be 3"))
-	must.Eq(t, int64(4449071), pm.imageProgress.currentBytes())
-	must.Eq(t, int64(15443505), pm.imageProgress.totalBytes())
+	must.Eq(t, 3, len(progress.layers), must.Sprint("number of layers should be 3"))
+	must.Eq(t, int64(4449071), progress.currentBytes())
+	must.Eq(t, int64(15443505), progress.totalBytes())
+}
+
+func Test_DockerBuildProgress(t *testing.T) {
+	ci.Parallel(t)
+
+	// vertex encodes a BuildKit Vertex message
+	vertex := func(digest, name string, cached, started, completed bool) []byte {
+		var b []byte
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendString(b, digest)
+		if name != "" {
+			b = protowire.AppendTag(b, 3, protowire.BytesType)
+			b = protowire.AppendString(b, name)
+		}
+		if cached {
+			b = protowire.AppendTag(b, 4, protowire.VarintType)
+			b = protowire.AppendVarint(b, 1)
+		}
+		// started and completed are timestamps, of which only the presence
+		// is tracked
+		if started {
+			b = protowire.AppendTag(b, 5, protowire.BytesType)
+			b = protowire.AppendBytes(b, []byte{0x08, 0x01})
+		}
+		if completed {
+			b = protowire.AppendTag(b, 6, protowire.BytesType)
+			b = protowire.AppendBytes(b, []byte{0x08, 0x02})
+		}
+		return b
+	}
+
+	// trace encodes a StatusResponse message as a status message line
+	trace := func(vertexes ...[]byte) string {
+		var b []byte
+		for _, v := range vertexes {
+			b = protowire.AppendTag(b, 1, protowire.BytesType)
+			b = protowire.AppendBytes(b, v)
+		}
+		// logs are ignored
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendBytes(b, []byte{0x0a, 0x01, 0x61})
+		return `{"id":"moby.buildkit.trace","aux":"` + base64.StdEncoding.EncodeToString(b) + `"}` + "\n"
+	}
+
+	progress := newBuildProgress()
+	pm := &imageProgressManager{progress: progress}
+
+	msg, _ := progress.get()
+	must.Eq(t, "No progress", msg)
+
+	_, err := pm.Write([]byte(trace(
+		vertex("sha256:1", "[1/3] FROM busybox", true, true, true),
+		vertex("sha256:2", "[2/3] COPY . /app", false, true, false),
+		vertex("sha256:3", "[3/3] RUN make", false, false, false),
+	)))
+	must.NoError(t, err)
+	msg, _ = progress.get()
+	must.Eq(t, "Built 1/3 steps (1 cached): [2/3] COPY . /app", msg)
+
+	// Updates may omit the name of the vertex
+	_, err = pm.Write([]byte(trace(
+		vertex("sha256:2", "", false, true, true),
+		vertex("sha256:3", "", false, true, false),
+	)))
+	must.NoError(t, err)
+	msg, _ = progress.get()
+	must.Eq(t, "Built 2/3 steps (1 cached): [3/3] RUN make", msg)
+
+	_, err = pm.Write([]byte(`{"id":"moby.image.id","aux":{"ID":"sha256:abc"}}` + "\n"))
+	must.NoError(t, err)
+	must.Eq(t, "sha256:abc", progress.imageID)
+
+	_, err = pm.Write([]byte(`{"errorDetail":{"message":"process \"make\" did not complete successfully"},"error":"process \"make\" did not complete successfully"}` + "\n"))
+	must.ErrorContains(t, err, "did not complete successfully")
 }
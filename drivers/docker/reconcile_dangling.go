@@ -12,6 +12,8 @@ import (
 
 	"github.com/docker/docker/api/types"
 	containerapi "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/client"
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-set/v3"
@@ -23,6 +25,10 @@ import (
 // possible for Docker to start a container successfully, but have the
 // creation API call fail with a network error.  containerReconciler
 // scans for these untracked containers and kill them.
+//
+// When image cleanup is enabled, it also removes the images built by the
+// driver that are no longer referenced, such as the images of tasks that
+// stopped while the client was down.
 type containerReconciler struct {
 	ctx       context.Context
 	config    *ContainerGCConfig
@@ -33,6 +39,10 @@ type containerReconciler struct {
 	trackedContainers func() set.Collection[string]
 	isNomadContainer  func(c types.Container) bool
 
+	cleanupImages bool
+	trackedImages func() set.Collection[string]
+	lockBuilds    func() (func(), bool)
+
 	once sync.Once
 }
 
@@ -46,6 +56,10 @@ func newReconciler(d *Driver) *containerReconciler {
 		isDriverHealthy:   func() bool { return d.previouslyDetected() && d.fingerprintSuccessful() },
 		trackedContainers: d.trackedContainers,
 		isNomadContainer:  isNomadContainer,
+
+		cleanupImages: d.config.GC.Image,
+		trackedImages: d.trackedImages,
+		lockBuilds:    d.lockBuilds,
 	}
 }
 
@@ -96,6 +110,16 @@ func (r *containerReconciler) removeDanglingContainersGoroutine() {
 
 func (r *containerReconciler) removeDanglingContainersIteration() error {
 	cutoff := time.Now().Add(-r.config.CreationGrace)
+	if err := r.removeUntrackedContainers(cutoff); err != nil {
+		return err
+	}
+	if r.cleanupImages {
+		return r.removeUntrackedImages(cutoff)
+	}
+	return nil
+}
+
+func (r *containerReconciler) removeUntrackedContainers(cutoff time.Time) error {
 	tracked := r.trackedContainers()
 	untracked, err := r.untrackedContainers(tracked, cutoff)
 	if err != nil {
@@ -170,6 +194,86 @@ func (r *containerReconciler) untrackedContainers(tracked set.Collection[string]
 	return result, nil
 }
 
+func (r *containerReconciler) removeUntrackedImages(cutoff time.Time) error {
+	// The image of a build is only tracked once the build completes, and is
+	// older than the cutoff if its layers were cached, so images are only
+	// removed while no build is in flight
+	unlock, ok := r.lockBuilds()
+	if !ok {
+		r.logger.Trace("skipping removal of untracked built images while images are built")
+		return nil
+	}
+	defer unlock()
+
+	untracked, err := r.untrackedImages(r.trackedImages(), cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to find untracked images: %v", err)
+	}
+
+	if untracked.Empty() {
+		return nil
+	}
+
+	if r.config.DryRun {
+		r.logger.Info("detected untracked built images", "image_ids", untracked)
+		return nil
+	}
+
+	dockerClient, err := r.getClient()
+	if err != nil {
+		return err
+	}
+
+	for id := range untracked.Items() {
+		ctx, cancel := r.dockerAPIQueryContext()
+		_, err := dockerClient.ImageRemove(ctx, id, image.RemoveOptions{Force: true})
+		cancel()
+		if err != nil {
+			r.logger.Warn("failed to remove untracked built image", "image_id", id, "error", err)
+		} else {
+			r.logger.Info("removed untracked built image", "image_id", id)
+		}
+	}
+
+	return nil
+}
+
+// untrackedImages returns the ids of images built by the driver that are
+// neither used by its tasks nor referenced by its coordinator
+func (r *containerReconciler) untrackedImages(tracked set.Collection[string], cutoffTime time.Time) (*set.Set[string], error) {
+	result := set.New[string](10)
+
+	ctx, cancel := r.dockerAPIQueryContext()
+	defer cancel()
+
+	dockerClient, err := r.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := dockerClient.ImageList(ctx, image.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", dockerLabelBuildAllocID)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %v", err)
+	}
+
+	cutoff := cutoffTime.Unix()
+
+	for _, img := range images {
+		if tracked.Contains(img.ID) {
+			continue
+		}
+
+		if img.Created > cutoff {
+			continue
+		}
+
+		result.Insert(img.ID)
+	}
+	return result, nil
+}
+
 // dockerAPIQueryTimeout returns a context for docker API response with an appropriate timeout
 // to protect against wedged locked-up API call.
 //
@@ -233,3 +337,22 @@ func (d *Driver) trackedContainers() set.Collection[string] {
 	// now also accumulate pause containers
 	return d.pauseContainers.union(ids)
 }
+
+// lockBuilds prevents the coordinator from building images, see
+// dockerCoordinator.tryLockBuilds.
+func (d *Driver) lockBuilds() (func(), bool) {
+	if d.coordinator == nil {
+		return func() {}, true
+	}
+	return d.coordinator.tryLockBuilds()
+}
+
+// trackedImages returns the set of IDs of images used by the tasks of the
+// Driver or referenced by its coordinator.
+func (d *Driver) trackedImages() set.Collection[string] {
+	ids := d.tasks.ImageIDs()
+	if d.coordinator != nil {
+		ids.InsertSet(d.coordinator.referencedImages())
+	}
+	return ids
+}
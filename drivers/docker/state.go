@@ -42,6 +42,18 @@ func (ts *taskStore) IDs() *set.Set[string] {
 	return s
 }
 
+// ImageIDs returns the IDs of the images of the tasks' containers
+func (ts *taskStore) ImageIDs() *set.Set[string] {
+	ts.lock.RLock()
+	defer ts.lock.RUnlock()
+
+	s := set.New[string](len(ts.store))
+	for _, handle := range ts.store {
+		s.Insert(handle.containerImage)
+	}
+	return s
+}
+
 func (ts *taskStore) Delete(id string) {
 	ts.lock.Lock()
 	defer ts.lock.Unlock()
@@ -84,14 +84,17 @@ var (
 			hclspec.NewAttr("allow_caps", "list(string)", false),
 			hclspec.NewLiteral(capabilities.HCLSpecLiteral),
 		),
-		"denied_host_uids": hclspec.NewAttr("denied_host_uids", "string", false),
-		"denied_host_gids": hclspec.NewAttr("denied_host_gids", "string", false),
+		"denied_host_uids":    hclspec.NewAttr("denied_host_uids", "string", false),
+		"denied_host_gids":    hclspec.NewAttr("denied_host_gids", "string", false),
+		"image_paths":         hclspec.NewAttr("image_paths", "list(string)", false),
+		"insecure_registries": hclspec.NewAttr("insecure_registries", "list(string)", false),
 	})
 
 	// taskConfigSpec is the hcl specification for the driver config section of
 	// a task within a job. It is returned in the TaskConfigSchema RPC
 	taskConfigSpec = hclspec.NewObject(map[string]*hclspec.Spec{
-		"command":  hclspec.NewAttr("command", "string", true),
+		"command":  hclspec.NewAttr("command", "string", false),
+		"image":    hclspec.NewAttr("image", "string", false),
 		"args":     hclspec.NewAttr("args", "list(string)", false),
 		"pid_mode": hclspec.NewAttr("pid_mode", "string", false),
 		"ipc_mode": hclspec.NewAttr("ipc_mode", "string", false),
@@ -111,6 +114,7 @@ var (
 			drivers.NetIsolationModeGroup,
 		},
 		MountConfigs: drivers.MountConfigSupportAll,
+		Resize:       true,
 	}
 )
 
@@ -168,6 +172,14 @@ type Config struct {
 
 	DeniedHostUids string `codec:"denied_host_uids"`
 	DeniedHostGids string `codec:"denied_host_gids"`
+
+	// ImagePaths is an allow-list of paths OCI image layouts may be loaded
+	// from, in addition to the allocation directory
+	ImagePaths []string `codec:"image_paths"`
+
+	// InsecureRegistries are the registries images are pulled from over
+	// plain HTTP
+	InsecureRegistries []string `codec:"insecure_registries"`
 }
 
 func (c *Config) validate() error {
@@ -193,9 +205,14 @@ func (c *Config) validate() error {
 
 // TaskConfig is the driver configuration of a task within a job
 type TaskConfig struct {
-	// Command is the thing to exec.
+	// Command is the thing to exec. It defaults to the entrypoint of the
+	// image.
 	Command string `codec:"command"`
 
+	// Image is the reference of the OCI image unpacked into the task
+	// directory.
+	Image string `codec:"image"`
+
 	// Args are passed along to Command.
 	Args []string `codec:"args"`
 
@@ -342,7 +359,9 @@ func (d *Driver) TaskConfigSchema() (*hclspec.Spec, error) {
 // Capabilities is returned by the Capabilities RPC and indicates what
 // optional features this driver supports
 func (d *Driver) Capabilities() (*drivers.Capabilities, error) {
-	return driverCapabilities, nil
+	caps := *driverCapabilities
+	caps.Checkpoint = executor.CheckpointSupported()
+	return &caps, nil
 }
 
 func (d *Driver) Fingerprint(ctx context.Context) (<-chan *drivers.Fingerprint, error) {
@@ -397,6 +416,7 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 	}
 
 	fp.Attributes["driver.exec"] = pstructs.NewBoolAttribute(true)
+	fp.Attributes["driver.exec.resize"] = pstructs.NewBoolAttribute(true)
 	d.setFingerprintSuccess()
 	return fp
 }
@@ -456,7 +476,21 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 	return nil
 }
 
-func (d *Driver) StartTask(cfg *drivers.TaskConfig) (handle *drivers.TaskHandle, network *drivers.DriverNetwork, err error) {
+func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	return d.startTask(cfg, "")
+}
+
+// RestoreTask starts the task from an archive written by CheckpointTask.
+func (d *Driver) RestoreTask(cfg *drivers.TaskConfig, path string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	if !executor.CheckpointSupported() {
+		return nil, nil, fmt.Errorf("checkpoint is not supported on this client")
+	}
+	return d.startTask(cfg, path)
+}
+
+// startTask launches the task, restoring it from the checkpoint archive at
+// restorePath if set.
+func (d *Driver) startTask(cfg *drivers.TaskConfig, restorePath string) (handle *drivers.TaskHandle, network *drivers.DriverNetwork, err error) {
 	if _, ok := d.tasks.Get(cfg.ID); ok {
 		return nil, nil, fmt.Errorf("task with ID %q already started", cfg.ID)
 	}
@@ -469,7 +503,13 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (handle *drivers.TaskHandle,
 	if err := driverConfig.validate(); err != nil {
 		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
 	}
+	if driverConfig.Command == "" && driverConfig.Image == "" {
+		return nil, nil, fmt.Errorf("failed driver config validation: command must be set unless image is set")
+	}
 
+	// The user of an image is not a user of the client, so images that set
+	// one are only run as the user the task sets
+	taskUser := cfg.User
 	if cfg.User == "" {
 		cfg.User = "nobody"
 	}
@@ -484,6 +524,15 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (handle *drivers.TaskHandle,
 	handle = drivers.NewTaskHandle(taskHandleVersion)
 	handle.Config = cfg
 
+	command, args, env, workDir := driverConfig.Command, driverConfig.Args, cfg.EnvList(), driverConfig.WorkDir
+	if driverConfig.Image != "" {
+		imageCmd, err := d.unpackImage(cfg, &driverConfig, taskUser)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to unpack image: %v", err)
+		}
+		command, args, env, workDir = imageCmd.command, imageCmd.args, imageCmd.env, imageCmd.workDir
+	}
+
 	pluginLogFile := filepath.Join(cfg.TaskDir().Dir, "executor.out")
 	executorConfig := &executor.ExecutorConfig{
 		LogFile:     pluginLogFile,
@@ -523,15 +572,15 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (handle *drivers.TaskHandle,
 	}()
 
 	execCmd := &executor.ExecCommand{
-		Cmd:              driverConfig.Command,
-		Args:             driverConfig.Args,
-		Env:              cfg.EnvList(),
+		Cmd:              command,
+		Args:             args,
+		Env:              env,
 		User:             user,
 		ResourceLimits:   true,
 		NoPivotRoot:      d.config.NoPivotRoot,
 		Resources:        cfg.Resources,
 		TaskDir:          cfg.TaskDir().Dir,
-		WorkDir:          driverConfig.WorkDir,
+		WorkDir:          workDir,
 		StdoutPath:       cfg.StdoutPath,
 		StderrPath:       cfg.StderrPath,
 		Mounts:           cfg.Mounts,
@@ -540,6 +589,7 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (handle *drivers.TaskHandle,
 		ModePID:          executor.IsolationMode(d.config.DefaultModePID, driverConfig.ModePID),
 		ModeIPC:          executor.IsolationMode(d.config.DefaultModeIPC, driverConfig.ModeIPC),
 		Capabilities:     caps,
+		RestorePath:      restorePath,
 	}
 
 	ps, err := exec.Launch(execCmd)
@@ -650,6 +700,36 @@ func (d *Driver) DestroyTask(taskID string, force bool) error {
 	return nil
 }
 
+// CheckpointTask writes the state of the running task to an archive at path
+// and stops the task.
+func (d *Driver) CheckpointTask(taskID string, path string) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if err := handle.exec.Checkpoint(path); err != nil {
+		return fmt.Errorf("executor Checkpoint failed: %v", err)
+	}
+
+	return nil
+}
+
+// UpdateTaskResources applies new cpu and memory limits to the cgroup of a
+// running task.
+func (d *Driver) UpdateTaskResources(taskID string, resources *drivers.Resources) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if err := handle.exec.UpdateResources(resources); err != nil {
+		return fmt.Errorf("executor UpdateResources failed: %v", err)
+	}
+
+	return nil
+}
+
 func (d *Driver) InspectTask(taskID string) (*drivers.TaskStatus, error) {
 	handle, ok := d.tasks.Get(taskID)
 	if !ok {
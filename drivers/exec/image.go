@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package exec
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/drivers/shared/ociimage"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// imageCommand is the command of a task running an OCI image
+type imageCommand struct {
+	command string
+	args    []string
+	env     []string
+	workDir string
+}
+
+// unpackImage unpacks the task's image over its task directory, which is the
+// root of the task's chroot, and returns the command to run. The directories
+// Nomad manages in the task directory are left untouched by the image.
+func (d *Driver) unpackImage(cfg *drivers.TaskConfig, driverConfig *TaskConfig, taskUser string) (*imageCommand, error) {
+	ref, err := ociimage.ParseReference(driverConfig.Image)
+	if err != nil {
+		return nil, err
+	}
+
+	if ref.Transport == ociimage.TransportOCI {
+		// Relative layouts are usually artifacts of the task
+		if !filepath.IsAbs(ref.Path) {
+			ref.Path = filepath.Join(cfg.TaskDir().Dir, ref.Path)
+		}
+		if !isAllowedImagePath(d.config.ImagePaths, cfg.AllocDir, ref.Path) {
+			return nil, fmt.Errorf("image %q is not in the allowed paths", ref.Path)
+		}
+	}
+
+	d.logger.Debug("unpacking image", "image", ref.String(), "task_name", cfg.Name)
+	imageCfg, err := ociimage.Unpack(d.ctx, ref, cfg.TaskDir().Dir, &ociimage.Options{
+		InsecureRegistries: d.config.InsecureRegistries,
+		ProtectedPaths: []string{
+			allocdir.SharedAllocName,
+			allocdir.TaskLocal,
+			allocdir.TaskSecrets,
+			allocdir.TaskPrivate,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveImageCommand(imageCfg, driverConfig, cfg.Env, taskUser)
+}
+
+// resolveImageCommand merges the image's execution configuration with the
+// task's. The task's command replaces the image's entrypoint and the task's
+// args replace the image's cmd, like they do with container runtimes. Images
+// that set a user are rejected unless the task sets its user.
+func resolveImageCommand(image *ociimage.Config, driverConfig *TaskConfig, env map[string]string, taskUser string) (*imageCommand, error) {
+	if image.User != "" && taskUser == "" {
+		return nil, fmt.Errorf("image runs as user %q, which must be set as the task's user", image.User)
+	}
+
+	ic := &imageCommand{workDir: driverConfig.WorkDir}
+	if ic.workDir == "" {
+		ic.workDir = image.WorkingDir
+	}
+
+	var argv []string
+	switch {
+	case driverConfig.Command != "":
+		argv = append([]string{driverConfig.Command}, driverConfig.Args...)
+	case len(driverConfig.Args) > 0:
+		argv = append(append(argv, image.Entrypoint...), driverConfig.Args...)
+	default:
+		argv = append(append(argv, image.Entrypoint...), image.Cmd...)
+	}
+	if len(argv) == 0 {
+		return nil, fmt.Errorf("image has no entrypoint or cmd and the task sets no command")
+	}
+	ic.command, ic.args = argv[0], argv[1:]
+
+	// The task's environment takes precedence over the image's
+	for k, v := range env {
+		ic.env = append(ic.env, k+"="+v)
+	}
+	sort.Strings(ic.env)
+	for _, kv := range image.Env {
+		k, _, _ := strings.Cut(kv, "=")
+		if _, ok := env[k]; !ok {
+			ic.env = append(ic.env, kv)
+		}
+	}
+	return ic, nil
+}
+
+// isAllowedImagePath returns whether the image path is within the allocation
+// directory or one of the allowed paths.
+func isAllowedImagePath(allowedPaths []string, allocDir, imagePath string) bool {
+	isParent := func(parent, path string) bool {
+		rel, err := filepath.Rel(parent, path)
+		return err == nil && !strings.HasPrefix(rel, "..")
+	}
+
+	if isParent(allocDir, imagePath) {
+		return true
+	}
+	for _, ap := range allowedPaths {
+		if isParent(ap, imagePath) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package exec
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/drivers/shared/ociimage"
+	"github.com/shoenig/test/must"
+)
+
+func TestResolveImageCommand(t *testing.T) {
+	ci.Parallel(t)
+
+	image := &ociimage.Config{
+		Entrypoint: []string{"/bin/app", "--verbose"},
+		Cmd:        []string{"serve"},
+		Env:        []string{"PATH=/bin", "MODE=image"},
+		WorkingDir: "/data",
+	}
+
+	cases := []struct {
+		name     string
+		image    *ociimage.Config
+		task     *TaskConfig
+		taskUser string
+		exp      *imageCommand
+		expErr   string
+	}{
+		{
+			name:  "image defaults",
+			image: image,
+			task:  &TaskConfig{},
+			exp: &imageCommand{
+				command: "/bin/app",
+				args:    []string{"--verbose", "serve"},
+				env:     []string{"MODE=task", "PATH=/bin"},
+				workDir: "/data",
+			},
+		},
+		{
+			name:  "args replace cmd",
+			image: image,
+			task:  &TaskConfig{Args: []string{"migrate"}},
+			exp: &imageCommand{
+				command: "/bin/app",
+				args:    []string{"--verbose", "migrate"},
+				env:     []string{"MODE=task", "PATH=/bin"},
+				workDir: "/data",
+			},
+		},
+		{
+			name:  "command replaces entrypoint",
+			image: image,
+			task:  &TaskConfig{Command: "/bin/sh", Args: []string{"-c", "true"}, WorkDir: "/"},
+			exp: &imageCommand{
+				command: "/bin/sh",
+				args:    []string{"-c", "true"},
+				env:     []string{"MODE=task", "PATH=/bin"},
+				workDir: "/",
+			},
+		},
+		{
+			name:  "cmd without entrypoint",
+			image: &ociimage.Config{Cmd: []string{"/bin/sh", "-c", "true"}},
+			task:  &TaskConfig{},
+			exp: &imageCommand{
+				command: "/bin/sh",
+				args:    []string{"-c", "true"},
+				env:     []string{"MODE=task"},
+			},
+		},
+		{
+			name:   "no command",
+			image:  &ociimage.Config{},
+			task:   &TaskConfig{},
+			expErr: "image has no entrypoint or cmd",
+		},
+		{
+			name:   "image user without task user",
+			image:  &ociimage.Config{Cmd: []string{"/bin/app"}, User: "app"},
+			task:   &TaskConfig{},
+			expErr: `image runs as user "app"`,
+		},
+		{
+			name:     "image user with task user",
+			image:    &ociimage.Config{Cmd: []string{"/bin/app"}, User: "app"},
+			task:     &TaskConfig{},
+			taskUser: "nobody",
+			exp: &imageCommand{
+				command: "/bin/app",
+				args:    []string{},
+				env:     []string{"MODE=task"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ic, err := resolveImageCommand(tc.image, tc.task, map[string]string{"MODE": "task"}, tc.taskUser)
+			if tc.expErr != "" {
+				must.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.exp.command, ic.command)
+			must.Eq(t, tc.exp.args, ic.args)
+			must.Eq(t, tc.exp.env, ic.env)
+			must.Eq(t, tc.exp.workDir, ic.workDir)
+		})
+	}
+}
+
+func TestIsAllowedImagePath(t *testing.T) {
+	ci.Parallel(t)
+
+	allowed := []string{"/var/lib/images"}
+	allocDir := "/alloc"
+
+	must.True(t, isAllowedImagePath(allowed, allocDir, "/alloc/web/local/image"))
+	must.True(t, isAllowedImagePath(allowed, allocDir, "/var/lib/images/app"))
+	must.False(t, isAllowedImagePath(allowed, allocDir, "/etc/image"))
+	must.False(t, isAllowedImagePath(allowed, allocDir, "/alloc/../etc"))
+}
@@ -0,0 +1,715 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package firecracker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/drivers/shared/eventer"
+	"github.com/hashicorp/nomad/drivers/shared/executor"
+	"github.com/hashicorp/nomad/helper/pluginutils/loader"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/plugins/drivers/fsisolation"
+	"github.com/hashicorp/nomad/plugins/shared/hclspec"
+	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
+)
+
+const (
+	// pluginName is the name of the plugin
+	pluginName = "firecracker"
+
+	// fingerprintPeriod is the interval at which the driver will send fingerprint responses
+	fingerprintPeriod = 30 * time.Second
+
+	// The keys populated in Node Attributes to indicate presence of the
+	// Firecracker driver
+	driverAttr        = "driver.firecracker"
+	driverVersionAttr = "driver.firecracker.version"
+
+	// defaultFirecrackerPath is the Firecracker binary used when the plugin
+	// configuration does not set one
+	defaultFirecrackerPath = "firecracker"
+
+	// kvmDevice is the device Firecracker requires to run microVMs
+	kvmDevice = "/dev/kvm"
+
+	// The files written to the task directory. Use short file names since
+	// socket paths have a maximum length.
+	vmConfigFileName = "fc.json"
+	vsockSocketName  = "fc.sock"
+
+	// https://man7.org/linux/man-pages/man7/unix.7.html
+	maxSocketPathLen = 108
+
+	// taskHandleVersion is the version of task handle which this driver sets
+	// and understands how to decode driver state
+	taskHandleVersion = 1
+)
+
+var (
+	// PluginID is the firecracker plugin metadata registered in the plugin
+	// catalog.
+	PluginID = loader.PluginID{
+		Name:       pluginName,
+		PluginType: base.PluginTypeDriver,
+	}
+
+	// PluginConfig is the firecracker driver factory function registered in
+	// the plugin catalog.
+	PluginConfig = &loader.InternalPluginConfig{
+		Config:  map[string]interface{}{},
+		Factory: func(ctx context.Context, l hclog.Logger) interface{} { return NewFirecrackerDriver(ctx, l) },
+	}
+
+	versionRegex = regexp.MustCompile(`Firecracker v(\d[\.\d+]+)`)
+
+	// pluginInfo is the response returned for the PluginInfo RPC
+	pluginInfo = &base.PluginInfoResponse{
+		Type:              base.PluginTypeDriver,
+		PluginApiVersions: []string{drivers.ApiVersion010},
+		PluginVersion:     "0.1.0",
+		Name:              pluginName,
+	}
+
+	// configSpec is the hcl specification returned by the ConfigSchema RPC
+	configSpec = hclspec.NewObject(map[string]*hclspec.Spec{
+		"firecracker_path": hclspec.NewDefault(
+			hclspec.NewAttr("firecracker_path", "string", false),
+			hclspec.NewLiteral(`"firecracker"`),
+		),
+		"image_paths": hclspec.NewAttr("image_paths", "list(string)", false),
+	})
+
+	// taskConfigSpec is the hcl specification for the driver config section of
+	// a taskConfig within a job. It is returned in the TaskConfigSchema RPC
+	taskConfigSpec = hclspec.NewObject(map[string]*hclspec.Spec{
+		"kernel_image":     hclspec.NewAttr("kernel_image", "string", true),
+		"kernel_args":      hclspec.NewAttr("kernel_args", "string", false),
+		"rootfs":           hclspec.NewAttr("rootfs", "string", true),
+		"rootfs_read_only": hclspec.NewAttr("rootfs_read_only", "bool", false),
+		"vcpus": hclspec.NewDefault(
+			hclspec.NewAttr("vcpus", "number", false),
+			hclspec.NewLiteral("1"),
+		),
+		"guest_agent_port": hclspec.NewDefault(
+			hclspec.NewAttr("guest_agent_port", "number", false),
+			hclspec.NewLiteral(fmt.Sprintf("%d", defaultGuestAgentPort)),
+		),
+	})
+
+	// capabilities is returned by the Capabilities RPC and indicates what
+	// optional features this driver supports
+	capabilities = &drivers.Capabilities{
+		SendSignals: false,
+		Exec:        true,
+		FSIsolation: fsisolation.Image,
+		NetIsolationModes: []drivers.NetIsolationMode{
+			drivers.NetIsolationModeHost,
+			drivers.NetIsolationModeGroup,
+		},
+		MountConfigs: drivers.MountConfigSupportNone,
+	}
+
+	_ drivers.DriverPlugin            = (*Driver)(nil)
+	_ drivers.ExecTaskStreamingDriver = (*Driver)(nil)
+)
+
+// TaskConfig is the driver configuration of a taskConfig within a job
+type TaskConfig struct {
+	KernelImage    string `codec:"kernel_image"`
+	KernelArgs     string `codec:"kernel_args"`
+	RootFS         string `codec:"rootfs"`
+	RootFSReadOnly bool   `codec:"rootfs_read_only"`
+	VCPUs          int    `codec:"vcpus"`
+	GuestAgentPort uint32 `codec:"guest_agent_port"`
+}
+
+// TaskState is the state which is encoded in the handle returned in StartTask.
+// This information is needed to rebuild the taskConfig state and handler
+// during recovery.
+type TaskState struct {
+	ReattachConfig *pstructs.ReattachConfig
+	TaskConfig     *drivers.TaskConfig
+	Pid            int
+	StartedAt      time.Time
+	VsockPath      string
+	GuestAgentPort uint32
+}
+
+// Config is the driver configuration set by SetConfig RPC call
+type Config struct {
+	// FirecrackerPath is the path to the Firecracker binary
+	FirecrackerPath string `codec:"firecracker_path"`
+
+	// ImagePaths is an allow-list of paths kernel and root filesystem images
+	// may be loaded from, in addition to the allocation directory
+	ImagePaths []string `codec:"image_paths"`
+}
+
+// Driver is a driver for running workloads in Firecracker microVMs
+type Driver struct {
+	// eventer is used to handle multiplexing of TaskEvents calls such that an
+	// event can be broadcast to all callers
+	eventer *eventer.Eventer
+
+	// config is the driver configuration set by the SetConfig RPC
+	config Config
+
+	// tasks is the in memory datastore mapping taskIDs to taskHandle
+	tasks *taskStore
+
+	// ctx is the context for the driver. It is passed to other subsystems to
+	// coordinate shutdown
+	ctx context.Context
+
+	// nomadConf is the client agent's configuration
+	nomadConfig *base.ClientDriverConfig
+
+	// logger will log to the Nomad agent
+	logger hclog.Logger
+}
+
+func NewFirecrackerDriver(ctx context.Context, logger hclog.Logger) drivers.DriverPlugin {
+	logger = logger.Named(pluginName)
+	return &Driver{
+		eventer: eventer.NewEventer(ctx, logger),
+		config:  Config{FirecrackerPath: defaultFirecrackerPath},
+		tasks:   newTaskStore(),
+		ctx:     ctx,
+		logger:  logger,
+	}
+}
+
+func (d *Driver) PluginInfo() (*base.PluginInfoResponse, error) {
+	return pluginInfo, nil
+}
+
+func (d *Driver) ConfigSchema() (*hclspec.Spec, error) {
+	return configSpec, nil
+}
+
+func (d *Driver) SetConfig(cfg *base.Config) error {
+	var config Config
+	if len(cfg.PluginConfig) != 0 {
+		if err := base.MsgPackDecode(cfg.PluginConfig, &config); err != nil {
+			return err
+		}
+	}
+	if config.FirecrackerPath == "" {
+		config.FirecrackerPath = defaultFirecrackerPath
+	}
+
+	d.config = config
+	if cfg.AgentConfig != nil {
+		d.nomadConfig = cfg.AgentConfig.Driver
+	}
+	return nil
+}
+
+func (d *Driver) TaskConfigSchema() (*hclspec.Spec, error) {
+	return taskConfigSpec, nil
+}
+
+func (d *Driver) Capabilities() (*drivers.Capabilities, error) {
+	return capabilities, nil
+}
+
+func (d *Driver) Fingerprint(ctx context.Context) (<-chan *drivers.Fingerprint, error) {
+	ch := make(chan *drivers.Fingerprint)
+	go d.handleFingerprint(ctx, ch)
+	return ch, nil
+}
+
+func (d *Driver) handleFingerprint(ctx context.Context, ch chan *drivers.Fingerprint) {
+	ticker := time.NewTimer(0)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.ctx.Done():
+			return
+		case <-ticker.C:
+			ticker.Reset(fingerprintPeriod)
+			ch <- d.buildFingerprint()
+		}
+	}
+}
+
+func (d *Driver) buildFingerprint() *drivers.Fingerprint {
+	fingerprint := &drivers.Fingerprint{
+		Attributes:        map[string]*pstructs.Attribute{},
+		Health:            drivers.HealthStateHealthy,
+		HealthDescription: drivers.DriverHealthy,
+	}
+
+	outBytes, err := exec.Command(d.config.FirecrackerPath, "--version").Output()
+	if err != nil {
+		// return no error, as it isn't an error to not find firecracker, it
+		// just means we can't use it.
+		fingerprint.Health = drivers.HealthStateUndetected
+		fingerprint.HealthDescription = ""
+		return fingerprint
+	}
+	out := strings.TrimSpace(string(outBytes))
+
+	matches := versionRegex.FindStringSubmatch(out)
+	if len(matches) != 2 {
+		fingerprint.Health = drivers.HealthStateUndetected
+		fingerprint.HealthDescription = fmt.Sprintf("Failed to parse firecracker version from %v", out)
+		return fingerprint
+	}
+
+	if _, err := os.Stat(kvmDevice); err != nil {
+		fingerprint.Health = drivers.HealthStateUnhealthy
+		fingerprint.HealthDescription = fmt.Sprintf("KVM is not available: %v", err)
+		return fingerprint
+	}
+
+	fingerprint.Attributes[driverAttr] = pstructs.NewBoolAttribute(true)
+	fingerprint.Attributes[driverVersionAttr] = pstructs.NewStringAttribute(matches[1])
+	return fingerprint
+}
+
+func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
+	if handle == nil {
+		return fmt.Errorf("error: handle cannot be nil")
+	}
+
+	// If already attached to handle there's nothing to recover.
+	if _, ok := d.tasks.Get(handle.Config.ID); ok {
+		d.logger.Trace("nothing to recover; task already exists",
+			"task_id", handle.Config.ID,
+			"task_name", handle.Config.Name,
+		)
+		return nil
+	}
+
+	var taskState TaskState
+	if err := handle.GetDriverState(&taskState); err != nil {
+		d.logger.Error("failed to decode taskConfig state from handle", "error", err, "task_id", handle.Config.ID)
+		return fmt.Errorf("failed to decode taskConfig state from handle: %v", err)
+	}
+
+	plugRC, err := pstructs.ReattachConfigToGoPlugin(taskState.ReattachConfig)
+	if err != nil {
+		d.logger.Error("failed to build ReattachConfig from taskConfig state", "error", err, "task_id", handle.Config.ID)
+		return fmt.Errorf("failed to build ReattachConfig from taskConfig state: %v", err)
+	}
+
+	execImpl, pluginClient, err := executor.ReattachToExecutor(
+		plugRC,
+		d.logger.With("task_name", handle.Config.Name, "alloc_id", handle.Config.AllocID),
+		d.nomadConfig.Topology.Compute(),
+	)
+	if err != nil {
+		d.logger.Error("failed to reattach to executor", "error", err, "task_id", handle.Config.ID)
+		return fmt.Errorf("failed to reattach to executor: %v", err)
+	}
+
+	h := &taskHandle{
+		exec:           execImpl,
+		pid:            taskState.Pid,
+		pluginClient:   pluginClient,
+		vsockPath:      taskState.VsockPath,
+		guestAgentPort: taskState.GuestAgentPort,
+		taskConfig:     taskState.TaskConfig,
+		procState:      drivers.TaskStateRunning,
+		startedAt:      taskState.StartedAt,
+		exitResult:     &drivers.ExitResult{},
+		logger:         d.logger,
+	}
+
+	d.tasks.Set(taskState.TaskConfig.ID, h)
+
+	go h.run()
+	return nil
+}
+
+// isAllowedImagePath returns whether the image is inside the allocation
+// directory or one of the allowed paths.
+func isAllowedImagePath(allowedPaths []string, allocDir, imagePath string) bool {
+	if !filepath.IsAbs(imagePath) {
+		imagePath = filepath.Join(allocDir, imagePath)
+	}
+
+	isParent := func(parent, path string) bool {
+		rel, err := filepath.Rel(parent, path)
+		return err == nil && !strings.HasPrefix(rel, "..")
+	}
+
+	// check if path is under alloc dir
+	if isParent(allocDir, imagePath) {
+		return true
+	}
+
+	// check allowed paths
+	for _, ap := range allowedPaths {
+		if isParent(ap, imagePath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// imagePath returns the absolute path of an image of the task. Relative paths
+// are relative to the task directory.
+func imagePath(cfg *drivers.TaskConfig, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(cfg.TaskDir().Dir, path)
+}
+
+func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	if _, ok := d.tasks.Get(cfg.ID); ok {
+		return nil, nil, fmt.Errorf("taskConfig with ID '%s' already started", cfg.ID)
+	}
+
+	var driverConfig TaskConfig
+	if err := cfg.DecodeDriverConfig(&driverConfig); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode driver config: %v", err)
+	}
+
+	handle := drivers.NewTaskHandle(taskHandleVersion)
+	handle.Config = cfg
+
+	if driverConfig.KernelImage == "" {
+		return nil, nil, fmt.Errorf("kernel_image must be set")
+	}
+	kernelPath := imagePath(cfg, driverConfig.KernelImage)
+	if !isAllowedImagePath(d.config.ImagePaths, cfg.AllocDir, kernelPath) {
+		return nil, nil, fmt.Errorf("kernel_image is not in the allowed paths")
+	}
+
+	if driverConfig.RootFS == "" {
+		return nil, nil, fmt.Errorf("rootfs must be set")
+	}
+	rootfsPath := imagePath(cfg, driverConfig.RootFS)
+	if !isAllowedImagePath(d.config.ImagePaths, cfg.AllocDir, rootfsPath) {
+		return nil, nil, fmt.Errorf("rootfs is not in the allowed paths")
+	}
+
+	if driverConfig.VCPUs < 1 || driverConfig.VCPUs > maxVCPUs {
+		return nil, nil, fmt.Errorf("vcpus must be between 1 and %d", maxVCPUs)
+	}
+	if driverConfig.GuestAgentPort == 0 {
+		driverConfig.GuestAgentPort = defaultGuestAgentPort
+	}
+
+	mb := cfg.Resources.NomadResources.Memory.MemoryMB
+	if mb < 1 {
+		return nil, nil, fmt.Errorf("Firecracker memory assignment out of bounds")
+	}
+
+	absPath, err := getAbsolutePath(d.config.FirecrackerPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	taskDir := cfg.TaskDir().Dir
+	vsockPath := filepath.Join(taskDir, vsockSocketName)
+	if err := validateSocketPath(vsockPath); err != nil {
+		return nil, nil, err
+	}
+
+	// Firecracker refuses to start if the vsock socket of a previous run of
+	// the task is left over
+	if err := os.Remove(vsockPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, nil, fmt.Errorf("failed to remove vsock socket: %v", err)
+	}
+
+	// Attach the VM to the allocation's network namespace, if any
+	var guestNet *guestNetwork
+	if cfg.NetworkIsolation != nil && cfg.NetworkIsolation.Path != "" {
+		guestNet, err = setupGuestNetwork(cfg.NetworkIsolation, cfg.Name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to set up microVM network: %v", err)
+		}
+		if cfg.NetworkIsolation.HostsConfig != nil {
+			guestNet.Hostname = cfg.NetworkIsolation.HostsConfig.Hostname
+		}
+		if cfg.DNS != nil {
+			guestNet.DNS = cfg.DNS.Servers
+		}
+	}
+
+	// The network is torn down if the microVM fails to start
+	teardownNet := func() {
+		if guestNet == nil {
+			return
+		}
+		if err := teardownGuestNetwork(cfg.NetworkIsolation, cfg.Name); err != nil {
+			d.logger.Warn("failed to tear down microVM network", "task_id", cfg.ID, "error", err)
+		}
+	}
+
+	vmConfig := newVMConfig(&driverConfig, kernelPath, rootfsPath, mb, vsockPath, guestNet)
+	vmConfigPath := filepath.Join(taskDir, vmConfigFileName)
+	if err := vmConfig.write(vmConfigPath); err != nil {
+		teardownNet()
+		return nil, nil, fmt.Errorf("failed to write microVM configuration: %v", err)
+	}
+
+	args := []string{
+		absPath,
+		"--no-api",
+		"--config-file", vmConfigPath,
+	}
+	d.logger.Debug("starting Firecracker microVM command", "args", strings.Join(args, " "))
+
+	pluginLogFile := filepath.Join(taskDir, fmt.Sprintf("%s-executor.out", cfg.Name))
+	executorConfig := &executor.ExecutorConfig{
+		LogFile:  pluginLogFile,
+		LogLevel: "debug",
+		Compute:  d.nomadConfig.Topology.Compute(),
+	}
+
+	execImpl, pluginClient, err := executor.CreateExecutor(
+		d.logger.With("task_name", handle.Config.Name, "alloc_id", handle.Config.AllocID),
+		d.nomadConfig, executorConfig)
+	if err != nil {
+		teardownNet()
+		return nil, nil, err
+	}
+
+	execCmd := &executor.ExecCommand{
+		Cmd:              args[0],
+		Args:             args[1:],
+		Env:              cfg.EnvList(),
+		User:             cfg.User,
+		TaskDir:          taskDir,
+		StdoutPath:       cfg.StdoutPath,
+		StderrPath:       cfg.StderrPath,
+		NetworkIsolation: cfg.NetworkIsolation,
+		Resources:        cfg.Resources.Copy(),
+	}
+	ps, err := execImpl.Launch(execCmd)
+	if err != nil {
+		pluginClient.Kill()
+		teardownNet()
+		return nil, nil, err
+	}
+	d.logger.Debug("started new Firecracker microVM", "task_id", cfg.ID)
+
+	h := &taskHandle{
+		exec:           execImpl,
+		pid:            ps.Pid,
+		pluginClient:   pluginClient,
+		vsockPath:      vsockPath,
+		guestAgentPort: driverConfig.GuestAgentPort,
+		taskConfig:     cfg,
+		procState:      drivers.TaskStateRunning,
+		startedAt:      time.Now().Round(time.Millisecond),
+		logger:         d.logger,
+	}
+
+	driverState := TaskState{
+		ReattachConfig: pstructs.ReattachConfigFromGoPlugin(pluginClient.ReattachConfig()),
+		Pid:            ps.Pid,
+		TaskConfig:     cfg,
+		StartedAt:      h.startedAt,
+		VsockPath:      vsockPath,
+		GuestAgentPort: driverConfig.GuestAgentPort,
+	}
+
+	if err := handle.SetDriverState(&driverState); err != nil {
+		d.logger.Error("failed to start task, error setting driver state", "error", err)
+		execImpl.Shutdown("", 0)
+		pluginClient.Kill()
+		teardownNet()
+		return nil, nil, fmt.Errorf("failed to set driver state: %v", err)
+	}
+
+	d.tasks.Set(cfg.ID, h)
+	go h.run()
+
+	var driverNetwork *drivers.DriverNetwork
+	if guestNet != nil {
+		driverNetwork = &drivers.DriverNetwork{
+			IP: guestNet.Address.IP.String(),
+		}
+	}
+	return handle, driverNetwork, nil
+}
+
+func (d *Driver) WaitTask(ctx context.Context, taskID string) (<-chan *drivers.ExitResult, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	ch := make(chan *drivers.ExitResult)
+	go d.handleWait(ctx, handle, ch)
+
+	return ch, nil
+}
+
+func (d *Driver) StopTask(taskID string, timeout time.Duration, signal string) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if err := handle.exec.Shutdown(signal, timeout); err != nil {
+		if handle.pluginClient.Exited() {
+			return nil
+		}
+		return fmt.Errorf("executor Shutdown failed: %v", err)
+	}
+
+	return nil
+}
+
+func (d *Driver) DestroyTask(taskID string, force bool) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if handle.IsRunning() && !force {
+		return fmt.Errorf("cannot destroy running task")
+	}
+
+	if !handle.pluginClient.Exited() {
+		if err := handle.exec.Shutdown("", 0); err != nil {
+			handle.logger.Error("destroying executor failed", "error", err)
+		}
+
+		handle.pluginClient.Kill()
+	}
+
+	d.tasks.Delete(taskID)
+	return nil
+}
+
+func (d *Driver) InspectTask(taskID string) (*drivers.TaskStatus, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	return handle.TaskStatus(), nil
+}
+
+// TaskStats returns the resource usage of the Firecracker process, which
+// includes the memory and CPU time used by the microVM.
+func (d *Driver) TaskStats(ctx context.Context, taskID string, interval time.Duration) (<-chan *drivers.TaskResourceUsage, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	return handle.exec.Stats(ctx, interval)
+}
+
+func (d *Driver) TaskEvents(ctx context.Context) (<-chan *drivers.TaskEvent, error) {
+	return d.eventer.TaskEvents(ctx)
+}
+
+func (d *Driver) SignalTask(_ string, _ string) error {
+	return fmt.Errorf("Firecracker driver can't signal commands")
+}
+
+func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*drivers.ExecTaskResult, error) {
+	ctx, cancel := context.WithTimeout(d.ctx, timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	result, err := d.ExecTaskStreaming(ctx, taskID, &drivers.ExecOptions{
+		Command: cmd,
+		Stdin:   nopReadCloser{},
+		Stdout:  nopWriteCloser{&stdout},
+		Stderr:  nopWriteCloser{&stderr},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &drivers.ExecTaskResult{
+		Stdout:     stdout.Bytes(),
+		Stderr:     stderr.Bytes(),
+		ExitResult: result,
+	}, nil
+}
+
+// ExecTaskStreaming runs a command inside the microVM through the guest agent
+// listening on the VM's vsock device.
+func (d *Driver) ExecTaskStreaming(ctx context.Context, taskID string, opts *drivers.ExecOptions) (*drivers.ExitResult, error) {
+	defer opts.Stdout.Close()
+	defer opts.Stderr.Close()
+
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	if len(opts.Command) == 0 {
+		return nil, fmt.Errorf("command is required but was empty")
+	}
+
+	conn, err := dialGuestAgent(ctx, handle.vsockPath, handle.guestAgentPort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to guest agent: %v", err)
+	}
+	defer conn.Close()
+
+	return execStream(ctx, conn, opts)
+}
+
+// getAbsolutePath returns the absolute path of the passed binary by resolving
+// it in the path and following symlinks.
+func getAbsolutePath(bin string) (string, error) {
+	lp, err := exec.LookPath(bin)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path to %q executable: %v", bin, err)
+	}
+
+	return filepath.EvalSymlinks(lp)
+}
+
+func (d *Driver) handleWait(ctx context.Context, handle *taskHandle, ch chan *drivers.ExitResult) {
+	defer close(ch)
+	var result *drivers.ExitResult
+	ps, err := handle.exec.Wait(ctx)
+	if err != nil {
+		result = &drivers.ExitResult{
+			Err: fmt.Errorf("executor: error waiting on process: %v", err),
+		}
+	} else {
+		result = &drivers.ExitResult{
+			ExitCode:  ps.ExitCode,
+			Signal:    ps.Signal,
+			OOMKilled: ps.OOMKilled,
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-d.ctx.Done():
+	case ch <- result:
+	}
+}
+
+// validateSocketPath ensures the socket path fits in a unix socket address.
+func validateSocketPath(path string) error {
+	if len(path) > maxSocketPathLen {
+		return fmt.Errorf(
+			"socket path %s is longer than the maximum length allowed (%d), try to reduce the task name or Nomad's data_dir if possible.",
+			path, maxSocketPathLen)
+	}
+
+	return nil
+}
@@ -0,0 +1,402 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package firecracker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	"github.com/hashicorp/nomad/client/lib/numalib"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	dtestutil "github.com/hashicorp/nomad/plugins/drivers/testutils"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+// fakeVMMEnv is set in the environment of the tasks to run the test binary as
+// a stand-in for Firecracker
+const fakeVMMEnv = "NOMAD_TEST_FAKE_FIRECRACKER"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(fakeVMMEnv) != "" {
+		os.Exit(runFakeVMM(os.Args[1:]))
+	}
+	os.Exit(m.Run())
+}
+
+// runFakeVMM reads the microVM configuration file like Firecracker does and
+// runs the commands of exec sessions on the host as the guest agent would.
+func runFakeVMM(args []string) int {
+	var configPath string
+	for i, arg := range args {
+		if arg == "--config-file" && i+1 < len(args) {
+			configPath = args[i+1]
+		}
+	}
+
+	buf, err := os.ReadFile(configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read config: %v\n", err)
+		return 1
+	}
+	var cfg vmConfig
+	if err := json.Unmarshal(buf, &cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to decode config: %v\n", err)
+		return 1
+	}
+	for _, path := range []string{cfg.BootSource.KernelImagePath, cfg.Drives[0].PathOnHost} {
+		if _, err := os.Stat(path); err != nil {
+			fmt.Fprintf(os.Stderr, "missing image: %v\n", err)
+			return 1
+		}
+	}
+
+	ln, err := net.Listen("unix", cfg.Vsock.UDSPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to listen on vsock socket: %v\n", err)
+		return 1
+	}
+	defer ln.Close()
+
+	fmt.Printf("booted microVM with %d vCPUs and %d MiB\n",
+		cfg.MachineConfig.VCPUCount, cfg.MachineConfig.MemSizeMiB)
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveFakeGuestAgent(conn)
+		}
+	}()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	<-sigCh
+	return 0
+}
+
+// serveFakeGuestAgent handles the vsock handshake and an exec session.
+func serveFakeGuestAgent(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	line, err := r.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "CONNECT ") {
+		return
+	}
+	fmt.Fprintf(conn, "OK 1073741824\n")
+
+	typ, payload, err := readFrame(r)
+	if err != nil || typ != frameExec {
+		return
+	}
+	var req execRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return
+	}
+
+	var lock sync.Mutex
+	send := func(typ byte, payload []byte) {
+		lock.Lock()
+		defer lock.Unlock()
+		writeFrame(conn, typ, payload)
+	}
+	exit := func(e *execExit) {
+		payload, _ := json.Marshal(e)
+		send(frameExit, payload)
+	}
+
+	cmd := exec.Command(req.Command[0], req.Command[1:]...)
+	cmd.Stdout = frameWriter{frameStdout, send}
+	cmd.Stderr = frameWriter{frameStderr, send}
+	stdin, _ := cmd.StdinPipe()
+	if err := cmd.Start(); err != nil {
+		exit(&execExit{Error: err.Error()})
+		return
+	}
+
+	go func() {
+		for {
+			typ, payload, err := readFrame(r)
+			if err != nil {
+				return
+			}
+			switch typ {
+			case frameStdin:
+				stdin.Write(payload)
+			case frameStdinClose:
+				stdin.Close()
+			}
+		}
+	}()
+
+	cmd.Wait()
+	exit(&execExit{ExitCode: cmd.ProcessState.ExitCode()})
+}
+
+type frameWriter struct {
+	typ  byte
+	send func(byte, []byte)
+}
+
+func (w frameWriter) Write(p []byte) (int, error) {
+	w.send(w.typ, append([]byte(nil), p...))
+	return len(p), nil
+}
+
+func testResources(allocID, task string) *drivers.Resources {
+	return &drivers.Resources{
+		NomadResources: &structs.AllocatedTaskResources{
+			Memory: structs.AllocatedMemoryResources{
+				MemoryMB: 256,
+			},
+			Cpu: structs.AllocatedCpuResources{
+				CpuShares: 100,
+			},
+		},
+		LinuxResources: &drivers.LinuxResources{
+			MemoryLimitBytes: 256 * 1024 * 1024,
+			CPUShares:        100,
+			CpusetCgroupPath: cgroupslib.LinuxResourcesPath(allocID, task, false),
+		},
+	}
+}
+
+func newTestDriver(t *testing.T) *Driver {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	path, err := os.Executable()
+	must.NoError(t, err)
+
+	d := NewFirecrackerDriver(ctx, testlog.HCLogger(t)).(*Driver)
+	d.config.FirecrackerPath = path
+	d.nomadConfig = &base.ClientDriverConfig{
+		Topology: numalib.Scan(numalib.PlatformScanners(false)),
+	}
+	return d
+}
+
+func TestFirecrackerDriver_Fingerprint_Undetected(t *testing.T) {
+	ci.Parallel(t)
+
+	d := newTestDriver(t)
+	d.config.FirecrackerPath = filepath.Join(t.TempDir(), "firecracker")
+
+	fp := d.buildFingerprint()
+	must.Eq(t, drivers.HealthStateUndetected, fp.Health)
+	must.MapNotContainsKey(t, fp.Attributes, driverAttr)
+}
+
+func TestFirecrackerDriver_StartTask_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		config *TaskConfig
+		expErr string
+	}{
+		{
+			name:   "kernel not allowed",
+			config: &TaskConfig{KernelImage: "/boot/vmlinux", RootFS: "rootfs.ext4", VCPUs: 1},
+			expErr: "kernel_image is not in the allowed paths",
+		},
+		{
+			name:   "rootfs not allowed",
+			config: &TaskConfig{KernelImage: "vmlinux", RootFS: "../../../rootfs.ext4", VCPUs: 1},
+			expErr: "rootfs is not in the allowed paths",
+		},
+		{
+			name:   "too many vcpus",
+			config: &TaskConfig{KernelImage: "vmlinux", RootFS: "rootfs.ext4", VCPUs: 64},
+			expErr: "vcpus must be between 1 and 32",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newTestDriver(t)
+			harness := dtestutil.NewDriverHarness(t, d)
+
+			allocID := uuid.Generate()
+			task := &drivers.TaskConfig{
+				AllocID:   allocID,
+				ID:        uuid.Generate(),
+				Name:      "vm",
+				Resources: testResources(allocID, "vm"),
+			}
+			must.NoError(t, task.EncodeConcreteDriverConfig(tc.config))
+			cleanup := harness.MkAllocDir(task, false)
+			defer cleanup()
+
+			_, _, err := harness.StartTask(task)
+			must.ErrorContains(t, err, tc.expErr)
+		})
+	}
+}
+
+func TestFirecrackerDriver_Start_Exec_Recover_Stop(t *testing.T) {
+	ci.Parallel(t)
+
+	d := newTestDriver(t)
+	harness := dtestutil.NewDriverHarness(t, d)
+	defer harness.Kill()
+
+	allocID := uuid.Generate()
+	task := &drivers.TaskConfig{
+		AllocID:   allocID,
+		ID:        uuid.Generate(),
+		Name:      "vm",
+		Env:       map[string]string{fakeVMMEnv: "1"},
+		Resources: testResources(allocID, "vm"),
+	}
+	tc := &TaskConfig{
+		KernelImage: "vmlinux",
+		RootFS:      "rootfs.ext4",
+		VCPUs:       2,
+	}
+	must.NoError(t, task.EncodeConcreteDriverConfig(tc))
+
+	cleanup := harness.MkAllocDir(task, true)
+	defer cleanup()
+	harness.MakeTaskCgroup(allocID, task.Name)
+
+	taskDir := task.TaskDir().Dir
+	for _, image := range []string{"vmlinux", "rootfs.ext4"} {
+		must.NoError(t, os.WriteFile(filepath.Join(taskDir, image), nil, 0644))
+	}
+
+	handle, driverNet, err := harness.StartTask(task)
+	must.NoError(t, err)
+	must.Nil(t, driverNet)
+	must.NoError(t, harness.WaitUntilStarted(task.ID, 5*time.Second))
+
+	// The VM configuration is written to the task directory
+	buf, err := os.ReadFile(filepath.Join(taskDir, vmConfigFileName))
+	must.NoError(t, err)
+	var cfg vmConfig
+	must.NoError(t, json.Unmarshal(buf, &cfg))
+	must.Eq(t, 2, cfg.MachineConfig.VCPUCount)
+	must.Eq(t, 256, cfg.MachineConfig.MemSizeMiB)
+	must.Eq(t, defaultKernelArgs, cfg.BootSource.BootArgs)
+	must.Eq(t, filepath.Join(taskDir, "rootfs.ext4"), cfg.Drives[0].PathOnHost)
+
+	// Wait for the fake VMM to listen on the vsock socket
+	must.Wait(t, wait.InitialSuccess(
+		wait.ErrorFunc(func() error {
+			_, err := os.Stat(filepath.Join(taskDir, vsockSocketName))
+			return err
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(50*time.Millisecond),
+	))
+
+	// Exec sessions stream stdin and the output of the command
+	stdinR, stdinW := io.Pipe()
+	var stdout, stderr bytes.Buffer
+	go func() {
+		stdinW.Write([]byte("hello from stdin"))
+		stdinW.Close()
+	}()
+	result, err := d.ExecTaskStreaming(context.Background(), task.ID, &drivers.ExecOptions{
+		Command: []string{"/bin/sh", "-c", "cat; echo oops >&2; exit 3"},
+		Stdin:   stdinR,
+		Stdout:  nopWriteCloser{&stdout},
+		Stderr:  nopWriteCloser{&stderr},
+	})
+	must.NoError(t, err)
+	must.Eq(t, 3, result.ExitCode)
+	must.Eq(t, "hello from stdin", stdout.String())
+	must.Eq(t, "oops\n", stderr.String())
+
+	// Lose the task and recover it
+	d.tasks.Delete(task.ID)
+	_, err = harness.InspectTask(task.ID)
+	must.Error(t, err)
+
+	must.NoError(t, harness.RecoverTask(handle))
+	status, err := harness.InspectTask(task.ID)
+	must.NoError(t, err)
+	must.Eq(t, drivers.TaskStateRunning, status.State)
+
+	execResult, err := harness.ExecTask(task.ID, []string{"/bin/echo", "recovered"}, 5*time.Second)
+	must.NoError(t, err)
+	must.Eq(t, 0, execResult.ExitResult.ExitCode)
+	must.Eq(t, "recovered\n", string(execResult.Stdout))
+
+	statsCh, err := harness.TaskStats(context.Background(), task.ID, 100*time.Millisecond)
+	must.NoError(t, err)
+	select {
+	case stats := <-statsCh:
+		must.NotNil(t, stats.ResourceUsage)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for stats")
+	}
+
+	waitCh, err := harness.WaitTask(context.Background(), task.ID)
+	must.NoError(t, err)
+	must.NoError(t, harness.StopTask(task.ID, 5*time.Second, "SIGTERM"))
+	select {
+	case res := <-waitCh:
+		must.NoError(t, res.Err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the task to stop")
+	}
+	must.NoError(t, harness.DestroyTask(task.ID, true))
+
+}
+
+func TestDialGuestAgent_Handshake(t *testing.T) {
+	ci.Parallel(t)
+
+	path := filepath.Join(t.TempDir(), "v.sock")
+	ln, err := net.Listen("unix", path)
+	must.NoError(t, err)
+	defer ln.Close()
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			r := bufio.NewReader(conn)
+			line, _ := r.ReadString('\n')
+			if line == "CONNECT 1024\n" {
+				fmt.Fprintf(conn, "OK 42\n")
+			} else {
+				conn.Close()
+			}
+		}
+	}()
+
+	conn, err := dialGuestAgent(context.Background(), path, 1024)
+	must.NoError(t, err)
+	conn.Close()
+
+	_, err = dialGuestAgent(context.Background(), path, 2048)
+	must.ErrorContains(t, err, "failed to read vsock handshake")
+}
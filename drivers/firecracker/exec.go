@@ -0,0 +1,251 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package firecracker
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// The guest agent is a process of the microVM listening on a vsock port that
+// runs the commands of exec sessions. The host connects to it through the
+// vsock unix socket of the VM and exchanges frames made of a type byte, the
+// big endian uint32 length of the payload and the payload.
+const (
+	// defaultGuestAgentPort is the vsock port the guest agent listens on
+	// unless the task configures another one
+	defaultGuestAgentPort = 1024
+
+	// frameExec starts the session. Its payload is the JSON encoded
+	// execRequest. It is the first frame sent by the host.
+	frameExec byte = 1
+
+	// frameStdin carries data of the command's stdin
+	frameStdin byte = 2
+
+	// frameStdinClose closes the command's stdin
+	frameStdinClose byte = 3
+
+	// frameResize resizes the command's terminal. Its payload is the JSON
+	// encoded drivers.TerminalSize.
+	frameResize byte = 4
+
+	// frameStdout and frameStderr carry the output of the command sent by
+	// the guest agent
+	frameStdout byte = 5
+	frameStderr byte = 6
+
+	// frameExit is the last frame sent by the guest agent. Its payload is the
+	// JSON encoded execExit.
+	frameExit byte = 7
+
+	// maxFrameSize is the maximum size of the payload of a frame
+	maxFrameSize = 1024 * 1024
+
+	// vsockHandshakeTimeout is the time to wait for Firecracker to connect to
+	// the guest agent
+	vsockHandshakeTimeout = 10 * time.Second
+)
+
+// execRequest is the payload of frameExec.
+type execRequest struct {
+	Command []string `json:"command"`
+	Tty     bool     `json:"tty"`
+}
+
+// execExit is the payload of frameExit.
+type execExit struct {
+	ExitCode int    `json:"exit_code"`
+	Error    string `json:"error,omitempty"`
+}
+
+// writeFrame writes a frame with the payload to w.
+func writeFrame(w io.Writer, typ byte, payload []byte) error {
+	header := make([]byte, 5)
+	header[0] = typ
+	binary.BigEndian.PutUint32(header[1:], uint32(len(payload)))
+	if _, err := w.Write(append(header, payload...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// readFrame reads a frame from r and returns its type and payload.
+func readFrame(r io.Reader) (byte, []byte, error) {
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, nil, err
+	}
+	size := binary.BigEndian.Uint32(header[1:])
+	if size > maxFrameSize {
+		return 0, nil, fmt.Errorf("frame of %d bytes exceeds the maximum size", size)
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, err
+	}
+	return header[0], payload, nil
+}
+
+// dialGuestAgent connects to the guest agent listening on port through the
+// microVM's vsock unix socket, see
+// https://github.com/firecracker-microvm/firecracker/blob/main/docs/vsock.md
+func dialGuestAgent(ctx context.Context, vsockPath string, port uint32) (net.Conn, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", vsockPath)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.SetDeadline(time.Now().Add(vsockHandshakeTimeout))
+	if _, err := fmt.Fprintf(conn, "CONNECT %d\n", port); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	// Read the acknowledgement a byte at a time so that no data of the
+	// session is buffered
+	var ack strings.Builder
+	b := make([]byte, 1)
+	for {
+		if _, err := conn.Read(b); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to read vsock handshake: %v", err)
+		}
+		if b[0] == '\n' {
+			break
+		}
+		ack.WriteByte(b[0])
+		if ack.Len() > 64 {
+			conn.Close()
+			return nil, fmt.Errorf("invalid vsock handshake response")
+		}
+	}
+	if !strings.HasPrefix(ack.String(), "OK ") {
+		conn.Close()
+		return nil, fmt.Errorf("unexpected vsock handshake response %q", ack.String())
+	}
+
+	conn.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+// execStream runs an exec session over the connection to the guest agent and
+// returns the exit result of the command.
+func execStream(ctx context.Context, conn net.Conn, opts *drivers.ExecOptions) (*drivers.ExitResult, error) {
+	done := make(chan struct{})
+	defer close(done)
+	defer opts.Stdin.Close()
+
+	// Closing the connection unblocks reading the output of the command
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	var writeLock sync.Mutex
+	send := func(typ byte, payload []byte) error {
+		writeLock.Lock()
+		defer writeLock.Unlock()
+		return writeFrame(conn, typ, payload)
+	}
+
+	req, err := json.Marshal(&execRequest{Command: opts.Command, Tty: opts.Tty})
+	if err != nil {
+		return nil, err
+	}
+	if err := send(frameExec, req); err != nil {
+		return nil, fmt.Errorf("failed to start exec session: %v", err)
+	}
+
+	go func() {
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := opts.Stdin.Read(buf)
+			if n > 0 {
+				if send(frameStdin, buf[:n]) != nil {
+					return
+				}
+			}
+			if err != nil {
+				send(frameStdinClose, nil)
+				return
+			}
+		}
+	}()
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case s, ok := <-opts.ResizeCh:
+				if !ok {
+					return
+				}
+				payload, _ := json.Marshal(&s)
+				if send(frameResize, payload) != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		typ, payload, err := readFrame(conn)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, fmt.Errorf("guest agent connection closed: %v", err)
+		}
+
+		switch typ {
+		case frameStdout:
+			if _, err := opts.Stdout.Write(payload); err != nil {
+				return nil, err
+			}
+		case frameStderr:
+			if _, err := opts.Stderr.Write(payload); err != nil {
+				return nil, err
+			}
+		case frameExit:
+			var exit execExit
+			if err := json.Unmarshal(payload, &exit); err != nil {
+				return nil, fmt.Errorf("failed to decode exit frame: %v", err)
+			}
+			if exit.Error != "" {
+				return nil, fmt.Errorf("guest agent failed to run command: %s", exit.Error)
+			}
+			return &drivers.ExitResult{ExitCode: exit.ExitCode}, nil
+		default:
+			return nil, fmt.Errorf("unexpected frame type %d", typ)
+		}
+	}
+}
+
+// nopReadCloser is an empty stdin.
+type nopReadCloser struct{}
+
+func (nopReadCloser) Read([]byte) (int, error) { return 0, io.EOF }
+func (nopReadCloser) Close() error             { return nil }
+
+// nopWriteCloser adds a no-op Close method to a writer.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
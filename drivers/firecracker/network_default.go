@@ -0,0 +1,24 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+// +build !linux
+
+package firecracker
+
+import (
+	"errors"
+
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// setupGuestNetwork is not supported outside of Linux, where Firecracker does
+// not run.
+func setupGuestNetwork(*drivers.NetworkIsolationSpec, string) (*guestNetwork, error) {
+	return nil, errors.New("microVM networking is only supported on Linux")
+}
+
+// teardownGuestNetwork is a no-op outside of Linux, where no network is set up.
+func teardownGuestNetwork(*drivers.NetworkIsolationSpec, string) error {
+	return nil
+}
@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+// +build linux
+
+package firecracker
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"os/exec"
+	"strings"
+
+	"github.com/hashicorp/nomad/client/lib/nsutil"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+const (
+	// allocInterfaceName is the interface the bridge and CNI networking modes
+	// create in the allocation's network namespace
+	allocInterfaceName = "eth0"
+
+	// tapPrefix prefixes the tap devices created in the allocation's network
+	// namespace for the microVMs
+	tapPrefix = "fctap"
+)
+
+// tapName returns the name of the tap device of the task's microVM, which is
+// unique among the tasks of an allocation and short enough for an interface
+// name.
+func tapName(taskName string) string {
+	h := fnv.New32a()
+	h.Write([]byte(taskName))
+	return fmt.Sprintf("%s%08x", tapPrefix, h.Sum32())
+}
+
+// setupGuestNetwork creates a tap device for the task's microVM in the
+// allocation's network namespace and redirects all the traffic of the
+// allocation's interface to it, so the guest takes over the allocation's
+// address. Since the guest receives all the allocation's traffic, it fails if
+// the microVM of another task already uses the allocation's network. A tap
+// device left over by a previous run of the task is recreated, and the tap
+// device is removed if it can't be set up.
+func setupGuestNetwork(spec *drivers.NetworkIsolationSpec, taskName string) (*guestNetwork, error) {
+	tap := tapName(taskName)
+
+	var guestNet *guestNetwork
+	err := nsutil.WithNetNSPath(spec.Path, func(nsutil.NetNS) error {
+		iface, err := net.InterfaceByName(allocInterfaceName)
+		if err != nil {
+			return fmt.Errorf("failed to find allocation interface: %v", err)
+		}
+
+		address, err := interfaceIPv4(iface)
+		if err != nil {
+			return err
+		}
+
+		gateway, err := defaultGateway()
+		if err != nil {
+			return err
+		}
+
+		ifaces, err := net.Interfaces()
+		if err != nil {
+			return fmt.Errorf("failed to list interfaces: %v", err)
+		}
+		for _, other := range ifaces {
+			if strings.HasPrefix(other.Name, tapPrefix) && other.Name != tap {
+				return errors.New("the allocation's network is already used by the microVM of another task, " +
+					"a task group can only have one firecracker task using the group network")
+			}
+		}
+
+		removeTap(iface.Name, tap)
+		if err := createTap(iface, tap); err != nil {
+			removeTap(iface.Name, tap)
+			return err
+		}
+
+		guestNet = &guestNetwork{
+			TapName: tap,
+			MAC:     iface.HardwareAddr.String(),
+			Address: address,
+			Gateway: gateway,
+		}
+		return nil
+	})
+	return guestNet, err
+}
+
+// teardownGuestNetwork removes the tap device of the task's microVM and the
+// redirection of the allocation's traffic to it.
+func teardownGuestNetwork(spec *drivers.NetworkIsolationSpec, taskName string) error {
+	return nsutil.WithNetNSPath(spec.Path, func(nsutil.NetNS) error {
+		removeTap(allocInterfaceName, tapName(taskName))
+		return nil
+	})
+}
+
+// interfaceIPv4 returns the IPv4 address of the interface.
+func interfaceIPv4(iface *net.Interface) (*net.IPNet, error) {
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read addresses of %s: %v", iface.Name, err)
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.To4() != nil {
+			return ipNet, nil
+		}
+	}
+	return nil, fmt.Errorf("interface %s has no IPv4 address", iface.Name)
+}
+
+// defaultGateway returns the gateway of the default IPv4 route, or nil if
+// there is no default route.
+func defaultGateway() (net.IP, error) {
+	out, err := exec.Command("ip", "-4", "route", "show", "default").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read default route: %v", err)
+	}
+	return parseDefaultGateway(string(out)), nil
+}
+
+// parseDefaultGateway returns the gateway of the output of "ip route show
+// default", such as "default via 172.26.64.1 dev eth0".
+func parseDefaultGateway(out string) net.IP {
+	fields := strings.Fields(out)
+	for i := 0; i < len(fields)-1; i++ {
+		if fields[i] == "via" {
+			return net.ParseIP(fields[i+1])
+		}
+	}
+	return nil
+}
+
+// createTap creates the tap device and redirects traffic between it and the
+// allocation's interface in both directions.
+func createTap(iface *net.Interface, tap string) error {
+	cmds := [][]string{
+		{"ip", "tuntap", "add", "dev", tap, "mode", "tap"},
+		{"ip", "link", "set", "dev", tap, "mtu", fmt.Sprintf("%d", iface.MTU), "up"},
+		{"tc", "qdisc", "add", "dev", iface.Name, "ingress"},
+		{"tc", "filter", "add", "dev", iface.Name, "parent", "ffff:", "protocol", "all",
+			"u32", "match", "u8", "0", "0", "action", "mirred", "egress", "redirect", "dev", tap},
+		{"tc", "qdisc", "add", "dev", tap, "ingress"},
+		{"tc", "filter", "add", "dev", tap, "parent", "ffff:", "protocol", "all",
+			"u32", "match", "u8", "0", "0", "action", "mirred", "egress", "redirect", "dev", iface.Name},
+	}
+	for _, args := range cmds {
+		if out, err := exec.Command(args[0], args[1:]...).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to run %q: %v: %s",
+				strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+		}
+	}
+	return nil
+}
+
+// removeTap removes the tap device, along with its filters, and the ingress
+// qdisc and filters of the allocation's interface. Either may not exist, such
+// as when creating the tap device failed, so errors are ignored.
+func removeTap(ifaceName, tap string) {
+	if _, err := net.InterfaceByName(tap); err == nil {
+		_ = exec.Command("ip", "link", "del", "dev", tap).Run()
+	}
+	_ = exec.Command("tc", "qdisc", "del", "dev", ifaceName, "ingress").Run()
+}
@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+// +build linux
+
+package firecracker
+
+import (
+	"net"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestParseDefaultGateway(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Eq(t, net.ParseIP("172.26.64.1"),
+		parseDefaultGateway("default via 172.26.64.1 dev eth0 \n"))
+	must.Nil(t, parseDefaultGateway(""))
+}
+
+func TestTapName(t *testing.T) {
+	ci.Parallel(t)
+
+	web, api := tapName("web"), tapName("api")
+	must.NotEq(t, web, api)
+	must.Eq(t, web, tapName("web"))
+	must.StrHasPrefix(t, tapPrefix, web)
+	must.LessEq(t, 15, len(web))
+}
@@ -0,0 +1,166 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package firecracker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+)
+
+const (
+	// maxVCPUs is the maximum number of vCPUs of a Firecracker microVM
+	maxVCPUs = 32
+
+	// guestCID is the vsock context ID of the microVM. Connections to the
+	// guest go through the vsock unix socket, so every VM can use the same ID.
+	guestCID = 3
+
+	// defaultKernelArgs are the kernel arguments used when the task does not
+	// set any. The serial console is written to the task's stdout.
+	defaultKernelArgs = "console=ttyS0 reboot=k panic=1 pci=off"
+
+	// guestInterfaceName is the name of the network interface in the guest
+	guestInterfaceName = "eth0"
+)
+
+// vmConfig is the Firecracker configuration file of a microVM, see
+// https://github.com/firecracker-microvm/firecracker/blob/main/docs/getting-started.md
+type vmConfig struct {
+	BootSource        vmBootSource          `json:"boot-source"`
+	Drives            []*vmDrive            `json:"drives"`
+	MachineConfig     vmMachineConfig       `json:"machine-config"`
+	NetworkInterfaces []*vmNetworkInterface `json:"network-interfaces,omitempty"`
+	Vsock             *vmVsock              `json:"vsock,omitempty"`
+}
+
+type vmBootSource struct {
+	KernelImagePath string `json:"kernel_image_path"`
+	BootArgs        string `json:"boot_args,omitempty"`
+}
+
+type vmDrive struct {
+	DriveID      string `json:"drive_id"`
+	PathOnHost   string `json:"path_on_host"`
+	IsRootDevice bool   `json:"is_root_device"`
+	IsReadOnly   bool   `json:"is_read_only"`
+}
+
+type vmMachineConfig struct {
+	VCPUCount  int   `json:"vcpu_count"`
+	MemSizeMiB int64 `json:"mem_size_mib"`
+}
+
+type vmNetworkInterface struct {
+	IfaceID     string `json:"iface_id"`
+	GuestMAC    string `json:"guest_mac,omitempty"`
+	HostDevName string `json:"host_dev_name"`
+}
+
+type vmVsock struct {
+	GuestCID uint32 `json:"guest_cid"`
+	UDSPath  string `json:"uds_path"`
+}
+
+// guestNetwork is the network configuration of a microVM attached to the
+// allocation's network namespace.
+type guestNetwork struct {
+	// TapName is the tap device backing the guest's interface
+	TapName string
+
+	// MAC is the guest's MAC address
+	MAC string
+
+	// Address and Gateway are the guest's address and default gateway
+	Address *net.IPNet
+	Gateway net.IP
+
+	// Hostname and DNS are the guest's host name and name servers
+	Hostname string
+	DNS      []string
+}
+
+// kernelArg returns the ip kernel argument statically configuring the guest's
+// interface, see
+// https://docs.kernel.org/admin-guide/nfs/nfsroot.html#kernel-command-line
+func (n *guestNetwork) kernelArg() string {
+	var gateway string
+	if n.Gateway != nil {
+		gateway = n.Gateway.String()
+	}
+
+	fields := []string{
+		n.Address.IP.String(),
+		"",
+		gateway,
+		net.IP(n.Address.Mask).String(),
+		n.Hostname,
+		guestInterfaceName,
+		"off",
+	}
+
+	// The kernel accepts up to two IPv4 name servers
+	var dns []string
+	for _, s := range n.DNS {
+		if ip := net.ParseIP(s); ip != nil && ip.To4() != nil && len(dns) < 2 {
+			dns = append(dns, s)
+		}
+	}
+	fields = append(fields, dns...)
+
+	return "ip=" + strings.Join(fields, ":")
+}
+
+// newVMConfig returns the configuration of the task's microVM.
+func newVMConfig(taskConfig *TaskConfig, kernelPath, rootfsPath string,
+	memoryMB int64, vsockPath string, network *guestNetwork) *vmConfig {
+
+	bootArgs := taskConfig.KernelArgs
+	if bootArgs == "" {
+		bootArgs = defaultKernelArgs
+	}
+
+	cfg := &vmConfig{
+		BootSource: vmBootSource{
+			KernelImagePath: kernelPath,
+		},
+		Drives: []*vmDrive{{
+			DriveID:      "rootfs",
+			PathOnHost:   rootfsPath,
+			IsRootDevice: true,
+			IsReadOnly:   taskConfig.RootFSReadOnly,
+		}},
+		MachineConfig: vmMachineConfig{
+			VCPUCount:  taskConfig.VCPUs,
+			MemSizeMiB: memoryMB,
+		},
+		Vsock: &vmVsock{
+			GuestCID: guestCID,
+			UDSPath:  vsockPath,
+		},
+	}
+
+	if network != nil {
+		cfg.NetworkInterfaces = []*vmNetworkInterface{{
+			IfaceID:     guestInterfaceName,
+			GuestMAC:    network.MAC,
+			HostDevName: network.TapName,
+		}}
+		bootArgs = bootArgs + " " + network.kernelArg()
+	}
+	cfg.BootSource.BootArgs = bootArgs
+
+	return cfg
+}
+
+// write writes the configuration file to path.
+func (c *vmConfig) write(path string) error {
+	buf, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode configuration: %v", err)
+	}
+	return os.WriteFile(path, buf, 0644)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package firecracker
+
+import (
+	"net"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestNewVMConfig_Network(t *testing.T) {
+	ci.Parallel(t)
+
+	_, address, err := net.ParseCIDR("172.26.64.5/20")
+	must.NoError(t, err)
+	address.IP = net.ParseIP("172.26.64.5")
+
+	network := &guestNetwork{
+		TapName:  "fctap0",
+		MAC:      "02:42:ac:1a:40:05",
+		Address:  address,
+		Gateway:  net.ParseIP("172.26.64.1"),
+		Hostname: "web",
+		DNS:      []string{"1.1.1.1", "2606:4700:4700::1111", "8.8.8.8", "9.9.9.9"},
+	}
+
+	cfg := newVMConfig(&TaskConfig{
+		KernelArgs:     "console=ttyS0",
+		VCPUs:          2,
+		RootFSReadOnly: true,
+	}, "/images/vmlinux", "/images/rootfs.ext4", 512, "/alloc/vm/fc.sock", network)
+
+	must.Eq(t, "console=ttyS0 ip=172.26.64.5::172.26.64.1:255.255.240.0:web:eth0:off:1.1.1.1:8.8.8.8",
+		cfg.BootSource.BootArgs)
+	must.Eq(t, []*vmNetworkInterface{{
+		IfaceID:     "eth0",
+		GuestMAC:    "02:42:ac:1a:40:05",
+		HostDevName: "fctap0",
+	}}, cfg.NetworkInterfaces)
+	must.True(t, cfg.Drives[0].IsReadOnly)
+	must.Eq(t, 512, cfg.MachineConfig.MemSizeMiB)
+	must.Eq(t, "/alloc/vm/fc.sock", cfg.Vsock.UDSPath)
+
+	// Without a network the VM has no interface
+	cfg = newVMConfig(&TaskConfig{VCPUs: 1}, "/images/vmlinux", "/images/rootfs.ext4", 512, "/alloc/vm/fc.sock", nil)
+	must.Eq(t, defaultKernelArgs, cfg.BootSource.BootArgs)
+	must.SliceEmpty(t, cfg.NetworkInterfaces)
+}
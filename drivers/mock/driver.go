@@ -90,6 +90,7 @@ var (
 		"exit_signal":            hclspec.NewAttr("exit_signal", "number", false),
 		"exit_err_msg":           hclspec.NewAttr("exit_err_msg", "string", false),
 		"signal_error":           hclspec.NewAttr("signal_error", "string", false),
+		"resize_error":           hclspec.NewAttr("resize_error", "string", false),
 		"stdout_string":          hclspec.NewAttr("stdout_string", "string", false),
 		"stdout_repeat":          hclspec.NewAttr("stdout_repeat", "number", false),
 		"stdout_repeat_duration": hclspec.NewAttr("stdout_repeat_duration", "string", false),
@@ -157,6 +158,7 @@ func NewMockDriver(ctx context.Context, logger hclog.Logger) drivers.DriverPlugi
 		Exec:         true,
 		FSIsolation:  drivers.FSIsolationNone,
 		MountConfigs: drivers.MountConfigSupportNone,
+		Resize:       true,
 	}
 
 	return &Driver{
@@ -205,6 +207,10 @@ type Command struct {
 	// SignalErr is the error message that the task returns if signalled
 	SignalErr string `codec:"signal_error"`
 
+	// ResizeErr is the error message that the task returns if its resources
+	// are updated
+	ResizeErr string `codec:"resize_error"`
+
 	// StdoutString is the string that should be sent to stdout
 	StdoutString string `codec:"stdout_string"`
 
@@ -346,6 +352,7 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 	} else {
 		health = drivers.HealthStateHealthy
 		attrs["driver.mock_driver"] = pstructs.NewBoolAttribute(true)
+		attrs["driver.mock_driver.resize"] = pstructs.NewBoolAttribute(true)
 		desc = drivers.DriverHealthy
 	}
 
@@ -640,6 +647,19 @@ func (d *Driver) SignalTask(taskID string, signal string) error {
 	return errors.New(h.command.SignalErr)
 }
 
+func (d *Driver) UpdateTaskResources(taskID string, resources *drivers.Resources) error {
+	h, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if h.command.ResizeErr == "" {
+		return nil
+	}
+
+	return errors.New(h.command.ResizeErr)
+}
+
 func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*drivers.ExecTaskResult, error) {
 	h, ok := d.tasks.Get(taskID)
 	if !ok {
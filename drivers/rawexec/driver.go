@@ -257,7 +257,10 @@ func (d *Driver) TaskConfigSchema() (*hclspec.Spec, error) {
 }
 
 func (d *Driver) Capabilities() (*drivers.Capabilities, error) {
-	return capabilities, nil
+	caps := *capabilities
+	// Tasks can only be resized when they run in a cgroup
+	caps.Resize = cgroupslib.GetMode() != cgroupslib.OFF
+	return &caps, nil
 }
 
 func (d *Driver) Fingerprint(ctx context.Context) (<-chan *drivers.Fingerprint, error) {
@@ -290,6 +293,9 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 		health = drivers.HealthStateHealthy
 		desc = drivers.DriverHealthy
 		attrs["driver.raw_exec"] = pstructs.NewBoolAttribute(true)
+		if cgroupslib.GetMode() != cgroupslib.OFF {
+			attrs["driver.raw_exec.resize"] = pstructs.NewBoolAttribute(true)
+		}
 	} else {
 		health = drivers.HealthStateUndetected
 		desc = "disabled"
@@ -570,6 +576,21 @@ func (d *Driver) SignalTask(taskID string, signal string) error {
 	return handle.exec.Signal(sig)
 }
 
+// UpdateTaskResources applies new cpu and memory limits to the cgroup of a
+// running task.
+func (d *Driver) UpdateTaskResources(taskID string, resources *drivers.Resources) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if err := handle.exec.UpdateResources(resources); err != nil {
+		return fmt.Errorf("executor UpdateResources failed: %v", err)
+	}
+
+	return nil
+}
+
 func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*drivers.ExecTaskResult, error) {
 	if len(cmd) == 0 {
 		return nil, fmt.Errorf("error cmd must have at least one value")
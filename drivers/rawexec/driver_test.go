@@ -175,6 +175,11 @@ func TestRawExecDriver_Fingerprint(t *testing.T) {
 		}
 	}
 
+	enabledAttrs := map[string]*pstructs.Attribute{"driver.raw_exec": pstructs.NewBoolAttribute(true)}
+	if cgroupslib.GetMode() != cgroupslib.OFF {
+		enabledAttrs["driver.raw_exec.resize"] = pstructs.NewBoolAttribute(true)
+	}
+
 	cases := []struct {
 		Name     string
 		Conf     Config
@@ -197,7 +202,7 @@ func TestRawExecDriver_Fingerprint(t *testing.T) {
 				Enabled: true,
 			},
 			Expected: drivers.Fingerprint{
-				Attributes:        map[string]*pstructs.Attribute{"driver.raw_exec": pstructs.NewBoolAttribute(true)},
+				Attributes:        enabledAttrs,
 				Health:            drivers.HealthStateHealthy,
 				HealthDescription: drivers.DriverHealthy,
 			},
@@ -0,0 +1,179 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build linux
+
+package executor
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/opencontainers/runc/libcontainer"
+)
+
+// CheckpointSupported returns true if the isolated executor can checkpoint
+// and restore tasks, which requires running as root with CRIU installed.
+func CheckpointSupported() bool {
+	if os.Geteuid() != 0 {
+		return false
+	}
+	_, err := exec.LookPath("criu")
+	return err == nil
+}
+
+// Checkpoint dumps the state of the container with CRIU and writes the
+// images to a gzipped tar archive at path. The container processes are
+// stopped once the dump completes.
+func (l *LibcontainerExecutor) Checkpoint(path string) error {
+	if l.container == nil {
+		return fmt.Errorf("container not yet launched")
+	}
+
+	imagesDir, err := os.MkdirTemp("", "nomad-checkpoint-")
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint directory: %v", err)
+	}
+	defer os.RemoveAll(imagesDir)
+
+	l.logger.Debug("checkpointing container", "container", l.id, "path", path)
+	err = l.container.Checkpoint(&libcontainer.CriuOpts{
+		ImagesDirectory: imagesDir,
+		TcpEstablished:  true,
+		FileLocks:       true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to checkpoint container(%s): %v", l.id, err)
+	}
+
+	if err := writeCheckpointArchive(imagesDir, path); err != nil {
+		os.Remove(path)
+		return fmt.Errorf("failed to write checkpoint archive: %v", err)
+	}
+	return nil
+}
+
+// restore starts the process in the container from the CRIU images in the
+// checkpoint archive at path.
+func (l *LibcontainerExecutor) restore(container libcontainer.Container, process *libcontainer.Process, path string) error {
+	imagesDir, err := os.MkdirTemp("", "nomad-restore-")
+	if err != nil {
+		return fmt.Errorf("failed to create restore directory: %v", err)
+	}
+	defer os.RemoveAll(imagesDir)
+
+	if err := readCheckpointArchive(path, imagesDir); err != nil {
+		return fmt.Errorf("failed to read checkpoint archive: %v", err)
+	}
+
+	l.logger.Debug("restoring container", "container", l.id, "path", path)
+	err = container.Restore(process, &libcontainer.CriuOpts{
+		ImagesDirectory: imagesDir,
+		TcpEstablished:  true,
+		FileLocks:       true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore container(%s): %v", l.id, err)
+	}
+	return nil
+}
+
+// writeCheckpointArchive writes the regular files of the CRIU images
+// directory to a gzipped tar archive at path.
+func writeCheckpointArchive(dir, path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		src, err := os.Open(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(tw, src)
+		src.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// readCheckpointArchive extracts a checkpoint archive written by
+// writeCheckpointArchive into dir.
+func readCheckpointArchive(path, dir string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// The archive only holds the flat images directory
+		name := filepath.Base(hdr.Name)
+		if hdr.Typeflag != tar.TypeReg || name != hdr.Name || strings.HasPrefix(name, ".") {
+			return fmt.Errorf("unexpected entry %q in checkpoint archive", hdr.Name)
+		}
+
+		dst, err := os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(dst, tr)
+		dst.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build linux
+
+package executor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestCheckpointArchive_RoundTrip(t *testing.T) {
+	ci.Parallel(t)
+
+	src := t.TempDir()
+	must.NoError(t, os.WriteFile(filepath.Join(src, "inventory.img"), []byte("inventory"), 0o600))
+	must.NoError(t, os.WriteFile(filepath.Join(src, "pages-1.img"), []byte("pages"), 0o600))
+	must.NoError(t, os.Mkdir(filepath.Join(src, "ignored"), 0o700))
+
+	archive := filepath.Join(t.TempDir(), "checkpoint.tar.gz")
+	must.NoError(t, writeCheckpointArchive(src, archive))
+
+	dst := t.TempDir()
+	must.NoError(t, readCheckpointArchive(archive, dst))
+
+	entries, err := os.ReadDir(dst)
+	must.NoError(t, err)
+	must.Len(t, 2, entries)
+
+	b, err := os.ReadFile(filepath.Join(dst, "pages-1.img"))
+	must.NoError(t, err)
+	must.Eq(t, "pages", string(b))
+
+	// Extracting into a directory that already has the files fails rather
+	// than overwriting them
+	must.Error(t, readCheckpointArchive(archive, dst))
+}
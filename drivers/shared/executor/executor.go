@@ -90,6 +90,11 @@ type Executor interface {
 
 	ExecStreaming(ctx context.Context, cmd []string, tty bool,
 		stream drivers.ExecTaskStream) error
+
+	// Checkpoint writes the state of the user process to an archive at the
+	// given path and stops the process. It is only supported by executors
+	// with process isolation.
+	Checkpoint(path string) error
 }
 
 // ExecCommand holds the user command, args, and other isolation related
@@ -171,6 +176,10 @@ type ExecCommand struct {
 	// OOMScoreAdj allows setting oom_score_adj (likelihood of process being
 	// OOM killed) on Linux systems
 	OOMScoreAdj int32
+
+	// RestorePath is the path to an archive written by Checkpoint. If set,
+	// the user process is restored from the archive instead of being started.
+	RestorePath string
 }
 
 func (c *ExecCommand) getCgroupOr(controller, fallback string) string {
@@ -356,6 +365,10 @@ func (e *UniversalExecutor) Version() (*ExecutorVersion, error) {
 func (e *UniversalExecutor) Launch(command *ExecCommand) (*ProcessState, error) {
 	e.logger.Trace("preparing to launch command", "command", command.Cmd, "args", strings.Join(command.Args, " "))
 
+	if command.RestorePath != "" {
+		return nil, fmt.Errorf("restoring from a checkpoint is not supported by the universal executor")
+	}
+
 	e.command = command
 
 	// setting the user of the process
@@ -558,8 +571,13 @@ func (e *UniversalExecutor) Wait(ctx context.Context) (*ProcessState, error) {
 	}
 }
 
+// UpdateResources applies the new cpu and memory limits of the task to the
+// cgroup the task runs in.
 func (e *UniversalExecutor) UpdateResources(resources *drivers.Resources) error {
-	return nil
+	if e.command == nil {
+		return fmt.Errorf("executor has not launched a task")
+	}
+	return e.updateResourceContainer(resources)
 }
 
 func (e *UniversalExecutor) wait() {
@@ -699,6 +717,11 @@ func (e *UniversalExecutor) Signal(s os.Signal) error {
 	return nil
 }
 
+// Checkpoint is not supported by the universal executor
+func (e *UniversalExecutor) Checkpoint(path string) error {
+	return fmt.Errorf("checkpoint is not supported by the universal executor")
+}
+
 func (e *UniversalExecutor) Stats(ctx context.Context, interval time.Duration) (<-chan *cstructs.TaskResourceUsage, error) {
 	ch := make(chan *cstructs.TaskResourceUsage)
 	go e.handleStats(ch, ctx, interval)
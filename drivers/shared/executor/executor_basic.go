@@ -6,6 +6,7 @@
 package executor
 
 import (
+	"fmt"
 	"os/exec"
 
 	"github.com/hashicorp/go-hclog"
@@ -27,6 +28,10 @@ func (e *UniversalExecutor) configureResourceContainer(_ *ExecCommand, _ int) (f
 	return running, cleanup, nil
 }
 
+func (e *UniversalExecutor) updateResourceContainer(*drivers.Resources) error {
+	return fmt.Errorf("updating task resources is not supported on this platform")
+}
+
 func (e *UniversalExecutor) start(command *ExecCommand) error {
 	return e.childCmd.Start()
 }
@@ -37,6 +42,10 @@ func withNetworkIsolation(f func() error, _ *drivers.NetworkIsolationSpec) error
 
 func setCmdUser(*exec.Cmd, string) error { return nil }
 
+// CheckpointSupported returns false as checkpointing tasks requires the
+// isolated executor.
+func CheckpointSupported() bool { return false }
+
 func (e *UniversalExecutor) ListProcesses() set.Collection[int] {
 	return procstats.ListByPid(e.childCmd.Process.Pid)
 }
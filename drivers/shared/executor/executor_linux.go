@@ -230,8 +230,13 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 	l.userCpuStats = cpustats.New(l.compute)
 	l.systemCpuStats = cpustats.New(l.compute)
 
-	// Starts the task
-	if err := container.Run(process); err != nil {
+	// Starts the task, or restores it from a checkpoint
+	if command.RestorePath != "" {
+		err = l.restore(container, process, command.RestorePath)
+	} else {
+		err = container.Run(process)
+	}
+	if err != nil {
 		container.Destroy()
 		return nil, err
 	}
@@ -382,6 +387,34 @@ func (l *LibcontainerExecutor) Shutdown(signal string, grace time.Duration) erro
 
 // UpdateResources updates the resource isolation with new values to be enforced
 func (l *LibcontainerExecutor) UpdateResources(resources *drivers.Resources) error {
+	if l.container == nil {
+		return fmt.Errorf("executor has not launched a task")
+	}
+	if resources == nil || resources.LinuxResources == nil || resources.NomadResources == nil {
+		return nil
+	}
+
+	// The cgroup of the config is shared with the container, copy it so the
+	// container keeps its current limits if they fail to be applied.
+	cfg := l.container.Config()
+	cgroup := *cfg.Cgroups
+	res := *cgroup.Resources
+	cgroup.Resources = &res
+	cfg.Cgroups = &cgroup
+
+	l.configureCgroupMemory(&cfg, &ExecCommand{Resources: resources})
+
+	cpuShares := l.clampCpuShares(resources.LinuxResources.CPUShares)
+	switch cgroupslib.GetMode() {
+	case cgroupslib.CG1:
+		res.CpuShares = uint64(cpuShares)
+	default:
+		res.CpuWeight = cgroups.ConvertCPUSharesToCgroupV2Value(uint64(cpuShares))
+	}
+
+	if err := l.container.Set(cfg); err != nil {
+		return fmt.Errorf("failed to update container resources: %w", err)
+	}
 	return nil
 }
 
@@ -751,6 +784,11 @@ func (l *LibcontainerExecutor) configureCgroups(cfg *runc.Config, command *ExecC
 	// set the libcontainer memory limits
 	l.configureCgroupMemory(cfg, command)
 
+	// set the libcontainer block io weight and limits
+	if err := l.configureCgroupIO(cfg, command); err != nil {
+		return err
+	}
+
 	// set cgroup v1/v2 specific attributes (cpu, path)
 	switch cgroupslib.GetMode() {
 	case cgroupslib.CG1:
@@ -784,6 +822,32 @@ func (l *LibcontainerExecutor) configureCgroupMemory(cfg *runc.Config, command *
 	cfg.Cgroups.Resources.MemorySwappiness = cgroupslib.MaybeDisableMemorySwappiness()
 }
 
+// configureCgroupIO sets the block io weight and per device limits of the
+// task, which libcontainer writes to the blkio (v1) or io (v2) controller.
+func (l *LibcontainerExecutor) configureCgroupIO(cfg *runc.Config, command *ExecCommand) error {
+	io := command.Resources.NomadResources.IO
+	cfg.Cgroups.Resources.BlkioWeight = uint16(io.Weight)
+
+	for _, limit := range io.Max {
+		major, minor, err := cgroupslib.BlockDevice(limit.Device)
+		if err != nil {
+			return fmt.Errorf("failed to limit io: %w", err)
+		}
+		throttle := func(devices []*runc.ThrottleDevice, rate int64) []*runc.ThrottleDevice {
+			if rate <= 0 {
+				return devices
+			}
+			return append(devices, runc.NewThrottleDevice(major, minor, uint64(rate)))
+		}
+		res := cfg.Cgroups.Resources
+		res.BlkioThrottleReadBpsDevice = throttle(res.BlkioThrottleReadBpsDevice, limit.ReadBps)
+		res.BlkioThrottleWriteBpsDevice = throttle(res.BlkioThrottleWriteBpsDevice, limit.WriteBps)
+		res.BlkioThrottleReadIOPSDevice = throttle(res.BlkioThrottleReadIOPSDevice, limit.ReadIOps)
+		res.BlkioThrottleWriteIOPSDevice = throttle(res.BlkioThrottleWriteIOPSDevice, limit.WriteIOps)
+	}
+	return nil
+}
+
 func (l *LibcontainerExecutor) configureCG1(cfg *runc.Config, command *ExecCommand, cgroup string) error {
 
 	cpuShares := l.clampCpuShares(command.Resources.LinuxResources.CPUShares)
@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/nomad/client/lib/nsutil"
 	"github.com/hashicorp/nomad/drivers/shared/executor/procstats"
 	"github.com/hashicorp/nomad/helper/users"
+	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/opencontainers/runc/libcontainer/cgroups"
 	"golang.org/x/sys/unix"
@@ -166,7 +167,9 @@ func (e *UniversalExecutor) configureResourceContainer(
 		deleteCgroup = func() {}
 		moveProcess = func() error { return nil }
 	default:
-		e.configureCG2(cgroup, command)
+		if err := e.configureCG2(cgroup, command); err != nil {
+			return moveProcess, deleteCgroup, err
+		}
 		// configure child process to spawn in the cgroup
 		// get file descriptor of the cgroup made for this task
 		fd, cleanup, err := e.statCG(cgroup)
@@ -274,13 +277,18 @@ func (e *UniversalExecutor) configureCG1(cgroup string, command *ExecCommand) er
 		_ = ed.Write("cpuset.cpus", cpuSet)
 	}
 
+	// the task cgroups don't include the blkio controller
+	if io := command.Resources.NomadResources.IO; io.Weight > 0 || len(io.Max) > 0 {
+		e.logger.Warn("io_weight and io_max are only enforced on cgroups v2")
+	}
+
 	return nil
 }
 
-func (e *UniversalExecutor) configureCG2(cgroup string, command *ExecCommand) {
+func (e *UniversalExecutor) configureCG2(cgroup string, command *ExecCommand) error {
 	// some drivers like qemu entirely own resource management
 	if command.Resources == nil || command.Resources.LinuxResources == nil {
-		return
+		return nil
 	}
 
 	// write memory cgroup files
@@ -312,6 +320,93 @@ func (e *UniversalExecutor) configureCG2(cgroup string, command *ExecCommand) {
 	// write cpuset cgroup file, if set
 	cpusetCpus := command.Resources.LinuxResources.CpusetCpus
 	_ = ed.Write("cpuset.cpus", cpusetCpus)
+
+	// write io cgroup files, if set
+	return configureIOCG2(ed, command.Resources.NomadResources.IO)
+}
+
+// configureIOCG2 writes the block I/O weight and per device limits of the
+// task. As with the libcontainer executor, the task fails to start if a device
+// can't be found or limited, since the constraint on the device should have
+// kept it from being placed on the node.
+func configureIOCG2(ed cgroupslib.Interface, io structs.AllocatedIOResources) error {
+	if io.Weight > 0 {
+		weight := cgroups.ConvertBlkIOToIOWeightValue(uint16(io.Weight))
+		if err := ed.Write("io.weight", strconv.FormatUint(weight, 10)); err != nil {
+			return fmt.Errorf("failed to write io weight: %w", err)
+		}
+	}
+
+	for _, limit := range io.Max {
+		major, minor, err := cgroupslib.BlockDevice(limit.Device)
+		if err != nil {
+			return fmt.Errorf("failed to limit io: %w", err)
+		}
+		line := cgroupslib.IOMax(major, minor, limit.ReadBps, limit.WriteBps, limit.ReadIOps, limit.WriteIOps)
+		if err := ed.Write("io.max", line); err != nil {
+			return fmt.Errorf("failed to write io limits of device %q: %w", limit.Device, err)
+		}
+	}
+	return nil
+}
+
+// updateResourceContainer writes new cpu and memory limits to the cgroup of a
+// running task. Unlike the initial configuration, errors are returned, so the
+// task keeps its previous limits and the caller can report the failure.
+func (e *UniversalExecutor) updateResourceContainer(resources *drivers.Resources) error {
+	// some drivers like qemu entirely own resource management
+	if resources == nil || resources.LinuxResources == nil || resources.NomadResources == nil {
+		return nil
+	}
+	if e.usesCustomCgroup() {
+		return fmt.Errorf("cannot update resources of a task using a custom cgroup")
+	}
+
+	command := &ExecCommand{Resources: resources}
+	memHard, memSoft := e.computeMemory(command)
+	cgroup := e.command.StatsCgroup()
+
+	switch cgroupslib.GetMode() {
+	case cgroupslib.CG1:
+		ed := cgroupslib.OpenFromFreezerCG1(cgroup, "memory")
+		if err := ed.Write("memory.limit_in_bytes", strconv.FormatInt(memHard, 10)); err != nil {
+			return fmt.Errorf("failed to write memory limit: %w", err)
+		}
+		soft := "-1"
+		if memSoft > 0 {
+			soft = strconv.FormatInt(memSoft, 10)
+		}
+		if err := ed.Write("memory.soft_limit_in_bytes", soft); err != nil {
+			return fmt.Errorf("failed to write memory soft limit: %w", err)
+		}
+		ed = cgroupslib.OpenFromFreezerCG1(cgroup, "cpu")
+		cpuShares := strconv.FormatInt(resources.LinuxResources.CPUShares, 10)
+		if err := ed.Write("cpu.shares", cpuShares); err != nil {
+			return fmt.Errorf("failed to write cpu shares: %w", err)
+		}
+	case cgroupslib.CG2:
+		ed := cgroupslib.OpenPath(cgroup)
+		hard := "max"
+		if memHard != memoryNoLimit {
+			hard = strconv.FormatInt(memHard, 10)
+		}
+		if err := ed.Write("memory.max", hard); err != nil {
+			return fmt.Errorf("failed to write memory limit: %w", err)
+		}
+		if err := ed.Write("memory.low", strconv.FormatInt(memSoft, 10)); err != nil {
+			return fmt.Errorf("failed to write memory soft limit: %w", err)
+		}
+		cpuWeight := e.computeCPU(command)
+		if err := ed.Write("cpu.weight", strconv.FormatUint(cpuWeight, 10)); err != nil {
+			return fmt.Errorf("failed to write cpu weight: %w", err)
+		}
+	default:
+		return fmt.Errorf("cannot update resources without cgroups")
+	}
+
+	e.logger.Debug("updated task resources", "cpu_shares", resources.LinuxResources.CPUShares,
+		"memory_bytes", memHard)
+	return nil
 }
 
 func (e *UniversalExecutor) setOomAdj(oomScore int32) error {
@@ -56,6 +56,7 @@ func (c *grpcExecutorClient) Launch(cmd *ExecCommand) (*ProcessState, error) {
 		CgroupV1Override: cmd.OverrideCgroupV1,
 		OomScoreAdj:      cmd.OOMScoreAdj,
 		WorkDir:          cmd.WorkDir,
+		RestorePath:      cmd.RestorePath,
 	}
 	resp, err := c.client.Launch(ctx, req)
 	if err != nil {
@@ -201,6 +202,18 @@ func (c *grpcExecutorClient) Exec(deadline time.Time, cmd string, args []string)
 	return resp.Output, int(resp.ExitCode), nil
 }
 
+func (c *grpcExecutorClient) Checkpoint(path string) error {
+	ctx := context.Background()
+	req := &proto.CheckpointRequest{
+		Path: path,
+	}
+	if _, err := c.client.Checkpoint(ctx, req); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (c *grpcExecutorClient) ExecStreaming(ctx context.Context,
 	command []string,
 	tty bool,
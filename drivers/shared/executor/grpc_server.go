@@ -45,6 +45,7 @@ func (s *grpcExecutorServer) Launch(ctx context.Context, req *proto.LaunchReques
 		OverrideCgroupV1: req.CgroupV1Override,
 		OOMScoreAdj:      req.OomScoreAdj,
 		WorkDir:          req.WorkDir,
+		RestorePath:      req.RestorePath,
 	})
 
 	if err != nil {
@@ -182,3 +183,11 @@ func (s *grpcExecutorServer) ExecStreaming(server proto.Executor_ExecStreamingSe
 		msg.Setup.Command, msg.Setup.Tty,
 		server)
 }
+
+func (s *grpcExecutorServer) Checkpoint(ctx context.Context, req *proto.CheckpointRequest) (*proto.CheckpointResponse, error) {
+	if err := s.impl.Checkpoint(req.Path); err != nil {
+		return nil, err
+	}
+
+	return &proto.CheckpointResponse{}, nil
+}
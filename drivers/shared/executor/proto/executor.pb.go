@@ -50,6 +50,7 @@ type LaunchRequest struct {
 	CgroupV1Override     map[string]string            `protobuf:"bytes,21,rep,name=cgroup_v1_override,json=cgroupV1Override,proto3" json:"cgroup_v1_override,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	OomScoreAdj          int32                        `protobuf:"varint,22,opt,name=oom_score_adj,json=oomScoreAdj,proto3" json:"oom_score_adj,omitempty"`
 	WorkDir              string                       `protobuf:"bytes,23,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
+	RestorePath          string                       `protobuf:"bytes,24,opt,name=restore_path,json=restorePath,proto3" json:"restore_path,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
 	XXX_unrecognized     []byte                       `json:"-"`
 	XXX_sizecache        int32                        `json:"-"`
@@ -242,6 +243,13 @@ func (m *LaunchRequest) GetWorkDir() string {
 	return ""
 }
 
+func (m *LaunchRequest) GetRestorePath() string {
+	if m != nil {
+		return m.RestorePath
+	}
+	return ""
+}
+
 type LaunchResponse struct {
 	Process              *ProcessState `protobuf:"bytes,1,opt,name=process,proto3" json:"process,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
@@ -890,6 +898,76 @@ func (m *ProcessState) GetOomKilled() bool {
 	return false
 }
 
+type CheckpointRequest struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointRequest) Reset()         { *m = CheckpointRequest{} }
+func (m *CheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckpointRequest) ProtoMessage()    {}
+func (*CheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{17}
+}
+
+func (m *CheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointRequest.Unmarshal(m, b)
+}
+func (m *CheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointRequest.Merge(m, src)
+}
+func (m *CheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckpointRequest.Size(m)
+}
+func (m *CheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointRequest proto.InternalMessageInfo
+
+func (m *CheckpointRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type CheckpointResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointResponse) Reset()         { *m = CheckpointResponse{} }
+func (m *CheckpointResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckpointResponse) ProtoMessage()    {}
+func (*CheckpointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{18}
+}
+
+func (m *CheckpointResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointResponse.Unmarshal(m, b)
+}
+func (m *CheckpointResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckpointResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointResponse.Merge(m, src)
+}
+func (m *CheckpointResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckpointResponse.Size(m)
+}
+func (m *CheckpointResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*LaunchRequest)(nil), "hashicorp.nomad.plugins.executor.proto.LaunchRequest")
 	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.executor.proto.LaunchRequest.CgroupV1OverrideEntry")
@@ -909,6 +987,8 @@ func init() {
 	proto.RegisterType((*ExecRequest)(nil), "hashicorp.nomad.plugins.executor.proto.ExecRequest")
 	proto.RegisterType((*ExecResponse)(nil), "hashicorp.nomad.plugins.executor.proto.ExecResponse")
 	proto.RegisterType((*ProcessState)(nil), "hashicorp.nomad.plugins.executor.proto.ProcessState")
+	proto.RegisterType((*CheckpointRequest)(nil), "hashicorp.nomad.plugins.executor.proto.CheckpointRequest")
+	proto.RegisterType((*CheckpointResponse)(nil), "hashicorp.nomad.plugins.executor.proto.CheckpointResponse")
 }
 
 func init() {
@@ -916,83 +996,86 @@ func init() {
 }
 
 var fileDescriptor_66b85426380683f3 = []byte{
-	// 1205 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xfb, 0x6e, 0x1b, 0xc5,
-	0x17, 0xee, 0xc6, 0x71, 0x6c, 0x1f, 0xdb, 0x89, 0x3b, 0xbf, 0x36, 0xdd, 0xfa, 0x27, 0xd4, 0xb0,
-	0x48, 0xd4, 0x82, 0xb2, 0x69, 0xd3, 0xab, 0x40, 0xa2, 0xd0, 0xa4, 0xa0, 0xaa, 0x17, 0xa2, 0x4d,
-	0x69, 0x25, 0xfe, 0x60, 0x99, 0xee, 0x4e, 0xed, 0xa9, 0xd7, 0x3b, 0xcb, 0xcc, 0xac, 0x9b, 0x48,
-	0x48, 0xbc, 0x04, 0x48, 0x3c, 0x00, 0xaf, 0xc7, 0x3b, 0xa0, 0xb9, 0x6d, 0xec, 0xb4, 0x54, 0x9b,
-	0x22, 0xfe, 0xca, 0x9e, 0xcf, 0xdf, 0xb9, 0xcc, 0x39, 0x73, 0xbe, 0x09, 0x5c, 0x49, 0x39, 0x9d,
-	0x13, 0x2e, 0xb6, 0xc5, 0x04, 0x73, 0x92, 0x6e, 0x93, 0x43, 0x92, 0x94, 0x92, 0xf1, 0xed, 0x82,
-	0x33, 0xc9, 0x2a, 0x33, 0xd4, 0x26, 0xfa, 0x78, 0x82, 0xc5, 0x84, 0x26, 0x8c, 0x17, 0x61, 0xce,
-	0x66, 0x38, 0x0d, 0x8b, 0xac, 0x1c, 0xd3, 0x5c, 0x84, 0xcb, 0xbc, 0xe1, 0xa5, 0x31, 0x63, 0xe3,
-	0x8c, 0x98, 0x20, 0x2f, 0xca, 0x97, 0xdb, 0x92, 0xce, 0x88, 0x90, 0x78, 0x56, 0x58, 0x42, 0x60,
-	0x1d, 0xb7, 0x5d, 0x7a, 0x93, 0xce, 0x58, 0x86, 0x13, 0xfc, 0xd5, 0x86, 0xfe, 0x23, 0x5c, 0xe6,
-	0xc9, 0x24, 0x22, 0x3f, 0x97, 0x44, 0x48, 0x34, 0x80, 0x46, 0x32, 0x4b, 0x7d, 0x6f, 0xcb, 0x1b,
-	0x75, 0x22, 0xf5, 0x89, 0x10, 0xac, 0x62, 0x3e, 0x16, 0xfe, 0xca, 0x56, 0x63, 0xd4, 0x89, 0xf4,
-	0x37, 0x7a, 0x02, 0x1d, 0x4e, 0x04, 0x2b, 0x79, 0x42, 0x84, 0xdf, 0xd8, 0xf2, 0x46, 0xdd, 0x9d,
-	0xab, 0xe1, 0x3f, 0x15, 0x6e, 0xf3, 0x9b, 0x94, 0x61, 0xe4, 0xfc, 0xa2, 0xe3, 0x10, 0xe8, 0x12,
-	0x74, 0x85, 0x4c, 0x59, 0x29, 0xe3, 0x02, 0xcb, 0x89, 0xbf, 0xaa, 0xb3, 0x83, 0x81, 0xf6, 0xb1,
-	0x9c, 0x58, 0x02, 0xe1, 0xdc, 0x10, 0x9a, 0x15, 0x81, 0x70, 0xae, 0x09, 0x03, 0x68, 0x90, 0x7c,
-	0xee, 0xaf, 0xe9, 0x22, 0xd5, 0xa7, 0xaa, 0xbb, 0x14, 0x84, 0xfb, 0x2d, 0xcd, 0xd5, 0xdf, 0xe8,
-	0x22, 0xb4, 0x25, 0x16, 0xd3, 0x38, 0xa5, 0xdc, 0x6f, 0x6b, 0xbc, 0xa5, 0xec, 0x3d, 0xca, 0xd1,
-	0x65, 0xd8, 0x70, 0xf5, 0xc4, 0x19, 0x9d, 0x51, 0x29, 0xfc, 0xce, 0x96, 0x37, 0x6a, 0x47, 0xeb,
-	0x0e, 0x7e, 0xa4, 0x51, 0x74, 0x03, 0xce, 0xbd, 0xc0, 0x82, 0x26, 0x71, 0xc1, 0x59, 0x42, 0x84,
-	0x88, 0x93, 0x31, 0x67, 0x65, 0xe1, 0x83, 0x62, 0xdf, 0x5b, 0xf1, 0xbd, 0x08, 0xe9, 0xdf, 0xf7,
-	0xcd, 0xcf, 0xbb, 0xfa, 0x57, 0xb4, 0x07, 0x6b, 0x33, 0x56, 0xe6, 0x52, 0xf8, 0xdd, 0xad, 0xc6,
-	0xa8, 0xbb, 0x73, 0xa5, 0x66, 0xbb, 0x1e, 0x2b, 0xa7, 0xc8, 0xfa, 0xa2, 0x6f, 0xa1, 0x95, 0x92,
-	0x39, 0x55, 0x5d, 0xef, 0xe9, 0x30, 0x9f, 0xd5, 0x0c, 0xb3, 0xa7, 0xbd, 0x22, 0xe7, 0x8d, 0x26,
-	0x70, 0x36, 0x27, 0xf2, 0x35, 0xe3, 0xd3, 0x98, 0x0a, 0x96, 0x61, 0x49, 0x59, 0xee, 0xf7, 0xf5,
-	0x20, 0xbf, 0xa8, 0x19, 0xf2, 0x89, 0xf1, 0x7f, 0xe0, 0xdc, 0x0f, 0x0a, 0x92, 0x44, 0x83, 0xfc,
-	0x04, 0x8a, 0x02, 0xe8, 0xe7, 0x2c, 0x2e, 0xe8, 0x9c, 0xc9, 0x98, 0x33, 0x26, 0xfd, 0x75, 0xdd,
-	0xd5, 0x6e, 0xce, 0xf6, 0x15, 0x16, 0x31, 0x26, 0xd1, 0x08, 0x06, 0x29, 0x79, 0x89, 0xcb, 0x4c,
-	0xc6, 0x05, 0x4d, 0xe3, 0x19, 0x4b, 0x89, 0xbf, 0xa1, 0xc7, 0xb3, 0x6e, 0xf1, 0x7d, 0x9a, 0x3e,
-	0x66, 0x29, 0x59, 0x64, 0xd2, 0x22, 0x31, 0xcc, 0xc1, 0x12, 0xf3, 0x41, 0x91, 0x68, 0xe6, 0x47,
-	0xd0, 0x4f, 0x8a, 0x52, 0x10, 0xe9, 0xe6, 0x73, 0x56, 0xd3, 0x7a, 0x06, 0xb4, 0x53, 0xf9, 0x00,
-	0x00, 0x67, 0x19, 0x7b, 0x1d, 0x27, 0xb8, 0x10, 0x3e, 0xd2, 0x97, 0xa7, 0xa3, 0x91, 0x5d, 0x5c,
-	0x08, 0x14, 0x40, 0x2f, 0xc1, 0x05, 0x7e, 0x41, 0x33, 0x2a, 0x29, 0x11, 0xfe, 0xff, 0x34, 0x61,
-	0x09, 0x43, 0x57, 0x00, 0x99, 0x04, 0xf1, 0x7c, 0x27, 0x66, 0x73, 0xc2, 0x39, 0x4d, 0x89, 0x7f,
-	0x4e, 0x27, 0x1b, 0x98, 0x5f, 0x9e, 0xed, 0x7c, 0x67, 0x71, 0x74, 0x74, 0xcc, 0xbe, 0x76, 0xcc,
-	0x3e, 0xaf, 0x67, 0xf9, 0x30, 0xac, 0xb7, 0xfa, 0xe1, 0xd2, 0xc6, 0x86, 0xe6, 0x28, 0xcf, 0xae,
-	0xb9, 0x1c, 0xf7, 0x73, 0xc9, 0x8f, 0xaa, 0xd4, 0x15, 0xac, 0x06, 0xc1, 0xd8, 0x2c, 0x16, 0x09,
-	0xe3, 0x24, 0xc6, 0xe9, 0x2b, 0x7f, 0x73, 0xcb, 0x1b, 0x35, 0xa3, 0x2e, 0x63, 0xb3, 0x03, 0x85,
-	0x7d, 0x9d, 0xbe, 0x52, 0xfb, 0xa1, 0xef, 0x84, 0xda, 0x8f, 0x0b, 0x66, 0x3f, 0x94, 0xbd, 0x47,
-	0xf9, 0x70, 0x17, 0xce, 0xbf, 0x35, 0x93, 0xda, 0xbc, 0x29, 0x39, 0x72, 0x8a, 0x31, 0x25, 0x47,
-	0xe8, 0x1c, 0x34, 0xe7, 0x38, 0x2b, 0x89, 0xbf, 0xa2, 0x31, 0x63, 0x7c, 0xbe, 0x72, 0xc7, 0x0b,
-	0x7e, 0x82, 0x75, 0x57, 0xbc, 0x28, 0x58, 0x2e, 0x08, 0x7a, 0x02, 0x2d, 0xbb, 0x47, 0x3a, 0x42,
-	0x77, 0xe7, 0x46, 0xdd, 0x2e, 0xd8, 0xfd, 0x3a, 0x90, 0x58, 0x92, 0xc8, 0x05, 0x09, 0xfa, 0xd0,
-	0x7d, 0x8e, 0xa9, 0xb4, 0xcd, 0x09, 0x7e, 0x84, 0x9e, 0x31, 0xff, 0xa3, 0x74, 0x8f, 0x60, 0xe3,
-	0x60, 0x52, 0xca, 0x94, 0xbd, 0xce, 0x9d, 0x82, 0x6e, 0xc2, 0x9a, 0xa0, 0xe3, 0x1c, 0x67, 0xb6,
-	0x25, 0xd6, 0x42, 0x1f, 0x42, 0x6f, 0xcc, 0x71, 0x42, 0xe2, 0x82, 0x70, 0xca, 0x52, 0xdd, 0x9c,
-	0x46, 0xd4, 0xd5, 0xd8, 0xbe, 0x86, 0x02, 0x04, 0x83, 0xe3, 0x68, 0xa6, 0xe2, 0x60, 0x02, 0x9b,
-	0xdf, 0x17, 0xa9, 0x4a, 0x5a, 0x09, 0xa7, 0x4d, 0xb4, 0x24, 0xc2, 0xde, 0xbf, 0x16, 0xe1, 0xe0,
-	0x22, 0x5c, 0x78, 0x23, 0x93, 0x2d, 0x62, 0x00, 0xeb, 0xcf, 0x08, 0x17, 0x94, 0xb9, 0x53, 0x06,
-	0x9f, 0xc2, 0x46, 0x85, 0xd8, 0xde, 0xfa, 0xd0, 0x9a, 0x1b, 0xc8, 0x9e, 0xdc, 0x99, 0xc1, 0x27,
-	0xd0, 0x53, 0x7d, 0xab, 0x2a, 0x1f, 0x42, 0x9b, 0xe6, 0x92, 0xf0, 0xb9, 0x6d, 0x52, 0x23, 0xaa,
-	0xec, 0xe0, 0x39, 0xf4, 0x2d, 0xd7, 0x86, 0xfd, 0x06, 0x9a, 0x42, 0x01, 0xa7, 0x3c, 0xe2, 0x53,
-	0x2c, 0xa6, 0x26, 0x90, 0x71, 0x0f, 0x2e, 0x43, 0xff, 0x40, 0x4f, 0xe2, 0xed, 0x83, 0x6a, 0xba,
-	0x41, 0xa9, 0xc3, 0x3a, 0xa2, 0x3d, 0xfe, 0x14, 0xba, 0xf7, 0x0f, 0x49, 0xe2, 0x1c, 0x6f, 0x41,
-	0x3b, 0x25, 0x38, 0xcd, 0x68, 0x4e, 0x6c, 0x51, 0xc3, 0xd0, 0xbc, 0xc6, 0xa1, 0x7b, 0x8d, 0xc3,
-	0xa7, 0xee, 0x35, 0x8e, 0x2a, 0xae, 0x7b, 0x5b, 0x57, 0xde, 0x7c, 0x5b, 0x1b, 0xc7, 0x6f, 0x6b,
-	0xb0, 0x0b, 0x3d, 0x93, 0xcc, 0x9e, 0x7f, 0x13, 0xd6, 0x58, 0x29, 0x8b, 0x52, 0xea, 0x5c, 0xbd,
-	0xc8, 0x5a, 0xe8, 0xff, 0xd0, 0x21, 0x87, 0x54, 0xc6, 0x89, 0xd2, 0xc0, 0x15, 0x7d, 0x82, 0xb6,
-	0x02, 0x76, 0x59, 0x4a, 0x82, 0x3f, 0x3d, 0xe8, 0x2d, 0xde, 0x58, 0x95, 0xbb, 0xa0, 0xa9, 0x3d,
-	0xa9, 0xfa, 0x7c, 0xa7, 0xff, 0x42, 0x6f, 0x1a, 0x8b, 0xbd, 0x41, 0x21, 0xac, 0xaa, 0xff, 0x33,
-	0xf4, 0x0b, 0xfd, 0xee, 0x63, 0x6b, 0x9e, 0x12, 0x58, 0x25, 0x3a, 0x53, 0x9a, 0x65, 0x24, 0xd5,
-	0xcf, 0x76, 0x3b, 0xea, 0x30, 0x36, 0x7b, 0xa8, 0x81, 0x9d, 0xdf, 0x3b, 0xd0, 0xbe, 0x6f, 0xf7,
-	0x0c, 0x1d, 0xc1, 0x9a, 0x11, 0x07, 0x74, 0xf3, 0xbd, 0x94, 0x70, 0x78, 0xeb, 0xb4, 0x6e, 0x76,
-	0xbc, 0x67, 0x90, 0x80, 0x55, 0x25, 0x13, 0xe8, 0x7a, 0xdd, 0x08, 0x0b, 0x1a, 0x33, 0xbc, 0x71,
-	0x3a, 0xa7, 0x2a, 0xe9, 0xaf, 0xd0, 0x76, 0xdb, 0x8e, 0x6e, 0xd7, 0x8d, 0x71, 0x42, 0x6d, 0x86,
-	0x77, 0x4e, 0xef, 0x58, 0x15, 0xf0, 0x9b, 0x07, 0x1b, 0x27, 0x36, 0x1e, 0x7d, 0x59, 0x37, 0xde,
-	0xdb, 0x45, 0x69, 0x78, 0xf7, 0xbd, 0xfd, 0xab, 0xb2, 0x7e, 0x81, 0x96, 0x95, 0x16, 0x54, 0x7b,
-	0xa2, 0xcb, 0xea, 0x34, 0xbc, 0x7d, 0x6a, 0xbf, 0x2a, 0xfb, 0x21, 0x34, 0xb5, 0x6c, 0xa0, 0xda,
-	0x63, 0x5d, 0x94, 0xb6, 0xe1, 0xcd, 0x53, 0x7a, 0xb9, 0xbc, 0x57, 0x3d, 0x75, 0xff, 0x8d, 0xee,
-	0xd4, 0xbf, 0xff, 0x4b, 0x82, 0x56, 0xff, 0xfe, 0x9f, 0x90, 0x37, 0x7d, 0xff, 0xd5, 0x1a, 0xd6,
-	0xbf, 0xff, 0x0b, 0x72, 0x58, 0xff, 0xfe, 0x2f, 0xca, 0x5a, 0x70, 0x06, 0xfd, 0xe1, 0x41, 0x5f,
-	0x41, 0x07, 0x92, 0x13, 0x3c, 0xa3, 0xf9, 0x18, 0xdd, 0xad, 0xa9, 0xed, 0xca, 0xcb, 0xe8, 0xbb,
-	0xf5, 0x74, 0xa5, 0x7c, 0xf5, 0xfe, 0x01, 0x5c, 0x59, 0x23, 0xef, 0xaa, 0x77, 0xaf, 0xf5, 0x43,
-	0xd3, 0x48, 0xda, 0x9a, 0xfe, 0x73, 0xfd, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0x5b, 0xc0, 0xa1,
-	0x7e, 0xc4, 0x0d, 0x00, 0x00,
+	// 1261 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xcd, 0x6f, 0x1b, 0xc5,
+	0x1b, 0xfe, 0x6d, 0x1c, 0xc7, 0xf6, 0x6b, 0x3b, 0x71, 0xe7, 0x97, 0xa6, 0x5b, 0x23, 0xd4, 0xb0,
+	0x48, 0xd4, 0x82, 0xe2, 0xb4, 0x69, 0xfa, 0x41, 0x91, 0x28, 0x34, 0x2d, 0xa8, 0xea, 0x07, 0xd1,
+	0xa6, 0xb4, 0x12, 0x07, 0x96, 0xe9, 0xee, 0xd4, 0x9e, 0x7a, 0xbd, 0xb3, 0xcc, 0xcc, 0xba, 0x89,
+	0x84, 0xc4, 0x89, 0x3b, 0x07, 0x0e, 0x5c, 0xb8, 0xf1, 0x87, 0xa2, 0xf9, 0xda, 0xd8, 0x6d, 0x81,
+	0x75, 0x10, 0x27, 0xcf, 0x3c, 0xfb, 0x3e, 0xef, 0xd7, 0xcc, 0xfb, 0x8c, 0xe1, 0x52, 0xc2, 0xe9,
+	0x8c, 0x70, 0xb1, 0x23, 0xc6, 0x98, 0x93, 0x64, 0x87, 0x1c, 0x91, 0xb8, 0x90, 0x8c, 0xef, 0xe4,
+	0x9c, 0x49, 0x56, 0x6e, 0x87, 0x7a, 0x8b, 0x3e, 0x18, 0x63, 0x31, 0xa6, 0x31, 0xe3, 0xf9, 0x30,
+	0x63, 0x53, 0x9c, 0x0c, 0xf3, 0xb4, 0x18, 0xd1, 0x4c, 0x0c, 0x17, 0xed, 0xfa, 0x17, 0x46, 0x8c,
+	0x8d, 0x52, 0x62, 0x9c, 0x3c, 0x2f, 0x5e, 0xec, 0x48, 0x3a, 0x25, 0x42, 0xe2, 0x69, 0x6e, 0x0d,
+	0x02, 0x4b, 0xdc, 0x71, 0xe1, 0x4d, 0x38, 0xb3, 0x33, 0x36, 0xc1, 0x2f, 0x2d, 0xe8, 0x3e, 0xc4,
+	0x45, 0x16, 0x8f, 0x43, 0xf2, 0x43, 0x41, 0x84, 0x44, 0x3d, 0xa8, 0xc5, 0xd3, 0xc4, 0xf7, 0xb6,
+	0xbd, 0x41, 0x2b, 0x54, 0x4b, 0x84, 0x60, 0x15, 0xf3, 0x91, 0xf0, 0x57, 0xb6, 0x6b, 0x83, 0x56,
+	0xa8, 0xd7, 0xe8, 0x31, 0xb4, 0x38, 0x11, 0xac, 0xe0, 0x31, 0x11, 0x7e, 0x6d, 0xdb, 0x1b, 0xb4,
+	0x77, 0x2f, 0x0f, 0xff, 0x2a, 0x71, 0x1b, 0xdf, 0x84, 0x1c, 0x86, 0x8e, 0x17, 0x9e, 0xb8, 0x40,
+	0x17, 0xa0, 0x2d, 0x64, 0xc2, 0x0a, 0x19, 0xe5, 0x58, 0x8e, 0xfd, 0x55, 0x1d, 0x1d, 0x0c, 0x74,
+	0x80, 0xe5, 0xd8, 0x1a, 0x10, 0xce, 0x8d, 0x41, 0xbd, 0x34, 0x20, 0x9c, 0x6b, 0x83, 0x1e, 0xd4,
+	0x48, 0x36, 0xf3, 0xd7, 0x74, 0x92, 0x6a, 0xa9, 0xf2, 0x2e, 0x04, 0xe1, 0x7e, 0x43, 0xdb, 0xea,
+	0x35, 0x3a, 0x0f, 0x4d, 0x89, 0xc5, 0x24, 0x4a, 0x28, 0xf7, 0x9b, 0x1a, 0x6f, 0xa8, 0xfd, 0x5d,
+	0xca, 0xd1, 0x45, 0xd8, 0x70, 0xf9, 0x44, 0x29, 0x9d, 0x52, 0x29, 0xfc, 0xd6, 0xb6, 0x37, 0x68,
+	0x86, 0xeb, 0x0e, 0x7e, 0xa8, 0x51, 0xb4, 0x07, 0x9b, 0xcf, 0xb1, 0xa0, 0x71, 0x94, 0x73, 0x16,
+	0x13, 0x21, 0xa2, 0x78, 0xc4, 0x59, 0x91, 0xfb, 0xa0, 0xac, 0xef, 0xac, 0xf8, 0x5e, 0x88, 0xf4,
+	0xf7, 0x03, 0xf3, 0x79, 0x5f, 0x7f, 0x45, 0x77, 0x61, 0x6d, 0xca, 0x8a, 0x4c, 0x0a, 0xbf, 0xbd,
+	0x5d, 0x1b, 0xb4, 0x77, 0x2f, 0x55, 0x6c, 0xd7, 0x23, 0x45, 0x0a, 0x2d, 0x17, 0x7d, 0x05, 0x8d,
+	0x84, 0xcc, 0xa8, 0xea, 0x7a, 0x47, 0xbb, 0xf9, 0xb8, 0xa2, 0x9b, 0xbb, 0x9a, 0x15, 0x3a, 0x36,
+	0x1a, 0xc3, 0x99, 0x8c, 0xc8, 0x57, 0x8c, 0x4f, 0x22, 0x2a, 0x58, 0x8a, 0x25, 0x65, 0x99, 0xdf,
+	0xd5, 0x07, 0xf9, 0x69, 0x45, 0x97, 0x8f, 0x0d, 0xff, 0xbe, 0xa3, 0x1f, 0xe6, 0x24, 0x0e, 0x7b,
+	0xd9, 0x6b, 0x28, 0x0a, 0xa0, 0x9b, 0xb1, 0x28, 0xa7, 0x33, 0x26, 0x23, 0xce, 0x98, 0xf4, 0xd7,
+	0x75, 0x57, 0xdb, 0x19, 0x3b, 0x50, 0x58, 0xc8, 0x98, 0x44, 0x03, 0xe8, 0x25, 0xe4, 0x05, 0x2e,
+	0x52, 0x19, 0xe5, 0x34, 0x89, 0xa6, 0x2c, 0x21, 0xfe, 0x86, 0x3e, 0x9e, 0x75, 0x8b, 0x1f, 0xd0,
+	0xe4, 0x11, 0x4b, 0xc8, 0xbc, 0x25, 0xcd, 0x63, 0x63, 0xd9, 0x5b, 0xb0, 0xbc, 0x9f, 0xc7, 0xda,
+	0xf2, 0x7d, 0xe8, 0xc6, 0x79, 0x21, 0x88, 0x74, 0xe7, 0x73, 0x46, 0x9b, 0x75, 0x0c, 0x68, 0x4f,
+	0xe5, 0x5d, 0x00, 0x9c, 0xa6, 0xec, 0x55, 0x14, 0xe3, 0x5c, 0xf8, 0x48, 0x5f, 0x9e, 0x96, 0x46,
+	0xf6, 0x71, 0x2e, 0x50, 0x00, 0x9d, 0x18, 0xe7, 0xf8, 0x39, 0x4d, 0xa9, 0xa4, 0x44, 0xf8, 0xff,
+	0xd7, 0x06, 0x0b, 0x18, 0xba, 0x04, 0xc8, 0x04, 0x88, 0x66, 0xbb, 0x11, 0x9b, 0x11, 0xce, 0x69,
+	0x42, 0xfc, 0x4d, 0x1d, 0xac, 0x67, 0xbe, 0x3c, 0xdd, 0xfd, 0xda, 0xe2, 0xe8, 0xf8, 0xc4, 0xfa,
+	0xca, 0x89, 0xf5, 0x59, 0x7d, 0x96, 0x0f, 0x86, 0xd5, 0x46, 0x7f, 0xb8, 0x30, 0xb1, 0x43, 0x53,
+	0xca, 0xd3, 0x2b, 0x2e, 0xc6, 0xbd, 0x4c, 0xf2, 0xe3, 0x32, 0x74, 0x09, 0xab, 0x83, 0x60, 0x6c,
+	0x1a, 0x89, 0x98, 0x71, 0x12, 0xe1, 0xe4, 0xa5, 0xbf, 0xb5, 0xed, 0x0d, 0xea, 0x61, 0x9b, 0xb1,
+	0xe9, 0xa1, 0xc2, 0xbe, 0x48, 0x5e, 0xaa, 0xf9, 0xd0, 0x77, 0x42, 0xcd, 0xc7, 0x39, 0x33, 0x1f,
+	0x6a, 0xaf, 0xe6, 0xe3, 0x3d, 0xe8, 0x70, 0x22, 0xa4, 0x22, 0xeb, 0x11, 0xf4, 0xf5, 0xe7, 0xb6,
+	0xc5, 0xd4, 0x0c, 0xf6, 0xf7, 0xe1, 0xec, 0x5b, 0x93, 0x51, 0xc3, 0x39, 0x21, 0xc7, 0x4e, 0x54,
+	0x26, 0xe4, 0x18, 0x6d, 0x42, 0x7d, 0x86, 0xd3, 0x82, 0xf8, 0x2b, 0x1a, 0x33, 0x9b, 0x5b, 0x2b,
+	0x37, 0xbd, 0xe0, 0x7b, 0x58, 0x77, 0xf5, 0x89, 0x9c, 0x65, 0x82, 0xa0, 0xc7, 0xd0, 0xb0, 0xa3,
+	0xa6, 0x3d, 0xb4, 0x77, 0xf7, 0xaa, 0x36, 0xca, 0x8e, 0xe0, 0xa1, 0xc4, 0x92, 0x84, 0xce, 0x49,
+	0xd0, 0x85, 0xf6, 0x33, 0x4c, 0xa5, 0xed, 0x5f, 0xf0, 0x1d, 0x74, 0xcc, 0xf6, 0x3f, 0x0a, 0xf7,
+	0x10, 0x36, 0x0e, 0xc7, 0x85, 0x4c, 0xd8, 0xab, 0xcc, 0x89, 0xec, 0x16, 0xac, 0x09, 0x3a, 0xca,
+	0x70, 0x6a, 0x5b, 0x62, 0x77, 0xaa, 0xc7, 0x23, 0x8e, 0x63, 0x12, 0xe5, 0x84, 0x53, 0x96, 0xe8,
+	0xe6, 0xd4, 0xc2, 0xb6, 0xc6, 0x0e, 0x34, 0x14, 0x20, 0xe8, 0x9d, 0x78, 0x33, 0x19, 0x07, 0x63,
+	0xd8, 0xfa, 0x26, 0x4f, 0x54, 0xd0, 0x52, 0x5b, 0x6d, 0xa0, 0x05, 0x9d, 0xf6, 0xfe, 0xb5, 0x4e,
+	0x07, 0xe7, 0xe1, 0xdc, 0x1b, 0x91, 0x6c, 0x12, 0x3d, 0x58, 0x7f, 0x4a, 0xb8, 0xa0, 0xcc, 0x55,
+	0x19, 0x7c, 0x04, 0x1b, 0x25, 0x62, 0x7b, 0xeb, 0x43, 0x63, 0x66, 0x20, 0x5b, 0xb9, 0xdb, 0x06,
+	0x1f, 0x42, 0x47, 0xf5, 0xad, 0xcc, 0xbc, 0x0f, 0x4d, 0x9a, 0x49, 0xc2, 0x67, 0xb6, 0x49, 0xb5,
+	0xb0, 0xdc, 0x07, 0xcf, 0xa0, 0x6b, 0x6d, 0xad, 0xdb, 0x2f, 0xa1, 0x2e, 0x14, 0xb0, 0x64, 0x89,
+	0x4f, 0xb0, 0x98, 0x18, 0x47, 0x86, 0x1e, 0x5c, 0x84, 0xee, 0xa1, 0x3e, 0x89, 0xb7, 0x1f, 0x54,
+	0xdd, 0x1d, 0x94, 0x2a, 0xd6, 0x19, 0xda, 0xf2, 0x27, 0xd0, 0xbe, 0x77, 0x44, 0x62, 0x47, 0xbc,
+	0x0e, 0xcd, 0x84, 0xe0, 0x24, 0xa5, 0x19, 0xb1, 0x49, 0xf5, 0x87, 0xe6, 0xc1, 0x1e, 0xba, 0x07,
+	0x7b, 0xf8, 0xc4, 0x3d, 0xd8, 0x61, 0x69, 0xeb, 0x9e, 0xdf, 0x95, 0x37, 0x9f, 0xdf, 0xda, 0xc9,
+	0xf3, 0x1b, 0xec, 0x43, 0xc7, 0x04, 0xb3, 0xf5, 0x6f, 0xc1, 0x1a, 0x2b, 0x64, 0x5e, 0x48, 0x1d,
+	0xab, 0x13, 0xda, 0x1d, 0x7a, 0x07, 0x5a, 0xe4, 0x88, 0xca, 0x28, 0x56, 0x32, 0xb9, 0xa2, 0x2b,
+	0x68, 0x2a, 0x60, 0x9f, 0x25, 0x24, 0xf8, 0xc3, 0x83, 0xce, 0xfc, 0x8d, 0x55, 0xb1, 0x73, 0x9a,
+	0xd8, 0x4a, 0xd5, 0xf2, 0x6f, 0xf9, 0x73, 0xbd, 0xa9, 0xcd, 0xf7, 0x06, 0x0d, 0x61, 0x55, 0xfd,
+	0x15, 0xf1, 0x57, 0xff, 0xb1, 0x6c, 0x6d, 0xa7, 0x34, 0x58, 0xe9, 0xd2, 0x84, 0xa6, 0x29, 0x49,
+	0xf4, 0xcb, 0xde, 0x0c, 0x5b, 0x8c, 0x4d, 0x1f, 0x68, 0x20, 0xb8, 0x08, 0x67, 0xf6, 0xc7, 0x24,
+	0x9e, 0xe4, 0x8c, 0x66, 0x6e, 0x66, 0x55, 0x53, 0xb4, 0x08, 0x99, 0x4b, 0xa4, 0xd7, 0xc1, 0x26,
+	0xa0, 0x79, 0x43, 0xd3, 0x9a, 0xdd, 0xdf, 0x01, 0x9a, 0xf7, 0xec, 0x98, 0xa2, 0x63, 0x58, 0x33,
+	0xda, 0x82, 0xae, 0x9d, 0x4a, 0x6b, 0xfb, 0xd7, 0x97, 0xa5, 0xd9, 0xdb, 0xf1, 0x3f, 0x24, 0x60,
+	0x55, 0xa9, 0x0c, 0xba, 0x5a, 0xd5, 0xc3, 0x9c, 0x44, 0xf5, 0xf7, 0x96, 0x23, 0x95, 0x41, 0x7f,
+	0x82, 0xa6, 0x13, 0x0b, 0x74, 0xa3, 0xaa, 0x8f, 0xd7, 0xc4, 0xaa, 0x7f, 0x73, 0x79, 0x62, 0x99,
+	0xc0, 0xaf, 0x1e, 0x6c, 0xbc, 0x26, 0x18, 0xe8, 0xb3, 0xaa, 0xfe, 0xde, 0xae, 0x69, 0xfd, 0xdb,
+	0xa7, 0xe6, 0x97, 0x69, 0xfd, 0x08, 0x0d, 0xab, 0x4c, 0xa8, 0xf2, 0x89, 0x2e, 0x8a, 0x5b, 0xff,
+	0xc6, 0xd2, 0xbc, 0x32, 0xfa, 0x11, 0xd4, 0xb5, 0xea, 0xa0, 0xca, 0xc7, 0x3a, 0xaf, 0x8c, 0xfd,
+	0x6b, 0x4b, 0xb2, 0x5c, 0xdc, 0xcb, 0x9e, 0xba, 0xff, 0x46, 0xb6, 0xaa, 0xdf, 0xff, 0x05, 0x3d,
+	0xec, 0x5f, 0x5f, 0x96, 0x36, 0x7f, 0xff, 0xd5, 0x18, 0x56, 0xbf, 0xff, 0x73, 0x6a, 0xda, 0xdf,
+	0x5b, 0x8e, 0x54, 0x06, 0xfd, 0xcd, 0x83, 0xae, 0x82, 0x0e, 0x25, 0x27, 0x78, 0x4a, 0xb3, 0x11,
+	0xba, 0x5d, 0xf1, 0x69, 0x50, 0x2c, 0xf3, 0x3c, 0x58, 0xa6, 0x4b, 0xe5, 0xf3, 0xd3, 0x3b, 0x70,
+	0x69, 0x0d, 0xbc, 0xcb, 0x1e, 0xfa, 0xd9, 0x03, 0x38, 0x91, 0x2b, 0xf4, 0x49, 0xd5, 0x0a, 0xdf,
+	0xd0, 0xc2, 0xfe, 0xad, 0xd3, 0x50, 0x5d, 0x2e, 0x77, 0x1a, 0xdf, 0xd6, 0x8d, 0x32, 0xaf, 0xe9,
+	0x9f, 0xab, 0x7f, 0x0e, 0x00, 0xa2, 0x21, 0x7d, 0x76, 0xae, 0x0e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1017,6 +1100,7 @@ type ExecutorClient interface {
 	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error)
 	// buf:lint:ignore RPC_REQUEST_RESPONSE_UNIQUE
 	ExecStreaming(ctx context.Context, opts ...grpc.CallOption) (Executor_ExecStreamingClient, error)
+	Checkpoint(ctx context.Context, in *CheckpointRequest, opts ...grpc.CallOption) (*CheckpointResponse, error)
 }
 
 type executorClient struct {
@@ -1153,6 +1237,15 @@ func (x *executorExecStreamingClient) Recv() (*proto1.ExecTaskStreamingResponse,
 	return m, nil
 }
 
+func (c *executorClient) Checkpoint(ctx context.Context, in *CheckpointRequest, opts ...grpc.CallOption) (*CheckpointResponse, error) {
+	out := new(CheckpointResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.executor.proto.Executor/Checkpoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ExecutorServer is the server API for Executor service.
 type ExecutorServer interface {
 	Launch(context.Context, *LaunchRequest) (*LaunchResponse, error)
@@ -1165,6 +1258,7 @@ type ExecutorServer interface {
 	Exec(context.Context, *ExecRequest) (*ExecResponse, error)
 	// buf:lint:ignore RPC_REQUEST_RESPONSE_UNIQUE
 	ExecStreaming(Executor_ExecStreamingServer) error
+	Checkpoint(context.Context, *CheckpointRequest) (*CheckpointResponse, error)
 }
 
 // UnimplementedExecutorServer can be embedded to have forward compatible implementations.
@@ -1198,6 +1292,9 @@ func (*UnimplementedExecutorServer) Exec(ctx context.Context, req *ExecRequest)
 func (*UnimplementedExecutorServer) ExecStreaming(srv Executor_ExecStreamingServer) error {
 	return status.Errorf(codes.Unimplemented, "method ExecStreaming not implemented")
 }
+func (*UnimplementedExecutorServer) Checkpoint(ctx context.Context, req *CheckpointRequest) (*CheckpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Checkpoint not implemented")
+}
 
 func RegisterExecutorServer(s *grpc.Server, srv ExecutorServer) {
 	s.RegisterService(&_Executor_serviceDesc, srv)
@@ -1376,6 +1473,24 @@ func (x *executorExecStreamingServer) Recv() (*proto1.ExecTaskStreamingRequest,
 	return m, nil
 }
 
+func _Executor_Checkpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorServer).Checkpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.executor.proto.Executor/Checkpoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorServer).Checkpoint(ctx, req.(*CheckpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Executor_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hashicorp.nomad.plugins.executor.proto.Executor",
 	HandlerType: (*ExecutorServer)(nil),
@@ -1408,6 +1523,10 @@ var _Executor_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Exec",
 			Handler:    _Executor_Exec_Handler,
 		},
+		{
+			MethodName: "Checkpoint",
+			Handler:    _Executor_Checkpoint_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,167 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ociimage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"runtime"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Options configures how images are fetched.
+type Options struct {
+	// InsecureRegistries are the registries accessed over plain HTTP
+	InsecureRegistries []string
+
+	// HTTPClient is the client used to access registries. It defaults to
+	// http.DefaultClient.
+	HTTPClient *http.Client
+
+	// ProtectedPaths are paths of the root filesystem, relative to it, that
+	// layers can't change. Entries of layers at or below them are skipped,
+	// including whiteouts, and opaque whiteouts of their parents keep them.
+	ProtectedPaths []string
+}
+
+// Config is the execution configuration of an unpacked image.
+type Config struct {
+	// Digest is the digest of the image's manifest
+	Digest string
+
+	Entrypoint []string
+	Cmd        []string
+	Env        []string
+	WorkingDir string
+	User       string
+}
+
+// Unpack fetches the image and applies its layers to the root filesystem at
+// dest. The image of the client's platform is selected from multi-platform
+// images.
+func Unpack(ctx context.Context, ref *Reference, dest string, opts *Options) (*Config, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	var src source
+	switch ref.Transport {
+	case TransportOCI:
+		layout, err := newLayoutSource(ref.Path)
+		if err != nil {
+			return nil, err
+		}
+		src = layout
+	case TransportDocker:
+		src = newRegistrySource(ref, opts)
+	default:
+		return nil, fmt.Errorf("unsupported image transport %q", ref.Transport)
+	}
+
+	buf, mediaType, err := src.manifest(ctx, ref.reference())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch manifest of %s: %w", ref, err)
+	}
+
+	if mediaType == v1.MediaTypeImageIndex || mediaType == mediaTypeDockerManifestList {
+		desc, err := selectPlatform(buf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select image of %s: %w", ref, err)
+		}
+		if buf, mediaType, err = src.manifest(ctx, string(desc.Digest)); err != nil {
+			return nil, fmt.Errorf("failed to fetch manifest of %s: %w", ref, err)
+		}
+	}
+	if mediaType != v1.MediaTypeImageManifest && mediaType != mediaTypeDockerManifest {
+		return nil, fmt.Errorf("unsupported manifest media type %q", mediaType)
+	}
+
+	var manifest v1.Manifest
+	if err := json.Unmarshal(buf, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+	sum := sha256.Sum256(buf)
+
+	configBlob, err := src.blob(ctx, string(manifest.Config.Digest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch image configuration: %w", err)
+	}
+	configBuf, err := readVerified(configBlob, string(manifest.Config.Digest))
+	configBlob.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image configuration: %w", err)
+	}
+	var image v1.Image
+	if err := json.Unmarshal(configBuf, &image); err != nil {
+		return nil, fmt.Errorf("failed to decode image configuration: %w", err)
+	}
+
+	protected := make([]string, 0, len(opts.ProtectedPaths))
+	for _, path := range opts.ProtectedPaths {
+		protected = append(protected, filepath.Join(dest, path))
+	}
+
+	for _, layer := range manifest.Layers {
+		if err := unpackLayer(ctx, src, layer, dest, protected); err != nil {
+			return nil, fmt.Errorf("failed to unpack layer %s: %w", layer.Digest, err)
+		}
+	}
+
+	return &Config{
+		Digest:     "sha256:" + hex.EncodeToString(sum[:]),
+		Entrypoint: image.Config.Entrypoint,
+		Cmd:        image.Config.Cmd,
+		Env:        image.Config.Env,
+		WorkingDir: image.Config.WorkingDir,
+		User:       image.Config.User,
+	}, nil
+}
+
+// selectPlatform returns the descriptor of the index's image for the client's
+// platform.
+func selectPlatform(buf []byte) (*v1.Descriptor, error) {
+	var index v1.Index
+	if err := json.Unmarshal(buf, &index); err != nil {
+		return nil, fmt.Errorf("failed to decode index: %w", err)
+	}
+
+	for _, desc := range index.Manifests {
+		if desc.Platform == nil {
+			continue
+		}
+		if desc.Platform.OS == runtime.GOOS && desc.Platform.Architecture == runtime.GOARCH {
+			return &desc, nil
+		}
+	}
+
+	// Indexes of OCI image layouts may list a single image without platform
+	if len(index.Manifests) == 1 && index.Manifests[0].Platform == nil {
+		return &index.Manifests[0], nil
+	}
+	return nil, fmt.Errorf("no image for platform %s/%s", runtime.GOOS, runtime.GOARCH)
+}
+
+// unpackLayer fetches the layer, checks its digest and applies it to dest.
+func unpackLayer(ctx context.Context, src source, layer v1.Descriptor, dest string, protected []string) error {
+	blob, err := src.blob(ctx, string(layer.Digest))
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+
+	v, err := newVerifier(blob, string(layer.Digest))
+	if err != nil {
+		return err
+	}
+	if err := applyLayer(v, layer.MediaType, dest, protected); err != nil {
+		return err
+	}
+	return v.verify()
+}
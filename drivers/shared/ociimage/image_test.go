@@ -0,0 +1,328 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ociimage
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/shoenig/test/must"
+)
+
+// tarEntry is an entry of a test layer
+type tarEntry struct {
+	name     string
+	typ      byte
+	content  string
+	linkname string
+}
+
+// testImage is an image whose blobs are kept in memory
+type testImage struct {
+	blobs    map[string][]byte
+	manifest v1.Descriptor
+	index    v1.Descriptor
+}
+
+func (i *testImage) addBlob(mediaType string, data []byte) v1.Descriptor {
+	sum := sha256.Sum256(data)
+	d := "sha256:" + hex.EncodeToString(sum[:])
+	i.blobs[d] = data
+	return v1.Descriptor{MediaType: mediaType, Digest: digest.Digest(d), Size: int64(len(data))}
+}
+
+func buildLayer(t *testing.T, entries []tarEntry) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Typeflag: e.typ, Mode: 0644, Linkname: e.linkname}
+		if e.typ == tar.TypeDir {
+			hdr.Mode = 0755
+		}
+		hdr.Size = int64(len(e.content))
+		must.NoError(t, tw.WriteHeader(hdr))
+		_, err := tw.Write([]byte(e.content))
+		must.NoError(t, err)
+	}
+	must.NoError(t, tw.Close())
+	must.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// newTestImage returns a two layer image of the client's platform in a
+// multi-platform index.
+func newTestImage(t *testing.T) *testImage {
+	img := &testImage{blobs: map[string][]byte{}}
+
+	layer1 := img.addBlob(v1.MediaTypeImageLayerGzip, buildLayer(t, []tarEntry{
+		{name: "bin/", typ: tar.TypeDir},
+		{name: "bin/app", typ: tar.TypeReg, content: "#!/bin/sh\necho app\n"},
+		{name: "etc/", typ: tar.TypeDir},
+		{name: "etc/conf", typ: tar.TypeReg, content: "old"},
+		{name: "data/", typ: tar.TypeDir},
+		{name: "data/old", typ: tar.TypeReg, content: "old"},
+		{name: "link", typ: tar.TypeSymlink, linkname: "/etc"},
+	}))
+	layer2 := img.addBlob(v1.MediaTypeImageLayerGzip, buildLayer(t, []tarEntry{
+		{name: "etc/.wh.conf", typ: tar.TypeReg},
+		{name: "data/new", typ: tar.TypeReg, content: "new"},
+		{name: "data/.wh..wh..opq", typ: tar.TypeReg},
+		{name: "link/escaped", typ: tar.TypeReg, content: "inside"},
+		{name: "../../outside", typ: tar.TypeReg, content: "inside"},
+		{name: "bin/app-link", typ: tar.TypeLink, linkname: "bin/app"},
+	}))
+
+	config, _ := json.Marshal(&v1.Image{
+		Platform: v1.Platform{OS: runtime.GOOS, Architecture: runtime.GOARCH},
+		Config: v1.ImageConfig{
+			Entrypoint: []string{"/bin/app"},
+			Cmd:        []string{"serve"},
+			Env:        []string{"PATH=/bin"},
+			WorkingDir: "/data",
+		},
+	})
+	configDesc := img.addBlob(v1.MediaTypeImageConfig, config)
+
+	manifest, _ := json.Marshal(&v1.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: v1.MediaTypeImageManifest,
+		Config:    configDesc,
+		Layers:    []v1.Descriptor{layer1, layer2},
+	})
+	img.manifest = img.addBlob(v1.MediaTypeImageManifest, manifest)
+	img.manifest.Platform = &v1.Platform{OS: runtime.GOOS, Architecture: runtime.GOARCH}
+
+	other := v1.Descriptor{
+		MediaType: v1.MediaTypeImageManifest,
+		Digest:    digest.Digest("sha256:" + strings.Repeat("0", 64)),
+		Platform:  &v1.Platform{OS: "plan9", Architecture: "mips"},
+	}
+	index, _ := json.Marshal(&v1.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: v1.MediaTypeImageIndex,
+		Manifests: []v1.Descriptor{other, img.manifest},
+	})
+	img.index = img.addBlob(v1.MediaTypeImageIndex, index)
+	return img
+}
+
+// writeLayout writes the image to an OCI image layout with the ref name.
+func (i *testImage) writeLayout(t *testing.T, ref string) string {
+	dir := t.TempDir()
+	blobDir := filepath.Join(dir, "blobs", "sha256")
+	must.NoError(t, os.MkdirAll(blobDir, 0755))
+	for d, data := range i.blobs {
+		must.NoError(t, os.WriteFile(filepath.Join(blobDir, strings.TrimPrefix(d, "sha256:")), data, 0644))
+	}
+
+	layout, _ := json.Marshal(&v1.ImageLayout{Version: v1.ImageLayoutVersion})
+	must.NoError(t, os.WriteFile(filepath.Join(dir, v1.ImageLayoutFile), layout, 0644))
+
+	desc := i.index
+	desc.Annotations = map[string]string{v1.AnnotationRefName: ref}
+	index, _ := json.Marshal(&v1.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		Manifests: []v1.Descriptor{desc},
+	})
+	must.NoError(t, os.WriteFile(filepath.Join(dir, v1.ImageIndexFile), index, 0644))
+	return dir
+}
+
+func requireUnpacked(t *testing.T, dest string, cfg *Config) {
+	must.Eq(t, []string{"/bin/app"}, cfg.Entrypoint)
+	must.Eq(t, []string{"serve"}, cfg.Cmd)
+	must.Eq(t, "/data", cfg.WorkingDir)
+
+	buf, err := os.ReadFile(filepath.Join(dest, "bin", "app-link"))
+	must.NoError(t, err)
+	must.Eq(t, "#!/bin/sh\necho app\n", string(buf))
+
+	// Whiteouts removed the content of the lower layer
+	must.FileNotExists(t, filepath.Join(dest, "etc", "conf"))
+	must.FileNotExists(t, filepath.Join(dest, "data", "old"))
+	must.FileExists(t, filepath.Join(dest, "data", "new"))
+
+	// Paths do not escape the root filesystem
+	buf, err = os.ReadFile(filepath.Join(dest, "etc", "escaped"))
+	must.NoError(t, err)
+	must.Eq(t, "inside", string(buf))
+	must.FileExists(t, filepath.Join(dest, "outside"))
+}
+
+func TestUnpack_Layout(t *testing.T) {
+	ci.Parallel(t)
+
+	img := newTestImage(t)
+	layout := img.writeLayout(t, "v1")
+
+	ref, err := ParseReference("oci:" + layout + ":v1")
+	must.NoError(t, err)
+
+	dest := t.TempDir()
+	cfg, err := Unpack(context.Background(), ref, dest, nil)
+	must.NoError(t, err)
+	must.Eq(t, string(img.manifest.Digest), cfg.Digest)
+	requireUnpacked(t, dest, cfg)
+
+	// Unknown ref names are errors
+	ref.Tag = "v2"
+	_, err = Unpack(context.Background(), ref, t.TempDir(), nil)
+	must.ErrorContains(t, err, `no image named "v2"`)
+}
+
+func TestUnpack_Registry(t *testing.T) {
+	ci.Parallel(t)
+
+	img := newTestImage(t)
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			if r.URL.Query().Get("scope") != "repository:team/app:pull" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`{"token":"secret"}`))
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.Header().Set("WWW-Authenticate",
+				`Bearer realm="`+srv.URL+`/token",service="test",scope="repository:team/app:pull"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		prefix := "/v2/team/app/"
+		if !strings.HasPrefix(r.URL.Path, prefix) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		kind, ref, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, prefix), "/")
+		switch {
+		case kind == "manifests" && ref == "latest":
+			w.Header().Set("Content-Type", v1.MediaTypeImageIndex)
+			w.Write(img.blobs[string(img.index.Digest)])
+		case kind == "manifests" || kind == "blobs":
+			data, ok := img.blobs[ref]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(data)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	host := strings.TrimPrefix(srv.URL, "http://")
+	ref, err := ParseReference("docker://" + host + "/team/app")
+	must.NoError(t, err)
+
+	// Registries are accessed over HTTPS unless allowed otherwise
+	_, err = Unpack(context.Background(), ref, t.TempDir(), nil)
+	must.Error(t, err)
+
+	dest := t.TempDir()
+	cfg, err := Unpack(context.Background(), ref, dest, &Options{InsecureRegistries: []string{host}})
+	must.NoError(t, err)
+	requireUnpacked(t, dest, cfg)
+
+	// Corrupted blobs are detected
+	for d, data := range img.blobs {
+		if d != string(img.index.Digest) && d != string(img.manifest.Digest) && len(data) > 0 && data[0] == 0x1f {
+			img.blobs[d] = append(append([]byte(nil), data...), 0)
+		}
+	}
+	_, err = Unpack(context.Background(), ref, t.TempDir(), &Options{InsecureRegistries: []string{host}})
+	must.ErrorContains(t, err, "digest mismatch")
+}
+
+func TestParseChallenge(t *testing.T) {
+	ci.Parallel(t)
+
+	scheme, params := parseChallenge(`Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/redis:pull"`)
+	must.Eq(t, "Bearer", scheme)
+	must.Eq(t, map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:library/redis:pull",
+	}, params)
+}
+
+func TestApplyLayer_ProtectedPaths(t *testing.T) {
+	ci.Parallel(t)
+
+	dest := t.TempDir()
+	for path, content := range map[string]string{
+		"alloc/logs/web.stdout.0": "logs",
+		"secrets/nomad_token":     "token",
+		"local/conf":              "conf",
+		"private/key":             "key",
+		"lower":                   "lower",
+	} {
+		must.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dest, path)), 0755))
+		must.NoError(t, os.WriteFile(filepath.Join(dest, path), []byte(content), 0644))
+	}
+
+	layer := buildLayer(t, []tarEntry{
+		{name: ".wh..wh..opq", typ: tar.TypeReg},
+		{name: ".wh.secrets", typ: tar.TypeReg},
+		{name: "alloc/.wh..wh..opq", typ: tar.TypeReg},
+		{name: "alloc/logs/.wh.web.stdout.0", typ: tar.TypeReg},
+		{name: "alloc/logs/injected", typ: tar.TypeReg, content: "injected"},
+		{name: "secrets/nomad_token", typ: tar.TypeReg, content: "replaced"},
+		{name: "local", typ: tar.TypeSymlink, linkname: "/etc"},
+		{name: "escape", typ: tar.TypeSymlink, linkname: "/private"},
+		{name: "escape/key", typ: tar.TypeReg, content: "replaced"},
+		{name: "token", typ: tar.TypeLink, linkname: "secrets/nomad_token"},
+		{name: "bin/", typ: tar.TypeDir},
+		{name: "bin/app", typ: tar.TypeReg, content: "app"},
+	})
+	protected := []string{
+		filepath.Join(dest, "alloc"),
+		filepath.Join(dest, "local"),
+		filepath.Join(dest, "secrets"),
+		filepath.Join(dest, "private"),
+	}
+	must.NoError(t, applyLayer(bytes.NewReader(layer), v1.MediaTypeImageLayerGzip, dest, protected))
+
+	// The protected paths are left untouched
+	for path, content := range map[string]string{
+		"alloc/logs/web.stdout.0": "logs",
+		"secrets/nomad_token":     "token",
+		"local/conf":              "conf",
+		"private/key":             "key",
+	} {
+		buf, err := os.ReadFile(filepath.Join(dest, path))
+		must.NoError(t, err)
+		must.Eq(t, content, string(buf))
+	}
+	must.FileNotExists(t, filepath.Join(dest, "alloc", "logs", "injected"))
+	must.FileNotExists(t, filepath.Join(dest, "token"))
+	info, err := os.Lstat(filepath.Join(dest, "local"))
+	must.NoError(t, err)
+	must.True(t, info.IsDir())
+
+	// Other entries of the layer are still applied
+	must.FileNotExists(t, filepath.Join(dest, "lower"))
+	must.FileExists(t, filepath.Join(dest, "bin", "app"))
+}
@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package ociimage fetches OCI images from OCI image layouts and registries
+// and unpacks their layers into a root filesystem.
+package ociimage
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// TransportOCI is the transport of images stored in a local OCI image
+	// layout directory, referenced as oci:<path>[:<ref>]
+	TransportOCI = "oci"
+
+	// TransportDocker is the transport of images stored in a registry,
+	// referenced as docker://[<registry>/]<repository>[:<tag>|@<digest>]
+	TransportDocker = "docker"
+
+	// defaultRegistry is the registry of references without one
+	defaultRegistry = "docker.io"
+
+	// defaultTag is the tag of registry references without a tag or digest
+	defaultTag = "latest"
+)
+
+var (
+	repositoryRegex = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+	tagRegex        = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+	digestRegex     = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+)
+
+// Reference locates an image. The syntax follows the image transports of
+// Podman and Skopeo.
+type Reference struct {
+	// Transport is TransportOCI or TransportDocker
+	Transport string
+
+	// Path is the directory of the OCI image layout of TransportOCI images
+	Path string
+
+	// Registry and Repository locate TransportDocker images
+	Registry   string
+	Repository string
+
+	// Tag is the tag of a registry image or the ref name annotation of an
+	// image in an OCI image layout. Digest is the digest of the image's
+	// manifest. At most one of them is set.
+	Tag    string
+	Digest string
+}
+
+// ParseReference parses an image reference.
+func ParseReference(s string) (*Reference, error) {
+	switch {
+	case strings.HasPrefix(s, TransportOCI+":"):
+		return parseLayoutReference(strings.TrimPrefix(s, TransportOCI+":"))
+	case strings.HasPrefix(s, TransportDocker+"://"):
+		return parseRegistryReference(strings.TrimPrefix(s, TransportDocker+"://"))
+	default:
+		return nil, fmt.Errorf("image reference %q must start with %q or %q",
+			s, TransportOCI+":", TransportDocker+"://")
+	}
+}
+
+func parseLayoutReference(s string) (*Reference, error) {
+	ref := &Reference{Transport: TransportOCI, Path: s}
+
+	// The ref name follows the last colon, unless the colon is part of the
+	// path
+	if i := strings.LastIndex(s, ":"); i >= 0 && !strings.Contains(s[i+1:], "/") {
+		ref.Path = s[:i]
+		name := s[i+1:]
+		if digestRegex.MatchString(name) {
+			ref.Digest = name
+		} else {
+			ref.Tag = name
+		}
+	}
+
+	if ref.Path == "" {
+		return nil, fmt.Errorf("image reference %q has no path", TransportOCI+":"+s)
+	}
+	return ref, nil
+}
+
+func parseRegistryReference(s string) (*Reference, error) {
+	ref := &Reference{Transport: TransportDocker}
+	name := s
+
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.Digest = name[i+1:]
+		name = name[:i]
+		if !digestRegex.MatchString(ref.Digest) {
+			return nil, fmt.Errorf("invalid digest %q in image reference", ref.Digest)
+		}
+	}
+	if i := strings.LastIndex(name, ":"); i >= 0 && !strings.Contains(name[i+1:], "/") {
+		if ref.Digest != "" {
+			return nil, fmt.Errorf("image reference %q must not have both a tag and a digest", s)
+		}
+		ref.Tag = name[i+1:]
+		name = name[:i]
+		if !tagRegex.MatchString(ref.Tag) {
+			return nil, fmt.Errorf("invalid tag %q in image reference", ref.Tag)
+		}
+	}
+
+	// The first component is the registry if it looks like a host name
+	ref.Registry = defaultRegistry
+	if i := strings.Index(name, "/"); i >= 0 {
+		host := name[:i]
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			ref.Registry = host
+			name = name[i+1:]
+		}
+	}
+	if ref.Registry == defaultRegistry && !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+
+	if !repositoryRegex.MatchString(name) {
+		return nil, fmt.Errorf("invalid repository %q in image reference", name)
+	}
+	ref.Repository = name
+
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = defaultTag
+	}
+	return ref, nil
+}
+
+// reference returns the digest of the reference if set, its tag otherwise.
+func (r *Reference) reference() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	return r.Tag
+}
+
+func (r *Reference) String() string {
+	switch r.Transport {
+	case TransportOCI:
+		if ref := r.reference(); ref != "" {
+			return fmt.Sprintf("%s:%s:%s", TransportOCI, r.Path, ref)
+		}
+		return fmt.Sprintf("%s:%s", TransportOCI, r.Path)
+	default:
+		if r.Digest != "" {
+			return fmt.Sprintf("%s://%s/%s@%s", TransportDocker, r.Registry, r.Repository, r.Digest)
+		}
+		return fmt.Sprintf("%s://%s/%s:%s", TransportDocker, r.Registry, r.Repository, r.Tag)
+	}
+}
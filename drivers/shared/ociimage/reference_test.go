@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ociimage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestParseReference(t *testing.T) {
+	ci.Parallel(t)
+
+	digest := "sha256:" + "ab12ab12ab12ab12ab12ab12ab12ab12ab12ab12ab12ab12ab12ab12ab12ab12"
+
+	cases := []struct {
+		ref    string
+		exp    *Reference
+		expErr string
+	}{
+		{
+			ref: "oci:local/image",
+			exp: &Reference{Transport: TransportOCI, Path: "local/image"},
+		},
+		{
+			ref: "oci:/var/lib/images/app:v1.2",
+			exp: &Reference{Transport: TransportOCI, Path: "/var/lib/images/app", Tag: "v1.2"},
+		},
+		{
+			ref: "docker://redis",
+			exp: &Reference{Transport: TransportDocker, Registry: "docker.io", Repository: "library/redis", Tag: "latest"},
+		},
+		{
+			ref: "docker://localhost:5000/team/app:1.0",
+			exp: &Reference{Transport: TransportDocker, Registry: "localhost:5000", Repository: "team/app", Tag: "1.0"},
+		},
+		{
+			ref: "docker://ghcr.io/org/app@" + digest,
+			exp: &Reference{Transport: TransportDocker, Registry: "ghcr.io", Repository: "org/app", Digest: digest},
+		},
+		{
+			ref:    "redis:7",
+			expErr: "must start with",
+		},
+		{
+			ref:    "docker://Redis",
+			expErr: "invalid repository",
+		},
+		{
+			ref:    "docker://redis@sha256:abc",
+			expErr: "invalid digest",
+		},
+		{
+			ref:    "oci::v1",
+			expErr: "has no path",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.ref, func(t *testing.T) {
+			ref, err := ParseReference(tc.ref)
+			if tc.expErr != "" {
+				must.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.exp, ref)
+		})
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ociimage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// The Docker media types registries may serve instead of the OCI ones
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeDockerLayerGzip    = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+
+	// dockerHubRegistry is the API host of the default registry
+	dockerHubRegistry = "registry-1.docker.io"
+)
+
+// manifestMediaTypes are the accepted manifest media types
+var manifestMediaTypes = []string{
+	v1.MediaTypeImageIndex,
+	v1.MediaTypeImageManifest,
+	mediaTypeDockerManifestList,
+	mediaTypeDockerManifest,
+}
+
+// registrySource is a repository of a registry implementing the OCI
+// distribution API, see
+// https://github.com/opencontainers/distribution-spec/blob/main/spec.md
+type registrySource struct {
+	client     *http.Client
+	baseURL    string
+	repository string
+
+	// token is the bearer token obtained from the registry's token service
+	token     string
+	tokenLock sync.Mutex
+}
+
+func newRegistrySource(ref *Reference, opts *Options) *registrySource {
+	host := ref.Registry
+	if host == defaultRegistry {
+		host = dockerHubRegistry
+	}
+
+	scheme := "https"
+	for _, r := range opts.InsecureRegistries {
+		if r == ref.Registry {
+			scheme = "http"
+		}
+	}
+
+	client := opts.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &registrySource{
+		client:     client,
+		baseURL:    fmt.Sprintf("%s://%s/v2/%s", scheme, host, ref.Repository),
+		repository: ref.Repository,
+	}
+}
+
+func (s *registrySource) manifest(ctx context.Context, ref string) ([]byte, string, error) {
+	resp, err := s.get(ctx, "/manifests/"+ref, strings.Join(manifestMediaTypes, ", "))
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	var buf []byte
+	if digestRegex.MatchString(ref) {
+		buf, err = readVerified(resp.Body, ref)
+	} else {
+		buf, err = io.ReadAll(io.LimitReader(resp.Body, maxManifestSize+1))
+		if err == nil && len(buf) > maxManifestSize {
+			err = errors.New("manifest exceeds the maximum size")
+		}
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch mediaType {
+	case v1.MediaTypeImageIndex, v1.MediaTypeImageManifest, mediaTypeDockerManifestList, mediaTypeDockerManifest:
+	default:
+		mediaType = detectMediaType(buf)
+	}
+	return buf, mediaType, nil
+}
+
+func (s *registrySource) blob(ctx context.Context, digest string) (io.ReadCloser, error) {
+	resp, err := s.get(ctx, "/blobs/"+digest, "")
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// get requests the path of the repository's API, authenticating with the
+// registry's token service if the registry requires it.
+func (s *registrySource) get(ctx context.Context, path, accept string) (*http.Response, error) {
+	resp, err := s.do(ctx, path, accept)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if err := s.authenticate(ctx, challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = s.do(ctx, path, accept); err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected response code %d from %s", resp.StatusCode, s.baseURL+path)
+	}
+	return resp, nil
+}
+
+func (s *registrySource) do(ctx context.Context, path, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	s.tokenLock.Lock()
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	s.tokenLock.Unlock()
+
+	return s.client.Do(req)
+}
+
+// authenticate obtains an anonymous bearer token from the token service of
+// the challenge, see https://distribution.github.io/distribution/spec/auth/token/
+func (s *registrySource) authenticate(ctx context.Context, challenge string) error {
+	scheme, params := parseChallenge(challenge)
+	if !strings.EqualFold(scheme, "bearer") || params["realm"] == "" {
+		return fmt.Errorf("registry requires unsupported authentication %q", challenge)
+	}
+
+	u, err := url.Parse(params["realm"])
+	if err != nil {
+		return fmt.Errorf("invalid token realm: %w", err)
+	}
+	q := u.Query()
+	if params["service"] != "" {
+		q.Set("service", params["service"])
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", s.repository)
+	}
+	q.Set("scope", scope)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request registry token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response code %d from token service", resp.StatusCode)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&token); err != nil {
+		return fmt.Errorf("failed to decode registry token: %w", err)
+	}
+
+	s.tokenLock.Lock()
+	defer s.tokenLock.Unlock()
+	s.token = token.Token
+	if s.token == "" {
+		s.token = token.AccessToken
+	}
+	if s.token == "" {
+		return errors.New("token service returned no token")
+	}
+	return nil
+}
+
+// parseChallenge parses a WWW-Authenticate header such as
+// Bearer realm="https://auth.docker.io/token",service="registry.docker.io"
+func parseChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := map[string]string{}
+
+	for rest = strings.TrimSpace(rest); rest != ""; {
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				break
+			}
+			params[key] = value[1 : end+1]
+			rest = value[end+2:]
+		} else {
+			value, rest, _ = strings.Cut(value, ",")
+			params[key] = strings.TrimSpace(value)
+		}
+		rest = strings.TrimLeft(rest, ", ")
+	}
+	return scheme, params
+}
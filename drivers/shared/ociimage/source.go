@@ -0,0 +1,194 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ociimage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// maxManifestSize is the maximum size of manifests, indexes and image
+	// configurations
+	maxManifestSize = 4 * 1024 * 1024
+)
+
+// source is a store of images.
+type source interface {
+	// manifest returns the manifest or index the reference, a tag or a
+	// digest, points to and its media type.
+	manifest(ctx context.Context, ref string) ([]byte, string, error)
+
+	// blob returns the content of the blob with the digest.
+	blob(ctx context.Context, digest string) (io.ReadCloser, error)
+}
+
+// layoutSource is an OCI image layout directory, see
+// https://github.com/opencontainers/image-spec/blob/main/image-layout.md
+type layoutSource struct {
+	path string
+}
+
+func newLayoutSource(path string) (*layoutSource, error) {
+	buf, err := os.ReadFile(filepath.Join(path, v1.ImageLayoutFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OCI image layout: %w", err)
+	}
+	var layout v1.ImageLayout
+	if err := json.Unmarshal(buf, &layout); err != nil {
+		return nil, fmt.Errorf("failed to decode OCI image layout: %w", err)
+	}
+	if layout.Version != v1.ImageLayoutVersion {
+		return nil, fmt.Errorf("unsupported OCI image layout version %q", layout.Version)
+	}
+	return &layoutSource{path: path}, nil
+}
+
+func (s *layoutSource) manifest(ctx context.Context, ref string) ([]byte, string, error) {
+	if !digestRegex.MatchString(ref) {
+		desc, err := s.lookup(ref)
+		if err != nil {
+			return nil, "", err
+		}
+		buf, err := s.readBlob(ctx, string(desc.Digest))
+		return buf, desc.MediaType, err
+	}
+
+	buf, err := s.readBlob(ctx, ref)
+	if err != nil {
+		return nil, "", err
+	}
+	return buf, detectMediaType(buf), nil
+}
+
+// lookup returns the descriptor of the index.json manifest with the ref name
+// annotation, or the only manifest if the ref is empty.
+func (s *layoutSource) lookup(ref string) (*v1.Descriptor, error) {
+	buf, err := os.ReadFile(filepath.Join(s.path, v1.ImageIndexFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OCI image index: %w", err)
+	}
+	var index v1.Index
+	if err := json.Unmarshal(buf, &index); err != nil {
+		return nil, fmt.Errorf("failed to decode OCI image index: %w", err)
+	}
+
+	if ref == "" {
+		if len(index.Manifests) != 1 {
+			return nil, fmt.Errorf("OCI image layout has %d images, a ref name must be set", len(index.Manifests))
+		}
+		return &index.Manifests[0], nil
+	}
+	for _, desc := range index.Manifests {
+		if desc.Annotations[v1.AnnotationRefName] == ref {
+			return &desc, nil
+		}
+	}
+	return nil, fmt.Errorf("OCI image layout has no image named %q", ref)
+}
+
+func (s *layoutSource) readBlob(ctx context.Context, digest string) ([]byte, error) {
+	r, err := s.blob(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return readVerified(r, digest)
+}
+
+func (s *layoutSource) blob(_ context.Context, digest string) (io.ReadCloser, error) {
+	if !digestRegex.MatchString(digest) {
+		return nil, fmt.Errorf("unsupported digest %q", digest)
+	}
+	algorithm, encoded, _ := strings.Cut(digest, ":")
+	f, err := os.Open(filepath.Join(s.path, v1.ImageBlobsDir, algorithm, encoded))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blob: %w", err)
+	}
+	return f, nil
+}
+
+// detectMediaType returns the media type set in a manifest or index.
+func detectMediaType(buf []byte) string {
+	var versioned struct {
+		MediaType string          `json:"mediaType"`
+		Manifests json.RawMessage `json:"manifests"`
+	}
+	if err := json.Unmarshal(buf, &versioned); err != nil {
+		return ""
+	}
+	if versioned.MediaType != "" {
+		return versioned.MediaType
+	}
+	if versioned.Manifests != nil {
+		return v1.MediaTypeImageIndex
+	}
+	return v1.MediaTypeImageManifest
+}
+
+// verifier hashes the content read from a blob so that it can be checked
+// against the blob's digest.
+type verifier struct {
+	r        io.Reader
+	hash     hash.Hash
+	expected string
+}
+
+func newVerifier(r io.Reader, digest string) (*verifier, error) {
+	if !digestRegex.MatchString(digest) {
+		return nil, fmt.Errorf("unsupported digest %q", digest)
+	}
+	return &verifier{
+		r:        r,
+		hash:     sha256.New(),
+		expected: strings.TrimPrefix(digest, "sha256:"),
+	}, nil
+}
+
+func (v *verifier) Read(p []byte) (int, error) {
+	n, err := v.r.Read(p)
+	v.hash.Write(p[:n])
+	return n, err
+}
+
+// verify reads the rest of the content and checks its digest.
+func (v *verifier) verify() error {
+	if _, err := io.Copy(io.Discard, v); err != nil {
+		return err
+	}
+	if actual := hex.EncodeToString(v.hash.Sum(nil)); actual != v.expected {
+		return fmt.Errorf("digest mismatch: expected sha256:%s, got sha256:%s", v.expected, actual)
+	}
+	return nil
+}
+
+// readVerified reads a manifest or configuration and checks its digest.
+func readVerified(r io.Reader, digest string) ([]byte, error) {
+	v, err := newVerifier(r, digest)
+	if err != nil {
+		return nil, err
+	}
+	buf, err := io.ReadAll(io.LimitReader(v, maxManifestSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) > maxManifestSize {
+		return nil, errors.New("content exceeds the maximum manifest size")
+	}
+	if err := v.verify(); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
@@ -0,0 +1,254 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ociimage
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/klauspost/compress/zstd"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// whiteoutPrefix marks files deleted by a layer, and whiteoutOpaque
+	// directories whose content of lower layers is deleted, see
+	// https://github.com/opencontainers/image-spec/blob/main/layer.md#whiteouts
+	whiteoutPrefix = ".wh."
+	whiteoutOpaque = ".wh..wh..opq"
+)
+
+// decompress returns the tar stream of a layer with the media type.
+func decompress(r io.Reader, mediaType string) (io.Reader, func(), error) {
+	switch mediaType {
+	case v1.MediaTypeImageLayer:
+		return r, func() {}, nil
+	case v1.MediaTypeImageLayerGzip, mediaTypeDockerLayerGzip:
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		return gz, func() { gz.Close() }, nil
+	case v1.MediaTypeImageLayerZstd:
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		return zr, zr.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported layer media type %q", mediaType)
+	}
+}
+
+// applyLayer extracts a layer to the root filesystem at dest, applying its
+// whiteouts to the content of lower layers. Paths are resolved within dest,
+// so symlinks of the image cannot be used to write outside of it, and entries
+// that would change the protected paths are skipped.
+func applyLayer(r io.Reader, mediaType, dest string, protected []string) error {
+	tr, closeFn, err := decompress(r, mediaType)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	// Opaque whiteouts only apply to lower layers, so skip the entries the
+	// layer already extracted
+	extracted := map[string]struct{}{}
+	chown := os.Geteuid() == 0
+
+	tarReader := tar.NewReader(tr)
+	for {
+		hdr, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		name := filepath.Clean("/" + hdr.Name)
+		if name == "/" {
+			continue
+		}
+		dir, base := filepath.Split(name)
+
+		parent, err := securejoin.SecureJoin(dest, dir)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case base == whiteoutOpaque:
+			if isProtected(parent, protected) {
+				continue
+			}
+			if err := clearDir(parent, extracted, protected); err != nil {
+				return err
+			}
+			continue
+		case strings.HasPrefix(base, whiteoutPrefix):
+			target := filepath.Join(parent, strings.TrimPrefix(base, whiteoutPrefix))
+			if isProtected(target, protected) || containsProtected(target, protected) {
+				continue
+			}
+			if err := os.RemoveAll(target); err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir, tar.TypeReg, tar.TypeSymlink, tar.TypeLink:
+		default:
+			// Device nodes and fifos are provided by the task's /dev
+			continue
+		}
+
+		target := filepath.Join(parent, base)
+		if isProtected(target, protected) {
+			continue
+		}
+		if hdr.Typeflag != tar.TypeDir && containsProtected(target, protected) {
+			continue
+		}
+		if hdr.Typeflag == tar.TypeLink {
+			source, err := securejoin.SecureJoin(dest, hdr.Linkname)
+			if err != nil {
+				return err
+			}
+			if isProtected(source, protected) {
+				continue
+			}
+		}
+
+		if err := os.MkdirAll(parent, 0755); err != nil {
+			return err
+		}
+		extracted[target] = struct{}{}
+
+		if err := extractEntry(tarReader, hdr, dest, target); err != nil {
+			return fmt.Errorf("failed to extract %s: %w", name, err)
+		}
+
+		if hdr.Typeflag == tar.TypeLink {
+			continue
+		}
+		if chown {
+			if err := os.Lchown(target, hdr.Uid, hdr.Gid); err != nil {
+				return err
+			}
+		}
+		if hdr.Typeflag != tar.TypeSymlink {
+			if err := os.Chmod(target, hdr.FileInfo().Mode()); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// extractEntry creates the file, directory or link of the tar entry at
+// target, replacing what lower layers left there.
+func extractEntry(tr *tar.Reader, hdr *tar.Header, dest, target string) error {
+	info, err := os.Lstat(target)
+	exists := err == nil
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	// Directories are merged with the directories of lower layers, other
+	// entries replace them
+	if exists && !(hdr.Typeflag == tar.TypeDir && info.IsDir()) {
+		if err := os.RemoveAll(target); err != nil {
+			return err
+		}
+		exists = false
+	}
+
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		if !exists {
+			return os.Mkdir(target, 0755)
+		}
+		return nil
+	case tar.TypeReg:
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(f, tr); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	case tar.TypeSymlink:
+		return os.Symlink(hdr.Linkname, target)
+	case tar.TypeLink:
+		source, err := securejoin.SecureJoin(dest, hdr.Linkname)
+		if err != nil {
+			return err
+		}
+		return os.Link(source, target)
+	default:
+		return fmt.Errorf("unsupported entry type %q", hdr.Typeflag)
+	}
+}
+
+// isProtected returns whether the path is one of the protected paths or below
+// one of them.
+func isProtected(path string, protected []string) bool {
+	for _, p := range protected {
+		if path == p || strings.HasPrefix(path, p+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsProtected returns whether one of the protected paths is below the
+// path, so removing the path would remove it.
+func containsProtected(path string, protected []string) bool {
+	for _, p := range protected {
+		if strings.HasPrefix(p, path+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// clearDir removes the content of the directory that was not extracted from
+// the current layer, keeping the protected paths.
+func clearDir(dir string, extracted map[string]struct{}, protected []string) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if isProtected(path, protected) {
+			continue
+		}
+		_, ok := extracted[path]
+		if ok || containsProtected(path, protected) {
+			if entry.IsDir() {
+				if err := clearDir(path, extracted, protected); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := os.RemoveAll(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
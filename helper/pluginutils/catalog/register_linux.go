@@ -0,0 +1,16 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+// +build linux
+
+package catalog
+
+import (
+	"github.com/hashicorp/nomad/drivers/firecracker"
+)
+
+// Register the drivers that only run on Linux.
+func init() {
+	Register(firecracker.PluginID, firecracker.PluginConfig)
+}
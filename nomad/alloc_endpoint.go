@@ -421,6 +421,11 @@ func (a *Alloc) GetServiceRegistrations(
 			for raw := iter.Next(); raw != nil; raw = iter.Next() {
 				services = append(services, raw.(*structs.ServiceRegistration))
 			}
+
+			services, err = weighServices(ws, stateStore, services)
+			if err != nil {
+				return err
+			}
 			reply.Services = services
 
 			// Use the index table to populate the query meta as we have no way
@@ -1394,7 +1394,10 @@ func TestAlloc_GetServiceRegistrations(t *testing.T) {
 		services[0].AllocID = alloc.ID
 		err = s.State().UpsertServiceRegistrations(structs.MsgTypeTestSetup, 20, services)
 
-		return err, alloc.ID, services[0]
+		// The registration is returned with its weight set.
+		service := services[0].Copy()
+		service.Weight = 100
+		return err, alloc.ID, service
 	}
 
 	testCases := []struct {
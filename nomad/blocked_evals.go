@@ -25,6 +25,12 @@ const (
 
 	// pruneThreshold is the threshold after which objects will be pruned.
 	pruneThreshold = 15 * time.Minute
+
+	// fairShareUnblockDelay is the delay applied to evaluations of
+	// namespaces over their fair share when they are unblocked by a capacity
+	// change, so that namespaces under their share get the first chance to
+	// use the capacity.
+	fairShareUnblockDelay = 5 * time.Second
 )
 
 // BlockedEvals is used to track evaluations that shouldn't be queued until a
@@ -53,6 +59,14 @@ type BlockedEvals struct {
 	// resource constraints.
 	system *systemEvals
 
+	// dependent is the set of evaluations that are waiting on the jobs their
+	// job depends on. These are not unblocked by capacity changes.
+	dependent map[string]wrappedEval
+
+	// dependentCh is used to signal that an evaluation was added to the
+	// dependent set.
+	dependentCh chan struct{}
+
 	// unblockCh is used to buffer unblocking of evaluations.
 	capacityChangeCh chan *capacityUpdate
 
@@ -76,6 +90,10 @@ type BlockedEvals struct {
 	// duplicates.
 	duplicateCh chan struct{}
 
+	// fairShare is the ratio of cluster usage to entitled share of each
+	// namespace, and is set when fair-share scheduling is enabled.
+	fairShare map[string]float64
+
 	// stopCh is used to stop any created goroutines.
 	stopCh chan struct{}
 }
@@ -108,6 +126,8 @@ func NewBlockedEvals(evalBroker *EvalBroker, logger hclog.Logger) *BlockedEvals
 		captured:         make(map[string]wrappedEval),
 		escaped:          make(map[string]wrappedEval),
 		system:           newSystemEvals(),
+		dependent:        make(map[string]wrappedEval),
+		dependentCh:      make(chan struct{}, 1),
 		jobs:             make(map[structs.NamespacedID]string),
 		unblockIndexes:   make(map[string]unblockEvent),
 		capacityChangeCh: make(chan *capacityUpdate, unblockBuffer),
@@ -179,6 +199,20 @@ func (b *BlockedEvals) processBlock(eval *structs.Evaluation, token string) {
 		return
 	}
 
+	// Evaluations waiting on job dependencies are unblocked once those are
+	// met, regardless of capacity changes.
+	if len(eval.UnmetDependencies) != 0 {
+		b.jobs[structs.NewNamespacedID(eval.JobID, eval.Namespace)] = eval.ID
+		b.stats.Block(eval)
+		b.dependent[eval.ID] = wrappedEval{eval: eval, token: token}
+
+		select {
+		case b.dependentCh <- struct{}{}:
+		default:
+		}
+		return
+	}
+
 	// Check if the eval missed an unblock while it was in the scheduler at an
 	// older index. The scheduler could have been invoked with a snapshot of
 	// state that was prior to additional capacity being added or allocations
@@ -253,6 +287,15 @@ func (b *BlockedEvals) processBlockJobDuplicate(eval *structs.Evaluation) (newCa
 			dup = eval
 			newCancelled = true
 		}
+	} else if existingW, ok = b.dependent[existingID]; ok {
+		if latestEvalIndex(existingW.eval) <= latestEvalIndex(eval) {
+			delete(b.dependent, existingID)
+			dup = existingW.eval
+			b.stats.Unblock(dup)
+		} else {
+			dup = eval
+			newCancelled = true
+		}
 	} else {
 		existingW, ok = b.escaped[existingID]
 		if !ok {
@@ -394,6 +437,12 @@ func (b *BlockedEvals) Untrack(jobID, namespace string) {
 			b.stats.TotalQuotaLimit--
 		}
 	}
+
+	if w, ok := b.dependent[evalID]; ok {
+		delete(b.jobs, nsID)
+		delete(b.dependent, evalID)
+		b.stats.Unblock(w.eval)
+	}
 }
 
 // Unblock causes any evaluation that could potentially make progress on a
@@ -596,10 +645,36 @@ func (b *BlockedEvals) unblock(computedClass, quota string, index uint64) {
 		}
 
 		// Enqueue all the unblocked evals into the broker.
-		b.evalBroker.EnqueueAll(unblocked)
+		b.evalBroker.EnqueueAll(b.delayOverShare(unblocked))
 	}
 }
 
+// delayOverShare replaces the evaluations of namespaces over their fair share
+// with copies that wait before being enqueued. This assumes the lock is held.
+func (b *BlockedEvals) delayOverShare(evals map[*structs.Evaluation]string) map[*structs.Evaluation]string {
+	if b.fairShare == nil {
+		return evals
+	}
+
+	out := make(map[*structs.Evaluation]string, len(evals))
+	for eval, token := range evals {
+		if b.fairShare[eval.Namespace] > 1 {
+			eval = eval.Copy()
+			eval.Wait = fairShareUnblockDelay
+		}
+		out[eval] = token
+	}
+	return out
+}
+
+// SetFairShare sets the ratio of cluster usage to entitled share of each
+// namespace. Passing nil disables fair-share scheduling.
+func (b *BlockedEvals) SetFairShare(ratios map[string]float64) {
+	b.l.Lock()
+	defer b.l.Unlock()
+	b.fairShare = ratios
+}
+
 // UnblockFailed unblocks all blocked evaluation that were due to scheduler
 // failure.
 func (b *BlockedEvals) UnblockFailed() {
@@ -646,6 +721,47 @@ func (b *BlockedEvals) UnblockFailed() {
 	}
 }
 
+// Dependent returns the evaluations that are waiting on job dependencies, along
+// with a channel that is signaled when an evaluation is added to the set.
+func (b *BlockedEvals) Dependent() ([]*structs.Evaluation, <-chan struct{}) {
+	b.l.RLock()
+	defer b.l.RUnlock()
+
+	evals := make([]*structs.Evaluation, 0, len(b.dependent))
+	for _, wrapped := range b.dependent {
+		evals = append(evals, wrapped.eval)
+	}
+	return evals, b.dependentCh
+}
+
+// UnblockDependent enqueues the evaluations with the given IDs that were
+// waiting on job dependencies into the eval broker.
+func (b *BlockedEvals) UnblockDependent(evalIDs ...string) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	// Do nothing if not enabled
+	if !b.enabled {
+		return
+	}
+
+	unblocked := make(map[*structs.Evaluation]string, len(evalIDs))
+	for _, id := range evalIDs {
+		wrapped, ok := b.dependent[id]
+		if !ok {
+			continue
+		}
+		unblocked[wrapped.eval] = wrapped.token
+		delete(b.dependent, id)
+		delete(b.jobs, structs.NewNamespacedID(wrapped.eval.JobID, wrapped.eval.Namespace))
+		b.stats.Unblock(wrapped.eval)
+	}
+
+	if len(unblocked) > 0 {
+		b.evalBroker.EnqueueAll(unblocked)
+	}
+}
+
 // GetDuplicates returns all the duplicate evaluations and blocks until the
 // passed timeout.
 func (b *BlockedEvals) GetDuplicates(timeout time.Duration) []*structs.Evaluation {
@@ -695,6 +811,8 @@ func (b *BlockedEvals) Flush() {
 	b.stats.BlockedResources = NewBlockedResourcesStats()
 	b.captured = make(map[string]wrappedEval)
 	b.escaped = make(map[string]wrappedEval)
+	b.dependent = make(map[string]wrappedEval)
+	b.dependentCh = make(chan struct{}, 1)
 	b.jobs = make(map[structs.NamespacedID]string)
 	b.unblockIndexes = make(map[string]unblockEvent)
 	b.duplicates = nil
@@ -702,6 +820,7 @@ func (b *BlockedEvals) Flush() {
 	b.stopCh = make(chan struct{})
 	b.duplicateCh = make(chan struct{}, 1)
 	b.system = newSystemEvals()
+	b.fairShare = nil
 }
 
 // Stats is used to query the state of the blocked eval tracker.
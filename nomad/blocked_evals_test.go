@@ -189,6 +189,73 @@ func TestBlockedEvals_UnblockEscaped(t *testing.T) {
 	requireBlockedEvalsEnqueued(t, blocked, broker, 1)
 }
 
+func TestBlockedEvals_Unblock_FairShare(t *testing.T) {
+	ci.Parallel(t)
+
+	blocked, broker := testBlockedEvals(t)
+	blocked.SetFairShare(map[string]float64{"over": 2})
+
+	under := mock.BlockedEval()
+	under.EscapedComputedClass = true
+	blocked.Block(under)
+
+	over := mock.BlockedEval()
+	over.Namespace = "over"
+	over.EscapedComputedClass = true
+	blocked.Block(over)
+
+	blocked.Unblock("v1:123", 1000)
+
+	// The eval of the namespace over its share waits before being enqueued
+	testutil.WaitForResult(func() (bool, error) {
+		stats := broker.Stats()
+		if stats.TotalReady != 1 || stats.TotalWaiting != 1 {
+			return false, fmt.Errorf("expected 1 ready and 1 waiting eval, got %#v", stats)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+
+	out, _, err := broker.Dequeue(defaultSched, time.Second)
+	must.NoError(t, err)
+	must.Eq(t, under.ID, out.ID)
+}
+
+func TestBlockedEvals_UnblockDependent(t *testing.T) {
+	ci.Parallel(t)
+
+	blocked, broker := testBlockedEvals(t)
+
+	// Create an eval waiting on a job dependency and add it to the blocked
+	// tracker.
+	e := mock.BlockedEval()
+	e.EscapedComputedClass = true
+	e.UnmetDependencies = []string{"db"}
+	blocked.Block(e)
+
+	evals, dependentCh := blocked.Dependent()
+	must.Len(t, 1, evals)
+	must.Eq(t, e.ID, evals[0].ID)
+	select {
+	case <-dependentCh:
+	default:
+		t.Fatal("expected dependent channel to be signaled")
+	}
+
+	// Capacity changes don't unblock the eval
+	blocked.Unblock("v1:123", 1000)
+	time.Sleep(100 * time.Millisecond)
+	must.Zero(t, broker.Stats().TotalReady)
+	must.Eq(t, 1, blocked.Stats().TotalBlocked)
+
+	blocked.UnblockDependent(e.ID)
+	requireBlockedEvalsEnqueued(t, blocked, broker, 1)
+
+	evals, _ = blocked.Dependent()
+	must.SliceEmpty(t, evals)
+}
+
 func requireBlockedEvalsEnqueued(t *testing.T, blocked *BlockedEvals, broker *EvalBroker, enqueued int) {
 	testutil.WaitForResult(func() (bool, error) {
 		// Verify Unblock caused an enqueue
@@ -51,3 +51,31 @@ func (s *ClientStats) Stats(args *nstructs.NodeSpecificRequest, reply *structs.C
 
 	return s.srv.forwardClientRPC("ClientStats.Stats", args.NodeID, args, reply)
 }
+
+func (s *ClientStats) History(args *nstructs.NodeSpecificRequest, reply *structs.ClientStatsHistoryResponse) error {
+
+	// We only allow stale reads since the only potentially stale information is
+	// the Node registration and the cost is fairly high for adding another hope
+	// in the forwarding chain.
+	args.QueryOptions.AllowStale = true
+	authErr := s.srv.Authenticate(nil, args)
+
+	// Potentially forward to a different region.
+	if done, err := s.srv.forward("ClientStats.History", args, args, reply); done {
+		return err
+	}
+	s.srv.MeasureRPCRate("client_stats", nstructs.RateMetricRead, args)
+	if authErr != nil {
+		return nstructs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client_stats", "history"}, time.Now())
+
+	// Check node read permissions
+	if aclObj, err := s.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNodeRead() {
+		return nstructs.ErrPermissionDenied
+	}
+
+	return s.srv.forwardClientRPC("ClientStats.History", args.NodeID, args, reply)
+}
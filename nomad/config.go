@@ -5,6 +5,7 @@ package nomad
 
 import (
 	"io"
+	"maps"
 	"net"
 	"os"
 	"runtime"
@@ -68,6 +69,19 @@ type Config struct {
 	// EventBufferSize is the amount of events to hold in memory.
 	EventBufferSize int64
 
+	// EventLogEnabled is used to enable the durable event log, which allows
+	// event stream subscribers to resume from indexes that are no longer in
+	// the event buffer.
+	EventLogEnabled bool
+
+	// EventLogMaxAge is how long events are retained in the event log. Zero
+	// disables age based retention.
+	EventLogMaxAge time.Duration
+
+	// EventLogMaxSize is the maximum size in bytes of the event log. Zero
+	// disables size based retention.
+	EventLogMaxSize int64
+
 	// JobMaxSourceSize limits the maximum size of a jobs source hcl/json
 	// before being discarded automatically. A value of zero indicates no job
 	// sources will be stored.
@@ -420,6 +434,11 @@ type Config struct {
 	// DeploymentWatcher to throttle the amount of simultaneously deployments
 	DeploymentQueryRateLimit float64
 
+	// CanaryAnalysisSources maps the names of the metrics sources that the
+	// canary analyses of jobs can query to the address of their
+	// Prometheus-compatible HTTP API.
+	CanaryAnalysisSources map[string]string
+
 	// JobDefaultPriority is the default Job priority if not specified.
 	JobDefaultPriority int
 
@@ -467,6 +486,7 @@ func (c *Config) Copy() *Config {
 	nc.LicenseConfig = c.LicenseConfig.Copy()
 	nc.SearchConfig = c.SearchConfig.Copy()
 	nc.KEKProviderConfigs = helper.CopySlice(c.KEKProviderConfigs)
+	nc.CanaryAnalysisSources = maps.Clone(c.CanaryAnalysisSources)
 
 	return &nc
 }
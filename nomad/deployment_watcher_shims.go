@@ -48,6 +48,11 @@ func (d *deploymentWatcherRaftShim) UpdateDeploymentPromotion(req *structs.Apply
 	return d.convertApplyErrors(fsmErrIntf, index, raftErr)
 }
 
+func (d *deploymentWatcherRaftShim) UpdateDeploymentRolloutStep(req *structs.ApplyDeploymentRolloutStepRequest) (uint64, error) {
+	fsmErrIntf, index, raftErr := d.apply(structs.DeploymentRolloutStepRequestType, req)
+	return d.convertApplyErrors(fsmErrIntf, index, raftErr)
+}
+
 func (d *deploymentWatcherRaftShim) UpdateDeploymentAllocHealth(req *structs.ApplyDeploymentAllocHealthRequest) (uint64, error) {
 	fsmErrIntf, index, raftErr := d.apply(structs.DeploymentAllocHealthRequestType, req)
 	return d.convertApplyErrors(fsmErrIntf, index, raftErr)
@@ -0,0 +1,568 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package deploymentwatcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// canaryAnalysisQueryTimeout is the maximum time a query of a canary
+	// analysis may take.
+	canaryAnalysisQueryTimeout = 30 * time.Second
+
+	// canaryAnalysisMaxResponseSize is the maximum size of the response to a
+	// query of a canary analysis.
+	canaryAnalysisMaxResponseSize = 1 << 20
+)
+
+var (
+	// canaryAnalysisClient is the HTTP client used to query the metrics
+	// sources of canary analyses. Redirects aren't followed, so that a source
+	// can't send the leader's queries elsewhere.
+	canaryAnalysisClient = func() *http.Client {
+		client := cleanhttp.DefaultPooledClient()
+		client.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+		return client
+	}()
+
+	// errNoMetricsData is returned when a query of a canary analysis doesn't
+	// return any value, such as before the metrics of new canaries have been
+	// collected.
+	errNoMetricsData = errors.New("query returned no data")
+)
+
+// startCanaryAnalyses starts the canary analysis of each task group of the
+// deployment that configures one.
+func (w *deploymentWatcher) startCanaryAnalyses() {
+	for _, tg := range w.j.TaskGroups {
+		if tg.Update == nil || tg.Update.Analysis == nil {
+			continue
+		}
+		if _, ok := w.d.TaskGroups[tg.Name]; !ok {
+			continue
+		}
+		go w.watchCanaryAnalysis(tg.Name, tg.Update.Analysis)
+	}
+}
+
+// passedCanaryAnalysis returns whether the canaries of the task group have
+// passed its canary analysis. Task groups without a canary analysis always
+// pass.
+func (w *deploymentWatcher) passedCanaryAnalysis(group string) bool {
+	tg := w.j.LookupTaskGroup(group)
+	if tg == nil || tg.Update == nil || tg.Update.Analysis == nil {
+		return true
+	}
+
+	w.l.RLock()
+	defer w.l.RUnlock()
+	return w.canaryAnalysisPassed[group]
+}
+
+// watchCanaryAnalysis periodically analyzes the canaries of a task group once
+// they are all healthy, until they pass or fail the analysis or the watcher is
+// stopped. Each run is recorded as a deployment status update, and the
+// outcome of the analysis applied to the deployment, by the watch loop.
+func (w *deploymentWatcher) watchCanaryAnalysis(group string, analysis *structs.CanaryAnalysis) {
+	ticker := time.NewTicker(analysis.Interval)
+	defer ticker.Stop()
+
+	var passed, failed int
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		d := w.getDeployment()
+		dstate, ok := d.TaskGroups[group]
+		if !ok || dstate.Promoted {
+			return
+		}
+
+		// The runs of a paused deployment are discarded, so that the analysis
+		// starts over once the deployment is resumed.
+		if d.Status != structs.DeploymentStatusRunning {
+			passed, failed = 0, 0
+			continue
+		}
+
+		canaries, stable, err := w.canaryAnalysisAllocs(group, dstate)
+		if err != nil {
+			w.logger.Error("failed to lookup allocations for canary analysis", "group", group, "error", err)
+			continue
+		}
+		if dstate.DesiredCanaries == 0 || len(canaries) < dstate.DesiredCanaries {
+			continue
+		}
+
+		run := passed + failed + 1
+		var results []*canaryMetricResult
+		address, ok := w.canaryAnalysisSources[analysis.Source]
+		if !ok {
+			err = fmt.Errorf("source %q is not configured", analysis.Source)
+		} else {
+			results, err = runCanaryAnalysis(w.ctx, address, analysis, w.j, group, canaries, stable)
+		}
+		if w.ctx.Err() != nil {
+			return
+		}
+
+		// The errors of queries may contain the responses of the metrics
+		// source, so they are only logged and the status of the deployment
+		// only refers to the metric.
+		var queryErr *canaryQueryError
+		if errors.As(err, &queryErr) && !errors.Is(err, errNoMetricsData) {
+			w.logger.Warn("canary analysis query failed", "group", group, "error", err)
+		}
+
+		r := &canaryAnalysisRun{group: group, analysis: analysis, run: run}
+		outcome := "failed"
+		switch {
+		case errors.Is(err, errNoMetricsData):
+			r.description = fmt.Sprintf("Canary analysis run %d of group %q is inconclusive: query of metric %q returned no data",
+				run, group, queryErr.metric)
+			if !w.sendCanaryAnalysisRun(r) {
+				passed, failed = 0, 0
+			}
+			continue
+		case queryErr != nil:
+			failed++
+			r.details = fmt.Sprintf("query of metric %q failed", queryErr.metric)
+		case err != nil:
+			failed++
+			r.details = err.Error()
+		default:
+			r.details = formatCanaryMetricResults(results)
+			if canaryMetricResultsPassed(results) {
+				outcome = "passed"
+				passed++
+			} else {
+				failed++
+			}
+		}
+		r.description = fmt.Sprintf("Canary analysis run %d of group %q %s: %s", run, group, outcome, r.details)
+
+		switch {
+		case failed > analysis.FailureLimit:
+			r.concluded = true
+		case passed >= analysis.SuccessfulRuns:
+			r.concluded, r.passed = true, true
+		}
+
+		// The analysis starts over if the run isn't recorded because the
+		// deployment was paused in the meantime, or if the deployment was
+		// paused by the analysis.
+		applied := w.sendCanaryAnalysisRun(r)
+		switch {
+		case !applied:
+			passed, failed = 0, 0
+		case r.concluded && (r.passed || analysis.OnFailure == structs.CanaryAnalysisOnFailureFail):
+			return
+		case r.concluded:
+			passed, failed = 0, 0
+		}
+	}
+}
+
+// canaryAnalysisRun is the result of a run of the canary analysis of a task
+// group. Runs are applied by the watch loop, so that they can't race with the
+// other updates of the status of the deployment.
+type canaryAnalysisRun struct {
+	group    string
+	analysis *structs.CanaryAnalysis
+	run      int
+
+	// description is recorded as the status description of the deployment.
+	description string
+
+	// concluded is set if the run concludes the analysis, which the canaries
+	// passed if passed is set, and details describes the results of the run.
+	concluded bool
+	passed    bool
+	details   string
+
+	// appliedCh receives whether the run was applied, which it isn't if the
+	// deployment is no longer running.
+	appliedCh chan bool
+}
+
+// sendCanaryAnalysisRun sends a run of a canary analysis to the watch loop
+// and returns whether it was applied.
+func (w *deploymentWatcher) sendCanaryAnalysisRun(r *canaryAnalysisRun) bool {
+	r.appliedCh = make(chan bool, 1)
+	select {
+	case w.canaryAnalysisCh <- r:
+	case <-w.ctx.Done():
+		return false
+	}
+
+	select {
+	case applied := <-r.appliedCh:
+		return applied
+	case <-w.ctx.Done():
+		return false
+	}
+}
+
+// applyCanaryAnalysisRun records a run of a canary analysis and applies the
+// outcome of the analysis if the run concludes it, if the deployment is still
+// running and the task group hasn't been promoted. It returns whether the run
+// was applied.
+func (w *deploymentWatcher) applyCanaryAnalysisRun(r *canaryAnalysisRun) bool {
+	d, err := w.state.DeploymentByID(nil, w.deploymentID)
+	if err != nil {
+		w.logger.Error("failed to lookup deployment for canary analysis", "error", err)
+		return false
+	}
+	if d == nil || d.Status != structs.DeploymentStatusRunning {
+		return false
+	}
+	if dstate, ok := d.TaskGroups[r.group]; !ok || dstate.Promoted {
+		return false
+	}
+
+	u := w.getDeploymentStatusUpdate(structs.DeploymentStatusRunning, r.description)
+	if _, err := w.upsertDeploymentStatusUpdate(u, nil, nil); err != nil {
+		w.logger.Error("failed to record canary analysis run", "error", err)
+	}
+
+	switch {
+	case !r.concluded:
+	case r.passed:
+		w.logger.Debug("canaries passed analysis", "group", r.group, "details", r.details)
+		w.passCanaryAnalysis(r.group, r.run, r.details)
+	default:
+		w.logger.Debug("canaries failed analysis", "group", r.group, "details", r.details)
+		w.failCanaryAnalysis(r.group, r.analysis, r.details)
+	}
+	return true
+}
+
+// canaryAnalysisAllocs returns the IDs of the healthy canaries of the task
+// group, and of its running allocations that aren't part of the deployment.
+func (w *deploymentWatcher) canaryAnalysisAllocs(group string, dstate *structs.DeploymentState) (canaries, stable []string, err error) {
+	snap, err := w.state.Snapshot()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	allocs, err := snap.AllocsByJob(nil, w.j.Namespace, w.j.ID, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	placed := make(map[string]struct{}, len(dstate.PlacedCanaries))
+	for _, id := range dstate.PlacedCanaries {
+		placed[id] = struct{}{}
+	}
+
+	for _, alloc := range allocs {
+		if alloc.TaskGroup != group || alloc.TerminalStatus() {
+			continue
+		}
+
+		if alloc.DeploymentID == w.deploymentID {
+			if _, ok := placed[alloc.ID]; ok && alloc.DeploymentStatus.IsHealthy() {
+				canaries = append(canaries, alloc.ID)
+			}
+		} else if alloc.ClientStatus == structs.AllocClientStatusRunning {
+			stable = append(stable, alloc.ID)
+		}
+	}
+	return canaries, stable, nil
+}
+
+// passCanaryAnalysis records that the canaries of the task group passed their
+// analysis and promotes the deployment if it can be automatically promoted.
+func (w *deploymentWatcher) passCanaryAnalysis(group string, runs int, details string) {
+	w.l.Lock()
+	if w.canaryAnalysisPassed == nil {
+		w.canaryAnalysisPassed = make(map[string]bool)
+	}
+	w.canaryAnalysisPassed[group] = true
+	w.l.Unlock()
+
+	desc := fmt.Sprintf("Canary analysis of group %q passed after %d runs: %s", group, runs, details)
+	u := w.getDeploymentStatusUpdate(structs.DeploymentStatusRunning, desc)
+	if _, err := w.upsertDeploymentStatusUpdate(u, nil, nil); err != nil {
+		w.logger.Error("failed to record canary analysis", "error", err)
+	}
+
+	allocs, _, err := w.getAllocs(1)
+	if err != nil {
+		w.logger.Error("failed to lookup allocations for auto promotion", "error", err)
+		return
+	}
+	if err := w.autoPromoteDeployment(allocs); err != nil {
+		w.logger.Error("failed to auto promote deployment", "error", err)
+	}
+}
+
+// failCanaryAnalysis applies the on_failure action of the canary analysis to
+// the deployment.
+func (w *deploymentWatcher) failCanaryAnalysis(group string, analysis *structs.CanaryAnalysis, details string) {
+	switch analysis.OnFailure {
+	case structs.CanaryAnalysisOnFailurePause:
+		desc := fmt.Sprintf("%s of group %q: %s", structs.DeploymentStatusDescriptionPausedAnalysis, group, details)
+		u := w.getDeploymentStatusUpdate(structs.DeploymentStatusPaused, desc)
+		if _, err := w.upsertDeploymentStatusUpdate(u, nil, nil); err != nil {
+			w.logger.Error("failed to pause deployment", "error", err)
+		}
+	default:
+		desc := fmt.Sprintf("%s of group %q: %s", structs.DeploymentStatusDescriptionFailedAnalysis, group, details)
+		if _, _, err := w.failDeployment(desc, w.getEval()); err != nil {
+			w.logger.Error("failed to fail deployment", "error", err)
+		}
+	}
+}
+
+// canaryMetricResult is the result of the comparison of a metric between the
+// canary and stable allocations.
+type canaryMetricResult struct {
+	metric *structs.CanaryMetric
+
+	canary float64
+
+	// stable is only set when the metric is compared to the stable
+	// allocations.
+	stable *float64
+}
+
+// passed returns whether the canaries are within the thresholds of the metric
+func (r *canaryMetricResult) passed() bool {
+	if r.metric.Max != nil && r.canary > *r.metric.Max {
+		return false
+	}
+	if r.stable != nil && r.canary > *r.stable*(1+*r.metric.MaxIncrease) {
+		return false
+	}
+	return true
+}
+
+func (r *canaryMetricResult) String() string {
+	out := fmt.Sprintf("%s=%g", r.metric.Name, r.canary)
+	if r.stable != nil {
+		out += fmt.Sprintf(" (stable=%g)", *r.stable)
+	}
+
+	switch {
+	case r.metric.Max != nil && r.canary > *r.metric.Max:
+		out += fmt.Sprintf(" exceeds max %g", *r.metric.Max)
+	case r.stable != nil && r.canary > *r.stable*(1+*r.metric.MaxIncrease):
+		out += fmt.Sprintf(" exceeds max increase %g%%", *r.metric.MaxIncrease*100)
+	}
+	return out
+}
+
+func canaryMetricResultsPassed(results []*canaryMetricResult) bool {
+	for _, r := range results {
+		if !r.passed() {
+			return false
+		}
+	}
+	return true
+}
+
+func formatCanaryMetricResults(results []*canaryMetricResult) string {
+	out := make([]string, 0, len(results))
+	for _, r := range results {
+		out = append(out, r.String())
+	}
+	return strings.Join(out, ", ")
+}
+
+// canaryQueryData is the data available to the query templates of the metrics
+// of a canary analysis.
+type canaryQueryData struct {
+	// AllocIDs is a regular expression matching the IDs of the allocations
+	// being queried.
+	AllocIDs string
+
+	// Window is the range of the analysis, as a Prometheus duration.
+	Window string
+
+	Namespace string
+	Job       string
+	Group     string
+}
+
+// canaryQueryError is returned when the query of a metric of a canary
+// analysis fails.
+type canaryQueryError struct {
+	metric string
+	err    error
+}
+
+func (e *canaryQueryError) Error() string {
+	return fmt.Sprintf("metric %q: %v", e.metric, e.err)
+}
+
+func (e *canaryQueryError) Unwrap() error {
+	return e.err
+}
+
+// runCanaryAnalysis queries each metric of the analysis from the metrics
+// source at address for the canaries, and for the stable allocations when the
+// metric is compared to them.
+func runCanaryAnalysis(ctx context.Context, address string, analysis *structs.CanaryAnalysis,
+	job *structs.Job, group string, canaries, stable []string) ([]*canaryMetricResult, error) {
+
+	data := canaryQueryData{
+		Window:    fmt.Sprintf("%ds", int64(analysis.Window.Seconds())),
+		Namespace: job.Namespace,
+		Job:       job.ID,
+		Group:     group,
+	}
+
+	results := make([]*canaryMetricResult, 0, len(analysis.Metrics))
+	for _, metric := range analysis.Metrics {
+		tmpl, err := template.New(metric.Name).Parse(metric.Query)
+		if err != nil {
+			return nil, &canaryQueryError{metric: metric.Name, err: fmt.Errorf("invalid query: %w", err)}
+		}
+
+		data.AllocIDs = allocIDsRegexp(canaries)
+		canary, err := queryCanaryMetric(ctx, address, tmpl, data)
+		if err != nil {
+			return nil, &canaryQueryError{metric: metric.Name, err: err}
+		}
+		result := &canaryMetricResult{metric: metric, canary: canary}
+
+		// Without stable allocations, e.g. when the group was scaled from
+		// zero, only the absolute threshold applies.
+		if metric.MaxIncrease != nil && len(stable) > 0 {
+			data.AllocIDs = allocIDsRegexp(stable)
+			stableValue, err := queryCanaryMetric(ctx, address, tmpl, data)
+			if err != nil {
+				return nil, &canaryQueryError{metric: metric.Name, err: fmt.Errorf("stable allocations: %w", err)}
+			}
+			result.stable = &stableValue
+		}
+
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func allocIDsRegexp(ids []string) string {
+	quoted := make([]string, 0, len(ids))
+	for _, id := range ids {
+		quoted = append(quoted, regexp.QuoteMeta(id))
+	}
+	return strings.Join(quoted, "|")
+}
+
+func queryCanaryMetric(ctx context.Context, address string, tmpl *template.Template, data canaryQueryData) (float64, error) {
+	var query strings.Builder
+	if err := tmpl.Execute(&query, data); err != nil {
+		return 0, fmt.Errorf("failed to render query: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, canaryAnalysisQueryTimeout)
+	defer cancel()
+	return queryPrometheus(ctx, address, query.String())
+}
+
+// prometheusResponse is the response of the instant query API of Prometheus
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// queryPrometheus runs an instant query against a Prometheus-compatible HTTP
+// API. The query must return a scalar or a vector of a single sample.
+func queryPrometheus(ctx context.Context, address, query string) (float64, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return 0, err
+	}
+	u = u.JoinPath("api", "v1", "query")
+	u.RawQuery = url.Values{"query": []string{query}}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := canaryAnalysisClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var body prometheusResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, canaryAnalysisMaxResponseSize)).Decode(&body); err != nil {
+		return 0, fmt.Errorf("failed to decode response with status %d: %w", resp.StatusCode, err)
+	}
+	if body.Status != "success" {
+		return 0, fmt.Errorf("query failed with status %d: %s", resp.StatusCode, body.Error)
+	}
+
+	var sample []any
+	switch body.Data.ResultType {
+	case "scalar":
+		if err := json.Unmarshal(body.Data.Result, &sample); err != nil {
+			return 0, fmt.Errorf("failed to decode scalar: %w", err)
+		}
+	case "vector":
+		var vector []struct {
+			Value []any `json:"value"`
+		}
+		if err := json.Unmarshal(body.Data.Result, &vector); err != nil {
+			return 0, fmt.Errorf("failed to decode vector: %w", err)
+		}
+		switch len(vector) {
+		case 0:
+			return 0, errNoMetricsData
+		case 1:
+			sample = vector[0].Value
+		default:
+			return 0, fmt.Errorf("query returned %d series, expected 1", len(vector))
+		}
+	default:
+		return 0, fmt.Errorf("unsupported result type %q", body.Data.ResultType)
+	}
+
+	// Samples are a pair of a timestamp and a value encoded as a string
+	if len(sample) != 2 {
+		return 0, fmt.Errorf("invalid sample %v", sample)
+	}
+	s, ok := sample[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("invalid sample value %v", sample[1])
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sample value %q: %w", s, err)
+	}
+
+	// A ratio of rates without any traffic is NaN
+	if math.IsNaN(value) {
+		return 0, errNoMetricsData
+	}
+	return value, nil
+}
@@ -0,0 +1,533 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package deploymentwatcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+	mocker "github.com/stretchr/testify/mock"
+)
+
+// testPrometheus is a stand-in for the query API of Prometheus, returning the
+// value of the first of values whose key is contained in the query. The values
+// must not be modified once the server is queried.
+func testPrometheus(t *testing.T, values map[string]string) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		query := r.URL.Query().Get("query")
+		for key, value := range values {
+			if strings.Contains(query, key) {
+				fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,%q]}]}}`, value)
+				return
+			}
+		}
+		fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[]}}`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestQueryPrometheus(t *testing.T) {
+	ci.Parallel(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("query") {
+		case "scalar":
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"0.5"]}}`)
+		case "vector":
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"1.5"]}]}}`)
+		case "series":
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[{"value":[1,"1"]},{"value":[1,"2"]}]}}`)
+		case "nan":
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"NaN"]}}`)
+		case "empty":
+			fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[]}}`)
+		case "redirect":
+			http.Redirect(w, r, "/api/v1/query?query=scalar", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"status":"error","errorType":"bad_data","error":"parse error"}`)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+
+	value, err := queryPrometheus(ctx, srv.URL, "scalar")
+	must.NoError(t, err)
+	must.Eq(t, 0.5, value)
+
+	value, err = queryPrometheus(ctx, srv.URL, "vector")
+	must.NoError(t, err)
+	must.Eq(t, 1.5, value)
+
+	_, err = queryPrometheus(ctx, srv.URL, "series")
+	must.ErrorContains(t, err, "query returned 2 series")
+
+	_, err = queryPrometheus(ctx, srv.URL, "nan")
+	must.ErrorIs(t, err, errNoMetricsData)
+
+	_, err = queryPrometheus(ctx, srv.URL, "empty")
+	must.ErrorIs(t, err, errNoMetricsData)
+
+	_, err = queryPrometheus(ctx, srv.URL, "invalid(")
+	must.ErrorContains(t, err, "parse error")
+
+	// Redirects aren't followed
+	_, err = queryPrometheus(ctx, srv.URL, "redirect")
+	must.ErrorContains(t, err, "status 302")
+}
+
+func TestCanaryMetricResult(t *testing.T) {
+	ci.Parallel(t)
+
+	metric := &structs.CanaryMetric{
+		Name:        "errors",
+		Max:         pointer.Of(1.0),
+		MaxIncrease: pointer.Of(0.5),
+	}
+
+	r := &canaryMetricResult{metric: metric, canary: 0.6, stable: pointer.Of(0.5)}
+	must.True(t, r.passed())
+	must.Eq(t, "errors=0.6 (stable=0.5)", r.String())
+
+	r = &canaryMetricResult{metric: metric, canary: 0.8, stable: pointer.Of(0.5)}
+	must.False(t, r.passed())
+	must.Eq(t, "errors=0.8 (stable=0.5) exceeds max increase 50%", r.String())
+
+	r = &canaryMetricResult{metric: metric, canary: 1.2}
+	must.False(t, r.passed())
+	must.Eq(t, "errors=1.2 exceeds max 1", r.String())
+}
+
+// testCanaryAnalysisDeployment upserts a job whose group has an analysis, a
+// deployment with a healthy canary, and a stable allocation of the previous
+// deployment. It returns the deployment and the canary and stable allocations.
+// The analysis is shared with the job, so must be complete when called.
+func testCanaryAnalysisDeployment(t *testing.T, m *mockBackend, analysis *structs.CanaryAnalysis) (*structs.Deployment, *structs.Allocation, *structs.Allocation) {
+	j := mock.Job()
+	j.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	j.TaskGroups[0].Update.MaxParallel = 2
+	j.TaskGroups[0].Update.Canary = 1
+	j.TaskGroups[0].Update.AutoPromote = true
+	j.TaskGroups[0].Update.AutoRevert = true
+	j.TaskGroups[0].Update.ProgressDeadline = 0
+	j.TaskGroups[0].Update.Analysis = analysis
+
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.TaskGroups["web"].AutoPromote = true
+	d.TaskGroups["web"].AutoRevert = true
+
+	canary := mock.Alloc()
+	canary.JobID = j.ID
+	canary.Job = j
+	canary.DeploymentID = d.ID
+	canary.ClientStatus = structs.AllocClientStatusRunning
+	canary.DeploymentStatus = &structs.AllocDeploymentStatus{
+		Healthy: pointer.Of(true),
+		Canary:  true,
+	}
+	d.TaskGroups["web"].DesiredCanaries = 1
+	d.TaskGroups["web"].PlacedCanaries = []string{canary.ID}
+	d.TaskGroups["web"].HealthyAllocs = 1
+
+	stable := mock.Alloc()
+	stable.JobID = j.ID
+	stable.Job = j
+	stable.ClientStatus = structs.AllocClientStatusRunning
+
+	must.NoError(t, m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), nil, j))
+	must.NoError(t, m.state.UpsertDeployment(m.nextIndex(), d))
+	must.NoError(t, m.state.UpsertAllocs(structs.MsgTypeTestSetup, m.nextIndex(), []*structs.Allocation{canary, stable}))
+	return d, canary, stable
+}
+
+func TestWatcher_CanaryAnalysis_Promote(t *testing.T) {
+	ci.Parallel(t)
+	w, m := defaultTestDeploymentWatcher(t)
+
+	m.On("UpdateDeploymentStatus", mocker.Anything).Return(nil).Maybe()
+	m.On("UpdateAllocDesiredTransition", mocker.Anything).Return(nil).Maybe()
+
+	values := make(map[string]string)
+	w.canaryAnalysisSources = map[string]string{"prometheus": testPrometheus(t, values).URL}
+	analysis := &structs.CanaryAnalysis{
+		Source:         "prometheus",
+		Interval:       50 * time.Millisecond,
+		Window:         time.Minute,
+		SuccessfulRuns: 2,
+		OnFailure:      structs.CanaryAnalysisOnFailureFail,
+		Metrics: []*structs.CanaryMetric{{
+			Name:        "errors",
+			Query:       `errors{alloc_id=~"{{ .AllocIDs }}"}[{{ .Window }}]`,
+			MaxIncrease: pointer.Of(0.1),
+		}},
+	}
+	d, canary, stable := testCanaryAnalysisDeployment(t, m, analysis)
+
+	values[canary.ID] = "1.05"
+	values[stable.ID] = "1"
+
+	matcher := matchDeploymentPromoteRequest(&matchDeploymentPromoteRequestConfig{
+		Promotion: &structs.DeploymentPromoteRequest{
+			DeploymentID: d.ID,
+			All:          true,
+		},
+		Eval: true,
+	})
+	m.On("UpdateDeploymentPromotion", mocker.MatchedBy(matcher)).Return(nil)
+
+	w.SetEnabled(true, m.state)
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			ds, _ := m.state.DeploymentByID(nil, d.ID)
+			return ds.TaskGroups["web"].Promoted
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	ds, err := m.state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.DeploymentStatusRunning, ds.Status)
+
+	// Each run and the outcome of the analysis are recorded as status updates
+	for _, desc := range []string{
+		`Canary analysis run 1 of group "web" passed: errors=1.05 (stable=1)`,
+		`Canary analysis run 2 of group "web" passed: errors=1.05 (stable=1)`,
+	} {
+		m.AssertCalled(t, "UpdateDeploymentStatus", mocker.MatchedBy(func(u *structs.DeploymentStatusUpdateRequest) bool {
+			return u.DeploymentUpdate.StatusDescription == desc
+		}))
+	}
+	m.AssertCalled(t, "UpdateDeploymentStatus", mocker.MatchedBy(func(u *structs.DeploymentStatusUpdateRequest) bool {
+		return u.DeploymentUpdate.StatusDescription == `Canary analysis of group "web" passed after 2 runs: errors=1.05 (stable=1)`
+	}))
+}
+
+func TestWatcher_CanaryAnalysis_Fail(t *testing.T) {
+	ci.Parallel(t)
+	w, m := defaultTestDeploymentWatcher(t)
+
+	m.On("UpdateDeploymentStatus", mocker.Anything).Return(nil)
+	m.On("UpdateAllocDesiredTransition", mocker.Anything).Return(nil).Maybe()
+
+	values := make(map[string]string)
+	w.canaryAnalysisSources = map[string]string{"prometheus": testPrometheus(t, values).URL}
+	analysis := &structs.CanaryAnalysis{
+		Source:         "prometheus",
+		Interval:       50 * time.Millisecond,
+		Window:         time.Minute,
+		SuccessfulRuns: 3,
+		FailureLimit:   1,
+		OnFailure:      structs.CanaryAnalysisOnFailureFail,
+		Metrics: []*structs.CanaryMetric{{
+			Name:  "errors",
+			Query: `errors{alloc_id=~"{{ .AllocIDs }}"}`,
+			Max:   pointer.Of(0.01),
+		}},
+	}
+	d, canary, _ := testCanaryAnalysisDeployment(t, m, analysis)
+
+	values[canary.ID] = "0.2"
+
+	w.SetEnabled(true, m.state)
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			ds, _ := m.state.DeploymentByID(nil, d.ID)
+			return ds.Status == structs.DeploymentStatusFailed
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	ds, err := m.state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	must.StrContains(t, ds.StatusDescription, structs.DeploymentStatusDescriptionFailedAnalysis)
+	must.StrContains(t, ds.StatusDescription, "errors=0.2 exceeds max 0.01")
+	must.StrContains(t, ds.StatusDescription, "no stable job version to auto revert to")
+	m.AssertNotCalled(t, "UpdateDeploymentPromotion", mocker.Anything)
+}
+
+func TestWatcher_CanaryAnalysis_Pause(t *testing.T) {
+	ci.Parallel(t)
+	w, m := defaultTestDeploymentWatcher(t)
+
+	m.On("UpdateDeploymentStatus", mocker.Anything).Return(nil)
+	m.On("UpdateAllocDesiredTransition", mocker.Anything).Return(nil).Maybe()
+
+	values := make(map[string]string)
+	w.canaryAnalysisSources = map[string]string{"prometheus": testPrometheus(t, values).URL}
+	analysis := &structs.CanaryAnalysis{
+		Source:         "prometheus",
+		Interval:       50 * time.Millisecond,
+		Window:         time.Minute,
+		SuccessfulRuns: 1,
+		OnFailure:      structs.CanaryAnalysisOnFailurePause,
+		Metrics: []*structs.CanaryMetric{{
+			Name:        "latency",
+			Query:       `latency{alloc_id=~"{{ .AllocIDs }}"}`,
+			MaxIncrease: pointer.Of(0.1),
+		}},
+	}
+	d, canary, stable := testCanaryAnalysisDeployment(t, m, analysis)
+
+	values[canary.ID] = "0.5"
+	values[stable.ID] = "0.2"
+
+	w.SetEnabled(true, m.state)
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			ds, _ := m.state.DeploymentByID(nil, d.ID)
+			return ds.Status == structs.DeploymentStatusPaused
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	ds, err := m.state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	must.StrContains(t, ds.StatusDescription, structs.DeploymentStatusDescriptionPausedAnalysis)
+	must.StrContains(t, ds.StatusDescription, "latency=0.5 (stable=0.2) exceeds max increase 10%")
+	must.False(t, ds.TaskGroups["web"].Promoted)
+}
+
+func TestWatcher_CanaryAnalysis_QueryError(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name   string
+		source string
+		desc   string
+	}{
+		{
+			name:   "query failed",
+			source: "prometheus",
+			desc:   `query of metric "errors" failed`,
+		},
+		{
+			name:   "unknown source",
+			source: "unknown",
+			desc:   `source "unknown" is not configured`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w, m := defaultTestDeploymentWatcher(t)
+
+			m.On("UpdateDeploymentStatus", mocker.Anything).Return(nil)
+			m.On("UpdateAllocDesiredTransition", mocker.Anything).Return(nil).Maybe()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"status":"error","errorType":"bad_data","error":"upstream secret"}`)
+			}))
+			t.Cleanup(srv.Close)
+			w.canaryAnalysisSources = map[string]string{"prometheus": srv.URL}
+
+			analysis := &structs.CanaryAnalysis{
+				Source:         tc.source,
+				Interval:       50 * time.Millisecond,
+				Window:         time.Minute,
+				SuccessfulRuns: 1,
+				OnFailure:      structs.CanaryAnalysisOnFailureFail,
+				Metrics: []*structs.CanaryMetric{{
+					Name:  "errors",
+					Query: `errors{alloc_id=~"{{ .AllocIDs }}"}`,
+					Max:   pointer.Of(0.01),
+				}},
+			}
+			d, _, _ := testCanaryAnalysisDeployment(t, m, analysis)
+
+			w.SetEnabled(true, m.state)
+			must.Wait(t, wait.InitialSuccess(
+				wait.BoolFunc(func() bool {
+					ds, _ := m.state.DeploymentByID(nil, d.ID)
+					return ds.Status == structs.DeploymentStatusFailed
+				}),
+				wait.Timeout(5*time.Second),
+				wait.Gap(10*time.Millisecond),
+			))
+
+			// The response of the metrics source isn't reflected in the
+			// status of the deployment
+			ds, err := m.state.DeploymentByID(nil, d.ID)
+			must.NoError(t, err)
+			must.StrContains(t, ds.StatusDescription, tc.desc)
+			must.StrNotContains(t, ds.StatusDescription, "upstream secret")
+		})
+	}
+}
+
+func TestWatcher_CanaryAnalysis_PausedDeployment(t *testing.T) {
+	ci.Parallel(t)
+	w, m := defaultTestDeploymentWatcher(t)
+
+	m.On("UpdateDeploymentStatus", mocker.Anything).Return(nil)
+	m.On("UpdateAllocDesiredTransition", mocker.Anything).Return(nil).Maybe()
+
+	// The analysis doesn't run before the runs applied below, so only they
+	// could update the deployment
+	w.canaryAnalysisSources = map[string]string{"prometheus": testPrometheus(t, map[string]string{}).URL}
+	analysis := &structs.CanaryAnalysis{
+		Source:         "prometheus",
+		Interval:       time.Hour,
+		Window:         time.Minute,
+		SuccessfulRuns: 1,
+		OnFailure:      structs.CanaryAnalysisOnFailureFail,
+		Metrics: []*structs.CanaryMetric{{
+			Name:  "errors",
+			Query: `errors{alloc_id=~"{{ .AllocIDs }}"}`,
+			Max:   pointer.Of(0.01),
+		}},
+	}
+	d, _, _ := testCanaryAnalysisDeployment(t, m, analysis)
+
+	w.SetEnabled(true, m.state)
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return watchersCount(w) == 1 }),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	w.l.RLock()
+	dw := w.watchers[d.ID]
+	w.l.RUnlock()
+
+	// Pause the deployment before the outcome of the analysis is applied
+	must.NoError(t, m.state.UpdateDeploymentStatus(structs.MsgTypeTestSetup, m.nextIndex(), &structs.DeploymentStatusUpdateRequest{
+		DeploymentUpdate: &structs.DeploymentStatusUpdate{
+			DeploymentID:      d.ID,
+			Status:            structs.DeploymentStatusPaused,
+			StatusDescription: structs.DeploymentStatusDescriptionPaused,
+		},
+	}))
+
+	failed := &canaryAnalysisRun{group: "web", analysis: analysis, run: 1, concluded: true,
+		description: `Canary analysis run 1 of group "web" failed: errors=0.2 exceeds max 0.01`,
+		details:     "errors=0.2 exceeds max 0.01"}
+	must.False(t, dw.sendCanaryAnalysisRun(failed))
+	passed := &canaryAnalysisRun{group: "web", analysis: analysis, run: 1, concluded: true, passed: true,
+		description: `Canary analysis run 1 of group "web" passed: errors=0`,
+		details:     "errors=0"}
+	must.False(t, dw.sendCanaryAnalysisRun(passed))
+
+	ds, err := m.state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.DeploymentStatusPaused, ds.Status)
+	must.Eq(t, structs.DeploymentStatusDescriptionPaused, ds.StatusDescription)
+	must.False(t, dw.passedCanaryAnalysis("web"))
+}
+
+func TestWatcher_CanaryAnalysis_RecordRuns(t *testing.T) {
+	ci.Parallel(t)
+	w, m := defaultTestDeploymentWatcher(t)
+
+	var l sync.Mutex
+	var descs []string
+	m.On("UpdateDeploymentStatus", mocker.Anything).Run(func(args mocker.Arguments) {
+		u := args.Get(0).(*structs.DeploymentStatusUpdateRequest)
+		l.Lock()
+		defer l.Unlock()
+		descs = append(descs, u.DeploymentUpdate.StatusDescription)
+	}).Return(nil)
+	m.On("UpdateAllocDesiredTransition", mocker.Anything).Return(nil).Maybe()
+
+	values := make(map[string]string)
+	w.canaryAnalysisSources = map[string]string{"prometheus": testPrometheus(t, values).URL}
+	analysis := &structs.CanaryAnalysis{
+		Source:         "prometheus",
+		Interval:       50 * time.Millisecond,
+		Window:         time.Minute,
+		SuccessfulRuns: 3,
+		FailureLimit:   1,
+		OnFailure:      structs.CanaryAnalysisOnFailureFail,
+		Metrics: []*structs.CanaryMetric{{
+			Name:  "errors",
+			Query: `errors{alloc_id=~"{{ .AllocIDs }}"}`,
+			Max:   pointer.Of(0.01),
+		}},
+	}
+	d, canary, _ := testCanaryAnalysisDeployment(t, m, analysis)
+	values[canary.ID] = "0.2"
+
+	w.SetEnabled(true, m.state)
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			ds, _ := m.state.DeploymentByID(nil, d.ID)
+			return ds.Status == structs.DeploymentStatusFailed
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	// Each run is recorded before the outcome of the analysis
+	l.Lock()
+	defer l.Unlock()
+	must.Len(t, 3, descs)
+	must.Eq(t, []string{
+		`Canary analysis run 1 of group "web" failed: errors=0.2 exceeds max 0.01`,
+		`Canary analysis run 2 of group "web" failed: errors=0.2 exceeds max 0.01`,
+	}, descs[:2])
+	must.StrHasPrefix(t, structs.DeploymentStatusDescriptionFailedAnalysis, descs[2])
+}
+
+func TestWatcher_CanaryAnalysis_RecordInconclusiveRun(t *testing.T) {
+	ci.Parallel(t)
+	w, m := defaultTestDeploymentWatcher(t)
+
+	m.On("UpdateDeploymentStatus", mocker.Anything).Return(nil)
+	m.On("UpdateAllocDesiredTransition", mocker.Anything).Return(nil).Maybe()
+
+	// The query returns no data, so every run is inconclusive
+	w.canaryAnalysisSources = map[string]string{"prometheus": testPrometheus(t, map[string]string{}).URL}
+	analysis := &structs.CanaryAnalysis{
+		Source:         "prometheus",
+		Interval:       50 * time.Millisecond,
+		Window:         time.Minute,
+		SuccessfulRuns: 1,
+		OnFailure:      structs.CanaryAnalysisOnFailureFail,
+		Metrics: []*structs.CanaryMetric{{
+			Name:  "errors",
+			Query: `errors{alloc_id=~"{{ .AllocIDs }}"}`,
+			Max:   pointer.Of(0.01),
+		}},
+	}
+	d, _, _ := testCanaryAnalysisDeployment(t, m, analysis)
+
+	w.SetEnabled(true, m.state)
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			ds, _ := m.state.DeploymentByID(nil, d.ID)
+			return ds.StatusDescription != structs.DeploymentStatusDescriptionRunning
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	ds, err := m.state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.DeploymentStatusRunning, ds.Status)
+	must.Eq(t, `Canary analysis run 1 of group "web" is inconclusive: query of metric "errors" returned no data`,
+		ds.StatusDescription)
+	must.False(t, ds.TaskGroups["web"].Promoted)
+}
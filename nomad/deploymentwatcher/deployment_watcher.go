@@ -53,6 +53,10 @@ type deploymentTriggers interface {
 	// upsertDeploymentAllocHealth is used to set the health of allocations in a
 	// deployment
 	upsertDeploymentAllocHealth(req *structs.ApplyDeploymentAllocHealthRequest) (uint64, error)
+
+	// upsertDeploymentRolloutStep is used to move a task group of a deployment
+	// on to its next rollout step
+	upsertDeploymentRolloutStep(req *structs.ApplyDeploymentRolloutStepRequest) (uint64, error)
 }
 
 // deploymentWatcher is used to watch a single deployment and trigger the
@@ -82,6 +86,10 @@ type deploymentWatcher struct {
 	// deploymentUpdateCh is triggered when there is an updated deployment
 	deploymentUpdateCh chan struct{}
 
+	// canaryAnalysisCh receives the runs of the canary analyses, which are
+	// applied by the watch loop
+	canaryAnalysisCh chan *canaryAnalysisRun
+
 	// d is the deployment being watched
 	d *structs.Deployment
 
@@ -101,6 +109,18 @@ type deploymentWatcher struct {
 	// by holding the lock or using the setter and getter methods.
 	latestEval uint64
 
+	// canaryAnalysisSources maps the names of the metrics sources that
+	// canary analyses can query to their address
+	canaryAnalysisSources map[string]string
+
+	// canaryAnalysisPassed marks the task groups whose canaries have passed
+	// their canary analysis. Access should be done through the lock.
+	canaryAnalysisPassed map[string]bool
+
+	// rolloutSteps tracks the progress of the current rollout step of the
+	// task groups rolled out in steps. It is only accessed by the watch loop.
+	rolloutSteps map[string]*rolloutStepProgress
+
 	logger log.Logger
 	ctx    context.Context
 	exitFn context.CancelFunc
@@ -112,27 +132,34 @@ type deploymentWatcher struct {
 func newDeploymentWatcher(parent context.Context, queryLimiter *rate.Limiter,
 	logger log.Logger, state *state.StateStore, d *structs.Deployment,
 	j *structs.Job, triggers deploymentTriggers,
-	deploymentRPC DeploymentRPC, jobRPC JobRPC) *deploymentWatcher {
+	deploymentRPC DeploymentRPC, jobRPC JobRPC,
+	canaryAnalysisSources map[string]string) *deploymentWatcher {
 
 	ctx, exitFn := context.WithCancel(parent)
 	w := &deploymentWatcher{
-		queryLimiter:       queryLimiter,
-		deploymentID:       d.ID,
-		deploymentUpdateCh: make(chan struct{}, 1),
-		d:                  d,
-		j:                  j,
-		state:              state,
-		deploymentTriggers: triggers,
-		DeploymentRPC:      deploymentRPC,
-		JobRPC:             jobRPC,
-		logger:             logger.With("deployment_id", d.ID, "job", j.NamespacedID()),
-		ctx:                ctx,
-		exitFn:             exitFn,
+		queryLimiter:          queryLimiter,
+		deploymentID:          d.ID,
+		deploymentUpdateCh:    make(chan struct{}, 1),
+		canaryAnalysisCh:      make(chan *canaryAnalysisRun),
+		rolloutSteps:          make(map[string]*rolloutStepProgress),
+		d:                     d,
+		j:                     j,
+		state:                 state,
+		deploymentTriggers:    triggers,
+		DeploymentRPC:         deploymentRPC,
+		JobRPC:                jobRPC,
+		canaryAnalysisSources: canaryAnalysisSources,
+		logger:                logger.With("deployment_id", d.ID, "job", j.NamespacedID()),
+		ctx:                   ctx,
+		exitFn:                exitFn,
 	}
 
 	// Start the long lived watcher that scans for allocation updates
 	go w.watch()
 
+	// Start the analysis of the canaries of the task groups that configure it
+	w.startCanaryAnalyses()
+
 	return w
 }
 
@@ -288,7 +315,7 @@ func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStu
 
 	// AutoPromote iff every task group with canaries is marked auto_promote and is healthy. The whole
 	// job version has been incremented, so we promote together. See also AutoRevert
-	for _, dstate := range d.TaskGroups {
+	for name, dstate := range d.TaskGroups {
 
 		// skip auto promote canary validation if the task group has no canaries
 		// to prevent auto promote hanging on mixed canary/non-canary taskgroup deploys
@@ -312,6 +339,11 @@ func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStu
 		if healthyCanaries != dstate.DesiredCanaries {
 			return nil
 		}
+
+		// The canaries must also have passed the canary analysis, if any
+		if !w.passedCanaryAnalysis(name) {
+			return nil
+		}
 	}
 
 	// Send the request
@@ -357,8 +389,27 @@ func (w *deploymentWatcher) FailDeployment(
 	req *structs.DeploymentFailRequest,
 	resp *structs.DeploymentUpdateResponse) error {
 
-	status, desc := structs.DeploymentStatusFailed, structs.DeploymentStatusDescriptionFailedByUser
+	eval := w.getEval()
+	i, rollbackJob, err := w.failDeployment(structs.DeploymentStatusDescriptionFailedByUser, eval)
+	if err != nil {
+		return err
+	}
+
+	// Build the response
+	resp.EvalID = eval.ID
+	resp.EvalCreateIndex = i
+	resp.DeploymentModifyIndex = i
+	resp.Index = i
+	if rollbackJob != nil {
+		resp.RevertedJobVersion = pointer.Of(rollbackJob.Version)
+	}
+	return nil
+}
 
+// failDeployment marks the deployment as failed with the given description,
+// rolling back the job if any of its task groups have auto_revert set. It
+// returns the index of the update and the job rolled back to, if any.
+func (w *deploymentWatcher) failDeployment(desc string, eval *structs.Evaluation) (uint64, *structs.Job, error) {
 	// Determine if we should rollback
 	rollback := false
 	for _, dstate := range w.getDeployment().TaskGroups {
@@ -373,7 +424,7 @@ func (w *deploymentWatcher) FailDeployment(
 		var err error
 		rollbackJob, err = w.latestStableJob()
 		if err != nil {
-			return err
+			return 0, nil, err
 		}
 
 		if rollbackJob != nil {
@@ -384,22 +435,12 @@ func (w *deploymentWatcher) FailDeployment(
 	}
 
 	// Commit the change
-	update := w.getDeploymentStatusUpdate(status, desc)
-	eval := w.getEval()
+	update := w.getDeploymentStatusUpdate(structs.DeploymentStatusFailed, desc)
 	i, err := w.upsertDeploymentStatusUpdate(update, eval, rollbackJob)
 	if err != nil {
-		return err
-	}
-
-	// Build the response
-	resp.EvalID = eval.ID
-	resp.EvalCreateIndex = i
-	resp.DeploymentModifyIndex = i
-	resp.Index = i
-	if rollbackJob != nil {
-		resp.RevertedJobVersion = pointer.Of(rollbackJob.Version)
+		return 0, nil, err
 	}
-	return nil
+	return i, rollbackJob, nil
 }
 
 // StopWatch stops watching the deployment. This should be called whenever a
@@ -429,6 +470,25 @@ func (w *deploymentWatcher) watch() {
 		deadlineTimer = time.NewTimer(time.Until(currentDeadline))
 	}
 
+	// The step timer fires when the pause of a rollout step elapses.
+	stepTimer := time.NewTimer(0)
+	if !stepTimer.Stop() {
+		<-stepTimer.C
+	}
+	defer stepTimer.Stop()
+	resetStepTimer := func(wait time.Duration) {
+		if !stepTimer.Stop() {
+			select {
+			case <-stepTimer.C:
+			default:
+			}
+		}
+		if wait > 0 {
+			stepTimer.Reset(wait)
+		}
+	}
+	resetStepTimer(w.advanceRolloutSteps(time.Now()))
+
 	allocIndex := uint64(1)
 	allocsCh := w.getAllocsCh(allocIndex)
 	var updates *allocUpdates
@@ -497,11 +557,21 @@ FAIL:
 				}
 			}
 
+			// Health changes of the allocations are reflected in the
+			// deployment, so this is where rollout steps become ready
+			resetStepTimer(w.advanceRolloutSteps(time.Now()))
+
 			err := w.nextRegion(w.getStatus())
 			if err != nil {
 				break FAIL
 			}
 
+		case <-stepTimer.C:
+			resetStepTimer(w.advanceRolloutSteps(time.Now()))
+
+		case r := <-w.canaryAnalysisCh:
+			r.appliedCh <- w.applyCanaryAnalysisRun(r)
+
 		case updates = <-allocsCh:
 			if err := updates.err; err != nil {
 				if err == context.Canceled || w.ctx.Err() == context.Canceled {
@@ -781,8 +851,10 @@ func (w *deploymentWatcher) doneGroups(d *structs.Deployment) map[string]bool {
 			continue
 		}
 
-		// Check we have enough healthy currently running allocations
-		groups[name] = healthy[name] >= dstate.DesiredTotal
+		// Check we have enough healthy currently running allocations. Groups
+		// rolled out in steps are done for now once their current step is
+		// healthy, since no progress is made while the step is paused.
+		groups[name] = healthy[name] >= dstate.StepTarget()
 	}
 
 	return groups
@@ -48,6 +48,10 @@ type DeploymentRaftEndpoints interface {
 	// UpdateDeploymentPromotion is used to promote canaries in a deployment
 	UpdateDeploymentPromotion(req *structs.ApplyDeploymentPromoteRequest) (uint64, error)
 
+	// UpdateDeploymentRolloutStep is used to move a task group of a deployment
+	// on to its next rollout step
+	UpdateDeploymentRolloutStep(req *structs.ApplyDeploymentRolloutStepRequest) (uint64, error)
+
 	// UpdateDeploymentAllocHealth is used to set the health of allocations in a
 	// deployment
 	UpdateDeploymentAllocHealth(req *structs.ApplyDeploymentAllocHealthRequest) (uint64, error)
@@ -84,6 +88,10 @@ type Watcher struct {
 	// server interface for Job RPCs
 	jobRPC JobRPC
 
+	// canaryAnalysisSources maps the names of the metrics sources that
+	// canary analyses can query to their address
+	canaryAnalysisSources map[string]string
+
 	// watchers is the set of active watchers, one per deployment
 	watchers map[string]*deploymentWatcher
 
@@ -105,15 +113,17 @@ func NewDeploymentsWatcher(logger log.Logger,
 	deploymentRPC DeploymentRPC, jobRPC JobRPC,
 	stateQueriesPerSecond float64,
 	updateBatchDuration time.Duration,
+	canaryAnalysisSources map[string]string,
 ) *Watcher {
 
 	return &Watcher{
-		raft:                raft,
-		deploymentRPC:       deploymentRPC,
-		jobRPC:              jobRPC,
-		queryLimiter:        rate.NewLimiter(rate.Limit(stateQueriesPerSecond), 100),
-		updateBatchDuration: updateBatchDuration,
-		logger:              logger.Named("deployments_watcher"),
+		raft:                  raft,
+		deploymentRPC:         deploymentRPC,
+		jobRPC:                jobRPC,
+		canaryAnalysisSources: canaryAnalysisSources,
+		queryLimiter:          rate.NewLimiter(rate.Limit(stateQueriesPerSecond), 100),
+		updateBatchDuration:   updateBatchDuration,
+		logger:                logger.Named("deployments_watcher"),
 	}
 }
 
@@ -301,7 +311,7 @@ func (w *Watcher) addLocked(d *structs.Deployment) (*deploymentWatcher, error) {
 	}
 
 	watcher := newDeploymentWatcher(w.ctx, w.queryLimiter, w.logger, w.state, d, job,
-		w, w.deploymentRPC, w.jobRPC)
+		w, w.deploymentRPC, w.jobRPC, w.canaryAnalysisSources)
 	w.watchers[d.ID] = watcher
 	return watcher, nil
 }
@@ -482,3 +492,8 @@ func (w *Watcher) upsertDeploymentPromotion(req *structs.ApplyDeploymentPromoteR
 func (w *Watcher) upsertDeploymentAllocHealth(req *structs.ApplyDeploymentAllocHealthRequest) (uint64, error) {
 	return w.raft.UpdateDeploymentAllocHealth(req)
 }
+
+// upsertDeploymentRolloutStep commits the given rollout step change to Raft
+func (w *Watcher) upsertDeploymentRolloutStep(req *structs.ApplyDeploymentRolloutStepRequest) (uint64, error) {
+	return w.raft.UpdateDeploymentRolloutStep(req)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package deploymentwatcher
+
+import (
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// rolloutStepProgress tracks the progress of the current rollout step of a
+// task group.
+type rolloutStepProgress struct {
+	// step is the index of the rollout step
+	step int
+
+	// healthyAt is the time at which the allocations of the step were first
+	// seen healthy, from which its pause elapses
+	healthyAt time.Time
+
+	// done marks that the next step has been started
+	done bool
+}
+
+// advanceRolloutSteps starts the next rollout step of each task group whose
+// current step is healthy and whose pause has elapsed. It returns the time to
+// wait for the next pause to elapse, or zero if no pause is pending.
+func (w *deploymentWatcher) advanceRolloutSteps(now time.Time) time.Duration {
+	d := w.getDeployment()
+	if d == nil || d.Status != structs.DeploymentStatusRunning {
+		return 0
+	}
+
+	var next time.Duration
+	for name, dstate := range d.TaskGroups {
+		if !dstate.HasPendingSteps() {
+			continue
+		}
+
+		tg := w.j.LookupTaskGroup(name)
+		if tg == nil || tg.Update == nil || len(tg.Update.Steps) != dstate.RolloutSteps {
+			continue
+		}
+
+		progress, ok := w.rolloutSteps[name]
+		if !ok || progress.step != dstate.CurrentStep {
+			progress = &rolloutStepProgress{step: dstate.CurrentStep}
+			w.rolloutSteps[name] = progress
+		}
+		if progress.done {
+			continue
+		}
+
+		if dstate.HealthyAllocs < dstate.StepTarget() {
+			progress.healthyAt = time.Time{}
+			continue
+		}
+		if progress.healthyAt.IsZero() {
+			progress.healthyAt = now
+		}
+
+		if wait := tg.Update.Steps[dstate.CurrentStep].Pause - now.Sub(progress.healthyAt); wait > 0 {
+			if next == 0 || wait < next {
+				next = wait
+			}
+			continue
+		}
+
+		step := dstate.CurrentStep + 1
+		_, err := w.upsertDeploymentRolloutStep(&structs.ApplyDeploymentRolloutStepRequest{
+			DeploymentID: d.ID,
+			Group:        name,
+			Step:         step,
+			Percent:      tg.Update.Steps[step].Percent,
+			Eval:         w.getEval(),
+		})
+		if err != nil {
+			w.logger.Error("failed to start rollout step", "group", name, "step", step+1, "error", err)
+			continue
+		}
+
+		w.logger.Debug("started rollout step", "group", name, "step", step+1)
+		progress.done = true
+	}
+
+	return next
+}
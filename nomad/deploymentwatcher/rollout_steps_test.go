@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package deploymentwatcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+	mocker "github.com/stretchr/testify/mock"
+)
+
+func TestWatcher_RolloutSteps(t *testing.T) {
+	ci.Parallel(t)
+	w, m := defaultTestDeploymentWatcher(t)
+
+	m.On("UpdateDeploymentRolloutStep", mocker.MatchedBy(func(req *structs.ApplyDeploymentRolloutStepRequest) bool {
+		return req.Group == "web" && req.Step == 1 && req.Percent == 100 && req.Eval != nil
+	})).Return(nil).Once()
+
+	j := mock.Job()
+	j.TaskGroups[0].Count = 4
+	j.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	j.TaskGroups[0].Update.Steps = []*structs.RolloutStep{
+		{Percent: 50, Pause: 200 * time.Millisecond},
+		{Percent: 100},
+	}
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.TaskGroups["web"].DesiredTotal = 4
+	d.TaskGroups["web"].RolloutSteps = 2
+	d.TaskGroups["web"].StepPercent = 50
+	must.NoError(t, m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), nil, j))
+	must.NoError(t, m.state.UpsertDeployment(m.nextIndex(), d))
+
+	w.SetEnabled(true, m.state)
+
+	// The step doesn't move on while its allocations aren't healthy
+	time.Sleep(300 * time.Millisecond)
+	m.AssertNotCalled(t, "UpdateDeploymentRolloutStep", mocker.Anything)
+
+	healthyAt := time.Now()
+	d = d.Copy()
+	d.TaskGroups["web"].HealthyAllocs = 2
+	must.NoError(t, m.state.UpsertDeployment(m.nextIndex(), d))
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			ds, _ := m.state.DeploymentByID(nil, d.ID)
+			return ds.TaskGroups["web"].CurrentStep == 1
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	must.GreaterEq(t, 200*time.Millisecond, time.Since(healthyAt))
+
+	ds, err := m.state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	must.Eq(t, 100, ds.TaskGroups["web"].StepPercent)
+	must.False(t, ds.TaskGroups["web"].HasPendingSteps())
+
+	// An evaluation is created to place the allocations of the next step
+	evals, err := m.state.EvalsByJob(nil, j.Namespace, j.ID)
+	must.NoError(t, err)
+	must.Len(t, 1, evals)
+}
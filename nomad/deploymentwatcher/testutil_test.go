@@ -155,6 +155,12 @@ func (m *mockBackend) UpdateDeploymentPromotion(req *structs.ApplyDeploymentProm
 	return i, m.state.UpdateDeploymentPromotion(structs.MsgTypeTestSetup, i, req)
 }
 
+func (m *mockBackend) UpdateDeploymentRolloutStep(req *structs.ApplyDeploymentRolloutStepRequest) (uint64, error) {
+	m.Called(req)
+	i := m.nextIndex()
+	return i, m.state.UpdateDeploymentRolloutStep(structs.MsgTypeTestSetup, i, req)
+}
+
 // matchDeploymentPromoteRequestConfig is used to configure the matching
 // function
 type matchDeploymentPromoteRequestConfig struct {
@@ -0,0 +1,282 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"golang.org/x/time/rate"
+)
+
+// dispatchLocks serializes dispatches of each parameterized job with limits,
+// so that concurrent dispatches can't exceed the limits between checking them
+// and registering the dispatched job. Dispatches of different jobs don't
+// block each other.
+type dispatchLocks struct {
+	l     sync.Mutex
+	locks map[structs.NamespacedID]*dispatchLock
+}
+
+// dispatchLock is the lock of a single parameterized job, removed once no
+// dispatch holds or waits for it.
+type dispatchLock struct {
+	l    sync.Mutex
+	refs int
+}
+
+// lock acquires the lock of the parameterized job and returns the function
+// that releases it.
+func (d *dispatchLocks) lock(id structs.NamespacedID) func() {
+	d.l.Lock()
+	if d.locks == nil {
+		d.locks = make(map[structs.NamespacedID]*dispatchLock)
+	}
+	jobLock, ok := d.locks[id]
+	if !ok {
+		jobLock = new(dispatchLock)
+		d.locks[id] = jobLock
+	}
+	jobLock.refs++
+	d.l.Unlock()
+
+	jobLock.l.Lock()
+	return func() {
+		jobLock.l.Unlock()
+
+		d.l.Lock()
+		defer d.l.Unlock()
+		jobLock.refs--
+		if jobLock.refs == 0 {
+			delete(d.locks, id)
+		}
+	}
+}
+
+// dispatchAllowed returns whether a dispatched job of the parameterized job
+// may be started without exceeding its concurrency and rate limits. If the
+// rate limit is exhausted, it also returns the time at which the next
+// dispatched job may be started.
+func dispatchAllowed(ws memdb.WatchSet, snap *state.StateSnapshot, parent *structs.Job, now time.Time) (bool, time.Time, error) {
+	config := parent.ParameterizedJob
+	if !config.Limited() {
+		return true, time.Time{}, nil
+	}
+
+	summary, err := snap.JobSummaryByID(ws, parent.Namespace, parent.ID)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	// The children summary tracks the dispatched jobs, so the limits can be
+	// checked without scanning every dispatched job of the parent.
+	var active int
+	var recent []int64
+	if summary != nil && summary.Children != nil {
+		active = int(summary.Children.Pending + summary.Children.Running)
+		if config.RateLimit != nil {
+			since := now.Add(-config.RateLimit.Interval).UnixNano()
+			for _, submitTime := range summary.Children.DispatchTimes {
+				if submitTime > since {
+					recent = append(recent, submitTime)
+				}
+			}
+		}
+	}
+
+	if config.MaxConcurrent > 0 && active >= config.MaxConcurrent {
+		return false, time.Time{}, nil
+	}
+
+	if config.RateLimit != nil && len(recent) >= config.RateLimit.Limit {
+		// A dispatched job may be started once enough of the recent ones have
+		// left the interval to bring their count below the limit.
+		slices.Sort(recent)
+		oldest := recent[len(recent)-config.RateLimit.Limit]
+		return false, time.Unix(0, oldest).Add(config.RateLimit.Interval), nil
+	}
+
+	return true, time.Time{}, nil
+}
+
+// releaseQueuedDispatches registers the queued dispatches of parameterized jobs
+// as their concurrency and rate limits allow. It should only be run on the
+// leader.
+func (s *Server) releaseQueuedDispatches(stopCh chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	limiter := rate.NewLimiter(dispatchQueueReleaseRateLimit, 1)
+	for {
+		if err := limiter.Wait(ctx); err != nil {
+			return
+		}
+
+		ws := memdb.NewWatchSet()
+		ws.Add(s.State().AbandonCh())
+
+		released, next, err := s.releaseQueuedDispatchesOnce(ws, time.Now())
+		if err != nil {
+			s.logger.Error("failed to release queued dispatches", "error", err)
+			continue
+		}
+		if released {
+			continue
+		}
+
+		// Wait for the state to change or for the rate limit of a job with
+		// queued dispatches to allow another dispatched job.
+		if next.IsZero() {
+			if err := ws.WatchCtx(ctx); err != nil {
+				return
+			}
+			continue
+		}
+
+		watchCtx, watchCancel := context.WithDeadline(ctx, next)
+		_ = ws.WatchCtx(watchCtx)
+		watchCancel()
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// releaseQueuedDispatchesOnce releases at most one queued dispatch of each
+// parameterized job. It returns whether any dispatch was released and the
+// earliest time at which the rate limit of a job with queued dispatches
+// allows another dispatched job.
+func (s *Server) releaseQueuedDispatchesOnce(ws memdb.WatchSet, now time.Time) (bool, time.Time, error) {
+	snap, err := s.State().Snapshot()
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	iter, err := snap.QueuedDispatches(ws)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	parents := make(map[structs.NamespacedID]struct{})
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		dispatch := raw.(*structs.QueuedDispatch)
+		parents[structs.NamespacedID{ID: dispatch.ParentID, Namespace: dispatch.Namespace}] = struct{}{}
+	}
+
+	var released bool
+	var next time.Time
+	for parentID := range parents {
+		ok, at, err := s.releaseQueuedDispatch(ws, parentID, now)
+		if err != nil {
+			return false, time.Time{}, err
+		}
+		released = released || ok
+		if !at.IsZero() && (next.IsZero() || at.Before(next)) {
+			next = at
+		}
+	}
+
+	return released, next, nil
+}
+
+// releaseQueuedDispatch releases the oldest queued dispatch of the
+// parameterized job if its limits allow it. It returns whether the dispatch
+// was released and, if the rate limit is exhausted, the time at which it
+// allows another dispatched job.
+func (s *Server) releaseQueuedDispatch(ws memdb.WatchSet, parentID structs.NamespacedID, now time.Time) (bool, time.Time, error) {
+	defer s.dispatchLocks.lock(parentID)()
+
+	// The state is read again while holding the lock, since dispatches of the
+	// job may have been registered or queued in the meantime.
+	snap, err := s.State().Snapshot()
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	queue, err := snap.QueuedDispatchesByParent(ws, parentID.Namespace, parentID.ID)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	if len(queue) == 0 {
+		return false, time.Time{}, nil
+	}
+
+	parent, err := snap.JobByID(ws, parentID.Namespace, parentID.ID)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	// Queued dispatches of parameterized jobs that no longer exist are
+	// dropped, and those of stopped jobs are held until the job is started
+	// again.
+	if parent == nil || !parent.IsParameterized() {
+		ids := make([]string, 0, len(queue))
+		for _, dispatch := range queue {
+			ids = append(ids, dispatch.ID)
+		}
+		if err := s.deleteQueuedDispatches(parentID.Namespace, ids); err != nil {
+			return false, time.Time{}, err
+		}
+		s.logger.Debug("dropped queued dispatches of removed parameterized job",
+			"job_id", parentID.ID, "namespace", parentID.Namespace, "dispatches", len(ids))
+		return false, time.Time{}, nil
+	}
+	if parent.Stop {
+		return false, time.Time{}, nil
+	}
+
+	allowed, at, err := dispatchAllowed(ws, snap, parent, now)
+	if err != nil || !allowed {
+		return false, at, err
+	}
+
+	dispatch := slices.MinFunc(queue, func(a, b *structs.QueuedDispatch) int {
+		return cmp.Compare(a.CreateIndex, b.CreateIndex)
+	})
+
+	// The dispatched job may already have been registered if the queued
+	// dispatch failed to be removed afterwards.
+	existing, err := snap.JobByID(nil, dispatch.Namespace, dispatch.ID)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	if existing == nil {
+		dispatchJob := deriveDispatchedJob(parent, dispatch.ID, dispatch.Meta, dispatch.Payload, dispatch.IdempotencyToken)
+		wr := structs.WriteRequest{Region: s.Region(), Namespace: dispatch.Namespace}
+		if err := s.registerDispatchedJob(dispatchJob, wr, &structs.JobDispatchResponse{}); err != nil {
+			return false, time.Time{}, err
+		}
+	}
+
+	if err := s.deleteQueuedDispatches(dispatch.Namespace, []string{dispatch.ID}); err != nil {
+		return false, time.Time{}, err
+	}
+
+	s.logger.Debug("released queued dispatch", "job_id", dispatch.ID, "namespace", dispatch.Namespace)
+	return true, time.Time{}, nil
+}
+
+// deleteQueuedDispatches removes the given dispatches from the queue via Raft.
+func (s *Server) deleteQueuedDispatches(namespace string, ids []string) error {
+	req := &structs.DispatchQueueDeleteRequest{
+		Namespace:    namespace,
+		IDs:          ids,
+		WriteRequest: structs.WriteRequest{Region: s.Region()},
+	}
+	_, _, err := s.raftApply(structs.DispatchQueueDeleteRequestType, req)
+	return err
+}
@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+func TestDispatchAllowed_RateLimit(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	now := time.Now()
+
+	parent := mock.BatchJob()
+	parent.ParameterizedJob = &structs.ParameterizedJobConfig{
+		RateLimit: &structs.DispatchRateLimit{
+			Limit:    2,
+			Interval: time.Minute,
+		},
+	}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 100, nil, parent))
+
+	dispatchAt := func(index uint64, at time.Time) {
+		child := deriveDispatchedJob(parent, structs.DispatchedID(parent.ID, "", at), nil, nil, "")
+		child.SubmitTime = at.UnixNano()
+		must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, index, nil, child))
+	}
+
+	// Dispatched jobs that left the interval don't count against the limit
+	dispatchAt(101, now.Add(-2*time.Minute))
+	dispatchAt(102, now.Add(-30*time.Second))
+
+	snap, err := store.Snapshot()
+	must.NoError(t, err)
+	allowed, _, err := dispatchAllowed(nil, snap, parent, now)
+	must.NoError(t, err)
+	must.True(t, allowed)
+
+	// Once the limit is reached, the next dispatch is allowed when the oldest
+	// dispatched job in the interval leaves it
+	dispatchAt(103, now.Add(-10*time.Second))
+
+	snap, err = store.Snapshot()
+	must.NoError(t, err)
+	allowed, next, err := dispatchAllowed(nil, snap, parent, now)
+	must.NoError(t, err)
+	must.False(t, allowed)
+	must.Eq(t, now.Add(30*time.Second).UnixNano(), next.UnixNano())
+}
+
+func TestDispatchAllowed_MaxConcurrent(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	now := time.Now()
+
+	parent := mock.BatchJob()
+	parent.ParameterizedJob = &structs.ParameterizedJobConfig{MaxConcurrent: 1}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 100, nil, parent))
+
+	child := deriveDispatchedJob(parent, structs.DispatchedID(parent.ID, "", now), nil, nil, "")
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 101, nil, child))
+
+	snap, err := store.Snapshot()
+	must.NoError(t, err)
+	allowed, _, err := dispatchAllowed(nil, snap, parent, now)
+	must.NoError(t, err)
+	must.False(t, allowed)
+
+	// Dead dispatched jobs don't count against the limit
+	eval := mock.Eval()
+	eval.JobID = child.ID
+	eval.Status = structs.EvalStatusComplete
+	must.NoError(t, store.UpsertEvals(structs.MsgTypeTestSetup, 102, []*structs.Evaluation{eval}))
+
+	snap, err = store.Snapshot()
+	must.NoError(t, err)
+	allowed, _, err = dispatchAllowed(nil, snap, parent, now)
+	must.NoError(t, err)
+	must.True(t, allowed)
+}
+
+func TestDispatchLocks(t *testing.T) {
+	ci.Parallel(t)
+
+	var locks dispatchLocks
+	a := structs.NamespacedID{ID: "a", Namespace: structs.DefaultNamespace}
+	b := structs.NamespacedID{ID: "b", Namespace: structs.DefaultNamespace}
+
+	unlockA := locks.lock(a)
+
+	// Dispatches of other jobs aren't blocked
+	unlockB := locks.lock(b)
+	unlockB()
+
+	acquired := make(chan struct{})
+	go func() {
+		defer locks.lock(a)()
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("lock of the same job acquired while held")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlockA()
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("lock not acquired after release")
+	}
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool {
+			locks.l.Lock()
+			defer locks.l.Unlock()
+			return len(locks.locks) == 0
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+}
@@ -118,6 +118,12 @@ type EvalBroker struct {
 	enqueuedTime map[string]time.Time
 	dequeuedTime map[string]time.Time
 
+	// fairShare is the ratio of cluster usage to entitled share of each
+	// namespace, and is set when fair-share scheduling is enabled. Ready
+	// evaluations of the same priority are then dequeued from the namespace
+	// with the lowest ratio first.
+	fairShare map[string]float64
+
 	l sync.RWMutex
 }
 
@@ -481,18 +487,66 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 		return b.dequeueForSched(eligibleSched[0])
 
 	default:
-		// Multiple tasks. We pick a random task so that we fairly
-		// distribute work.
+		// Multiple tasks. With fair-share scheduling we pick the task of the
+		// namespace furthest below its share.
+		if b.fairShare != nil {
+			best, bestRatio := "", 0.0
+			for _, sched := range eligibleSched {
+				readyQueue := b.ready[sched]
+				ratio := b.fairShare[readyQueue[b.fairShareIndex(readyQueue)].Namespace]
+				if best == "" || ratio < bestRatio {
+					best, bestRatio = sched, ratio
+				}
+			}
+			return b.dequeueForSched(best)
+		}
+
+		// Otherwise we pick a random task so that we fairly distribute work.
 		offset := rand.Intn(n)
 		return b.dequeueForSched(eligibleSched[offset])
 	}
 }
 
+// fairShareIndex returns the index of the next evaluation to dequeue from the
+// ready queue with fair-share scheduling. This is the evaluation of the
+// namespace with the lowest usage ratio among the evaluations with the highest
+// priority, and the oldest evaluation of that namespace. This assumes locks
+// are held and that the queue is not empty.
+func (b *EvalBroker) fairShareIndex(readyQueue ReadyEvaluations) int {
+	priority := readyQueue[0].Priority
+	best := 0
+	bestRatio := b.fairShare[readyQueue[0].Namespace]
+	for i, eval := range readyQueue {
+		if eval.Priority != priority {
+			continue
+		}
+		ratio := b.fairShare[eval.Namespace]
+		if ratio < bestRatio || (ratio == bestRatio && eval.CreateIndex < readyQueue[best].CreateIndex) {
+			best, bestRatio = i, ratio
+		}
+	}
+	return best
+}
+
+// SetFairShare sets the ratio of cluster usage to entitled share of each
+// namespace used to order evaluations. Namespaces that are not included are
+// not using any of the cluster. Passing nil disables fair-share scheduling.
+func (b *EvalBroker) SetFairShare(ratios map[string]float64) {
+	b.l.Lock()
+	defer b.l.Unlock()
+	b.fairShare = ratios
+}
+
 // dequeueForSched is used to dequeue the next work item for a given scheduler.
 // This assumes locks are held and that this scheduler has work
 func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string, error) {
 	readyQueue := b.ready[sched]
-	raw := heap.Pop(&readyQueue)
+	var raw interface{}
+	if b.fairShare != nil {
+		raw = heap.Remove(&readyQueue, b.fairShareIndex(readyQueue))
+	} else {
+		raw = heap.Pop(&readyQueue)
+	}
 	b.ready[sched] = readyQueue
 	eval := raw.(*structs.Evaluation)
 
@@ -849,6 +903,7 @@ func (b *EvalBroker) flush() {
 	b.delayHeap = delayheap.NewDelayHeap()
 	b.enqueuedTime = make(map[string]time.Time)
 	b.dequeuedTime = make(map[string]time.Time)
+	b.fairShare = nil
 }
 
 // evalWrapper satisfies the HeapNode interface
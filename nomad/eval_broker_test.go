@@ -710,6 +710,42 @@ func TestEvalBroker_Dequeue_FIFO(t *testing.T) {
 	}
 }
 
+// Ensure namespaces below their fair share are dequeued first at a fixed
+// priority
+func TestEvalBroker_Dequeue_FairShare(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+	b.SetFairShare(map[string]float64{
+		"over":  2,
+		"under": 0.5,
+	})
+
+	newEval := func(namespace string, priority int, index uint64) *structs.Evaluation {
+		eval := mock.Eval()
+		eval.Namespace = namespace
+		eval.Priority = priority
+		eval.CreateIndex = index
+		eval.ModifyIndex = index
+		b.Enqueue(eval)
+		return eval
+	}
+
+	over1 := newEval("over", 50, 1)
+	over2 := newEval("over", 50, 2)
+	under := newEval("under", 50, 3)
+	idle := newEval("idle", 50, 4)
+	high := newEval("over", 70, 5)
+
+	// Priority still takes precedence, then namespaces by ratio and finally
+	// evaluations by create index
+	for _, expected := range []*structs.Evaluation{high, idle, under, over1, over2} {
+		out, _, err := b.Dequeue(defaultSched, time.Second)
+		must.NoError(t, err)
+		must.Eq(t, expected.ID, out.ID)
+	}
+}
+
 // Ensure fairness between schedulers
 func TestEvalBroker_Dequeue_Fairness(t *testing.T) {
 	ci.Parallel(t)
@@ -6,12 +6,14 @@ package nomad
 import (
 	"context"
 	"io"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/go-msgpack/v2/codec"
 
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/auth"
 	"github.com/hashicorp/nomad/nomad/stream"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -69,6 +71,13 @@ func (e *Event) stream(conn io.ReadWriteCloser) {
 		return
 	}
 
+	// Some topics are filtered per event. The ACL used for this is replaced
+	// whenever the subscription is re-authenticated, so policy changes apply
+	// to the events that follow.
+	var eventACL atomic.Pointer[acl.ACL]
+	eventACL.Store(resolvedACL)
+	claim := auth.IdentityToACLClaim(args.GetIdentity(), e.srv.State())
+
 	// Generate the subscription request
 	subReq := &stream.SubscribeRequest{
 		Token:  args.AuthToken,
@@ -85,8 +94,14 @@ func (e *Event) stream(conn io.ReadWriteCloser) {
 			if err != nil {
 				return err
 			}
-			_, err = e.validateACL(args.Namespace, args.Topics, resolvedACL)
-			return err
+			if _, err = e.validateACL(args.Namespace, args.Topics, resolvedACL); err != nil {
+				return err
+			}
+			eventACL.Store(resolvedACL)
+			return nil
+		},
+		Allow: func(event structs.Event) bool {
+			return allowEvent(eventACL.Load(), claim, event)
 		},
 	}
 
@@ -283,6 +298,10 @@ func validateNsOp(namespace string, topics map[structs.Topic][]string, aclObj *a
 			if ok := aclObj.AllowNsOp(namespace, acl.NamespaceCapabilityReadJob); !ok {
 				return structs.ErrPermissionDenied
 			}
+		case structs.TopicVariable,
+			structs.TopicNamespace:
+			// Permissions depend on the variable path or namespace name,
+			// so these events are filtered by allowEvent instead.
 		case structs.TopicNode:
 			if ok := aclObj.AllowNodeRead(); !ok {
 				return structs.ErrPermissionDenied
@@ -303,3 +322,18 @@ func validateNsOp(namespace string, topics map[structs.Topic][]string, aclObj *a
 	return nil
 
 }
+
+// allowEvent checks the permissions of topics which can only be validated
+// against the event itself. Variable events require the read capability on
+// the variable path, and namespace events require access to the namespace.
+func allowEvent(aclObj *acl.ACL, claim *acl.ACLClaim, event structs.Event) bool {
+	switch event.Topic {
+	case structs.TopicVariable:
+		return aclObj.AllowVariableOperation(event.Namespace, event.Key,
+			acl.VariablesCapabilityRead, claim)
+	case structs.TopicNamespace:
+		return aclObj.AllowNamespace(event.Key)
+	default:
+		return true
+	}
+}
@@ -548,6 +548,37 @@ func TestEventStream_validateACL(t *testing.T) {
 	})
 }
 
+func TestEventStream_allowEvent(t *testing.T) {
+	ci.Parallel(t)
+
+	policy, err := acl.Parse(mock.NamespacePolicyWithVariables("foo", "read", nil,
+		map[string][]string{"app/*": {acl.VariablesCapabilityRead}}))
+	must.NoError(t, err)
+	testACL, err := acl.NewACL(false, []*acl.Policy{policy})
+	must.NoError(t, err)
+
+	variableEvent := func(ns, path string) structs.Event {
+		return structs.Event{Topic: structs.TopicVariable, Namespace: ns, Key: path}
+	}
+	namespaceEvent := func(name string) structs.Event {
+		return structs.Event{Topic: structs.TopicNamespace, Key: name}
+	}
+
+	must.True(t, allowEvent(testACL, nil, variableEvent("foo", "app/db")))
+	must.False(t, allowEvent(testACL, nil, variableEvent("foo", "other/db")))
+	must.False(t, allowEvent(testACL, nil, variableEvent("bar", "app/db")))
+	must.True(t, allowEvent(testACL, nil, namespaceEvent("foo")))
+	must.False(t, allowEvent(testACL, nil, namespaceEvent("bar")))
+	must.True(t, allowEvent(testACL, nil, structs.Event{Topic: structs.TopicJob}))
+
+	// topics filtered per event don't require a capability to subscribe
+	topics := map[structs.Topic][]string{
+		structs.TopicVariable:  {"*"},
+		structs.TopicNamespace: {"*"},
+	}
+	must.NoError(t, validateNsOp("bar", topics, testACL))
+}
+
 // TestEventStream_ACL_Update_Close_Stream asserts that an active subscription
 // is closed after the token is no longer valid
 func TestEventStream_ACL_Update_Close_Stream(t *testing.T) {
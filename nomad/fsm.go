@@ -18,6 +18,7 @@ import (
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/stream"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/scheduler"
 	"github.com/hashicorp/raft"
@@ -57,6 +58,8 @@ const (
 	JobSubmissionSnapshot                SnapshotType = 29
 	RootKeySnapshot                      SnapshotType = 30
 	HostVolumeSnapshot                   SnapshotType = 31
+	DispatchQueueSnapshot                SnapshotType = 32
+	PeriodicHistorySnapshot              SnapshotType = 33
 
 	// TimeTableSnapshot
 	// Deprecated: Nomad no longer supports TimeTable snapshots since 1.9.2
@@ -108,6 +111,8 @@ var snapshotTypeStrings = map[SnapshotType]string{
 	JobSubmissionSnapshot:                "JobSubmission",
 	RootKeySnapshot:                      "WrappedRootKeys",
 	HostVolumeSnapshot:                   "HostVolumeSnapshot",
+	DispatchQueueSnapshot:                "DispatchQueue",
+	PeriodicHistorySnapshot:              "PeriodicHistory",
 	NamespaceSnapshot:                    "Namespace",
 }
 
@@ -192,6 +197,10 @@ type FSMConfig struct {
 	// EventBufferSize is the amount of messages to hold in memory
 	EventBufferSize int64
 
+	// EventLog is the optional durable log events are written to. It is
+	// shared by the state stores created when restoring snapshots.
+	EventLog *stream.EventLog
+
 	// JobTrackedVersions is the number of historic job versions that are kept.
 	JobTrackedVersions int
 }
@@ -204,6 +213,7 @@ func NewFSM(config *FSMConfig) (*nomadFSM, error) {
 		Region:             config.Region,
 		EnablePublisher:    config.EnableEventBroker,
 		EventBufferSize:    config.EventBufferSize,
+		EventLog:           config.EventLog,
 		JobTrackedVersions: config.JobTrackedVersions,
 	}
 	state, err := state.NewStateStore(sconfig)
@@ -297,8 +307,14 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyDeploymentPromotion(msgType, buf[1:], log.Index)
 	case structs.DeploymentAllocHealthRequestType:
 		return n.applyDeploymentAllocHealth(msgType, buf[1:], log.Index)
+	case structs.DeploymentRolloutStepRequestType:
+		return n.applyDeploymentRolloutStep(msgType, buf[1:], log.Index)
 	case structs.DeploymentDeleteRequestType:
 		return n.applyDeploymentDelete(buf[1:], log.Index)
+	case structs.DispatchQueueUpsertRequestType:
+		return n.applyDispatchQueueUpsert(msgType, buf[1:], log.Index)
+	case structs.DispatchQueueDeleteRequestType:
+		return n.applyDispatchQueueDelete(msgType, buf[1:], log.Index)
 	case structs.JobStabilityRequestType:
 		return n.applyJobStability(buf[1:], log.Index)
 	case structs.ACLPolicyUpsertRequestType:
@@ -832,6 +848,12 @@ func (n *nomadFSM) handleJobDeregister(index uint64, jobID, namespace string, pu
 		// the job was updated to be non-periodic, thus checking if it is periodic
 		// doesn't ensure we clean it up properly.
 		n.state.DeletePeriodicLaunchTxn(index, namespace, jobID, tx)
+
+		// The history of a periodic job is kept when the jobs it launched are
+		// garbage collected, but not when the periodic job itself is purged.
+		if err := n.state.DeletePeriodicHistoryTxn(index, namespace, jobID, tx); err != nil {
+			return err
+		}
 	} else {
 		// Get the current job and mark it as stopped and re-insert it.
 		ws := memdb.NewWatchSet()
@@ -1091,6 +1113,57 @@ func (n *nomadFSM) applyDeploymentPromotion(msgType structs.MessageType, buf []b
 	return nil
 }
 
+// applyDeploymentRolloutStep is used to move a task group of a deployment on to
+// its next rollout step
+func (n *nomadFSM) applyDeploymentRolloutStep(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_deployment_rollout_step"}, time.Now())
+	var req structs.ApplyDeploymentRolloutStepRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpdateDeploymentRolloutStep(msgType, index, &req); err != nil {
+		n.logger.Error("UpdateDeploymentRolloutStep failed", "error", err)
+		return err
+	}
+
+	n.handleUpsertedEval(req.Eval)
+	return nil
+}
+
+// applyDispatchQueueUpsert is used to queue a dispatch of a parameterized job
+func (n *nomadFSM) applyDispatchQueueUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_dispatch_queue_upsert"}, time.Now())
+	var req structs.DispatchQueueUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertQueuedDispatch(msgType, index, req.Dispatch); err != nil {
+		n.logger.Error("UpsertQueuedDispatch failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applyDispatchQueueDelete is used to remove dispatches from the queue of a
+// parameterized job
+func (n *nomadFSM) applyDispatchQueueDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_dispatch_queue_delete"}, time.Now())
+	var req structs.DispatchQueueDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteQueuedDispatches(msgType, index, req.Namespace, req.IDs); err != nil {
+		n.logger.Error("DeleteQueuedDispatches failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
 // applyDeploymentAllocHealth is used to set the health of allocations as part
 // of a deployment
 func (n *nomadFSM) applyDeploymentAllocHealth(msgType structs.MessageType, buf []byte, index uint64) interface{} {
@@ -1480,6 +1553,7 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 		Region:             n.config.Region,
 		EnablePublisher:    n.config.EnableEventBroker,
 		EventBufferSize:    n.config.EventBufferSize,
+		EventLog:           n.config.EventLog,
 		JobTrackedVersions: n.config.JobTrackedVersions,
 	}
 	newState, err := state.NewStateStore(config)
@@ -1891,6 +1965,28 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 				}
 			}
 
+		case DispatchQueueSnapshot:
+			dispatch := new(structs.QueuedDispatch)
+			if err := dec.Decode(dispatch); err != nil {
+				return err
+			}
+			if filter.Include(dispatch) {
+				if err := restore.QueuedDispatchRestore(dispatch); err != nil {
+					return err
+				}
+			}
+
+		case PeriodicHistorySnapshot:
+			run := new(structs.PeriodicRun)
+			if err := dec.Decode(run); err != nil {
+				return err
+			}
+			if filter.Include(run) {
+				if err := restore.PeriodicRunRestore(run); err != nil {
+					return err
+				}
+			}
+
 		default:
 			// Check if this is an enterprise only object being restored
 			restorer, ok := n.enterpriseRestorers[snapType]
@@ -2533,6 +2629,14 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistDispatchQueue(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistPeriodicHistory(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	return nil
 }
 
@@ -3244,6 +3348,38 @@ func (s *nomadSnapshot) persistHostVolumes(sink raft.SnapshotSink, encoder *code
 	return nil
 }
 
+func (s *nomadSnapshot) persistDispatchQueue(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	iter, err := s.snap.QueuedDispatches(nil)
+	if err != nil {
+		return err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		dispatch := raw.(*structs.QueuedDispatch)
+
+		sink.Write([]byte{byte(DispatchQueueSnapshot)})
+		if err := encoder.Encode(dispatch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistPeriodicHistory(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	iter, err := s.snap.PeriodicHistory(nil)
+	if err != nil {
+		return err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		run := raw.(*structs.PeriodicRun)
+
+		sink.Write([]byte{byte(PeriodicHistorySnapshot)})
+		if err := encoder.Encode(run); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Release is a no-op, as we just need to GC the pointer
 // to the state store snapshot. There is nothing to explicitly
 // cleanup.
@@ -2717,6 +2717,63 @@ func TestFSM_SnapshotRestore_ACLBindingRules(t *testing.T) {
 	must.SliceContainsAll(t, restoredACLBindingRules, mockedACLBindingRoles)
 }
 
+func TestFSM_SnapshotRestore_DispatchQueue(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create our initial FSM which will be snapshotted.
+	fsm := testFSM(t)
+	testState := fsm.State()
+
+	dispatch := &structs.QueuedDispatch{
+		ID:        "job/dispatch-1",
+		Namespace: structs.DefaultNamespace,
+		ParentID:  "job",
+		Payload:   []byte("payload"),
+		Meta:      map[string]string{"foo": "bar"},
+	}
+	must.NoError(t, testState.UpsertQueuedDispatch(structs.MsgTypeTestSetup, 10, dispatch))
+
+	// Perform a snapshot restore.
+	restoredFSM := testSnapshotRestore(t, fsm)
+	restoredState := restoredFSM.State()
+
+	out, err := restoredState.QueuedDispatchByID(nil, dispatch.Namespace, dispatch.ID)
+	must.NoError(t, err)
+	must.Eq(t, dispatch, out)
+}
+
+func TestFSM_SnapshotRestore_PeriodicHistory(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create our initial FSM which will be snapshotted.
+	fsm := testFSM(t)
+	testState := fsm.State()
+
+	// Record a launch in the history of a periodic job
+	parent := mock.PeriodicJob()
+	parent.Periodic.FailedJobsHistoryLimit = pointer.Of(1)
+	must.NoError(t, testState.UpsertJob(structs.MsgTypeTestSetup, 10, nil, parent))
+
+	child := parent.Copy()
+	child.ID = parent.ID + structs.PeriodicLaunchSuffix + "1700000000"
+	child.ParentID = parent.ID
+	child.Periodic = nil
+	child.Stop = true
+	must.NoError(t, testState.UpsertJob(structs.MsgTypeTestSetup, 11, nil, child))
+
+	runs, err := testState.PeriodicHistoryByParent(nil, parent.Namespace, parent.ID)
+	must.NoError(t, err)
+	must.Len(t, 1, runs)
+
+	// Perform a snapshot restore.
+	restoredFSM := testSnapshotRestore(t, fsm)
+	restoredState := restoredFSM.State()
+
+	out, err := restoredState.PeriodicHistoryByParent(nil, parent.Namespace, parent.ID)
+	must.NoError(t, err)
+	must.Eq(t, runs, out)
+}
+
 func TestFSM_SnapshotRestore_JobSubmissions(t *testing.T) {
 	ci.Parallel(t)
 
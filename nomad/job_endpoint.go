@@ -85,6 +85,7 @@ func NewJobEndpoints(s *Server, ctx *RPCContext) *Job {
 			jobConsulHook{srv: s},
 			jobNamespaceConstraintCheckHook{srv: s},
 			jobNodePoolValidatingHook{srv: s},
+			jobDependsOnHook{srv: s},
 			&jobValidate{srv: s},
 			&memoryOversubscriptionValidate{srv: s},
 			jobNumaHook{},
@@ -2077,37 +2078,123 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 		}
 	}
 
+	// Dispatches of a parameterized job with limits are serialized, so that
+	// concurrent requests can't exceed the limits between checking them and
+	// registering the dispatched job.
+	dispatchID := structs.DispatchedID(parameterizedJob.ID, args.IdPrefixTemplate, time.Now())
+	payload := snappy.Encode(nil, args.Payload)
+	if parameterizedJob.ParameterizedJob.Limited() {
+		defer j.srv.dispatchLocks.lock(parameterizedJob.NamespacedID())()
+
+		snap, err := j.srv.fsm.State().Snapshot()
+		if err != nil {
+			return err
+		}
+
+		queued, err := snap.QueuedDispatchesByParent(nil, parameterizedJob.Namespace, parameterizedJob.ID)
+		if err != nil {
+			return err
+		}
+
+		// Retried requests of queued dispatches return the queued dispatch
+		if args.IdempotencyToken != "" {
+			for _, dispatch := range queued {
+				if dispatch.IdempotencyToken == args.IdempotencyToken {
+					reply.DispatchedJobID = dispatch.ID
+					reply.Queued = true
+					reply.Index = dispatch.ModifyIndex
+					return nil
+				}
+			}
+		}
+
+		// Dispatches can't jump the queue, so they are only started right
+		// away if nothing is queued.
+		allowed := false
+		if len(queued) == 0 {
+			allowed, _, err = dispatchAllowed(nil, snap, parameterizedJob, time.Now())
+			if err != nil {
+				return err
+			}
+		}
+
+		if !allowed {
+			if parameterizedJob.ParameterizedJob.Queue == nil {
+				return structs.NewErrRPCCodedf(http.StatusTooManyRequests,
+					"dispatch limit of parameterized job %q reached", parameterizedJob.ID)
+			}
+			if len(queued) >= parameterizedJob.ParameterizedJob.Queue.MaxDepth {
+				return structs.NewErrRPCCodedf(http.StatusTooManyRequests,
+					"dispatch queue of parameterized job %q is full", parameterizedJob.ID)
+			}
+
+			queueReq := &structs.DispatchQueueUpsertRequest{
+				Dispatch: &structs.QueuedDispatch{
+					ID:               dispatchID,
+					Namespace:        parameterizedJob.Namespace,
+					ParentID:         parameterizedJob.ID,
+					Payload:          payload,
+					Meta:             args.Meta,
+					IdempotencyToken: args.IdempotencyToken,
+					EnqueueTime:      time.Now().UnixNano(),
+				},
+				WriteRequest: args.WriteRequest,
+			}
+			_, index, err := j.srv.raftApply(structs.DispatchQueueUpsertRequestType, queueReq)
+			if err != nil {
+				j.logger.Error("queuing dispatch failed", "error", err)
+				return err
+			}
+
+			reply.DispatchedJobID = dispatchID
+			reply.Queued = true
+			reply.Index = index
+			return nil
+		}
+	}
+
 	// Derive the child job and commit it via Raft - with initial status
+	dispatchJob := deriveDispatchedJob(parameterizedJob, dispatchID, args.Meta, payload, args.IdempotencyToken)
+	return j.srv.registerDispatchedJob(dispatchJob, args.WriteRequest, reply)
+}
+
+// deriveDispatchedJob returns the job dispatched from the parameterized job
+// with the given ID, meta data and compressed payload.
+func deriveDispatchedJob(parameterizedJob *structs.Job, id string, meta map[string]string, payload []byte, idempotencyToken string) *structs.Job {
 	dispatchJob := parameterizedJob.Copy()
-	dispatchJob.ID = structs.DispatchedID(parameterizedJob.ID, args.IdPrefixTemplate, time.Now())
+	dispatchJob.ID = id
 	dispatchJob.ParentID = parameterizedJob.ID
 	dispatchJob.Name = dispatchJob.ID
 	dispatchJob.SetSubmitTime()
 	dispatchJob.Dispatched = true
 	dispatchJob.Status = ""
 	dispatchJob.StatusDescription = ""
-	dispatchJob.DispatchIdempotencyToken = args.IdempotencyToken
+	dispatchJob.DispatchIdempotencyToken = idempotencyToken
 
 	// Merge in the meta data
-	for k, v := range args.Meta {
+	for k, v := range meta {
 		if dispatchJob.Meta == nil {
-			dispatchJob.Meta = make(map[string]string, len(args.Meta))
+			dispatchJob.Meta = make(map[string]string, len(meta))
 		}
 		dispatchJob.Meta[k] = v
 	}
 
-	// Compress the payload
-	dispatchJob.Payload = snappy.Encode(nil, args.Payload)
+	dispatchJob.Payload = payload
+	return dispatchJob
+}
 
+// registerDispatchedJob commits the dispatched job via Raft and creates its
+// evaluation.
+func (s *Server) registerDispatchedJob(dispatchJob *structs.Job, wr structs.WriteRequest, reply *structs.JobDispatchResponse) error {
 	regReq := &structs.JobRegisterRequest{
 		Job:          dispatchJob,
-		WriteRequest: args.WriteRequest,
+		WriteRequest: wr,
 	}
 
 	// Commit this update via Raft
-	_, jobCreateIndex, err := j.srv.raftApply(structs.JobRegisterRequestType, regReq)
+	_, jobCreateIndex, err := s.raftApply(structs.JobRegisterRequestType, regReq)
 	if err != nil {
-		j.logger.Error("dispatched job register failed", "error")
+		s.logger.Error("dispatched job register failed", "error", err)
 		return err
 	}
 
@@ -2121,7 +2208,7 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 		now := time.Now().UnixNano()
 		eval := &structs.Evaluation{
 			ID:             uuid.Generate(),
-			Namespace:      args.RequestNamespace(),
+			Namespace:      dispatchJob.Namespace,
 			Priority:       dispatchJob.Priority,
 			Type:           dispatchJob.Type,
 			TriggeredBy:    structs.EvalTriggerJobRegister,
@@ -2133,13 +2220,13 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 		}
 		update := &structs.EvalUpdateRequest{
 			Evals:        []*structs.Evaluation{eval},
-			WriteRequest: structs.WriteRequest{Region: args.Region},
+			WriteRequest: structs.WriteRequest{Region: wr.Region},
 		}
 
 		// Commit this evaluation via Raft
-		_, evalIndex, err := j.srv.raftApply(structs.EvalUpdateRequestType, update)
+		_, evalIndex, err := s.raftApply(structs.EvalUpdateRequestType, update)
 		if err != nil {
-			j.logger.Error("eval create failed", "error", err, "method", "dispatch")
+			s.logger.Error("eval create failed", "error", err, "method", "dispatch")
 			return err
 		}
 
@@ -2152,6 +2239,56 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 	return nil
 }
 
+// DispatchQueue is used to list the queued dispatches of a parameterized job
+func (j *Job) DispatchQueue(args *structs.JobSpecificRequest,
+	reply *structs.JobDispatchQueueResponse) error {
+	authErr := j.srv.Authenticate(j.ctx, args)
+	if done, err := j.srv.forward("Job.DispatchQueue", args, args, reply); done {
+		return err
+	}
+	j.srv.MeasureRPCRate("job", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "dispatch_queue"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := j.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			dispatches, err := store.QueuedDispatchesByParent(ws, args.RequestNamespace(), args.JobID)
+			if err != nil {
+				return err
+			}
+
+			reply.Dispatches = make([]*structs.QueuedDispatchStub, 0, len(dispatches))
+			for _, dispatch := range dispatches {
+				reply.Dispatches = append(reply.Dispatches, dispatch.Stub())
+			}
+
+			// Use the last index that affected the dispatch queue table
+			index, err := store.Index(state.TableDispatchQueue)
+			if err != nil {
+				return err
+			}
+			reply.Index = max(1, index)
+
+			// Set the query response
+			j.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+
+	return j.srv.blockingRPC(&opts)
+}
+
 // validateDispatchRequest returns whether the request is valid given the
 // parameterized job.
 func validateDispatchRequest(req *structs.JobDispatchRequest, job *structs.Job) error {
@@ -2397,6 +2534,11 @@ func (j *Job) GetServiceRegistrations(
 			for raw := iter.Next(); raw != nil; raw = iter.Next() {
 				services = append(services, raw.(*structs.ServiceRegistration))
 			}
+
+			services, err = weighServices(ws, stateStore, services)
+			if err != nil {
+				return err
+			}
 			reply.Services = services
 
 			// Use the index table to populate the query meta as we have no way
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// jobDependsOnHook implements a job Validating admission controller that
+// checks the jobs a job depends on against the jobs already registered.
+type jobDependsOnHook struct {
+	srv *Server
+}
+
+func (jobDependsOnHook) Name() string {
+	return "depends_on"
+}
+
+// Validate returns an error if the job's dependencies form a cycle with the
+// registered jobs, since none of the jobs in the cycle could ever be placed.
+// Dependencies on jobs that are not registered yet are allowed but produce a
+// warning.
+func (h jobDependsOnHook) Validate(job *structs.Job) (warnings []error, err error) {
+	if len(job.DependsOn) == 0 {
+		return nil, nil
+	}
+
+	snap, err := h.srv.State().Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dep := range job.DependsOn {
+		existing, err := snap.JobByID(nil, job.Namespace, dep.JobID)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			warnings = append(warnings, fmt.Errorf(
+				"job %q depends on job %q which is not registered", job.ID, dep.JobID))
+		}
+	}
+
+	// Walk the dependency graph from the job, using the submitted version of
+	// the job in place of any registered version.
+	visited := make(map[string]bool)
+	var visit func(jobID string, path []string) error
+	visit = func(jobID string, path []string) error {
+		if jobID == job.ID && len(path) > 0 {
+			return fmt.Errorf("job dependencies form a cycle: %s",
+				strings.Join(append(path, jobID), " -> "))
+		}
+		if visited[jobID] {
+			return nil
+		}
+		visited[jobID] = true
+
+		current := job
+		if jobID != job.ID {
+			registered, err := snap.JobByID(nil, job.Namespace, jobID)
+			if err != nil {
+				return err
+			}
+			if registered == nil {
+				return nil
+			}
+			current = registered
+		}
+
+		for _, dep := range current.DependsOn {
+			if err := visit(dep.JobID, append(path, jobID)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return warnings, visit(job.ID, nil)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobDependsOnHook_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	hook := jobDependsOnHook{srv: &Server{fsm: &nomadFSM{state: store}}}
+
+	dependsOn := func(job *structs.Job, ids ...string) *structs.Job {
+		for _, id := range ids {
+			job.DependsOn = append(job.DependsOn, &structs.JobDependency{
+				JobID:     id,
+				Condition: structs.JobDependencyConditionRunning,
+			})
+		}
+		return job
+	}
+
+	// a -> b -> c
+	a, b, c := mock.Job(), mock.Job(), mock.Job()
+	a.ID, b.ID, c.ID = "a", "b", "c"
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, dependsOn(a, "b")))
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1001, nil, dependsOn(b, "c")))
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1002, nil, c))
+
+	// Jobs without dependencies are always valid
+	warnings, err := hook.Validate(mock.Job())
+	must.NoError(t, err)
+	must.SliceEmpty(t, warnings)
+
+	// Depending on jobs that aren't registered only warns
+	job := dependsOn(mock.Job(), "a", "missing")
+	warnings, err = hook.Validate(job)
+	must.NoError(t, err)
+	must.Len(t, 1, warnings)
+	must.ErrorContains(t, warnings[0], `job "missing"`)
+
+	// c -> a closes the cycle
+	job = dependsOn(c.Copy(), "a")
+	_, err = hook.Validate(job)
+	must.EqError(t, err, "job dependencies form a cycle: c -> a -> b -> c")
+}
@@ -27,6 +27,7 @@ const (
 	attrLoopbackCNI       = `${attr.plugins.cni.version.loopback}`
 	attrPortMapCNI        = `${attr.plugins.cni.version.portmap}`
 	attrConsulCNI         = `${attr.plugins.cni.version.consul-cni}`
+	attrBandwidthCNI      = `${attr.plugins.cni.version.bandwidth}`
 )
 
 // cniMinVersion is the version expression for the minimum CNI version supported
@@ -136,6 +137,14 @@ var (
 		Operand: structs.ConstraintSemver,
 	}
 
+	// cniBandwidthConstraint is an implicit constraint added to jobs limiting
+	// the egress bandwidth of bridge networks.
+	cniBandwidthConstraint = &structs.Constraint{
+		LTarget: attrBandwidthCNI,
+		RTarget: cniMinVersion,
+		Operand: structs.ConstraintSemver,
+	}
+
 	// cniConsulConstraint is an implicit constraint added to jobs making use of
 	// transparent proxy mode.
 	cniConsulConstraint = &structs.Constraint{
@@ -279,6 +288,10 @@ func (jobImpliedConstraints) Mutate(j *structs.Job) (*structs.Job, []error, erro
 
 	taskScheduleTaskGroups := j.RequiredScheduleTask()
 
+	egressLimitTaskGroups := j.RequiredEgressLimit()
+
+	blockDevices := j.RequiredBlockDevices()
+
 	// Hot path where none of our things require constraints.
 	//
 	// [UPDATE THIS] if you are adding a new constraint thing!
@@ -286,7 +299,8 @@ func (jobImpliedConstraints) Mutate(j *structs.Job) (*structs.Job, []error, erro
 		nativeServiceDisco.Empty() && len(consulServiceDisco) == 0 &&
 		numaTaskGroups.Empty() && bridgeNetworkingTaskGroups.Empty() &&
 		transparentProxyTaskGroups.Empty() &&
-		taskScheduleTaskGroups.Empty() {
+		taskScheduleTaskGroups.Empty() &&
+		egressLimitTaskGroups.Empty() && len(blockDevices) == 0 {
 		return j, nil, nil
 	}
 
@@ -352,6 +366,18 @@ func (jobImpliedConstraints) Mutate(j *structs.Job) (*structs.Job, []error, erro
 			mutateConstraint(constraintMatcherLeft, tg, cniPortMapConstraint)
 		}
 
+		if egressLimitTaskGroups.Contains(tg.Name) {
+			mutateConstraint(constraintMatcherLeft, tg, cniBandwidthConstraint)
+		}
+
+		// Tasks limiting the io of block devices must be placed on nodes
+		// with those devices.
+		for _, task := range tg.Tasks {
+			for _, device := range blockDevices[tg.Name][task.Name] {
+				mutateConstraint(constraintMatcherLeft, task, blockDeviceConstraintFn(device))
+			}
+		}
+
 		if transparentProxyTaskGroups.Contains(tg.Name) {
 			mutateConstraint(constraintMatcherLeft, tg, cniConsulConstraint)
 			mutateConstraint(constraintMatcherLeft, tg, tproxyConstraint)
@@ -398,6 +424,15 @@ func consulConstraintFn(service *structs.Service) *structs.Constraint {
 	return consulServiceDiscoveryConstraint
 }
 
+// blockDeviceConstraintFn returns a constraint that matches nodes with the
+// fingerprinted block device.
+func blockDeviceConstraintFn(device string) *structs.Constraint {
+	return &structs.Constraint{
+		LTarget: fmt.Sprintf("${attr.storage.block.%s.dev}", device),
+		Operand: structs.ConstraintAttributeIsSet,
+	}
+}
+
 // constraintMatcher is a custom type which helps control how constraints are
 // identified as being present within a task group.
 type constraintMatcher uint
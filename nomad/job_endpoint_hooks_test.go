@@ -1246,6 +1246,94 @@ func Test_jobImpliedConstraints_Mutate(t *testing.T) {
 			expectedOutputError:    nil,
 			name:                   "task group with tproxy",
 		},
+		{
+			name: "task group with egress limit",
+			inputJob: &structs.Job{
+				Name: "example",
+				TaskGroups: []*structs.TaskGroup{
+					{
+						Name: "group-with-egress",
+						Networks: []*structs.NetworkResource{
+							{Mode: "bridge", EgressMBits: 100},
+						},
+					},
+				},
+			},
+			expectedOutputJob: &structs.Job{
+				Name: "example",
+				TaskGroups: []*structs.TaskGroup{
+					{
+						Name: "group-with-egress",
+						Networks: []*structs.NetworkResource{
+							{Mode: "bridge", EgressMBits: 100},
+						},
+						Constraints: []*structs.Constraint{
+							cniBridgeConstraint,
+							cniFirewallConstraint,
+							cniHostLocalConstraint,
+							cniLoopbackConstraint,
+							cniPortMapConstraint,
+							cniBandwidthConstraint,
+						},
+					},
+				},
+			},
+			expectedOutputWarnings: nil,
+			expectedOutputError:    nil,
+		},
+		{
+			name: "task with io max",
+			inputJob: &structs.Job{
+				Name: "example",
+				TaskGroups: []*structs.TaskGroup{
+					{
+						Name: "group-with-io",
+						Tasks: []*structs.Task{
+							{
+								Name: "task-with-io",
+								Resources: &structs.Resources{
+									IOMax: structs.IOLimits{
+										{Device: "sda", ReadBps: 1024},
+										{Device: "nvme0n1", WriteIOps: 100},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedOutputJob: &structs.Job{
+				Name: "example",
+				TaskGroups: []*structs.TaskGroup{
+					{
+						Name: "group-with-io",
+						Tasks: []*structs.Task{
+							{
+								Name: "task-with-io",
+								Resources: &structs.Resources{
+									IOMax: structs.IOLimits{
+										{Device: "sda", ReadBps: 1024},
+										{Device: "nvme0n1", WriteIOps: 100},
+									},
+								},
+								Constraints: []*structs.Constraint{
+									{
+										LTarget: "${attr.storage.block.sda.dev}",
+										Operand: structs.ConstraintAttributeIsSet,
+									},
+									{
+										LTarget: "${attr.storage.block.nvme0n1.dev}",
+										Operand: structs.ConstraintAttributeIsSet,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedOutputWarnings: nil,
+			expectedOutputError:    nil,
+		},
 		{
 			name: "task with schedule",
 			inputJob: &structs.Job{
@@ -0,0 +1,235 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/nomad/acl"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// jobStatsConcurrency is the maximum number of allocations whose history
+	// Job.Stats reads from their clients concurrently.
+	jobStatsConcurrency = 16
+
+	// jobStatsAllocTimeout is the maximum time Job.Stats waits for the
+	// history of an allocation.
+	jobStatsAllocTimeout = 10 * time.Second
+)
+
+// Stats summarizes the resource usage history of the allocations of a job,
+// read from the clients running them, into percentiles per task.
+func (j *Job) Stats(args *structs.JobStatsRequest, reply *structs.JobStatsResponse) error {
+	authErr := j.srv.Authenticate(j.ctx, args)
+	if done, err := j.srv.forward("Job.Stats", args, args, reply); done {
+		return err
+	}
+	j.srv.MeasureRPCRate("job", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "stats"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := j.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.JobID == "" {
+		return fmt.Errorf("missing job ID for stats")
+	}
+
+	snap, err := j.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	job, err := snap.JobByID(nil, args.RequestNamespace(), args.JobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return structs.NewErrRPCCoded(404, fmt.Sprintf("job %q not found", args.JobID))
+	}
+	allocs, err := snap.AllocsByJob(nil, args.RequestNamespace(), args.JobID, false)
+	if err != nil {
+		return err
+	}
+
+	// Allocations that never ran, or that were lost, don't have any history.
+	// The history of terminal allocations is only read when requested, since
+	// their clients have likely garbage collected it.
+	allocs = slices.DeleteFunc(allocs, func(alloc *structs.Allocation) bool {
+		switch alloc.ClientStatus {
+		case structs.AllocClientStatusRunning:
+			return false
+		case structs.AllocClientStatusComplete, structs.AllocClientStatusFailed:
+			return !args.IncludeTerminal
+		default:
+			return true
+		}
+	})
+
+	// Read the history of the allocations from their clients concurrently
+	responses := make([]*cstructs.AllocStatsHistoryResponse, len(allocs))
+	errs := make([]error, len(allocs))
+	sem := make(chan struct{}, jobStatsConcurrency)
+	var wg sync.WaitGroup
+	for i, alloc := range allocs {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			responses[i], errs[i] = j.allocStatsHistory(alloc, args.AuthToken)
+		}()
+	}
+	wg.Wait()
+
+	history := make(map[string]map[string][][]*cstructs.StatsSample)
+	numAllocs := make(map[string]int)
+	allocErrors := make(map[string]string)
+	for i, alloc := range allocs {
+		if errs[i] != nil {
+			allocErrors[alloc.ID] = errs[i].Error()
+			continue
+		}
+
+		resp := responses[i]
+		if history[alloc.TaskGroup] == nil {
+			history[alloc.TaskGroup] = make(map[string][][]*cstructs.StatsSample)
+		}
+		for task, samples := range resp.Tasks {
+			history[alloc.TaskGroup][task] = append(history[alloc.TaskGroup][task], samples)
+		}
+		numAllocs[alloc.TaskGroup]++
+	}
+
+	stats := &structs.JobStats{
+		TaskGroups: make(map[string]*structs.TaskGroupUsage, len(history)),
+	}
+	for tg, tasks := range history {
+		usage := &structs.TaskGroupUsage{
+			Allocs: numAllocs[tg],
+			Tasks:  make(map[string]*structs.TaskUsage, len(tasks)),
+		}
+		for task, samples := range tasks {
+			usage.Tasks[task] = summarizeTaskUsage(slices.Concat(samples...))
+		}
+		stats.TaskGroups[tg] = usage
+	}
+	if len(allocErrors) > 0 {
+		stats.Errors = allocErrors
+	}
+	reply.Stats = stats
+
+	j.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
+// allocStatsHistory reads the resource usage history of an allocation from
+// its client, giving up after jobStatsAllocTimeout. The client RPC can't be
+// canceled, so it's left to complete in the background on timeout.
+func (j *Job) allocStatsHistory(alloc *structs.Allocation, authToken string) (*cstructs.AllocStatsHistoryResponse, error) {
+	req := &cstructs.AllocStatsHistoryRequest{
+		AllocID: alloc.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    j.srv.Region(),
+			Namespace: alloc.Namespace,
+			AuthToken: authToken,
+		},
+	}
+
+	var resp cstructs.AllocStatsHistoryResponse
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- j.srv.RPC("ClientAllocations.StatsHistory", req, &resp)
+	}()
+
+	timer, stop := helper.NewSafeTimer(jobStatsAllocTimeout)
+	defer stop()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			return nil, err
+		}
+		return &resp, nil
+	case <-timer.C:
+		return nil, fmt.Errorf("timed out reading the history of the allocation")
+	case <-j.srv.shutdownCh:
+		return nil, fmt.Errorf("server is shutting down")
+	}
+}
+
+// summarizeTaskUsage computes the percentiles of the usage of the samples of a
+// task, weighting each sample by the duration of its window so that the
+// downsampled history doesn't count less than the recent history.
+func summarizeTaskUsage(samples []*cstructs.StatsSample) *structs.TaskUsage {
+	cpu := make([]weightedValue, 0, len(samples))
+	memory := make([]weightedValue, 0, len(samples))
+	var cpuMax, memoryMax float64
+	for _, s := range samples {
+		weight := s.Window.Seconds()
+		memoryMB := float64(s.MemoryBytes) / 1024 / 1024
+		cpu = append(cpu, weightedValue{value: s.CPUTicks, weight: weight})
+		memory = append(memory, weightedValue{value: memoryMB, weight: weight})
+		cpuMax = max(cpuMax, s.CPUTicksMax)
+		memoryMax = max(memoryMax, float64(s.MemoryBytesMax)/1024/1024)
+	}
+
+	return &structs.TaskUsage{
+		Samples: len(samples),
+		CPU: structs.UsagePercentiles{
+			P50: weightedPercentile(cpu, 0.5),
+			P95: weightedPercentile(cpu, 0.95),
+			Max: cpuMax,
+		},
+		MemoryMB: structs.UsagePercentiles{
+			P50: weightedPercentile(memory, 0.5),
+			P95: weightedPercentile(memory, 0.95),
+			Max: memoryMax,
+		},
+	}
+}
+
+type weightedValue struct {
+	value  float64
+	weight float64
+}
+
+// weightedPercentile returns the smallest value for which the values less than
+// or equal to it account for at least the fraction p of the total weight.
+func weightedPercentile(values []weightedValue, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	slices.SortFunc(values, func(a, b weightedValue) int {
+		return cmp.Compare(a.value, b.value)
+	})
+
+	var total float64
+	for _, v := range values {
+		total += v.weight
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v.weight
+		if sum >= p*total {
+			return v.value
+		}
+	}
+	return values[len(values)-1].value
+}
@@ -0,0 +1,189 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client"
+	"github.com/hashicorp/nomad/client/config"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+func TestJob_Stats(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	c, cleanupC := client.TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s.config.RPCAddr.String()}
+	})
+	defer cleanupC()
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return len(s.connectedNodes()) == 1 }),
+		wait.Timeout(10*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	// Force a running allocation onto the node
+	a := mock.Alloc()
+	a.NodeID = c.NodeID()
+	a.ClientStatus = structs.AllocClientStatusRunning
+	a.Job.TaskGroups[0].Count = 1
+	a.Job.TaskGroups[0].Tasks[0] = &structs.Task{
+		Name:   "web",
+		Driver: "mock_driver",
+		Config: map[string]interface{}{
+			"run_for": "20s",
+		},
+		LogConfig: structs.DefaultLogConfig(),
+		Resources: &structs.Resources{
+			CPU:      500,
+			MemoryMB: 256,
+		},
+	}
+
+	// An allocation that never ran isn't summarized
+	pending := mock.Alloc()
+	pending.Job = a.Job
+	pending.JobID = a.JobID
+	pending.NodeID = c.NodeID()
+	pending.ClientStatus = structs.AllocClientStatusPending
+
+	// A complete allocation is only summarized when requested
+	complete := mock.Alloc()
+	complete.Job = a.Job
+	complete.JobID = a.JobID
+	complete.ClientStatus = structs.AllocClientStatusComplete
+
+	state := s.State()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 999, nil, a.Job))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1003,
+		[]*structs.Allocation{a, pending, complete}))
+
+	// Wait for the client to run the allocation
+	must.Wait(t, wait.InitialSuccess(
+		wait.ErrorFunc(func() error {
+			_, err := c.GetAllocStats(a.ID)
+			return err
+		}),
+		wait.Timeout(10*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	req := &structs.JobStatsRequest{
+		JobID: a.JobID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: a.Namespace,
+		},
+	}
+	var resp structs.JobStatsResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Stats", req, &resp))
+	must.NotNil(t, resp.Stats)
+	must.MapLen(t, 1, resp.Stats.TaskGroups)
+	tg := resp.Stats.TaskGroups[a.TaskGroup]
+	must.NotNil(t, tg)
+	must.Eq(t, 1, tg.Allocs)
+	must.MapContainsKey(t, tg.Tasks, "web")
+	must.MapEmpty(t, resp.Stats.Errors)
+
+	// The node of the complete allocation doesn't exist, so reading its
+	// history fails
+	req.IncludeTerminal = true
+	resp = structs.JobStatsResponse{}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Stats", req, &resp))
+	must.Eq(t, 1, resp.Stats.TaskGroups[a.TaskGroup].Allocs)
+	must.MapLen(t, 1, resp.Stats.Errors)
+	must.MapContainsKey(t, resp.Stats.Errors, complete.ID)
+
+	// Unknown jobs aren't found
+	req.JobID = "unknown"
+	err := msgpackrpc.CallWithCodec(codec, "Job.Stats", req, &resp)
+	must.ErrorContains(t, err, `job "unknown" not found`)
+}
+
+func TestJob_Stats_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s, root, cleanupS := TestACLServer(t, nil)
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	job := mock.Job()
+	must.NoError(t, s.State().UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	req := &structs.JobStatsRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Try without a token
+	var resp structs.JobStatsResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Stats", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Try with a token without read-job
+	policy := mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityListJobs})
+	token := mock.CreatePolicyAndToken(t, s.State(), 1005, "invalid", policy)
+	req.AuthToken = token.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Job.Stats", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Try with a management token
+	req.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Stats", req, &resp))
+	must.MapEmpty(t, resp.Stats.TaskGroups)
+}
+
+func Test_summarizeTaskUsage(t *testing.T) {
+	ci.Parallel(t)
+
+	must.Eq(t, &structs.TaskUsage{}, summarizeTaskUsage(nil))
+
+	mb := uint64(1024 * 1024)
+	var samples []*cstructs.StatsSample
+	for i := 1; i <= 100; i++ {
+		samples = append(samples, &cstructs.StatsSample{
+			Window:         10 * time.Second,
+			CPUTicks:       float64(i),
+			CPUTicksMax:    float64(i + 1),
+			MemoryBytes:    uint64(i) * mb,
+			MemoryBytesMax: uint64(i+2) * mb,
+		})
+	}
+	must.Eq(t, &structs.TaskUsage{
+		Samples:  100,
+		CPU:      structs.UsagePercentiles{P50: 50, P95: 95, Max: 101},
+		MemoryMB: structs.UsagePercentiles{P50: 50, P95: 95, Max: 102},
+	}, summarizeTaskUsage(samples))
+
+	// a downsampled sample weighs as much as the samples of its window
+	samples = append(samples, &cstructs.StatsSample{
+		Window:      1000 * time.Second,
+		CPUTicks:    1,
+		CPUTicksMax: 1,
+	})
+	usage := summarizeTaskUsage(samples)
+	must.Eq(t, 1, usage.CPU.P50)
+	must.Eq(t, 90, usage.CPU.P95)
+	must.Eq(t, 101, usage.CPU.Max)
+}
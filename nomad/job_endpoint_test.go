@@ -26,6 +26,7 @@ import (
 	"github.com/hashicorp/raft"
 	"github.com/kr/pretty"
 	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -7054,6 +7055,131 @@ func TestJobEndpoint_Dispatch_JobChildrenSummary(t *testing.T) {
 	require.Equal(t, structs.JobStatusDead, dispatchedStatus())
 }
 
+func TestJobEndpoint_Dispatch_Limits(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+
+	store := s1.fsm.State()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1, mock.Node()))
+
+	parameterizedJob := mock.BatchJob()
+	parameterizedJob.ParameterizedJob = &structs.ParameterizedJobConfig{
+		MaxConcurrent: 1,
+		Queue:         &structs.DispatchQueueConfig{MaxDepth: 1},
+	}
+	regReq := &structs.JobRegisterRequest{
+		Job: parameterizedJob,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: parameterizedJob.Namespace,
+		},
+	}
+	var regResp structs.JobRegisterResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", regReq, &regResp))
+
+	dispatch := func(token string) (*structs.JobDispatchResponse, error) {
+		req := &structs.JobDispatchRequest{
+			JobID: parameterizedJob.ID,
+			WriteRequest: structs.WriteRequest{
+				Region:           "global",
+				Namespace:        parameterizedJob.Namespace,
+				IdempotencyToken: token,
+			},
+		}
+		var resp structs.JobDispatchResponse
+		err := msgpackrpc.CallWithCodec(codec, "Job.Dispatch", req, &resp)
+		return &resp, err
+	}
+
+	// The first dispatch is started right away
+	first, err := dispatch("")
+	must.NoError(t, err)
+	must.False(t, first.Queued)
+	must.NotEq(t, "", first.EvalID)
+
+	// The second dispatch exceeds the concurrency limit and is queued
+	second, err := dispatch("second")
+	must.NoError(t, err)
+	must.True(t, second.Queued)
+	must.Eq(t, "", second.EvalID)
+
+	job, err := store.JobByID(nil, parameterizedJob.Namespace, second.DispatchedJobID)
+	must.NoError(t, err)
+	must.Nil(t, job)
+
+	// Retrying the queued dispatch returns it instead of queueing it again
+	retry, err := dispatch("second")
+	must.NoError(t, err)
+	must.True(t, retry.Queued)
+	must.Eq(t, second.DispatchedJobID, retry.DispatchedJobID)
+
+	// The queue is full, so further dispatches are rejected
+	_, err = dispatch("")
+	must.ErrorContains(t, err, "dispatch queue of parameterized job")
+
+	queueReq := &structs.JobSpecificRequest{
+		JobID: parameterizedJob.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: parameterizedJob.Namespace,
+		},
+	}
+	var queueResp structs.JobDispatchQueueResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.DispatchQueue", queueReq, &queueResp))
+	must.Len(t, 1, queueResp.Dispatches)
+	must.Eq(t, second.DispatchedJobID, queueResp.Dispatches[0].ID)
+
+	// Completing the first dispatched job releases the queued dispatch
+	firstJob, err := store.JobByID(nil, parameterizedJob.Namespace, first.DispatchedJobID)
+	must.NoError(t, err)
+
+	eval, err := store.EvalByID(nil, first.EvalID)
+	must.NoError(t, err)
+	eval = eval.Copy()
+	eval.Status = structs.EvalStatusComplete
+	must.NoError(t, store.UpsertEvals(structs.MsgTypeTestSetup, 1000, []*structs.Evaluation{eval}))
+
+	alloc := mock.Alloc()
+	alloc.Job = firstJob
+	alloc.JobID = firstJob.ID
+	alloc.TaskGroup = firstJob.TaskGroups[0].Name
+	alloc.Namespace = firstJob.Namespace
+	alloc.ClientStatus = structs.AllocClientStatusComplete
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{alloc}))
+
+	must.Wait(t, wait.InitialSuccess(
+		wait.ErrorFunc(func() error {
+			job, err := store.JobByID(nil, parameterizedJob.Namespace, second.DispatchedJobID)
+			if err != nil {
+				return err
+			}
+			if job == nil {
+				return fmt.Errorf("queued dispatch not released")
+			}
+			if job.DispatchIdempotencyToken != "second" {
+				return fmt.Errorf("unexpected idempotency token %q", job.DispatchIdempotencyToken)
+			}
+			queued, err := store.QueuedDispatchesByParent(nil, parameterizedJob.Namespace, parameterizedJob.ID)
+			if err != nil {
+				return err
+			}
+			if len(queued) != 0 {
+				return fmt.Errorf("expected empty queue, got %d dispatches", len(queued))
+			}
+			return nil
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(50*time.Millisecond),
+	))
+}
+
 func TestJobEndpoint_Dispatch_ACL_RejectedBySchedulerConfig(t *testing.T) {
 	ci.Parallel(t)
 	s1, root, cleanupS1 := TestACLServer(t, nil)
@@ -8118,7 +8244,10 @@ func TestJob_GetServiceRegistrations(t *testing.T) {
 		services[0].JobID = job.ID
 		err = s.State().UpsertServiceRegistrations(structs.MsgTypeTestSetup, 20, services)
 
-		return err, job.ID, services[0]
+		// The registration is returned with its weight set.
+		service := services[0].Copy()
+		service.Weight = 100
+		return err, job.ID, service
 	}
 
 	testCases := []struct {
@@ -21,6 +21,7 @@ import (
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
 	"github.com/hashicorp/raft"
 	"github.com/hashicorp/serf/serf"
 	"golang.org/x/time/rate"
@@ -32,6 +33,18 @@ const (
 	// high contention when the schedulers plan does not make progress.
 	failedEvalUnblockInterval = 1 * time.Minute
 
+	// fairShareUpdateInterval is the interval at which the fair-share usage
+	// of each namespace is recomputed when fair-share scheduling is enabled.
+	fairShareUpdateInterval = 5 * time.Second
+
+	// dependentEvalUnblockRateLimit is used to rate limit how often the
+	// dependencies of evaluations waiting on other jobs are checked
+	dependentEvalUnblockRateLimit rate.Limit = 2.0
+
+	// dispatchQueueReleaseRateLimit is used to rate limit how often queued
+	// dispatches of parameterized jobs are released
+	dispatchQueueReleaseRateLimit rate.Limit = 10.0
+
 	// replicationRateLimit is used to rate limit how often data is replicated
 	// between the authoritative region and the local region
 	replicationRateLimit rate.Limit = 10.0
@@ -439,6 +452,15 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// Periodically unblock failed allocations
 	go s.periodicUnblockFailedEvals(stopCh)
 
+	// Unblock evaluations once their job dependencies are met
+	go s.unblockDependentEvals(stopCh)
+
+	// Release queued dispatches as the limits of their jobs allow
+	go s.releaseQueuedDispatches(stopCh)
+
+	// Periodically update the fair-share usage of each namespace
+	go s.periodicUpdateFairShare(stopCh)
+
 	// Periodically publish job summary metrics
 	go s.publishJobSummaryMetrics(stopCh)
 
@@ -863,6 +885,13 @@ func (s *Server) restorePeriodicDispatcher() error {
 				job.ID, job.Namespace)
 		}
 
+		if job.Periodic.Catchup != nil {
+			if err := s.catchUpPeriodicJob(job, launch.Launch, now); err != nil {
+				return err
+			}
+			continue
+		}
+
 		// nextLaunch is the next launch that should occur.
 		nextLaunch, err := job.Periodic.Next(launch.Launch.In(job.Periodic.GetLocation()))
 		if err != nil {
@@ -898,6 +927,46 @@ func (s *Server) restorePeriodicDispatcher() error {
 	return nil
 }
 
+// catchUpPeriodicJob launches the launches of the periodic job that were missed
+// since its last launch, according to the catchup policy of the job.
+func (s *Server) catchUpPeriodicJob(job *structs.Job, lastLaunch, now time.Time) error {
+	logger := s.logger.Named("periodic")
+
+	launches, skipped, err := job.Periodic.CatchupLaunches(lastLaunch.In(job.Periodic.GetLocation()), now)
+	if err != nil {
+		logger.Error("failed to determine missed periodic launches for job", "job", job.NamespacedID(), "error", err)
+		return nil
+	}
+	if skipped {
+		logger.Warn("periodic job missed too many launches, only looking for the most recent ones",
+			"job", job.NamespacedID(), "last_launch", lastLaunch, "max_missed", structs.PeriodicCatchupMaxMissed)
+	}
+	if len(launches) == 0 {
+		return nil
+	}
+
+	// We skip if the job doesn't allow overlap and there are already
+	// instances running
+	allowed, err := s.cronJobOverlapAllowed(job)
+	if err != nil {
+		return fmt.Errorf("failed to get job status: %v", err)
+	}
+	if !allowed {
+		return nil
+	}
+
+	for _, launch := range launches {
+		if _, err := s.periodicDispatcher.CatchUp(job.Namespace, job.ID, launch); err != nil {
+			logger.Error("catch up of periodic job failed", "job", job.NamespacedID(), "launch", launch, "error", err)
+			return fmt.Errorf("catch up of periodic job %q failed: %v", job.NamespacedID(), err)
+		}
+	}
+
+	logger.Debug("periodic job caught up during leadership establishment",
+		"job", job.NamespacedID(), "launches", len(launches))
+	return nil
+}
+
 // cronJobOverlapAllowed checks if the job allows for overlap and if there are already
 // instances of the job running in order to determine if a new evaluation needs to
 // be created upon periodic dispatcher restore
@@ -1214,6 +1283,112 @@ func (s *Server) periodicUnblockFailedEvals(stopCh chan struct{}) {
 	}
 }
 
+// unblockDependentEvals watches the jobs that blocked evaluations depend on and
+// unblocks the evaluations once their dependencies are met.
+func (s *Server) unblockDependentEvals(stopCh chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	limiter := rate.NewLimiter(dependentEvalUnblockRateLimit, 1)
+	for {
+		if err := limiter.Wait(ctx); err != nil {
+			return
+		}
+
+		evals, dependentCh := s.blockedEvals.Dependent()
+
+		ws := memdb.NewWatchSet()
+		ws.Add(dependentCh)
+		ws.Add(s.State().AbandonCh())
+
+		snap, err := s.State().Snapshot()
+		if err != nil {
+			s.logger.Error("failed to get state", "error", err)
+			return
+		}
+
+		var unblock []string
+		for _, eval := range evals {
+			job, err := snap.JobByID(ws, eval.Namespace, eval.JobID)
+			if err != nil {
+				s.logger.Error("failed to get job", "job_id", eval.JobID, "error", err)
+				continue
+			}
+
+			unmet, err := scheduler.UnmetJobDependencies(ws, snap, job)
+			if err != nil {
+				s.logger.Error("failed to check job dependencies", "job_id", eval.JobID, "error", err)
+				continue
+			}
+			if len(unmet) == 0 {
+				unblock = append(unblock, eval.ID)
+				continue
+			}
+
+			// Evaluations are also unblocked when the jobs they wait on are
+			// registered or removed, so the scheduler blocks them again with
+			// a description of their current dependencies
+			desc, err := scheduler.UnmetJobDependenciesDesc(snap, eval.Namespace, unmet)
+			if err != nil {
+				s.logger.Error("failed to check job dependencies", "job_id", eval.JobID, "error", err)
+				continue
+			}
+			if desc != eval.StatusDescription {
+				unblock = append(unblock, eval.ID)
+			}
+		}
+
+		if len(unblock) > 0 {
+			s.blockedEvals.UnblockDependent(unblock...)
+			continue
+		}
+
+		if err := ws.WatchCtx(ctx); err != nil {
+			return
+		}
+	}
+}
+
+// periodicUpdateFairShare periodically computes how much of the cluster each
+// namespace is using relative to its share, and passes it to the eval broker
+// and blocked evals tracker when fair-share scheduling is enabled.
+func (s *Server) periodicUpdateFairShare(stopCh chan struct{}) {
+	ticker := time.NewTicker(fairShareUpdateInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			var ratios map[string]float64
+
+			_, schedConfig, err := s.State().SchedulerConfig()
+			if err != nil {
+				s.logger.Error("failed to get scheduler configuration", "error", err)
+				continue
+			}
+			if schedConfig != nil && schedConfig.FairShareConfig.Enabled {
+				fairShare, err := scheduler.NewFairShare(nil, s.State(), &schedConfig.FairShareConfig)
+				if err != nil {
+					s.logger.Error("failed to compute fair-share usage", "error", err)
+					continue
+				}
+				ratios = fairShare.Ratios()
+			}
+
+			s.evalBroker.SetFairShare(ratios)
+			s.blockedEvals.SetFairShare(ratios)
+		}
+	}
+}
+
 // publishJobSummaryMetrics publishes the job summaries as metrics
 func (s *Server) publishJobSummaryMetrics(stopCh chan struct{}) {
 	timer := time.NewTimer(0)
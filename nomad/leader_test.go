@@ -534,6 +534,68 @@ func TestLeader_PeriodicDispatcher_Restore_Evals(t *testing.T) {
 	must.True(t, md.forceEvalCalled, must.Sprint("failed to force job evaluation"))
 }
 
+func TestLeader_PeriodicDispatcher_Restore_Catchup(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Inject a periodic job that missed three launches and catches up on the
+	// latest two of them.
+	now := time.Now().Round(time.Second)
+	missed := []time.Time{
+		now.Add(-3 * time.Minute),
+		now.Add(-2 * time.Minute),
+		now.Add(-1 * time.Minute),
+	}
+	job := testPeriodicJob(append(missed, now.Add(time.Hour))...)
+	job.Periodic.Catchup = &structs.PeriodicCatchup{
+		Policy: structs.PeriodicCatchupAll,
+		Limit:  2,
+	}
+	req := structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Namespace: job.Namespace,
+		},
+	}
+	_, _, err := s1.raftApply(structs.JobRegisterRequestType, req)
+	must.NoError(t, err)
+
+	// Flush the periodic dispatcher and record a last launch from before the
+	// missed launches.
+	s1.periodicDispatcher.SetEnabled(false)
+	store := s1.fsm.State()
+	must.NoError(t, store.UpsertPeriodicLaunch(1000, &structs.PeriodicLaunch{
+		ID:        job.ID,
+		Namespace: job.Namespace,
+		Launch:    now.Add(-4 * time.Minute),
+	}))
+
+	// Restore the periodic dispatcher.
+	s1.periodicDispatcher.SetEnabled(true)
+	must.NoError(t, s1.restorePeriodicDispatcher())
+
+	ws := memdb.NewWatchSet()
+	for i, launch := range missed {
+		child, err := store.JobByID(ws, job.Namespace, s1.periodicDispatcher.derivedJobID(job, launch))
+		must.NoError(t, err)
+		if i == 0 {
+			must.Nil(t, child, must.Sprint("expected oldest missed launch to be skipped"))
+		} else {
+			must.NotNil(t, child, must.Sprintf("expected missed launch %v to be launched", launch))
+		}
+	}
+
+	last, err := store.PeriodicLaunchByID(ws, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.NotNil(t, last)
+	must.Eq(t, missed[2].Unix(), last.Launch.Unix())
+}
+
 func TestLeader_PeriodicDispatcher_No_Overlaps_No_Running_Job(t *testing.T) {
 	ci.Parallel(t)
 
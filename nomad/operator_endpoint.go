@@ -22,6 +22,7 @@ import (
 	"github.com/hashicorp/nomad/helper/snapshot"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
 )
 
 // Operator endpoint is used to perform low-level operator tasks for Nomad.
@@ -553,6 +554,70 @@ func (op *Operator) SchedulerGetConfiguration(args *structs.GenericRequest, repl
 	return nil
 }
 
+// SchedulerSimulate is used to run the scheduler against a copy of the
+// current cluster state with the changes of the simulation applied, without
+// modifying the cluster.
+func (op *Operator) SchedulerSimulate(args *structs.SchedulerSimulateRequest, reply *structs.SchedulerSimulateResponse) error {
+
+	authErr := op.srv.Authenticate(op.ctx, args)
+	if done, err := op.srv.forward("Operator.SchedulerSimulate", args, args, reply); done {
+		return err
+	}
+	op.srv.MeasureRPCRate("operator", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// The simulation can see and change every job of the cluster, so it
+	// requires operator write access.
+	aclObj, err := op.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	} else if !aclObj.AllowOperatorWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.Simulation == nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "missing simulation")
+	}
+
+	// Run the jobs through the same admission controllers as a registration
+	// so they are scheduled as they would be if submitted.
+	jobs := NewJobEndpoints(op.srv, op.ctx)
+	sim := *args.Simulation
+	sim.Jobs = make([]*structs.Job, 0, len(args.Simulation.Jobs))
+	for _, job := range args.Simulation.Jobs {
+		if job == nil {
+			return structs.NewErrRPCCoded(http.StatusBadRequest, "missing job")
+		}
+		job, _, err := jobs.admissionControllers(job)
+		if err != nil {
+			return structs.NewErrRPCCoded(http.StatusBadRequest, err.Error())
+		}
+		sim.Jobs = append(sim.Jobs, job)
+	}
+
+	snap, err := op.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	index, err := snap.LatestIndex()
+	if err != nil {
+		return err
+	}
+
+	result, err := scheduler.Simulate(op.logger, &snap.StateStore, &sim)
+	if err != nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, err.Error())
+	}
+
+	reply.Result = result
+	reply.QueryMeta.Index = index
+	op.srv.setQueryMeta(&reply.QueryMeta)
+
+	return nil
+}
+
 func (op *Operator) forwardStreamingRPC(region string, method string, args interface{}, in io.ReadWriteCloser) error {
 	server, err := op.srv.findRegionServer(region)
 	if err != nil {
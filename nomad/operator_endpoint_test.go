@@ -841,6 +841,61 @@ func TestOperator_SchedulerSetConfiguration_ACL(t *testing.T) {
 
 }
 
+func TestOperator_SchedulerSimulate(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	node := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+	readToken := mock.CreatePolicyAndToken(t, state, 1001, "operator-read", `operator { policy = "read" }`)
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	arg := structs.SchedulerSimulateRequest{
+		Simulation: &structs.SchedulerSimulation{
+			AddNodes: []*structs.SimulatedNodes{{NodeID: node.ID, Count: 1}},
+			Jobs:     []*structs.Job{job},
+		},
+		QueryOptions: structs.QueryOptions{
+			Region: s1.config.Region,
+		},
+	}
+	var reply structs.SchedulerSimulateResponse
+
+	// Operator read access is not enough to run a simulation
+	arg.AuthToken = readToken.SecretID
+	err := msgpackrpc.CallWithCodec(codec, "Operator.SchedulerSimulate", &arg, &reply)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	arg.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.SchedulerSimulate", &arg, &reply))
+	must.Positive(t, reply.Index)
+	must.Len(t, 1, reply.Result.Evaluations)
+	must.Eq(t, job.ID, reply.Result.Evaluations[0].JobID)
+	must.Eq(t, 2, reply.Result.Evaluations[0].DesiredTGUpdates["web"].Place)
+	must.MapEmpty(t, reply.Result.Evaluations[0].FailedTGAllocs)
+	must.Len(t, 1, reply.Result.NodePools)
+	must.Eq(t, 1, reply.Result.NodePools[0].Before.Nodes)
+	must.Eq(t, 2, reply.Result.NodePools[0].After.Nodes)
+
+	// The cluster state is not modified
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Nil(t, out)
+	nodes, err := state.Nodes(nil)
+	must.NoError(t, err)
+	var count int
+	for raw := nodes.Next(); raw != nil; raw = nodes.Next() {
+		count++
+	}
+	must.Eq(t, 1, count)
+}
+
 func TestOperator_SnapshotSave(t *testing.T) {
 	ci.Parallel(t)
 
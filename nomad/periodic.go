@@ -7,8 +7,6 @@ import (
 	"container/heap"
 	"context"
 	"fmt"
-	"strconv"
-	"strings"
 	"sync"
 	"time"
 
@@ -281,6 +279,18 @@ func (p *PeriodicDispatch) removeLocked(jobID structs.NamespacedID) error {
 // ForceEval causes the periodic job to be evaluated immediately and returns the
 // subsequent eval.
 func (p *PeriodicDispatch) ForceEval(namespace, jobID string) (*structs.Evaluation, error) {
+	return p.evalAt(namespace, jobID, time.Time{})
+}
+
+// CatchUp causes the periodic job to be evaluated for a launch that was
+// missed, deriving the child job from the time the launch was scheduled for.
+func (p *PeriodicDispatch) CatchUp(namespace, jobID string, launch time.Time) (*structs.Evaluation, error) {
+	return p.evalAt(namespace, jobID, launch)
+}
+
+// evalAt creates an evaluation for the tracked periodic job launched at the
+// given time, or now if the time is zero.
+func (p *PeriodicDispatch) evalAt(namespace, jobID string, launch time.Time) (*structs.Evaluation, error) {
 	p.l.Lock()
 
 	// Do nothing if not enabled
@@ -300,7 +310,10 @@ func (p *PeriodicDispatch) ForceEval(namespace, jobID string) (*structs.Evaluati
 	}
 
 	p.l.Unlock()
-	return p.createEval(job, time.Now().In(job.Periodic.GetLocation()))
+	if launch.IsZero() {
+		launch = time.Now()
+	}
+	return p.createEval(job, launch.In(job.Periodic.GetLocation()))
 }
 
 // shouldRun returns whether the long lived run function should run.
@@ -444,17 +457,7 @@ func (p *PeriodicDispatch) derivedJobID(periodicJob *structs.Job, time time.Time
 // LaunchTime returns the launch time of the job. This is only valid for
 // jobs created by PeriodicDispatch and will otherwise return an error.
 func (p *PeriodicDispatch) LaunchTime(jobID string) (time.Time, error) {
-	index := strings.LastIndex(jobID, structs.PeriodicLaunchSuffix)
-	if index == -1 {
-		return time.Time{}, fmt.Errorf("couldn't parse launch time from eval: %v", jobID)
-	}
-
-	launch, err := strconv.Atoi(jobID[index+len(structs.PeriodicLaunchSuffix):])
-	if err != nil {
-		return time.Time{}, fmt.Errorf("couldn't parse launch time from eval: %v", jobID)
-	}
-
-	return time.Unix(int64(launch), 0), nil
+	return structs.PeriodicLaunchTime(jobID)
 }
 
 // flush clears the state of the PeriodicDispatcher
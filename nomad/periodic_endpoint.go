@@ -12,6 +12,7 @@ import (
 	metrics "github.com/hashicorp/go-metrics/compat"
 
 	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -81,3 +82,50 @@ func (p *Periodic) Force(args *structs.PeriodicForceRequest, reply *structs.Peri
 	reply.Index = eval.CreateIndex
 	return nil
 }
+
+// History is used to list the outcomes of the recent launches of a periodic
+// job, most recent launch first.
+func (p *Periodic) History(args *structs.JobSpecificRequest, reply *structs.PeriodicHistoryResponse) error {
+
+	authErr := p.srv.Authenticate(p.ctx, args)
+	if done, err := p.srv.forward("Periodic.History", args, args, reply); done {
+		return err
+	}
+	p.srv.MeasureRPCRate("periodic", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "periodic", "history"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := p.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			runs, err := store.PeriodicHistoryByParent(ws, args.RequestNamespace(), args.JobID)
+			if err != nil {
+				return err
+			}
+			reply.Runs = runs
+
+			// Use the last index that affected the periodic history table
+			index, err := store.Index(state.TablePeriodicHistory)
+			if err != nil {
+				return err
+			}
+			reply.Index = max(1, index)
+
+			// Set the query response
+			p.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+
+	return p.srv.blockingRPC(&opts)
+}
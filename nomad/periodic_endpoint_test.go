@@ -10,9 +10,11 @@ import (
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -198,3 +200,56 @@ func TestPeriodicEndpoint_Force_NonPeriodic(t *testing.T) {
 		t.Fatalf("Force on non-periodic job should err")
 	}
 }
+
+func TestPeriodicEndpoint_History(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	state := s1.fsm.State()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create a periodic job with a failed launch in its history.
+	job := mock.PeriodicJob()
+	job.Periodic.FailedJobsHistoryLimit = pointer.Of(1)
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 100, nil, job))
+
+	child := job.Copy()
+	child.ID = job.ID + structs.PeriodicLaunchSuffix + "1700000000"
+	child.ParentID = job.ID
+	child.Periodic = nil
+	child.Stop = true
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 101, nil, child))
+
+	req := &structs.JobSpecificRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Try with no token and expect permission denied
+	var resp structs.PeriodicHistoryResponse
+	err := msgpackrpc.CallWithCodec(codec, "Periodic.History", req, &resp)
+	must.ErrorContains(t, err, structs.ErrPermissionDenied.Error())
+
+	// Fetch the response with a token that can read the job
+	policy := mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob})
+	token := mock.CreatePolicyAndToken(t, state, 1005, "valid", policy)
+	req.AuthToken = token.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Periodic.History", req, &resp))
+	must.Eq(t, 101, resp.Index)
+	must.Len(t, 1, resp.Runs)
+	must.Eq(t, child.ID, resp.Runs[0].JobID)
+	must.Eq(t, structs.PeriodicRunStatusFailed, resp.Runs[0].Status)
+
+	// Fetch the history of a job without one with the management token
+	req.AuthToken = root.SecretID
+	req.JobID = "missing"
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Periodic.History", req, &resp))
+	must.SliceEmpty(t, resp.Runs)
+}
@@ -45,6 +45,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/lock"
 	"github.com/hashicorp/nomad/nomad/reporting"
 	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/stream"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/nomad/structs/config"
 	"github.com/hashicorp/nomad/nomad/volumewatcher"
@@ -69,6 +70,7 @@ const (
 	peersPollJitterFactor = 2
 
 	raftState         = "raft/"
+	eventLogPath      = "events/"
 	serfSnapshot      = "serf/snapshot"
 	snapshotsRetained = 2
 
@@ -131,6 +133,10 @@ type Server struct {
 	// fsm is the state machine used with Raft
 	fsm *nomadFSM
 
+	// eventLog is the optional durable log of events published by the fsm's
+	// state store. It is shared by state stores created on snapshot restore.
+	eventLog *stream.EventLog
+
 	// rpcListener is used to listen for incoming connections
 	rpcListener net.Listener
 	listenerCh  chan struct{}
@@ -241,6 +247,10 @@ type Server struct {
 	// periodicDispatcher is used to track and create evaluations for periodic jobs.
 	periodicDispatcher *PeriodicDispatch
 
+	// dispatchLocks serializes checking the dispatch limits of each
+	// parameterized job with registering its dispatched jobs.
+	dispatchLocks dispatchLocks
+
 	// planner is used to mange the submitted allocation plans that are waiting
 	// to be accessed by the leader
 	*planner
@@ -756,6 +766,13 @@ func (s *Server) Shutdown() error {
 		s.fsm.Close()
 	}
 
+	// Close the event log once nothing can publish to it
+	if s.eventLog != nil {
+		if err := s.eventLog.Close(); err != nil {
+			s.logger.Warn("failed to close event log", "error", err)
+		}
+	}
+
 	// Stop being able to set Configuration Entries
 	s.consulConfigEntries.Stop()
 
@@ -1136,6 +1153,7 @@ func (s *Server) setupDeploymentWatcher() error {
 		NewJobEndpoints(s, nil),
 		s.config.DeploymentQueryRateLimit,
 		deploymentwatcher.CrossDeploymentUpdateBatchDuration,
+		s.config.CanaryAnalysisSources,
 	)
 
 	return nil
@@ -1319,6 +1337,23 @@ func (s *Server) setupRaft() error {
 		}
 	}()
 
+	// Open the event log, which outlives the FSM's state stores
+	if s.config.EventLogEnabled && s.config.EnableEventBroker && s.eventLog == nil {
+		if s.config.DevMode && s.config.DataDir == "" {
+			s.logger.Warn("event log requires a data directory, disabling")
+		} else {
+			eventLog, err := stream.NewEventLog(s.logger, stream.EventLogConfig{
+				Dir:     filepath.Join(s.config.DataDir, eventLogPath),
+				MaxAge:  s.config.EventLogMaxAge,
+				MaxSize: s.config.EventLogMaxSize,
+			})
+			if err != nil {
+				return err
+			}
+			s.eventLog = eventLog
+		}
+	}
+
 	// Create the FSM
 	fsmConfig := &FSMConfig{
 		EvalBroker:         s.evalBroker,
@@ -1329,6 +1364,7 @@ func (s *Server) setupRaft() error {
 		Region:             s.Region(),
 		EnableEventBroker:  s.config.EnableEventBroker,
 		EventBufferSize:    s.config.EventBufferSize,
+		EventLog:           s.eventLog,
 		JobTrackedVersions: s.config.JobTrackedVersions,
 	}
 
@@ -375,6 +375,11 @@ func (s *ServiceRegistration) GetService(
 					http.StatusBadRequest, "failed to read result page: %v", err)
 			}
 
+			services, err = weighServices(ws, stateStore, services)
+			if err != nil {
+				return err
+			}
+
 			// Select which subset and the order of services to return if using ?choose
 			if args.Choose != "" {
 				chosen, chooseErr := s.choose(services, args.Choose)
@@ -396,6 +401,136 @@ func (s *ServiceRegistration) GetService(
 	})
 }
 
+// serviceVersion identifies the registrations of a service by the allocations
+// of a task group that run either the job version being rolled out or an older
+// one.
+type serviceVersion struct {
+	namespace string
+	service   string
+	jobID     string
+	taskGroup string
+	latest    bool
+}
+
+// weighServices returns copies of the service registrations with their weight
+// set. The weight is relative to the other registrations of the service, with
+// 100 being an even share. While the task group of an allocation is rolled out
+// in steps, the percentage of the current step is the share of the job version
+// being rolled out and the rest is the share of the older versions, each split
+// evenly between the registrations of the allocations running them.
+func weighServices(ws memdb.WatchSet, stateStore *state.StateStore,
+	services []*structs.ServiceRegistration) ([]*structs.ServiceRegistration, error) {
+
+	deployments := make(map[structs.NamespacedID]*structs.Deployment)
+	allocs := make(map[string]*structs.Allocation)
+
+	// rollout returns the deployment state of the task group of the
+	// registration if it is being rolled out in steps.
+	rollout := func(service *structs.ServiceRegistration) (*structs.DeploymentState, serviceVersion, error) {
+		version := serviceVersion{
+			namespace: service.Namespace,
+			service:   service.ServiceName,
+			jobID:     service.JobID,
+		}
+
+		jobID := structs.NewNamespacedID(service.JobID, service.Namespace)
+		deployment, ok := deployments[jobID]
+		if !ok {
+			var err error
+			deployment, err = stateStore.LatestDeploymentByJobID(ws, service.Namespace, service.JobID)
+			if err != nil {
+				return nil, version, err
+			}
+			deployments[jobID] = deployment
+		}
+		if deployment == nil || !deployment.Active() {
+			return nil, version, nil
+		}
+
+		alloc, ok := allocs[service.AllocID]
+		if !ok {
+			var err error
+			alloc, err = stateStore.AllocByID(nil, service.AllocID)
+			if err != nil {
+				return nil, version, err
+			}
+			allocs[service.AllocID] = alloc
+		}
+		if alloc == nil || alloc.Job == nil {
+			return nil, version, nil
+		}
+
+		dstate, ok := deployment.TaskGroups[alloc.TaskGroup]
+		if !ok || dstate.RolloutSteps == 0 {
+			return nil, version, nil
+		}
+		version.taskGroup = alloc.TaskGroup
+		version.latest = alloc.Job.Version == deployment.JobVersion
+		return dstate, version, nil
+	}
+
+	// The registrations of each version are counted over all registrations
+	// of the service, not only the ones being weighed, so that the weights
+	// don't depend on pagination or on which endpoint looked them up.
+	counts := make(map[serviceVersion]int)
+	counted := make(map[structs.NamespacedID]bool)
+	count := func(service *structs.ServiceRegistration) error {
+		name := structs.NewNamespacedID(service.ServiceName, service.Namespace)
+		if counted[name] {
+			return nil
+		}
+		counted[name] = true
+
+		iter, err := stateStore.GetServiceRegistrationByName(ws, service.Namespace, service.ServiceName)
+		if err != nil {
+			return err
+		}
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			dstate, version, err := rollout(raw.(*structs.ServiceRegistration))
+			if err != nil {
+				return err
+			}
+			if dstate != nil {
+				counts[version]++
+			}
+		}
+		return nil
+	}
+
+	weighed := make([]*structs.ServiceRegistration, 0, len(services))
+	for _, service := range services {
+		service = service.Copy()
+		service.Weight = 100
+		weighed = append(weighed, service)
+
+		dstate, version, err := rollout(service)
+		if err != nil {
+			return nil, err
+		}
+		if dstate == nil {
+			continue
+		}
+		if err := count(service); err != nil {
+			return nil, err
+		}
+
+		share := dstate.StepPercent
+		if !version.latest {
+			share = 100 - share
+		}
+		other := version
+		other.latest = !version.latest
+		instances := max(counts[version], 1)
+		total := instances + counts[other]
+
+		// A share of p percent split between n of the t registrations is
+		// p*t/n times an even share of 100/t percent, rounded.
+		service.Weight = (share*total + instances/2) / instances
+	}
+
+	return weighed, nil
+}
+
 // choose uses rendezvous hashing to make a stable selection of a subset of services
 // to return.
 //
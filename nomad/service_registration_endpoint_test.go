@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/shoenig/test/must"
@@ -1217,6 +1218,7 @@ func TestServiceRegistration_GetService(t *testing.T) {
 				err := msgpackrpc.CallWithCodec(
 					codec, structs.ServiceRegistrationGetServiceRPCMethod, serviceRegReq, &serviceRegResp)
 				require.NoError(t, err)
+				nextServices[0].Weight = 100
 				require.ElementsMatch(t, []*structs.ServiceRegistration{nextServices[0]}, serviceRegResp.Services)
 
 				// Create a test function which can be used for each namespace
@@ -1430,6 +1432,80 @@ func TestServiceRegistration_GetService(t *testing.T) {
 	}
 }
 
+func TestServiceRegistration_weighServices(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+
+	// Three allocations run version 0 of the job and one runs version 1,
+	// which is rolled out by the second of three steps.
+	newAlloc := mock.Alloc()
+	newAlloc.Job.Version = 1
+	allocs := []*structs.Allocation{newAlloc}
+	for range 3 {
+		oldAlloc := mock.Alloc()
+		oldAlloc.JobID = newAlloc.JobID
+		allocs = append(allocs, oldAlloc)
+	}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 10, nil, newAlloc.Job))
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 20, allocs))
+
+	deployment := mock.Deployment()
+	deployment.JobID = newAlloc.JobID
+	deployment.JobVersion = 1
+	deployment.TaskGroups["web"].RolloutSteps = 3
+	deployment.TaskGroups["web"].CurrentStep = 1
+	deployment.TaskGroups["web"].StepPercent = 40
+	must.NoError(t, store.UpsertDeployment(30, deployment))
+
+	var services []*structs.ServiceRegistration
+	for _, alloc := range allocs {
+		service := mock.ServiceRegistrations()[0]
+		service.ID = fmt.Sprintf("_nomad-task-%s-web", alloc.ID)
+		service.JobID = alloc.JobID
+		service.AllocID = alloc.ID
+		services = append(services, service)
+	}
+	other := services[0].Copy()
+	other.ID = "_nomad-task-other-web"
+	other.JobID = "other"
+	services = append(services, other)
+	must.NoError(t, store.UpsertServiceRegistrations(structs.MsgTypeTestSetup, 40, services))
+
+	// The new version gets 40% of the traffic from its single registration,
+	// and the old version 60% split between its three registrations, relative
+	// to an even share of 100 per registration of the job.
+	weighed, err := weighServices(nil, store, services)
+	must.NoError(t, err)
+	must.Len(t, 5, weighed)
+	must.Eq(t, 160, weighed[0].Weight)
+	must.Eq(t, 80, weighed[1].Weight)
+	must.Eq(t, 80, weighed[2].Weight)
+	must.Eq(t, 80, weighed[3].Weight)
+	must.Eq(t, 100, weighed[4].Weight)
+
+	// Weighing only some of the registrations, such as those of a page or of
+	// a single allocation, gives them the same weights.
+	weighed, err = weighServices(nil, store, services[1:2])
+	must.NoError(t, err)
+	must.Len(t, 1, weighed)
+	must.Eq(t, 80, weighed[0].Weight)
+
+	// The registrations held by the state store are left untouched.
+	must.Zero(t, services[0].Weight)
+
+	// Once the deployment is over all registrations get the full weight.
+	deployment = deployment.Copy()
+	deployment.Status = structs.DeploymentStatusSuccessful
+	must.NoError(t, store.UpsertDeployment(50, deployment))
+
+	weighed, err = weighServices(nil, store, services)
+	must.NoError(t, err)
+	for _, service := range weighed {
+		must.Eq(t, 100, service.Weight)
+	}
+}
+
 func TestServiceRegistration_chooseErr(t *testing.T) {
 	ci.Parallel(t)
 
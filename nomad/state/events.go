@@ -27,6 +27,7 @@ var MsgTypeEvents = map[structs.MessageType]string{
 	structs.DeploymentStatusUpdateRequestType:            structs.TypeDeploymentUpdate,
 	structs.DeploymentPromoteRequestType:                 structs.TypeDeploymentPromotion,
 	structs.DeploymentAllocHealthRequestType:             structs.TypeDeploymentAllocHealth,
+	structs.DeploymentRolloutStepRequestType:             structs.TypeDeploymentUpdate,
 	structs.ApplyPlanResultsRequestType:                  structs.TypePlanResult,
 	structs.ACLTokenDeleteRequestType:                    structs.TypeACLTokenDeleted,
 	structs.ACLTokenUpsertRequestType:                    structs.TypeACLTokenUpserted,
@@ -46,6 +47,9 @@ var MsgTypeEvents = map[structs.MessageType]string{
 	structs.CSIVolumeRegisterRequestType:                 structs.TypeCSIVolumeRegistered,
 	structs.CSIVolumeDeregisterRequestType:               structs.TypeCSIVolumeDeregistered,
 	structs.CSIVolumeClaimRequestType:                    structs.TypeCSIVolumeClaim,
+	structs.VarApplyStateRequestType:                     structs.TypeVariableUpserted,
+	structs.NamespaceUpsertRequestType:                   structs.TypeNamespaceUpserted,
+	structs.NamespaceDeleteRequestType:                   structs.TypeNamespaceDeleted,
 }
 
 func eventsFromChanges(tx ReadTxn, changes Changes) *structs.Events {
@@ -57,7 +61,11 @@ func eventsFromChanges(tx ReadTxn, changes Changes) *structs.Events {
 	var events []structs.Event
 	for _, change := range changes.Changes {
 		if event, ok := eventFromChange(change); ok {
-			event.Type = eventType
+			// some changes, such as variable lock operations, determine
+			// their own event type
+			if event.Type == "" {
+				event.Type = eventType
+			}
 			event.Index = changes.Index
 			events = append(events, event)
 		}
@@ -238,6 +246,32 @@ func eventFromChange(change memdb.Change) (structs.Event, bool) {
 					Plugin: before,
 				},
 			}, true
+		case TableVariables:
+			before, ok := change.Before.(*structs.VariableEncrypted)
+			if !ok {
+				return structs.Event{}, false
+			}
+			return structs.Event{
+				Topic:     structs.TopicVariable,
+				Type:      structs.TypeVariableDeleted,
+				Key:       before.Path,
+				Namespace: before.Namespace,
+				Payload: &structs.VariableEvent{
+					Variable: variableEventMetadata(before),
+				},
+			}, true
+		case TableNamespaces:
+			before, ok := change.Before.(*structs.Namespace)
+			if !ok {
+				return structs.Event{}, false
+			}
+			return structs.Event{
+				Topic: structs.TopicNamespace,
+				Key:   before.Name,
+				Payload: &structs.NamespaceEvent{
+					Namespace: before,
+				},
+			}, true
 		}
 		return structs.Event{}, false
 	}
@@ -468,7 +502,65 @@ func eventFromChange(change memdb.Change) (structs.Event, bool) {
 				Plugin: after,
 			},
 		}, true
+	case TableVariables:
+		after, ok := change.After.(*structs.VariableEncrypted)
+		if !ok {
+			return structs.Event{}, false
+		}
+		before, _ := change.Before.(*structs.VariableEncrypted)
+		return structs.Event{
+			Topic:     structs.TopicVariable,
+			Type:      variableEventType(before, after),
+			Key:       after.Path,
+			Namespace: after.Namespace,
+			Payload: &structs.VariableEvent{
+				Variable: variableEventMetadata(after),
+			},
+		}, true
+	case TableNamespaces:
+		after, ok := change.After.(*structs.Namespace)
+		if !ok {
+			return structs.Event{}, false
+		}
+		return structs.Event{
+			Topic: structs.TopicNamespace,
+			Key:   after.Name,
+			Payload: &structs.NamespaceEvent{
+				Namespace: after,
+			},
+		}, true
 	}
 
 	return structs.Event{}, false
 }
+
+// variableEventMetadata returns the metadata of a variable to be included in
+// an event. The lock is removed, as the Variables endpoints do for callers
+// that aren't the lock holder, so that subscribers can't learn the lock ID.
+func variableEventMetadata(sv *structs.VariableEncrypted) *structs.VariableMetadata {
+	meta := sv.VariableMetadata.Copy()
+	meta.Lock = nil
+	return meta
+}
+
+// variableEventType returns the event type of a variable upsert, which is
+// either a lock acquisition, a lock release, or a plain upsert.
+func variableEventType(before, after *structs.VariableEncrypted) string {
+	var beforeLock string
+	if before != nil && before.Lock != nil {
+		beforeLock = before.Lock.ID
+	}
+	var afterLock string
+	if after.Lock != nil {
+		afterLock = after.Lock.ID
+	}
+
+	switch {
+	case afterLock != "" && afterLock != beforeLock:
+		return structs.TypeVariableLockAcquired
+	case afterLock == "" && beforeLock != "":
+		return structs.TypeVariableLockReleased
+	default:
+		return structs.TypeVariableUpserted
+	}
+}
@@ -1392,3 +1392,79 @@ func testNodeID() string {
 func testNodeIDTwo() string {
 	return "694ff31d-8c59-4030-ac83-e15692560c8d"
 }
+
+func TestEvents_Variables(t *testing.T) {
+	ci.Parallel(t)
+	store := TestStateStoreCfg(t, TestStateStorePublisher(t))
+	defer store.StopEventBroker()
+
+	index, err := store.LatestIndex()
+	must.NoError(t, err)
+
+	sv := mock.VariableEncrypted()
+	index++
+	resp := store.VarSet(index, &structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: sv})
+	must.NoError(t, resp.Error)
+
+	locked := sv.Copy()
+	locked.Lock = &structs.VariableLock{ID: uuid.Generate(), TTL: time.Minute}
+	index++
+	resp = store.VarLockAcquire(index, &structs.VarApplyStateRequest{Op: structs.VarOpLockAcquire, Var: &locked})
+	must.NoError(t, resp.Error)
+
+	index++
+	resp = store.VarLockRelease(index, &structs.VarApplyStateRequest{Op: structs.VarOpLockRelease, Var: &locked})
+	must.NoError(t, resp.Error)
+
+	index++
+	resp = store.VarDelete(index, &structs.VarApplyStateRequest{Op: structs.VarOpDelete, Var: sv})
+	must.NoError(t, resp.Error)
+
+	events := WaitForEvents(t, store, 0, 4, 1*time.Second)
+	must.Len(t, 4, events)
+
+	expected := []string{
+		structs.TypeVariableUpserted,
+		structs.TypeVariableLockAcquired,
+		structs.TypeVariableLockReleased,
+		structs.TypeVariableDeleted,
+	}
+	for i, event := range events {
+		must.Eq(t, structs.TopicVariable, event.Topic)
+		must.Eq(t, expected[i], event.Type)
+		must.Eq(t, sv.Path, event.Key)
+		must.Eq(t, sv.Namespace, event.Namespace)
+
+		// only metadata without the lock is ever included in the payload
+		payload, ok := event.Payload.(*structs.VariableEvent)
+		must.True(t, ok)
+		must.Eq(t, sv.Path, payload.Variable.Path)
+		must.Nil(t, payload.Variable.Lock)
+	}
+}
+
+func TestEvents_Namespaces(t *testing.T) {
+	ci.Parallel(t)
+	store := TestStateStoreCfg(t, TestStateStorePublisher(t))
+	defer store.StopEventBroker()
+
+	index, err := store.LatestIndex()
+	must.NoError(t, err)
+
+	ns := mock.Namespace()
+	index++
+	must.NoError(t, store.UpsertNamespaces(index, []*structs.Namespace{ns}))
+
+	index++
+	must.NoError(t, store.DeleteNamespaces(index, []string{ns.Name}))
+
+	// the default namespace created with the state store is not published
+	events := WaitForEvents(t, store, 0, 2, 1*time.Second)
+	must.Len(t, 2, events)
+	must.Eq(t, structs.TopicNamespace, events[0].Topic)
+	must.Eq(t, structs.TypeNamespaceUpserted, events[0].Type)
+	must.Eq(t, ns.Name, events[0].Key)
+	must.Eq(t, structs.TopicNamespace, events[1].Topic)
+	must.Eq(t, structs.TypeNamespaceDeleted, events[1].Type)
+	must.Eq(t, ns.Name, events[1].Key)
+}
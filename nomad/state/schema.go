@@ -30,6 +30,8 @@ const (
 	TableCSIVolumes               = "csi_volumes"
 	TableCSIPlugins               = "csi_plugins"
 	TableTaskGroupHostVolumeClaim = "task_volume"
+	TableDispatchQueue            = "dispatch_queue"
+	TablePeriodicHistory          = "periodic_history"
 )
 
 const (
@@ -47,6 +49,7 @@ const (
 	indexAuthMethod    = "auth_method"
 	indexNodePool      = "node_pool"
 	indexClaimID       = "claim_id"
+	indexParent        = "parent"
 )
 
 var (
@@ -104,6 +107,8 @@ func init() {
 		bindingRulesTableSchema,
 		hostVolumeTableSchema,
 		taskGroupHostVolumeClaimSchema,
+		dispatchQueueTableSchema,
+		periodicHistoryTableSchema,
 	}...)
 }
 
@@ -1713,3 +1718,87 @@ func taskGroupHostVolumeClaimSchema() *memdb.TableSchema {
 		},
 	}
 }
+
+// dispatchQueueTableSchema returns the MemDB schema for the queued dispatches
+// of parameterized jobs.
+func dispatchQueueTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableDispatchQueue,
+		Indexes: map[string]*memdb.IndexSchema{
+			// The ID of the dispatched job in combination with the namespace
+			// uniquely identifies a queued dispatch.
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+						&memdb.StringFieldIndex{
+							Field: "ID",
+						},
+					},
+				},
+			},
+			indexParent: {
+				Name:         indexParent,
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+						&memdb.StringFieldIndex{
+							Field: "ParentID",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// periodicHistoryTableSchema returns the MemDB schema for the history of
+// periodic jobs.
+func periodicHistoryTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TablePeriodicHistory,
+		Indexes: map[string]*memdb.IndexSchema{
+			// The ID of the launched job in combination with the namespace
+			// uniquely identifies a periodic run.
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+						&memdb.StringFieldIndex{
+							Field: "JobID",
+						},
+					},
+				},
+			},
+			indexParent: {
+				Name:         indexParent,
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+						&memdb.StringFieldIndex{
+							Field: "ParentID",
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -89,6 +89,9 @@ type StateStoreConfig struct {
 	// EventBufferSize configures the amount of events to hold in memory
 	EventBufferSize int64
 
+	// EventLog is the optional durable log the event publisher writes to
+	EventLog *stream.EventLog
+
 	// JobTrackedVersions is the number of historic job versions that are kept.
 	JobTrackedVersions int
 }
@@ -149,6 +152,7 @@ func NewStateStore(config *StateStoreConfig) (*StateStore, error) {
 		broker, err := stream.NewEventBroker(ctx, stream.EventBrokerCfg{
 			EventBufferSize: config.EventBufferSize,
 			Logger:          config.Logger,
+			EventLog:        config.EventLog,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("creating state store event broker %w", err)
@@ -198,7 +202,8 @@ func (s *StateStore) namespaceInit() error {
 		Description: structs.DefaultNamespaceDescription,
 	}
 
-	if err := s.UpsertNamespaces(1, []*structs.Namespace{defaultNs}); err != nil {
+	err := s.upsertNamespaces(structs.SystemInitializationType, 1, []*structs.Namespace{defaultNs})
+	if err != nil {
 		return fmt.Errorf("inserting default namespace failed: %v", err)
 	}
 
@@ -1813,6 +1818,12 @@ func (s *StateStore) upsertJobImpl(index uint64, sub *structs.JobSubmission, job
 			return fmt.Errorf("setting job status for %q failed: %v", job.ID, err)
 		}
 
+		if job.Dispatched {
+			if err := s.recordDispatchTxn(index, txn, job); err != nil {
+				return err
+			}
+		}
+
 		// Have to get the job again since it could have been updated
 		updated, err := txn.First("jobs", "id", job.Namespace, job.ID)
 		if err != nil {
@@ -4961,6 +4972,58 @@ func (s *StateStore) UpdateDeploymentPromotion(msgType structs.MessageType, inde
 	return txn.Commit()
 }
 
+// UpdateDeploymentRolloutStep is used to move a task group of a deployment on
+// to its next rollout step and potentially make an evaluation
+func (s *StateStore) UpdateDeploymentRolloutStep(msgType structs.MessageType, index uint64, req *structs.ApplyDeploymentRolloutStepRequest) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	// Retrieve deployment and ensure it is not terminal and is active
+	ws := memdb.NewWatchSet()
+	deployment, err := s.deploymentByIDImpl(ws, req.DeploymentID, txn)
+	if err != nil {
+		return err
+	} else if deployment == nil {
+		return fmt.Errorf("Deployment ID %q couldn't be updated as it does not exist", req.DeploymentID)
+	} else if !deployment.Active() {
+		return fmt.Errorf("Deployment %q has terminal status %q:", deployment.ID, deployment.Status)
+	}
+
+	dstate, ok := deployment.TaskGroups[req.Group]
+	if !ok {
+		return fmt.Errorf("Deployment %q has no task group %q", deployment.ID, req.Group)
+	} else if req.Step <= dstate.CurrentStep || req.Step >= dstate.RolloutSteps {
+		return fmt.Errorf("Task group %q can not move from rollout step %d to step %d", req.Group, dstate.CurrentStep, req.Step)
+	}
+
+	// Update deployment
+	copy := deployment.Copy()
+	copy.ModifyIndex = index
+	status := copy.TaskGroups[req.Group]
+	status.CurrentStep = req.Step
+	status.StepPercent = req.Percent
+
+	// reset the progress deadline, as no progress is made while a step is
+	// paused
+	if status.ProgressDeadline > 0 && !status.RequireProgressBy.IsZero() {
+		status.RequireProgressBy = time.Now().Add(status.ProgressDeadline)
+	}
+
+	// Insert the deployment
+	if err := s.upsertDeploymentImpl(index, copy, txn); err != nil {
+		return err
+	}
+
+	// Upsert the optional eval
+	if req.Eval != nil {
+		if err := s.nestedUpsertEval(txn, index, req.Eval); err != nil {
+			return err
+		}
+	}
+
+	return txn.Commit()
+}
+
 // UpdateDeploymentAllocHealth is used to update the health of allocations as
 // part of the deployment and potentially make a evaluation
 func (s *StateStore) UpdateDeploymentAllocHealth(msgType structs.MessageType, index uint64, req *structs.ApplyDeploymentAllocHealthRequest) error {
@@ -5173,6 +5236,12 @@ func (s *StateStore) ReconcileJobSummaries(index uint64) error {
 				Children:  &structs.JobChildrenSummary{},
 			}
 
+			// The dispatch times can't be recomputed from the children, as
+			// they only track the dispatches counted by the rate limit
+			if oldSummary.Children != nil {
+				summary.Children.DispatchTimes = oldSummary.Children.DispatchTimes
+			}
+
 			// Iterate over children of this job if any to fix summary counts
 			children := parentMap[job.ID]
 			for _, childJob := range children {
@@ -5346,6 +5415,13 @@ func (s *StateStore) setJobStatus(index uint64, txn *txn,
 		return err
 	}
 
+	// Record the outcome of jobs launched by periodic jobs
+	if newStatus == structs.JobStatusDead && updated.ParentID != "" {
+		if err := s.upsertPeriodicRunTxn(index, txn, updated); err != nil {
+			return fmt.Errorf("periodic run update failed: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -6808,7 +6884,13 @@ func (s *StateStore) NamespaceNames() ([]string, error) {
 
 // UpsertNamespaces is used to register or update a set of namespaces.
 func (s *StateStore) UpsertNamespaces(index uint64, namespaces []*structs.Namespace) error {
-	txn := s.db.WriteTxn(index)
+	return s.upsertNamespaces(structs.NamespaceUpsertRequestType, index, namespaces)
+}
+
+// upsertNamespaces upserts a set of namespaces within a transaction of the
+// given message type, which determines the events that are published.
+func (s *StateStore) upsertNamespaces(msgType structs.MessageType, index uint64, namespaces []*structs.Namespace) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
 	defer txn.Abort()
 
 	for _, ns := range namespaces {
@@ -6877,7 +6959,7 @@ func (s *StateStore) upsertNamespaceImpl(index uint64, txn *txn, namespace *stru
 
 // DeleteNamespaces is used to remove a set of namespaces
 func (s *StateStore) DeleteNamespaces(index uint64, names []string) error {
-	txn := s.db.WriteTxn(index)
+	txn := s.db.WriteTxnMsgT(structs.NamespaceDeleteRequestType, index)
 	defer txn.Abort()
 
 	for _, name := range names {
@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// recordDispatchTxn records the submit time of a new dispatched job in the
+// children summary of its parameterized job, if the parameterized job has a
+// rate limit, so that the rate limit can be checked without scanning every
+// dispatched job.
+func (s *StateStore) recordDispatchTxn(index uint64, txn *txn, job *structs.Job) error {
+	raw, err := txn.First("jobs", "id", job.Namespace, job.ParentID)
+	if err != nil {
+		return fmt.Errorf("parent job lookup failed: %w", err)
+	}
+	if raw == nil {
+		return nil
+	}
+	parent := raw.(*structs.Job)
+	if !parent.IsParameterized() || parent.ParameterizedJob.RateLimit == nil {
+		return nil
+	}
+
+	raw, err = txn.First("job_summary", "id", job.Namespace, job.ParentID)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve summary for parent job: %w", err)
+	}
+	if raw == nil {
+		return nil
+	}
+	summary := raw.(*structs.JobSummary).Copy()
+	if summary.Children == nil {
+		summary.Children = new(structs.JobChildrenSummary)
+	}
+
+	times := append(summary.Children.DispatchTimes, job.SubmitTime)
+	slices.Sort(times)
+	if limit := parent.ParameterizedJob.RateLimit.Limit; len(times) > limit {
+		times = times[len(times)-limit:]
+	}
+	summary.Children.DispatchTimes = times
+	summary.ModifyIndex = index
+
+	if err := txn.Insert("job_summary", summary); err != nil {
+		return fmt.Errorf("job summary insert failed: %w", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"job_summary", index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+	return nil
+}
+
+// UpsertQueuedDispatch is used to queue a dispatch of a parameterized job.
+func (s *StateStore) UpsertQueuedDispatch(msgType structs.MessageType, index uint64, dispatch *structs.QueuedDispatch) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	existing, err := txn.First(TableDispatchQueue, indexID, dispatch.Namespace, dispatch.ID)
+	if err != nil {
+		return fmt.Errorf("queued dispatch lookup failed: %w", err)
+	}
+	if existing != nil {
+		dispatch.CreateIndex = existing.(*structs.QueuedDispatch).CreateIndex
+	} else {
+		dispatch.CreateIndex = index
+	}
+	dispatch.ModifyIndex = index
+
+	if err := txn.Insert(TableDispatchQueue, dispatch); err != nil {
+		return fmt.Errorf("queued dispatch insert failed: %w", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableDispatchQueue, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
+
+// DeleteQueuedDispatches is used to remove dispatches from the queue. Missing
+// dispatches are ignored, as they may already have been released.
+func (s *StateStore) DeleteQueuedDispatches(msgType structs.MessageType, index uint64, namespace string, ids []string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	var deleted bool
+	for _, id := range ids {
+		existing, err := txn.First(TableDispatchQueue, indexID, namespace, id)
+		if err != nil {
+			return fmt.Errorf("queued dispatch lookup failed: %w", err)
+		}
+		if existing == nil {
+			continue
+		}
+		if err := txn.Delete(TableDispatchQueue, existing); err != nil {
+			return fmt.Errorf("queued dispatch deletion failed: %w", err)
+		}
+		deleted = true
+	}
+
+	if !deleted {
+		return nil
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableDispatchQueue, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
+
+// QueuedDispatchByID returns the queued dispatch with the given dispatched
+// job ID, or nil if it isn't queued.
+func (s *StateStore) QueuedDispatchByID(ws memdb.WatchSet, namespace, id string) (*structs.QueuedDispatch, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableDispatchQueue, indexID, namespace, id)
+	if err != nil {
+		return nil, fmt.Errorf("queued dispatch lookup failed: %w", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.QueuedDispatch), nil
+	}
+	return nil, nil
+}
+
+// QueuedDispatchesByParent returns the queued dispatches of a parameterized
+// job in the order they were queued.
+func (s *StateStore) QueuedDispatchesByParent(ws memdb.WatchSet, namespace, parentID string) ([]*structs.QueuedDispatch, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableDispatchQueue, indexParent, namespace, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("queued dispatch lookup failed: %w", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	var dispatches []*structs.QueuedDispatch
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		dispatches = append(dispatches, raw.(*structs.QueuedDispatch))
+	}
+
+	// Entries that share an index key are ordered by their ID, so restore
+	// the order in which they were queued.
+	slices.SortFunc(dispatches, func(a, b *structs.QueuedDispatch) int {
+		return cmp.Compare(a.CreateIndex, b.CreateIndex)
+	})
+	return dispatches, nil
+}
+
+// QueuedDispatches returns an iterator over all the queued dispatches.
+func (s *StateStore) QueuedDispatches(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableDispatchQueue, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("queued dispatch lookup failed: %w", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestStateStore_QueuedDispatches(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	// Queue dispatches whose IDs sort differently from the order in which
+	// they were queued.
+	for i, id := range []string{"job/dispatch-c", "job/dispatch-a", "job/dispatch-b"} {
+		must.NoError(t, store.UpsertQueuedDispatch(structs.MsgTypeTestSetup, uint64(10+i), &structs.QueuedDispatch{
+			ID:        id,
+			Namespace: structs.DefaultNamespace,
+			ParentID:  "job",
+		}))
+	}
+	must.NoError(t, store.UpsertQueuedDispatch(structs.MsgTypeTestSetup, 20, &structs.QueuedDispatch{
+		ID:        "other/dispatch-a",
+		Namespace: structs.DefaultNamespace,
+		ParentID:  "other",
+	}))
+
+	index, err := store.Index(TableDispatchQueue)
+	must.NoError(t, err)
+	must.Eq(t, 20, index)
+
+	ws := memdb.NewWatchSet()
+	dispatches, err := store.QueuedDispatchesByParent(ws, structs.DefaultNamespace, "job")
+	must.NoError(t, err)
+	must.Len(t, 3, dispatches)
+	must.Eq(t, "job/dispatch-c", dispatches[0].ID)
+	must.Eq(t, "job/dispatch-a", dispatches[1].ID)
+	must.Eq(t, "job/dispatch-b", dispatches[2].ID)
+
+	// Releasing a dispatch fires the watch, and deleting missing dispatches
+	// is a no-op.
+	must.NoError(t, store.DeleteQueuedDispatches(structs.MsgTypeTestSetup, 21,
+		structs.DefaultNamespace, []string{"job/dispatch-c", "job/dispatch-missing"}))
+	must.True(t, watchFired(ws))
+
+	must.NoError(t, store.DeleteQueuedDispatches(structs.MsgTypeTestSetup, 22,
+		structs.DefaultNamespace, []string{"job/dispatch-missing"}))
+	index, err = store.Index(TableDispatchQueue)
+	must.NoError(t, err)
+	must.Eq(t, 21, index)
+
+	dispatch, err := store.QueuedDispatchByID(nil, structs.DefaultNamespace, "job/dispatch-c")
+	must.NoError(t, err)
+	must.Nil(t, dispatch)
+
+	dispatch, err = store.QueuedDispatchByID(nil, structs.DefaultNamespace, "job/dispatch-a")
+	must.NoError(t, err)
+	must.NotNil(t, dispatch)
+	must.Eq(t, 11, dispatch.CreateIndex)
+
+	iter, err := store.QueuedDispatches(nil)
+	must.NoError(t, err)
+	var count int
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		count++
+	}
+	must.Eq(t, 3, count)
+}
+
+func TestStateStore_RecordDispatchTimes(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	parent := mock.BatchJob()
+	parent.ParameterizedJob = &structs.ParameterizedJobConfig{
+		RateLimit: &structs.DispatchRateLimit{Limit: 2, Interval: time.Minute},
+	}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 10, nil, parent))
+
+	// Only the most recent dispatches up to the rate limit are kept, even if
+	// they are registered out of order.
+	for i, submitTime := range []int64{300, 100, 200} {
+		child := parent.Copy()
+		child.ID = fmt.Sprintf("%s/dispatch-%d", parent.ID, i)
+		child.ParentID = parent.ID
+		child.Dispatched = true
+		child.ParameterizedJob = nil
+		child.Status = ""
+		child.SubmitTime = submitTime
+		must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, uint64(11+i), nil, child))
+	}
+
+	summary, err := store.JobSummaryByID(nil, parent.Namespace, parent.ID)
+	must.NoError(t, err)
+	must.Eq(t, []int64{200, 300}, summary.Children.DispatchTimes)
+	must.Eq(t, 3, summary.Children.Pending)
+	must.Eq(t, 13, summary.ModifyIndex)
+}
@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// upsertPeriodicRunTxn records the outcome of a dead job in the history of
+// the periodic job that launched it, and prunes the history down to the limits
+// of the periodic job. Jobs that weren't launched by a periodic job are
+// ignored.
+func (s *StateStore) upsertPeriodicRunTxn(index uint64, txn Txn, job *structs.Job) error {
+	raw, err := txn.First("jobs", "id", job.Namespace, job.ParentID)
+	if err != nil {
+		return fmt.Errorf("parent job lookup failed: %w", err)
+	}
+	if raw == nil {
+		return nil
+	}
+	parent := raw.(*structs.Job)
+	if !parent.IsPeriodic() {
+		return nil
+	}
+
+	iter, err := txn.Get("allocs", "job", job.Namespace, job.ID)
+	if err != nil {
+		return fmt.Errorf("alloc lookup failed: %w", err)
+	}
+	var allocs []*structs.Allocation
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		alloc := raw.(*structs.Allocation)
+		if alloc.Job != nil && alloc.Job.CreateIndex != job.CreateIndex {
+			continue
+		}
+		allocs = append(allocs, alloc)
+	}
+
+	run := structs.NewPeriodicRun(job, allocs)
+	existing, err := txn.First(TablePeriodicHistory, indexID, run.Namespace, run.JobID)
+	if err != nil {
+		return fmt.Errorf("periodic run lookup failed: %w", err)
+	}
+	if existing != nil {
+		run.CreateIndex = existing.(*structs.PeriodicRun).CreateIndex
+	} else {
+		run.CreateIndex = index
+	}
+	run.ModifyIndex = index
+
+	if err := txn.Insert(TablePeriodicHistory, run); err != nil {
+		return fmt.Errorf("periodic run insert failed: %w", err)
+	}
+
+	// Prune the oldest runs of each status beyond the limits of the
+	// periodic job
+	runs, err := periodicRunsByParentTxn(nil, txn, parent.Namespace, parent.ID)
+	if err != nil {
+		return err
+	}
+	var successful, failed int
+	for _, run := range runs {
+		var keep bool
+		switch run.Status {
+		case structs.PeriodicRunStatusSuccessful:
+			successful++
+			keep = successful <= parent.Periodic.SuccessfulHistoryLimit()
+		default:
+			failed++
+			keep = failed <= parent.Periodic.FailedHistoryLimit()
+		}
+		if keep {
+			continue
+		}
+		if err := txn.Delete(TablePeriodicHistory, run); err != nil {
+			return fmt.Errorf("periodic run deletion failed: %w", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TablePeriodicHistory, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+	return nil
+}
+
+// DeletePeriodicHistoryTxn is used to delete the history of a periodic job.
+func (s *StateStore) DeletePeriodicHistoryTxn(index uint64, namespace, jobID string, txn Txn) error {
+	deleted, err := txn.DeleteAll(TablePeriodicHistory, indexParent, namespace, jobID)
+	if err != nil {
+		return fmt.Errorf("periodic history deletion failed: %w", err)
+	}
+	if deleted == 0 {
+		return nil
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TablePeriodicHistory, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+	return nil
+}
+
+// PeriodicHistoryByParent returns the history of a periodic job, most recent
+// launch first.
+func (s *StateStore) PeriodicHistoryByParent(ws memdb.WatchSet, namespace, parentID string) ([]*structs.PeriodicRun, error) {
+	txn := s.db.ReadTxn()
+	return periodicRunsByParentTxn(ws, txn, namespace, parentID)
+}
+
+func periodicRunsByParentTxn(ws memdb.WatchSet, txn Txn, namespace, parentID string) ([]*structs.PeriodicRun, error) {
+	iter, err := txn.Get(TablePeriodicHistory, indexParent, namespace, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("periodic history lookup failed: %w", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	var runs []*structs.PeriodicRun
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		runs = append(runs, raw.(*structs.PeriodicRun))
+	}
+
+	slices.SortFunc(runs, func(a, b *structs.PeriodicRun) int {
+		return cmp.Or(b.LaunchTime.Compare(a.LaunchTime), cmp.Compare(b.CreateIndex, a.CreateIndex))
+	})
+	return runs, nil
+}
+
+// PeriodicHistory returns an iterator over the runs of all periodic jobs.
+func (s *StateStore) PeriodicHistory(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TablePeriodicHistory, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("periodic history lookup failed: %w", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestStateStore_PeriodicHistory(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	parent := mock.PeriodicJob()
+	parent.Periodic.SuccessfulJobsHistoryLimit = pointer.Of(2)
+	parent.Periodic.FailedJobsHistoryLimit = pointer.Of(1)
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 100, nil, parent))
+
+	index := uint64(100)
+	start := time.Unix(1700000000, 0)
+
+	// launch runs a job launched by the periodic job to completion
+	launch := func(hour int, clientStatus string, exitCode int) *structs.Job {
+		child := parent.Copy()
+		child.ID = fmt.Sprintf("%s%s%d", parent.ID, structs.PeriodicLaunchSuffix,
+			start.Add(time.Duration(hour)*time.Hour).Unix())
+		child.ParentID = parent.ID
+		child.Periodic = nil
+		child.Status = ""
+		index++
+		must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, index, nil, child))
+		child, _ = store.JobByID(nil, child.Namespace, child.ID)
+
+		alloc := mock.Alloc()
+		alloc.Job = child
+		alloc.JobID = child.ID
+		alloc.TaskGroup = child.TaskGroups[0].Name
+		alloc.ClientStatus = structs.AllocClientStatusPending
+		index++
+		must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, index, []*structs.Allocation{alloc}))
+
+		eval := mock.Eval()
+		eval.JobID = child.ID
+		eval.Status = structs.EvalStatusComplete
+		index++
+		must.NoError(t, store.UpsertEvals(structs.MsgTypeTestSetup, index, []*structs.Evaluation{eval}))
+
+		update := alloc.Copy()
+		update.ClientStatus = clientStatus
+		update.TaskStates = map[string]*structs.TaskState{
+			"web": {
+				State:      structs.TaskStateDead,
+				Failed:     clientStatus == structs.AllocClientStatusFailed,
+				FinishedAt: start.Add(time.Duration(hour)*time.Hour + time.Minute),
+				Events: []*structs.TaskEvent{
+					structs.NewTaskEvent(structs.TaskTerminated).SetExitCode(exitCode),
+				},
+			},
+		}
+		index++
+		must.NoError(t, store.UpdateAllocsFromClient(structs.MsgTypeTestSetup, index, []*structs.Allocation{update}))
+		return child
+	}
+
+	ws := memdb.NewWatchSet()
+	_, err := store.PeriodicHistoryByParent(ws, parent.Namespace, parent.ID)
+	must.NoError(t, err)
+
+	// Only the most recent runs of each status are kept
+	launch(1, structs.AllocClientStatusComplete, 0)
+	must.True(t, watchFired(ws))
+	success2 := launch(2, structs.AllocClientStatusComplete, 0)
+	launch(3, structs.AllocClientStatusFailed, 1)
+	success4 := launch(4, structs.AllocClientStatusComplete, 0)
+	failed5 := launch(5, structs.AllocClientStatusFailed, 2)
+
+	runs, err := store.PeriodicHistoryByParent(nil, parent.Namespace, parent.ID)
+	must.NoError(t, err)
+	must.Len(t, 3, runs)
+	must.Eq(t, failed5.ID, runs[0].JobID)
+	must.Eq(t, success4.ID, runs[1].JobID)
+	must.Eq(t, success2.ID, runs[2].JobID)
+
+	must.Eq(t, structs.PeriodicRunStatusFailed, runs[0].Status)
+	must.Eq(t, start.Add(5*time.Hour).Unix(), runs[0].LaunchTime.Unix())
+	must.Eq(t, start.Add(5*time.Hour+time.Minute).Unix(), runs[0].FinishTime.Unix())
+	must.Len(t, 1, runs[0].Tasks)
+	must.Eq(t, 2, runs[0].Tasks[0].ExitCode)
+	must.True(t, runs[0].Tasks[0].Failed)
+	must.Eq(t, structs.PeriodicRunStatusSuccessful, runs[1].Status)
+
+	// The history is kept when the launched job is garbage collected
+	must.NoError(t, store.DeleteJob(index+1, failed5.Namespace, failed5.ID))
+	runs, err = store.PeriodicHistoryByParent(nil, parent.Namespace, parent.ID)
+	must.NoError(t, err)
+	must.Len(t, 3, runs)
+
+	// Stopped jobs count as failed
+	stopped := parent.Copy()
+	stopped.ID = fmt.Sprintf("%s%s%d", parent.ID, structs.PeriodicLaunchSuffix, start.Add(6*time.Hour).Unix())
+	stopped.ParentID = parent.ID
+	stopped.Periodic = nil
+	stopped.Stop = true
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, index+2, nil, stopped))
+	runs, err = store.PeriodicHistoryByParent(nil, parent.Namespace, parent.ID)
+	must.NoError(t, err)
+	must.Len(t, 3, runs)
+	must.Eq(t, stopped.ID, runs[0].JobID)
+	must.Eq(t, structs.PeriodicRunStatusFailed, runs[0].Status)
+
+	// Purging the periodic job deletes its history
+	txn := store.db.WriteTxn(index + 3)
+	must.NoError(t, store.DeletePeriodicHistoryTxn(index+3, parent.Namespace, parent.ID, txn))
+	must.NoError(t, txn.Commit())
+
+	runs, err = store.PeriodicHistoryByParent(nil, parent.Namespace, parent.ID)
+	must.NoError(t, err)
+	must.SliceEmpty(t, runs)
+
+	tableIndex, err := store.Index(TablePeriodicHistory)
+	must.NoError(t, err)
+	must.Eq(t, index+3, tableIndex)
+}
+
+func TestStateStore_PeriodicHistory_Limits(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+	index := uint64(100)
+
+	// stop records a failed run of the periodic job by launching a stopped job
+	stop := func(parent *structs.Job, i int) {
+		child := parent.Copy()
+		child.ID = fmt.Sprintf("%s%s%d", parent.ID, structs.PeriodicLaunchSuffix, 1700000000+i)
+		child.ParentID = parent.ID
+		child.Periodic = nil
+		child.Stop = true
+		index++
+		must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, index, nil, child))
+	}
+
+	// Unset limits keep the default history
+	unset := mock.PeriodicJob()
+	unset.Periodic.SuccessfulJobsHistoryLimit = nil
+	unset.Periodic.FailedJobsHistoryLimit = nil
+	index++
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, index, nil, unset))
+	for i := 0; i < 3; i++ {
+		stop(unset, i)
+	}
+	runs, err := store.PeriodicHistoryByParent(nil, unset.Namespace, unset.ID)
+	must.NoError(t, err)
+	must.Len(t, structs.PeriodicFailedJobsHistoryLimit, runs)
+
+	// A limit of 0 keeps no history
+	zero := mock.PeriodicJob()
+	zero.Periodic.FailedJobsHistoryLimit = pointer.Of(0)
+	index++
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, index, nil, zero))
+	stop(zero, 0)
+	runs, err = store.PeriodicHistoryByParent(nil, zero.Namespace, zero.ID)
+	must.NoError(t, err)
+	must.SliceEmpty(t, runs)
+}
@@ -283,3 +283,21 @@ func (r *StateRestore) HostVolumeRestore(vol *structs.HostVolume) error {
 	}
 	return nil
 }
+
+// QueuedDispatchRestore restores a single queued dispatch into the
+// dispatch_queue table.
+func (r *StateRestore) QueuedDispatchRestore(dispatch *structs.QueuedDispatch) error {
+	if err := r.txn.Insert(TableDispatchQueue, dispatch); err != nil {
+		return fmt.Errorf("queued dispatch insert failed: %w", err)
+	}
+	return nil
+}
+
+// PeriodicRunRestore restores a single periodic run into the periodic_history
+// table.
+func (r *StateRestore) PeriodicRunRestore(run *structs.PeriodicRun) error {
+	if err := r.txn.Insert(TablePeriodicHistory, run); err != nil {
+		return fmt.Errorf("periodic run insert failed: %w", err)
+	}
+	return nil
+}
@@ -8438,6 +8438,45 @@ func TestStateStore_UpdateJobStability(t *testing.T) {
 	require.False(t, jout.Stable)
 }
 
+// Test moving a task group of a deployment on to its next rollout step
+func TestStateStore_UpdateDeploymentRolloutStep(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+
+	d := mock.Deployment()
+	d.TaskGroups["web"].RolloutSteps = 3
+	d.TaskGroups["web"].StepPercent = 10
+	must.NoError(t, state.UpsertDeployment(1, d))
+
+	// Steps can't be skipped backwards or past the last step
+	for _, step := range []int{0, 3} {
+		err := state.UpdateDeploymentRolloutStep(structs.MsgTypeTestSetup, 2,
+			&structs.ApplyDeploymentRolloutStepRequest{DeploymentID: d.ID, Group: "web", Step: step})
+		must.ErrorContains(t, err, "can not move from rollout step")
+	}
+
+	e := mock.Eval()
+	req := &structs.ApplyDeploymentRolloutStepRequest{
+		DeploymentID: d.ID,
+		Group:        "web",
+		Step:         1,
+		Percent:      50,
+		Eval:         e,
+	}
+	must.NoError(t, state.UpdateDeploymentRolloutStep(structs.MsgTypeTestSetup, 3, req))
+
+	dout, err := state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	must.Eq(t, uint64(3), dout.ModifyIndex)
+	must.Eq(t, 1, dout.TaskGroups["web"].CurrentStep)
+	must.Eq(t, 50, dout.TaskGroups["web"].StepPercent)
+
+	eout, err := state.EvalByID(nil, e.ID)
+	must.NoError(t, err)
+	must.NotNil(t, eout)
+}
+
 // Test that nonexistent deployment can't be promoted
 func TestStateStore_UpsertDeploymentPromotion_Nonexistent(t *testing.T) {
 	ci.Parallel(t)
@@ -24,6 +24,11 @@ const (
 type EventBrokerCfg struct {
 	EventBufferSize int64
 	Logger          hclog.Logger
+
+	// EventLog is an optional durable log that published events are written
+	// to. Subscriptions requesting an index that is no longer in the event
+	// buffer are served from it.
+	EventLog *EventLog
 }
 
 type EventBroker struct {
@@ -34,6 +39,9 @@ type EventBroker struct {
 	// eventBuf stores a configurable amount of events in memory
 	eventBuf *eventBuffer
 
+	// eventLog optionally stores events on disk beyond the eventBuf
+	eventLog *EventLog
+
 	// publishCh is used to send messages from an active txn to a goroutine which
 	// publishes events, so that publishing can happen asynchronously from
 	// the Commit call in the FSM hot path.
@@ -62,6 +70,7 @@ func NewEventBroker(ctx context.Context, cfg EventBrokerCfg) (*EventBroker, erro
 	e := &EventBroker{
 		logger:    cfg.Logger.Named("event_broker"),
 		eventBuf:  buffer,
+		eventLog:  cfg.EventLog,
 		publishCh: make(chan *structs.Events, 64),
 		aclCh:     make(chan structs.Event, 10),
 		subscriptions: &subscriptions{
@@ -120,17 +129,26 @@ func (e *EventBroker) Subscribe(req *SubscribeRequest) (*Subscription, error) {
 	} else {
 		head = e.eventBuf.Head()
 	}
+
+	// If the requested index has been dropped from the buffer, replay the
+	// events from the event log before continuing from the buffer.
+	var replay *eventLogReader
+	closest := head.Events.Index
+	if e.replayable(req.Index, head, offset) {
+		replay = e.eventLog.newEventLogReader(req.Index)
+		closest = max(req.Index, e.eventLog.FirstIndex())
+		offset = int(closest) - int(req.Index)
+	}
+
 	if offset > 0 && req.StartExactlyAtIndex {
 		return nil, fmt.Errorf("requested index not in buffer")
 	} else if offset > 0 {
 		metrics.SetGauge([]string{"nomad", "event_broker", "subscription", "request_offset"}, float32(offset))
-		e.logger.Debug("requested index no longer in buffer", "requsted", int(req.Index), "closest", int(head.Events.Index))
+		e.logger.Debug("requested index no longer in buffer", "requsted", int(req.Index), "closest", int(closest))
 	}
 
 	// Empty head so that calling Next on sub
-	start := newBufferItem(&structs.Events{Index: req.Index})
-	start.link.next.Store(head)
-	close(start.link.nextCh)
+	start := newStartItem(req.Index, head)
 
 	if req.Authenticate == nil {
 		req.Authenticate = func() error {
@@ -141,11 +159,30 @@ func (e *EventBroker) Subscribe(req *SubscribeRequest) (*Subscription, error) {
 	}
 
 	sub := newSubscription(req, start, e.subscriptions.unsubscribeFn(req))
+	if replay != nil {
+		sub.replay = replay
+		sub.resume = e.eventBuf.StartAfter
+	}
 
 	e.subscriptions.add(req, sub)
 	return sub, nil
 }
 
+// replayable returns true if a subscription for index should be served from
+// the event log. This is the case when the closest item in the buffer is
+// after the requested index, or the buffer is empty, and the event log
+// contains events at or after the requested index.
+func (e *EventBroker) replayable(index uint64, closest *bufferItem, offset int) bool {
+	if e.eventLog == nil || index == 0 || offset == 0 {
+		return false
+	}
+	if closest.Events.Index != 0 && closest.Events.Index < index {
+		// the requested index is not yet in the buffer
+		return false
+	}
+	return e.eventLog.LastIndex() >= index
+}
+
 // CloseAll closes all subscriptions
 func (e *EventBroker) CloseAll() {
 	e.subscriptions.closeAll()
@@ -158,6 +195,14 @@ func (e *EventBroker) handleUpdates(ctx context.Context) {
 			e.subscriptions.closeAll()
 			return
 		case update := <-e.publishCh:
+			// Write to the event log first, so any events in the buffer
+			// are also in the log when a subscription switches from
+			// replaying the log to reading the buffer.
+			if e.eventLog != nil {
+				if err := e.eventLog.Append(update); err != nil {
+					e.logger.Error("failed to write events to event log", "index", update.Index, "error", err)
+				}
+			}
 			e.eventBuf.Append(update)
 		}
 	}
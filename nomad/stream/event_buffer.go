@@ -181,6 +181,25 @@ func (b *eventBuffer) StartAtClosest(index uint64) (*bufferItem, int) {
 	}
 }
 
+// StartAfter returns the newest item in the buffer with an index less than or
+// equal to index, so that calling Next on it returns the events published
+// after index. If index is older than the head of the buffer, an empty item
+// linked to the head is returned.
+func (b *eventBuffer) StartAfter(index uint64) *bufferItem {
+	item := b.Head()
+	if item.Events.Index > index {
+		return newStartItem(index, item)
+	}
+
+	for {
+		next := item.NextNoBlock()
+		if next == nil || next.Events.Index > index {
+			return item
+		}
+		item = next
+	}
+}
+
 // Len returns the current length of the buffer
 func (b *eventBuffer) Len() int {
 	return int(atomic.LoadInt64(b.size))
@@ -252,6 +271,15 @@ func newBufferItem(events *structs.Events) *bufferItem {
 	}
 }
 
+// newStartItem returns an empty item with the given index whose next item is
+// next. It allows a subscriber to call Next to receive next.
+func newStartItem(index uint64, next *bufferItem) *bufferItem {
+	start := newBufferItem(&structs.Events{Index: index})
+	start.link.next.Store(next)
+	close(start.link.nextCh)
+	return start
+}
+
 // Next return the next buffer item in the buffer. It may block until ctx is
 // cancelled or until the next item is published.
 func (i *bufferItem) Next(ctx context.Context, forceClose <-chan struct{}) (*bufferItem, error) {
@@ -0,0 +1,590 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package stream
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	metrics "github.com/hashicorp/go-metrics/compat"
+	"github.com/hashicorp/go-msgpack/v2/codec"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// eventLogSegmentExt is the file extension of event log segments. Segments
+	// are named after the index of the first events they contain.
+	eventLogSegmentExt = ".log"
+
+	// defaultEventLogSegmentSize is the size at which the active segment is
+	// closed and a new one is started.
+	defaultEventLogSegmentSize = 64 * 1024 * 1024
+
+	// minEventLogSegmentSize is the smallest default segment size, so that
+	// small retention sizes don't create a file per event.
+	minEventLogSegmentSize = 1024 * 1024
+
+	// eventLogPruneInterval is the minimum time between applying the
+	// retention policy when events are appended.
+	eventLogPruneInterval = time.Minute
+)
+
+// errEventLogClosed is returned when appending to an EventLog that has been
+// closed.
+var errEventLogClosed = errors.New("event log closed")
+
+// EventLogConfig configures an EventLog.
+type EventLogConfig struct {
+	// Dir is the directory in which segment files are stored.
+	Dir string
+
+	// MaxAge is how long events are retained. Segments whose newest event is
+	// older than MaxAge are deleted. Zero disables age based retention.
+	MaxAge time.Duration
+
+	// MaxSize is the maximum size in bytes of all segments. The oldest
+	// segments are deleted until the log is below MaxSize. Zero disables
+	// size based retention.
+	MaxSize int64
+
+	// SegmentSize is the size in bytes at which a new segment is started. It
+	// defaults to 64MiB, or a quarter of MaxSize if that is smaller but no
+	// less than 1MiB.
+	SegmentSize int64
+}
+
+// EventLog is a durable, append-only log of the events published to the
+// EventBroker, stored as a series of newline delimited JSON segment files.
+// It allows subscribers to resume from indexes that have been dropped from
+// the in-memory eventBuffer, including across server restarts and leader
+// elections. Events are retained according to the configured age and size
+// limits, and are removed a whole segment at a time.
+//
+// The EventLog outlives the state store and its EventBroker, so a single log
+// is shared by every broker created as snapshots are restored.
+type EventLog struct {
+	logger hclog.Logger
+	config EventLogConfig
+
+	// mu protects all fields below. Readers hold it while reading from a
+	// segment so they never observe a partially written record.
+	mu        sync.RWMutex
+	segments  []*eventLogSegment
+	active    *os.File
+	lastPrune time.Time
+	closed    bool
+}
+
+// eventLogSegment describes a single segment file.
+type eventLogSegment struct {
+	path       string
+	firstIndex uint64
+	lastIndex  uint64
+	size       int64
+	modTime    time.Time
+}
+
+// eventLogRecord is the representation of a set of events stored on disk.
+type eventLogRecord struct {
+	Index  uint64
+	Events []eventLogEvent
+}
+
+// eventLogEvent is the representation of a single event stored on disk. The
+// payload is stored as it is encoded for event stream subscribers, and is
+// sent to them unchanged when replayed.
+type eventLogEvent struct {
+	Topic      structs.Topic
+	Type       string
+	Key        string
+	Namespace  string
+	FilterKeys []string
+	Index      uint64
+	Payload    json.RawMessage
+}
+
+// NewEventLog opens the event log stored in config.Dir, creating the
+// directory if needed, and applies the retention policy.
+func NewEventLog(logger hclog.Logger, config EventLogConfig) (*EventLog, error) {
+	if config.Dir == "" {
+		return nil, fmt.Errorf("event log directory must be set")
+	}
+	if config.MaxAge < 0 {
+		return nil, fmt.Errorf("event log max age must not be negative")
+	}
+	if config.MaxSize < 0 {
+		return nil, fmt.Errorf("event log max size must not be negative")
+	}
+
+	if config.SegmentSize == 0 {
+		config.SegmentSize = defaultEventLogSegmentSize
+		if config.MaxSize > 0 && config.MaxSize/4 < config.SegmentSize {
+			config.SegmentSize = max(config.MaxSize/4, minEventLogSegmentSize)
+		}
+	}
+
+	if err := os.MkdirAll(config.Dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create event log directory: %w", err)
+	}
+
+	l := &EventLog{
+		logger: logger.Named("event_log"),
+		config: config,
+	}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	l.prune(time.Now())
+	l.mu.Unlock()
+
+	return l, nil
+}
+
+// open loads the existing segments and truncates any partially written
+// record from the newest segment.
+func (l *EventLog) open() error {
+	entries, err := os.ReadDir(l.config.Dir)
+	if err != nil {
+		return fmt.Errorf("failed to read event log directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, eventLogSegmentExt) {
+			continue
+		}
+		first, err := strconv.ParseUint(strings.TrimSuffix(name, eventLogSegmentExt), 10, 64)
+		if err != nil {
+			l.logger.Warn("ignoring unexpected file in event log directory", "file", name)
+			continue
+		}
+		l.segments = append(l.segments, &eventLogSegment{
+			path:       filepath.Join(l.config.Dir, name),
+			firstIndex: first,
+		})
+	}
+
+	sort.Slice(l.segments, func(i, j int) bool {
+		return l.segments[i].firstIndex < l.segments[j].firstIndex
+	})
+
+	for i, seg := range l.segments {
+		if err := l.scanSegment(seg, i == len(l.segments)-1); err != nil {
+			return err
+		}
+	}
+
+	// Drop trailing segments that contain no complete records.
+	for len(l.segments) > 0 && l.segments[len(l.segments)-1].lastIndex == 0 {
+		seg := l.segments[len(l.segments)-1]
+		if err := os.Remove(seg.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove empty event log segment: %w", err)
+		}
+		l.segments = l.segments[:len(l.segments)-1]
+	}
+
+	return nil
+}
+
+// scanSegment reads the segment to determine the index of its last record. If
+// truncate is set, a trailing partial record left by a crash is removed.
+func (l *EventLog) scanSegment(seg *eventLogSegment, truncate bool) error {
+	f, err := os.Open(seg.path)
+	if err != nil {
+		return fmt.Errorf("failed to open event log segment: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat event log segment: %w", err)
+	}
+	seg.modTime = info.ModTime()
+
+	var valid int64
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("failed to read event log segment: %w", err)
+		}
+
+		var record eventLogRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			break
+		}
+		seg.lastIndex = record.Index
+		valid += int64(len(line))
+	}
+	seg.size = valid
+
+	if valid < info.Size() {
+		if !truncate {
+			l.logger.Warn("event log segment is corrupt, ignoring trailing data", "file", seg.path)
+			return nil
+		}
+		l.logger.Warn("truncating partially written event log record", "file", seg.path)
+		if err := os.Truncate(seg.path, valid); err != nil {
+			return fmt.Errorf("failed to truncate event log segment: %w", err)
+		}
+	}
+	return nil
+}
+
+// FirstIndex returns the index of the oldest events in the log, or 0 if the
+// log is empty.
+func (l *EventLog) FirstIndex() uint64 {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if len(l.segments) == 0 {
+		return 0
+	}
+	return l.segments[0].firstIndex
+}
+
+// LastIndex returns the index of the newest events in the log, or 0 if the
+// log is empty.
+func (l *EventLog) LastIndex() uint64 {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.lastIndex()
+}
+
+func (l *EventLog) lastIndex() uint64 {
+	if len(l.segments) == 0 {
+		return 0
+	}
+	return l.segments[len(l.segments)-1].lastIndex
+}
+
+// Size returns the total size in bytes of all segments.
+func (l *EventLog) Size() int64 {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var size int64
+	for _, seg := range l.segments {
+		size += seg.size
+	}
+	return size
+}
+
+// Append writes the events to the log. Events with an index that is not
+// greater than the last index in the log are ignored, as they are replayed
+// from the Raft log when a server restarts.
+func (l *EventLog) Append(events *structs.Events) error {
+	if events == nil || len(events.Events) == 0 {
+		return nil
+	}
+
+	record, err := encodeEventLogRecord(events)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return errEventLogClosed
+	}
+	if events.Index <= l.lastIndex() {
+		return nil
+	}
+
+	now := time.Now()
+	seg, err := l.activeSegment(events.Index, now)
+	if err != nil {
+		return err
+	}
+
+	n, err := l.active.Write(record)
+	if err != nil {
+		// Remove any partial write so the segment stays readable.
+		_ = l.active.Truncate(seg.size)
+		return fmt.Errorf("failed to write event log record: %w", err)
+	}
+
+	seg.size += int64(n)
+	seg.lastIndex = events.Index
+	seg.modTime = now
+
+	if now.Sub(l.lastPrune) > eventLogPruneInterval {
+		l.prune(now)
+	}
+
+	metrics.SetGauge([]string{"nomad", "event_broker", "event_log", "last_index"}, float32(events.Index))
+	return nil
+}
+
+// activeSegment returns the segment to append to, starting a new one if there
+// is none or the current one is full. l.mu must be held.
+func (l *EventLog) activeSegment(index uint64, now time.Time) (*eventLogSegment, error) {
+	if len(l.segments) > 0 {
+		seg := l.segments[len(l.segments)-1]
+		if seg.size < l.config.SegmentSize {
+			if l.active == nil {
+				f, err := os.OpenFile(seg.path, os.O_WRONLY|os.O_APPEND, 0o600)
+				if err != nil {
+					return nil, fmt.Errorf("failed to open event log segment: %w", err)
+				}
+				l.active = f
+			}
+			return seg, nil
+		}
+	}
+
+	if l.active != nil {
+		if err := l.active.Close(); err != nil {
+			l.logger.Warn("failed to close event log segment", "error", err)
+		}
+		l.active = nil
+	}
+
+	path := filepath.Join(l.config.Dir, fmt.Sprintf("%020d%s", index, eventLogSegmentExt))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create event log segment: %w", err)
+	}
+	l.active = f
+
+	seg := &eventLogSegment{
+		path:       path,
+		firstIndex: index,
+		modTime:    now,
+	}
+	l.segments = append(l.segments, seg)
+
+	// A new segment may allow an older one to be removed.
+	l.prune(now)
+	return seg, nil
+}
+
+// prune deletes the oldest segments that are past the retention policy. The
+// newest segment is never deleted. l.mu must be held.
+func (l *EventLog) prune(now time.Time) {
+	l.lastPrune = now
+
+	var size int64
+	for _, seg := range l.segments {
+		size += seg.size
+	}
+
+	for len(l.segments) > 1 {
+		seg := l.segments[0]
+		expired := l.config.MaxAge > 0 && now.Sub(seg.modTime) > l.config.MaxAge
+		oversized := l.config.MaxSize > 0 && size > l.config.MaxSize
+		if !expired && !oversized {
+			break
+		}
+
+		// Readers with the segment open can continue to read it after it has
+		// been removed.
+		if err := os.Remove(seg.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			l.logger.Warn("failed to remove event log segment", "file", seg.path, "error", err)
+			break
+		}
+		l.logger.Debug("removed event log segment", "file", seg.path,
+			"first_index", seg.firstIndex, "last_index", seg.lastIndex)
+
+		size -= seg.size
+		l.segments = l.segments[1:]
+	}
+}
+
+// Close closes the log. Further appends will fail.
+func (l *EventLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+
+	if l.active != nil {
+		err := l.active.Close()
+		l.active = nil
+		return err
+	}
+	return nil
+}
+
+// segmentFor returns the oldest segment containing events with an index
+// greater than index. l.mu must be held.
+func (l *EventLog) segmentFor(index uint64) *eventLogSegment {
+	for _, seg := range l.segments {
+		if seg.lastIndex > index {
+			return seg
+		}
+	}
+	return nil
+}
+
+// encodeEventLogRecord encodes the events as a single newline terminated
+// record. Payloads are encoded the same way they are for event stream
+// subscribers.
+func encodeEventLogRecord(events *structs.Events) ([]byte, error) {
+	record := eventLogRecord{
+		Index:  events.Index,
+		Events: make([]eventLogEvent, 0, len(events.Events)),
+	}
+
+	for _, event := range events.Events {
+		var payload []byte
+		switch p := event.Payload.(type) {
+		case json.RawMessage:
+			payload = p
+		default:
+			var buf bytes.Buffer
+			if err := codec.NewEncoder(&buf, structs.JsonHandleWithExtensions).Encode(p); err != nil {
+				return nil, fmt.Errorf("failed to encode event payload: %w", err)
+			}
+			payload = buf.Bytes()
+		}
+
+		record.Events = append(record.Events, eventLogEvent{
+			Topic:      event.Topic,
+			Type:       event.Type,
+			Key:        event.Key,
+			Namespace:  event.Namespace,
+			FilterKeys: event.FilterKeys,
+			Index:      event.Index,
+			Payload:    payload,
+		})
+	}
+
+	b, err := json.Marshal(&record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event log record: %w", err)
+	}
+	return append(b, '\n'), nil
+}
+
+// decode returns the events of the record. Payloads are returned as
+// json.RawMessage.
+func (r *eventLogRecord) decode() *structs.Events {
+	events := &structs.Events{
+		Index:  r.Index,
+		Events: make([]structs.Event, 0, len(r.Events)),
+	}
+	for _, event := range r.Events {
+		events.Events = append(events.Events, structs.Event{
+			Topic:      event.Topic,
+			Type:       event.Type,
+			Key:        event.Key,
+			Namespace:  event.Namespace,
+			FilterKeys: event.FilterKeys,
+			Index:      event.Index,
+			Payload:    event.Payload,
+		})
+	}
+	return events
+}
+
+// eventLogReader reads the events stored in an EventLog in index order,
+// moving across segments as they are exhausted.
+type eventLogReader struct {
+	log *EventLog
+
+	// lastIndex is the index of the last events returned. Only events with
+	// a greater index are returned.
+	lastIndex uint64
+
+	file   *os.File
+	reader *bufio.Reader
+}
+
+// newEventLogReader returns a reader of the events with an index greater
+// than or equal to index.
+func (l *EventLog) newEventLogReader(index uint64) *eventLogReader {
+	r := &eventLogReader{log: l}
+	if index > 0 {
+		r.lastIndex = index - 1
+	}
+	return r
+}
+
+// Next returns the next events in the log, or nil if the reader has reached
+// the end of the log.
+func (r *eventLogReader) Next() (*structs.Events, error) {
+	r.log.mu.RLock()
+	defer r.log.mu.RUnlock()
+
+	for {
+		if r.file == nil {
+			seg := r.log.segmentFor(r.lastIndex)
+			if seg == nil {
+				return nil, nil
+			}
+
+			f, err := os.Open(seg.path)
+			if errors.Is(err, os.ErrNotExist) {
+				// The segment was removed by the retention policy but the
+				// segments can't change while the lock is held, so skip
+				// past its events to the next segment.
+				r.lastIndex = seg.lastIndex
+				continue
+			} else if err != nil {
+				return nil, fmt.Errorf("failed to open event log segment: %w", err)
+			}
+			r.file = f
+			r.reader = bufio.NewReader(f)
+		}
+
+		line, err := r.reader.ReadBytes('\n')
+		if err == io.EOF {
+			// Move on to the next segment, if there is one.
+			r.closeFile()
+			continue
+		} else if err != nil {
+			r.closeFile()
+			return nil, fmt.Errorf("failed to read event log segment: %w", err)
+		}
+
+		var record eventLogRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			// Trailing data that could not be decoded when the log was
+			// opened is not part of the segment, so skip to the next one.
+			r.closeFile()
+			continue
+		}
+		if record.Index <= r.lastIndex {
+			continue
+		}
+
+		r.lastIndex = record.Index
+		return record.decode(), nil
+	}
+}
+
+func (r *eventLogReader) closeFile() {
+	if r.file != nil {
+		_ = r.file.Close()
+	}
+	r.file = nil
+	r.reader = nil
+}
+
+// Close releases the resources held by the reader.
+func (r *eventLogReader) Close() {
+	r.closeFile()
+}
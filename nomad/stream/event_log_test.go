@@ -0,0 +1,221 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+func testEventLog(t *testing.T, config EventLogConfig) *EventLog {
+	t.Helper()
+
+	if config.Dir == "" {
+		config.Dir = t.TempDir()
+	}
+	l, err := NewEventLog(testlog.HCLogger(t), config)
+	must.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+	return l
+}
+
+func testEvents(index uint64) *structs.Events {
+	return &structs.Events{
+		Index: index,
+		Events: []structs.Event{{
+			Topic:     "Test",
+			Type:      "Updated",
+			Key:       "key",
+			Namespace: "default",
+			Index:     index,
+			Payload:   map[string]any{"Index": index},
+		}},
+	}
+}
+
+// readEventLog returns the indexes of all events in the log after index.
+func readEventLog(t *testing.T, l *EventLog, index uint64) []uint64 {
+	t.Helper()
+
+	r := l.newEventLogReader(index)
+	defer r.Close()
+
+	var indexes []uint64
+	for {
+		events, err := r.Next()
+		must.NoError(t, err)
+		if events == nil {
+			return indexes
+		}
+		indexes = append(indexes, events.Index)
+	}
+}
+
+func TestEventLog_AppendRead(t *testing.T) {
+	ci.Parallel(t)
+
+	l := testEventLog(t, EventLogConfig{SegmentSize: 512})
+
+	for i := uint64(1); i <= 20; i++ {
+		must.NoError(t, l.Append(testEvents(i)))
+	}
+
+	// events at or below the last index are ignored
+	must.NoError(t, l.Append(testEvents(5)))
+
+	must.Greater(t, 1, len(l.segments))
+	must.Eq(t, 1, l.FirstIndex())
+	must.Eq(t, 20, l.LastIndex())
+
+	must.Len(t, 20, readEventLog(t, l, 0))
+	must.Eq(t, []uint64{15, 16, 17, 18, 19, 20}, readEventLog(t, l, 15))
+	must.Nil(t, readEventLog(t, l, 21))
+
+	// payloads are returned as they were encoded for subscribers
+	r := l.newEventLogReader(3)
+	defer r.Close()
+	events, err := r.Next()
+	must.NoError(t, err)
+	must.Eq(t, 3, events.Index)
+	must.Eq(t, "key", events.Events[0].Key)
+	must.Eq(t, json.RawMessage(`{"Index":3}`), events.Events[0].Payload.(json.RawMessage))
+}
+
+func TestEventLog_Reopen(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	l, err := NewEventLog(testlog.HCLogger(t), EventLogConfig{Dir: dir})
+	must.NoError(t, err)
+	for i := uint64(1); i <= 5; i++ {
+		must.NoError(t, l.Append(testEvents(i)))
+	}
+	must.NoError(t, l.Close())
+	must.ErrorIs(t, l.Append(testEvents(6)), errEventLogClosed)
+
+	// simulate a crash while writing a record
+	path := filepath.Join(dir, "00000000000000000001.log")
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	must.NoError(t, err)
+	_, err = f.Write([]byte(`{"Index":6,"Eve`))
+	must.NoError(t, err)
+	must.NoError(t, f.Close())
+
+	l = testEventLog(t, EventLogConfig{Dir: dir})
+	must.Eq(t, 5, l.LastIndex())
+	must.Eq(t, []uint64{1, 2, 3, 4, 5}, readEventLog(t, l, 0))
+
+	must.NoError(t, l.Append(testEvents(6)))
+	must.Eq(t, []uint64{5, 6}, readEventLog(t, l, 5))
+}
+
+func TestEventLog_Retention(t *testing.T) {
+	ci.Parallel(t)
+
+	t.Run("size", func(t *testing.T) {
+		l := testEventLog(t, EventLogConfig{SegmentSize: 512, MaxSize: 2048})
+
+		for i := uint64(1); i <= 100; i++ {
+			must.NoError(t, l.Append(testEvents(i)))
+		}
+
+		must.LessEq(t, 2048+512, l.Size())
+		must.Greater(t, 1, l.FirstIndex())
+		must.Eq(t, 100, l.LastIndex())
+
+		indexes := readEventLog(t, l, 0)
+		must.Eq(t, l.FirstIndex(), indexes[0])
+		must.Eq(t, 100, indexes[len(indexes)-1])
+	})
+
+	t.Run("age", func(t *testing.T) {
+		dir := t.TempDir()
+		l := testEventLog(t, EventLogConfig{Dir: dir, SegmentSize: 512})
+		for i := uint64(1); i <= 20; i++ {
+			must.NoError(t, l.Append(testEvents(i)))
+		}
+		must.NoError(t, l.Close())
+
+		// age all but the newest segment
+		old := time.Now().Add(-2 * time.Hour)
+		entries, err := os.ReadDir(dir)
+		must.NoError(t, err)
+		for _, entry := range entries[:len(entries)-1] {
+			must.NoError(t, os.Chtimes(filepath.Join(dir, entry.Name()), old, old))
+		}
+
+		l = testEventLog(t, EventLogConfig{Dir: dir, SegmentSize: 512, MaxAge: time.Hour})
+		must.Len(t, 1, l.segments)
+		must.Eq(t, 20, l.LastIndex())
+	})
+}
+
+func TestEventLog_RemovedSegment(t *testing.T) {
+	ci.Parallel(t)
+
+	l := testEventLog(t, EventLogConfig{SegmentSize: 512})
+	for i := uint64(1); i <= 20; i++ {
+		must.NoError(t, l.Append(testEvents(i)))
+	}
+
+	// readers skip the events of a segment that was removed from disk
+	first := l.segments[0]
+	must.NoError(t, os.Remove(first.path))
+
+	indexes := readEventLog(t, l, 0)
+	must.Eq(t, first.lastIndex+1, indexes[0])
+	must.Eq(t, 20, indexes[len(indexes)-1])
+}
+
+func TestEventBroker_Subscribe_EventLog(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	l := testEventLog(t, EventLogConfig{SegmentSize: 512})
+	publisher, err := NewEventBroker(ctx, EventBrokerCfg{EventBufferSize: 5, EventLog: l})
+	must.NoError(t, err)
+
+	for i := uint64(1); i <= 20; i++ {
+		publisher.Publish(testEvents(i))
+	}
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return publisher.Len() == 5 && l.LastIndex() == 20 }),
+		wait.Timeout(2*time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	// index 3 has been dropped from the buffer but is in the log
+	sub, err := publisher.Subscribe(&SubscribeRequest{
+		Index:               3,
+		StartExactlyAtIndex: true,
+		Namespaces:          []string{"default"},
+		Topics:              map[structs.Topic][]string{"*": {"*"}},
+	})
+	must.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	for i := uint64(3); i <= 20; i++ {
+		events, err := sub.Next(ctx)
+		must.NoError(t, err)
+		must.Eq(t, i, events.Index)
+	}
+
+	// new events are received from the buffer
+	publisher.Publish(testEvents(21))
+	events, err := sub.Next(ctx)
+	must.NoError(t, err)
+	must.Eq(t, 21, events.Index)
+}
@@ -41,6 +41,19 @@ type Subscription struct {
 	// EventBroker to cancel Next().
 	forceClosed chan struct{}
 
+	// replay reads events from the event log that are no longer in the
+	// buffer. It is nil if the subscription started within the buffer, and
+	// is cleared once the log has been read to its end.
+	replay *eventLogReader
+
+	// resume is called with the index of the last event read from the event
+	// log to find the buffer item the subscription continues from.
+	resume func(index uint64) *bufferItem
+
+	// replayedIndex is the index of the last events read from the event
+	// log. Events in the buffer up to this index have already been returned.
+	replayedIndex uint64
+
 	// unsub is a function set by EventBroker that is called to free resources
 	// when the subscription is no longer needed.
 	// It must be safe to call the function from multiple goroutines and the function
@@ -65,6 +78,11 @@ type SubscribeRequest struct {
 	// associated with the SubscribeRequest has not expired and
 	// has the correct permissions
 	Authenticate func() error
+
+	// Allow is an optional callback used to filter out events the
+	// subscription is not permitted to read, for topics whose permissions
+	// depend on the event itself rather than only its namespace.
+	Allow func(structs.Event) bool
 }
 
 func newSubscription(req *SubscribeRequest, item *bufferItem, unsub func()) *Subscription {
@@ -81,6 +99,13 @@ func (s *Subscription) Next(ctx context.Context) (structs.Events, error) {
 		return structs.Events{}, ErrSubscriptionClosed
 	}
 
+	if s.replay != nil {
+		events, err := s.nextReplay(ctx)
+		if err != nil || len(events.Events) > 0 {
+			return events, err
+		}
+	}
+
 	for {
 		next, err := s.currentItem.Next(ctx, s.forceClosed)
 		switch {
@@ -91,6 +116,11 @@ func (s *Subscription) Next(ctx context.Context) (structs.Events, error) {
 		}
 		s.currentItem = next
 
+		// skip events that were already read from the event log
+		if next.Events.Index <= s.replayedIndex {
+			continue
+		}
+
 		events := filter(s.req, next.Events.Events)
 		if len(events) == 0 {
 			continue
@@ -104,6 +134,13 @@ func (s *Subscription) NextNoBlock() ([]structs.Event, error) {
 		return nil, ErrSubscriptionClosed
 	}
 
+	if s.replay != nil {
+		events, err := s.nextReplay(context.Background())
+		if err != nil || len(events.Events) > 0 {
+			return events.Events, err
+		}
+	}
+
 	for {
 		next := s.currentItem.NextNoBlock()
 		if next == nil {
@@ -111,6 +148,10 @@ func (s *Subscription) NextNoBlock() ([]structs.Event, error) {
 		}
 		s.currentItem = next
 
+		if next.Events.Index <= s.replayedIndex {
+			continue
+		}
+
 		events := filter(s.req, next.Events.Events)
 		if len(events) == 0 {
 			continue
@@ -119,6 +160,52 @@ func (s *Subscription) NextNoBlock() ([]structs.Event, error) {
 	}
 }
 
+// nextReplay returns the next events from the event log matching the
+// subscription. Once the end of the log is reached the subscription
+// continues from the buffer and empty events are returned. If the replay is
+// interrupted the subscription is closed, as it can't continue from the
+// buffer without missing events.
+func (s *Subscription) nextReplay(ctx context.Context) (structs.Events, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			s.stopReplay()
+			return structs.Events{}, err
+		}
+		if atomic.LoadUint32(&s.state) == subscriptionStateClosed {
+			s.stopReplay()
+			return structs.Events{}, ErrSubscriptionClosed
+		}
+
+		events, err := s.replay.Next()
+		if err != nil {
+			s.stopReplay()
+			return structs.Events{}, err
+		}
+
+		if events == nil {
+			s.replayedIndex = s.replay.lastIndex
+			s.currentItem = s.resume(s.replayedIndex)
+			s.replay.Close()
+			s.replay = nil
+			return structs.Events{}, nil
+		}
+
+		filtered := filter(s.req, events.Events)
+		if len(filtered) == 0 {
+			continue
+		}
+		return structs.Events{Index: events.Index, Events: filtered}, nil
+	}
+}
+
+// stopReplay closes the event log reader of an interrupted replay and closes
+// the subscription.
+func (s *Subscription) stopReplay() {
+	s.replay.Close()
+	s.replay = nil
+	s.forceClose()
+}
+
 func (s *Subscription) Unsubscribe() {
 	s.unsub()
 }
@@ -138,6 +225,10 @@ func filter(req *SubscribeRequest, events []structs.Event) []structs.Event {
 			continue
 		}
 
+		if req.Allow != nil && !req.Allow(event) {
+			continue
+		}
+
 		// *[*] always matches
 		if len(allTopicKeys) == 1 && allTopicKeys[0] == string(structs.TopicAll) {
 			result = append(result, event)
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import "maps"
+
+// LogSinkConfig configures a sink that the client ships task log lines to, in
+// addition to writing them to the allocation's log files.
+type LogSinkConfig struct {
+	// Name is the unique name of the sink
+	Name string `hcl:",key"`
+
+	// Type is the type of the sink: syslog, otlp or unix
+	Type string `hcl:"type"`
+
+	// Address is the address of the sink. Syslog sinks take a URL with a tcp,
+	// udp, unix or unixgram scheme, otlp sinks the http or https URL of the
+	// logs endpoint and unix sinks the path to the socket.
+	Address string `hcl:"address"`
+
+	// Facility is the syslog facility of the messages of syslog sinks.
+	// Defaults to "user".
+	Facility string `hcl:"facility"`
+
+	// Headers are added to the requests made to otlp sinks
+	Headers map[string]string `hcl:"headers"`
+
+	// BufferSize is the number of log lines buffered per task for the sink
+	// before lines are dropped. Defaults to 1024.
+	BufferSize int `hcl:"buffer_size"`
+}
+
+func (c *LogSinkConfig) Copy() *LogSinkConfig {
+	if c == nil {
+		return nil
+	}
+
+	nc := new(LogSinkConfig)
+	*nc = *c
+	nc.Headers = maps.Clone(c.Headers)
+	return nc
+}
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import "github.com/hashicorp/nomad/helper/pointer"
+
+// ServiceDNSConfig configures the client DNS server which answers queries for
+// services registered with the Nomad service discovery provider.
+type ServiceDNSConfig struct {
+	// Enabled starts the DNS server on the client.
+	Enabled *bool `hcl:"enabled"`
+
+	// Address is the IP address the DNS server binds to for both UDP and TCP.
+	Address *string `hcl:"address"`
+
+	// Port is the port the DNS server binds to for both UDP and TCP.
+	Port *int `hcl:"port"`
+
+	// Domain is the top level domain the DNS server is authoritative for.
+	Domain *string `hcl:"domain"`
+
+	// TTL is the time-to-live of answered records, as a duration string.
+	TTL *string `hcl:"ttl"`
+
+	// Token is the ACL token used to read service registrations and check
+	// results. It must be able to read jobs in every namespace that is
+	// queried.
+	Token *string `hcl:"token"`
+}
+
+func (s *ServiceDNSConfig) Copy() *ServiceDNSConfig {
+	if s == nil {
+		return nil
+	}
+
+	return &ServiceDNSConfig{
+		Enabled: pointer.Copy(s.Enabled),
+		Address: pointer.Copy(s.Address),
+		Port:    pointer.Copy(s.Port),
+		Domain:  pointer.Copy(s.Domain),
+		TTL:     pointer.Copy(s.TTL),
+		Token:   pointer.Copy(s.Token),
+	}
+}
+
+func (s *ServiceDNSConfig) Merge(o *ServiceDNSConfig) *ServiceDNSConfig {
+	switch {
+	case s == nil:
+		return o.Copy()
+	case o == nil:
+		return s.Copy()
+	default:
+		ns := s.Copy()
+		if o.Enabled != nil {
+			ns.Enabled = pointer.Copy(o.Enabled)
+		}
+		if o.Address != nil {
+			ns.Address = pointer.Copy(o.Address)
+		}
+		if o.Port != nil {
+			ns.Port = pointer.Copy(o.Port)
+		}
+		if o.Domain != nil {
+			ns.Domain = pointer.Copy(o.Domain)
+		}
+		if o.TTL != nil {
+			ns.TTL = pointer.Copy(o.TTL)
+		}
+		if o.Token != nil {
+			ns.Token = pointer.Copy(o.Token)
+		}
+		return ns
+	}
+}
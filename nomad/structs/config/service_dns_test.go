@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/shoenig/test/must"
+)
+
+func TestServiceDNSConfig_Copy(t *testing.T) {
+	ci.Parallel(t)
+
+	var nilConfig *ServiceDNSConfig
+	must.Nil(t, nilConfig.Copy())
+
+	original := &ServiceDNSConfig{
+		Enabled: pointer.Of(true),
+		Address: pointer.Of("127.0.0.1"),
+		Port:    pointer.Of(8653),
+		Domain:  pointer.Of("nomad"),
+		TTL:     pointer.Of("5s"),
+		Token:   pointer.Of("secret"),
+	}
+	copied := original.Copy()
+	must.Eq(t, original, copied)
+
+	*copied.Port = 53
+	must.Eq(t, 8653, *original.Port)
+}
+
+func TestServiceDNSConfig_Merge(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name     string
+		a        *ServiceDNSConfig
+		b        *ServiceDNSConfig
+		expected *ServiceDNSConfig
+	}{
+		{
+			name:     "both nil",
+			expected: nil,
+		},
+		{
+			name:     "nil a",
+			b:        &ServiceDNSConfig{Enabled: pointer.Of(true)},
+			expected: &ServiceDNSConfig{Enabled: pointer.Of(true)},
+		},
+		{
+			name:     "nil b",
+			a:        &ServiceDNSConfig{Port: pointer.Of(8653)},
+			expected: &ServiceDNSConfig{Port: pointer.Of(8653)},
+		},
+		{
+			name: "override",
+			a: &ServiceDNSConfig{
+				Enabled: pointer.Of(true),
+				Address: pointer.Of("127.0.0.1"),
+				Port:    pointer.Of(8653),
+			},
+			b: &ServiceDNSConfig{
+				Enabled: pointer.Of(false),
+				Domain:  pointer.Of("example"),
+				TTL:     pointer.Of("10s"),
+			},
+			expected: &ServiceDNSConfig{
+				Enabled: pointer.Of(false),
+				Address: pointer.Of("127.0.0.1"),
+				Port:    pointer.Of(8653),
+				Domain:  pointer.Of("example"),
+				TTL:     pointer.Of("10s"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			must.Eq(t, tc.expected, tc.a.Merge(tc.b))
+		})
+	}
+}
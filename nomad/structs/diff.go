@@ -129,6 +129,17 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 		diff.Objects = append(diff.Objects, affinitiesDiff...)
 	}
 
+	// Dependencies diff
+	depsDiff := primitiveObjectSetDiff(
+		interfaceSlice(j.DependsOn),
+		interfaceSlice(other.DependsOn),
+		nil,
+		"DependsOn",
+		contextual)
+	if depsDiff != nil {
+		diff.Objects = append(diff.Objects, depsDiff...)
+	}
+
 	// Task groups diff
 	tgs, err := taskGroupDiffs(j.TaskGroups, other.TaskGroups, contextual)
 	if err != nil {
@@ -311,8 +322,7 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 	}
 
 	// Update diff
-	// COMPAT: Remove "Stagger" in 0.7.0.
-	if uDiff := primitiveObjectDiff(tg.Update, other.Update, []string{"Stagger"}, "Update", contextual); uDiff != nil {
+	if uDiff := updateStrategyDiff(tg.Update, other.Update, contextual); uDiff != nil {
 		diff.Objects = append(diff.Objects, uDiff)
 	}
 
@@ -321,6 +331,11 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 		diff.Objects = append(diff.Objects, disconnectDiff)
 	}
 
+	// Gang diff
+	if gDiff := gangDiff(tg.Gang, other.Gang, contextual); gDiff != nil {
+		diff.Objects = append(diff.Objects, gDiff)
+	}
+
 	// Network Resources diff
 	if nDiffs := networkResourceDiffs(tg.Networks, other.Networks, contextual); nDiffs != nil {
 		diff.Objects = append(diff.Objects, nDiffs...)
@@ -2197,6 +2212,15 @@ func parameterizedJobDiff(old, new *ParameterizedJobConfig, contextual bool) *Ob
 		diff.Objects = append(diff.Objects, requiredDiff)
 	}
 
+	// Dispatch limit diffs
+	if queueDiff := primitiveObjectDiff(old.Queue, new.Queue, nil, "Queue", contextual); queueDiff != nil {
+		diff.Objects = append(diff.Objects, queueDiff)
+	}
+
+	if rateDiff := primitiveObjectDiff(old.RateLimit, new.RateLimit, nil, "RateLimit", contextual); rateDiff != nil {
+		diff.Objects = append(diff.Objects, rateDiff)
+	}
+
 	return diff
 }
 
@@ -2590,6 +2614,11 @@ func (r *Resources) Diff(other *Resources, contextual bool) *ObjectDiff {
 		diff.Objects = append(diff.Objects, nDiff)
 	}
 
+	// IO limits diff
+	if ioDiffs := ioLimitsDiffs(r.IOMax, other.IOMax, contextual); ioDiffs != nil {
+		diff.Objects = append(diff.Objects, ioDiffs...)
+	}
+
 	return diff
 }
 
@@ -2616,6 +2645,13 @@ func (n *NetworkResource) Diff(other *NetworkResource, contextual bool) *ObjectD
 		newPrimitiveFlat = flatmap.Flatten(other, filter, true)
 	}
 
+	// The egress limit is only shown when set
+	for _, flat := range []map[string]string{oldPrimitiveFlat, newPrimitiveFlat} {
+		if flat["EgressMBits"] == "0" {
+			delete(flat, "EgressMBits")
+		}
+	}
+
 	// Diff the primitive fields.
 	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
 
@@ -2719,6 +2755,106 @@ func disconectStrategyDiffs(old, new *DisconnectStrategy, contextual bool) *Obje
 	return diff
 }
 
+// updateStrategyDiff diffs the primitive fields of an UpdateStrategy and its
+// canary analysis.
+func updateStrategyDiff(old, new *UpdateStrategy, contextual bool) *ObjectDiff {
+	// COMPAT: Remove "Stagger" in 0.7.0.
+	diff := primitiveObjectDiff(old, new, []string{"Stagger"}, "Update", contextual)
+
+	var oldAnalysis, newAnalysis *CanaryAnalysis
+	var oldSteps, newSteps []*RolloutStep
+	if old != nil {
+		oldAnalysis = old.Analysis
+		oldSteps = old.Steps
+	}
+	if new != nil {
+		newAnalysis = new.Analysis
+		newSteps = new.Steps
+	}
+
+	var objects []*ObjectDiff
+	if aDiff := canaryAnalysisDiff(oldAnalysis, newAnalysis, contextual); aDiff != nil {
+		objects = append(objects, aDiff)
+	}
+	objects = append(objects, rolloutStepsDiff(oldSteps, newSteps, contextual)...)
+	if len(objects) == 0 {
+		return diff
+	}
+
+	if diff == nil {
+		diff = &ObjectDiff{Type: DiffTypeEdited, Name: "Update"}
+	}
+	diff.Objects = append(diff.Objects, objects...)
+	return diff
+}
+
+// rolloutStepsDiff diffs the rollout steps of an update strategy by their
+// position.
+func rolloutStepsDiff(old, new []*RolloutStep, contextual bool) []*ObjectDiff {
+	var diffs []*ObjectDiff
+	for i := 0; i < max(len(old), len(new)); i++ {
+		var oldStep, newStep *RolloutStep
+		if i < len(old) {
+			oldStep = old[i]
+		}
+		if i < len(new) {
+			newStep = new[i]
+		}
+		if diff := primitiveObjectDiff(oldStep, newStep, nil, "Step", contextual); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs
+}
+
+func canaryAnalysisDiff(old, new *CanaryAnalysis, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Analysis"}
+	var oldAnalysisFlat, newAnalysisFlat map[string]string
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		diff.Type = DiffTypeAdded
+		newAnalysisFlat = flatmap.Flatten(new, nil, false)
+	} else if new == nil {
+		diff.Type = DiffTypeDeleted
+		oldAnalysisFlat = flatmap.Flatten(old, nil, false)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldAnalysisFlat = flatmap.Flatten(old, nil, false)
+		newAnalysisFlat = flatmap.Flatten(new, nil, false)
+	}
+
+	// Diff the fields, including those of the metrics.
+	diff.Fields = fieldDiffs(oldAnalysisFlat, newAnalysisFlat, contextual)
+
+	return diff
+}
+
+func gangDiff(old, new *Gang, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Gang"}
+	var oldGangFlat, newGangFlat map[string]string
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		diff.Type = DiffTypeAdded
+		newGangFlat = flatmap.Flatten(new, nil, false)
+	} else if new == nil {
+		diff.Type = DiffTypeDeleted
+		oldGangFlat = flatmap.Flatten(old, nil, false)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldGangFlat = flatmap.Flatten(old, nil, false)
+		newGangFlat = flatmap.Flatten(new, nil, false)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldGangFlat, newGangFlat, contextual)
+
+	return diff
+}
+
 // networkResourceDiffs diffs a set of NetworkResources. If contextual diff is enabled,
 // non-changed fields will still be returned.
 func networkResourceDiffs(old, new []*NetworkResource, contextual bool) []*ObjectDiff {
@@ -2866,6 +3002,64 @@ func (r *RequestedDevice) Diff(other *RequestedDevice, contextual bool) *ObjectD
 	return diff
 }
 
+// Diff returns a diff of two IO limits. If contextual diff is enabled,
+// non-changed fields will still be returned.
+func (l *IOLimit) Diff(other *IOLimit, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "IOMax"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if reflect.DeepEqual(l, other) {
+		return nil
+	} else if l == nil {
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(other, nil, true)
+	} else if other == nil {
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(l, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(l, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(other, nil, true)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	return diff
+}
+
+// ioLimitsDiffs diffs a set of IO limits keyed by their device. If contextual
+// diff is enabled, non-changed fields will still be returned.
+func ioLimitsDiffs(old, new IOLimits, contextual bool) []*ObjectDiff {
+	makeSet := func(limits IOLimits) map[string]*IOLimit {
+		limitMap := make(map[string]*IOLimit, len(limits))
+		for _, l := range limits {
+			limitMap[l.Device] = l
+		}
+		return limitMap
+	}
+
+	oldSet := makeSet(old)
+	newSet := makeSet(new)
+
+	var diffs []*ObjectDiff
+	for k, oldV := range oldSet {
+		if diff := oldV.Diff(newSet[k], contextual); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+	for k, newV := range newSet {
+		if _, ok := oldSet[k]; !ok {
+			if diff := (*IOLimit)(nil).Diff(newV, contextual); diff != nil {
+				diffs = append(diffs, diff)
+			}
+		}
+	}
+
+	sort.Sort(ObjectDiffs(diffs))
+	return diffs
+}
+
 // requestedDevicesDiffs diffs a set of RequestedDevices. If contextual diff is enabled,
 // non-changed fields will still be returned.
 func requestedDevicesDiffs(old, new []*RequestedDevice, contextual bool) []*ObjectDiff {
@@ -3246,6 +3440,20 @@ func stringSetDiff(old, new []string, name string, contextual bool) *ObjectDiff
 	return diff
 }
 
+// flattenPeriodicHistoryLimits adds the history limits that are set to the
+// flattened periodic config, if any.
+func flattenPeriodicHistoryLimits(flat map[string]string, p *PeriodicConfig) {
+	if flat == nil {
+		return
+	}
+	if p.SuccessfulJobsHistoryLimit != nil {
+		flat["SuccessfulJobsHistoryLimit"] = strconv.Itoa(*p.SuccessfulJobsHistoryLimit)
+	}
+	if p.FailedJobsHistoryLimit != nil {
+		flat["FailedJobsHistoryLimit"] = strconv.Itoa(*p.FailedJobsHistoryLimit)
+	}
+}
+
 func periodicDiff(old, new *PeriodicConfig, contextual bool) *ObjectDiff {
 	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Periodic"}
 	var oldPeriodicFlat, newPeriodicFlat map[string]string
@@ -3266,6 +3474,10 @@ func periodicDiff(old, new *PeriodicConfig, contextual bool) *ObjectDiff {
 		newPeriodicFlat = flatmap.Flatten(new, nil, true)
 	}
 
+	// The history limits are pointers, which aren't flattened
+	flattenPeriodicHistoryLimits(oldPeriodicFlat, old)
+	flattenPeriodicHistoryLimits(newPeriodicFlat, new)
+
 	// Diff the primitive fields.
 	diff.Fields = fieldDiffs(oldPeriodicFlat, newPeriodicFlat, contextual)
 
@@ -3273,6 +3485,11 @@ func periodicDiff(old, new *PeriodicConfig, contextual bool) *ObjectDiff {
 		diff.Objects = append(diff.Objects, setDiff)
 	}
 
+	// Catchup diff
+	if catchupDiff := primitiveObjectDiff(old.Catchup, new.Catchup, nil, "Catchup", contextual); catchupDiff != nil {
+		diff.Objects = append(diff.Objects, catchupDiff)
+	}
+
 	sort.Sort(FieldDiffs(diff.Fields))
 	return diff
 }
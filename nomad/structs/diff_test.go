@@ -703,6 +703,9 @@ func TestJobDiff(t *testing.T) {
 					SpecType:        "cron",
 					ProhibitOverlap: true,
 					TimeZone:        "America/Los_Angeles",
+
+					SuccessfulJobsHistoryLimit: pointer.Of(3),
+					FailedJobsHistoryLimit:     pointer.Of(1),
 				},
 			},
 			Expected: &JobDiff{
@@ -718,6 +721,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "false",
 								New:  "true",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "FailedJobsHistoryLimit",
+								Old:  "",
+								New:  "1",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "ProhibitOverlap",
@@ -736,6 +745,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "foo",
 								New:  "cron",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "SuccessfulJobsHistoryLimit",
+								Old:  "",
+								New:  "3",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "TimeZone",
@@ -747,6 +762,61 @@ func TestJobDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			// Periodic catchup added
+			Old: &Job{
+				Periodic: &PeriodicConfig{
+					Enabled: true,
+					Spec:    "* * * * * *",
+				},
+			},
+			New: &Job{
+				Periodic: &PeriodicConfig{
+					Enabled: true,
+					Spec:    "* * * * * *",
+					Catchup: &PeriodicCatchup{
+						Policy:           PeriodicCatchupAll,
+						Limit:            3,
+						StartingDeadline: time.Hour,
+					},
+				},
+			},
+			Expected: &JobDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Periodic",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Catchup",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "Limit",
+										Old:  "",
+										New:  "3",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Policy",
+										Old:  "",
+										New:  "all",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "StartingDeadline",
+										Old:  "",
+										New:  "3600000000000",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			// Periodic single to multiple times
 			Old: &Job{
@@ -1263,6 +1333,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeAdded,
 						Name: "ParameterizedJob",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxConcurrent",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Payload",
@@ -1317,6 +1393,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeDeleted,
 						Name: "ParameterizedJob",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxConcurrent",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Payload",
@@ -1450,6 +1532,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "ParameterizedJob",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "MaxConcurrent",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Payload",
@@ -1487,6 +1575,77 @@ func TestJobDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			// Parameterized Job dispatch limits edited
+			Old: &Job{
+				ParameterizedJob: &ParameterizedJobConfig{
+					Payload:       DispatchPayloadOptional,
+					MaxConcurrent: 2,
+					RateLimit: &DispatchRateLimit{
+						Limit:    10,
+						Interval: time.Minute,
+					},
+				},
+			},
+			New: &Job{
+				ParameterizedJob: &ParameterizedJobConfig{
+					Payload:       DispatchPayloadOptional,
+					MaxConcurrent: 4,
+					Queue: &DispatchQueueConfig{
+						MaxDepth: 100,
+					},
+				},
+			},
+			Expected: &JobDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "ParameterizedJob",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "MaxConcurrent",
+								Old:  "2",
+								New:  "4",
+							},
+						},
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Queue",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "MaxDepth",
+										Old:  "",
+										New:  "100",
+									},
+								},
+							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "RateLimit",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeDeleted,
+										Name: "Interval",
+										Old:  "60000000000",
+										New:  "",
+									},
+									{
+										Type: DiffTypeDeleted,
+										Name: "Limit",
+										Old:  "10",
+										New:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			// Multiregion: region added
 			Old: &Job{
@@ -2968,6 +3127,161 @@ func TestTaskGroupDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			TestCase: "Update strategy analysis added",
+			Old: &TaskGroup{
+				Update: &UpdateStrategy{
+					MaxParallel: 5,
+					Canary:      1,
+				},
+			},
+			New: &TaskGroup{
+				Update: &UpdateStrategy{
+					MaxParallel: 5,
+					Canary:      1,
+					Analysis: &CanaryAnalysis{
+						Source:         "prometheus",
+						Interval:       time.Minute,
+						Window:         5 * time.Minute,
+						SuccessfulRuns: 3,
+						OnFailure:      CanaryAnalysisOnFailureFail,
+						Metrics: []*CanaryMetric{{
+							Name:  "errors",
+							Query: "errors",
+							Max:   pointer.Of(0.5),
+						}},
+					},
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Update",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Analysis",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "FailureLimit",
+										New:  "0",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Interval",
+										New:  "60000000000",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Metrics[0].Max",
+										New:  "0.5",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Metrics[0].MaxIncrease",
+										New:  "nil",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Metrics[0].Name",
+										New:  "errors",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Metrics[0].Query",
+										New:  "errors",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "OnFailure",
+										New:  "fail",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Source",
+										New:  "prometheus",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "SuccessfulRuns",
+										New:  "3",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Window",
+										New:  "300000000000",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			TestCase: "Update strategy steps edited",
+			Old: &TaskGroup{
+				Update: &UpdateStrategy{
+					MaxParallel: 5,
+					Steps: []*RolloutStep{
+						{Percent: 10, Pause: time.Minute},
+						{Percent: 100},
+					},
+				},
+			},
+			New: &TaskGroup{
+				Update: &UpdateStrategy{
+					MaxParallel: 5,
+					Steps: []*RolloutStep{
+						{Percent: 10, Pause: time.Minute},
+						{Percent: 50},
+						{Percent: 100},
+					},
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Update",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Step",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeEdited,
+										Name: "Percent",
+										Old:  "100",
+										New:  "50",
+									},
+								},
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Step",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "Pause",
+										New:  "0",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Percent",
+										New:  "100",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			TestCase: "Disconnect strategy deleted",
 			Old: &TaskGroup{
@@ -5699,6 +6013,18 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "",
 								New:  "1",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxTotalSizeMB",
+								Old:  "",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "RotationInterval",
+								Old:  "",
+								New:  "0",
+							},
 						},
 					},
 				},
@@ -5739,6 +6065,18 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "1",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxTotalSizeMB",
+								Old:  "0",
+								New:  "",
+							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "RotationInterval",
+								Old:  "0",
+								New:  "",
+							},
 						},
 					},
 				},
@@ -5814,6 +6152,12 @@ func TestTaskDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "LogConfig",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "Compression",
+								Old:  "",
+								New:  "",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Disabled",
@@ -5832,6 +6176,18 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "1",
 								New:  "1",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "MaxTotalSizeMB",
+								Old:  "0",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "RotationInterval",
+								Old:  "0",
+								New:  "0",
+							},
 						},
 					},
 				},
@@ -6107,6 +6463,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "0",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "IOWeight",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "MemoryMB",
@@ -6216,6 +6578,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "0",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "IOWeight",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "MemoryMB",
@@ -6583,6 +6951,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "0",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "IOWeight",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "MemoryMB",
@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"maps"
+	"slices"
+)
+
+// QueuedDispatch is a dispatch of a parameterized job that was held back
+// because it exceeded the concurrency or rate limits of the job. Queued
+// dispatches are released in the order they were queued as capacity frees up.
+type QueuedDispatch struct {
+	// ID is the ID of the dispatched job that is registered once the dispatch
+	// is released. It is derived when the dispatch is queued so that callers
+	// can track the job before it exists.
+	ID string
+
+	// Namespace is the namespace of the parameterized job.
+	Namespace string
+
+	// ParentID is the ID of the parameterized job.
+	ParentID string
+
+	// Payload is the snappy compressed payload of the dispatch.
+	Payload []byte
+
+	// Meta is the metadata of the dispatch.
+	Meta map[string]string
+
+	// IdempotencyToken is the idempotency token of the dispatch request, which
+	// is carried over to the dispatched job.
+	IdempotencyToken string
+
+	// EnqueueTime is the time at which the dispatch was queued.
+	EnqueueTime int64
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// Copy returns a deep copy of the queued dispatch.
+func (q *QueuedDispatch) Copy() *QueuedDispatch {
+	if q == nil {
+		return nil
+	}
+	nq := new(QueuedDispatch)
+	*nq = *q
+	nq.Payload = slices.Clone(q.Payload)
+	nq.Meta = maps.Clone(q.Meta)
+	return nq
+}
+
+// Stub returns a summary of the queued dispatch without its payload.
+func (q *QueuedDispatch) Stub() *QueuedDispatchStub {
+	return &QueuedDispatchStub{
+		ID:          q.ID,
+		Namespace:   q.Namespace,
+		ParentID:    q.ParentID,
+		Meta:        maps.Clone(q.Meta),
+		EnqueueTime: q.EnqueueTime,
+		CreateIndex: q.CreateIndex,
+		ModifyIndex: q.ModifyIndex,
+	}
+}
+
+// QueuedDispatchStub is used to list the queued dispatches of a
+// parameterized job.
+type QueuedDispatchStub struct {
+	ID          string
+	Namespace   string
+	ParentID    string
+	Meta        map[string]string
+	EnqueueTime int64
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// DispatchQueueUpsertRequest is used to queue a dispatch of a parameterized
+// job.
+type DispatchQueueUpsertRequest struct {
+	Dispatch *QueuedDispatch
+	WriteRequest
+}
+
+// DispatchQueueDeleteRequest is used to remove dispatches from the queue,
+// either because they were released or because their parameterized job no
+// longer exists.
+type DispatchQueueDeleteRequest struct {
+	Namespace string
+	IDs       []string
+	WriteRequest
+}
+
+// JobDispatchQueueResponse is used to return the queued dispatches of a
+// parameterized job, in the order they will be released.
+type JobDispatchQueueResponse struct {
+	Dispatches []*QueuedDispatchStub
+	QueryMeta
+}
@@ -34,6 +34,8 @@ const (
 	TopicHostVolume     Topic = "HostVolume"
 	TopicCSIVolume      Topic = "CSIVolume"
 	TopicCSIPlugin      Topic = "CSIPlugin"
+	TopicVariable       Topic = "Variable"
+	TopicNamespace      Topic = "Namespace"
 	TopicAll            Topic = "*"
 
 	TypeNodeRegistration              = "NodeRegistration"
@@ -71,6 +73,12 @@ const (
 	TypeCSIVolumeRegistered           = "CSIVolumeRegistered"
 	TypeCSIVolumeDeregistered         = "CSIVolumeDeregistered"
 	TypeCSIVolumeClaim                = "CSIVolumeClaim"
+	TypeVariableUpserted              = "VariableUpserted"
+	TypeVariableDeleted               = "VariableDeleted"
+	TypeVariableLockAcquired          = "VariableLockAcquired"
+	TypeVariableLockReleased          = "VariableLockReleased"
+	TypeNamespaceUpserted             = "NamespaceUpserted"
+	TypeNamespaceDeleted              = "NamespaceDeleted"
 )
 
 // Event represents a change in Nomads state.
@@ -214,3 +222,16 @@ type CSIVolumeEvent struct {
 type CSIPluginEvent struct {
 	Plugin *CSIPlugin
 }
+
+// VariableEvent holds the metadata of a newly updated or deleted variable to
+// be used as an event in the event stream. The encrypted items are never
+// included.
+type VariableEvent struct {
+	Variable *VariableMetadata
+}
+
+// NamespaceEvent holds a newly updated or deleted namespace to be used as an
+// event in the event stream.
+type NamespaceEvent struct {
+	Namespace *Namespace
+}
@@ -6,6 +6,7 @@ package structs
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -29,6 +30,10 @@ var (
 	errNegativeStopAfter   = errors.New("stop_after cannot be a negative duration")
 	errStopAfterNonService = errors.New("stop_after can only be used with service or batch job types")
 	errInvalidReconcile    = errors.New("reconcile option is invalid")
+
+	// Gang validation errors
+	errGangNonBatch    = errors.New("gang can only be used with batch job types")
+	errGangInvalidName = errors.New("gang name contains invalid characters")
 )
 
 func NewDefaultDisconnectStrategy() *DisconnectStrategy {
@@ -137,3 +142,53 @@ func (ds *DisconnectStrategy) ReconcileStrategy() string {
 
 	return ds.Reconcile
 }
+
+// Gang configures all-or-nothing placement for a task group. The scheduler
+// only submits placements for a gang when every allocation in it can be
+// placed; otherwise none are placed and the evaluation is blocked.
+type Gang struct {
+	// Name groups task groups of the same job into a single gang. Task
+	// groups with an empty name form a gang on their own.
+	Name string `mapstructure:"name" hcl:"name,optional"`
+}
+
+func (g *Gang) Validate(job *Job) error {
+	if g == nil {
+		return nil
+	}
+
+	var mErr *multierror.Error
+
+	if job.Type != JobTypeBatch {
+		mErr = multierror.Append(mErr, errGangNonBatch)
+	}
+
+	if strings.ContainsAny(g.Name, "\000 ") {
+		mErr = multierror.Append(mErr, fmt.Errorf("%w: %q", errGangInvalidName, g.Name))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+func (g *Gang) Copy() *Gang {
+	if g == nil {
+		return nil
+	}
+
+	ng := new(Gang)
+	*ng = *g
+	return ng
+}
+
+// GangName returns the name of the gang the task group belongs to, or an
+// empty string if the group is not gang scheduled. Groups without an
+// explicit gang name are their own gang.
+func (tg *TaskGroup) GangName() string {
+	if tg.Gang == nil {
+		return ""
+	}
+	if tg.Gang.Name != "" {
+		return tg.Gang.Name
+	}
+	return tg.Name
+}
@@ -219,3 +219,33 @@ func TestJob_Validate_DisconnectRescheduleLost(t *testing.T) {
 
 	must.NoError(t, testDisconnectRescheduleLostJob.Validate())
 }
+
+func TestJobConfig_Validate_Gang(t *testing.T) {
+	ci.Parallel(t)
+
+	job := testJob()
+	job.Type = JobTypeService
+	job.TaskGroups[0].Gang = &Gang{Name: "bad name"}
+
+	err := job.Validate()
+	must.Error(t, err)
+	must.StrContains(t, err.Error(), errGangNonBatch.Error())
+	must.StrContains(t, err.Error(), errGangInvalidName.Error())
+
+	job.Type = JobTypeBatch
+	job.TaskGroups[0].Gang = &Gang{Name: "mpi"}
+	must.NoError(t, job.Validate())
+}
+
+func TestTaskGroup_GangName(t *testing.T) {
+	ci.Parallel(t)
+
+	tg := &TaskGroup{Name: "web"}
+	must.Eq(t, "", tg.GangName())
+
+	tg.Gang = &Gang{}
+	must.Eq(t, "web", tg.GangName())
+
+	tg.Gang.Name = "mpi"
+	must.Eq(t, "mpi", tg.GangName())
+}
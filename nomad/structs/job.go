@@ -4,6 +4,11 @@
 package structs
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/go-set/v3"
 )
 
@@ -58,6 +63,64 @@ type JobBatchDeregisterResponse struct {
 	QueryMeta
 }
 
+// JobStatsRequest is used on the Job.Stats RPC endpoint to summarize the
+// resource usage history of the allocations of a job.
+type JobStatsRequest struct {
+	JobID string
+
+	// IncludeTerminal includes the complete and failed allocations of the
+	// job, whose history may have been garbage collected by their clients.
+	IncludeTerminal bool
+
+	QueryOptions
+}
+
+// JobStatsResponse is the response from the Job.Stats RPC endpoint.
+type JobStatsResponse struct {
+	Stats *JobStats
+	QueryMeta
+}
+
+// JobStats summarizes the resource usage history of the allocations of a job.
+type JobStats struct {
+	// TaskGroups is the resource usage of each task group, keyed by name
+	TaskGroups map[string]*TaskGroupUsage
+
+	// Errors contains the reason the history of an allocation couldn't be
+	// read, keyed by allocation ID
+	Errors map[string]string
+}
+
+// TaskGroupUsage summarizes the resource usage of the tasks of a task group
+// across its allocations.
+type TaskGroupUsage struct {
+	// Allocs is the number of allocations summarized
+	Allocs int
+
+	// Tasks is the resource usage of each task, keyed by name
+	Tasks map[string]*TaskUsage
+}
+
+// TaskUsage summarizes the resource usage history of a task.
+type TaskUsage struct {
+	// Samples is the number of history samples summarized
+	Samples int
+
+	// CPU is the CPU usage in MHz
+	CPU UsagePercentiles
+
+	// MemoryMB is the memory usage in MB
+	MemoryMB UsagePercentiles
+}
+
+// UsagePercentiles are the percentiles of the mean usage of each sample,
+// weighted by the duration of the samples, and the maximum usage observed.
+type UsagePercentiles struct {
+	P50 float64
+	P95 float64
+	Max float64
+}
+
 // JobStatusesRequest is used on the Job.Statuses RPC endpoint
 // to get job/alloc/deployment status for jobs.
 type JobStatusesRequest struct {
@@ -262,6 +325,41 @@ func (j *Job) RequiredBridgeNetwork() set.Collection[string] {
 	return result
 }
 
+// RequiredEgressLimit identifies which task groups, if any, within the job
+// limit the egress bandwidth of their bridge network.
+func (j *Job) RequiredEgressLimit() set.Collection[string] {
+	result := set.New[string](len(j.TaskGroups))
+	for _, tg := range j.TaskGroups {
+		for _, n := range tg.Networks {
+			if n.Mode == "bridge" && n.EgressMBits > 0 {
+				result.Insert(tg.Name)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// RequiredBlockDevices returns the block devices the io limits of the tasks of
+// the job refer to, keyed by task group and task name.
+func (j *Job) RequiredBlockDevices() map[string]map[string][]string {
+	result := make(map[string]map[string][]string)
+	for _, tg := range j.TaskGroups {
+		for _, task := range tg.Tasks {
+			if task.Resources == nil || len(task.Resources.IOMax) == 0 {
+				continue
+			}
+			if result[tg.Name] == nil {
+				result[tg.Name] = make(map[string][]string)
+			}
+			for _, limit := range task.Resources.IOMax {
+				result[tg.Name][task.Name] = append(result[tg.Name][task.Name], limit.Device)
+			}
+		}
+	}
+	return result
+}
+
 // RequiredTransparentProxy identifies which task groups, if any, within the job
 // contain Connect blocks using transparent proxy
 func (j *Job) RequiredTransparentProxy() set.Collection[string] {
@@ -294,3 +392,68 @@ func (j *Job) RequiredScheduleTask() set.Collection[string] {
 	}
 	return result
 }
+
+const (
+	// JobDependencyConditionRunning is met once the job depended on is
+	// running.
+	JobDependencyConditionRunning = "running"
+
+	// JobDependencyConditionHealthy is met once the current version of the
+	// job depended on is running with all of its allocations healthy.
+	JobDependencyConditionHealthy = "healthy"
+
+	// JobDependencyConditionComplete is met once the job depended on has
+	// finished and every task group has at least one completed allocation.
+	JobDependencyConditionComplete = "complete"
+)
+
+// JobDependency is a job that must reach a given state before allocations
+// for the job declaring the dependency are placed.
+type JobDependency struct {
+	// JobID is the ID of the job depended on, which must be in the same
+	// namespace as the dependent job.
+	JobID string
+
+	// Condition is the state the job depended on must reach.
+	Condition string
+}
+
+func (d *JobDependency) Copy() *JobDependency {
+	if d == nil {
+		return nil
+	}
+	nd := new(JobDependency)
+	*nd = *d
+	return nd
+}
+
+func (d *JobDependency) Validate() error {
+	var mErr multierror.Error
+
+	if d.JobID == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("Missing job ID"))
+	} else if strings.ContainsAny(d.JobID, " \000") {
+		mErr.Errors = append(mErr.Errors, errors.New("Job ID contains a space or null character"))
+	}
+
+	switch d.Condition {
+	case JobDependencyConditionRunning, JobDependencyConditionHealthy, JobDependencyConditionComplete:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Invalid condition %q", d.Condition))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// CopySliceJobDependencies copies a slice of job dependencies.
+func CopySliceJobDependencies(s []*JobDependency) []*JobDependency {
+	if s == nil {
+		return nil
+	}
+
+	c := make([]*JobDependency, len(s))
+	for i, d := range s {
+		c[i] = d.Copy()
+	}
+	return c
+}
@@ -514,3 +514,25 @@ func TestJob_RequiredTproxy(t *testing.T) {
 	result := job.RequiredTransparentProxy()
 	must.SliceContainsAll(t, expect, result.Slice())
 }
+
+func TestJob_Validate_DependsOn(t *testing.T) {
+	job := testJob()
+	job.DependsOn = []*JobDependency{
+		{JobID: "db", Condition: JobDependencyConditionHealthy},
+		{JobID: "db", Condition: JobDependencyConditionRunning},
+		{JobID: job.ID, Condition: JobDependencyConditionRunning},
+		{JobID: "cache", Condition: "ready"},
+	}
+
+	err := job.Validate()
+	must.ErrorContains(t, err, `Dependency 2 redefines job "db"`)
+	must.ErrorContains(t, err, "Dependency 3: job cannot depend on itself")
+	must.ErrorContains(t, err, `Invalid condition "ready"`)
+
+	job.DependsOn = job.DependsOn[:1]
+	must.NoError(t, job.Validate())
+
+	job.Type = JobTypeSystem
+	job.TaskGroups[0].Count = 1
+	must.ErrorContains(t, job.Validate(), "Job dependencies can only be used")
+}
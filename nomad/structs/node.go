@@ -328,6 +328,12 @@ type DriverInfo struct {
 	UpdateTime        time.Time
 }
 
+// DriverResizeAttr returns the name of the attribute set on the DriverInfo of
+// drivers that can update the cpu and memory of running tasks.
+func DriverResizeAttr(driver string) string {
+	return "driver." + driver + ".resize"
+}
+
 func (di *DriverInfo) Copy() *DriverInfo {
 	if di == nil {
 		return nil
@@ -6,10 +6,13 @@ package structs
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"net/netip"
 	"time"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/raft"
 )
 
@@ -209,8 +212,18 @@ const (
 	// SchedulerAlgorithmSpread indicates that the scheduler should spread
 	// allocations as evenly as possible over the available hardware.
 	SchedulerAlgorithmSpread SchedulerAlgorithm = "spread"
+
+	// SchedulerAlgorithmCost indicates that the scheduler should binpack
+	// allocations onto the cheapest nodes first, as given by the
+	// NodeMetaCost of each node.
+	SchedulerAlgorithmCost SchedulerAlgorithm = "cost"
 )
 
+// NodeMetaCost is the meta key holding the relative cost of a node, used by
+// the cost scheduler algorithm. It is read from the node meta, falling back
+// to the meta of the node's pool, and must be a non-negative number.
+const NodeMetaCost = "node_cost"
+
 // SchedulerConfiguration is the config for controlling scheduler behavior
 type SchedulerConfiguration struct {
 	// SchedulerAlgorithm lets you select between available scheduling algorithms.
@@ -233,6 +246,16 @@ type SchedulerConfiguration struct {
 	// during leadership transitions.
 	PauseEvalBroker bool `hcl:"pause_eval_broker"`
 
+	// RankIterators is the list of additional ranking iterators, registered
+	// with the scheduler by name, that are inserted into the ranking stage of
+	// the scheduler stacks in the order given.
+	RankIterators []*SchedulerRankIterator `hcl:"rank_iterator"`
+
+	// FairShareConfig specifies whether evaluations and preemption should
+	// account for how much of the cluster each namespace is using relative to
+	// its configured share.
+	FairShareConfig FairShareConfig `hcl:"fair_share_config"`
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -244,6 +267,13 @@ func (s *SchedulerConfiguration) Copy() *SchedulerConfiguration {
 	}
 
 	ns := *s
+	if s.RankIterators != nil {
+		ns.RankIterators = make([]*SchedulerRankIterator, len(s.RankIterators))
+		for i, ri := range s.RankIterators {
+			ns.RankIterators[i] = ri.Copy()
+		}
+	}
+	ns.FairShareConfig.Weights = maps.Clone(s.FairShareConfig.Weights)
 	return &ns
 }
 
@@ -276,9 +306,16 @@ func (s *SchedulerConfiguration) WithNodePool(pool *NodePool) *SchedulerConfigur
 }
 
 func (s *SchedulerConfiguration) Canonicalize() {
-	if s != nil && s.SchedulerAlgorithm == "" {
+	if s == nil {
+		return
+	}
+
+	if s.SchedulerAlgorithm == "" {
 		s.SchedulerAlgorithm = SchedulerAlgorithmBinpack
 	}
+	for _, ri := range s.RankIterators {
+		ri.Canonicalize()
+	}
 }
 
 func (s *SchedulerConfiguration) Validate() error {
@@ -287,11 +324,75 @@ func (s *SchedulerConfiguration) Validate() error {
 	}
 
 	switch s.SchedulerAlgorithm {
-	case "", SchedulerAlgorithmBinpack, SchedulerAlgorithmSpread:
+	case "", SchedulerAlgorithmBinpack, SchedulerAlgorithmSpread, SchedulerAlgorithmCost:
 	default:
 		return fmt.Errorf("invalid scheduler algorithm: %v", s.SchedulerAlgorithm)
 	}
 
+	seen := make(map[string]struct{}, len(s.RankIterators))
+	for i, ri := range s.RankIterators {
+		if ri == nil {
+			return fmt.Errorf("rank iterator %d must not be empty", i)
+		}
+		if err := ri.Validate(); err != nil {
+			return fmt.Errorf("invalid rank iterator %d: %v", i, err)
+		}
+		if _, ok := seen[ri.Name]; ok {
+			return fmt.Errorf("duplicate rank iterator %q", ri.Name)
+		}
+		seen[ri.Name] = struct{}{}
+	}
+
+	if err := s.FairShareConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid fair share config: %v", err)
+	}
+
+	return nil
+}
+
+// SchedulerRankIterator configures an additional ranking iterator to be
+// inserted into the scheduler stacks. The iterator must have been registered
+// with the scheduler under the given name.
+type SchedulerRankIterator struct {
+	// Name is the name the iterator was registered with.
+	Name string `hcl:"name"`
+
+	// Weight scales the scores produced by the iterator relative to the
+	// built-in scorers. Defaults to 1 if unset, and 0 disables the iterator.
+	Weight *float64 `hcl:"weight"`
+}
+
+func (r *SchedulerRankIterator) Copy() *SchedulerRankIterator {
+	if r == nil {
+		return nil
+	}
+
+	nr := *r
+	nr.Weight = pointer.Copy(r.Weight)
+	return &nr
+}
+
+func (r *SchedulerRankIterator) Canonicalize() {
+	if r != nil && r.Weight == nil {
+		r.Weight = pointer.Of(1.0)
+	}
+}
+
+// GetWeight returns the weight of the iterator, defaulting to 1 if unset.
+func (r *SchedulerRankIterator) GetWeight() float64 {
+	if r.Weight == nil {
+		return 1
+	}
+	return *r.Weight
+}
+
+func (r *SchedulerRankIterator) Validate() error {
+	if r.Name == "" {
+		return errors.New("missing name")
+	}
+	if weight := r.GetWeight(); weight < 0 || weight > 1 {
+		return fmt.Errorf("weight must be between 0 and 1, got %v", weight)
+	}
 	return nil
 }
 
@@ -328,6 +429,36 @@ type PreemptionConfig struct {
 	ServiceSchedulerEnabled bool `hcl:"service_scheduler_enabled"`
 }
 
+// FairShareConfig configures weighted fair-share scheduling across namespaces.
+// When enabled, evaluations of namespaces using less of the cluster than their
+// share are processed first, and jobs of those namespaces may preempt
+// allocations of namespaces using more than their share.
+type FairShareConfig struct {
+	// Enabled specifies if fair-share scheduling is enabled.
+	Enabled bool `hcl:"enabled"`
+
+	// Weights maps namespace names to their relative share of the cluster.
+	// Namespaces that are not listed have a weight of 1.
+	Weights map[string]int `hcl:"weights"`
+}
+
+// Weight returns the configured weight of the namespace.
+func (f *FairShareConfig) Weight(namespace string) int {
+	if weight, ok := f.Weights[namespace]; ok {
+		return weight
+	}
+	return 1
+}
+
+func (f *FairShareConfig) Validate() error {
+	for namespace, weight := range f.Weights {
+		if weight < 1 {
+			return fmt.Errorf("weight of namespace %q must be greater than 0, got %d", namespace, weight)
+		}
+	}
+	return nil
+}
+
 // SchedulerSetConfigRequest is used by the Operator endpoint to update the
 // current Scheduler configuration of the cluster.
 type SchedulerSetConfigRequest struct {
@@ -341,6 +472,121 @@ type SchedulerSetConfigRequest struct {
 	WriteRequest
 }
 
+// SchedulerSimulation is a set of changes to make to a copy of the cluster
+// state before running the scheduler against it, so that operators can see
+// how the cluster would respond without changing it.
+type SchedulerSimulation struct {
+	// AddNodes adds copies of existing nodes to the cluster.
+	AddNodes []*SimulatedNodes
+
+	// DrainNodePools drains every node of the node pools, migrating their
+	// allocations elsewhere.
+	DrainNodePools []string
+
+	// Jobs are registered after the nodes are updated.
+	Jobs []*Job
+}
+
+// SimulatedNodes adds Count copies of an existing node to a simulation.
+type SimulatedNodes struct {
+	// NodeID is the ID or a unique ID prefix of the node to copy.
+	NodeID string
+
+	// Count is the number of copies to add.
+	Count int
+}
+
+// Validate returns an error if the simulation can't be run.
+func (s *SchedulerSimulation) Validate() error {
+	var mErr multierror.Error
+	if len(s.AddNodes) == 0 && len(s.DrainNodePools) == 0 && len(s.Jobs) == 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("simulation must add nodes, drain node pools or register jobs"))
+	}
+	for _, nodes := range s.AddNodes {
+		if nodes.NodeID == "" {
+			mErr.Errors = append(mErr.Errors, errors.New("missing node ID of nodes to add"))
+		}
+		if nodes.Count < 1 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("count of nodes to add must be greater than 0, got %d", nodes.Count))
+		}
+	}
+	for _, job := range s.Jobs {
+		if job == nil {
+			mErr.Errors = append(mErr.Errors, errors.New("missing job"))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// SchedulerSimulationResult is the outcome of a scheduler simulation.
+type SchedulerSimulationResult struct {
+	// Evaluations are the evaluations processed by the simulation, in the
+	// order they were processed.
+	Evaluations []*SimulatedEvaluation
+
+	// NodePools is the utilization of each node pool before and after the
+	// simulation.
+	NodePools []*SimulatedNodePool
+}
+
+// SimulatedEvaluation is the outcome of an evaluation processed by a
+// scheduler simulation.
+type SimulatedEvaluation struct {
+	Namespace   string
+	JobID       string
+	TriggeredBy string
+
+	// Status is the status the scheduler set on the evaluation. It is blocked
+	// if some allocations could not be placed.
+	Status string
+
+	// DesiredTGUpdates is the set of changes the scheduler made to each task
+	// group.
+	DesiredTGUpdates map[string]*DesiredUpdates
+
+	// FailedTGAllocs are the metrics of the task groups that failed to
+	// place.
+	FailedTGAllocs map[string]*AllocMetric
+
+	// PreemptedAllocs is the number of allocations preempted to make the
+	// placements.
+	PreemptedAllocs int
+}
+
+// SimulatedNodePool is the utilization of a node pool before and after a
+// scheduler simulation.
+type SimulatedNodePool struct {
+	Name   string
+	Before *NodePoolUtilization
+	After  *NodePoolUtilization
+}
+
+// NodePoolUtilization is the capacity of the schedulable nodes of a node pool
+// and how much of it is allocated.
+type NodePoolUtilization struct {
+	Nodes         int
+	CPUShares     int64
+	CPUSharesUsed int64
+	MemoryMB      int64
+	MemoryMBUsed  int64
+}
+
+// SchedulerSimulateRequest is used by the Operator endpoint to run a scheduler
+// simulation against a copy of the current cluster state.
+type SchedulerSimulateRequest struct {
+	Simulation *SchedulerSimulation
+
+	QueryOptions
+}
+
+// SchedulerSimulateResponse is the response object that wraps the result of a
+// scheduler simulation.
+type SchedulerSimulateResponse struct {
+	Result *SchedulerSimulationResult
+
+	QueryMeta
+}
+
 // SnapshotSaveRequest is used by the Operator endpoint to get a Raft snapshot
 type SnapshotSaveRequest struct {
 	QueryOptions
@@ -106,3 +106,106 @@ func TestSchedulerConfiguration_WithNodePool(t *testing.T) {
 		})
 	}
 }
+
+func TestSchedulerConfiguration_Validate_RankIterators(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name          string
+		rankIterators []*SchedulerRankIterator
+		expectedErr   string
+	}{
+		{
+			name: "valid",
+			rankIterators: []*SchedulerRankIterator{
+				{Name: "warm-cache", Weight: pointer.Of(0.5)},
+				{Name: "other"},
+			},
+		},
+		{
+			name:          "disabled",
+			rankIterators: []*SchedulerRankIterator{{Name: "warm-cache", Weight: pointer.Of(0.0)}},
+		},
+		{
+			name:          "nil iterator",
+			rankIterators: []*SchedulerRankIterator{nil},
+			expectedErr:   "must not be empty",
+		},
+		{
+			name:          "missing name",
+			rankIterators: []*SchedulerRankIterator{{Weight: pointer.Of(1.0)}},
+			expectedErr:   "missing name",
+		},
+		{
+			name:          "weight out of range",
+			rankIterators: []*SchedulerRankIterator{{Name: "warm-cache", Weight: pointer.Of(2.0)}},
+			expectedErr:   "weight must be between 0 and 1",
+		},
+		{
+			name: "duplicate",
+			rankIterators: []*SchedulerRankIterator{
+				{Name: "warm-cache"},
+				{Name: "warm-cache"},
+			},
+			expectedErr: "duplicate rank iterator",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedConfig := &SchedulerConfiguration{RankIterators: tc.rankIterators}
+			err := schedConfig.Validate()
+			if tc.expectedErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestSchedulerConfiguration_Copy_RankIterators(t *testing.T) {
+	ci.Parallel(t)
+
+	schedConfig := &SchedulerConfiguration{
+		RankIterators: []*SchedulerRankIterator{{Name: "warm-cache", Weight: pointer.Of(0.5)}},
+	}
+	copied := schedConfig.Copy()
+	must.Eq(t, schedConfig, copied)
+
+	copied.RankIterators[0].Weight = pointer.Of(1.0)
+	must.Eq(t, 0.5, *schedConfig.RankIterators[0].Weight)
+}
+
+func TestSchedulerConfiguration_Canonicalize_RankIterators(t *testing.T) {
+	ci.Parallel(t)
+
+	schedConfig := &SchedulerConfiguration{
+		RankIterators: []*SchedulerRankIterator{
+			{Name: "unset"},
+			{Name: "disabled", Weight: pointer.Of(0.0)},
+		},
+	}
+	schedConfig.Canonicalize()
+	must.Eq(t, 1.0, schedConfig.RankIterators[0].GetWeight())
+	must.Eq(t, 0.0, schedConfig.RankIterators[1].GetWeight())
+}
+
+func TestSchedulerConfiguration_FairShareConfig(t *testing.T) {
+	ci.Parallel(t)
+
+	schedConfig := &SchedulerConfiguration{
+		FairShareConfig: FairShareConfig{
+			Enabled: true,
+			Weights: map[string]int{"prod": 3},
+		},
+	}
+	must.NoError(t, schedConfig.Validate())
+	must.Eq(t, 3, schedConfig.FairShareConfig.Weight("prod"))
+	must.Eq(t, 1, schedConfig.FairShareConfig.Weight("default"))
+
+	copied := schedConfig.Copy()
+	copied.FairShareConfig.Weights["prod"] = 0
+	must.Eq(t, 3, schedConfig.FairShareConfig.Weights["prod"])
+	must.ErrorContains(t, copied.Validate(), `weight of namespace "prod" must be greater than 0`)
+}
@@ -0,0 +1,145 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+const (
+	PeriodicRunStatusSuccessful = "successful"
+	PeriodicRunStatusFailed     = "failed"
+)
+
+// PeriodicRun records the outcome of a job launched by a periodic job. Runs
+// are kept in the history of the periodic job independently of the launched
+// job, so that the outcome is still known once the launched job has been
+// garbage collected.
+type PeriodicRun struct {
+	// Namespace is the namespace of the periodic job.
+	Namespace string
+
+	// ParentID is the ID of the periodic job.
+	ParentID string
+
+	// JobID is the ID of the launched job.
+	JobID string
+
+	// LaunchTime is the time the job was launched for.
+	LaunchTime time.Time
+
+	// FinishTime is the time the last task of the launched job finished, or
+	// the zero time if no task ran.
+	FinishTime time.Time
+
+	// Status is either successful or failed.
+	Status string
+
+	// Tasks are the outcomes of the tasks of the launched job. Allocations
+	// that were replaced by a reschedule are not included.
+	Tasks []*PeriodicRunTask
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// PeriodicRunTask is the outcome of a task of a job launched by a periodic
+// job.
+type PeriodicRunTask struct {
+	AllocID   string
+	TaskGroup string
+	Task      string
+
+	// Failed marks the task as having failed.
+	Failed bool
+
+	// ExitCode is the exit code of the last time the task terminated.
+	ExitCode int
+}
+
+// NewPeriodicRun returns the run of the dead job launched by a periodic job,
+// given its allocations. The run failed if the job was stopped or any of its
+// latest allocations or their tasks failed.
+func NewPeriodicRun(job *Job, allocs []*Allocation) *PeriodicRun {
+	run := &PeriodicRun{
+		Namespace: job.Namespace,
+		ParentID:  job.ParentID,
+		JobID:     job.ID,
+		Status:    PeriodicRunStatusSuccessful,
+	}
+	if launch, err := PeriodicLaunchTime(job.ID); err == nil {
+		run.LaunchTime = launch
+	}
+
+	failed := job.Stop
+	for _, alloc := range allocs {
+		// Only the outcome of the replacement of a rescheduled allocation
+		// matters
+		if alloc.NextAllocation != "" {
+			continue
+		}
+		switch alloc.ClientStatus {
+		case AllocClientStatusFailed, AllocClientStatusLost:
+			failed = true
+		}
+
+		for name, state := range alloc.TaskStates {
+			task := &PeriodicRunTask{
+				AllocID:   alloc.ID,
+				TaskGroup: alloc.TaskGroup,
+				Task:      name,
+				Failed:    state.Failed,
+			}
+			for i := len(state.Events) - 1; i >= 0; i-- {
+				if state.Events[i].Type == TaskTerminated {
+					task.ExitCode = state.Events[i].ExitCode
+					break
+				}
+			}
+			if state.Failed {
+				failed = true
+			}
+			if state.FinishedAt.After(run.FinishTime) {
+				run.FinishTime = state.FinishedAt
+			}
+			run.Tasks = append(run.Tasks, task)
+		}
+	}
+
+	if failed {
+		run.Status = PeriodicRunStatusFailed
+	}
+
+	// Task states are keyed by name, so sort the tasks for a stable order
+	slices.SortFunc(run.Tasks, func(a, b *PeriodicRunTask) int {
+		return cmp.Or(cmp.Compare(a.AllocID, b.AllocID), cmp.Compare(a.Task, b.Task))
+	})
+	return run
+}
+
+// Copy returns a deep copy of the periodic run.
+func (r *PeriodicRun) Copy() *PeriodicRun {
+	if r == nil {
+		return nil
+	}
+	nr := new(PeriodicRun)
+	*nr = *r
+	if r.Tasks != nil {
+		nr.Tasks = make([]*PeriodicRunTask, len(r.Tasks))
+		for i, task := range r.Tasks {
+			nt := *task
+			nr.Tasks[i] = &nt
+		}
+	}
+	return nr
+}
+
+// PeriodicHistoryResponse is used to return the history of a periodic job,
+// most recent launch first.
+type PeriodicHistoryResponse struct {
+	Runs []*PeriodicRun
+	QueryMeta
+}
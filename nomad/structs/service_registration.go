@@ -91,6 +91,14 @@ type ServiceRegistration struct {
 	// is determined by a combination of factors on the client.
 	Port int
 
+	// Weight is the share of traffic the registration should receive,
+	// relative to the other registrations of the service, with 100 being an
+	// even share. While the task group of the allocation is rolled out in
+	// steps, the share of each job version is split between its registrations.
+	// It isn't stored, but computed by the server when registrations are
+	// looked up.
+	Weight int
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
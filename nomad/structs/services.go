@@ -347,7 +347,7 @@ func (sc *ServiceCheck) validateCommon(allowableTypes []string) error {
 
 // validate a Service's ServiceCheck in the context of the Nomad provider.
 func (sc *ServiceCheck) validateNomad() error {
-	allowable := []string{ServiceCheckTCP, ServiceCheckHTTP}
+	allowable := []string{ServiceCheckGRPC, ServiceCheckTCP, ServiceCheckHTTP, ServiceCheckScript}
 	if err := sc.validateCommon(allowable); err != nil {
 		return err
 	}
@@ -398,11 +398,6 @@ func (sc *ServiceCheck) validateNomad() error {
 		return errors.New("failures_before_warning may only be set for Consul service checks")
 	}
 
-	// tls_server_name is consul only
-	if sc.TLSServerName != "" {
-		return errors.New("tls_server_name may only be set for Consul service checks")
-	}
-
 	return nil
 }
 
@@ -1583,6 +1578,15 @@ func (t *SidecarTask) MergeIntoTask(task *Task) {
 			if t.LogConfig.MaxFileSizeMB > 0 {
 				task.LogConfig.MaxFileSizeMB = t.LogConfig.MaxFileSizeMB
 			}
+			if t.LogConfig.Compression != "" {
+				task.LogConfig.Compression = t.LogConfig.Compression
+			}
+			if t.LogConfig.RotationInterval > 0 {
+				task.LogConfig.RotationInterval = t.LogConfig.RotationInterval
+			}
+			if t.LogConfig.MaxTotalSizeMB > 0 {
+				task.LogConfig.MaxTotalSizeMB = t.LogConfig.MaxTotalSizeMB
+			}
 		}
 	}
 
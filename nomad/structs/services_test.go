@@ -255,8 +255,26 @@ func TestServiceCheck_validateNomad(t *testing.T) {
 		sc   *ServiceCheck
 		exp  string
 	}{
-		{name: "grpc", sc: &ServiceCheck{Type: ServiceCheckGRPC}, exp: `invalid check type ("grpc"), must be one of tcp, http`},
-		{name: "script", sc: &ServiceCheck{Type: ServiceCheckScript}, exp: `invalid check type ("script"), must be one of tcp, http`},
+		{name: "docker", sc: &ServiceCheck{Type: "docker"}, exp: `invalid check type ("docker"), must be one of grpc, tcp, http, script`},
+		{name: "script without command", sc: &ServiceCheck{Type: ServiceCheckScript}, exp: `script type must have a valid script path`},
+		{
+			name: "grpc",
+			sc: &ServiceCheck{
+				Type:        ServiceCheckGRPC,
+				GRPCService: "health",
+				Interval:    3 * time.Second,
+				Timeout:     1 * time.Second,
+			},
+		},
+		{
+			name: "script",
+			sc: &ServiceCheck{
+				Type:     ServiceCheckScript,
+				Command:  "/bin/true",
+				Interval: 3 * time.Second,
+				Timeout:  1 * time.Second,
+			},
+		},
 		{
 			name: "expose",
 			sc: &ServiceCheck{
@@ -392,7 +410,6 @@ func TestServiceCheck_validateNomad(t *testing.T) {
 				Path:          "/health",
 				TLSServerName: "foo",
 			},
-			exp: `tls_server_name may only be set for Consul service checks`,
 		},
 	}
 
@@ -1978,7 +1995,7 @@ func TestService_Validate(t *testing.T) {
 				Checks: []*ServiceCheck{
 					{
 						Name: "servicecheck",
-						Type: "script",
+						Type: "docker",
 					},
 				},
 			},
@@ -2206,7 +2223,7 @@ func TestService_validateNomadService(t *testing.T) {
 			},
 			inputErr: &multierror.Error{},
 			expectedOutputErrors: []error{
-				errors.New(`invalid check type (""), must be one of tcp, http`),
+				errors.New(`invalid check type (""), must be one of grpc, tcp, http, script`),
 			},
 			name: "bad nomad check",
 		},
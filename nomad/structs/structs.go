@@ -27,6 +27,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/hashicorp/cronexpr"
@@ -136,6 +137,9 @@ const (
 	HostVolumeRegisterRequestType             MessageType = 75
 	HostVolumeDeleteRequestType               MessageType = 76
 	TaskGroupHostVolumeClaimDeleteRequestType MessageType = 77
+	DeploymentRolloutStepRequestType          MessageType = 78
+	DispatchQueueUpsertRequestType            MessageType = 79
+	DispatchQueueDeleteRequestType            MessageType = 80
 
 	// NOTE: MessageTypes are shared between CE and ENT. If you need to add a
 	// new type, check that ENT is not already using that value.
@@ -1361,6 +1365,25 @@ type ApplyDeploymentPromoteRequest struct {
 	Eval *Evaluation
 }
 
+// ApplyDeploymentRolloutStepRequest is used to move a task group of a
+// deployment on to its next rollout step via Raft
+type ApplyDeploymentRolloutStepRequest struct {
+	DeploymentID string
+
+	// Group is the task group whose rollout step is advanced
+	Group string
+
+	// Step is the index of the rollout step to start, and Percent its
+	// percentage of allocations
+	Step    int
+	Percent int
+
+	// An optional evaluation to create after starting the step
+	Eval *Evaluation
+
+	WriteRequest
+}
+
 // DeploymentPauseRequest is used to pause a deployment
 type DeploymentPauseRequest struct {
 	DeploymentID string
@@ -1609,6 +1632,12 @@ type JobDispatchResponse struct {
 	EvalID          string
 	EvalCreateIndex uint64
 	JobCreateIndex  uint64
+
+	// Queued is set if the dispatch was queued because it exceeded the limits
+	// of the parameterized job. The job is registered with the dispatched job
+	// ID once it is released from the queue.
+	Queued bool
+
 	WriteMeta
 }
 
@@ -2406,6 +2435,8 @@ type Resources struct {
 	Devices     ResourceDevices
 	NUMA        *NUMA
 	SecretsMB   int
+	IOWeight    int
+	IOMax       IOLimits
 }
 
 const (
@@ -2500,9 +2531,111 @@ func (r *Resources) Validate() error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("SecretsMB value (%d) cannot be negative", r.SecretsMB))
 	}
 
+	// Ensure the io weight is within the range of the blkio and io cgroup
+	// controllers
+	if r.IOWeight != 0 && (r.IOWeight < MinIOWeight || r.IOWeight > MaxIOWeight) {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("IOWeight value (%d) must be between %d and %d", r.IOWeight, MinIOWeight, MaxIOWeight))
+	}
+
+	// Ensure io limits are valid and target distinct devices
+	ioDevices := set.New[string](len(r.IOMax))
+	for i, l := range r.IOMax {
+		if err := l.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("io_max %d failed validation: %v", i+1, err))
+		}
+		if !ioDevices.Insert(l.Device) {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("io_max device %q is limited more than once", l.Device))
+		}
+	}
+
 	return mErr.ErrorOrNil()
 }
 
+const (
+	// MinIOWeight and MaxIOWeight are the bounds of the relative block I/O
+	// weight of a task, following the range of the cgroups v1 blkio.weight
+	// interface. The weight is scaled to the io.weight range on cgroups v2.
+	MinIOWeight = 10
+	MaxIOWeight = 1000
+)
+
+// IOLimit is a limit on the block I/O of a task to a block device.
+type IOLimit struct {
+	// Device is the kernel name of the block device, such as "sda" or
+	// "nvme0n1".
+	Device string
+
+	// ReadBps and WriteBps limit the bytes read from and written to the
+	// device per second.
+	ReadBps  int64
+	WriteBps int64
+
+	// ReadIOps and WriteIOps limit the read and write operations on the
+	// device per second.
+	ReadIOps  int64
+	WriteIOps int64
+}
+
+// Copy returns a copy of the IOLimit.
+func (l *IOLimit) Copy() *IOLimit {
+	if l == nil {
+		return nil
+	}
+	nl := *l
+	return &nl
+}
+
+// Validate returns an error if the device of the IOLimit isn't set, or if
+// it doesn't limit anything.
+func (l *IOLimit) Validate() error {
+	var mErr multierror.Error
+	if l.Device == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("device must be set"))
+	} else if strings.ContainsAny(l.Device, "/.") {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("device %q must be the kernel name of a block device", l.Device))
+	}
+	if l.ReadBps < 0 || l.WriteBps < 0 || l.ReadIOps < 0 || l.WriteIOps < 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("limits cannot be negative"))
+	}
+	if l.ReadBps == 0 && l.WriteBps == 0 && l.ReadIOps == 0 && l.WriteIOps == 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("at least one of read_bps, write_bps, read_iops or write_iops must be set"))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// IOLimits are the block I/O limits of a task.
+type IOLimits []*IOLimit
+
+// Copy returns a deep copy of the IOLimits.
+func (ls IOLimits) Copy() IOLimits {
+	if ls == nil {
+		return nil
+	}
+	c := make(IOLimits, len(ls))
+	for i, l := range ls {
+		c[i] = l.Copy()
+	}
+	return c
+}
+
+// Equal returns whether both IOLimits limit the same devices to the same
+// values, regardless of their order.
+func (ls IOLimits) Equal(o IOLimits) bool {
+	if len(ls) != len(o) {
+		return false
+	}
+	m := make(map[string]IOLimit, len(ls))
+	for _, l := range ls {
+		m[l.Device] = *l
+	}
+	for _, ol := range o {
+		if l, ok := m[ol.Device]; !ok || l != *ol {
+			return false
+		}
+	}
+	return true
+}
+
 // Merge merges this resource with another resource.
 // COMPAT(0.10): Remove in 0.10
 func (r *Resources) Merge(other *Resources) {
@@ -2530,6 +2663,12 @@ func (r *Resources) Merge(other *Resources) {
 	if other.SecretsMB != 0 {
 		r.SecretsMB = other.SecretsMB
 	}
+	if other.IOWeight != 0 {
+		r.IOWeight = other.IOWeight
+	}
+	if len(other.IOMax) != 0 {
+		r.IOMax = other.IOMax
+	}
 }
 
 // Equal Resources.
@@ -2550,7 +2689,9 @@ func (r *Resources) Equal(o *Resources) bool {
 		r.IOPS == o.IOPS &&
 		r.Networks.Equal(&o.Networks) &&
 		r.Devices.Equal(&o.Devices) &&
-		r.SecretsMB == o.SecretsMB
+		r.SecretsMB == o.SecretsMB &&
+		r.IOWeight == o.IOWeight &&
+		r.IOMax.Equal(o.IOMax)
 }
 
 // ResourceDevices are part of Resources.
@@ -2610,6 +2751,9 @@ func (r *Resources) Canonicalize() {
 	if len(r.Devices) == 0 {
 		r.Devices = nil
 	}
+	if len(r.IOMax) == 0 {
+		r.IOMax = nil
+	}
 
 	for _, n := range r.Networks {
 		n.Canonicalize()
@@ -2650,6 +2794,8 @@ func (r *Resources) Copy() *Resources {
 		Devices:     r.Devices.Copy(),
 		NUMA:        r.NUMA.Copy(),
 		SecretsMB:   r.SecretsMB,
+		IOWeight:    r.IOWeight,
+		IOMax:       r.IOMax.Copy(),
 	}
 }
 
@@ -2913,6 +3059,7 @@ type NetworkResource struct {
 	IP            string     // Host IP address
 	Hostname      string     `json:",omitempty"` // Hostname of the network namespace
 	MBits         int        // Throughput
+	EgressMBits   int        `json:",omitempty"` // Egress bandwidth limit of bridge networks
 	DNS           *DNSConfig // DNS Configuration
 	ReservedPorts []Port     // Host Reserved ports
 	DynamicPorts  []Port     // Host Dynamically assigned ports
@@ -2922,6 +3069,9 @@ type NetworkResource struct {
 func (n *NetworkResource) Hash() uint32 {
 	var data []byte
 	data = append(data, []byte(fmt.Sprintf("%s%s%s%s%s%d", n.Mode, n.Device, n.CIDR, n.IP, n.Hostname, n.MBits))...)
+	if n.EgressMBits != 0 {
+		data = append(data, []byte(fmt.Sprintf("e%d", n.EgressMBits))...)
+	}
 
 	for i, port := range n.ReservedPorts {
 		data = append(data, []byte(fmt.Sprintf("r%d%s%d%d", i, port.Label, port.Value, port.To))...)
@@ -3910,6 +4060,7 @@ func (a *AllocatedResources) Canonicalize() {
 type AllocatedTaskResources struct {
 	Cpu      AllocatedCpuResources
 	Memory   AllocatedMemoryResources
+	IO       AllocatedIOResources
 	Networks Networks
 	Devices  []*AllocatedDeviceResource
 }
@@ -3921,6 +4072,9 @@ func (a *AllocatedTaskResources) Copy() *AllocatedTaskResources {
 	newA := new(AllocatedTaskResources)
 	*newA = *a
 
+	// Copy the io limits
+	newA.IO = a.IO.Copy()
+
 	// Copy the networks
 	newA.Networks = a.Networks.Copy()
 
@@ -4139,6 +4293,21 @@ func (a *AllocatedCpuResources) Max(other *AllocatedCpuResources) {
 	}
 }
 
+// AllocatedIOResources captures the block I/O weight and limits of a task.
+// They aren't accounted for by the scheduler and are only enforced on the
+// client.
+type AllocatedIOResources struct {
+	Weight int64
+	Max    IOLimits
+}
+
+func (a AllocatedIOResources) Copy() AllocatedIOResources {
+	return AllocatedIOResources{
+		Weight: a.Weight,
+		Max:    a.Max.Copy(),
+	}
+}
+
 // AllocatedMemoryResources captures the allocated memory resources.
 type AllocatedMemoryResources struct {
 	MemoryMB    int64
@@ -4497,6 +4666,10 @@ type Job struct {
 	// for dispatching.
 	ParameterizedJob *ParameterizedJobConfig
 
+	// DependsOn is the set of jobs that must reach a given state before
+	// allocations for this job are placed.
+	DependsOn []*JobDependency
+
 	// Dispatched is used to identify if the Job has been dispatched from a
 	// parameterized job.
 	Dispatched bool
@@ -4757,6 +4930,7 @@ func (j *Job) Copy() *Job {
 	nj.Periodic = j.Periodic.Copy()
 	nj.Meta = maps.Clone(j.Meta)
 	nj.ParameterizedJob = j.ParameterizedJob.Copy()
+	nj.DependsOn = CopySliceJobDependencies(j.DependsOn)
 	return nj
 }
 
@@ -4870,6 +5044,28 @@ func (j *Job) Validate() error {
 		}
 	}
 
+	// Validate the job dependencies
+	if len(j.DependsOn) > 0 && j.Type != JobTypeService && j.Type != JobTypeBatch {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf(
+			"Job dependencies can only be used with %q or %q scheduler", JobTypeService, JobTypeBatch,
+		))
+	}
+	dependencies := make(map[string]struct{}, len(j.DependsOn))
+	for idx, dep := range j.DependsOn {
+		if err := dep.Validate(); err != nil {
+			outer := fmt.Errorf("Dependency %d validation failed: %s", idx+1, err)
+			mErr.Errors = append(mErr.Errors, outer)
+			continue
+		}
+		if dep.JobID == j.ID {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Dependency %d: job cannot depend on itself", idx+1))
+		}
+		if _, ok := dependencies[dep.JobID]; ok {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Dependency %d redefines job %q", idx+1, dep.JobID))
+		}
+		dependencies[dep.JobID] = struct{}{}
+	}
+
 	// Validate the task group
 	for _, tg := range j.TaskGroups {
 		if err := tg.Validate(j); err != nil {
@@ -5282,6 +5478,11 @@ type JobChildrenSummary struct {
 	Pending int64
 	Running int64
 	Dead    int64
+
+	// DispatchTimes are the submit times of the most recent dispatched jobs
+	// of a parameterized job with a rate limit, oldest first. Only as many
+	// dispatches as the rate limit allows per interval are kept.
+	DispatchTimes []int64
 }
 
 // Copy returns a new copy of a JobChildrenSummary
@@ -5292,6 +5493,7 @@ func (jc *JobChildrenSummary) Copy() *JobChildrenSummary {
 
 	njc := new(JobChildrenSummary)
 	*njc = *jc
+	njc.DispatchTimes = slices.Clone(jc.DispatchTimes)
 	return njc
 }
 
@@ -5379,6 +5581,16 @@ type UpdateStrategy struct {
 	// Canary is the number of canaries to deploy when a change to the task
 	// group is detected.
 	Canary int
+
+	// Analysis, if set, gates the promotion of the canaries on a comparison of
+	// their metrics against the metrics of the stable allocations.
+	Analysis *CanaryAnalysis
+
+	// Steps, if set, rolls the new version of the task group out
+	// progressively. Each step raises the share of allocations running the
+	// new version, and the deployment only moves on to the next step once the
+	// allocations of the current step are healthy and its pause has elapsed.
+	Steps []*RolloutStep
 }
 
 func (u *UpdateStrategy) Copy() *UpdateStrategy {
@@ -5388,6 +5600,8 @@ func (u *UpdateStrategy) Copy() *UpdateStrategy {
 
 	c := new(UpdateStrategy)
 	*c = *u
+	c.Analysis = u.Analysis.Copy()
+	c.Steps = helper.CopySlice(u.Steps)
 	return c
 }
 
@@ -5430,6 +5644,31 @@ func (u *UpdateStrategy) Validate() error {
 	if u.Stagger <= 0 {
 		_ = multierror.Append(&mErr, fmt.Errorf("Stagger must be greater than zero: %v", u.Stagger))
 	}
+	if u.Analysis != nil {
+		if u.Canary == 0 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Analysis requires a Canary count greater than zero"))
+		}
+		if err := u.Analysis.Validate(); err != nil {
+			_ = multierror.Append(&mErr, multierror.Prefix(err, "Analysis:"))
+		}
+	}
+	if len(u.Steps) != 0 {
+		if u.Canary != 0 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Steps can not be combined with canaries"))
+		}
+		prev := 0
+		for i, step := range u.Steps {
+			if err := step.Validate(); err != nil {
+				_ = multierror.Append(&mErr, multierror.Prefix(err, fmt.Sprintf("Step %d:", i+1)))
+			} else if step.Percent <= prev {
+				_ = multierror.Append(&mErr, fmt.Errorf("Step %d percent must be greater than the percent of the previous step: %d <= %d", i+1, step.Percent, prev))
+			}
+			prev = step.Percent
+		}
+		if last := u.Steps[len(u.Steps)-1]; last.Percent != 100 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Last step must roll out 100 percent of the allocations: %d", last.Percent))
+		}
+	}
 
 	return mErr.ErrorOrNil()
 }
@@ -5454,6 +5693,191 @@ func (u *UpdateStrategy) Rolling() bool {
 	return u.Stagger > 0 && u.MaxParallel > 0
 }
 
+// RolloutStep is a step of the progressive rollout of a task group.
+type RolloutStep struct {
+	// Percent is the percentage of the allocations of the task group that run
+	// the new version once the step is complete.
+	Percent int
+
+	// Pause is the time to wait once the allocations of the step are healthy
+	// before moving on to the next step.
+	Pause time.Duration
+}
+
+func (r *RolloutStep) Copy() *RolloutStep {
+	if r == nil {
+		return nil
+	}
+
+	c := new(RolloutStep)
+	*c = *r
+	return c
+}
+
+func (r *RolloutStep) Validate() error {
+	var mErr multierror.Error
+	if r.Percent < 1 || r.Percent > 100 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Percent must be between 1 and 100: %d", r.Percent))
+	}
+	if r.Pause < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Pause can not be less than zero: %v", r.Pause))
+	}
+	return mErr.ErrorOrNil()
+}
+
+const (
+	// CanaryAnalysisOnFailureFail fails the deployment, reverting the job if
+	// auto_revert is set, when the canary analysis fails.
+	CanaryAnalysisOnFailureFail = "fail"
+
+	// CanaryAnalysisOnFailurePause pauses the deployment when the canary
+	// analysis fails, so that an operator can decide to promote or fail it.
+	CanaryAnalysisOnFailurePause = "pause"
+)
+
+// CanaryAnalysis configures the automated analysis of the canaries of a task
+// group. The analysis periodically queries a Prometheus-compatible metrics
+// source and compares the canary allocations with the stable allocations of
+// the task group.
+type CanaryAnalysis struct {
+	// Source is the name of the metrics source to query, out of the
+	// canary_analysis_source blocks of the server configuration.
+	Source string
+
+	// Interval is the time between two analysis runs.
+	Interval time.Duration
+
+	// Window is the range of time each query is evaluated over. It is
+	// available to the queries as {{ .Window }}.
+	Window time.Duration
+
+	// SuccessfulRuns is the number of successful runs required for the
+	// canaries to pass the analysis.
+	SuccessfulRuns int
+
+	// FailureLimit is the number of failed runs tolerated before the canaries
+	// fail the analysis.
+	FailureLimit int
+
+	// OnFailure is the action taken on the deployment when the canaries fail
+	// the analysis.
+	OnFailure string
+
+	// Metrics are the metrics compared between the canary and stable
+	// allocations.
+	Metrics []*CanaryMetric
+}
+
+func (a *CanaryAnalysis) Copy() *CanaryAnalysis {
+	if a == nil {
+		return nil
+	}
+
+	c := new(CanaryAnalysis)
+	*c = *a
+	c.Metrics = helper.CopySlice(a.Metrics)
+	return c
+}
+
+func (a *CanaryAnalysis) Validate() error {
+	if a == nil {
+		return nil
+	}
+
+	var mErr multierror.Error
+	if a.Source == "" {
+		_ = multierror.Append(&mErr, fmt.Errorf("Source must be set"))
+	}
+	if a.Interval <= 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Interval must be greater than zero: %v", a.Interval))
+	}
+	if a.Window < time.Second {
+		_ = multierror.Append(&mErr, fmt.Errorf("Window must be at least one second: %v", a.Window))
+	}
+	if a.SuccessfulRuns < 1 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Successful runs must be greater than zero: %d", a.SuccessfulRuns))
+	}
+	if a.FailureLimit < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Failure limit can not be less than zero: %d < 0", a.FailureLimit))
+	}
+	switch a.OnFailure {
+	case CanaryAnalysisOnFailureFail, CanaryAnalysisOnFailurePause:
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid on_failure given: %q", a.OnFailure))
+	}
+
+	if len(a.Metrics) == 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("At least one metric must be set"))
+	}
+	names := make(map[string]struct{}, len(a.Metrics))
+	for i, m := range a.Metrics {
+		if _, ok := names[m.Name]; ok {
+			_ = multierror.Append(&mErr, fmt.Errorf("Metric %d redefines %q", i+1, m.Name))
+		}
+		names[m.Name] = struct{}{}
+
+		if err := m.Validate(); err != nil {
+			_ = multierror.Append(&mErr, multierror.Prefix(err, fmt.Sprintf("Metric %d:", i+1)))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// CanaryMetric is a metric compared between the canary and stable allocations
+// of a task group. Lower values are considered better, as for error rates and
+// latencies.
+type CanaryMetric struct {
+	// Name identifies the metric in the analysis results.
+	Name string
+
+	// Query is a Go template of a query that returns a single value. The
+	// template is executed once for the canaries and once for the stable
+	// allocations, with {{ .AllocIDs }} set to a regular expression matching
+	// the IDs of either set of allocations.
+	Query string
+
+	// Max is the maximum value of the metric for the canaries, if set.
+	Max *float64
+
+	// MaxIncrease is the maximum increase of the metric for the canaries
+	// relative to the stable allocations, if set. For example 0.1 allows the
+	// canaries to be 10% worse than the stable allocations.
+	MaxIncrease *float64
+}
+
+func (m *CanaryMetric) Copy() *CanaryMetric {
+	if m == nil {
+		return nil
+	}
+
+	c := new(CanaryMetric)
+	*c = *m
+	c.Max = pointer.Copy(m.Max)
+	c.MaxIncrease = pointer.Copy(m.MaxIncrease)
+	return c
+}
+
+func (m *CanaryMetric) Validate() error {
+	var mErr multierror.Error
+	if m.Name == "" {
+		_ = multierror.Append(&mErr, fmt.Errorf("Name must be set"))
+	}
+	if m.Query == "" {
+		_ = multierror.Append(&mErr, fmt.Errorf("Query must be set"))
+	} else if _, err := template.New(m.Name).Parse(m.Query); err != nil {
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid query template: %v", err))
+	}
+	if m.Max == nil && m.MaxIncrease == nil {
+		_ = multierror.Append(&mErr, fmt.Errorf("At least one of max or max_increase must be set"))
+	}
+	if m.MaxIncrease != nil && *m.MaxIncrease < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Max increase can not be less than zero: %v", *m.MaxIncrease))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
 type Multiregion struct {
 	Strategy *MultiregionStrategy
 	Regions  []*MultiregionRegion
@@ -5799,6 +6223,30 @@ const (
 	PeriodicSpecTest = "_internal_test"
 )
 
+const (
+	// PeriodicCatchupNone skips the launches that were missed.
+	PeriodicCatchupNone = "none"
+
+	// PeriodicCatchupLatest launches the most recent launch that was missed.
+	PeriodicCatchupLatest = "latest"
+
+	// PeriodicCatchupAll launches the launches that were missed, up to a
+	// limit.
+	PeriodicCatchupAll = "all"
+
+	// PeriodicCatchupMaxMissed is the number of missed launches after which
+	// CatchupLaunches stops walking the schedule launch by launch, so that a
+	// frequent schedule that was missed for a long time doesn't delay
+	// establishing leadership.
+	PeriodicCatchupMaxMissed = 100
+
+	// PeriodicSuccessfulJobsHistoryLimit and PeriodicFailedJobsHistoryLimit
+	// are the default number of successful and failed launches kept in the
+	// history of a periodic job.
+	PeriodicSuccessfulJobsHistoryLimit = 3
+	PeriodicFailedJobsHistoryLimit     = 1
+)
+
 // Periodic defines the interval a job should be run at.
 type PeriodicConfig struct {
 	// Enabled determines if the job should be run periodically.
@@ -5825,6 +6273,18 @@ type PeriodicConfig struct {
 	// Reference: https://www.iana.org/time-zones
 	TimeZone string
 
+	// Catchup configures which of the launches that were missed while no
+	// leader was running the periodic dispatcher are launched once one is.
+	// If nil, a single launch is made when any were missed.
+	Catchup *PeriodicCatchup
+
+	// SuccessfulJobsHistoryLimit and FailedJobsHistoryLimit are the number of
+	// successful and failed launches whose outcome is kept in the history of
+	// the periodic job. Zero means no history is kept. Nil means the defaults
+	// apply, such as for jobs registered before the limits existed.
+	SuccessfulJobsHistoryLimit *int
+	FailedJobsHistoryLimit     *int
+
 	// location is the time zone to evaluate the launch time against
 	location *time.Location
 }
@@ -5835,9 +6295,30 @@ func (p *PeriodicConfig) Copy() *PeriodicConfig {
 	}
 	np := new(PeriodicConfig)
 	*np = *p
+	np.Catchup = p.Catchup.Copy()
+	np.SuccessfulJobsHistoryLimit = pointer.Copy(p.SuccessfulJobsHistoryLimit)
+	np.FailedJobsHistoryLimit = pointer.Copy(p.FailedJobsHistoryLimit)
 	return np
 }
 
+// SuccessfulHistoryLimit returns the number of successful launches kept in the
+// history of the periodic job.
+func (p *PeriodicConfig) SuccessfulHistoryLimit() int {
+	if p.SuccessfulJobsHistoryLimit == nil {
+		return PeriodicSuccessfulJobsHistoryLimit
+	}
+	return *p.SuccessfulJobsHistoryLimit
+}
+
+// FailedHistoryLimit returns the number of failed launches kept in the history
+// of the periodic job.
+func (p *PeriodicConfig) FailedHistoryLimit() int {
+	if p.FailedJobsHistoryLimit == nil {
+		return PeriodicFailedJobsHistoryLimit
+	}
+	return *p.FailedJobsHistoryLimit
+}
+
 func (p *PeriodicConfig) Validate() error {
 	if !p.Enabled {
 		return nil
@@ -5879,6 +6360,19 @@ func (p *PeriodicConfig) Validate() error {
 		_ = multierror.Append(&mErr, fmt.Errorf("Unknown periodic specification type %q", p.SpecType))
 	}
 
+	if p.Catchup != nil {
+		if err := p.Catchup.Validate(); err != nil {
+			_ = multierror.Append(&mErr, err)
+		}
+	}
+
+	if limit := p.SuccessfulHistoryLimit(); limit < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Successful jobs history limit can not be negative: %d", limit))
+	}
+	if limit := p.FailedHistoryLimit(); limit < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Failed jobs history limit can not be negative: %d", limit))
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -5961,6 +6455,72 @@ func (p *PeriodicConfig) Next(fromTime time.Time) (time.Time, error) {
 	return time.Time{}, nil
 }
 
+// CatchupLaunches returns the launches that were missed between the last
+// launch and now and should be launched according to the catchup policy, in
+// the order they were scheduled. Jobs that prohibit overlap catch up on at most
+// one launch.
+//
+// Once more than PeriodicCatchupMaxMissed launches were missed, the walk jumps
+// ahead to just enough of the shortest period seen before now to find the
+// launches to keep, and the returned bool is true. Launches of irregular
+// schedules may then be skipped.
+func (p *PeriodicConfig) CatchupLaunches(lastLaunch, now time.Time) ([]time.Time, bool, error) {
+	if p.Catchup == nil || p.Catchup.Policy == PeriodicCatchupNone {
+		return nil, false, nil
+	}
+
+	// Launches scheduled before the starting deadline are skipped
+	from := lastLaunch
+	if p.Catchup.StartingDeadline > 0 {
+		if deadline := now.Add(-p.Catchup.StartingDeadline); deadline.After(from) {
+			from = deadline
+		}
+	}
+
+	keep := 1
+	if p.Catchup.Policy == PeriodicCatchupAll && !p.ProhibitOverlap {
+		keep = p.Catchup.Limit
+	}
+
+	var launches []time.Time
+	var missed int
+	var period time.Duration
+	var skipped bool
+	for {
+		next, err := p.Next(from)
+		if err != nil {
+			return nil, false, err
+		}
+		if next.IsZero() || !next.Before(now) {
+			break
+		}
+
+		// After the first launch, from is the previous launch
+		if missed > 0 && (period == 0 || next.Sub(from) < period) {
+			period = next.Sub(from)
+		}
+		missed++
+
+		if !skipped && missed > PeriodicCatchupMaxMissed && period > 0 {
+			skipped = true
+			if skip := now.Add(-time.Duration(keep+1) * period); skip.After(next) {
+				launches = nil
+				from = skip
+				continue
+			}
+		}
+
+		// Only the most recent launches are kept
+		launches = append(launches, next)
+		if len(launches) > keep {
+			launches = launches[1:]
+		}
+		from = next
+	}
+
+	return launches, skipped, nil
+}
+
 // GetLocation returns the location to use for determining the time zone to run
 // the periodic job against.
 func (p *PeriodicConfig) GetLocation() *time.Location {
@@ -5972,6 +6532,52 @@ func (p *PeriodicConfig) GetLocation() *time.Location {
 	return time.UTC
 }
 
+// PeriodicCatchup configures how a periodic job catches up on the launches that
+// were missed while no leader was running the periodic dispatcher.
+type PeriodicCatchup struct {
+	// Policy is the catchup policy, which is one of none, latest or all.
+	Policy string
+
+	// Limit is the maximum number of missed launches that are launched with
+	// the all policy. The most recent launches are kept.
+	Limit int
+
+	// StartingDeadline is how late a missed launch may be launched. Launches
+	// that were scheduled longer ago are skipped. Zero means no deadline.
+	StartingDeadline time.Duration
+}
+
+func (c *PeriodicCatchup) Copy() *PeriodicCatchup {
+	if c == nil {
+		return nil
+	}
+	nc := new(PeriodicCatchup)
+	*nc = *c
+	return nc
+}
+
+func (c *PeriodicCatchup) Validate() error {
+	var mErr multierror.Error
+	switch c.Policy {
+	case PeriodicCatchupNone, PeriodicCatchupLatest:
+		if c.Limit != 0 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Catchup limit can only be set with the %q policy", PeriodicCatchupAll))
+		}
+	case PeriodicCatchupAll:
+		if c.Limit < 1 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Catchup limit must be greater than zero with the %q policy", PeriodicCatchupAll))
+		}
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Unknown catchup policy %q", c.Policy))
+	}
+
+	if c.StartingDeadline < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Catchup starting deadline can not be negative: %v", c.StartingDeadline))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
 const (
 	// PeriodicLaunchSuffix is the string appended to the periodic jobs ID
 	// when launching derived instances of it.
@@ -5989,6 +6595,22 @@ type PeriodicLaunch struct {
 	ModifyIndex uint64
 }
 
+// PeriodicLaunchTime returns the launch time encoded in the ID of a job
+// launched by a periodic job.
+func PeriodicLaunchTime(jobID string) (time.Time, error) {
+	index := strings.LastIndex(jobID, PeriodicLaunchSuffix)
+	if index == -1 {
+		return time.Time{}, fmt.Errorf("couldn't parse launch time from eval: %v", jobID)
+	}
+
+	launch, err := strconv.Atoi(jobID[index+len(PeriodicLaunchSuffix):])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("couldn't parse launch time from eval: %v", jobID)
+	}
+
+	return time.Unix(int64(launch), 0), nil
+}
+
 const (
 	DispatchPayloadForbidden = "forbidden"
 	DispatchPayloadOptional  = "optional"
@@ -6009,6 +6631,18 @@ type ParameterizedJobConfig struct {
 
 	// MetaOptional is metadata keys that may be specified by the dispatcher
 	MetaOptional []string
+
+	// MaxConcurrent is the maximum number of dispatched jobs that may be
+	// pending or running at once. Zero means no limit.
+	MaxConcurrent int
+
+	// Queue, if set, holds back the dispatches that exceed the limits of the
+	// parameterized job instead of rejecting them.
+	Queue *DispatchQueueConfig
+
+	// RateLimit, if set, limits the rate at which dispatched jobs are
+	// started.
+	RateLimit *DispatchRateLimit
 }
 
 func (d *ParameterizedJobConfig) Validate() error {
@@ -6025,9 +6659,35 @@ func (d *ParameterizedJobConfig) Validate() error {
 		_ = multierror.Append(&mErr, fmt.Errorf("Required and optional meta keys should be disjoint. Following keys exist in both: %v", offending))
 	}
 
+	if d.MaxConcurrent < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Max concurrent can not be less than zero: %d < 0", d.MaxConcurrent))
+	}
+	if d.Queue != nil {
+		if d.Queue.MaxDepth < 1 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Queue max depth must be greater than zero: %d", d.Queue.MaxDepth))
+		}
+		if d.MaxConcurrent == 0 && d.RateLimit == nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("Queue requires max concurrent or a rate limit to be set"))
+		}
+	}
+	if d.RateLimit != nil {
+		if d.RateLimit.Limit < 1 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Rate limit must be greater than zero: %d", d.RateLimit.Limit))
+		}
+		if d.RateLimit.Interval <= 0 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Rate limit interval must be greater than zero: %v", d.RateLimit.Interval))
+		}
+	}
+
 	return mErr.ErrorOrNil()
 }
 
+// Limited returns whether the parameterized job limits the dispatched jobs
+// that may be started.
+func (d *ParameterizedJobConfig) Limited() bool {
+	return d != nil && (d.MaxConcurrent > 0 || d.RateLimit != nil)
+}
+
 func (d *ParameterizedJobConfig) Canonicalize() {
 	if d.Payload == "" {
 		d.Payload = DispatchPayloadOptional
@@ -6042,9 +6702,47 @@ func (d *ParameterizedJobConfig) Copy() *ParameterizedJobConfig {
 	*nd = *d
 	nd.MetaOptional = slices.Clone(nd.MetaOptional)
 	nd.MetaRequired = slices.Clone(nd.MetaRequired)
+	nd.Queue = d.Queue.Copy()
+	nd.RateLimit = d.RateLimit.Copy()
 	return nd
 }
 
+// DispatchQueueConfig configures the queue of the dispatches of a
+// parameterized job.
+type DispatchQueueConfig struct {
+	// MaxDepth is the maximum number of queued dispatches. Dispatches are
+	// rejected once the queue is full.
+	MaxDepth int
+}
+
+func (q *DispatchQueueConfig) Copy() *DispatchQueueConfig {
+	if q == nil {
+		return nil
+	}
+	nq := new(DispatchQueueConfig)
+	*nq = *q
+	return nq
+}
+
+// DispatchRateLimit limits the rate at which the dispatched jobs of a
+// parameterized job are started.
+type DispatchRateLimit struct {
+	// Limit is the number of dispatched jobs that may be started per interval.
+	Limit int
+
+	// Interval is the sliding window over which dispatched jobs are counted.
+	Interval time.Duration
+}
+
+func (r *DispatchRateLimit) Copy() *DispatchRateLimit {
+	if r == nil {
+		return nil
+	}
+	nr := new(DispatchRateLimit)
+	*nr = *r
+	return nr
+}
+
 // DispatchedID returns an ID appropriate for a job dispatched against a
 // particular parameterized job
 func DispatchedID(templateID, idPrefixTemplate string, t time.Time) string {
@@ -6831,6 +7529,10 @@ type TaskGroup struct {
 	// disconnection between them.
 	Disconnect *DisconnectStrategy
 
+	// Gang, if set, requires that every allocation of the group (and of any
+	// other group in the same named gang) be placed together or not at all.
+	Gang *Gang
+
 	// Tasks are the collection of tasks that this task group needs to run
 	Tasks []*Task
 
@@ -6897,6 +7599,7 @@ func (tg *TaskGroup) Copy() *TaskGroup {
 	ntg.Constraints = CopySliceConstraints(ntg.Constraints)
 	ntg.RestartPolicy = ntg.RestartPolicy.Copy()
 	ntg.Disconnect = ntg.Disconnect.Copy()
+	ntg.Gang = ntg.Gang.Copy()
 	ntg.ReschedulePolicy = ntg.ReschedulePolicy.Copy()
 	ntg.Affinities = CopySliceAffinities(ntg.Affinities)
 	ntg.Spreads = CopySliceSpreads(ntg.Spreads)
@@ -7057,6 +7760,12 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		}
 	}
 
+	if tg.Gang != nil {
+		if err := tg.Gang.Validate(j); err != nil {
+			mErr = multierror.Append(mErr, err)
+		}
+	}
+
 	for idx, constr := range tg.Constraints {
 		if err := constr.Validate(); err != nil {
 			outer := fmt.Errorf("Constraint %d validation failed: %s", idx+1, err)
@@ -7265,6 +7974,14 @@ func (tg *TaskGroup) validateNetworks() error {
 				mErr.Errors = append(mErr.Errors, err)
 			}
 		}
+		if net.EgressMBits < 0 {
+			err := fmt.Errorf("Network egress_mbits (%d) cannot be negative", net.EgressMBits)
+			mErr.Errors = append(mErr.Errors, err)
+		} else if net.EgressMBits > 0 && net.Mode != "bridge" {
+			err := fmt.Errorf("Network egress_mbits is only supported in bridge mode, not %q", net.Mode)
+			mErr.Errors = append(mErr.Errors, err)
+		}
+
 		// Validate the cniArgs in each network resource. Make sure there are no duplicate Args in
 		// different network resources or invalid characters (;) in key or value ;)
 		if net.CNI != nil {
@@ -7715,11 +8432,30 @@ const (
 	DefaultKillTimeout = 5 * time.Second
 )
 
+const (
+	// LogCompressionGzip and LogCompressionZstd are the supported algorithms
+	// for compressing rotated log files. An empty compression leaves rotated
+	// files uncompressed.
+	LogCompressionGzip = "gzip"
+	LogCompressionZstd = "zstd"
+)
+
 // LogConfig provides configuration for log rotation
 type LogConfig struct {
 	MaxFiles      int
 	MaxFileSizeMB int
 	Disabled      bool
+
+	// Compression is the algorithm used to compress rotated log files.
+	Compression string
+
+	// RotationInterval rotates the log file once it has been written to for
+	// longer than the interval, regardless of its size.
+	RotationInterval time.Duration
+
+	// MaxTotalSizeMB caps the total size of the rotated log files on disk.
+	// The oldest files are removed once the cap is exceeded.
+	MaxTotalSizeMB int
 }
 
 func (l *LogConfig) Equal(o *LogConfig) bool {
@@ -7739,6 +8475,18 @@ func (l *LogConfig) Equal(o *LogConfig) bool {
 		return false
 	}
 
+	if l.Compression != o.Compression {
+		return false
+	}
+
+	if l.RotationInterval != o.RotationInterval {
+		return false
+	}
+
+	if l.MaxTotalSizeMB != o.MaxTotalSizeMB {
+		return false
+	}
+
 	return true
 }
 
@@ -7747,9 +8495,12 @@ func (l *LogConfig) Copy() *LogConfig {
 		return nil
 	}
 	return &LogConfig{
-		MaxFiles:      l.MaxFiles,
-		MaxFileSizeMB: l.MaxFileSizeMB,
-		Disabled:      l.Disabled,
+		MaxFiles:         l.MaxFiles,
+		MaxFileSizeMB:    l.MaxFileSizeMB,
+		Disabled:         l.Disabled,
+		Compression:      l.Compression,
+		RotationInterval: l.RotationInterval,
+		MaxTotalSizeMB:   l.MaxTotalSizeMB,
 	}
 }
 
@@ -7773,8 +8524,26 @@ func (l *LogConfig) Validate(disk *EphemeralDisk) error {
 	if l.MaxFileSizeMB < 1 {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("minimum file size is 1MB; got %d", l.MaxFileSizeMB))
 	}
+	switch l.Compression {
+	case "", LogCompressionGzip, LogCompressionZstd:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("unsupported compression %q; must be one of %q or %q",
+			l.Compression, LogCompressionGzip, LogCompressionZstd))
+	}
+	if l.RotationInterval < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("rotation interval must not be negative; got %v", l.RotationInterval))
+	}
+	if l.MaxTotalSizeMB < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("max total size must not be negative; got %d", l.MaxTotalSizeMB))
+	} else if l.MaxTotalSizeMB > 0 && l.MaxTotalSizeMB < l.MaxFileSizeMB {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("max total size (%d MB) must be at least the max file size (%d MB)",
+			l.MaxTotalSizeMB, l.MaxFileSizeMB))
+	}
 	if disk != nil {
 		logUsage := (l.MaxFiles * l.MaxFileSizeMB)
+		if l.MaxTotalSizeMB > 0 && l.MaxTotalSizeMB < logUsage {
+			logUsage = l.MaxTotalSizeMB
+		}
 		if disk.SizeMB <= logUsage {
 			mErr.Errors = append(mErr.Errors,
 				fmt.Errorf("log storage (%d MB) must be less than requested disk capacity (%d MB)",
@@ -9217,6 +9986,22 @@ const (
 	// configured to ignore the shutdown delay value set for the tas.
 	TaskSkippingShutdownDelay = "Skipping shutdown delay"
 
+	// TaskCheckpointed indicates that the task was checkpointed to an archive
+	// instead of being killed so it can be restored after being migrated.
+	TaskCheckpointed = "Checkpointed"
+
+	// TaskRestoredFromCheckpoint indicates that the task was started from
+	// the archive written when it was checkpointed.
+	TaskRestoredFromCheckpoint = "Restored from checkpoint"
+
+	// TaskResized indicates that the cpu and memory limits of the running
+	// task were updated without restarting it.
+	TaskResized = "Resized"
+
+	// TaskResizeFailed indicates that the driver failed to update the cpu
+	// and memory limits of the running task.
+	TaskResizeFailed = "Resize Failed"
+
 	// TaskRunning indicates a task is running due to a schedule or schedule
 	// override. (Enterprise)
 	TaskRunning = "Running"
@@ -10407,6 +11192,8 @@ const (
 	DeploymentStatusDescriptionFailedAllocations     = "Failed due to unhealthy allocations"
 	DeploymentStatusDescriptionProgressDeadline      = "Failed due to progress deadline"
 	DeploymentStatusDescriptionFailedByUser          = "Deployment marked as failed"
+	DeploymentStatusDescriptionFailedAnalysis        = "Failed due to canary analysis"
+	DeploymentStatusDescriptionPausedAnalysis        = "Deployment is paused due to canary analysis"
 
 	// used only in multiregion deployments
 	DeploymentStatusDescriptionFailedByPeer   = "Failed because of an error in peer region"
@@ -10657,6 +11444,34 @@ type DeploymentState struct {
 
 	// UnhealthyAllocs are allocations that have been marked as unhealthy.
 	UnhealthyAllocs int
+
+	// RolloutSteps is the number of rollout steps of the task group, copied
+	// from its update strategy. It is zero if the task group isn't rolled out
+	// in steps.
+	RolloutSteps int
+
+	// CurrentStep is the index of the rollout step in progress.
+	CurrentStep int
+
+	// StepPercent is the percentage of the allocations of the deployment that
+	// are rolled out by the current step.
+	StepPercent int
+}
+
+// StepTarget returns the number of allocations of the deployment that the
+// current rollout step places. If the task group isn't rolled out in steps
+// the whole deployment is targeted.
+func (d *DeploymentState) StepTarget() int {
+	if d.RolloutSteps == 0 {
+		return d.DesiredTotal
+	}
+	return (d.DesiredTotal*d.StepPercent + 99) / 100
+}
+
+// HasPendingSteps returns whether the task group has rollout steps that are
+// yet to be started.
+func (d *DeploymentState) HasPendingSteps() bool {
+	return d.CurrentStep < d.RolloutSteps-1
 }
 
 func (d *DeploymentState) GoString() string {
@@ -10669,6 +11484,9 @@ func (d *DeploymentState) GoString() string {
 	base += fmt.Sprintf("\n\tUnhealthy: %d", d.UnhealthyAllocs)
 	base += fmt.Sprintf("\n\tAutoRevert: %v", d.AutoRevert)
 	base += fmt.Sprintf("\n\tAutoPromote: %v", d.AutoPromote)
+	if d.RolloutSteps != 0 {
+		base += fmt.Sprintf("\n\tStep: %d/%d (%d%%)", d.CurrentStep+1, d.RolloutSteps, d.StepPercent)
+	}
 	return base
 }
 
@@ -11887,6 +12705,11 @@ type AllocMetric struct {
 	// This is to prevent creating many failed allocations for a
 	// single task group.
 	CoalescedFailures int
+
+	// GangUnsatisfied is set to the name of the gang when placements for a
+	// gang scheduled task group were withheld because the gang could not be
+	// placed in full.
+	GangUnsatisfied string
 }
 
 func (a *AllocMetric) Copy() *AllocMetric {
@@ -12400,6 +13223,12 @@ type Evaluation struct {
 	// captured by computed node classes.
 	EscapedComputedClass bool
 
+	// UnmetDependencies is the set of job IDs the job depends on that have
+	// not reached their required state. A blocked evaluation with unmet
+	// dependencies is unblocked once they are met, rather than on capacity
+	// changes.
+	UnmetDependencies []string
+
 	// AnnotatePlan triggers the scheduler to provide additional annotations
 	// during the evaluation. This should not be set during normal operations.
 	AnnotatePlan bool
@@ -12569,6 +13398,8 @@ func (e *Evaluation) Copy() *Evaluation {
 		ne.QueuedAllocations = queuedAllocations
 	}
 
+	ne.UnmetDependencies = slices.Clone(e.UnmetDependencies)
+
 	return ne
 }
 
@@ -12907,6 +13738,45 @@ func (p *Plan) PopUpdate(alloc *Allocation) {
 	}
 }
 
+// RemoveUpdate removes the allocation from the plan's node updates,
+// regardless of its position.
+func (p *Plan) RemoveUpdate(alloc *Allocation) {
+	existing := p.NodeUpdate[alloc.NodeID]
+	existing = slices.DeleteFunc(existing, func(a *Allocation) bool {
+		return a.ID == alloc.ID
+	})
+	if len(existing) > 0 {
+		p.NodeUpdate[alloc.NodeID] = existing
+	} else {
+		delete(p.NodeUpdate, alloc.NodeID)
+	}
+}
+
+// RemoveAlloc removes a placement previously added with AppendAlloc, along
+// with any preemptions that were added to make room for it.
+func (p *Plan) RemoveAlloc(alloc *Allocation) {
+	existing := p.NodeAllocation[alloc.NodeID]
+	existing = slices.DeleteFunc(existing, func(a *Allocation) bool {
+		return a.ID == alloc.ID
+	})
+	if len(existing) > 0 {
+		p.NodeAllocation[alloc.NodeID] = existing
+	} else {
+		delete(p.NodeAllocation, alloc.NodeID)
+	}
+
+	for nodeID, preempted := range p.NodePreemptions {
+		preempted = slices.DeleteFunc(preempted, func(a *Allocation) bool {
+			return a.PreemptedByAllocation == alloc.ID
+		})
+		if len(preempted) > 0 {
+			p.NodePreemptions[nodeID] = preempted
+		} else {
+			delete(p.NodePreemptions, nodeID)
+		}
+	}
+}
+
 // AppendAlloc appends the alloc to the plan allocations.
 // Uses the passed job if explicitly passed, otherwise
 // it is assumed the alloc will use the plan Job version.
@@ -13033,11 +13903,15 @@ type DesiredUpdates struct {
 	DestructiveUpdate uint64
 	Canary            uint64
 	Preemptions       uint64
+
+	// InPlaceResize is the number of in-place updates that resize the cpu or
+	// memory of running tasks.
+	InPlaceResize uint64
 }
 
 func (d *DesiredUpdates) GoString() string {
-	return fmt.Sprintf("(place %d) (inplace %d) (destructive %d) (stop %d) (migrate %d) (ignore %d) (canary %d)",
-		d.Place, d.InPlaceUpdate, d.DestructiveUpdate, d.Stop, d.Migrate, d.Ignore, d.Canary)
+	return fmt.Sprintf("(place %d) (inplace %d) (resize %d) (destructive %d) (stop %d) (migrate %d) (ignore %d) (canary %d)",
+		d.Place, d.InPlaceUpdate, d.InPlaceResize, d.DestructiveUpdate, d.Stop, d.Migrate, d.Ignore, d.Canary)
 }
 
 // msgpackHandle is a shared handle for encoding/decoding of structs
@@ -2068,6 +2068,32 @@ func TestTaskGroupNetwork_Validate(t *testing.T) {
 			},
 			ErrContains: "invalid ';' character in CNI arg value \"first_value;",
 		},
+		{
+			TG: &TaskGroup{
+				Name: "testing-egress-limit-bridge-ok",
+				Networks: []*NetworkResource{
+					{Mode: "bridge", EgressMBits: 100},
+				},
+			},
+		},
+		{
+			TG: &TaskGroup{
+				Name: "testing-egress-limit-host",
+				Networks: []*NetworkResource{
+					{Mode: "host", EgressMBits: 100},
+				},
+			},
+			ErrContains: "Network egress_mbits is only supported in bridge mode",
+		},
+		{
+			TG: &TaskGroup{
+				Name: "testing-egress-limit-negative",
+				Networks: []*NetworkResource{
+					{Mode: "bridge", EgressMBits: -1},
+				},
+			},
+			ErrContains: "Network egress_mbits (-1) cannot be negative",
+		},
 		{
 			TG: &TaskGroup{
 				Name: "testing-port-ignore-collision-ok",
@@ -2328,6 +2354,57 @@ func TestTask_Validate_Resources(t *testing.T) {
 			},
 			err: "numa device \"bad/bad\" not requested as task resource",
 		},
+		{
+			name: "io limits",
+			res: &Resources{
+				CPU:      100,
+				MemoryMB: 200,
+				IOWeight: 500,
+				IOMax: IOLimits{
+					{Device: "sda", ReadBps: 1048576, WriteBps: 1048576},
+					{Device: "nvme0n1", WriteIOps: 100},
+				},
+			},
+		},
+		{
+			name: "io weight out of range",
+			res: &Resources{
+				CPU:      100,
+				MemoryMB: 200,
+				IOWeight: 5,
+			},
+			err: "IOWeight value (5) must be between 10 and 1000",
+		},
+		{
+			name: "io max without limits",
+			res: &Resources{
+				CPU:      100,
+				MemoryMB: 200,
+				IOMax:    IOLimits{{Device: "sda"}},
+			},
+			err: "at least one of read_bps, write_bps, read_iops or write_iops must be set",
+		},
+		{
+			name: "io max device path",
+			res: &Resources{
+				CPU:      100,
+				MemoryMB: 200,
+				IOMax:    IOLimits{{Device: "/dev/sda", ReadBps: 1}},
+			},
+			err: `device "/dev/sda" must be the kernel name of a block device`,
+		},
+		{
+			name: "io max duplicate device",
+			res: &Resources{
+				CPU:      100,
+				MemoryMB: 200,
+				IOMax: IOLimits{
+					{Device: "sda", ReadBps: 1},
+					{Device: "sda", WriteBps: 1},
+				},
+			},
+			err: `io_max device "sda" is limited more than once`,
+		},
 		{
 			name: "numa affinity not valid",
 			res: &Resources{
@@ -2805,19 +2882,19 @@ func TestTask_Validate_Service_Check_AddressMode(t *testing.T) {
 
 	getTask := func(s *Service) (*Task, *TaskGroup) {
 		return &Task{
-				Services: []*Service{s},
-			}, &TaskGroup{
-				Networks: []*NetworkResource{
-					{
-						DynamicPorts: []Port{
-							{
-								Label: "http",
-								Value: 9999,
-							},
+			Services: []*Service{s},
+		}, &TaskGroup{
+			Networks: []*NetworkResource{
+				{
+					DynamicPorts: []Port{
+						{
+							Label: "http",
+							Value: 9999,
 						},
 					},
 				},
-			}
+			},
+		}
 	}
 
 	cases := []struct {
@@ -3187,6 +3264,24 @@ func TestLogConfig_Equals(t *testing.T) {
 		require.False(t, a.Equal(b))
 	})
 
+	t.Run("compression", func(t *testing.T) {
+		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200}
+		b := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, Compression: LogCompressionGzip}
+		require.False(t, a.Equal(b))
+	})
+
+	t.Run("rotation interval", func(t *testing.T) {
+		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, RotationInterval: time.Hour}
+		b := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, RotationInterval: time.Minute}
+		require.False(t, a.Equal(b))
+	})
+
+	t.Run("max total size", func(t *testing.T) {
+		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, MaxTotalSizeMB: 400}
+		b := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200}
+		require.False(t, a.Equal(b))
+	})
+
 	t.Run("same", func(t *testing.T) {
 		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200}
 		b := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200}
@@ -3194,6 +3289,85 @@ func TestLogConfig_Equals(t *testing.T) {
 	})
 }
 
+func TestLogConfig_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	disk := &EphemeralDisk{SizeMB: 150}
+
+	cases := []struct {
+		name   string
+		config *LogConfig
+		expErr string
+	}{
+		{
+			name:   "default",
+			config: DefaultLogConfig(),
+		},
+		{
+			name: "compression",
+			config: &LogConfig{
+				MaxFiles:      10,
+				MaxFileSizeMB: 10,
+				Compression:   LogCompressionZstd,
+			},
+		},
+		{
+			name: "unsupported compression",
+			config: &LogConfig{
+				MaxFiles:      10,
+				MaxFileSizeMB: 10,
+				Compression:   "lz4",
+			},
+			expErr: "unsupported compression",
+		},
+		{
+			name: "negative rotation interval",
+			config: &LogConfig{
+				MaxFiles:         10,
+				MaxFileSizeMB:    10,
+				RotationInterval: -time.Second,
+			},
+			expErr: "rotation interval must not be negative",
+		},
+		{
+			name: "total size smaller than file size",
+			config: &LogConfig{
+				MaxFiles:       10,
+				MaxFileSizeMB:  10,
+				MaxTotalSizeMB: 5,
+			},
+			expErr: "must be at least the max file size",
+		},
+		{
+			name: "total size caps log storage",
+			config: &LogConfig{
+				MaxFiles:       100,
+				MaxFileSizeMB:  10,
+				MaxTotalSizeMB: 100,
+			},
+		},
+		{
+			name: "log storage exceeds disk",
+			config: &LogConfig{
+				MaxFiles:      100,
+				MaxFileSizeMB: 10,
+			},
+			expErr: "log storage (1000 MB)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate(disk)
+			if tc.expErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expErr)
+			}
+		})
+	}
+}
+
 func TestTask_Validate_CSIPluginConfig(t *testing.T) {
 	ci.Parallel(t)
 
@@ -3800,6 +3974,101 @@ func TestUpdateStrategy_Validate(t *testing.T) {
 	)
 }
 
+func TestUpdateStrategy_Validate_Analysis(t *testing.T) {
+	ci.Parallel(t)
+
+	u := DefaultUpdateStrategy.Copy()
+	u.Analysis = &CanaryAnalysis{
+		Interval:       -1,
+		Window:         time.Millisecond,
+		SuccessfulRuns: 0,
+		FailureLimit:   -1,
+		OnFailure:      "retry",
+		Metrics: []*CanaryMetric{
+			{Name: "errors", Query: "{{ .AllocIDs"},
+			{Name: "errors", Query: "errors", MaxIncrease: pointer.Of(-0.1)},
+		},
+	}
+
+	err := u.Validate()
+	requireErrors(t, err,
+		"Analysis requires a Canary count greater than zero",
+		"Source must be set",
+		"Interval must be greater than zero",
+		"Window must be at least one second",
+		"Successful runs must be greater than zero",
+		"Failure limit can not be less than zero",
+		"Invalid on_failure given",
+		"Metric 2 redefines \"errors\"",
+		"Invalid query template",
+		"At least one of max or max_increase must be set",
+		"Max increase can not be less than zero",
+	)
+
+	u.Canary = 1
+	u.Analysis = &CanaryAnalysis{
+		Source:         "prometheus",
+		Interval:       time.Minute,
+		Window:         5 * time.Minute,
+		SuccessfulRuns: 3,
+		OnFailure:      CanaryAnalysisOnFailurePause,
+		Metrics: []*CanaryMetric{{
+			Name:        "errors",
+			Query:       `sum(rate(errors_total{alloc_id=~"{{ .AllocIDs }}"}[{{ .Window }}]))`,
+			MaxIncrease: pointer.Of(0.1),
+		}},
+	}
+	must.NoError(t, u.Validate())
+}
+
+func TestUpdateStrategy_Validate_Steps(t *testing.T) {
+	ci.Parallel(t)
+
+	u := DefaultUpdateStrategy.Copy()
+	u.Canary = 1
+	u.Steps = []*RolloutStep{
+		{Percent: 50, Pause: -1},
+		{Percent: 20},
+		{Percent: 120},
+		{Percent: 90},
+	}
+
+	err := u.Validate()
+	requireErrors(t, err,
+		"Steps can not be combined with canaries",
+		"Pause can not be less than zero",
+		"Step 2 percent must be greater than the percent of the previous step",
+		"Percent must be between 1 and 100",
+		"Last step must roll out 100 percent of the allocations",
+	)
+
+	u.Canary = 0
+	u.Steps = []*RolloutStep{
+		{Percent: 10, Pause: 10 * time.Minute},
+		{Percent: 50},
+		{Percent: 100},
+	}
+	must.NoError(t, u.Validate())
+}
+
+func TestDeploymentState_StepTarget(t *testing.T) {
+	ci.Parallel(t)
+
+	d := &DeploymentState{DesiredTotal: 12}
+	must.Eq(t, 12, d.StepTarget())
+	must.False(t, d.HasPendingSteps())
+
+	d.RolloutSteps = 3
+	d.StepPercent = 10
+	must.Eq(t, 2, d.StepTarget())
+	must.True(t, d.HasPendingSteps())
+
+	d.CurrentStep = 2
+	d.StepPercent = 100
+	must.Eq(t, 12, d.StepTarget())
+	must.False(t, d.HasPendingSteps())
+}
+
 func TestResource_NetIndex(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4595,6 +4864,157 @@ func TestPeriodicConfig_DST(t *testing.T) {
 	require.Equal(e2, n2.UTC())
 }
 
+func TestPeriodicConfig_Catchup(t *testing.T) {
+	ci.Parallel(t)
+
+	// Launches at the top of every hour
+	last := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, time.January, 1, 4, 30, 0, 0, time.UTC)
+	hour := func(h int) time.Time { return last.Add(time.Duration(h) * time.Hour) }
+
+	cases := []struct {
+		name     string
+		catchup  *PeriodicCatchup
+		overlap  bool
+		expected []time.Time
+	}{
+		{
+			name:     "no catchup",
+			catchup:  nil,
+			expected: nil,
+		},
+		{
+			name:     "none",
+			catchup:  &PeriodicCatchup{Policy: PeriodicCatchupNone},
+			expected: nil,
+		},
+		{
+			name:     "latest",
+			catchup:  &PeriodicCatchup{Policy: PeriodicCatchupLatest},
+			expected: []time.Time{hour(4)},
+		},
+		{
+			name:     "all up to limit",
+			catchup:  &PeriodicCatchup{Policy: PeriodicCatchupAll, Limit: 3},
+			expected: []time.Time{hour(2), hour(3), hour(4)},
+		},
+		{
+			name:     "all prohibit overlap",
+			catchup:  &PeriodicCatchup{Policy: PeriodicCatchupAll, Limit: 3},
+			overlap:  true,
+			expected: []time.Time{hour(4)},
+		},
+		{
+			name: "all within starting deadline",
+			catchup: &PeriodicCatchup{
+				Policy:           PeriodicCatchupAll,
+				Limit:            10,
+				StartingDeadline: 2 * time.Hour,
+			},
+			expected: []time.Time{hour(3), hour(4)},
+		},
+		{
+			name: "latest past starting deadline",
+			catchup: &PeriodicCatchup{
+				Policy:           PeriodicCatchupLatest,
+				StartingDeadline: 10 * time.Minute,
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &PeriodicConfig{
+				Enabled:         true,
+				SpecType:        PeriodicSpecCron,
+				Spec:            "0 * * * *",
+				ProhibitOverlap: tc.overlap,
+				Catchup:         tc.catchup,
+			}
+			p.Canonicalize()
+			must.NoError(t, p.Validate())
+
+			launches, skipped, err := p.CatchupLaunches(last, now)
+			must.NoError(t, err)
+			must.False(t, skipped)
+			must.Len(t, len(tc.expected), launches)
+			for i := range tc.expected {
+				must.Eq(t, tc.expected[i], launches[i].UTC())
+			}
+		})
+	}
+}
+
+func TestPeriodicConfig_Catchup_MaxMissed(t *testing.T) {
+	ci.Parallel(t)
+
+	// A per-second schedule missed for 30 days
+	now := time.Date(2024, time.January, 31, 0, 0, 0, 500, time.UTC)
+	last := now.Add(-30 * 24 * time.Hour)
+	second := func(s int) time.Time { return time.Date(2024, time.January, 31, 0, 0, s, 0, time.UTC) }
+
+	p := &PeriodicConfig{
+		Enabled:  true,
+		SpecType: PeriodicSpecCron,
+		Spec:     "* * * * * * *",
+		Catchup:  &PeriodicCatchup{Policy: PeriodicCatchupAll, Limit: 3},
+	}
+	p.Canonicalize()
+	must.NoError(t, p.Validate())
+
+	launches, skipped, err := p.CatchupLaunches(last, now)
+	must.NoError(t, err)
+	must.True(t, skipped)
+	must.Eq(t, []time.Time{second(-2), second(-1), second(0)}, launches)
+}
+
+func TestPeriodicCatchup_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name    string
+		catchup *PeriodicCatchup
+		errMsg  string
+	}{
+		{
+			name:    "valid",
+			catchup: &PeriodicCatchup{Policy: PeriodicCatchupAll, Limit: 5, StartingDeadline: time.Hour},
+		},
+		{
+			name:    "unknown policy",
+			catchup: &PeriodicCatchup{Policy: "some"},
+			errMsg:  `Unknown catchup policy "some"`,
+		},
+		{
+			name:    "all without limit",
+			catchup: &PeriodicCatchup{Policy: PeriodicCatchupAll},
+			errMsg:  "Catchup limit must be greater than zero",
+		},
+		{
+			name:    "limit without all",
+			catchup: &PeriodicCatchup{Policy: PeriodicCatchupLatest, Limit: 2},
+			errMsg:  "Catchup limit can only be set",
+		},
+		{
+			name:    "negative deadline",
+			catchup: &PeriodicCatchup{Policy: PeriodicCatchupNone, StartingDeadline: -time.Second},
+			errMsg:  "Catchup starting deadline can not be negative",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.catchup.Validate()
+			if tc.errMsg == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.errMsg)
+			}
+		})
+	}
+}
+
 func TestTaskLifecycleConfig_Validate(t *testing.T) {
 	ci.Parallel(t)
 
@@ -6454,6 +6874,60 @@ func TestParameterizedJobConfig_Validate(t *testing.T) {
 	}
 }
 
+func TestParameterizedJobConfig_Validate_Limits(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		config *ParameterizedJobConfig
+		errMsg string
+	}{
+		{
+			name: "valid",
+			config: &ParameterizedJobConfig{
+				MaxConcurrent: 2,
+				Queue:         &DispatchQueueConfig{MaxDepth: 10},
+				RateLimit:     &DispatchRateLimit{Limit: 5, Interval: time.Minute},
+			},
+		},
+		{
+			name:   "negative max concurrent",
+			config: &ParameterizedJobConfig{MaxConcurrent: -1},
+			errMsg: "Max concurrent can not be less than zero",
+		},
+		{
+			name: "queue without depth",
+			config: &ParameterizedJobConfig{
+				MaxConcurrent: 2,
+				Queue:         &DispatchQueueConfig{},
+			},
+			errMsg: "Queue max depth must be greater than zero",
+		},
+		{
+			name:   "queue without limits",
+			config: &ParameterizedJobConfig{Queue: &DispatchQueueConfig{MaxDepth: 10}},
+			errMsg: "Queue requires max concurrent or a rate limit",
+		},
+		{
+			name:   "rate limit without interval",
+			config: &ParameterizedJobConfig{RateLimit: &DispatchRateLimit{Limit: 5}},
+			errMsg: "Rate limit interval must be greater than zero",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.config.Payload = DispatchPayloadOptional
+			err := tc.config.Validate()
+			if tc.errMsg == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.errMsg)
+			}
+		})
+	}
+}
+
 func TestParameterizedJobConfig_Validate_NonBatch(t *testing.T) {
 	ci.Parallel(t)
 
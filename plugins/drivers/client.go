@@ -81,6 +81,8 @@ func (d *driverPluginClient) Capabilities() (*Capabilities, error) {
 		caps.MountConfigs = MountConfigSupport(resp.Capabilities.MountConfigs)
 		caps.DisableLogCollection = resp.Capabilities.DisableLogCollection
 		caps.DynamicWorkloadUsers = resp.Capabilities.DynamicWorkloadUsers
+		caps.Checkpoint = resp.Capabilities.Checkpoint
+		caps.Resize = resp.Capabilities.Resize
 	}
 
 	return caps, nil
@@ -161,19 +163,7 @@ func (d *driverPluginClient) StartTask(c *TaskConfig) (*TaskHandle, *DriverNetwo
 		return nil, nil, grpcutils.HandleGrpcErr(err, d.doneCtx)
 	}
 
-	var net *DriverNetwork
-	if resp.NetworkOverride != nil {
-		net = &DriverNetwork{
-			PortMap:       map[string]int{},
-			IP:            resp.NetworkOverride.Addr,
-			AutoAdvertise: resp.NetworkOverride.AutoAdvertise,
-		}
-		for k, v := range resp.NetworkOverride.PortMap {
-			net.PortMap[k] = int(v)
-		}
-	}
-
-	return taskHandleFromProto(resp.Handle), net, nil
+	return taskHandleFromProto(resp.Handle), driverNetworkFromProto(resp.NetworkOverride), nil
 }
 
 // WaitTask returns a channel that will have an ExitResult pushed to it once when the task
@@ -509,3 +499,52 @@ func (d *driverPluginClient) DestroyNetwork(allocID string, spec *NetworkIsolati
 
 	return nil
 }
+
+// CheckpointTask writes the state of the running task to an archive and
+// stops the task. It is only implemented by drivers with the Checkpoint
+// capability.
+func (d *driverPluginClient) CheckpointTask(taskID, path string) error {
+	req := &proto.CheckpointTaskRequest{
+		TaskId: taskID,
+		Path:   path,
+	}
+
+	_, err := d.client.CheckpointTask(d.doneCtx, req)
+	if err != nil {
+		return grpcutils.HandleGrpcErr(err, d.doneCtx)
+	}
+
+	return nil
+}
+
+// RestoreTask starts the task from an archive written by CheckpointTask. It
+// is only implemented by drivers with the Checkpoint capability.
+func (d *driverPluginClient) RestoreTask(c *TaskConfig, path string) (*TaskHandle, *DriverNetwork, error) {
+	req := &proto.RestoreTaskRequest{
+		Task: taskConfigToProto(c),
+		Path: path,
+	}
+
+	resp, err := d.client.RestoreTask(d.doneCtx, req)
+	if err != nil {
+		return nil, nil, grpcutils.HandleGrpcErr(err, d.doneCtx)
+	}
+
+	return taskHandleFromProto(resp.Handle), driverNetworkFromProto(resp.NetworkOverride), nil
+}
+
+// UpdateTaskResources updates the cpu and memory limits of a running task. It
+// is only implemented by drivers with the Resize capability.
+func (d *driverPluginClient) UpdateTaskResources(taskID string, resources *Resources) error {
+	req := &proto.UpdateTaskResourcesRequest{
+		TaskId:    taskID,
+		Resources: ResourcesToProto(resources),
+	}
+
+	_, err := d.client.UpdateTaskResources(d.doneCtx, req)
+	if err != nil {
+		return grpcutils.HandleGrpcErr(err, d.doneCtx)
+	}
+
+	return nil
+}
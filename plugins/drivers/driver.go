@@ -98,6 +98,29 @@ type DriverNetworkManager interface {
 	DestroyNetwork(allocID string, spec *NetworkIsolationSpec) error
 }
 
+// CheckpointableDriver is the interface for drivers that can freeze the state
+// of a running task into an archive and later start the task again from that
+// archive, possibly on another node. This only needs to be implemented if the
+// driver sets the Checkpoint capability.
+type CheckpointableDriver interface {
+	// CheckpointTask writes the state of the running task to an archive at
+	// path and stops the task. The task must still be destroyed afterwards.
+	CheckpointTask(taskID string, path string) error
+
+	// RestoreTask starts the task from an archive written by CheckpointTask
+	// instead of starting it from scratch.
+	RestoreTask(cfg *TaskConfig, path string) (*TaskHandle, *DriverNetwork, error)
+}
+
+// ResizableDriver is the interface for drivers that can update the cpu and
+// memory limits of a running task without restarting it. This only needs to
+// be implemented if the driver sets the Resize capability.
+type ResizableDriver interface {
+	// UpdateTaskResources applies the cpu and memory limits of resources to
+	// the running task.
+	UpdateTaskResources(taskID string, resources *Resources) error
+}
+
 // DriverSignalTaskNotSupported can be embedded by drivers which don't support
 // the SignalTask RPC. This satisfies the SignalTask func requirement for the
 // DriverPlugin interface.
@@ -177,6 +200,15 @@ type Capabilities struct {
 	// The allocation of a unique, not-in-use UID/GID is managed by Nomad client
 	// ensuring no overlap.
 	DynamicWorkloadUsers bool
+
+	// Checkpoint indicates this driver can checkpoint a running task to an
+	// archive and restore a task from one, and that the CheckpointTask and
+	// RestoreTask RPCs are implemented.
+	Checkpoint bool
+
+	// Resize indicates this driver can update the cpu and memory limits of a
+	// running task, and that the UpdateTaskResources RPC is implemented.
+	Resize bool
 }
 
 func (c *Capabilities) HasNetIsolationMode(m NetIsolationMode) bool {
@@ -1854,7 +1854,13 @@ type DriverCapabilities struct {
 	DisableLogCollection bool `protobuf:"varint,8,opt,name=disable_log_collection,json=disableLogCollection,proto3" json:"disable_log_collection,omitempty"`
 	// dynamic_workload_users indicates the task is capable of using UID/GID
 	// assigned from the Nomad client as user credentials for the task.
-	DynamicWorkloadUsers bool     `protobuf:"varint,9,opt,name=dynamic_workload_users,json=dynamicWorkloadUsers,proto3" json:"dynamic_workload_users,omitempty"`
+	DynamicWorkloadUsers bool `protobuf:"varint,9,opt,name=dynamic_workload_users,json=dynamicWorkloadUsers,proto3" json:"dynamic_workload_users,omitempty"`
+	// checkpoint indicates that the driver can checkpoint a running task to
+	// an archive and restore a task from one.
+	Checkpoint bool `protobuf:"varint,10,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	// resize indicates that the driver can update the cpu and memory limits
+	// of a running task.
+	Resize               bool     `protobuf:"varint,11,opt,name=resize,proto3" json:"resize,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1941,6 +1947,20 @@ func (m *DriverCapabilities) GetDynamicWorkloadUsers() bool {
 	return false
 }
 
+func (m *DriverCapabilities) GetCheckpoint() bool {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return false
+}
+
+func (m *DriverCapabilities) GetResize() bool {
+	if m != nil {
+		return m.Resize
+	}
+	return false
+}
+
 type NetworkIsolationSpec struct {
 	Mode                 NetworkIsolationSpec_NetworkIsolationMode `protobuf:"varint,1,opt,name=mode,proto3,enum=hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec_NetworkIsolationMode" json:"mode,omitempty"`
 	Path                 string                                    `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
@@ -2404,6 +2424,7 @@ type AllocatedTaskResources struct {
 	Cpu                  *AllocatedCpuResources    `protobuf:"bytes,1,opt,name=cpu,proto3" json:"cpu,omitempty"`
 	Memory               *AllocatedMemoryResources `protobuf:"bytes,2,opt,name=memory,proto3" json:"memory,omitempty"`
 	Networks             []*NetworkResource        `protobuf:"bytes,5,rep,name=networks,proto3" json:"networks,omitempty"`
+	Io                   *AllocatedIOResources     `protobuf:"bytes,6,opt,name=io,proto3" json:"io,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
 	XXX_unrecognized     []byte                    `json:"-"`
 	XXX_sizecache        int32                     `json:"-"`
@@ -2455,6 +2476,13 @@ func (m *AllocatedTaskResources) GetNetworks() []*NetworkResource {
 	return nil
 }
 
+func (m *AllocatedTaskResources) GetIo() *AllocatedIOResources {
+	if m != nil {
+		return m.Io
+	}
+	return nil
+}
+
 type AllocatedCpuResources struct {
 	CpuShares            int64    `protobuf:"varint,1,opt,name=cpu_shares,json=cpuShares,proto3" json:"cpu_shares,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -3675,6 +3703,387 @@ func (m *DriverTaskEvent) GetAnnotations() map[string]string {
 	return nil
 }
 
+type CheckpointTaskRequest struct {
+	// TaskId is the ID of the target task
+	TaskId string `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Path is the path of the archive the checkpoint is written to
+	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointTaskRequest) Reset()         { *m = CheckpointTaskRequest{} }
+func (m *CheckpointTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckpointTaskRequest) ProtoMessage()    {}
+func (*CheckpointTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{57}
+}
+
+func (m *CheckpointTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointTaskRequest.Unmarshal(m, b)
+}
+func (m *CheckpointTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckpointTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointTaskRequest.Merge(m, src)
+}
+func (m *CheckpointTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckpointTaskRequest.Size(m)
+}
+func (m *CheckpointTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointTaskRequest proto.InternalMessageInfo
+
+func (m *CheckpointTaskRequest) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *CheckpointTaskRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type CheckpointTaskResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointTaskResponse) Reset()         { *m = CheckpointTaskResponse{} }
+func (m *CheckpointTaskResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckpointTaskResponse) ProtoMessage()    {}
+func (*CheckpointTaskResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{58}
+}
+
+func (m *CheckpointTaskResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointTaskResponse.Unmarshal(m, b)
+}
+func (m *CheckpointTaskResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointTaskResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckpointTaskResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointTaskResponse.Merge(m, src)
+}
+func (m *CheckpointTaskResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckpointTaskResponse.Size(m)
+}
+func (m *CheckpointTaskResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointTaskResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointTaskResponse proto.InternalMessageInfo
+
+type RestoreTaskRequest struct {
+	// Task configuration to restore
+	Task *TaskConfig `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// Path is the path of the archive written by CheckpointTask
+	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreTaskRequest) Reset()         { *m = RestoreTaskRequest{} }
+func (m *RestoreTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreTaskRequest) ProtoMessage()    {}
+func (*RestoreTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{59}
+}
+
+func (m *RestoreTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTaskRequest.Unmarshal(m, b)
+}
+func (m *RestoreTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTaskRequest.Merge(m, src)
+}
+func (m *RestoreTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreTaskRequest.Size(m)
+}
+func (m *RestoreTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTaskRequest proto.InternalMessageInfo
+
+func (m *RestoreTaskRequest) GetTask() *TaskConfig {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
+func (m *RestoreTaskRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type RestoreTaskResponse struct {
+	// Handle is opaque to the client, but must be stored in order to recover
+	// the task.
+	Handle *TaskHandle `protobuf:"bytes,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	// NetworkOverride is set if the driver sets network settings and the service ip/port
+	// needs to be set differently.
+	NetworkOverride      *NetworkOverride `protobuf:"bytes,2,opt,name=network_override,json=networkOverride,proto3" json:"network_override,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *RestoreTaskResponse) Reset()         { *m = RestoreTaskResponse{} }
+func (m *RestoreTaskResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreTaskResponse) ProtoMessage()    {}
+func (*RestoreTaskResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{60}
+}
+
+func (m *RestoreTaskResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTaskResponse.Unmarshal(m, b)
+}
+func (m *RestoreTaskResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTaskResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreTaskResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTaskResponse.Merge(m, src)
+}
+func (m *RestoreTaskResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreTaskResponse.Size(m)
+}
+func (m *RestoreTaskResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTaskResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTaskResponse proto.InternalMessageInfo
+
+func (m *RestoreTaskResponse) GetHandle() *TaskHandle {
+	if m != nil {
+		return m.Handle
+	}
+	return nil
+}
+
+func (m *RestoreTaskResponse) GetNetworkOverride() *NetworkOverride {
+	if m != nil {
+		return m.NetworkOverride
+	}
+	return nil
+}
+
+type UpdateTaskResourcesRequest struct {
+	// TaskId is the ID of the target task
+	TaskId string `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Resources are the new resources of the task
+	Resources            *Resources `protobuf:"bytes,2,opt,name=resources,proto3" json:"resources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *UpdateTaskResourcesRequest) Reset()         { *m = UpdateTaskResourcesRequest{} }
+func (m *UpdateTaskResourcesRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateTaskResourcesRequest) ProtoMessage()    {}
+func (*UpdateTaskResourcesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{61}
+}
+
+func (m *UpdateTaskResourcesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateTaskResourcesRequest.Unmarshal(m, b)
+}
+func (m *UpdateTaskResourcesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateTaskResourcesRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateTaskResourcesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateTaskResourcesRequest.Merge(m, src)
+}
+func (m *UpdateTaskResourcesRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateTaskResourcesRequest.Size(m)
+}
+func (m *UpdateTaskResourcesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateTaskResourcesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateTaskResourcesRequest proto.InternalMessageInfo
+
+func (m *UpdateTaskResourcesRequest) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *UpdateTaskResourcesRequest) GetResources() *Resources {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+type UpdateTaskResourcesResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateTaskResourcesResponse) Reset()         { *m = UpdateTaskResourcesResponse{} }
+func (m *UpdateTaskResourcesResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateTaskResourcesResponse) ProtoMessage()    {}
+func (*UpdateTaskResourcesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{62}
+}
+
+func (m *UpdateTaskResourcesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateTaskResourcesResponse.Unmarshal(m, b)
+}
+func (m *UpdateTaskResourcesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateTaskResourcesResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateTaskResourcesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateTaskResourcesResponse.Merge(m, src)
+}
+func (m *UpdateTaskResourcesResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateTaskResourcesResponse.Size(m)
+}
+func (m *UpdateTaskResourcesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateTaskResourcesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateTaskResourcesResponse proto.InternalMessageInfo
+
+type AllocatedIOResources struct {
+	// Weight is the relative block I/O weight of the task
+	Weight int64 `protobuf:"varint,1,opt,name=weight,proto3" json:"weight,omitempty"`
+	// Max are the limits of the block I/O of the task per device
+	Max                  []*IOLimit `protobuf:"bytes,2,rep,name=max,proto3" json:"max,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *AllocatedIOResources) Reset()         { *m = AllocatedIOResources{} }
+func (m *AllocatedIOResources) String() string { return proto.CompactTextString(m) }
+func (*AllocatedIOResources) ProtoMessage()    {}
+func (*AllocatedIOResources) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{63}
+}
+
+func (m *AllocatedIOResources) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AllocatedIOResources.Unmarshal(m, b)
+}
+func (m *AllocatedIOResources) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AllocatedIOResources.Marshal(b, m, deterministic)
+}
+func (m *AllocatedIOResources) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AllocatedIOResources.Merge(m, src)
+}
+func (m *AllocatedIOResources) XXX_Size() int {
+	return xxx_messageInfo_AllocatedIOResources.Size(m)
+}
+func (m *AllocatedIOResources) XXX_DiscardUnknown() {
+	xxx_messageInfo_AllocatedIOResources.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AllocatedIOResources proto.InternalMessageInfo
+
+func (m *AllocatedIOResources) GetWeight() int64 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *AllocatedIOResources) GetMax() []*IOLimit {
+	if m != nil {
+		return m.Max
+	}
+	return nil
+}
+
+type IOLimit struct {
+	// Device is the kernel name of the block device
+	Device               string   `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	ReadBps              int64    `protobuf:"varint,2,opt,name=read_bps,json=readBps,proto3" json:"read_bps,omitempty"`
+	WriteBps             int64    `protobuf:"varint,3,opt,name=write_bps,json=writeBps,proto3" json:"write_bps,omitempty"`
+	ReadIops             int64    `protobuf:"varint,4,opt,name=read_iops,json=readIops,proto3" json:"read_iops,omitempty"`
+	WriteIops            int64    `protobuf:"varint,5,opt,name=write_iops,json=writeIops,proto3" json:"write_iops,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IOLimit) Reset()         { *m = IOLimit{} }
+func (m *IOLimit) String() string { return proto.CompactTextString(m) }
+func (*IOLimit) ProtoMessage()    {}
+func (*IOLimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{64}
+}
+
+func (m *IOLimit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IOLimit.Unmarshal(m, b)
+}
+func (m *IOLimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IOLimit.Marshal(b, m, deterministic)
+}
+func (m *IOLimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IOLimit.Merge(m, src)
+}
+func (m *IOLimit) XXX_Size() int {
+	return xxx_messageInfo_IOLimit.Size(m)
+}
+func (m *IOLimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_IOLimit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IOLimit proto.InternalMessageInfo
+
+func (m *IOLimit) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+func (m *IOLimit) GetReadBps() int64 {
+	if m != nil {
+		return m.ReadBps
+	}
+	return 0
+}
+
+func (m *IOLimit) GetWriteBps() int64 {
+	if m != nil {
+		return m.WriteBps
+	}
+	return 0
+}
+
+func (m *IOLimit) GetReadIops() int64 {
+	if m != nil {
+		return m.ReadIops
+	}
+	return 0
+}
+
+func (m *IOLimit) GetWriteIops() int64 {
+	if m != nil {
+		return m.WriteIops
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.TaskState", TaskState_name, TaskState_value)
 	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.FingerprintResponse_HealthState", FingerprintResponse_HealthState_name, FingerprintResponse_HealthState_value)
@@ -3751,6 +4160,14 @@ func init() {
 	proto.RegisterType((*MemoryUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.MemoryUsage")
 	proto.RegisterType((*DriverTaskEvent)(nil), "hashicorp.nomad.plugins.drivers.proto.DriverTaskEvent")
 	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.drivers.proto.DriverTaskEvent.AnnotationsEntry")
+	proto.RegisterType((*CheckpointTaskRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.CheckpointTaskRequest")
+	proto.RegisterType((*CheckpointTaskResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.CheckpointTaskResponse")
+	proto.RegisterType((*RestoreTaskRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.RestoreTaskRequest")
+	proto.RegisterType((*RestoreTaskResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.RestoreTaskResponse")
+	proto.RegisterType((*UpdateTaskResourcesRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.UpdateTaskResourcesRequest")
+	proto.RegisterType((*UpdateTaskResourcesResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.UpdateTaskResourcesResponse")
+	proto.RegisterType((*AllocatedIOResources)(nil), "hashicorp.nomad.plugins.drivers.proto.AllocatedIOResources")
+	proto.RegisterType((*IOLimit)(nil), "hashicorp.nomad.plugins.drivers.proto.IOLimit")
 }
 
 func init() {
@@ -3758,254 +4175,270 @@ func init() {
 }
 
 var fileDescriptor_4a8f45747846a74d = []byte{
-	// 3938 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0x4f, 0x73, 0xdb, 0x48,
-	0x76, 0x17, 0xf8, 0x4f, 0xe4, 0x23, 0x45, 0x41, 0x2d, 0xc9, 0xa6, 0x39, 0x9b, 0x8c, 0x07, 0x5b,
-	0x93, 0x52, 0x76, 0x67, 0xe8, 0x59, 0x6d, 0x32, 0x1e, 0x7b, 0x3d, 0xeb, 0xe1, 0x50, 0xb4, 0x45,
-	0x5b, 0xa2, 0x94, 0x26, 0x15, 0xaf, 0xe3, 0x64, 0x10, 0x08, 0x68, 0x53, 0xb0, 0x48, 0x00, 0x83,
-	0x06, 0x65, 0x69, 0x53, 0xa9, 0xa4, 0x36, 0x55, 0xa9, 0x4d, 0x55, 0x52, 0xc9, 0x65, 0xb2, 0x97,
-	0x9c, 0xb6, 0x2a, 0xa7, 0x54, 0xee, 0xa9, 0x4d, 0xed, 0x29, 0x87, 0x7c, 0x89, 0x1c, 0x92, 0x5b,
-	0xae, 0xf9, 0x04, 0xd9, 0xea, 0x3f, 0x00, 0x01, 0x92, 0x1e, 0x83, 0x94, 0x4f, 0xe4, 0x7b, 0xdd,
-	0xfd, 0xeb, 0x87, 0xf7, 0x5e, 0xbf, 0x7e, 0xdd, 0xfd, 0x40, 0xf3, 0x86, 0xe3, 0x81, 0xed, 0xd0,
-	0x3b, 0x96, 0x6f, 0x5f, 0x10, 0x9f, 0xde, 0xf1, 0x7c, 0x37, 0x70, 0x25, 0xd5, 0xe0, 0x04, 0xfa,
-	0xf0, 0xcc, 0xa0, 0x67, 0xb6, 0xe9, 0xfa, 0x5e, 0xc3, 0x71, 0x47, 0x86, 0xd5, 0x90, 0x63, 0x1a,
-	0x72, 0x8c, 0xe8, 0x56, 0xff, 0xed, 0x81, 0xeb, 0x0e, 0x86, 0x44, 0x20, 0x9c, 0x8e, 0x5f, 0xde,
-	0xb1, 0xc6, 0xbe, 0x11, 0xd8, 0xae, 0x23, 0xdb, 0xdf, 0x9f, 0x6e, 0x0f, 0xec, 0x11, 0xa1, 0x81,
-	0x31, 0xf2, 0x64, 0x87, 0x0f, 0x43, 0x59, 0xe8, 0x99, 0xe1, 0x13, 0xeb, 0xce, 0x99, 0x39, 0xa4,
-	0x1e, 0x31, 0xd9, 0xaf, 0xce, 0xfe, 0xc8, 0x6e, 0x1f, 0x4d, 0x75, 0xa3, 0x81, 0x3f, 0x36, 0x83,
-	0x50, 0x72, 0x23, 0x08, 0x7c, 0xfb, 0x74, 0x1c, 0x10, 0xd1, 0x5b, 0xbb, 0x05, 0x37, 0xfb, 0x06,
-	0x3d, 0x6f, 0xb9, 0xce, 0x4b, 0x7b, 0xd0, 0x33, 0xcf, 0xc8, 0xc8, 0xc0, 0xe4, 0xeb, 0x31, 0xa1,
-	0x81, 0xf6, 0xc7, 0x50, 0x9b, 0x6d, 0xa2, 0x9e, 0xeb, 0x50, 0x82, 0xbe, 0x80, 0x1c, 0x9b, 0xb2,
-	0xa6, 0xdc, 0x56, 0x76, 0xca, 0xbb, 0x1f, 0x35, 0xde, 0xa4, 0x02, 0x21, 0x43, 0x43, 0x8a, 0xda,
-	0xe8, 0x79, 0xc4, 0xc4, 0x7c, 0xa4, 0xb6, 0x0d, 0x9b, 0x2d, 0xc3, 0x33, 0x4e, 0xed, 0xa1, 0x1d,
-	0xd8, 0x84, 0x86, 0x93, 0x8e, 0x61, 0x2b, 0xc9, 0x96, 0x13, 0xfe, 0x09, 0x54, 0xcc, 0x18, 0x5f,
-	0x4e, 0x7c, 0xaf, 0x91, 0x4a, 0xf7, 0x8d, 0x3d, 0x4e, 0x25, 0x80, 0x13, 0x70, 0xda, 0x16, 0xa0,
-	0x47, 0xb6, 0x33, 0x20, 0xbe, 0xe7, 0xdb, 0x4e, 0x10, 0x0a, 0xf3, 0xeb, 0x2c, 0x6c, 0x26, 0xd8,
-	0x52, 0x98, 0x57, 0x00, 0x91, 0x1e, 0x99, 0x28, 0xd9, 0x9d, 0xf2, 0xee, 0x93, 0x94, 0xa2, 0xcc,
-	0xc1, 0x6b, 0x34, 0x23, 0xb0, 0xb6, 0x13, 0xf8, 0x57, 0x38, 0x86, 0x8e, 0xbe, 0x82, 0xc2, 0x19,
-	0x31, 0x86, 0xc1, 0x59, 0x2d, 0x73, 0x5b, 0xd9, 0xa9, 0xee, 0x3e, 0xba, 0xc6, 0x3c, 0xfb, 0x1c,
-	0xa8, 0x17, 0x18, 0x01, 0xc1, 0x12, 0x15, 0x7d, 0x0c, 0x48, 0xfc, 0xd3, 0x2d, 0x42, 0x4d, 0xdf,
-	0xf6, 0x98, 0x4b, 0xd6, 0xb2, 0xb7, 0x95, 0x9d, 0x12, 0xde, 0x10, 0x2d, 0x7b, 0x93, 0x86, 0xba,
-	0x07, 0xeb, 0x53, 0xd2, 0x22, 0x15, 0xb2, 0xe7, 0xe4, 0x8a, 0x5b, 0xa4, 0x84, 0xd9, 0x5f, 0xf4,
-	0x18, 0xf2, 0x17, 0xc6, 0x70, 0x4c, 0xb8, 0xc8, 0xe5, 0xdd, 0x1f, 0xbc, 0xcd, 0x3d, 0xa4, 0x8b,
-	0x4e, 0xf4, 0x80, 0xc5, 0xf8, 0xfb, 0x99, 0xcf, 0x14, 0xed, 0x1e, 0x94, 0x63, 0x72, 0xa3, 0x2a,
-	0xc0, 0x49, 0x77, 0xaf, 0xdd, 0x6f, 0xb7, 0xfa, 0xed, 0x3d, 0x75, 0x05, 0xad, 0x41, 0xe9, 0xa4,
-	0xbb, 0xdf, 0x6e, 0x1e, 0xf4, 0xf7, 0x9f, 0xab, 0x0a, 0x2a, 0xc3, 0x6a, 0x48, 0x64, 0xb4, 0x4b,
-	0x40, 0x98, 0x98, 0xee, 0x05, 0xf1, 0x99, 0x23, 0x4b, 0xab, 0xa2, 0x9b, 0xb0, 0x1a, 0x18, 0xf4,
-	0x5c, 0xb7, 0x2d, 0x29, 0x73, 0x81, 0x91, 0x1d, 0x0b, 0x75, 0xa0, 0x70, 0x66, 0x38, 0xd6, 0xf0,
-	0xed, 0x72, 0x27, 0x55, 0xcd, 0xc0, 0xf7, 0xf9, 0x40, 0x2c, 0x01, 0x98, 0x77, 0x27, 0x66, 0x16,
-	0x06, 0xd0, 0x9e, 0x83, 0xda, 0x0b, 0x0c, 0x3f, 0x88, 0x8b, 0xd3, 0x86, 0x1c, 0x9b, 0x5f, 0x7a,
-	0xf4, 0x22, 0x73, 0x8a, 0x95, 0x89, 0xf9, 0x70, 0xed, 0xff, 0x32, 0xb0, 0x11, 0xc3, 0x96, 0x9e,
-	0xfa, 0x0c, 0x0a, 0x3e, 0xa1, 0xe3, 0x61, 0xc0, 0xe1, 0xab, 0xbb, 0x0f, 0x53, 0xc2, 0xcf, 0x20,
-	0x35, 0x30, 0x87, 0xc1, 0x12, 0x0e, 0xed, 0x80, 0x2a, 0x46, 0xe8, 0xc4, 0xf7, 0x5d, 0x5f, 0x1f,
-	0xd1, 0x01, 0xd7, 0x5a, 0x09, 0x57, 0x05, 0xbf, 0xcd, 0xd8, 0x87, 0x74, 0x10, 0xd3, 0x6a, 0xf6,
-	0x9a, 0x5a, 0x45, 0x06, 0xa8, 0x0e, 0x09, 0x5e, 0xbb, 0xfe, 0xb9, 0xce, 0x54, 0xeb, 0xdb, 0x16,
-	0xa9, 0xe5, 0x38, 0xe8, 0xa7, 0x29, 0x41, 0xbb, 0x62, 0xf8, 0x91, 0x1c, 0x8d, 0xd7, 0x9d, 0x24,
-	0x43, 0xfb, 0x3e, 0x14, 0xc4, 0x97, 0x32, 0x4f, 0xea, 0x9d, 0xb4, 0x5a, 0xed, 0x5e, 0x4f, 0x5d,
-	0x41, 0x25, 0xc8, 0xe3, 0x76, 0x1f, 0x33, 0x0f, 0x2b, 0x41, 0xfe, 0x51, 0xb3, 0xdf, 0x3c, 0x50,
-	0x33, 0xda, 0xf7, 0x60, 0xfd, 0x99, 0x61, 0x07, 0x69, 0x9c, 0x4b, 0x73, 0x41, 0x9d, 0xf4, 0x95,
-	0xd6, 0xe9, 0x24, 0xac, 0x93, 0x5e, 0x35, 0xed, 0x4b, 0x3b, 0x98, 0xb2, 0x87, 0x0a, 0x59, 0xe2,
-	0xfb, 0xd2, 0x04, 0xec, 0xaf, 0xf6, 0x1a, 0xd6, 0x7b, 0x81, 0xeb, 0xa5, 0xf2, 0xfc, 0x1f, 0xc2,
-	0x2a, 0xdb, 0x6d, 0xdc, 0x71, 0x20, 0x5d, 0xff, 0x56, 0x43, 0xec, 0x46, 0x8d, 0x70, 0x37, 0x6a,
-	0xec, 0xc9, 0xdd, 0x0a, 0x87, 0x3d, 0xd1, 0x0d, 0x28, 0x50, 0x7b, 0xe0, 0x18, 0x43, 0x19, 0x2d,
-	0x24, 0xa5, 0x21, 0xe6, 0xe4, 0xe1, 0xc4, 0xd2, 0xf1, 0x5b, 0x80, 0xf6, 0x08, 0x0d, 0x7c, 0xf7,
-	0x2a, 0x95, 0x3c, 0x5b, 0x90, 0x7f, 0xe9, 0xfa, 0xa6, 0x58, 0x88, 0x45, 0x2c, 0x08, 0xb6, 0xa8,
-	0x12, 0x20, 0x12, 0xfb, 0x63, 0x40, 0x1d, 0x87, 0xed, 0x29, 0xe9, 0x0c, 0xf1, 0x0f, 0x19, 0xd8,
-	0x4c, 0xf4, 0x97, 0xc6, 0x58, 0x7e, 0x1d, 0xb2, 0xc0, 0x34, 0xa6, 0x62, 0x1d, 0xa2, 0x23, 0x28,
-	0x88, 0x1e, 0x52, 0x93, 0x77, 0x17, 0x00, 0x12, 0xdb, 0x94, 0x84, 0x93, 0x30, 0x73, 0x9d, 0x3e,
-	0xfb, 0x6e, 0x9d, 0xfe, 0x35, 0xa8, 0xe1, 0x77, 0xd0, 0xb7, 0xda, 0xe6, 0x09, 0x6c, 0x9a, 0xee,
-	0x70, 0x48, 0x4c, 0xe6, 0x0d, 0xba, 0xed, 0x04, 0xc4, 0xbf, 0x30, 0x86, 0x6f, 0xf7, 0x1b, 0x34,
-	0x19, 0xd5, 0x91, 0x83, 0xb4, 0x17, 0xb0, 0x11, 0x9b, 0x58, 0x1a, 0xe2, 0x11, 0xe4, 0x29, 0x63,
-	0x48, 0x4b, 0x7c, 0xb2, 0xa0, 0x25, 0x28, 0x16, 0xc3, 0xb5, 0x4d, 0x01, 0xde, 0xbe, 0x20, 0x4e,
-	0xf4, 0x59, 0xda, 0x1e, 0x6c, 0xf4, 0xb8, 0x9b, 0xa6, 0xf2, 0xc3, 0x89, 0x8b, 0x67, 0x12, 0x2e,
-	0xbe, 0x05, 0x28, 0x8e, 0x22, 0x1d, 0xf1, 0x0a, 0xd6, 0xdb, 0x97, 0xc4, 0x4c, 0x85, 0x5c, 0x83,
-	0x55, 0xd3, 0x1d, 0x8d, 0x0c, 0xc7, 0xaa, 0x65, 0x6e, 0x67, 0x77, 0x4a, 0x38, 0x24, 0xe3, 0x6b,
-	0x31, 0x9b, 0x76, 0x2d, 0x6a, 0x7f, 0xa7, 0x80, 0x3a, 0x99, 0x5b, 0x2a, 0x92, 0x49, 0x1f, 0x58,
-	0x0c, 0x88, 0xcd, 0x5d, 0xc1, 0x92, 0x92, 0xfc, 0x30, 0x5c, 0x08, 0x3e, 0xf1, 0xfd, 0x58, 0x38,
-	0xca, 0x5e, 0x33, 0x1c, 0x69, 0xfb, 0xf0, 0x9d, 0x50, 0x9c, 0x5e, 0xe0, 0x13, 0x63, 0x64, 0x3b,
-	0x83, 0xce, 0xd1, 0x91, 0x47, 0x84, 0xe0, 0x08, 0x41, 0xce, 0x32, 0x02, 0x43, 0x0a, 0xc6, 0xff,
-	0xb3, 0x45, 0x6f, 0x0e, 0x5d, 0x1a, 0x2d, 0x7a, 0x4e, 0x68, 0xff, 0x99, 0x85, 0xda, 0x0c, 0x54,
-	0xa8, 0xde, 0x17, 0x90, 0xa7, 0x24, 0x18, 0x7b, 0xd2, 0x55, 0xda, 0xa9, 0x05, 0x9e, 0x8f, 0xd7,
-	0xe8, 0x31, 0x30, 0x2c, 0x30, 0xd1, 0x00, 0x8a, 0x41, 0x70, 0xa5, 0x53, 0xfb, 0xa7, 0x61, 0x42,
-	0x70, 0x70, 0x5d, 0xfc, 0x3e, 0xf1, 0x47, 0xb6, 0x63, 0x0c, 0x7b, 0xf6, 0x4f, 0x09, 0x5e, 0x0d,
-	0x82, 0x2b, 0xf6, 0x07, 0x3d, 0x67, 0x0e, 0x6f, 0xd9, 0x8e, 0x54, 0x7b, 0x6b, 0xd9, 0x59, 0x62,
-	0x0a, 0xc6, 0x02, 0xb1, 0x7e, 0x00, 0x79, 0xfe, 0x4d, 0xcb, 0x38, 0xa2, 0x0a, 0xd9, 0x20, 0xb8,
-	0xe2, 0x42, 0x15, 0x31, 0xfb, 0x5b, 0x7f, 0x00, 0x95, 0xf8, 0x17, 0x30, 0x47, 0x3a, 0x23, 0xf6,
-	0xe0, 0x4c, 0x38, 0x58, 0x1e, 0x4b, 0x8a, 0x59, 0xf2, 0xb5, 0x6d, 0xc9, 0x94, 0x35, 0x8f, 0x05,
-	0xa1, 0xfd, 0x5b, 0x06, 0x6e, 0xcd, 0xd1, 0x8c, 0x74, 0xd6, 0x17, 0x09, 0x67, 0x7d, 0x47, 0x5a,
-	0x08, 0x3d, 0xfe, 0x45, 0xc2, 0xe3, 0xdf, 0x21, 0x38, 0x5b, 0x36, 0x37, 0xa0, 0x40, 0x2e, 0xed,
-	0x80, 0x58, 0x52, 0x55, 0x92, 0x8a, 0x2d, 0xa7, 0xdc, 0x75, 0x97, 0xd3, 0x21, 0x6c, 0xb5, 0x7c,
-	0x62, 0x04, 0x44, 0x86, 0xf2, 0xd0, 0xff, 0x6f, 0x41, 0xd1, 0x18, 0x0e, 0x5d, 0x73, 0x62, 0xd6,
-	0x55, 0x4e, 0x77, 0x2c, 0x54, 0x87, 0xe2, 0x99, 0x4b, 0x03, 0xc7, 0x18, 0x11, 0x19, 0xbc, 0x22,
-	0x5a, 0xfb, 0x46, 0x81, 0xed, 0x29, 0x3c, 0x69, 0x85, 0x53, 0xa8, 0xda, 0xd4, 0x1d, 0xf2, 0x0f,
-	0xd4, 0x63, 0x27, 0xbc, 0x1f, 0x2d, 0xb6, 0xd5, 0x74, 0x42, 0x0c, 0x7e, 0xe0, 0x5b, 0xb3, 0xe3,
-	0x24, 0xf7, 0x38, 0x3e, 0xb9, 0x25, 0x57, 0x7a, 0x48, 0x6a, 0xff, 0xa8, 0xc0, 0xb6, 0xdc, 0xe1,
-	0xd3, 0x7f, 0xe8, 0xac, 0xc8, 0x99, 0x77, 0x2d, 0xb2, 0x56, 0x83, 0x1b, 0xd3, 0x72, 0xc9, 0x98,
-	0xff, 0xdf, 0x79, 0x40, 0xb3, 0xa7, 0x4b, 0xf4, 0x01, 0x54, 0x28, 0x71, 0x2c, 0x5d, 0xec, 0x17,
-	0x62, 0x2b, 0x2b, 0xe2, 0x32, 0xe3, 0x89, 0x8d, 0x83, 0xb2, 0x10, 0x48, 0x2e, 0xa5, 0xb4, 0x45,
-	0xcc, 0xff, 0xa3, 0x33, 0xa8, 0xbc, 0xa4, 0x7a, 0x34, 0x37, 0x77, 0xa8, 0x6a, 0xea, 0xb0, 0x36,
-	0x2b, 0x47, 0xe3, 0x51, 0x2f, 0xfa, 0x2e, 0x5c, 0x7e, 0x49, 0x23, 0x02, 0xfd, 0x5c, 0x81, 0x9b,
-	0x61, 0x5a, 0x31, 0x51, 0xdf, 0xc8, 0xb5, 0x08, 0xad, 0xe5, 0x6e, 0x67, 0x77, 0xaa, 0xbb, 0xc7,
-	0xd7, 0xd0, 0xdf, 0x0c, 0xf3, 0xd0, 0xb5, 0x08, 0xde, 0x76, 0xe6, 0x70, 0x29, 0x6a, 0xc0, 0xe6,
-	0x68, 0x4c, 0x03, 0x5d, 0x78, 0x81, 0x2e, 0x3b, 0xd5, 0xf2, 0x5c, 0x2f, 0x1b, 0xac, 0x29, 0xe1,
-	0xab, 0xe8, 0x1c, 0xd6, 0x46, 0xee, 0xd8, 0x09, 0x74, 0x93, 0x9f, 0x7f, 0x68, 0xad, 0xb0, 0xd0,
-	0xc1, 0x78, 0x8e, 0x96, 0x0e, 0x19, 0x9c, 0x38, 0x4d, 0x51, 0x5c, 0x19, 0xc5, 0x28, 0xf4, 0x7b,
-	0x70, 0xc3, 0xb2, 0xa9, 0x71, 0x3a, 0x24, 0xfa, 0xd0, 0x1d, 0xe8, 0x93, 0x1c, 0xa6, 0x56, 0xe4,
-	0xf2, 0x6d, 0xc9, 0xd6, 0x03, 0x77, 0xd0, 0x8a, 0xda, 0xf8, 0xa8, 0x2b, 0xc7, 0x18, 0xd9, 0xa6,
-	0xce, 0x44, 0x1e, 0xba, 0x86, 0xa5, 0x8f, 0x29, 0xf1, 0x69, 0xad, 0x24, 0x47, 0x89, 0xd6, 0x67,
-	0xb2, 0xf1, 0x84, 0xb5, 0x69, 0xf7, 0xa1, 0x1c, 0xb3, 0x17, 0x2a, 0x42, 0xae, 0x7b, 0xd4, 0x6d,
-	0xab, 0x2b, 0x08, 0xa0, 0xd0, 0xda, 0xc7, 0x47, 0x47, 0x7d, 0x71, 0xfc, 0xe8, 0x1c, 0x36, 0x1f,
-	0xb7, 0xd5, 0x0c, 0x63, 0x9f, 0x74, 0xff, 0xb0, 0xdd, 0x39, 0x50, 0xb3, 0x5a, 0x1b, 0x2a, 0xf1,
-	0xaf, 0x40, 0x08, 0xaa, 0x27, 0xdd, 0xa7, 0xdd, 0xa3, 0x67, 0x5d, 0xfd, 0xf0, 0xe8, 0xa4, 0xdb,
-	0x67, 0x87, 0x98, 0x2a, 0x40, 0xb3, 0xfb, 0x7c, 0x42, 0xaf, 0x41, 0xa9, 0x7b, 0x14, 0x92, 0x4a,
-	0x3d, 0xa3, 0x2a, 0x4f, 0x72, 0xc5, 0x55, 0xb5, 0x88, 0x2b, 0x3e, 0x19, 0xb9, 0x01, 0xd1, 0xd9,
-	0x16, 0x41, 0xb5, 0xff, 0xc8, 0xc2, 0xd6, 0x3c, 0x23, 0x23, 0x0b, 0x72, 0xcc, 0x61, 0xe4, 0xd1,
-	0xf2, 0xdd, 0xfb, 0x0b, 0x47, 0x67, 0xeb, 0xc4, 0x33, 0xe4, 0x5e, 0x52, 0xc2, 0xfc, 0x3f, 0xd2,
-	0xa1, 0x30, 0x34, 0x4e, 0xc9, 0x90, 0xd6, 0xb2, 0xfc, 0xf2, 0xe5, 0xf1, 0x75, 0xe6, 0x3e, 0xe0,
-	0x48, 0xe2, 0xe6, 0x45, 0xc2, 0xa2, 0x3e, 0x94, 0x59, 0xb4, 0xa4, 0x42, 0x9d, 0x32, 0x80, 0xef,
-	0xa6, 0x9c, 0x65, 0x7f, 0x32, 0x12, 0xc7, 0x61, 0xea, 0xf7, 0xa0, 0x1c, 0x9b, 0x6c, 0xce, 0xc5,
-	0xc9, 0x56, 0xfc, 0xe2, 0xa4, 0x14, 0xbf, 0x05, 0x79, 0x38, 0x6b, 0x03, 0xa6, 0x23, 0xe6, 0x24,
-	0xfb, 0x47, 0xbd, 0xbe, 0x38, 0xa2, 0x3e, 0xc6, 0x47, 0x27, 0xc7, 0xaa, 0xc2, 0x98, 0xfd, 0x66,
-	0xef, 0xa9, 0x9a, 0x89, 0x7c, 0x28, 0xab, 0xb5, 0xa0, 0x1c, 0x93, 0x2b, 0xb1, 0x3d, 0x28, 0xc9,
-	0xed, 0x81, 0x05, 0x68, 0xc3, 0xb2, 0x7c, 0x42, 0xa9, 0x94, 0x23, 0x24, 0xb5, 0x17, 0x50, 0xda,
-	0xeb, 0xf6, 0x24, 0x44, 0x0d, 0x56, 0x29, 0xf1, 0xd9, 0x77, 0xf3, 0x2b, 0xb0, 0x12, 0x0e, 0x49,
-	0x06, 0x4e, 0x89, 0xe1, 0x9b, 0x67, 0x84, 0xca, 0xa4, 0x22, 0xa2, 0xd9, 0x28, 0x97, 0x5f, 0x25,
-	0x09, 0xdb, 0x95, 0x70, 0x48, 0x6a, 0xff, 0x5f, 0x04, 0x98, 0x5c, 0x6b, 0xa0, 0x2a, 0x64, 0xa2,
-	0x60, 0x9f, 0xb1, 0x2d, 0xe6, 0x07, 0xb1, 0xcd, 0x8c, 0xff, 0x47, 0xbb, 0xb0, 0x3d, 0xa2, 0x03,
-	0xcf, 0x30, 0xcf, 0x75, 0x79, 0x1b, 0x21, 0x62, 0x02, 0x0f, 0x9c, 0x15, 0xbc, 0x29, 0x1b, 0xe5,
-	0x92, 0x17, 0xb8, 0x07, 0x90, 0x25, 0xce, 0x05, 0x0f, 0x72, 0xe5, 0xdd, 0xfb, 0x0b, 0x5f, 0xb7,
-	0x34, 0xda, 0xce, 0x85, 0xf0, 0x15, 0x06, 0x83, 0x74, 0x00, 0x8b, 0x5c, 0xd8, 0x26, 0xd1, 0x19,
-	0x68, 0x9e, 0x83, 0x7e, 0xb1, 0x38, 0xe8, 0x1e, 0xc7, 0x88, 0xa0, 0x4b, 0x56, 0x48, 0xa3, 0x2e,
-	0x94, 0x7c, 0x42, 0xdd, 0xb1, 0x6f, 0x12, 0x11, 0xe9, 0xd2, 0x9f, 0x88, 0x70, 0x38, 0x0e, 0x4f,
-	0x20, 0xd0, 0x1e, 0x14, 0x78, 0x80, 0xa3, 0xb5, 0x55, 0x2e, 0xec, 0x47, 0x29, 0xc1, 0x78, 0x74,
-	0xc1, 0x72, 0x2c, 0x7a, 0x0c, 0xab, 0x42, 0x44, 0x5a, 0x2b, 0x72, 0x98, 0x8f, 0xd3, 0x46, 0x5f,
-	0x3e, 0x0a, 0x87, 0xa3, 0x99, 0x55, 0x59, 0x60, 0xe4, 0x71, 0xb1, 0x84, 0xf9, 0x7f, 0xf4, 0x1e,
-	0x94, 0xc4, 0x66, 0x6f, 0xd9, 0x7e, 0x0d, 0x84, 0x73, 0x72, 0xc6, 0x9e, 0xed, 0xa3, 0xf7, 0xa1,
-	0x2c, 0x92, 0x3a, 0x9d, 0x47, 0x85, 0x32, 0x6f, 0x06, 0xc1, 0x3a, 0x66, 0xb1, 0x41, 0x74, 0x20,
-	0xbe, 0x2f, 0x3a, 0x54, 0xa2, 0x0e, 0xc4, 0xf7, 0x79, 0x87, 0xdf, 0x81, 0x75, 0x9e, 0x0a, 0x0f,
-	0x7c, 0x77, 0xec, 0xe9, 0xdc, 0xa7, 0xd6, 0x78, 0xa7, 0x35, 0xc6, 0x7e, 0xcc, 0xb8, 0x5d, 0xe6,
-	0x5c, 0xb7, 0xa0, 0xf8, 0xca, 0x3d, 0x15, 0x1d, 0xaa, 0x62, 0x1d, 0xbc, 0x72, 0x4f, 0xc3, 0xa6,
-	0x28, 0x1d, 0x59, 0x4f, 0xa6, 0x23, 0x5f, 0xc3, 0x8d, 0xd9, 0x7d, 0x95, 0xa7, 0x25, 0xea, 0xf5,
-	0xd3, 0x92, 0x2d, 0x67, 0x5e, 0x1c, 0xfe, 0x12, 0xb2, 0x96, 0x43, 0x6b, 0x1b, 0x0b, 0x39, 0x47,
-	0xb4, 0x8e, 0x31, 0x1b, 0x8c, 0xb6, 0xa1, 0xc0, 0x3e, 0xd6, 0xb6, 0x6a, 0x48, 0x84, 0x9e, 0x57,
-	0xee, 0x69, 0xc7, 0x42, 0xdf, 0x81, 0x12, 0xfb, 0x7e, 0xea, 0x19, 0x26, 0xa9, 0x6d, 0xf2, 0x96,
-	0x09, 0x83, 0x19, 0xca, 0x71, 0x2d, 0x22, 0x54, 0xb4, 0x25, 0x0c, 0xc5, 0x18, 0x5c, 0x47, 0x37,
-	0x61, 0x95, 0x37, 0xda, 0x56, 0x6d, 0x5b, 0x9c, 0x38, 0x18, 0xd9, 0xb1, 0x90, 0x06, 0x6b, 0x9e,
-	0xe1, 0x13, 0x27, 0xd0, 0xe5, 0x8c, 0x37, 0x78, 0x73, 0x59, 0x30, 0x9f, 0xb0, 0x79, 0xeb, 0x9f,
-	0x42, 0x31, 0x5c, 0x0c, 0x8b, 0x84, 0xc9, 0xfa, 0x03, 0xa8, 0x26, 0x97, 0xd2, 0x42, 0x41, 0xf6,
-	0x9f, 0x33, 0x50, 0x8a, 0x16, 0x0d, 0x72, 0x60, 0x93, 0x1b, 0x95, 0xa5, 0xa6, 0xfa, 0x64, 0x0d,
-	0x8a, 0x84, 0xf8, 0xf3, 0x94, 0x6a, 0x6e, 0x86, 0x08, 0xf2, 0x64, 0x2e, 0x17, 0x24, 0x8a, 0x90,
-	0x27, 0xf3, 0x7d, 0x05, 0xeb, 0x43, 0xdb, 0x19, 0x5f, 0xc6, 0xe6, 0x12, 0x99, 0xec, 0xef, 0xa7,
-	0x9c, 0xeb, 0x80, 0x8d, 0x9e, 0xcc, 0x51, 0x1d, 0x26, 0x68, 0xb4, 0x0f, 0x79, 0xcf, 0xf5, 0x83,
-	0x70, 0xcf, 0x4c, 0xbb, 0x9b, 0x1d, 0xbb, 0x7e, 0x70, 0x68, 0x78, 0x1e, 0x3b, 0xac, 0x09, 0x00,
-	0xed, 0x9b, 0x0c, 0xdc, 0x98, 0xff, 0x61, 0xa8, 0x0b, 0x59, 0xd3, 0x1b, 0x4b, 0x25, 0x3d, 0x58,
-	0x54, 0x49, 0x2d, 0x6f, 0x3c, 0x91, 0x9f, 0x01, 0xa1, 0x67, 0x50, 0x18, 0x91, 0x91, 0xeb, 0x5f,
-	0x49, 0x5d, 0x3c, 0x5c, 0x14, 0xf2, 0x90, 0x8f, 0x9e, 0xa0, 0x4a, 0x38, 0x84, 0xa1, 0x28, 0x17,
-	0x13, 0x95, 0x61, 0x7b, 0xc1, 0xeb, 0xb4, 0x10, 0x12, 0x47, 0x38, 0xda, 0xa7, 0xb0, 0x3d, 0xf7,
-	0x53, 0xd0, 0x6f, 0x01, 0x98, 0xde, 0x58, 0xe7, 0xcf, 0x1d, 0xc2, 0x83, 0xb2, 0xb8, 0x64, 0x7a,
-	0xe3, 0x1e, 0x67, 0x68, 0x2f, 0xa0, 0xf6, 0x26, 0x79, 0xd9, 0x1a, 0x13, 0x12, 0xeb, 0xa3, 0x53,
-	0xae, 0x83, 0x2c, 0x2e, 0x0a, 0xc6, 0xe1, 0x29, 0x5b, 0x4a, 0x61, 0xa3, 0x71, 0xc9, 0x3a, 0x64,
-	0x79, 0x87, 0xb2, 0xec, 0x60, 0x5c, 0x1e, 0x9e, 0x6a, 0xbf, 0xc8, 0xc0, 0xfa, 0x94, 0xc8, 0xec,
-	0xc8, 0x2a, 0x02, 0x70, 0x78, 0x19, 0x20, 0x28, 0x16, 0x8d, 0x4d, 0xdb, 0x0a, 0xaf, 0x91, 0xf9,
-	0x7f, 0xbe, 0x0f, 0x7b, 0xf2, 0x8a, 0x37, 0x63, 0x7b, 0x6c, 0xf9, 0x8c, 0x4e, 0xed, 0x80, 0xf2,
-	0xa4, 0x28, 0x8f, 0x05, 0x81, 0x9e, 0x43, 0xd5, 0x27, 0x7c, 0xff, 0xb7, 0x74, 0xe1, 0x65, 0xf9,
-	0x85, 0xbc, 0x4c, 0x4a, 0xc8, 0x9c, 0x0d, 0xaf, 0x85, 0x48, 0x8c, 0xa2, 0xe8, 0x19, 0xac, 0x85,
-	0xc9, 0xb4, 0x40, 0x2e, 0x2c, 0x8d, 0x5c, 0x91, 0x40, 0x1c, 0x58, 0xbb, 0x07, 0xe5, 0x58, 0x23,
-	0xfb, 0x30, 0x9e, 0xfd, 0x49, 0x9d, 0x08, 0x22, 0x19, 0x2d, 0xf2, 0x32, 0x5a, 0x68, 0xa7, 0x50,
-	0x8e, 0xad, 0x8b, 0x45, 0x86, 0x32, 0x7d, 0x06, 0x2e, 0xd7, 0x67, 0x1e, 0x67, 0x02, 0x97, 0xc5,
-	0x49, 0x96, 0x79, 0xe9, 0xb6, 0xc7, 0x35, 0x5a, 0xc2, 0x05, 0x46, 0x76, 0x3c, 0xed, 0x57, 0x19,
-	0xa8, 0x26, 0x97, 0x74, 0xe8, 0x47, 0x1e, 0xf1, 0x6d, 0xd7, 0x8a, 0xf9, 0xd1, 0x31, 0x67, 0x30,
-	0x5f, 0x61, 0xcd, 0x5f, 0x8f, 0xdd, 0xc0, 0x08, 0x7d, 0xc5, 0xf4, 0xc6, 0x7f, 0xc0, 0xe8, 0x29,
-	0x1f, 0xcc, 0x4e, 0xf9, 0x20, 0xfa, 0x08, 0x90, 0x74, 0xa5, 0xa1, 0x3d, 0xb2, 0x03, 0xfd, 0xf4,
-	0x2a, 0x20, 0xc2, 0xc6, 0x59, 0xac, 0x8a, 0x96, 0x03, 0xd6, 0xf0, 0x25, 0xe3, 0x33, 0xc7, 0x73,
-	0xdd, 0x91, 0x4e, 0x4d, 0xd7, 0x27, 0xba, 0x61, 0xbd, 0xe2, 0xa7, 0xb5, 0x2c, 0x2e, 0xbb, 0xee,
-	0xa8, 0xc7, 0x78, 0x4d, 0xeb, 0x15, 0xdb, 0x88, 0x4d, 0x6f, 0x4c, 0x49, 0xa0, 0xb3, 0x1f, 0x9e,
-	0xbb, 0x94, 0x30, 0x08, 0x56, 0xcb, 0x1b, 0x53, 0xf4, 0x5d, 0x58, 0x0b, 0x3b, 0xf0, 0xbd, 0x58,
-	0x26, 0x01, 0x15, 0xd9, 0x85, 0xf3, 0x90, 0x06, 0x95, 0x63, 0xe2, 0x9b, 0xc4, 0x09, 0xfa, 0xb6,
-	0x79, 0x4e, 0xf9, 0xb1, 0x4b, 0xc1, 0x09, 0x9e, 0x3c, 0xb5, 0x84, 0xb3, 0x8d, 0xc8, 0x88, 0x6a,
-	0xff, 0xaa, 0x40, 0x9e, 0xa7, 0x2c, 0x4c, 0x29, 0x7c, 0xbb, 0xe7, 0xd9, 0x80, 0x4c, 0x75, 0x19,
-	0x83, 0xe7, 0x02, 0xef, 0x41, 0x89, 0x2b, 0x3f, 0x76, 0xc2, 0xe0, 0x79, 0x30, 0x6f, 0xac, 0x43,
-	0xd1, 0x27, 0x86, 0xe5, 0x3a, 0xc3, 0xf0, 0x16, 0x2c, 0xa2, 0xd1, 0xef, 0x82, 0xea, 0xf9, 0xae,
-	0x67, 0x0c, 0x26, 0x07, 0x67, 0x69, 0xbe, 0xf5, 0x18, 0x9f, 0xa7, 0xe8, 0xdf, 0x85, 0x35, 0x4a,
-	0x44, 0x64, 0x17, 0x4e, 0x92, 0x17, 0x9f, 0x29, 0x99, 0xfc, 0x44, 0xa0, 0x7d, 0x0d, 0x05, 0xb1,
-	0x71, 0x5d, 0x43, 0xde, 0x8f, 0x01, 0x09, 0x45, 0x32, 0x07, 0x19, 0xd9, 0x94, 0xca, 0x2c, 0x9b,
-	0x3f, 0xe5, 0x8a, 0x96, 0xe3, 0x49, 0x83, 0xf6, 0x5f, 0x8a, 0xc8, 0xb7, 0xc5, 0x23, 0x1b, 0x4b,
-	0xcc, 0xd9, 0xaa, 0x61, 0x47, 0x5b, 0x71, 0x9b, 0x17, 0x92, 0xa8, 0x03, 0x05, 0x99, 0x56, 0x67,
-	0x96, 0x7d, 0xa3, 0x94, 0x00, 0xe1, 0xdd, 0x3e, 0x91, 0x37, 0x1b, 0x8b, 0xde, 0xed, 0x13, 0x71,
-	0xb7, 0x4f, 0xd0, 0x07, 0x50, 0x91, 0x09, 0xbf, 0x80, 0xcb, 0xf1, 0x7c, 0xbf, 0x6c, 0x45, 0x0f,
-	0x28, 0x44, 0xfb, 0x5f, 0x25, 0x8a, 0x7b, 0xe1, 0x43, 0x07, 0xfa, 0x0a, 0x8a, 0x2c, 0x84, 0xe8,
-	0x23, 0xc3, 0x93, 0xcf, 0xf6, 0xad, 0xe5, 0xde, 0x50, 0xc2, 0x5d, 0x51, 0xa4, 0xeb, 0xab, 0x9e,
-	0xa0, 0x58, 0xfc, 0x64, 0x47, 0xa5, 0x30, 0x7e, 0xb2, 0xff, 0xe8, 0x43, 0xa8, 0x1a, 0xe3, 0xc0,
-	0xd5, 0x0d, 0xeb, 0x82, 0xf8, 0x81, 0x4d, 0x89, 0xf4, 0xa5, 0x35, 0xc6, 0x6d, 0x86, 0xcc, 0xfa,
-	0x7d, 0xa8, 0xc4, 0x31, 0xdf, 0x96, 0xb7, 0xe4, 0xe3, 0x79, 0xcb, 0x9f, 0x02, 0x4c, 0x2e, 0x0d,
-	0x99, 0x8f, 0x90, 0x4b, 0x3b, 0xd0, 0xcd, 0xf0, 0x6c, 0x9e, 0xc7, 0x45, 0xc6, 0x68, 0x31, 0x67,
-	0x4c, 0xbe, 0x68, 0xe4, 0xc3, 0x17, 0x0d, 0x16, 0x1d, 0xd8, 0x82, 0x3e, 0xb7, 0x87, 0xc3, 0xe8,
-	0x22, 0xb3, 0xe4, 0xba, 0xa3, 0xa7, 0x9c, 0xa1, 0xfd, 0x3a, 0x23, 0x7c, 0x45, 0xbc, 0x4d, 0xa5,
-	0x3a, 0x9b, 0xbd, 0x2b, 0x53, 0xdf, 0x03, 0xa0, 0x81, 0xe1, 0xb3, 0x24, 0xcc, 0x08, 0xaf, 0x52,
-	0xeb, 0x33, 0x4f, 0x22, 0xfd, 0xb0, 0x58, 0x06, 0x97, 0x64, 0xef, 0x66, 0x80, 0x3e, 0x87, 0x8a,
-	0xe9, 0x8e, 0xbc, 0x21, 0x91, 0x83, 0xf3, 0x6f, 0x1d, 0x5c, 0x8e, 0xfa, 0x37, 0x83, 0xd8, 0x05,
-	0x6e, 0xe1, 0xba, 0x17, 0xb8, 0xbf, 0x52, 0xc4, 0x13, 0x5b, 0xfc, 0x85, 0x0f, 0x0d, 0xe6, 0x94,
-	0x91, 0x3c, 0x5e, 0xf2, 0xb9, 0xf0, 0xdb, 0x6a, 0x48, 0xea, 0x9f, 0xa7, 0x29, 0xda, 0x78, 0x73,
-	0x5a, 0xfc, 0xef, 0x59, 0x28, 0x45, 0xaf, 0x6b, 0x33, 0xb6, 0xff, 0x0c, 0x4a, 0x51, 0xa5, 0x92,
-	0x0c, 0x10, 0xdf, 0x6a, 0x9e, 0xa8, 0x33, 0x7a, 0x09, 0xc8, 0x18, 0x0c, 0xa2, 0x74, 0x57, 0x1f,
-	0x53, 0x63, 0x10, 0xbe, 0x6d, 0x7e, 0xb6, 0x80, 0x1e, 0xc2, 0xfd, 0xf1, 0x84, 0x8d, 0xc7, 0xaa,
-	0x31, 0x18, 0x24, 0x38, 0xe8, 0xcf, 0x60, 0x3b, 0x39, 0x87, 0x7e, 0x7a, 0xa5, 0x7b, 0xb6, 0x25,
-	0xef, 0x00, 0xf6, 0x17, 0x7d, 0x60, 0x6c, 0x24, 0xe0, 0xbf, 0xbc, 0x3a, 0xb6, 0x2d, 0xa1, 0x73,
-	0xe4, 0xcf, 0x34, 0xd4, 0xff, 0x02, 0x6e, 0xbe, 0xa1, 0xfb, 0x1c, 0x1b, 0x74, 0x93, 0x85, 0x33,
-	0xcb, 0x2b, 0x21, 0x66, 0xbd, 0x5f, 0x2a, 0xe2, 0x1d, 0x34, 0xa9, 0x93, 0x66, 0x3c, 0x4f, 0xbf,
-	0x93, 0x72, 0x9e, 0xd6, 0xf1, 0x89, 0x80, 0xe7, 0xa9, 0xf9, 0x93, 0xa9, 0xd4, 0x3c, 0x6d, 0x42,
-	0x26, 0x32, 0x5c, 0x01, 0x24, 0x11, 0xb4, 0x7f, 0xc9, 0x42, 0x31, 0x44, 0xe7, 0x27, 0xf8, 0x2b,
-	0x1a, 0x90, 0x91, 0x1e, 0x5d, 0x2f, 0x2a, 0x18, 0x04, 0x8b, 0xef, 0xa8, 0xef, 0x41, 0x69, 0x4c,
-	0x89, 0x2f, 0x9a, 0x33, 0xbc, 0xb9, 0xc8, 0x18, 0xbc, 0xf1, 0x7d, 0x28, 0x07, 0x6e, 0x60, 0x0c,
-	0xf5, 0x80, 0xe7, 0x0b, 0x59, 0x31, 0x9a, 0xb3, 0x78, 0xb6, 0x80, 0xbe, 0x0f, 0x1b, 0xc1, 0x99,
-	0xef, 0x06, 0xc1, 0x90, 0xe5, 0xaa, 0x3c, 0x73, 0x12, 0x89, 0x4e, 0x0e, 0xab, 0x51, 0x83, 0xc8,
-	0xa8, 0x28, 0x8b, 0xde, 0x93, 0xce, 0xcc, 0x75, 0x79, 0x10, 0xc9, 0xe1, 0xb5, 0x88, 0xcb, 0x5c,
-	0x9b, 0x6d, 0x9e, 0x9e, 0xc8, 0x48, 0x78, 0xac, 0x50, 0x70, 0x48, 0x22, 0x1d, 0xd6, 0x47, 0xc4,
-	0xa0, 0x63, 0x9f, 0x58, 0xfa, 0x4b, 0x9b, 0x0c, 0x2d, 0x71, 0xf1, 0x52, 0x4d, 0x7d, 0xdc, 0x08,
-	0xd5, 0xd2, 0x78, 0xc4, 0x47, 0xe3, 0x6a, 0x08, 0x27, 0x68, 0x96, 0x39, 0x88, 0x7f, 0x68, 0x1d,
-	0xca, 0xbd, 0xe7, 0xbd, 0x7e, 0xfb, 0x50, 0x3f, 0x3c, 0xda, 0x6b, 0xcb, 0xda, 0xa8, 0x5e, 0x1b,
-	0x0b, 0x52, 0x61, 0xed, 0xfd, 0xa3, 0x7e, 0xf3, 0x40, 0xef, 0x77, 0x5a, 0x4f, 0x7b, 0x6a, 0x06,
-	0x6d, 0xc3, 0x46, 0x7f, 0x1f, 0x1f, 0xf5, 0xfb, 0x07, 0xed, 0x3d, 0xfd, 0xb8, 0x8d, 0x3b, 0x47,
-	0x7b, 0x3d, 0x35, 0x8b, 0x10, 0x54, 0x27, 0xec, 0x7e, 0xe7, 0xb0, 0xad, 0xe6, 0x50, 0x19, 0x56,
-	0x8f, 0xdb, 0xb8, 0xd5, 0xee, 0xf6, 0xd5, 0xbc, 0xf6, 0x8b, 0x2c, 0x94, 0x63, 0x56, 0x64, 0x8e,
-	0xec, 0x53, 0x71, 0xae, 0xc9, 0x61, 0xf6, 0x97, 0xbf, 0xe5, 0x1a, 0xe6, 0x99, 0xb0, 0x4e, 0x0e,
-	0x0b, 0x82, 0x9f, 0x65, 0x8c, 0xcb, 0xd8, 0x3a, 0xcf, 0xe1, 0xe2, 0xc8, 0xb8, 0x14, 0x20, 0x1f,
-	0x40, 0xe5, 0x9c, 0xf8, 0x0e, 0x19, 0xca, 0x76, 0x61, 0x91, 0xb2, 0xe0, 0x89, 0x2e, 0x3b, 0xa0,
-	0xca, 0x2e, 0x13, 0x18, 0x61, 0x8e, 0xaa, 0xe0, 0x1f, 0x86, 0x60, 0x5b, 0x90, 0x17, 0xcd, 0xab,
-	0x62, 0x7e, 0x4e, 0xb0, 0x6d, 0x8a, 0xbe, 0x36, 0x3c, 0x9e, 0x43, 0xe6, 0x30, 0xff, 0x8f, 0x4e,
-	0x67, 0xed, 0x53, 0xe0, 0xf6, 0xb9, 0xb7, 0xb8, 0x3b, 0xbf, 0xc9, 0x44, 0x67, 0x91, 0x89, 0x56,
-	0x21, 0x8b, 0xc3, 0x82, 0xa2, 0x56, 0xb3, 0xb5, 0xcf, 0xcc, 0xb2, 0x06, 0xa5, 0xc3, 0xe6, 0x4f,
-	0xf4, 0x93, 0x9e, 0xb8, 0xd5, 0x57, 0xa1, 0xf2, 0xb4, 0x8d, 0xbb, 0xed, 0x03, 0xc9, 0xc9, 0xa2,
-	0x2d, 0x50, 0x25, 0x67, 0xd2, 0x2f, 0xc7, 0x10, 0xc4, 0xdf, 0x3c, 0x2a, 0x42, 0xae, 0xf7, 0xac,
-	0x79, 0xac, 0x16, 0xb4, 0xff, 0xc9, 0xc0, 0xba, 0xd8, 0x16, 0xa2, 0xd2, 0x87, 0x37, 0x3f, 0xfd,
-	0xc6, 0x6f, 0xb1, 0x32, 0xc9, 0x5b, 0xac, 0x30, 0x09, 0xe5, 0xbb, 0x7a, 0x76, 0x92, 0x84, 0xf2,
-	0x9b, 0x9d, 0x44, 0xc4, 0xcf, 0x2d, 0x12, 0xf1, 0x6b, 0xb0, 0x3a, 0x22, 0x34, 0xb2, 0x5b, 0x09,
-	0x87, 0x24, 0xb2, 0xa1, 0x6c, 0x38, 0x8e, 0x1b, 0x18, 0xe2, 0x6a, 0xb8, 0xb0, 0xd0, 0x66, 0x38,
-	0xf5, 0xc5, 0x8d, 0xe6, 0x04, 0x49, 0x04, 0xe6, 0x38, 0x76, 0xfd, 0xc7, 0xa0, 0x4e, 0x77, 0x58,
-	0x64, 0x3b, 0xfc, 0xde, 0x0f, 0x26, 0xbb, 0x21, 0x61, 0xeb, 0x42, 0xbe, 0xb3, 0xa8, 0x2b, 0x8c,
-	0xc0, 0x27, 0xdd, 0x6e, 0xa7, 0xfb, 0x58, 0x55, 0x10, 0x40, 0xa1, 0xfd, 0x93, 0x4e, 0xbf, 0xbd,
-	0xa7, 0x66, 0x76, 0x7f, 0xb9, 0x01, 0x05, 0x21, 0x24, 0xfa, 0x46, 0x66, 0x02, 0xf1, 0xb2, 0x5a,
-	0xf4, 0xe3, 0x85, 0x33, 0xea, 0x44, 0xa9, 0x6e, 0xfd, 0xe1, 0xd2, 0xe3, 0xe5, 0x33, 0xe6, 0x0a,
-	0xfa, 0x1b, 0x05, 0x2a, 0x89, 0x27, 0xcc, 0xb4, 0x57, 0xe3, 0x73, 0xaa, 0x78, 0xeb, 0x3f, 0x5a,
-	0x6a, 0x6c, 0x24, 0xcb, 0xcf, 0x15, 0x28, 0xc7, 0xea, 0x57, 0xd1, 0xbd, 0x65, 0x6a, 0x5e, 0x85,
-	0x24, 0xf7, 0x97, 0x2f, 0x97, 0xd5, 0x56, 0x3e, 0x51, 0xd0, 0x5f, 0x2b, 0x50, 0x8e, 0x55, 0x72,
-	0xa6, 0x16, 0x65, 0xb6, 0xee, 0x34, 0xb5, 0x28, 0xf3, 0x0a, 0x47, 0x57, 0xd0, 0x5f, 0x2a, 0x50,
-	0x8a, 0xaa, 0x32, 0xd1, 0xdd, 0xc5, 0xeb, 0x38, 0x85, 0x10, 0x9f, 0x2d, 0x5b, 0x00, 0xaa, 0xad,
-	0xa0, 0x3f, 0x87, 0x62, 0x58, 0xc2, 0x88, 0xd2, 0xee, 0x5e, 0x53, 0xf5, 0x91, 0xf5, 0xbb, 0x0b,
-	0x8f, 0x8b, 0x4f, 0x1f, 0xd6, 0x15, 0xa6, 0x9e, 0x7e, 0xaa, 0x02, 0xb2, 0x7e, 0x77, 0xe1, 0x71,
-	0xd1, 0xf4, 0xcc, 0x13, 0x62, 0xe5, 0x87, 0xa9, 0x3d, 0x61, 0xb6, 0xee, 0x31, 0xb5, 0x27, 0xcc,
-	0xab, 0x76, 0x14, 0x82, 0xc4, 0x0a, 0x18, 0x53, 0x0b, 0x32, 0x5b, 0x24, 0x99, 0x5a, 0x90, 0x39,
-	0xf5, 0x92, 0xda, 0x0a, 0xfa, 0x99, 0x12, 0x3f, 0x17, 0xdc, 0x5d, 0xb8, 0x4e, 0x6f, 0x41, 0x97,
-	0x9c, 0xa9, 0x14, 0xe4, 0x0b, 0xf4, 0x67, 0xf2, 0x16, 0x43, 0x94, 0xf9, 0xa1, 0x45, 0xc0, 0x12,
-	0x95, 0x81, 0xf5, 0x4f, 0x97, 0xdb, 0x6c, 0xb8, 0x10, 0x7f, 0xa5, 0x00, 0x4c, 0x0a, 0x02, 0x53,
-	0x0b, 0x31, 0x53, 0x89, 0x58, 0xbf, 0xb7, 0xc4, 0xc8, 0xf8, 0x02, 0x09, 0x0b, 0x96, 0x52, 0x2f,
-	0x90, 0xa9, 0x82, 0xc5, 0xd4, 0x0b, 0x64, 0xba, 0xd8, 0x50, 0x5b, 0x41, 0xff, 0xa4, 0xc0, 0xc6,
-	0x4c, 0xc1, 0x14, 0x7a, 0x78, 0xcd, 0x9a, 0xb9, 0xfa, 0x17, 0xcb, 0x03, 0x84, 0xa2, 0xed, 0x28,
-	0x9f, 0x28, 0xe8, 0x6f, 0x15, 0x58, 0x4b, 0x16, 0x92, 0xa4, 0xde, 0xa5, 0xe6, 0x94, 0x5e, 0xd5,
-	0x1f, 0x2c, 0x37, 0x38, 0xd2, 0xd6, 0xdf, 0x2b, 0x50, 0x4d, 0xd6, 0x14, 0xa1, 0x07, 0x8b, 0x85,
-	0x85, 0x29, 0x81, 0x3e, 0x5f, 0x72, 0x74, 0x28, 0xd1, 0x97, 0xab, 0x7f, 0x94, 0x17, 0xd9, 0x5b,
-	0x81, 0xff, 0xfc, 0xf0, 0x37, 0x01, 0x00, 0x00, 0xff, 0xff, 0x4f, 0xda, 0xef, 0xe8, 0xfd, 0x34,
-	0x00, 0x00,
+	// 4199 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x5a, 0x5f, 0x73, 0x1b, 0x4b,
+	0x56, 0xcf, 0x68, 0x24, 0x59, 0x3a, 0xb2, 0xe5, 0x71, 0xdb, 0x4e, 0x14, 0xdd, 0xdd, 0xbd, 0xd9,
+	0xd9, 0xba, 0x54, 0xd8, 0xbd, 0x57, 0xb9, 0xeb, 0x85, 0x9b, 0x9b, 0x6c, 0xb2, 0xb9, 0x8a, 0xac,
+	0xc4, 0x4a, 0x6c, 0xd9, 0xb4, 0x64, 0xb2, 0x21, 0x70, 0x87, 0xf1, 0x4c, 0x47, 0x9e, 0x58, 0x9a,
+	0x99, 0x3b, 0x3d, 0x4a, 0xec, 0xa5, 0x28, 0xa8, 0xa5, 0xa0, 0x96, 0x2a, 0x28, 0x78, 0x59, 0x96,
+	0x07, 0x8a, 0x07, 0xaa, 0x78, 0xa2, 0x78, 0xa7, 0x96, 0xda, 0xa7, 0x7d, 0xe0, 0x4b, 0x50, 0x54,
+	0xc1, 0x13, 0xaf, 0x7c, 0x02, 0xa8, 0xfe, 0x33, 0xa3, 0x19, 0x49, 0xd9, 0x8c, 0xe4, 0xf0, 0x24,
+	0x9d, 0xd3, 0xdd, 0xbf, 0x3e, 0x73, 0xfa, 0xf4, 0xe9, 0xd3, 0xa7, 0x0f, 0xe8, 0xfe, 0x70, 0x3c,
+	0x70, 0x5c, 0x7a, 0xcb, 0x0e, 0x9c, 0xd7, 0x24, 0xa0, 0xb7, 0xfc, 0xc0, 0x0b, 0x3d, 0x49, 0x35,
+	0x38, 0x81, 0x3e, 0x3a, 0x35, 0xe9, 0xa9, 0x63, 0x79, 0x81, 0xdf, 0x70, 0xbd, 0x91, 0x69, 0x37,
+	0xe4, 0x98, 0x86, 0x1c, 0x23, 0xba, 0xd5, 0xbf, 0x31, 0xf0, 0xbc, 0xc1, 0x90, 0x08, 0x84, 0x93,
+	0xf1, 0xcb, 0x5b, 0xf6, 0x38, 0x30, 0x43, 0xc7, 0x73, 0x65, 0xfb, 0x87, 0xd3, 0xed, 0xa1, 0x33,
+	0x22, 0x34, 0x34, 0x47, 0xbe, 0xec, 0xf0, 0x51, 0x24, 0x0b, 0x3d, 0x35, 0x03, 0x62, 0xdf, 0x3a,
+	0xb5, 0x86, 0xd4, 0x27, 0x16, 0xfb, 0x35, 0xd8, 0x1f, 0xd9, 0xed, 0xe3, 0xa9, 0x6e, 0x34, 0x0c,
+	0xc6, 0x56, 0x18, 0x49, 0x6e, 0x86, 0x61, 0xe0, 0x9c, 0x8c, 0x43, 0x22, 0x7a, 0xeb, 0xd7, 0xe1,
+	0x5a, 0xdf, 0xa4, 0x67, 0x2d, 0xcf, 0x7d, 0xe9, 0x0c, 0x7a, 0xd6, 0x29, 0x19, 0x99, 0x98, 0x7c,
+	0x35, 0x26, 0x34, 0xd4, 0x7f, 0x17, 0x6a, 0xb3, 0x4d, 0xd4, 0xf7, 0x5c, 0x4a, 0xd0, 0x17, 0x90,
+	0x67, 0x53, 0xd6, 0x94, 0x1b, 0xca, 0xcd, 0xca, 0xce, 0xc7, 0x8d, 0xb7, 0xa9, 0x40, 0xc8, 0xd0,
+	0x90, 0xa2, 0x36, 0x7a, 0x3e, 0xb1, 0x30, 0x1f, 0xa9, 0x6f, 0xc3, 0x66, 0xcb, 0xf4, 0xcd, 0x13,
+	0x67, 0xe8, 0x84, 0x0e, 0xa1, 0xd1, 0xa4, 0x63, 0xd8, 0x4a, 0xb3, 0xe5, 0x84, 0xbf, 0x07, 0xab,
+	0x56, 0x82, 0x2f, 0x27, 0xbe, 0xd3, 0xc8, 0xa4, 0xfb, 0xc6, 0x2e, 0xa7, 0x52, 0xc0, 0x29, 0x38,
+	0x7d, 0x0b, 0xd0, 0x23, 0xc7, 0x1d, 0x90, 0xc0, 0x0f, 0x1c, 0x37, 0x8c, 0x84, 0xf9, 0x85, 0x0a,
+	0x9b, 0x29, 0xb6, 0x14, 0xe6, 0x15, 0x40, 0xac, 0x47, 0x26, 0x8a, 0x7a, 0xb3, 0xb2, 0xf3, 0x24,
+	0xa3, 0x28, 0x73, 0xf0, 0x1a, 0xcd, 0x18, 0xac, 0xed, 0x86, 0xc1, 0x05, 0x4e, 0xa0, 0xa3, 0x2f,
+	0xa1, 0x78, 0x4a, 0xcc, 0x61, 0x78, 0x5a, 0xcb, 0xdd, 0x50, 0x6e, 0x56, 0x77, 0x1e, 0x5d, 0x62,
+	0x9e, 0x3d, 0x0e, 0xd4, 0x0b, 0xcd, 0x90, 0x60, 0x89, 0x8a, 0x3e, 0x01, 0x24, 0xfe, 0x19, 0x36,
+	0xa1, 0x56, 0xe0, 0xf8, 0xcc, 0x24, 0x6b, 0xea, 0x0d, 0xe5, 0x66, 0x19, 0x6f, 0x88, 0x96, 0xdd,
+	0x49, 0x43, 0xdd, 0x87, 0xf5, 0x29, 0x69, 0x91, 0x06, 0xea, 0x19, 0xb9, 0xe0, 0x2b, 0x52, 0xc6,
+	0xec, 0x2f, 0x7a, 0x0c, 0x85, 0xd7, 0xe6, 0x70, 0x4c, 0xb8, 0xc8, 0x95, 0x9d, 0xef, 0xbe, 0xcb,
+	0x3c, 0xa4, 0x89, 0x4e, 0xf4, 0x80, 0xc5, 0xf8, 0xbb, 0xb9, 0xcf, 0x15, 0xfd, 0x0e, 0x54, 0x12,
+	0x72, 0xa3, 0x2a, 0xc0, 0x71, 0x77, 0xb7, 0xdd, 0x6f, 0xb7, 0xfa, 0xed, 0x5d, 0xed, 0x0a, 0x5a,
+	0x83, 0xf2, 0x71, 0x77, 0xaf, 0xdd, 0xdc, 0xef, 0xef, 0x3d, 0xd7, 0x14, 0x54, 0x81, 0x95, 0x88,
+	0xc8, 0xe9, 0xe7, 0x80, 0x30, 0xb1, 0xbc, 0xd7, 0x24, 0x60, 0x86, 0x2c, 0x57, 0x15, 0x5d, 0x83,
+	0x95, 0xd0, 0xa4, 0x67, 0x86, 0x63, 0x4b, 0x99, 0x8b, 0x8c, 0xec, 0xd8, 0xa8, 0x03, 0xc5, 0x53,
+	0xd3, 0xb5, 0x87, 0xef, 0x96, 0x3b, 0xad, 0x6a, 0x06, 0xbe, 0xc7, 0x07, 0x62, 0x09, 0xc0, 0xac,
+	0x3b, 0x35, 0xb3, 0x58, 0x00, 0xfd, 0x39, 0x68, 0xbd, 0xd0, 0x0c, 0xc2, 0xa4, 0x38, 0x6d, 0xc8,
+	0xb3, 0xf9, 0x6b, 0xca, 0xc2, 0x73, 0x8a, 0x9d, 0x89, 0xf9, 0x70, 0xfd, 0x7f, 0x72, 0xb0, 0x91,
+	0xc0, 0x96, 0x96, 0xfa, 0x0c, 0x8a, 0x01, 0xa1, 0xe3, 0x61, 0xc8, 0xe1, 0xab, 0x3b, 0x0f, 0x32,
+	0xc2, 0xcf, 0x20, 0x35, 0x30, 0x87, 0xc1, 0x12, 0x0e, 0xdd, 0x04, 0x4d, 0x8c, 0x30, 0x48, 0x10,
+	0x78, 0x81, 0x31, 0xa2, 0x03, 0xae, 0xb5, 0x32, 0xae, 0x0a, 0x7e, 0x9b, 0xb1, 0x0f, 0xe8, 0x20,
+	0xa1, 0x55, 0xf5, 0x92, 0x5a, 0x45, 0x26, 0x68, 0x2e, 0x09, 0xdf, 0x78, 0xc1, 0x99, 0xc1, 0x54,
+	0x1b, 0x38, 0x36, 0xa9, 0xe5, 0x39, 0xe8, 0x67, 0x19, 0x41, 0xbb, 0x62, 0xf8, 0xa1, 0x1c, 0x8d,
+	0xd7, 0xdd, 0x34, 0x43, 0xff, 0x0e, 0x14, 0xc5, 0x97, 0x32, 0x4b, 0xea, 0x1d, 0xb7, 0x5a, 0xed,
+	0x5e, 0x4f, 0xbb, 0x82, 0xca, 0x50, 0xc0, 0xed, 0x3e, 0x66, 0x16, 0x56, 0x86, 0xc2, 0xa3, 0x66,
+	0xbf, 0xb9, 0xaf, 0xe5, 0xf4, 0x6f, 0xc3, 0xfa, 0x33, 0xd3, 0x09, 0xb3, 0x18, 0x97, 0xee, 0x81,
+	0x36, 0xe9, 0x2b, 0x57, 0xa7, 0x93, 0x5a, 0x9d, 0xec, 0xaa, 0x69, 0x9f, 0x3b, 0xe1, 0xd4, 0x7a,
+	0x68, 0xa0, 0x92, 0x20, 0x90, 0x4b, 0xc0, 0xfe, 0xea, 0x6f, 0x60, 0xbd, 0x17, 0x7a, 0x7e, 0x26,
+	0xcb, 0xff, 0x1e, 0xac, 0xb0, 0xd3, 0xc6, 0x1b, 0x87, 0xd2, 0xf4, 0xaf, 0x37, 0xc4, 0x69, 0xd4,
+	0x88, 0x4e, 0xa3, 0xc6, 0xae, 0x3c, 0xad, 0x70, 0xd4, 0x13, 0x5d, 0x85, 0x22, 0x75, 0x06, 0xae,
+	0x39, 0x94, 0xde, 0x42, 0x52, 0x3a, 0x02, 0x6d, 0x32, 0xb1, 0x34, 0xfc, 0x16, 0xa0, 0x5d, 0x42,
+	0xc3, 0xc0, 0xbb, 0xc8, 0x24, 0xcf, 0x16, 0x14, 0x5e, 0x7a, 0x81, 0x25, 0x36, 0x62, 0x09, 0x0b,
+	0x82, 0x6d, 0xaa, 0x14, 0x88, 0xc4, 0xfe, 0x04, 0x50, 0xc7, 0x65, 0x67, 0x4a, 0xb6, 0x85, 0xf8,
+	0xeb, 0x1c, 0x6c, 0xa6, 0xfa, 0xcb, 0xc5, 0x58, 0x7e, 0x1f, 0x32, 0xc7, 0x34, 0xa6, 0x62, 0x1f,
+	0xa2, 0x43, 0x28, 0x8a, 0x1e, 0x52, 0x93, 0xb7, 0x17, 0x00, 0x12, 0xc7, 0x94, 0x84, 0x93, 0x30,
+	0x73, 0x8d, 0x5e, 0x7d, 0xbf, 0x46, 0xff, 0x06, 0xb4, 0xe8, 0x3b, 0xe8, 0x3b, 0xd7, 0xe6, 0x09,
+	0x6c, 0x5a, 0xde, 0x70, 0x48, 0x2c, 0x66, 0x0d, 0x86, 0xe3, 0x86, 0x24, 0x78, 0x6d, 0x0e, 0xdf,
+	0x6d, 0x37, 0x68, 0x32, 0xaa, 0x23, 0x07, 0xe9, 0x2f, 0x60, 0x23, 0x31, 0xb1, 0x5c, 0x88, 0x47,
+	0x50, 0xa0, 0x8c, 0x21, 0x57, 0xe2, 0xd3, 0x05, 0x57, 0x82, 0x62, 0x31, 0x5c, 0xdf, 0x14, 0xe0,
+	0xed, 0xd7, 0xc4, 0x8d, 0x3f, 0x4b, 0xdf, 0x85, 0x8d, 0x1e, 0x37, 0xd3, 0x4c, 0x76, 0x38, 0x31,
+	0xf1, 0x5c, 0xca, 0xc4, 0xb7, 0x00, 0x25, 0x51, 0xa4, 0x21, 0x5e, 0xc0, 0x7a, 0xfb, 0x9c, 0x58,
+	0x99, 0x90, 0x6b, 0xb0, 0x62, 0x79, 0xa3, 0x91, 0xe9, 0xda, 0xb5, 0xdc, 0x0d, 0xf5, 0x66, 0x19,
+	0x47, 0x64, 0x72, 0x2f, 0xaa, 0x59, 0xf7, 0xa2, 0xfe, 0x97, 0x0a, 0x68, 0x93, 0xb9, 0xa5, 0x22,
+	0x99, 0xf4, 0xa1, 0xcd, 0x80, 0xd8, 0xdc, 0xab, 0x58, 0x52, 0x92, 0x1f, 0xb9, 0x0b, 0xc1, 0x27,
+	0x41, 0x90, 0x70, 0x47, 0xea, 0x25, 0xdd, 0x91, 0xbe, 0x07, 0x5f, 0x8b, 0xc4, 0xe9, 0x85, 0x01,
+	0x31, 0x47, 0x8e, 0x3b, 0xe8, 0x1c, 0x1e, 0xfa, 0x44, 0x08, 0x8e, 0x10, 0xe4, 0x6d, 0x33, 0x34,
+	0xa5, 0x60, 0xfc, 0x3f, 0xdb, 0xf4, 0xd6, 0xd0, 0xa3, 0xf1, 0xa6, 0xe7, 0x84, 0xfe, 0x6f, 0x2a,
+	0xd4, 0x66, 0xa0, 0x22, 0xf5, 0xbe, 0x80, 0x02, 0x25, 0xe1, 0xd8, 0x97, 0xa6, 0xd2, 0xce, 0x2c,
+	0xf0, 0x7c, 0xbc, 0x46, 0x8f, 0x81, 0x61, 0x81, 0x89, 0x06, 0x50, 0x0a, 0xc3, 0x0b, 0x83, 0x3a,
+	0x3f, 0x8a, 0x02, 0x82, 0xfd, 0xcb, 0xe2, 0xf7, 0x49, 0x30, 0x72, 0x5c, 0x73, 0xd8, 0x73, 0x7e,
+	0x44, 0xf0, 0x4a, 0x18, 0x5e, 0xb0, 0x3f, 0xe8, 0x39, 0x33, 0x78, 0xdb, 0x71, 0xa5, 0xda, 0x5b,
+	0xcb, 0xce, 0x92, 0x50, 0x30, 0x16, 0x88, 0xf5, 0x7d, 0x28, 0xf0, 0x6f, 0x5a, 0xc6, 0x10, 0x35,
+	0x50, 0xc3, 0xf0, 0x82, 0x0b, 0x55, 0xc2, 0xec, 0x6f, 0xfd, 0x1e, 0xac, 0x26, 0xbf, 0x80, 0x19,
+	0xd2, 0x29, 0x71, 0x06, 0xa7, 0xc2, 0xc0, 0x0a, 0x58, 0x52, 0x6c, 0x25, 0xdf, 0x38, 0xb6, 0x0c,
+	0x59, 0x0b, 0x58, 0x10, 0xfa, 0xbf, 0xe4, 0xe0, 0xfa, 0x1c, 0xcd, 0x48, 0x63, 0x7d, 0x91, 0x32,
+	0xd6, 0xf7, 0xa4, 0x85, 0xc8, 0xe2, 0x5f, 0xa4, 0x2c, 0xfe, 0x3d, 0x82, 0xb3, 0x6d, 0x73, 0x15,
+	0x8a, 0xe4, 0xdc, 0x09, 0x89, 0x2d, 0x55, 0x25, 0xa9, 0xc4, 0x76, 0xca, 0x5f, 0x76, 0x3b, 0x1d,
+	0xc0, 0x56, 0x2b, 0x20, 0x66, 0x48, 0xa4, 0x2b, 0x8f, 0xec, 0xff, 0x3a, 0x94, 0xcc, 0xe1, 0xd0,
+	0xb3, 0x26, 0xcb, 0xba, 0xc2, 0xe9, 0x8e, 0x8d, 0xea, 0x50, 0x3a, 0xf5, 0x68, 0xe8, 0x9a, 0x23,
+	0x22, 0x9d, 0x57, 0x4c, 0xeb, 0x3f, 0x55, 0x60, 0x7b, 0x0a, 0x4f, 0xae, 0xc2, 0x09, 0x54, 0x1d,
+	0xea, 0x0d, 0xf9, 0x07, 0x1a, 0x89, 0x1b, 0xde, 0xf7, 0x17, 0x3b, 0x6a, 0x3a, 0x11, 0x06, 0xbf,
+	0xf0, 0xad, 0x39, 0x49, 0x92, 0x5b, 0x1c, 0x9f, 0xdc, 0x96, 0x3b, 0x3d, 0x22, 0xf5, 0xbf, 0x51,
+	0x60, 0x5b, 0x9e, 0xf0, 0xd9, 0x3f, 0x74, 0x56, 0xe4, 0xdc, 0xfb, 0x16, 0x59, 0xaf, 0xc1, 0xd5,
+	0x69, 0xb9, 0xa4, 0xcf, 0xff, 0xdb, 0x22, 0xa0, 0xd9, 0xdb, 0x25, 0xfa, 0x26, 0xac, 0x52, 0xe2,
+	0xda, 0x86, 0x38, 0x2f, 0xc4, 0x51, 0x56, 0xc2, 0x15, 0xc6, 0x13, 0x07, 0x07, 0x65, 0x2e, 0x90,
+	0x9c, 0x4b, 0x69, 0x4b, 0x98, 0xff, 0x47, 0xa7, 0xb0, 0xfa, 0x92, 0x1a, 0xf1, 0xdc, 0xdc, 0xa0,
+	0xaa, 0x99, 0xdd, 0xda, 0xac, 0x1c, 0x8d, 0x47, 0xbd, 0xf8, 0xbb, 0x70, 0xe5, 0x25, 0x8d, 0x09,
+	0xf4, 0x13, 0x05, 0xae, 0x45, 0x61, 0xc5, 0x44, 0x7d, 0x23, 0xcf, 0x26, 0xb4, 0x96, 0xbf, 0xa1,
+	0xde, 0xac, 0xee, 0x1c, 0x5d, 0x42, 0x7f, 0x33, 0xcc, 0x03, 0xcf, 0x26, 0x78, 0xdb, 0x9d, 0xc3,
+	0xa5, 0xa8, 0x01, 0x9b, 0xa3, 0x31, 0x0d, 0x0d, 0x61, 0x05, 0x86, 0xec, 0x54, 0x2b, 0x70, 0xbd,
+	0x6c, 0xb0, 0xa6, 0x94, 0xad, 0xa2, 0x33, 0x58, 0x1b, 0x79, 0x63, 0x37, 0x34, 0x2c, 0x7e, 0xff,
+	0xa1, 0xb5, 0xe2, 0x42, 0x17, 0xe3, 0x39, 0x5a, 0x3a, 0x60, 0x70, 0xe2, 0x36, 0x45, 0xf1, 0xea,
+	0x28, 0x41, 0xa1, 0xdf, 0x80, 0xab, 0xb6, 0x43, 0xcd, 0x93, 0x21, 0x31, 0x86, 0xde, 0xc0, 0x98,
+	0xc4, 0x30, 0xb5, 0x12, 0x97, 0x6f, 0x4b, 0xb6, 0xee, 0x7b, 0x83, 0x56, 0xdc, 0xc6, 0x47, 0x5d,
+	0xb8, 0xe6, 0xc8, 0xb1, 0x0c, 0x26, 0xf2, 0xd0, 0x33, 0x6d, 0x63, 0x4c, 0x49, 0x40, 0x6b, 0x65,
+	0x39, 0x4a, 0xb4, 0x3e, 0x93, 0x8d, 0xc7, 0xac, 0x0d, 0x7d, 0x03, 0xc0, 0x3a, 0x25, 0xd6, 0x99,
+	0xef, 0x39, 0x6e, 0x58, 0x03, 0xde, 0x33, 0xc1, 0x61, 0x8e, 0x26, 0x20, 0xfc, 0x38, 0xaa, 0x08,
+	0x47, 0x23, 0x28, 0xfd, 0x2e, 0x54, 0x12, 0xeb, 0x8c, 0x4a, 0x90, 0xef, 0x1e, 0x76, 0xdb, 0xda,
+	0x15, 0x04, 0x50, 0x6c, 0xed, 0xe1, 0xc3, 0xc3, 0xbe, 0xb8, 0xb6, 0x74, 0x0e, 0x9a, 0x8f, 0xdb,
+	0x5a, 0x8e, 0xb1, 0x8f, 0xbb, 0xbf, 0xdd, 0xee, 0xec, 0x6b, 0xaa, 0xde, 0x86, 0xd5, 0xe4, 0xd7,
+	0x23, 0x04, 0xd5, 0xe3, 0xee, 0xd3, 0xee, 0xe1, 0xb3, 0xae, 0x71, 0x70, 0x78, 0xdc, 0xed, 0xb3,
+	0xcb, 0x4f, 0x15, 0xa0, 0xd9, 0x7d, 0x3e, 0xa1, 0xd7, 0xa0, 0xdc, 0x3d, 0x8c, 0x48, 0xa5, 0x9e,
+	0xd3, 0x94, 0x27, 0xf9, 0xd2, 0x8a, 0x56, 0xc2, 0xab, 0x01, 0x19, 0x79, 0x21, 0x31, 0xd8, 0xd1,
+	0x42, 0xf5, 0x5f, 0xaa, 0xb0, 0x35, 0xcf, 0x38, 0x90, 0x0d, 0x79, 0x66, 0x68, 0xf2, 0x4a, 0xfa,
+	0xfe, 0xed, 0x8c, 0xa3, 0xb3, 0xfd, 0xe5, 0x9b, 0xf2, 0x0c, 0x2a, 0x63, 0xfe, 0x1f, 0x19, 0x50,
+	0x1c, 0x9a, 0x27, 0x64, 0x48, 0x6b, 0x2a, 0x4f, 0xda, 0x3c, 0xbe, 0xcc, 0xdc, 0xfb, 0x1c, 0x49,
+	0x64, 0x6c, 0x24, 0x2c, 0xea, 0x43, 0x85, 0x79, 0x59, 0x2a, 0xd4, 0x29, 0x1d, 0xff, 0x4e, 0xc6,
+	0x59, 0xf6, 0x26, 0x23, 0x71, 0x12, 0xa6, 0x7e, 0x07, 0x2a, 0x89, 0xc9, 0xe6, 0x24, 0x5c, 0xb6,
+	0x92, 0x09, 0x97, 0x72, 0x32, 0x7b, 0xf2, 0x00, 0xb6, 0xe6, 0xe9, 0x88, 0x19, 0xc9, 0xde, 0x61,
+	0xaf, 0x2f, 0xae, 0xb6, 0x8f, 0xf1, 0xe1, 0xf1, 0x91, 0xa6, 0x30, 0x66, 0xbf, 0xd9, 0x7b, 0xaa,
+	0xe5, 0x62, 0x1b, 0x52, 0xf5, 0x16, 0x54, 0x12, 0x72, 0xa5, 0x8e, 0x15, 0x25, 0x7d, 0xac, 0x30,
+	0xc7, 0x6e, 0xda, 0x76, 0x40, 0x28, 0x95, 0x72, 0x44, 0xa4, 0xfe, 0x02, 0xca, 0xbb, 0xdd, 0x9e,
+	0x84, 0xa8, 0xc1, 0x0a, 0x25, 0x01, 0xfb, 0x6e, 0x9e, 0x3a, 0x2b, 0xe3, 0x88, 0x64, 0xe0, 0x94,
+	0x98, 0x81, 0x75, 0x4a, 0xa8, 0x0c, 0x46, 0x62, 0x9a, 0x8d, 0xf2, 0x78, 0x0a, 0x4a, 0xac, 0x5d,
+	0x19, 0x47, 0xa4, 0xfe, 0xbf, 0x25, 0x80, 0x49, 0x3a, 0x04, 0x55, 0x21, 0x17, 0x1f, 0x12, 0x39,
+	0xc7, 0x66, 0x76, 0x90, 0x38, 0x04, 0xf9, 0x7f, 0xb4, 0x03, 0xdb, 0x23, 0x3a, 0xf0, 0x4d, 0xeb,
+	0xcc, 0x90, 0x59, 0x0c, 0xe1, 0x4b, 0xb8, 0xc3, 0x5d, 0xc5, 0x9b, 0xb2, 0x51, 0xba, 0x0a, 0x81,
+	0xbb, 0x0f, 0x2a, 0x71, 0x5f, 0x73, 0xe7, 0x58, 0xd9, 0xb9, 0xbb, 0x70, 0x9a, 0xa6, 0xd1, 0x76,
+	0x5f, 0x0b, 0x5b, 0x61, 0x30, 0xc8, 0x00, 0xb0, 0xc9, 0x6b, 0xc7, 0x22, 0x06, 0x03, 0x2d, 0x70,
+	0xd0, 0x2f, 0x16, 0x07, 0xdd, 0xe5, 0x18, 0x31, 0x74, 0xd9, 0x8e, 0x68, 0xd4, 0x85, 0x72, 0x40,
+	0xa8, 0x37, 0x0e, 0x2c, 0x22, 0x3c, 0x64, 0xf6, 0x9b, 0x14, 0x8e, 0xc6, 0xe1, 0x09, 0x04, 0xda,
+	0x85, 0x22, 0x77, 0x8c, 0xb4, 0xb6, 0x72, 0x43, 0xfd, 0x95, 0x39, 0xdf, 0x34, 0x18, 0xf7, 0x2e,
+	0x58, 0x8e, 0x45, 0x8f, 0x61, 0x45, 0x88, 0x48, 0x6b, 0x25, 0x0e, 0xf3, 0x49, 0x56, 0xaf, 0xcd,
+	0x47, 0xe1, 0x68, 0x34, 0x5b, 0x55, 0xe6, 0x50, 0xb9, 0x3f, 0x2d, 0x63, 0xfe, 0x1f, 0x7d, 0x00,
+	0x65, 0x11, 0x24, 0xd8, 0x4e, 0xc0, 0xdd, 0x67, 0x19, 0x8b, 0xa8, 0x61, 0xd7, 0x09, 0xd0, 0x87,
+	0x50, 0x11, 0xc1, 0xa0, 0xc1, 0xbd, 0x42, 0x85, 0x37, 0x83, 0x60, 0x1d, 0x31, 0xdf, 0x20, 0x3a,
+	0x90, 0x20, 0x10, 0x1d, 0x56, 0xe3, 0x0e, 0x24, 0x08, 0x78, 0x87, 0x5f, 0x83, 0x75, 0x1e, 0x42,
+	0x0f, 0x02, 0x6f, 0xec, 0x1b, 0xdc, 0xa6, 0xd6, 0x78, 0xa7, 0x35, 0xc6, 0x7e, 0xcc, 0xb8, 0x5d,
+	0x66, 0x5c, 0xd7, 0xa1, 0xf4, 0xca, 0x3b, 0x11, 0x1d, 0xaa, 0x62, 0x1f, 0xbc, 0xf2, 0x4e, 0xa2,
+	0xa6, 0x38, 0x8c, 0x59, 0x4f, 0x87, 0x31, 0x5f, 0xc1, 0xd5, 0xd9, 0xf3, 0x98, 0x87, 0x33, 0xda,
+	0xe5, 0xc3, 0x99, 0x2d, 0x77, 0x0e, 0x17, 0x3d, 0x04, 0xd5, 0x76, 0x69, 0x6d, 0x63, 0x21, 0xe3,
+	0x88, 0xf7, 0x31, 0x66, 0x83, 0xd1, 0x36, 0x14, 0xd9, 0xc7, 0x3a, 0x76, 0x0d, 0x09, 0xd7, 0xf3,
+	0xca, 0x3b, 0xe9, 0xd8, 0xe8, 0x6b, 0x50, 0x66, 0xdf, 0x4f, 0x7d, 0xd3, 0x22, 0xb5, 0x4d, 0xde,
+	0x32, 0x61, 0xb0, 0x85, 0x72, 0x3d, 0x9b, 0x08, 0x15, 0x6d, 0x89, 0x85, 0x62, 0x0c, 0xae, 0xa3,
+	0x6b, 0xb0, 0xc2, 0x1b, 0x1d, 0xbb, 0xb6, 0xcd, 0x9b, 0x8a, 0x8c, 0xec, 0xd8, 0x48, 0x87, 0x35,
+	0xdf, 0x0c, 0x88, 0x1b, 0x1a, 0x72, 0xc6, 0xab, 0xbc, 0xb9, 0x22, 0x98, 0x4f, 0xd8, 0xbc, 0xf5,
+	0xcf, 0xa0, 0x14, 0x6d, 0x86, 0x45, 0xdc, 0x64, 0xfd, 0x1e, 0x54, 0xd3, 0x5b, 0x69, 0x21, 0x27,
+	0xfb, 0x8f, 0x39, 0x28, 0xc7, 0x9b, 0x06, 0xb9, 0xb0, 0xc9, 0x17, 0xd5, 0x0c, 0x89, 0x6d, 0x4c,
+	0xf6, 0xa0, 0x08, 0xa4, 0xef, 0x67, 0x54, 0x73, 0x33, 0x42, 0x90, 0x37, 0x7a, 0xb9, 0x21, 0x51,
+	0x8c, 0x3c, 0x99, 0xef, 0x4b, 0x58, 0x1f, 0x3a, 0xee, 0xf8, 0x3c, 0x31, 0x97, 0x88, 0x80, 0x7f,
+	0x33, 0xe3, 0x5c, 0xfb, 0x6c, 0xf4, 0x64, 0x8e, 0xea, 0x30, 0x45, 0xa3, 0x3d, 0x28, 0xf8, 0x5e,
+	0x10, 0x46, 0x67, 0x66, 0xd6, 0xd3, 0xec, 0xc8, 0x0b, 0xc2, 0x03, 0xd3, 0xf7, 0xd9, 0x25, 0x4f,
+	0x00, 0xe8, 0xff, 0x95, 0x83, 0xab, 0xf3, 0x3f, 0x0c, 0x75, 0x41, 0xb5, 0xfc, 0xb1, 0x54, 0xd2,
+	0xbd, 0x45, 0x95, 0xd4, 0xf2, 0xc7, 0x13, 0xf9, 0x19, 0x10, 0x4b, 0x7c, 0x8f, 0xc8, 0xc8, 0x0b,
+	0x2e, 0xa4, 0x2e, 0x1e, 0x2c, 0x0a, 0x79, 0xc0, 0x47, 0x4f, 0x50, 0x25, 0x1c, 0xc2, 0x50, 0x92,
+	0x9b, 0x89, 0x4a, 0xb7, 0xbd, 0x60, 0x1a, 0x2e, 0x82, 0xc4, 0x31, 0x0e, 0x7a, 0x0a, 0x39, 0xc7,
+	0xab, 0x15, 0x17, 0xda, 0xe7, 0xb1, 0xa0, 0x9d, 0xc3, 0x89, 0x90, 0x39, 0xc7, 0xd3, 0x3f, 0x83,
+	0xed, 0xb9, 0x7a, 0x41, 0x5f, 0x07, 0xb0, 0xfc, 0xb1, 0xc1, 0xdf, 0x5c, 0x84, 0x39, 0xaa, 0xb8,
+	0x6c, 0xf9, 0xe3, 0x1e, 0x67, 0xe8, 0x2f, 0xa0, 0xf6, 0xb6, 0x8f, 0x67, 0x1b, 0x56, 0x7c, 0xbe,
+	0x31, 0x3a, 0xe1, 0x0a, 0x55, 0x71, 0x49, 0x30, 0x0e, 0x4e, 0xd8, 0xbe, 0x8c, 0x1a, 0xcd, 0x73,
+	0xd6, 0x41, 0xe5, 0x1d, 0x2a, 0xb2, 0x83, 0x79, 0x7e, 0x70, 0xa2, 0xff, 0x2c, 0x07, 0xeb, 0x53,
+	0xdf, 0xcf, 0xc2, 0x59, 0xe1, 0xcd, 0xa3, 0x8c, 0x84, 0xa0, 0x98, 0x6b, 0xb7, 0x1c, 0x3b, 0xca,
+	0x65, 0xf3, 0xff, 0xfc, 0x50, 0xf7, 0x65, 0x9e, 0x39, 0xe7, 0xf8, 0x6c, 0x2f, 0x8e, 0x4e, 0x9c,
+	0x90, 0xf2, 0x08, 0xab, 0x80, 0x05, 0x81, 0x9e, 0x43, 0x35, 0x20, 0x3c, 0x98, 0xb0, 0x0d, 0x61,
+	0xb2, 0x85, 0x85, 0x4c, 0x56, 0x4a, 0xc8, 0x2c, 0x17, 0xaf, 0x45, 0x48, 0x8c, 0xa2, 0xe8, 0x19,
+	0xac, 0x45, 0x11, 0xbd, 0x40, 0x2e, 0x2e, 0x8d, 0xbc, 0x2a, 0x81, 0x38, 0x30, 0x7b, 0xde, 0x4a,
+	0x34, 0xb2, 0x0f, 0xe3, 0xa1, 0xa4, 0xd4, 0x89, 0x20, 0xd2, 0xae, 0xa7, 0x20, 0x5d, 0x8f, 0x7e,
+	0x02, 0x95, 0xc4, 0x26, 0x5b, 0x64, 0x28, 0xd3, 0x67, 0xe8, 0x71, 0x7d, 0x16, 0x70, 0x2e, 0xf4,
+	0x98, 0xd3, 0x65, 0x61, 0x9c, 0xe1, 0xf8, 0x5c, 0xa3, 0x65, 0x5c, 0x64, 0x64, 0xc7, 0xd7, 0x7f,
+	0x9e, 0x83, 0x6a, 0xda, 0x3f, 0x44, 0x76, 0xe4, 0x93, 0xc0, 0xf1, 0xec, 0x84, 0x1d, 0x1d, 0x71,
+	0x06, 0xb3, 0x15, 0xd6, 0xfc, 0xd5, 0xd8, 0x0b, 0xcd, 0xc8, 0x56, 0x2c, 0x7f, 0xfc, 0x5b, 0x8c,
+	0x9e, 0xb2, 0x41, 0x75, 0xca, 0x06, 0xd1, 0xc7, 0x80, 0xa4, 0x29, 0x0d, 0x9d, 0x91, 0x13, 0x1a,
+	0x27, 0x17, 0x21, 0x11, 0x6b, 0xac, 0x62, 0x4d, 0xb4, 0xec, 0xb3, 0x86, 0x87, 0x8c, 0xcf, 0x0c,
+	0xcf, 0xf3, 0x46, 0x06, 0xb5, 0xbc, 0x80, 0x18, 0xa6, 0xfd, 0x8a, 0x5f, 0x19, 0x55, 0x5c, 0xf1,
+	0xbc, 0x51, 0x8f, 0xf1, 0x9a, 0xf6, 0x2b, 0x76, 0xaa, 0x5b, 0xfe, 0x98, 0x92, 0xd0, 0x60, 0x3f,
+	0x7c, 0x8f, 0x95, 0x31, 0x08, 0x56, 0xcb, 0x1f, 0x53, 0xf4, 0x2d, 0x58, 0x8b, 0x3a, 0xf0, 0x83,
+	0x5d, 0x46, 0x14, 0xab, 0xb2, 0x0b, 0xe7, 0x21, 0x1d, 0x56, 0x8f, 0x48, 0x60, 0x11, 0x37, 0xec,
+	0x3b, 0xd6, 0x19, 0xe5, 0x77, 0x3f, 0x05, 0xa7, 0x78, 0xf2, 0x0a, 0x14, 0xcd, 0x36, 0x22, 0x23,
+	0xaa, 0xff, 0xb3, 0x02, 0x05, 0x1e, 0xff, 0x30, 0xa5, 0xf0, 0xd8, 0x81, 0x87, 0x16, 0x32, 0x6e,
+	0x66, 0x0c, 0x1e, 0x58, 0x7c, 0x00, 0x65, 0xae, 0xfc, 0xc4, 0x75, 0x85, 0x07, 0xd5, 0xbc, 0xb1,
+	0x0e, 0xa5, 0x80, 0x98, 0xb6, 0xe7, 0x0e, 0xa3, 0x54, 0x5c, 0x4c, 0xa3, 0x5f, 0x07, 0xcd, 0x0f,
+	0x3c, 0xdf, 0x1c, 0x4c, 0x6e, 0xef, 0x72, 0xf9, 0xd6, 0x13, 0x7c, 0x1e, 0xef, 0x7f, 0x0b, 0xd6,
+	0x28, 0x11, 0xc7, 0x84, 0x30, 0x92, 0x82, 0xf8, 0x4c, 0xc9, 0xe4, 0xd7, 0x0b, 0xfd, 0x2b, 0x28,
+	0x8a, 0x53, 0xf0, 0x12, 0xf2, 0x7e, 0x02, 0x48, 0x28, 0x92, 0x19, 0xc8, 0xc8, 0xa1, 0x54, 0x86,
+	0xec, 0xfc, 0x3d, 0x59, 0xb4, 0x1c, 0x4d, 0x1a, 0xf4, 0x7f, 0x57, 0x00, 0x26, 0x2f, 0x7d, 0x2c,
+	0xca, 0x67, 0xbb, 0x86, 0xdd, 0xaf, 0x45, 0x4a, 0x31, 0x22, 0x59, 0x36, 0x4d, 0xc6, 0xe8, 0xb9,
+	0x65, 0x1f, 0x4a, 0x25, 0x40, 0xf4, 0xc0, 0x40, 0x64, 0x7a, 0x65, 0xd1, 0x07, 0x06, 0x22, 0x1e,
+	0x18, 0x08, 0x4b, 0xf2, 0xc8, 0xdb, 0x83, 0x80, 0xcb, 0xf3, 0xcb, 0x43, 0xc5, 0x8e, 0x5f, 0x71,
+	0x88, 0xfe, 0xdf, 0x4a, 0xec, 0xf7, 0xa2, 0xd7, 0x16, 0xf4, 0x25, 0x94, 0x98, 0x0b, 0x31, 0x46,
+	0xa6, 0x2f, 0x6b, 0x07, 0x5a, 0xcb, 0x3d, 0xe4, 0x44, 0x47, 0xac, 0x88, 0xfd, 0x57, 0x7c, 0x41,
+	0x31, 0xff, 0xc9, 0xee, 0x5d, 0x91, 0xff, 0x64, 0xff, 0xd1, 0x47, 0x50, 0x35, 0xc7, 0xa1, 0x67,
+	0x98, 0xf6, 0x6b, 0x12, 0x84, 0x0e, 0x25, 0xd2, 0x96, 0xd6, 0x18, 0xb7, 0x19, 0x31, 0xeb, 0x77,
+	0x61, 0x35, 0x89, 0xf9, 0xae, 0x20, 0xa8, 0x90, 0x0c, 0x82, 0x7e, 0x1f, 0x60, 0x92, 0xb9, 0x64,
+	0x36, 0xc2, 0xd2, 0xa0, 0x86, 0x15, 0x5d, 0xf4, 0x0b, 0xb8, 0xc4, 0x18, 0x2d, 0x66, 0x8c, 0xe9,
+	0x67, 0x95, 0x42, 0xf4, 0xac, 0xc2, 0xbc, 0x03, 0xdb, 0xd0, 0x67, 0xce, 0x70, 0x18, 0x67, 0x53,
+	0xcb, 0x9e, 0x37, 0x7a, 0xca, 0x19, 0xfa, 0x2f, 0x72, 0xc2, 0x56, 0xc4, 0x03, 0x59, 0xa6, 0x8b,
+	0xde, 0xfb, 0x5a, 0xea, 0x3b, 0x00, 0x34, 0x34, 0x03, 0x16, 0xd1, 0x99, 0x51, 0x3e, 0xb7, 0x3e,
+	0xf3, 0x2e, 0xd3, 0x8f, 0x2a, 0x76, 0x70, 0x59, 0xf6, 0x6e, 0x86, 0xe8, 0x3e, 0xac, 0x5a, 0xde,
+	0xc8, 0x1f, 0x12, 0x39, 0xb8, 0xf0, 0xce, 0xc1, 0x95, 0xb8, 0x7f, 0x33, 0x4c, 0x64, 0x91, 0x8b,
+	0x97, 0xcd, 0x22, 0xff, 0x5c, 0x11, 0xef, 0x7c, 0xc9, 0x67, 0x46, 0x34, 0x98, 0x53, 0xcb, 0xf2,
+	0x78, 0xc9, 0x37, 0xcb, 0x5f, 0x55, 0xc8, 0x52, 0xbf, 0x9f, 0xa5, 0x72, 0xe4, 0xed, 0x31, 0xf6,
+	0xbf, 0xaa, 0x50, 0x8e, 0x96, 0x65, 0x76, 0xed, 0x3f, 0x87, 0x72, 0x5c, 0x2e, 0x55, 0xcb, 0xbd,
+	0x53, 0xc3, 0x93, 0xce, 0xe8, 0x25, 0x20, 0x73, 0x30, 0x88, 0x63, 0x67, 0x63, 0x4c, 0xcd, 0x41,
+	0xf4, 0xc0, 0xfa, 0xf9, 0x02, 0x7a, 0x88, 0xce, 0xc7, 0x63, 0x36, 0x1e, 0x6b, 0xe6, 0x60, 0x90,
+	0xe2, 0xa0, 0x3f, 0x80, 0xed, 0xf4, 0x1c, 0xc6, 0xc9, 0x85, 0xe1, 0x3b, 0xb6, 0x4c, 0x28, 0xec,
+	0x2d, 0xfa, 0xca, 0xd9, 0x48, 0xc1, 0x3f, 0xbc, 0x38, 0x72, 0x6c, 0xa1, 0x73, 0x14, 0xcc, 0x34,
+	0xd4, 0xff, 0x08, 0xae, 0xbd, 0xa5, 0xfb, 0x9c, 0x35, 0xe8, 0xa6, 0xab, 0x77, 0x96, 0x57, 0x42,
+	0x62, 0xf5, 0xfe, 0x41, 0x81, 0x8d, 0x99, 0x0e, 0xa8, 0x99, 0x0c, 0xfa, 0x6f, 0x65, 0x9c, 0xa7,
+	0x75, 0x74, 0x2c, 0xe0, 0xd9, 0x58, 0xf4, 0x64, 0x2a, 0xce, 0xcf, 0x1a, 0x90, 0x89, 0x08, 0x57,
+	0x00, 0x49, 0x04, 0xfd, 0x9f, 0x54, 0x28, 0x45, 0xe8, 0x3c, 0x1d, 0x70, 0x41, 0x43, 0x32, 0x32,
+	0xe2, 0x5c, 0xa5, 0x82, 0x41, 0xb0, 0xf8, 0x89, 0xfa, 0x01, 0x94, 0xc7, 0x94, 0x04, 0xa2, 0x39,
+	0xc7, 0x9b, 0x4b, 0x8c, 0xc1, 0x1b, 0x3f, 0x84, 0x4a, 0xe8, 0x85, 0xe6, 0xd0, 0x08, 0x79, 0xbc,
+	0xa0, 0x8a, 0xd1, 0x9c, 0xc5, 0xa3, 0x05, 0xf4, 0x1d, 0xd8, 0x08, 0x4f, 0x03, 0x2f, 0x0c, 0x87,
+	0x2c, 0x56, 0xe5, 0x91, 0x93, 0x08, 0x74, 0xf2, 0x58, 0x8b, 0x1b, 0x44, 0x44, 0x45, 0x99, 0xf7,
+	0x9e, 0x74, 0x66, 0xa6, 0xcb, 0x9d, 0x48, 0x1e, 0xaf, 0xc5, 0x5c, 0x66, 0xda, 0xec, 0xf0, 0xf4,
+	0x45, 0x44, 0xc2, 0x7d, 0x85, 0x82, 0x23, 0x12, 0x19, 0xb0, 0x3e, 0x22, 0x26, 0x1d, 0x07, 0xc4,
+	0x36, 0x5e, 0x3a, 0x64, 0x68, 0x8b, 0x2c, 0x4e, 0x35, 0xf3, 0xdd, 0x25, 0x52, 0x4b, 0xe3, 0x11,
+	0x1f, 0x8d, 0xab, 0x11, 0x9c, 0xa0, 0x59, 0xe4, 0x20, 0xfe, 0xa1, 0x75, 0xa8, 0xf4, 0x9e, 0xf7,
+	0xfa, 0xed, 0x03, 0xe3, 0xe0, 0x70, 0xb7, 0x2d, 0x0b, 0xb4, 0x7a, 0x6d, 0x2c, 0x48, 0x85, 0xb5,
+	0xf7, 0x0f, 0xfb, 0xcd, 0x7d, 0xa3, 0xdf, 0x69, 0x3d, 0xed, 0x69, 0x39, 0xb4, 0x0d, 0x1b, 0xfd,
+	0x3d, 0x7c, 0xd8, 0xef, 0xef, 0xb7, 0x77, 0x8d, 0xa3, 0x36, 0xee, 0x1c, 0xee, 0xf6, 0x34, 0x95,
+	0x25, 0xa2, 0x27, 0xec, 0x7e, 0xe7, 0xa0, 0xad, 0xe5, 0x59, 0x49, 0xce, 0x51, 0x1b, 0xb7, 0xda,
+	0xdd, 0xbe, 0x56, 0xd0, 0x7f, 0xa6, 0x42, 0x25, 0xb1, 0x8a, 0xcc, 0x90, 0x03, 0x2a, 0xee, 0x35,
+	0x79, 0xcc, 0xfe, 0xf2, 0x07, 0x65, 0xd3, 0x3a, 0x15, 0xab, 0x93, 0xc7, 0x82, 0xe0, 0x77, 0x19,
+	0xf3, 0x3c, 0xb1, 0xcf, 0xf3, 0xb8, 0x34, 0x32, 0xcf, 0x05, 0xc8, 0x37, 0x61, 0xf5, 0x8c, 0x04,
+	0x2e, 0x19, 0xca, 0x76, 0xb1, 0x22, 0x15, 0xc1, 0x13, 0x5d, 0x6e, 0x82, 0x26, 0xbb, 0x4c, 0x60,
+	0xc4, 0x72, 0x54, 0x05, 0xff, 0x20, 0x02, 0xdb, 0x82, 0x82, 0x68, 0x5e, 0x11, 0xf3, 0x73, 0x82,
+	0x1d, 0x53, 0xf4, 0x8d, 0xe9, 0xf3, 0x18, 0x32, 0x8f, 0xf9, 0x7f, 0x74, 0x32, 0xbb, 0x3e, 0x45,
+	0xbe, 0x3e, 0x77, 0x16, 0x37, 0xe7, 0xb7, 0x2d, 0xd1, 0x69, 0xbc, 0x44, 0x2b, 0xa0, 0xe2, 0xa8,
+	0xaa, 0xa9, 0xd5, 0x6c, 0xed, 0xb1, 0x65, 0x59, 0x83, 0xf2, 0x41, 0xf3, 0x87, 0xc6, 0x71, 0x4f,
+	0x3c, 0x11, 0x68, 0xb0, 0xfa, 0xb4, 0x8d, 0xbb, 0xed, 0x7d, 0xc9, 0x51, 0xd1, 0x16, 0x68, 0x92,
+	0x33, 0xe9, 0x97, 0x67, 0x08, 0xe2, 0x6f, 0x81, 0xa5, 0x8c, 0x7b, 0xcf, 0x9a, 0x47, 0x5a, 0x51,
+	0xff, 0xcf, 0x1c, 0xac, 0x8b, 0x63, 0x21, 0xae, 0xbf, 0x78, 0xfb, 0xfb, 0x73, 0x32, 0x25, 0x96,
+	0x4b, 0xa7, 0xc4, 0xa2, 0x20, 0x94, 0x9f, 0xea, 0xea, 0x24, 0x08, 0xe5, 0x69, 0xa2, 0x94, 0xc7,
+	0xcf, 0x2f, 0xe2, 0xf1, 0x6b, 0xb0, 0x32, 0x22, 0x34, 0x5e, 0xb7, 0x32, 0x8e, 0x48, 0xe4, 0x40,
+	0xc5, 0x74, 0x5d, 0x2f, 0x34, 0x45, 0x9e, 0xb9, 0xb8, 0xd0, 0x61, 0x38, 0xf5, 0xc5, 0x8d, 0xe6,
+	0x04, 0x49, 0x38, 0xe6, 0x24, 0x76, 0xfd, 0x07, 0xa0, 0x4d, 0x77, 0x58, 0xe8, 0x38, 0xdc, 0x85,
+	0xed, 0x56, 0xfc, 0x32, 0x94, 0xa9, 0xe2, 0x64, 0xce, 0x7b, 0x08, 0x7b, 0xd7, 0x9c, 0x46, 0x91,
+	0xef, 0x9a, 0x1e, 0x2b, 0x9d, 0xa4, 0xa1, 0x17, 0x90, 0xf7, 0x5f, 0xab, 0x38, 0x57, 0x94, 0x5f,
+	0x2a, 0xb0, 0x99, 0x9a, 0x71, 0x52, 0x23, 0x27, 0xcb, 0x07, 0x95, 0xff, 0x8f, 0xf2, 0xc1, 0xdc,
+	0xfb, 0xad, 0xa4, 0xfa, 0x53, 0x05, 0xea, 0xc7, 0xbe, 0x6d, 0x86, 0x24, 0x9d, 0xb7, 0x7b, 0xd7,
+	0xe2, 0xa4, 0xb2, 0xf5, 0xb9, 0x4b, 0x67, 0xeb, 0xf5, 0xaf, 0xc3, 0x07, 0x73, 0xc5, 0x90, 0xab,
+	0xeb, 0xc3, 0xd6, 0xbc, 0xfc, 0x11, 0x0b, 0xcc, 0xdf, 0x4c, 0x0a, 0x3a, 0x54, 0x2c, 0x29, 0xf4,
+	0x05, 0xa8, 0x23, 0xf3, 0x9c, 0xbf, 0xc9, 0x54, 0x76, 0x1a, 0x19, 0x05, 0xeb, 0x1c, 0xf2, 0xbb,
+	0x3a, 0x66, 0x43, 0x59, 0xc9, 0xc1, 0x8a, 0x64, 0xbc, 0x35, 0xf1, 0x73, 0x5d, 0x5c, 0x75, 0x8d,
+	0x13, 0x9f, 0xca, 0xc4, 0xc1, 0x0a, 0xa3, 0x1f, 0xfa, 0x3c, 0x01, 0xf5, 0x26, 0x70, 0x42, 0xc2,
+	0xdb, 0x44, 0xda, 0xa0, 0xc4, 0x19, 0xb2, 0x91, 0x8f, 0x73, 0x3c, 0x3f, 0x4a, 0x16, 0x70, 0xa0,
+	0x8e, 0xe7, 0xf3, 0x6c, 0x85, 0x18, 0xc9, 0x5b, 0x45, 0x86, 0x40, 0x60, 0xb1, 0xe6, 0x6f, 0x7f,
+	0x77, 0x12, 0x55, 0x12, 0x76, 0xbe, 0xc8, 0xc7, 0x4f, 0xed, 0x0a, 0x23, 0xf0, 0x71, 0xb7, 0xdb,
+	0xe9, 0x3e, 0xd6, 0x14, 0xf6, 0x64, 0xda, 0xfe, 0x61, 0x87, 0x55, 0x1c, 0xe7, 0x76, 0xfe, 0x63,
+	0x0b, 0x8a, 0x62, 0xb3, 0xa3, 0x9f, 0xca, 0x88, 0x3a, 0x59, 0x23, 0x8f, 0x7e, 0xb0, 0xf0, 0xb6,
+	0x48, 0xd5, 0xdd, 0xd7, 0x1f, 0x2c, 0x3d, 0x5e, 0xae, 0xee, 0x15, 0xf4, 0xe7, 0x0a, 0xac, 0xa6,
+	0xea, 0x11, 0xb2, 0xbe, 0x57, 0xcd, 0x29, 0xc9, 0xaf, 0x7f, 0x7f, 0xa9, 0xb1, 0xb1, 0x2c, 0x3f,
+	0x51, 0xa0, 0x92, 0x28, 0x46, 0x47, 0x77, 0x96, 0x29, 0x60, 0x17, 0x92, 0xdc, 0x5d, 0xbe, 0xf6,
+	0x5d, 0xbf, 0xf2, 0xa9, 0x82, 0xfe, 0x4c, 0x81, 0x4a, 0xa2, 0x2c, 0x3b, 0xb3, 0x28, 0xb3, 0x45,
+	0xe4, 0xf5, 0xbb, 0xcb, 0x0c, 0x8d, 0x75, 0xf2, 0xc7, 0x0a, 0x94, 0xe3, 0x12, 0x6b, 0x74, 0x7b,
+	0xf1, 0xa2, 0x6c, 0x21, 0xc4, 0xe7, 0xcb, 0x56, 0x73, 0xeb, 0x57, 0xd0, 0x1f, 0x42, 0x29, 0xaa,
+	0x47, 0x46, 0x59, 0xdd, 0xdf, 0x54, 0xb1, 0x73, 0xfd, 0xf6, 0xc2, 0xe3, 0x92, 0xd3, 0x47, 0x45,
+	0xc2, 0x99, 0xa7, 0x9f, 0x2a, 0x67, 0xae, 0xdf, 0x5e, 0x78, 0x5c, 0x3c, 0x3d, 0xb3, 0x84, 0x44,
+	0x2d, 0x71, 0x66, 0x4b, 0x98, 0x2d, 0x62, 0xae, 0xdf, 0x5d, 0x66, 0x68, 0x4a, 0x90, 0x44, 0x35,
+	0x72, 0x66, 0x41, 0x66, 0x2b, 0x9e, 0xeb, 0x77, 0x97, 0x19, 0x1a, 0x0b, 0xf2, 0x63, 0x25, 0x79,
+	0xbf, 0xbe, 0xbd, 0x70, 0xd1, 0xed, 0x82, 0x26, 0x39, 0x53, 0xf6, 0xcb, 0x37, 0xe8, 0x8f, 0x65,
+	0x36, 0x50, 0xd4, 0xec, 0xa2, 0x45, 0xc0, 0x52, 0x65, 0xbe, 0xf5, 0xcf, 0x96, 0x0b, 0xda, 0xb8,
+	0x10, 0x7f, 0xa2, 0x00, 0x4c, 0xaa, 0x7b, 0x33, 0x0b, 0x31, 0x53, 0x56, 0x5c, 0xbf, 0xb3, 0xc4,
+	0xc8, 0xe4, 0x06, 0x89, 0xaa, 0x0f, 0x33, 0x6f, 0x90, 0xa9, 0xea, 0xe3, 0xfa, 0xed, 0x85, 0xc7,
+	0xc5, 0xd3, 0xff, 0x9d, 0x02, 0x1b, 0x33, 0xd5, 0x8f, 0xe8, 0xc1, 0x25, 0x0b, 0x60, 0xeb, 0x5f,
+	0x2c, 0x0f, 0x10, 0x89, 0x76, 0x53, 0xf9, 0x54, 0x41, 0x7f, 0xa1, 0xc0, 0x5a, 0xba, 0x2a, 0x2c,
+	0xf3, 0x29, 0x35, 0xa7, 0x8e, 0xb2, 0x7e, 0x6f, 0xb9, 0xc1, 0xb1, 0xb6, 0xfe, 0x4a, 0x81, 0xaa,
+	0xdc, 0xdf, 0x91, 0x3c, 0xf7, 0x16, 0x73, 0x0b, 0x53, 0x02, 0xdd, 0x5f, 0x72, 0x74, 0x4a, 0xa2,
+	0x74, 0x68, 0x9f, 0x59, 0xa2, 0xb9, 0xf7, 0x8a, 0xfa, 0xfd, 0x25, 0x47, 0xa7, 0x3c, 0x5d, 0x22,
+	0xc0, 0x5f, 0xe0, 0xf0, 0x9d, 0xbe, 0x86, 0xd4, 0xef, 0x2e, 0x33, 0x34, 0x16, 0xe4, 0xef, 0x15,
+	0xd8, 0x9c, 0x13, 0x1c, 0xa3, 0x66, 0x46, 0xd4, 0xb7, 0xc7, 0xf7, 0xf5, 0x87, 0x97, 0x81, 0x88,
+	0x04, 0x7c, 0xb8, 0xf2, 0x3b, 0x05, 0x71, 0x83, 0x2d, 0xf2, 0x9f, 0xef, 0xfd, 0xdf, 0x00, 0x84,
+	0x30, 0x0c, 0xd3, 0x86, 0x3a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -4073,6 +4506,18 @@ type DriverClient interface {
 	// DestroyNetwork destroys a previously created network. This rpc is only
 	// implemented if the driver needs to manage network namespace creation.
 	DestroyNetwork(ctx context.Context, in *DestroyNetworkRequest, opts ...grpc.CallOption) (*DestroyNetworkResponse, error)
+	// CheckpointTask freezes the state of a running task into an archive and
+	// stops the task. This rpc is only implemented if the driver sets the
+	// checkpoint capability.
+	CheckpointTask(ctx context.Context, in *CheckpointTaskRequest, opts ...grpc.CallOption) (*CheckpointTaskResponse, error)
+	// RestoreTask starts a task from an archive written by CheckpointTask.
+	// This rpc is only implemented if the driver sets the checkpoint
+	// capability.
+	RestoreTask(ctx context.Context, in *RestoreTaskRequest, opts ...grpc.CallOption) (*RestoreTaskResponse, error)
+	// UpdateTaskResources updates the cpu and memory limits of a running
+	// task without restarting it. This rpc is only implemented if the driver
+	// sets the resize capability.
+	UpdateTaskResources(ctx context.Context, in *UpdateTaskResourcesRequest, opts ...grpc.CallOption) (*UpdateTaskResourcesResponse, error)
 }
 
 type driverClient struct {
@@ -4318,6 +4763,33 @@ func (c *driverClient) DestroyNetwork(ctx context.Context, in *DestroyNetworkReq
 	return out, nil
 }
 
+func (c *driverClient) CheckpointTask(ctx context.Context, in *CheckpointTaskRequest, opts ...grpc.CallOption) (*CheckpointTaskResponse, error) {
+	out := new(CheckpointTaskResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/CheckpointTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverClient) RestoreTask(ctx context.Context, in *RestoreTaskRequest, opts ...grpc.CallOption) (*RestoreTaskResponse, error) {
+	out := new(RestoreTaskResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/RestoreTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverClient) UpdateTaskResources(ctx context.Context, in *UpdateTaskResourcesRequest, opts ...grpc.CallOption) (*UpdateTaskResourcesResponse, error) {
+	out := new(UpdateTaskResourcesResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/UpdateTaskResources", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DriverServer is the server API for Driver service.
 type DriverServer interface {
 	// TaskConfigSchema returns the schema for parsing the driver
@@ -4373,6 +4845,18 @@ type DriverServer interface {
 	// DestroyNetwork destroys a previously created network. This rpc is only
 	// implemented if the driver needs to manage network namespace creation.
 	DestroyNetwork(context.Context, *DestroyNetworkRequest) (*DestroyNetworkResponse, error)
+	// CheckpointTask freezes the state of a running task into an archive and
+	// stops the task. This rpc is only implemented if the driver sets the
+	// checkpoint capability.
+	CheckpointTask(context.Context, *CheckpointTaskRequest) (*CheckpointTaskResponse, error)
+	// RestoreTask starts a task from an archive written by CheckpointTask.
+	// This rpc is only implemented if the driver sets the checkpoint
+	// capability.
+	RestoreTask(context.Context, *RestoreTaskRequest) (*RestoreTaskResponse, error)
+	// UpdateTaskResources updates the cpu and memory limits of a running
+	// task without restarting it. This rpc is only implemented if the driver
+	// sets the resize capability.
+	UpdateTaskResources(context.Context, *UpdateTaskResourcesRequest) (*UpdateTaskResourcesResponse, error)
 }
 
 // UnimplementedDriverServer can be embedded to have forward compatible implementations.
@@ -4427,6 +4911,15 @@ func (*UnimplementedDriverServer) CreateNetwork(ctx context.Context, req *Create
 func (*UnimplementedDriverServer) DestroyNetwork(ctx context.Context, req *DestroyNetworkRequest) (*DestroyNetworkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DestroyNetwork not implemented")
 }
+func (*UnimplementedDriverServer) CheckpointTask(ctx context.Context, req *CheckpointTaskRequest) (*CheckpointTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckpointTask not implemented")
+}
+func (*UnimplementedDriverServer) RestoreTask(ctx context.Context, req *RestoreTaskRequest) (*RestoreTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreTask not implemented")
+}
+func (*UnimplementedDriverServer) UpdateTaskResources(ctx context.Context, req *UpdateTaskResourcesRequest) (*UpdateTaskResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTaskResources not implemented")
+}
 
 func RegisterDriverServer(s *grpc.Server, srv DriverServer) {
 	s.RegisterService(&_Driver_serviceDesc, srv)
@@ -4737,6 +5230,60 @@ func _Driver_DestroyNetwork_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Driver_CheckpointTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckpointTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).CheckpointTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/CheckpointTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).CheckpointTask(ctx, req.(*CheckpointTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Driver_RestoreTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).RestoreTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/RestoreTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).RestoreTask(ctx, req.(*RestoreTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Driver_UpdateTaskResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTaskResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).UpdateTaskResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/UpdateTaskResources",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).UpdateTaskResources(ctx, req.(*UpdateTaskResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Driver_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hashicorp.nomad.plugins.drivers.proto.Driver",
 	HandlerType: (*DriverServer)(nil),
@@ -4789,6 +5336,18 @@ var _Driver_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DestroyNetwork",
 			Handler:    _Driver_DestroyNetwork_Handler,
 		},
+		{
+			MethodName: "CheckpointTask",
+			Handler:    _Driver_CheckpointTask_Handler,
+		},
+		{
+			MethodName: "RestoreTask",
+			Handler:    _Driver_RestoreTask_Handler,
+		},
+		{
+			MethodName: "UpdateTaskResources",
+			Handler:    _Driver_UpdateTaskResources_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -4,6 +4,8 @@
 package drivers
 
 import (
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
@@ -124,6 +126,19 @@ func ResourcesFromProto(pb *proto.Resources) *Resources {
 			r.NomadResources.Memory.MemoryMaxMB = pb.AllocatedResources.Memory.MemoryMaxMb
 		}
 
+		if pb.AllocatedResources.Io != nil {
+			r.NomadResources.IO.Weight = pb.AllocatedResources.Io.Weight
+			for _, limit := range pb.AllocatedResources.Io.Max {
+				r.NomadResources.IO.Max = append(r.NomadResources.IO.Max, &structs.IOLimit{
+					Device:    limit.Device,
+					ReadBps:   limit.ReadBps,
+					WriteBps:  limit.WriteBps,
+					ReadIOps:  limit.ReadIops,
+					WriteIOps: limit.WriteIops,
+				})
+			}
+		}
+
 		for _, network := range pb.AllocatedResources.Networks {
 			var n structs.NetworkResource
 			n.Device = network.Device
@@ -190,9 +205,22 @@ func ResourcesToProto(r *Resources) *proto.Resources {
 				MemoryMb:    r.NomadResources.Memory.MemoryMB,
 				MemoryMaxMb: r.NomadResources.Memory.MemoryMaxMB,
 			},
+			Io: &proto.AllocatedIOResources{
+				Weight: r.NomadResources.IO.Weight,
+			},
 			Networks: make([]*proto.NetworkResource, len(r.NomadResources.Networks)),
 		}
 
+		for _, limit := range r.NomadResources.IO.Max {
+			pb.AllocatedResources.Io.Max = append(pb.AllocatedResources.Io.Max, &proto.IOLimit{
+				Device:    limit.Device,
+				ReadBps:   limit.ReadBps,
+				WriteBps:  limit.WriteBps,
+				ReadIops:  limit.ReadIOps,
+				WriteIops: limit.WriteIOps,
+			})
+		}
+
 		for i, network := range r.NomadResources.Networks {
 			var n proto.NetworkResource
 			n.Device = network.Device
@@ -371,6 +399,39 @@ func taskHandleToProto(handle *TaskHandle) *proto.TaskHandle {
 	}
 }
 
+func driverNetworkFromProto(pb *proto.NetworkOverride) *DriverNetwork {
+	if pb == nil {
+		return nil
+	}
+	net := &DriverNetwork{
+		PortMap:       map[string]int{},
+		IP:            pb.Addr,
+		AutoAdvertise: pb.AutoAdvertise,
+	}
+	for k, v := range pb.PortMap {
+		net.PortMap[k] = int(v)
+	}
+	return net
+}
+
+func driverNetworkToProto(net *DriverNetwork) (*proto.NetworkOverride, error) {
+	if net == nil {
+		return nil, nil
+	}
+	pb := &proto.NetworkOverride{
+		PortMap:       map[string]int32{},
+		Addr:          net.IP,
+		AutoAdvertise: net.AutoAdvertise,
+	}
+	for k, v := range net.PortMap {
+		if v > math.MaxInt32 {
+			return nil, fmt.Errorf("port map out of bounds")
+		}
+		pb.PortMap[k] = int32(v)
+	}
+	return pb, nil
+}
+
 func exitResultToProto(result *ExitResult) *proto.ExitResult {
 	if result == nil {
 		return &proto.ExitResult{}
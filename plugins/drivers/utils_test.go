@@ -4,6 +4,7 @@
 package drivers
 
 import (
+	"math"
 	"testing"
 
 	"github.com/hashicorp/nomad/helper/uuid"
@@ -59,6 +60,14 @@ func TestTaskConfigRoundTrip(t *testing.T) {
 				Memory: structs.AllocatedMemoryResources{
 					MemoryMB: int64(300),
 				},
+				IO: structs.AllocatedIOResources{
+					Weight: 500,
+					Max: structs.IOLimits{{
+						Device:    "sda",
+						ReadBps:   1048576,
+						WriteIOps: 100,
+					}},
+				},
 			},
 			LinuxResources: &LinuxResources{
 				MemoryLimitBytes: 300 * 1024 * 1024,
@@ -141,3 +150,23 @@ func Test_networkCreateRequestFromProto(t *testing.T) {
 		})
 	}
 }
+
+func TestDriverNetworkRoundTrip(t *testing.T) {
+	input := &DriverNetwork{
+		PortMap:       map[string]int{"http": 8080},
+		IP:            "10.0.0.1",
+		AutoAdvertise: true,
+	}
+
+	pb, err := driverNetworkToProto(input)
+	must.NoError(t, err)
+	must.Eq(t, input, driverNetworkFromProto(pb))
+
+	pb, err = driverNetworkToProto(nil)
+	must.NoError(t, err)
+	must.Nil(t, pb)
+	must.Nil(t, driverNetworkFromProto(nil))
+
+	_, err = driverNetworkToProto(&DriverNetwork{PortMap: map[string]int{"http": math.MaxInt32 + 1}})
+	must.ErrorContains(t, err, "out of bounds")
+}
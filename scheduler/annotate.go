@@ -13,6 +13,7 @@ const (
 	AnnotationForcesCreate            = "forces create"
 	AnnotationForcesDestroy           = "forces destroy"
 	AnnotationForcesInplaceUpdate     = "forces in-place update"
+	AnnotationForcesInplaceResize     = "forces in-place resize"
 	AnnotationForcesDestructiveUpdate = "forces create/destroy update"
 )
 
@@ -24,6 +25,7 @@ const (
 	UpdateTypeMigrate           = "migrate"
 	UpdateTypeCanary            = "canary"
 	UpdateTypeInplaceUpdate     = "in-place update"
+	UpdateTypeInplaceResize     = "in-place resize"
 	UpdateTypeDestructiveUpdate = "create/destroy update"
 )
 
@@ -39,6 +41,7 @@ const (
 // * Task changes will be annotated with:
 //   - forces create/destroy update
 //   - forces in-place update
+//   - forces in-place resize
 func Annotate(diff *structs.JobDiff, annotations *structs.PlanAnnotations) error {
 	tgDiffs := diff.TaskGroups
 	if len(tgDiffs) == 0 {
@@ -79,8 +82,12 @@ func annotateTaskGroup(diff *structs.TaskGroupDiff, annotations *structs.PlanAnn
 			if tg.Canary != 0 {
 				diff.Updates[UpdateTypeCanary] = tg.Canary
 			}
-			if tg.InPlaceUpdate != 0 {
-				diff.Updates[UpdateTypeInplaceUpdate] = tg.InPlaceUpdate
+			// In-place updates that resize tasks are reported separately
+			if inplace := tg.InPlaceUpdate - min(tg.InPlaceResize, tg.InPlaceUpdate); inplace != 0 {
+				diff.Updates[UpdateTypeInplaceUpdate] = inplace
+			}
+			if tg.InPlaceResize != 0 {
+				diff.Updates[UpdateTypeInplaceResize] = tg.InPlaceResize
 			}
 			if tg.DestructiveUpdate != 0 {
 				diff.Updates[UpdateTypeDestructiveUpdate] = tg.DestructiveUpdate
@@ -182,14 +189,22 @@ FieldsLoop:
 	}
 
 	// Object changes that can be done in-place are log configs, services,
-	// constraints, affinity or spread.
-
+	// constraints, affinity or spread. Changes to the cpu and memory
+	// resources resize the running task in-place.
+	resized := false
 	if !destructive {
 	ObjectsLoop:
 		for _, oDiff := range diff.Objects {
 			switch oDiff.Name {
 			case "Service", "Constraint", "Affinity", "Spread":
 				continue
+			case "Resources":
+				if !resizeOnly(oDiff) {
+					destructive = true
+					break ObjectsLoop
+				}
+				resized = true
+				continue
 			case "LogConfig":
 				for _, fDiff := range oDiff.Fields {
 					switch fDiff.Name {
@@ -207,9 +222,36 @@ FieldsLoop:
 		}
 	}
 
-	if destructive {
+	switch {
+	case destructive:
 		diff.Annotations = append(diff.Annotations, AnnotationForcesDestructiveUpdate)
-	} else {
+	case resized:
+		diff.Annotations = append(diff.Annotations, AnnotationForcesInplaceResize)
+	default:
 		diff.Annotations = append(diff.Annotations, AnnotationForcesInplaceUpdate)
 	}
 }
+
+// resizeOnly returns whether a resources diff only changes the cpu or memory
+// of the task, which can be updated without restarting it.
+func resizeOnly(diff *structs.ObjectDiff) bool {
+	if diff.Type != structs.DiffTypeEdited {
+		return false
+	}
+	for _, oDiff := range diff.Objects {
+		if oDiff.Type != structs.DiffTypeNone {
+			return false
+		}
+	}
+	for _, fDiff := range diff.Fields {
+		if fDiff.Type == structs.DiffTypeNone {
+			continue
+		}
+		switch fDiff.Name {
+		case "CPU", "MemoryMB", "MemoryMaxMB":
+		default:
+			return false
+		}
+	}
+	return true
+}
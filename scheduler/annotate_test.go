@@ -24,6 +24,7 @@ func TestAnnotateTaskGroup_Updates(t *testing.T) {
 				InPlaceUpdate:     5,
 				DestructiveUpdate: 6,
 				Canary:            7,
+				InPlaceResize:     2,
 			},
 		},
 	}
@@ -40,7 +41,8 @@ func TestAnnotateTaskGroup_Updates(t *testing.T) {
 			UpdateTypeCreate:            2,
 			UpdateTypeMigrate:           3,
 			UpdateTypeDestroy:           4,
-			UpdateTypeInplaceUpdate:     5,
+			UpdateTypeInplaceUpdate:     3,
+			UpdateTypeInplaceResize:     2,
 			UpdateTypeDestructiveUpdate: 6,
 			UpdateTypeCanary:            7,
 		},
@@ -435,6 +437,62 @@ func TestAnnotateTask(t *testing.T) {
 			Parent:  &structs.TaskGroupDiff{Type: structs.DiffTypeEdited},
 			Desired: AnnotationForcesInplaceUpdate,
 		},
+		// Resources resized
+		{
+			Diff: &structs.TaskDiff{
+				Type: structs.DiffTypeEdited,
+				Objects: []*structs.ObjectDiff{
+					{
+						Type: structs.DiffTypeEdited,
+						Name: "Resources",
+						Fields: []*structs.FieldDiff{
+							{
+								Type: structs.DiffTypeEdited,
+								Name: "CPU",
+								Old:  "100",
+								New:  "200",
+							},
+							{
+								Type: structs.DiffTypeNone,
+								Name: "Cores",
+								Old:  "0",
+								New:  "0",
+							},
+							{
+								Type: structs.DiffTypeEdited,
+								Name: "MemoryMB",
+								Old:  "256",
+								New:  "512",
+							},
+						},
+					},
+				},
+			},
+			Parent:  &structs.TaskGroupDiff{Type: structs.DiffTypeEdited},
+			Desired: AnnotationForcesInplaceResize,
+		},
+		// Resources edited
+		{
+			Diff: &structs.TaskDiff{
+				Type: structs.DiffTypeEdited,
+				Objects: []*structs.ObjectDiff{
+					{
+						Type: structs.DiffTypeEdited,
+						Name: "Resources",
+						Fields: []*structs.FieldDiff{
+							{
+								Type: structs.DiffTypeEdited,
+								Name: "Cores",
+								Old:  "1",
+								New:  "2",
+							},
+						},
+					},
+				},
+			},
+			Parent:  &structs.TaskGroupDiff{Type: structs.DiffTypeEdited},
+			Desired: AnnotationForcesDestructiveUpdate,
+		},
 		// Task deleted new parent
 		{
 			Diff: &structs.TaskDiff{
@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// UnmetJobDependencies returns the IDs of the jobs the given job depends on
+// that have not yet reached their required state. Dependencies are only
+// enforced before the first allocations of a job version are placed, so
+// nothing is returned for a stopped job or a job version that already has
+// allocations.
+func UnmetJobDependencies(ws memdb.WatchSet, state State, job *structs.Job) ([]string, error) {
+	if job == nil || job.Stopped() || len(job.DependsOn) == 0 {
+		return nil, nil
+	}
+
+	allocs, err := state.AllocsByJob(ws, job.Namespace, job.ID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allocs for job %q: %v", job.ID, err)
+	}
+	for _, alloc := range allocs {
+		if alloc.Job != nil && alloc.Job.Version == job.Version {
+			return nil, nil
+		}
+	}
+
+	var unmet []string
+	for _, dep := range job.DependsOn {
+		met, err := jobDependencyMet(ws, state, job.Namespace, dep)
+		if err != nil {
+			return nil, err
+		}
+		if !met {
+			unmet = append(unmet, dep.JobID)
+		}
+	}
+	return unmet, nil
+}
+
+// UnmetJobDependenciesDesc describes the unmet dependencies of a job, calling
+// out the jobs that are not registered, such as jobs that were never submitted
+// or were garbage collected, since evaluations wait on those until they are
+// registered.
+func UnmetJobDependenciesDesc(state State, namespace string, unmet []string) (string, error) {
+	var missing []string
+	for _, jobID := range unmet {
+		job, err := state.JobByID(nil, namespace, jobID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get job %q: %v", jobID, err)
+		}
+		if job == nil {
+			missing = append(missing, jobID)
+		}
+	}
+
+	desc := fmt.Sprintf("waiting on job dependencies: %s", strings.Join(unmet, ", "))
+	if len(missing) > 0 {
+		desc += fmt.Sprintf(" (not registered: %s)", strings.Join(missing, ", "))
+	}
+	return desc, nil
+}
+
+// jobDependencyMet returns whether the job depended on has reached the state
+// required by the dependency.
+func jobDependencyMet(ws memdb.WatchSet, state State, namespace string, dep *structs.JobDependency) (bool, error) {
+	job, err := state.JobByID(ws, namespace, dep.JobID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get job %q: %v", dep.JobID, err)
+	}
+	if job == nil || job.Stopped() {
+		return false, nil
+	}
+
+	switch dep.Condition {
+	case structs.JobDependencyConditionRunning:
+		return job.Status == structs.JobStatusRunning, nil
+
+	case structs.JobDependencyConditionHealthy:
+		if job.Status != structs.JobStatusRunning {
+			return false, nil
+		}
+
+		// Jobs with an update block are healthy once the deployment of their
+		// current version succeeds.
+		deployment, err := state.LatestDeploymentByJobID(ws, namespace, dep.JobID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get deployment for job %q: %v", dep.JobID, err)
+		}
+		if deployment != nil && deployment.JobVersion == job.Version {
+			return deployment.Status == structs.DeploymentStatusSuccessful, nil
+		}
+
+		// Otherwise all of the desired allocations of the current version
+		// must be running.
+		allocs, err := state.AllocsByJob(ws, namespace, dep.JobID, false)
+		if err != nil {
+			return false, fmt.Errorf("failed to get allocs for job %q: %v", dep.JobID, err)
+		}
+		running := make(map[string]int)
+		for _, alloc := range allocs {
+			if alloc.Job == nil || alloc.Job.Version != job.Version ||
+				alloc.ClientStatus != structs.AllocClientStatusRunning {
+				continue
+			}
+			if alloc.DeploymentStatus != nil && alloc.DeploymentStatus.IsUnhealthy() {
+				continue
+			}
+			running[alloc.TaskGroup]++
+		}
+		for _, tg := range job.TaskGroups {
+			if running[tg.Name] < tg.Count {
+				return false, nil
+			}
+		}
+		return true, nil
+
+	case structs.JobDependencyConditionComplete:
+		if job.Status != structs.JobStatusDead {
+			return false, nil
+		}
+
+		allocs, err := state.AllocsByJob(ws, namespace, dep.JobID, false)
+		if err != nil {
+			return false, fmt.Errorf("failed to get allocs for job %q: %v", dep.JobID, err)
+		}
+		complete := make(map[string]bool)
+		for _, alloc := range allocs {
+			if alloc.Job != nil && alloc.Job.Version == job.Version &&
+				alloc.ClientStatus == structs.AllocClientStatusComplete {
+				complete[alloc.TaskGroup] = true
+			}
+		}
+		for _, tg := range job.TaskGroups {
+			if !complete[tg.Name] {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+
+	return false, fmt.Errorf("unknown job dependency condition %q", dep.Condition)
+}
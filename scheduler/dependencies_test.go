@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestUnmetJobDependencies(t *testing.T) {
+	ci.Parallel(t)
+
+	// upsertAllocs creates an allocation of the job's first task group for
+	// each of the given client statuses.
+	upsertAllocs := func(t *testing.T, h *Harness, job *structs.Job, statuses ...string) []*structs.Allocation {
+		var allocs []*structs.Allocation
+		for _, status := range statuses {
+			alloc := mock.Alloc()
+			alloc.Job = job
+			alloc.JobID = job.ID
+			alloc.TaskGroup = job.TaskGroups[0].Name
+			alloc.ClientStatus = status
+			if status != structs.AllocClientStatusRunning {
+				alloc.DesiredStatus = structs.AllocDesiredStatusStop
+			}
+			allocs = append(allocs, alloc)
+		}
+		must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+		return allocs
+	}
+
+	dependentJob := func(depID, condition string) *structs.Job {
+		job := mock.BatchJob()
+		job.DependsOn = []*structs.JobDependency{{JobID: depID, Condition: condition}}
+		return job
+	}
+
+	t.Run("running", func(t *testing.T) {
+		h := NewHarness(t)
+		dep := mock.Job()
+		must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, dep))
+
+		job := dependentJob(dep.ID, structs.JobDependencyConditionRunning)
+		unmet, err := UnmetJobDependencies(nil, h.State, job)
+		must.NoError(t, err)
+		must.Eq(t, []string{dep.ID}, unmet)
+
+		upsertAllocs(t, h, dep, structs.AllocClientStatusRunning)
+		unmet, err = UnmetJobDependencies(nil, h.State, job)
+		must.NoError(t, err)
+		must.SliceEmpty(t, unmet)
+	})
+
+	t.Run("healthy without deployment", func(t *testing.T) {
+		h := NewHarness(t)
+		dep := mock.Job()
+		dep.TaskGroups[0].Count = 2
+		must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, dep))
+
+		job := dependentJob(dep.ID, structs.JobDependencyConditionHealthy)
+		upsertAllocs(t, h, dep, structs.AllocClientStatusRunning)
+		unmet, err := UnmetJobDependencies(nil, h.State, job)
+		must.NoError(t, err)
+		must.Eq(t, []string{dep.ID}, unmet)
+
+		upsertAllocs(t, h, dep, structs.AllocClientStatusRunning)
+		unmet, err = UnmetJobDependencies(nil, h.State, job)
+		must.NoError(t, err)
+		must.SliceEmpty(t, unmet)
+	})
+
+	t.Run("healthy with deployment", func(t *testing.T) {
+		h := NewHarness(t)
+		dep := mock.Job()
+		dep.TaskGroups[0].Count = 1
+		must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, dep))
+		upsertAllocs(t, h, dep, structs.AllocClientStatusRunning)
+
+		d := mock.Deployment()
+		d.JobID = dep.ID
+		d.JobVersion = dep.Version
+		d.Status = structs.DeploymentStatusRunning
+		must.NoError(t, h.State.UpsertDeployment(h.NextIndex(), d))
+
+		job := dependentJob(dep.ID, structs.JobDependencyConditionHealthy)
+		unmet, err := UnmetJobDependencies(nil, h.State, job)
+		must.NoError(t, err)
+		must.Eq(t, []string{dep.ID}, unmet)
+
+		d = d.Copy()
+		d.Status = structs.DeploymentStatusSuccessful
+		must.NoError(t, h.State.UpsertDeployment(h.NextIndex(), d))
+		unmet, err = UnmetJobDependencies(nil, h.State, job)
+		must.NoError(t, err)
+		must.SliceEmpty(t, unmet)
+	})
+
+	t.Run("complete", func(t *testing.T) {
+		h := NewHarness(t)
+		dep := mock.BatchJob()
+		must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, dep))
+		running := upsertAllocs(t, h, dep, structs.AllocClientStatusRunning)
+
+		job := dependentJob(dep.ID, structs.JobDependencyConditionComplete)
+		unmet, err := UnmetJobDependencies(nil, h.State, job)
+		must.NoError(t, err)
+		must.Eq(t, []string{dep.ID}, unmet)
+
+		// A batch job is only dead once it has a terminal evaluation
+		eval := mock.Eval()
+		eval.JobID = dep.ID
+		eval.Status = structs.EvalStatusComplete
+		must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+		complete := running[0].Copy()
+		complete.ClientStatus = structs.AllocClientStatusComplete
+		must.NoError(t, h.State.UpdateAllocsFromClient(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Allocation{complete}))
+		unmet, err = UnmetJobDependencies(nil, h.State, job)
+		must.NoError(t, err)
+		must.SliceEmpty(t, unmet)
+	})
+
+	t.Run("not enforced once placed", func(t *testing.T) {
+		h := NewHarness(t)
+		job := dependentJob("missing", structs.JobDependencyConditionRunning)
+		must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+		unmet, err := UnmetJobDependencies(nil, h.State, job)
+		must.NoError(t, err)
+		must.Eq(t, []string{"missing"}, unmet)
+
+		upsertAllocs(t, h, job, structs.AllocClientStatusRunning)
+		unmet, err = UnmetJobDependencies(nil, h.State, job)
+		must.NoError(t, err)
+		must.SliceEmpty(t, unmet)
+	})
+}
@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// FairShare is a snapshot of how much of the cluster each namespace is using
+// relative to the share it is entitled to by the fair-share configuration.
+//
+// The usage of a namespace is its dominant share of the cluster: the largest
+// fraction of the total CPU or memory of the ready nodes that its running
+// allocations are using. A namespace is entitled to a fraction of the cluster
+// proportional to its weight among the namespaces competing for it, which are
+// the namespaces with running allocations.
+type FairShare struct {
+	config *structs.FairShareConfig
+
+	// usage is the dominant share of the cluster used by each namespace
+	// with running allocations.
+	usage map[string]float64
+
+	// activeWeight is the sum of the weights of the namespaces in usage.
+	activeWeight int
+}
+
+// NewFairShare computes the fair-share usage of each namespace from the
+// allocations running on the ready nodes of the cluster.
+func NewFairShare(ws memdb.WatchSet, state State, config *structs.FairShareConfig) (*FairShare, error) {
+	iter, err := state.Nodes(ws)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nodes: %v", err)
+	}
+
+	var cpuCapacity, memoryCapacity float64
+	cpuUsed := make(map[string]float64)
+	memoryUsed := make(map[string]float64)
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if !node.Ready() {
+			continue
+		}
+
+		capacity := node.NodeResources.Comparable()
+		if reserved := node.ReservedResources.Comparable(); reserved != nil {
+			capacity.Subtract(reserved)
+		}
+		cpuCapacity += float64(capacity.Flattened.Cpu.CpuShares)
+		memoryCapacity += float64(capacity.Flattened.Memory.MemoryMB)
+
+		allocs, err := state.AllocsByNodeTerminal(ws, node.ID, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get allocs for node %q: %v", node.ID, err)
+		}
+		for _, alloc := range allocs {
+			resources := alloc.AllocatedResources.Comparable()
+			if resources == nil {
+				continue
+			}
+			cpuUsed[alloc.Namespace] += float64(resources.Flattened.Cpu.CpuShares)
+			memoryUsed[alloc.Namespace] += float64(resources.Flattened.Memory.MemoryMB)
+		}
+	}
+
+	f := &FairShare{
+		config: config,
+		usage:  make(map[string]float64, len(cpuUsed)),
+	}
+	for namespace := range cpuUsed {
+		var usage float64
+		if cpuCapacity > 0 {
+			usage = cpuUsed[namespace] / cpuCapacity
+		}
+		if memoryCapacity > 0 {
+			usage = max(usage, memoryUsed[namespace]/memoryCapacity)
+		}
+		f.usage[namespace] = usage
+		f.activeWeight += config.Weight(namespace)
+	}
+	return f, nil
+}
+
+// Ratios returns the ratio of usage to entitled share of each namespace with
+// running allocations. Namespaces that are not included are not using any of
+// the cluster.
+func (f *FairShare) Ratios() map[string]float64 {
+	ratios := make(map[string]float64, len(f.usage))
+	for namespace := range f.usage {
+		ratios[namespace] = f.ratio(namespace, "")
+	}
+	return ratios
+}
+
+// OverShare returns true if the namespace is using more than its share of the
+// cluster once the requesting namespace also competes for it.
+func (f *FairShare) OverShare(namespace, requester string) bool {
+	return f.ratio(namespace, requester) > 1
+}
+
+// ratio returns the ratio of the namespace's usage to its entitled share. A
+// ratio above 1 means the namespace is using more than its share. The
+// namespace and the optional requesting namespace are counted as competing
+// for the cluster even if they have no running allocations.
+func (f *FairShare) ratio(namespace, requester string) float64 {
+	usage, ok := f.usage[namespace]
+	if !ok || usage == 0 {
+		return 0
+	}
+
+	total := f.activeWeight
+	if _, ok := f.usage[requester]; requester != "" && !ok {
+		total += f.config.Weight(requester)
+	}
+	entitled := float64(f.config.Weight(namespace)) / float64(total)
+	return usage / entitled
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestFairShare(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Two nodes of 4000 MHz and 8192 MB each
+	var nodes []*structs.Node
+	for i := 0; i < 2; i++ {
+		node := mock.Node()
+		node.NodeResources.Cpu, node.NodeResources.Processors = cpuResources(4000)
+		node.NodeResources.Memory.MemoryMB = 8192
+		node.ReservedResources = nil
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+		nodes = append(nodes, node)
+	}
+
+	// A down node doesn't count towards capacity or usage
+	down := mock.Node()
+	down.Status = structs.NodeStatusDown
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), down))
+
+	newAlloc := func(namespace, nodeID string, cpu, memory int64) *structs.Allocation {
+		alloc := mock.Alloc()
+		alloc.Namespace = namespace
+		alloc.NodeID = nodeID
+		alloc.AllocatedResources = &structs.AllocatedResources{
+			Tasks: map[string]*structs.AllocatedTaskResources{
+				"web": {
+					Cpu:    structs.AllocatedCpuResources{CpuShares: cpu},
+					Memory: structs.AllocatedMemoryResources{MemoryMB: memory},
+				},
+			},
+		}
+		return alloc
+	}
+
+	stopped := newAlloc("dev", nodes[1].ID, 4000, 8192)
+	stopped.DesiredStatus = structs.AllocDesiredStatusStop
+	stopped.ClientStatus = structs.AllocClientStatusComplete
+
+	allocs := []*structs.Allocation{
+		// prod uses 50% of the CPU and 25% of the memory of the cluster
+		newAlloc("prod", nodes[0].ID, 4000, 2048),
+		newAlloc("prod", nodes[1].ID, 0, 2048),
+		// batch uses 25% of the memory of the cluster
+		newAlloc("batch", nodes[1].ID, 1000, 4096),
+		newAlloc("batch", down.ID, 4000, 8192),
+		stopped,
+	}
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	config := &structs.FairShareConfig{
+		Enabled: true,
+		Weights: map[string]int{"prod": 3},
+	}
+	fairShare, err := NewFairShare(nil, h.State, config)
+	must.NoError(t, err)
+
+	// prod is entitled to 3/4 of the cluster and batch to 1/4
+	must.Eq(t, map[string]float64{
+		"prod":  0.5 / 0.75,
+		"batch": 0.25 / 0.25,
+	}, fairShare.Ratios())
+
+	must.False(t, fairShare.OverShare("prod", ""))
+	must.False(t, fairShare.OverShare("batch", ""))
+	must.False(t, fairShare.OverShare("dev", ""))
+
+	// Once dev competes for the cluster batch is only entitled to 1/5
+	must.True(t, fairShare.OverShare("batch", "dev"))
+	must.False(t, fairShare.OverShare("prod", "dev"))
+}
@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// gangPlacement records a placement made for a gang scheduled task group so
+// it can be backed out of the plan if the gang can't be placed in full.
+type gangPlacement struct {
+	alloc *structs.Allocation
+
+	// prev is the previous allocation stopped as part of this placement, if
+	// any, and rescheduling is true if the placement was a reschedule of it.
+	prev         *structs.Allocation
+	rescheduling bool
+}
+
+// gangTracker tracks the placements made for each gang of a job during a
+// single call to computePlacements.
+type gangTracker struct {
+	job        *structs.Job
+	placements map[string][]*gangPlacement
+}
+
+func newGangTracker(job *structs.Job) *gangTracker {
+	return &gangTracker{
+		job:        job,
+		placements: make(map[string][]*gangPlacement),
+	}
+}
+
+// track records a successful placement for the task group if it belongs to a
+// gang.
+func (g *gangTracker) track(tg *structs.TaskGroup, alloc, prev *structs.Allocation, rescheduling bool) {
+	name := tg.GangName()
+	if name == "" {
+		return
+	}
+	g.placements[name] = append(g.placements[name], &gangPlacement{
+		alloc:        alloc,
+		prev:         prev,
+		rescheduling: rescheduling,
+	})
+}
+
+// rollback removes the placements of every gang that has at least one task
+// group that failed to place from the plan, and marks all of the gang's task
+// groups as failed so that a blocked evaluation retries the gang as a whole.
+// It returns the updated failed task group metrics and true if the plan still
+// contains gang placements.
+func (g *gangTracker) rollback(plan *structs.Plan, failed map[string]*structs.AllocMetric) (map[string]*structs.AllocMetric, bool) {
+	unsatisfied := make(map[string]struct{})
+	for _, tg := range g.job.TaskGroups {
+		name := tg.GangName()
+		if name == "" {
+			continue
+		}
+		if _, ok := failed[tg.Name]; ok {
+			unsatisfied[name] = struct{}{}
+		}
+	}
+
+	for name := range unsatisfied {
+		// Back out the placements made for the gang, keeping the metrics of
+		// the last placement of each task group for the failure report.
+		metrics := make(map[string]*structs.AllocMetric)
+		placed := make(map[string]int)
+		for _, p := range g.placements[name] {
+			plan.RemoveAlloc(p.alloc)
+			if p.prev != nil {
+				plan.RemoveUpdate(p.prev)
+				if p.rescheduling {
+					annotateRescheduleTracker(p.prev, structs.LastRescheduleFailedToPlace)
+				}
+			}
+			metrics[p.alloc.TaskGroup] = p.alloc.Metrics
+			placed[p.alloc.TaskGroup]++
+		}
+		delete(g.placements, name)
+
+		for _, tg := range g.job.TaskGroups {
+			if tg.GangName() != name {
+				continue
+			}
+
+			metric, ok := failed[tg.Name]
+			if !ok {
+				// The task group had nothing to place in this evaluation
+				if placed[tg.Name] == 0 {
+					continue
+				}
+				metric = metrics[tg.Name].Copy()
+				metric.CoalescedFailures = placed[tg.Name] - 1
+			} else {
+				metric.CoalescedFailures += placed[tg.Name]
+			}
+			metric.GangUnsatisfied = name
+
+			if failed == nil {
+				failed = make(map[string]*structs.AllocMetric)
+			}
+			failed[tg.Name] = metric
+		}
+	}
+
+	return failed, len(g.placements) > 0
+}
@@ -179,6 +179,11 @@ func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 			s.deployment.GetID())
 	}
 
+	// Defer the evaluation until the jobs the job depends on are ready
+	if deferred, err := s.deferForDependencies(); deferred || err != nil {
+		return err
+	}
+
 	// Retry up to the maxScheduleAttempts and reset if progress is made.
 	progress := func() bool { return progressMade(s.planResult) }
 	limit := maxServiceScheduleAttempts
@@ -220,6 +225,52 @@ func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 		s.deployment.GetID())
 }
 
+// deferForDependencies checks whether the job's dependencies are met. If they
+// are not, it blocks the evaluation until they are and returns true.
+func (s *GenericScheduler) deferForDependencies() (bool, error) {
+	job, err := s.state.JobByID(nil, s.eval.Namespace, s.eval.JobID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get job %q: %v", s.eval.JobID, err)
+	}
+
+	unmet, err := UnmetJobDependencies(nil, s.state, job)
+	if err != nil {
+		return false, err
+	}
+	if len(unmet) == 0 {
+		// Clear dependencies left over from when the eval was blocked on them
+		if len(s.eval.UnmetDependencies) > 0 {
+			s.eval = s.eval.Copy()
+			s.eval.UnmetDependencies = nil
+		}
+		return false, nil
+	}
+
+	desc, err := UnmetJobDependenciesDesc(s.state, s.eval.Namespace, unmet)
+	if err != nil {
+		return false, err
+	}
+	s.logger.Debug("job dependencies not met, deferring evaluation", "dependencies", unmet)
+
+	// A blocked eval is just blocked again until its dependencies are met
+	if s.eval.Status == structs.EvalStatusBlocked {
+		newEval := s.eval.Copy()
+		newEval.UnmetDependencies = unmet
+		newEval.StatusDescription = desc
+		return true, s.planner.ReblockEval(newEval)
+	}
+
+	s.blocked = s.eval.CreateBlockedEval(nil, false, "", nil)
+	s.blocked.UnmetDependencies = unmet
+	s.blocked.StatusDescription = desc
+	if err := s.planner.CreateEval(s.blocked); err != nil {
+		return true, err
+	}
+
+	return true, setStatus(s.logger, s.planner, s.eval, nil, s.blocked,
+		nil, structs.EvalStatusComplete, desc, nil, "")
+}
+
 // createBlockedEval creates a blocked eval and submits it to the planner. If
 // failure is set to true, the eval's trigger reason reflects that.
 func (s *GenericScheduler) createBlockedEval(planFailure bool) error {
@@ -525,6 +576,10 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 	// Capture current time to use as the start time for any rescheduled allocations
 	now := time.Now()
 
+	// Track placements of gang scheduled task groups so they can be backed
+	// out if their gang can't be placed in full
+	gangs := newGangTracker(s.job)
+
 	// Have to handle destructive changes first as we need to discount their
 	// resources. To understand this imagine the resources were reduced and the
 	// count was scaled up.
@@ -704,6 +759,12 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 				// Track the placement
 				s.plan.AppendAlloc(alloc, downgradedJob)
 
+				var stoppedPrev *structs.Allocation
+				if stopPrevAlloc {
+					stoppedPrev = prevAllocation
+				}
+				gangs.track(tg, alloc, stoppedPrev, missing.IsRescheduling())
+
 			} else {
 				// Lazy initialize the failed map
 				if s.failedTGAllocs == nil {
@@ -736,6 +797,15 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 		}
 	}
 
+	// Gangs are placed all-or-nothing, so back out any gang that couldn't be
+	// placed in full. The remaining gang placements must be committed
+	// atomically by the plan applier.
+	var gangPlaced bool
+	s.failedTGAllocs, gangPlaced = gangs.rollback(s.plan, s.failedTGAllocs)
+	if gangPlaced {
+		s.plan.AllAtOnce = true
+	}
+
 	return nil
 }
 
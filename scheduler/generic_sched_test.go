@@ -3157,6 +3157,113 @@ func TestServiceSched_JobModify_InPlace(t *testing.T) {
 	}
 }
 
+func TestServiceSched_JobModify_InPlaceResize(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name        string
+		memoryMB    int
+		canResize   bool
+		expInplace  uint64
+		expResize   uint64
+		expDestruct uint64
+	}{
+		{
+			name:       "fits on node",
+			memoryMB:   512,
+			canResize:  true,
+			expInplace: 5,
+			expResize:  5,
+		},
+		{
+			name:        "exceeds node",
+			memoryMB:    100_000,
+			canResize:   true,
+			expDestruct: 5,
+		},
+		{
+			name:        "driver can't resize",
+			memoryMB:    512,
+			expDestruct: 5,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewHarness(t)
+
+			var nodes []*structs.Node
+			for i := 0; i < 5; i++ {
+				node := mock.Node()
+				if tc.canResize {
+					node.Drivers["exec"].Attributes = map[string]string{
+						structs.DriverResizeAttr("exec"): "true",
+					}
+				}
+				nodes = append(nodes, node)
+				must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+			}
+
+			job := mock.Job()
+			job.TaskGroups[0].Count = 5
+			must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+			var allocs []*structs.Allocation
+			for i := 0; i < 5; i++ {
+				alloc := mock.AllocForNode(nodes[i])
+				alloc.Job = job
+				alloc.JobID = job.ID
+				alloc.Name = fmt.Sprintf("my-job.web[%d]", i)
+				allocs = append(allocs, alloc)
+			}
+			must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+			// Only change the cpu and memory of the task
+			job2 := job.Copy()
+			job2.TaskGroups[0].Tasks[0].Resources.CPU = 600
+			job2.TaskGroups[0].Tasks[0].Resources.MemoryMB = tc.memoryMB
+			must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job2))
+
+			eval := &structs.Evaluation{
+				Namespace:    structs.DefaultNamespace,
+				ID:           uuid.Generate(),
+				Priority:     50,
+				TriggeredBy:  structs.EvalTriggerJobRegister,
+				JobID:        job.ID,
+				AnnotatePlan: true,
+				Status:       structs.EvalStatusPending,
+			}
+			must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+			must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+			must.SliceLen(t, 1, h.Plans)
+			plan := h.Plans[0]
+			desired := plan.Annotations.DesiredTGUpdates["web"]
+			must.NotNil(t, desired)
+			must.Eq(t, tc.expInplace, desired.InPlaceUpdate)
+			must.Eq(t, tc.expResize, desired.InPlaceResize)
+			must.Eq(t, tc.expDestruct, desired.DestructiveUpdate)
+
+			if tc.expResize == 0 {
+				return
+			}
+
+			// The existing allocs are updated with the new resources
+			must.MapEmpty(t, plan.NodeUpdate)
+			var planned []*structs.Allocation
+			for _, allocList := range plan.NodeAllocation {
+				planned = append(planned, allocList...)
+			}
+			must.SliceLen(t, 5, planned)
+			for _, p := range planned {
+				tr := p.AllocatedResources.Tasks["web"]
+				must.Eq(t, 600, tr.Cpu.CpuShares)
+				must.Eq(t, int64(tc.memoryMB), tr.Memory.MemoryMB)
+			}
+		})
+	}
+}
+
 // TestServiceSched_JobModify_InPlace08 asserts that inplace updates of
 // allocations created with Nomad 0.8 do not cause panics.
 //
@@ -5316,6 +5423,236 @@ func TestBatchSched_Run_FailedAllocQueuedAllocations(t *testing.T) {
 	}
 }
 
+func TestBatchSched_Gang(t *testing.T) {
+	ci.Parallel(t)
+
+	// gangJob returns a batch job whose allocations each need most of a mock
+	// node's memory, so that every node fits a single allocation.
+	gangJob := func(groups map[string]int, gang string) *structs.Job {
+		job := mock.BatchJob()
+		base := job.TaskGroups[0]
+		job.TaskGroups = nil
+		for _, name := range []string{"web", "db"} {
+			count, ok := groups[name]
+			if !ok {
+				continue
+			}
+			tg := base.Copy()
+			tg.Name = name
+			tg.Count = count
+			tg.Gang = &structs.Gang{Name: gang}
+			tg.Tasks[0].Resources.MemoryMB = 5000
+			job.TaskGroups = append(job.TaskGroups, tg)
+		}
+		return job
+	}
+
+	testCases := []struct {
+		name      string
+		nodes     int
+		groups    map[string]int
+		gang      string
+		expPlaced int
+		expFailed map[string]int
+		expGang   string
+	}{
+		{
+			name:      "group fits",
+			nodes:     3,
+			groups:    map[string]int{"web": 3},
+			expPlaced: 3,
+		},
+		{
+			name:      "group does not fit",
+			nodes:     2,
+			groups:    map[string]int{"web": 3},
+			expFailed: map[string]int{"web": 3},
+			expGang:   "web",
+		},
+		{
+			name:      "named gang fits",
+			nodes:     4,
+			groups:    map[string]int{"web": 2, "db": 2},
+			gang:      "mpi",
+			expPlaced: 4,
+		},
+		{
+			name:      "named gang does not fit",
+			nodes:     3,
+			groups:    map[string]int{"web": 2, "db": 2},
+			gang:      "mpi",
+			expFailed: map[string]int{"web": 2, "db": 2},
+			expGang:   "mpi",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewHarness(t)
+
+			for i := 0; i < tc.nodes; i++ {
+				node := mock.Node()
+				must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+			}
+
+			job := gangJob(tc.groups, tc.gang)
+			must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+			eval := &structs.Evaluation{
+				Namespace:   structs.DefaultNamespace,
+				ID:          uuid.Generate(),
+				Priority:    job.Priority,
+				TriggeredBy: structs.EvalTriggerJobRegister,
+				JobID:       job.ID,
+				Status:      structs.EvalStatusPending,
+			}
+			must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+			must.NoError(t, h.Process(NewBatchScheduler, eval))
+			must.Len(t, 1, h.Evals)
+			outEval := h.Evals[0]
+
+			if tc.expPlaced > 0 {
+				must.Len(t, 1, h.Plans)
+				plan := h.Plans[0]
+				must.True(t, plan.AllAtOnce)
+
+				var placed int
+				for _, allocs := range plan.NodeAllocation {
+					placed += len(allocs)
+				}
+				must.Eq(t, tc.expPlaced, placed)
+				must.MapEmpty(t, outEval.FailedTGAllocs)
+				must.SliceEmpty(t, h.CreateEvals)
+				return
+			}
+
+			// No allocations of the gang may be placed
+			must.SliceEmpty(t, h.Plans)
+			must.Len(t, 1, h.CreateEvals)
+			must.Eq(t, structs.EvalStatusBlocked, h.CreateEvals[0].Status)
+
+			must.MapLen(t, len(tc.expFailed), outEval.FailedTGAllocs)
+			for tg, count := range tc.expFailed {
+				metrics := outEval.FailedTGAllocs[tg]
+				must.NotNil(t, metrics)
+				must.Eq(t, tc.expGang, metrics.GangUnsatisfied)
+				must.Eq(t, count-1, metrics.CoalescedFailures)
+				must.Eq(t, count, outEval.QueuedAllocations[tg])
+			}
+		})
+	}
+}
+
+func TestBatchSched_DependsOn(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	node := mock.Node()
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	dep := mock.Job()
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, dep))
+
+	job := mock.BatchJob()
+	job.TaskGroups[0].Count = 1
+	job.DependsOn = []*structs.JobDependency{{
+		JobID:     dep.ID,
+		Condition: structs.JobDependencyConditionRunning,
+	}}
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// The evaluation is deferred with a blocked eval waiting on the
+	// dependency
+	must.NoError(t, h.Process(NewBatchScheduler, eval))
+	must.SliceEmpty(t, h.Plans)
+	must.Len(t, 1, h.CreateEvals)
+	blocked := h.CreateEvals[0]
+	must.Eq(t, structs.EvalStatusBlocked, blocked.Status)
+	must.Eq(t, []string{dep.ID}, blocked.UnmetDependencies)
+	must.Len(t, 1, h.Evals)
+	must.Eq(t, structs.EvalStatusComplete, h.Evals[0].Status)
+	must.Eq(t, blocked.ID, h.Evals[0].BlockedEval)
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{blocked}))
+
+	// Processing the blocked eval before the dependency is met blocks it again
+	must.NoError(t, h.Process(NewBatchScheduler, blocked))
+	must.SliceEmpty(t, h.Plans)
+	must.Len(t, 1, h.ReblockEvals)
+	must.Eq(t, []string{dep.ID}, h.ReblockEvals[0].UnmetDependencies)
+
+	// Once the dependency is running the allocations are placed
+	alloc := mock.Alloc()
+	alloc.Job = dep
+	alloc.JobID = dep.ID
+	alloc.NodeID = node.ID
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Allocation{alloc}))
+
+	must.NoError(t, h.Process(NewBatchScheduler, blocked))
+	must.Len(t, 1, h.Plans)
+	must.Len(t, 1, h.ReblockEvals)
+	must.Len(t, 2, h.Evals)
+	must.Eq(t, structs.EvalStatusComplete, h.Evals[1].Status)
+	must.SliceEmpty(t, h.Evals[1].UnmetDependencies)
+}
+
+func TestBatchSched_DependsOn_MissingJob(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	job := mock.BatchJob()
+	job.DependsOn = []*structs.JobDependency{{
+		JobID:     "missing",
+		Condition: structs.JobDependencyConditionComplete,
+	}}
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// The evaluation waits on the job, and its description calls out that the
+	// job is not registered
+	must.NoError(t, h.Process(NewBatchScheduler, eval))
+	must.SliceEmpty(t, h.Plans)
+	must.Len(t, 1, h.CreateEvals)
+	blocked := h.CreateEvals[0]
+	must.Eq(t, []string{"missing"}, blocked.UnmetDependencies)
+	must.Eq(t, "waiting on job dependencies: missing (not registered: missing)", blocked.StatusDescription)
+	must.Len(t, 1, h.Evals)
+	must.Eq(t, blocked.StatusDescription, h.Evals[0].StatusDescription)
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{blocked}))
+
+	// Once the job is registered the evaluation keeps waiting on it
+	dep := mock.BatchJob()
+	dep.ID = "missing"
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, dep))
+
+	must.NoError(t, h.Process(NewBatchScheduler, blocked))
+	must.SliceEmpty(t, h.Plans)
+	must.Len(t, 1, h.ReblockEvals)
+	must.Eq(t, "waiting on job dependencies: missing", h.ReblockEvals[0].StatusDescription)
+}
+
 func TestBatchSched_ReRun_SuccessfullyFinishedAlloc(t *testing.T) {
 	ci.Parallel(t)
 
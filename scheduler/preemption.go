@@ -129,6 +129,10 @@ type Preemptor struct {
 	// currentAllocs is the candidate set used to find preemptible allocations
 	currentAllocs []*structs.Allocation
 
+	// fairShare is the fair-share usage of the cluster, if fair-share
+	// scheduling is enabled
+	fairShare *FairShare
+
 	// ctx is the context from the scheduler stack
 	ctx Context
 }
@@ -156,6 +160,7 @@ func (p *Preemptor) Copy() *Preemptor {
 		jobID:                  p.jobID,
 		nodeRemainingResources: p.nodeRemainingResources.Copy(),
 		currentAllocs:          helper.CopySlice(p.currentAllocs),
+		fairShare:              p.fairShare,
 		ctx:                    p.ctx,
 	}
 }
@@ -171,6 +176,14 @@ func (p *Preemptor) SetNode(node *structs.Node) {
 	p.nodeRemainingResources = nodeRemainingResources
 }
 
+// SetFairShare enables fair-share preemption, which allows allocations of
+// namespaces using more than their share of the cluster to be preempted by
+// jobs of namespaces using less than their share, as long as the allocations
+// don't have a higher priority.
+func (p *Preemptor) SetFairShare(fairShare *FairShare) {
+	p.fairShare = fairShare
+}
+
 // SetCandidates initializes the candidate set from which preemptions are chosen
 func (p *Preemptor) SetCandidates(allocs []*structs.Allocation) {
 	// Reset candidate set
@@ -221,8 +234,25 @@ func (p *Preemptor) getNumPreemptions(alloc *structs.Allocation) int {
 	return c
 }
 
+// preemptible returns whether the allocation can be preempted by the job being
+// placed. Allocations of jobs with a priority at least 10 lower than the job
+// can always be preempted. With fair-share preemption, allocations of jobs
+// with an equal or lower priority can also be preempted if their namespace is
+// over its share while the job's namespace isn't.
+func (p *Preemptor) preemptible(alloc *structs.Allocation) bool {
+	if p.jobPriority-alloc.Job.Priority >= 10 {
+		return true
+	}
+	if p.fairShare == nil || alloc.Job.Priority > p.jobPriority {
+		return false
+	}
+	return p.fairShare.OverShare(alloc.Namespace, p.jobID.Namespace) &&
+		!p.fairShare.OverShare(p.jobID.Namespace, p.jobID.Namespace)
+}
+
 // PreemptForTaskGroup computes a list of allocations to preempt to accommodate
-// the resources asked for. Only allocs with a job priority < 10 of jobPriority are considered
+// the resources asked for. Only allocs with a job priority < 10 of jobPriority
+// are considered, unless fair-share preemption is enabled.
 // This method is meant only for finding preemptible allocations based on CPU/Memory/Disk
 func (p *Preemptor) PreemptForTaskGroup(resourceAsk *structs.AllocatedResources) []*structs.Allocation {
 	resourcesNeeded := resourceAsk.Comparable()
@@ -234,7 +264,7 @@ func (p *Preemptor) PreemptForTaskGroup(resourceAsk *structs.AllocatedResources)
 	}
 
 	// Group candidates by priority, filter out ineligible allocs
-	allocsByPriority := filterAndGroupPreemptibleAllocs(p.jobPriority, p.currentAllocs)
+	allocsByPriority := p.filterAndGroupPreemptibleAllocs(p.currentAllocs)
 
 	var bestAllocs []*structs.Allocation
 	allRequirementsMet := false
@@ -336,7 +366,7 @@ func (p *Preemptor) PreemptForNetwork(networkResourceAsk *structs.NetworkResourc
 		net := networks[0]
 
 		// Filter out alloc that's ineligible due to priority
-		if p.jobPriority-alloc.Job.Priority < 10 {
+		if !p.preemptible(alloc) {
 			// Populate any reserved ports used by
 			// this allocation that cannot be preempted
 			for _, port := range net.ReservedPorts {
@@ -429,7 +459,7 @@ OUTER:
 		}
 
 		// Split by priority
-		allocsByPriority := filterAndGroupPreemptibleAllocs(p.jobPriority, currentAllocs)
+		allocsByPriority := p.filterAndGroupPreemptibleAllocs(currentAllocs)
 
 		for _, allocsGrp := range allocsByPriority {
 			allocs := allocsGrp.allocs
@@ -546,7 +576,7 @@ func (p *Preemptor) PreemptForDevice(ask *structs.RequestedDevice, devAlloc *dev
 OUTER:
 	for deviceIDTuple, allocsGrp := range deviceToAllocs {
 		// First group and sort allocations using this device by priority
-		allocsByPriority := filterAndGroupPreemptibleAllocs(p.jobPriority, allocsGrp.allocs)
+		allocsByPriority := p.filterAndGroupPreemptibleAllocs(allocsGrp.allocs)
 
 		// Reset preempted count for this device
 		preemptedCount := 0
@@ -688,18 +718,18 @@ func scoreForNetwork(resourceUsed *structs.NetworkResource, resourceNeeded *stru
 }
 
 // filterAndGroupPreemptibleAllocs groups allocations by priority after filtering allocs
-// that are not preemptible based on the jobPriority arg
-func filterAndGroupPreemptibleAllocs(jobPriority int, current []*structs.Allocation) []*groupedAllocs {
+// that are not preemptible by the job being placed
+func (p *Preemptor) filterAndGroupPreemptibleAllocs(current []*structs.Allocation) []*groupedAllocs {
 	allocsByPriority := make(map[int][]*structs.Allocation)
 	for _, alloc := range current {
 		if alloc.Job == nil {
 			continue
 		}
 
-		// Skip allocs whose priority is within a delta of 10
-		// This also skips any allocs of the current job
-		// for which we are attempting preemption
-		if jobPriority-alloc.Job.Priority < 10 {
+		// Skip allocs whose priority is within a delta of 10, unless
+		// fair-share allows preempting them. This also skips any allocs of
+		// the current job for which we are attempting preemption
+		if !p.preemptible(alloc) {
 			continue
 		}
 		grpAllocs, ok := allocsByPriority[alloc.Job.Priority]
@@ -1436,6 +1436,99 @@ func TestPreemption_Normal(t *testing.T) {
 	}
 }
 
+func TestPreemption_FairShare(t *testing.T) {
+	ci.Parallel(t)
+
+	legacyCpuResources, processorResources := cpuResources(4000)
+	nodeResources := &structs.NodeResources{
+		Processors: processorResources,
+		Cpu:        legacyCpuResources,
+		Memory: structs.NodeMemoryResources{
+			MemoryMB: 8192,
+		},
+		Disk: structs.NodeDiskResources{
+			DiskMB: 100 * 1024,
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		fairShare structs.FairShareConfig
+		preempted bool
+	}{
+		{
+			name:      "disabled",
+			fairShare: structs.FairShareConfig{},
+		},
+		{
+			name:      "over share",
+			fairShare: structs.FairShareConfig{Enabled: true},
+			preempted: true,
+		},
+		{
+			name: "under share",
+			fairShare: structs.FairShareConfig{
+				Enabled: true,
+				Weights: map[string]int{"batch-team": 4},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			state, ctx := testContext(t)
+
+			node := mock.Node()
+			node.NodeResources = nodeResources
+			node.ReservedResources = nil
+			require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+			// The existing alloc has the same priority as the job being placed
+			// and uses 75% of the cluster
+			otherJob := mock.Job()
+			otherJob.Namespace = "batch-team"
+			alloc := createAlloc(uuid.Generate(), otherJob, &structs.Resources{
+				CPU:      3000,
+				MemoryMB: 6144,
+			})
+			alloc.Namespace = otherJob.Namespace
+			alloc.NodeID = node.ID
+			require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{alloc}))
+
+			static := NewStaticRankIterator(ctx, []*RankedNode{{Node: node}})
+			binPackIter := NewBinPackIterator(ctx, static, true, 0)
+			job := mock.Job()
+			binPackIter.SetJob(job)
+
+			schedConfig := testSchedulerConfig.Copy()
+			schedConfig.FairShareConfig = tc.fairShare
+			binPackIter.SetSchedulerConfiguration(schedConfig)
+
+			binPackIter.SetTaskGroup(&structs.TaskGroup{
+				EphemeralDisk: &structs.EphemeralDisk{},
+				Tasks: []*structs.Task{
+					{
+						Name: "web",
+						Resources: &structs.Resources{
+							CPU:      2000,
+							MemoryMB: 2048,
+						},
+					},
+				},
+			})
+
+			option := binPackIter.Next()
+			if !tc.preempted {
+				require.Nil(t, option)
+				return
+			}
+			require.NotNil(t, option)
+			require.Len(t, option.PreemptedAllocs, 1)
+			require.Equal(t, alloc.ID, option.PreemptedAllocs[0].ID)
+		})
+	}
+}
+
 // TestPreemptionMultiple tests evicting multiple allocations in the same time
 func TestPreemptionMultiple(t *testing.T) {
 	ci.Parallel(t)
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math"
 	"slices"
+	"strconv"
 
 	"github.com/hashicorp/go-set/v3"
 	"github.com/hashicorp/nomad/client/lib/idset"
@@ -166,6 +167,12 @@ type BinPackIterator struct {
 	taskGroup              *structs.TaskGroup
 	memoryOversubscription bool
 	scoreFit               func(*structs.Node, *structs.ComparableResources) float64
+
+	// fairShareConfig is the fair-share configuration if fair-share
+	// scheduling is enabled, and fairShare is the usage computed from it the
+	// first time preemption is attempted.
+	fairShareConfig *structs.FairShareConfig
+	fairShare       *FairShare
 }
 
 // NewBinPackIterator returns a BinPackIterator which tries to fit tasks
@@ -204,6 +211,29 @@ func (iter *BinPackIterator) SetSchedulerConfiguration(schedConfig *structs.Sche
 
 	// Set memory oversubscription.
 	iter.memoryOversubscription = schedConfig != nil && schedConfig.MemoryOversubscriptionEnabled
+
+	// Set fair-share preemption.
+	iter.fairShareConfig = nil
+	iter.fairShare = nil
+	if schedConfig != nil && schedConfig.FairShareConfig.Enabled {
+		iter.fairShareConfig = &schedConfig.FairShareConfig
+	}
+}
+
+// getFairShare returns the fair-share usage of the cluster, computing it the
+// first time it's needed. It returns nil if fair-share scheduling is disabled.
+func (iter *BinPackIterator) getFairShare() *FairShare {
+	if iter.fairShareConfig == nil || iter.fairShare != nil {
+		return iter.fairShare
+	}
+
+	fairShare, err := NewFairShare(nil, iter.ctx.State(), iter.fairShareConfig)
+	if err != nil {
+		iter.ctx.Logger().Named("binpack").Error("failed computing fair-share usage", "error", err)
+		return nil
+	}
+	iter.fairShare = fairShare
+	return fairShare
 }
 
 func (iter *BinPackIterator) Next() *RankedNode {
@@ -277,6 +307,9 @@ NEXTNODE:
 		// Initialize preemptor with node
 		preemptor := NewPreemptor(iter.priority, iter.ctx, &iter.jobId)
 		preemptor.SetNode(option.Node)
+		if iter.evict {
+			preemptor.SetFairShare(iter.getFairShare())
+		}
 
 		// Count the number of existing preemptions
 		allPreemptions := iter.ctx.Plan().NodePreemptions
@@ -378,6 +411,10 @@ NEXTNODE:
 					MemoryMB: safemath.Add(
 						int64(task.Resources.MemoryMB), int64(task.Resources.SecretsMB)),
 				},
+				IO: structs.AllocatedIOResources{
+					Weight: int64(task.Resources.IOWeight),
+					Max:    task.Resources.IOMax.Copy(),
+				},
 			}
 			if iter.memoryOversubscription {
 				taskResources.Memory.MemoryMaxMB = safemath.Add(
@@ -900,6 +937,132 @@ func (iter *NodeReschedulingPenaltyIterator) Reset() {
 	iter.source.Reset()
 }
 
+// NodeCostIterator is a RankIterator used by the cost scheduler algorithm to
+// prefer the cheapest nodes. The cost of a node is read from NodeMetaCost in
+// its meta or the meta of its node pool, and is scored relative to the costs
+// of the other nodes being considered: the cheapest node scores 1 and the most
+// expensive 0. Nodes without a cost are not scored.
+//
+// Scores are kept positive so that negative scores from spread blocks are
+// able to outweigh the preference for cheaper nodes.
+type NodeCostIterator struct {
+	ctx     Context
+	source  RankIterator
+	enabled bool
+
+	minCost float64
+	maxCost float64
+
+	// poolCosts caches the cost set on each node pool, or nil if the pool
+	// doesn't set a valid cost.
+	poolCosts map[string]*float64
+}
+
+// NewNodeCostIterator is used to create a NodeCostIterator that applies a
+// score based on the cost of nodes when the cost scheduler algorithm is used.
+func NewNodeCostIterator(ctx Context, source RankIterator) *NodeCostIterator {
+	return &NodeCostIterator{
+		ctx:       ctx,
+		source:    source,
+		poolCosts: make(map[string]*float64),
+	}
+}
+
+func (iter *NodeCostIterator) SetSchedulerConfiguration(schedConfig *structs.SchedulerConfiguration) {
+	iter.enabled = schedConfig.EffectiveSchedulerAlgorithm() == structs.SchedulerAlgorithmCost
+}
+
+// SetNodes computes the range of costs of the nodes that will be considered,
+// which is used to scale the score of each node.
+func (iter *NodeCostIterator) SetNodes(nodes []*structs.Node) {
+	iter.minCost, iter.maxCost = 0, 0
+	if !iter.enabled {
+		return
+	}
+
+	first := true
+	for _, node := range nodes {
+		cost, ok := iter.nodeCost(node)
+		if !ok {
+			continue
+		}
+		if first || cost < iter.minCost {
+			iter.minCost = cost
+		}
+		if first || cost > iter.maxCost {
+			iter.maxCost = cost
+		}
+		first = false
+	}
+}
+
+// hasCosts returns true if the nodes are being scored by cost.
+func (iter *NodeCostIterator) hasCosts() bool {
+	return iter.enabled && iter.maxCost > iter.minCost
+}
+
+// nodeCost returns the cost of a node and whether it has one.
+func (iter *NodeCostIterator) nodeCost(node *structs.Node) (float64, bool) {
+	if cost, ok := parseNodeCost(node.Meta[structs.NodeMetaCost]); ok {
+		return cost, true
+	}
+
+	poolCost, ok := iter.poolCosts[node.NodePool]
+	if !ok {
+		pool, err := iter.ctx.State().NodePoolByName(nil, node.NodePool)
+		if err != nil {
+			iter.ctx.Logger().Named("node_cost").Error("failed to lookup node pool",
+				"node_pool", node.NodePool, "error", err)
+		} else if pool != nil {
+			if cost, ok := parseNodeCost(pool.Meta[structs.NodeMetaCost]); ok {
+				poolCost = &cost
+			}
+		}
+		iter.poolCosts[node.NodePool] = poolCost
+	}
+
+	if poolCost == nil {
+		return 0, false
+	}
+	return *poolCost, true
+}
+
+// parseNodeCost parses a cost meta value, which must be a non-negative number.
+func parseNodeCost(value string) (float64, bool) {
+	if value == "" {
+		return 0, false
+	}
+	cost, err := strconv.ParseFloat(value, 64)
+	if err != nil || cost < 0 || math.IsNaN(cost) || math.IsInf(cost, 0) {
+		return 0, false
+	}
+	return cost, true
+}
+
+func (iter *NodeCostIterator) Next() *RankedNode {
+	option := iter.source.Next()
+	if option == nil {
+		return nil
+	}
+	if !iter.hasCosts() {
+		return option
+	}
+
+	cost, ok := iter.nodeCost(option.Node)
+	if !ok {
+		return option
+	}
+
+	score := (iter.maxCost - cost) / (iter.maxCost - iter.minCost)
+	option.Scores = append(option.Scores, score)
+	iter.ctx.Metrics().ScoreNode(option.Node, "node-cost", score)
+	return option
+}
+
+func (iter *NodeCostIterator) Reset() {
+	iter.source.Reset()
+}
+
 // NodeAffinityIterator is used to resolve any affinity rules in the job or task group,
 // and apply a weighted score to nodes if they match.
 type NodeAffinityIterator struct {
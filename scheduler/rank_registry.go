@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// RankIteratorFactory is used to instantiate a RankIterator that is inserted
+// into the ranking stage of the scheduler stacks. The returned iterator must
+// consume nodes from source. Any score it produces should be in the range
+// [-1, 1], scaled by weight, appended to RankedNode.Scores and recorded with
+// ctx.Metrics().ScoreNode so it is averaged with the built-in scorers by the
+// ScoreNormalizationIterator.
+//
+// If the returned iterator implements ContextualIterator it will be given
+// the job and task group being placed. If it implements
+// SchedulerConfigurableIterator it will be given the scheduler configuration
+// for the job's node pool.
+type RankIteratorFactory func(ctx Context, source RankIterator, weight float64) RankIterator
+
+// SchedulerConfigurableIterator is an iterator that can have the scheduler
+// configuration set on it.
+type SchedulerConfigurableIterator interface {
+	SetSchedulerConfiguration(*structs.SchedulerConfiguration)
+}
+
+// rankIteratorFactories contains the registered rank iterators which can be
+// enabled in the scheduler configuration.
+var rankIteratorFactories = map[string]RankIteratorFactory{}
+
+// RegisterRankIterator registers a RankIterator factory under the given name
+// so that it may be referenced by the RankIterators field of the scheduler
+// configuration. It is not safe for concurrent use and must be called during
+// initialization, before any scheduler is created.
+func RegisterRankIterator(name string, factory RankIteratorFactory) error {
+	if name == "" {
+		return fmt.Errorf("rank iterator name must not be empty")
+	}
+	if factory == nil {
+		return fmt.Errorf("rank iterator %q factory must not be nil", name)
+	}
+	if _, ok := rankIteratorFactories[name]; ok {
+		return fmt.Errorf("rank iterator %q already registered", name)
+	}
+
+	rankIteratorFactories[name] = factory
+	return nil
+}
+
+// RegisteredRankIterators returns the sorted names of all registered rank
+// iterators.
+func RegisteredRankIterators() []string {
+	names := make([]string, 0, len(rankIteratorFactories))
+	for name := range rankIteratorFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// newConfiguredRankIterators chains the rank iterators enabled in the
+// scheduler configuration onto source. It returns the last iterator of the
+// chain, which is source if none are enabled, along with the iterators that
+// were created. Iterators that are not registered are skipped.
+func newConfiguredRankIterators(ctx Context, source RankIterator, schedConfig *structs.SchedulerConfiguration) (RankIterator, []RankIterator) {
+	if schedConfig == nil || len(schedConfig.RankIterators) == 0 {
+		return source, nil
+	}
+
+	iters := make([]RankIterator, 0, len(schedConfig.RankIterators))
+	for _, conf := range schedConfig.RankIterators {
+		// A weight of 0 disables the iterator
+		if conf == nil || conf.GetWeight() == 0 {
+			continue
+		}
+
+		factory, ok := rankIteratorFactories[conf.Name]
+		if !ok {
+			ctx.Logger().Warn("skipping unknown rank iterator", "rank_iterator", conf.Name)
+			continue
+		}
+
+		source = factory(ctx, source, conf.GetWeight())
+		iters = append(iters, source)
+	}
+
+	return source, iters
+}
+
+// setRankIteratorsJob sets the job on the configured rank iterators that
+// implement ContextualIterator.
+func setRankIteratorsJob(iters []RankIterator, job *structs.Job) {
+	for _, iter := range iters {
+		if contextual, ok := iter.(ContextualIterator); ok {
+			contextual.SetJob(job)
+		}
+	}
+}
+
+// setRankIteratorsTaskGroup sets the task group on the configured rank
+// iterators that implement ContextualIterator.
+func setRankIteratorsTaskGroup(iters []RankIterator, tg *structs.TaskGroup) {
+	for _, iter := range iters {
+		if contextual, ok := iter.(ContextualIterator); ok {
+			contextual.SetTaskGroup(tg)
+		}
+	}
+}
+
+// setRankIteratorsSchedulerConfiguration sets the scheduler configuration on
+// the configured rank iterators that implement SchedulerConfigurableIterator.
+func setRankIteratorsSchedulerConfiguration(iters []RankIterator, schedConfig *structs.SchedulerConfiguration) {
+	for _, iter := range iters {
+		if configurable, ok := iter.(SchedulerConfigurableIterator); ok {
+			configurable.SetSchedulerConfiguration(schedConfig)
+		}
+	}
+}
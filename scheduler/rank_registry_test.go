@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+const testMetaRankIterator = "test-prefer-meta"
+
+func init() {
+	err := RegisterRankIterator(testMetaRankIterator, newTestMetaRankIterator)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// testMetaIterator prefers nodes with the "warm" meta key set to the
+// task group name.
+type testMetaIterator struct {
+	ctx    Context
+	source RankIterator
+	weight float64
+	tg     string
+}
+
+func newTestMetaRankIterator(ctx Context, source RankIterator, weight float64) RankIterator {
+	return &testMetaIterator{ctx: ctx, source: source, weight: weight}
+}
+
+func (iter *testMetaIterator) SetJob(*structs.Job) {}
+
+func (iter *testMetaIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.tg = tg.Name
+}
+
+func (iter *testMetaIterator) Next() *RankedNode {
+	option := iter.source.Next()
+	if option == nil {
+		return nil
+	}
+
+	score := -iter.weight
+	if option.Node.Meta["warm"] == iter.tg {
+		score = iter.weight
+	}
+	option.Scores = append(option.Scores, score)
+	iter.ctx.Metrics().ScoreNode(option.Node, testMetaRankIterator, score)
+	return option
+}
+
+func (iter *testMetaIterator) Reset() {
+	iter.source.Reset()
+}
+
+func TestRegisterRankIterator(t *testing.T) {
+	ci.Parallel(t)
+
+	must.ErrorContains(t, RegisterRankIterator(testMetaRankIterator, newTestMetaRankIterator),
+		"already registered")
+	must.ErrorContains(t, RegisterRankIterator("", newTestMetaRankIterator), "must not be empty")
+	must.ErrorContains(t, RegisterRankIterator("nil-factory", nil), "must not be nil")
+	must.SliceContains(t, RegisteredRankIterators(), testMetaRankIterator)
+}
+
+func TestServiceStack_Select_RankIterators(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+	must.NoError(t, state.SchedulerSetConfig(100, &structs.SchedulerConfiguration{
+		RankIterators: []*structs.SchedulerRankIterator{
+			{Name: "unknown"},
+			{Name: testMetaRankIterator, Weight: pointer.Of(0.5)},
+		},
+	}))
+
+	job := mock.Job()
+	cold, warm := mock.Node(), mock.Node()
+	warm.Meta["warm"] = job.TaskGroups[0].Name
+
+	stack := NewGenericStack(false, ctx)
+	must.Len(t, 1, stack.rankIterators)
+	stack.SetNodes([]*structs.Node{cold, warm})
+	stack.SetJob(job)
+
+	node := stack.Select(job.TaskGroups[0], &SelectOptions{})
+	must.NotNil(t, node)
+	must.Eq(t, warm.ID, node.Node.ID)
+
+	ctx.Metrics().PopulateScoreMetaData()
+	scores := ctx.Metrics().ScoreMetaData
+	must.SliceNotEmpty(t, scores)
+	for _, meta := range scores {
+		switch meta.NodeID {
+		case warm.ID:
+			must.Eq(t, 0.5, meta.Scores[testMetaRankIterator])
+		case cold.ID:
+			must.Eq(t, -0.5, meta.Scores[testMetaRankIterator])
+		}
+	}
+}
+
+func TestSystemStack_Select_RankIterators(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+	must.NoError(t, state.SchedulerSetConfig(100, &structs.SchedulerConfiguration{
+		RankIterators: []*structs.SchedulerRankIterator{
+			{Name: testMetaRankIterator},
+		},
+	}))
+
+	job := mock.SystemJob()
+	node := mock.Node()
+	node.Meta["warm"] = job.TaskGroups[0].Name
+
+	stack := NewSystemStack(false, ctx)
+	must.Len(t, 1, stack.rankIterators)
+	stack.SetNodes([]*structs.Node{node})
+	stack.SetJob(job)
+
+	option := stack.Select(job.TaskGroups[0], &SelectOptions{})
+	must.NotNil(t, option)
+	must.SliceContains(t, option.Scores, 1.0)
+}
+
+func TestGenericStack_RankIterators_Disabled(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+	must.NoError(t, state.SchedulerSetConfig(100, &structs.SchedulerConfiguration{
+		RankIterators: []*structs.SchedulerRankIterator{
+			{Name: testMetaRankIterator, Weight: pointer.Of(0.0)},
+		},
+	}))
+
+	stack := NewGenericStack(false, ctx)
+	must.SliceEmpty(t, stack.rankIterators)
+}
@@ -7,6 +7,7 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/lib/idset"
 	"github.com/hashicorp/nomad/client/lib/numalib"
 	"github.com/hashicorp/nomad/client/lib/numalib/hw"
@@ -2334,3 +2335,57 @@ func TestNodeAffinityIterator(t *testing.T) {
 	}
 
 }
+
+func TestNodeCostIterator(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+
+	pool := mock.NodePool()
+	pool.Meta = map[string]string{structs.NodeMetaCost: "2"}
+	must.NoError(t, state.UpsertNodePools(structs.MsgTypeTestSetup, 100, []*structs.NodePool{pool}))
+
+	nodes := []*structs.Node{mock.Node(), mock.Node(), mock.Node(), mock.Node(), mock.Node()}
+	nodes[0].Meta[structs.NodeMetaCost] = "1"
+	nodes[1].Meta[structs.NodeMetaCost] = "3"
+	nodes[2].NodePool = pool.Name
+	nodes[3].Meta[structs.NodeMetaCost] = "invalid"
+	nodes[4].NodePool = pool.Name
+	nodes[4].Meta[structs.NodeMetaCost] = "3"
+
+	ranked := make([]*RankedNode, len(nodes))
+	for i, node := range nodes {
+		ranked[i] = &RankedNode{Node: node}
+	}
+	static := NewStaticRankIterator(ctx, ranked)
+
+	nodeCost := NewNodeCostIterator(ctx, static)
+	nodeCost.SetSchedulerConfiguration(&structs.SchedulerConfiguration{
+		SchedulerAlgorithm: structs.SchedulerAlgorithmCost,
+	})
+	nodeCost.SetNodes(nodes)
+	must.True(t, nodeCost.hasCosts())
+
+	out := collectRanked(nodeCost)
+	must.Len(t, 5, out)
+	must.Eq(t, []float64{1}, out[0].Scores)
+	must.Eq(t, []float64{0}, out[1].Scores)
+	must.Eq(t, []float64{0.5}, out[2].Scores)
+	must.SliceEmpty(t, out[3].Scores)
+	must.Eq(t, []float64{0}, out[4].Scores)
+
+	// nodes are not scored by other algorithms
+	static.Reset()
+	for _, node := range ranked {
+		node.Scores = nil
+	}
+	nodeCost.SetSchedulerConfiguration(testSchedulerConfig)
+	nodeCost.SetNodes(nodes)
+	must.False(t, nodeCost.hasCosts())
+
+	out = collectRanked(nodeCost)
+	must.Len(t, 5, out)
+	for _, option := range out {
+		must.SliceEmpty(t, option.Scores)
+	}
+}
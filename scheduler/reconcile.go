@@ -559,6 +559,11 @@ func (a *allocReconciler) computeGroup(groupName string, all allocSet) bool {
 
 	desiredChanges.Ignore += uint64(len(ignoreUpdates))
 	desiredChanges.InPlaceUpdate += uint64(len(inplace))
+	for _, alloc := range inplace {
+		if tasksResized(alloc.Job, tg) {
+			desiredChanges.InPlaceResize++
+		}
+	}
 	if !existingDeployment {
 		dstate.DesiredTotal += len(destructive) + len(inplace)
 	}
@@ -598,6 +603,7 @@ func (a *allocReconciler) computeGroup(groupName string, all allocSet) bool {
 	underProvisionedBy = a.computeReplacements(deploymentPlaceReady, desiredChanges, place, rescheduleNow, lost, underProvisionedBy)
 
 	if deploymentPlaceReady {
+		underProvisionedBy = a.computeStepLimit(dstate, untainted, underProvisionedBy)
 		a.computeDestructiveUpdates(destructive, underProvisionedBy, desiredChanges, tg)
 	} else {
 		desiredChanges.Ignore += uint64(len(destructive))
@@ -632,6 +638,10 @@ func (a *allocReconciler) initializeDeploymentState(group string, tg *structs.Ta
 			dstate.AutoRevert = tg.Update.AutoRevert
 			dstate.AutoPromote = tg.Update.AutoPromote
 			dstate.ProgressDeadline = tg.Update.ProgressDeadline
+			if len(tg.Update.Steps) != 0 {
+				dstate.RolloutSteps = len(tg.Update.Steps)
+				dstate.StepPercent = tg.Update.Steps[0].Percent
+			}
 		}
 	}
 
@@ -926,6 +936,23 @@ func (a *allocReconciler) computeReplacements(deploymentPlaceReady bool, desired
 	return underProvisionedBy
 }
 
+// computeStepLimit caps the number of destructive updates to the allocations
+// that remain to be placed by the current rollout step of the group, if it is
+// rolled out in steps.
+func (a *allocReconciler) computeStepLimit(dstate *structs.DeploymentState, untainted allocSet, underProvisionedBy int) int {
+	if dstate == nil || dstate.RolloutSteps == 0 {
+		return underProvisionedBy
+	}
+
+	placed := 0
+	if a.deployment != nil {
+		partOf, _ := untainted.filterByDeployment(a.deployment.ID)
+		placed = len(partOf)
+	}
+
+	return max(0, min(underProvisionedBy, dstate.StepTarget()-placed))
+}
+
 func (a *allocReconciler) computeDestructiveUpdates(destructive allocSet, underProvisionedBy int,
 	desiredChanges *structs.DesiredUpdates, tg *structs.TaskGroup) {
 
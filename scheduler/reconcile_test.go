@@ -2833,6 +2833,83 @@ func TestReconciler_CreateDeployment_RollingUpgrade_Destructive(t *testing.T) {
 	assertNamesHaveIndexes(t, intRange(0, 3), destructiveResultsToNames(r.destructiveUpdate))
 }
 
+// Tests the reconciler limits destructive updates to the current rollout step
+// of a task group rolled out in steps
+func TestReconciler_RolloutSteps(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	job.TaskGroups[0].Update = noCanaryUpdate.Copy()
+	job.TaskGroups[0].Update.Steps = []*structs.RolloutStep{
+		{Percent: 10, Pause: 10 * time.Minute},
+		{Percent: 50},
+		{Percent: 100},
+	}
+
+	cases := []struct {
+		name        string
+		newAllocs   int
+		step        int
+		destructive int
+	}{
+		{name: "first step", newAllocs: 0, step: 0, destructive: 1},
+		{name: "first step placed", newAllocs: 1, step: 0, destructive: 0},
+		{name: "second step", newAllocs: 1, step: 1, destructive: 4},
+		{name: "last step", newAllocs: 5, step: 2, destructive: 4},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := structs.NewDeployment(job, 50, time.Now().UnixNano())
+			d.TaskGroups[job.TaskGroups[0].Name] = &structs.DeploymentState{
+				DesiredTotal: 10,
+				PlacedAllocs: c.newAllocs,
+				RolloutSteps: 3,
+				CurrentStep:  c.step,
+				StepPercent:  job.TaskGroups[0].Update.Steps[c.step].Percent,
+			}
+
+			var allocs []*structs.Allocation
+			handled := make(map[string]allocUpdateType)
+			for i := 0; i < 10; i++ {
+				alloc := mock.Alloc()
+				alloc.Job = job
+				alloc.JobID = job.ID
+				alloc.NodeID = uuid.Generate()
+				alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+				alloc.TaskGroup = job.TaskGroups[0].Name
+				if i < c.newAllocs {
+					alloc.DeploymentID = d.ID
+					alloc.DeploymentStatus = &structs.AllocDeploymentStatus{
+						Healthy: pointer.Of(true),
+					}
+					handled[alloc.ID] = allocUpdateFnIgnore
+				}
+				allocs = append(allocs, alloc)
+			}
+
+			// The first step creates the deployment
+			if c.newAllocs == 0 {
+				d = nil
+			}
+
+			mockUpdateFn := allocUpdateFnMock(handled, allocUpdateFnDestructive)
+			reconciler := NewAllocReconciler(testlog.HCLogger(t), mockUpdateFn, false, job.ID, job,
+				d, allocs, nil, "", 50, true)
+			r := reconciler.Compute()
+
+			must.Eq(t, uint64(c.destructive), r.desiredTGUpdates[job.TaskGroups[0].Name].DestructiveUpdate)
+			if d == nil {
+				must.NotNil(t, r.deployment)
+				dstate := r.deployment.TaskGroups[job.TaskGroups[0].Name]
+				must.Eq(t, 3, dstate.RolloutSteps)
+				must.Eq(t, 0, dstate.CurrentStep)
+				must.Eq(t, 10, dstate.StepPercent)
+			}
+		})
+	}
+}
+
 // Tests the reconciler creates a deployment for inplace updates
 func TestReconciler_CreateDeployment_RollingUpgrade_Inplace(t *testing.T) {
 	ci.Parallel(t)
@@ -0,0 +1,483 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Simulate applies the changes of the simulation to the state store and runs
+// the schedulers against the evaluations they would create, reporting the
+// placements, failures and node pool utilization that result. The state store
+// is modified by the simulation, so it must be a copy of the cluster state
+// that can be discarded afterwards, such as a snapshot or a state store
+// restored from a snapshot archive.
+//
+// Jobs are registered as given, so callers should canonicalize and validate
+// them first. Only the evaluations created by the changes are processed;
+// follow-up evaluations, such as the next steps of a rolling update, are not.
+func Simulate(logger log.Logger, store *state.StateStore, sim *structs.SchedulerSimulation) (*structs.SchedulerSimulationResult, error) {
+	if err := sim.Validate(); err != nil {
+		return nil, err
+	}
+
+	index, err := store.LatestIndex()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest index: %v", err)
+	}
+	p := &simulationPlanner{
+		state:     store,
+		nextIndex: index + 1,
+	}
+
+	before, err := nodePoolUtilization(store)
+	if err != nil {
+		return nil, err
+	}
+
+	var evals []*structs.Evaluation
+	addEvals, err := simulateAddNodes(p, sim.AddNodes)
+	if err != nil {
+		return nil, err
+	}
+	evals = append(evals, addEvals...)
+
+	drainEvals, err := simulateDrainNodePools(p, sim.DrainNodePools)
+	if err != nil {
+		return nil, err
+	}
+	evals = append(evals, drainEvals...)
+
+	jobEvals, err := simulateRegisterJobs(p, sim.Jobs)
+	if err != nil {
+		return nil, err
+	}
+	evals = append(evals, jobEvals...)
+
+	// Process the evaluations in the order the broker would dequeue them
+	sort.SliceStable(evals, func(i, j int) bool {
+		return evals[i].Priority > evals[j].Priority
+	})
+	if err := store.UpsertEvals(structs.MsgTypeTestSetup, p.NextIndex(), evals); err != nil {
+		return nil, fmt.Errorf("failed to upsert evaluations: %v", err)
+	}
+
+	result := &structs.SchedulerSimulationResult{}
+	for _, eval := range evals {
+		simulated, err := simulateEval(logger, p, eval)
+		if err != nil {
+			return nil, err
+		}
+		result.Evaluations = append(result.Evaluations, simulated)
+	}
+
+	after, err := nodePoolUtilization(store)
+	if err != nil {
+		return nil, err
+	}
+	for name, utilization := range after {
+		result.NodePools = append(result.NodePools, &structs.SimulatedNodePool{
+			Name:   name,
+			Before: before[name],
+			After:  utilization,
+		})
+	}
+	slices.SortFunc(result.NodePools, func(a, b *structs.SimulatedNodePool) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return result, nil
+}
+
+// simulationPlanner is the planner of a simulation. Plans are applied directly
+// to the state store of the simulation, without being checked against the
+// current state as the plan applier of the leader does, and the plans and
+// evaluation updates of the evaluation being simulated are kept for its
+// result.
+type simulationPlanner struct {
+	state     *state.StateStore
+	nextIndex uint64
+
+	plans   []*structs.Plan
+	updates []*structs.Evaluation
+}
+
+// NextIndex returns the next index of the state store of the simulation
+func (p *simulationPlanner) NextIndex() uint64 {
+	index := p.nextIndex
+	p.nextIndex++
+	return index
+}
+
+func (p *simulationPlanner) SubmitPlan(plan *structs.Plan) (*structs.PlanResult, State, error) {
+	p.plans = append(p.plans, plan)
+
+	index := p.NextIndex()
+	result := &structs.PlanResult{
+		NodeUpdate:      plan.NodeUpdate,
+		NodeAllocation:  plan.NodeAllocation,
+		NodePreemptions: plan.NodePreemptions,
+		AllocIndex:      index,
+	}
+
+	req := structs.ApplyPlanResultsRequest{
+		AllocUpdateRequest: structs.AllocUpdateRequest{
+			Job: plan.Job,
+		},
+		Deployment:        plan.Deployment,
+		DeploymentUpdates: plan.DeploymentUpdates,
+		EvalID:            plan.EvalID,
+	}
+
+	now := time.Now().UTC().UnixNano()
+	for _, allocs := range plan.NodeUpdate {
+		for _, alloc := range allocs {
+			req.AllocsStopped = append(req.AllocsStopped, alloc.AllocationDiff())
+		}
+	}
+	for _, allocs := range plan.NodeAllocation {
+		for _, alloc := range allocs {
+			if alloc.CreateTime == 0 {
+				alloc.CreateTime = now
+			}
+			req.AllocsUpdated = append(req.AllocsUpdated, alloc)
+		}
+	}
+	for _, allocs := range plan.NodePreemptions {
+		for _, alloc := range allocs {
+			diff := alloc.AllocationDiff()
+			diff.ModifyTime = now
+			req.AllocsPreempted = append(req.AllocsPreempted, diff)
+		}
+	}
+
+	if err := p.state.UpsertPlanResults(structs.MsgTypeTestSetup, index, &req); err != nil {
+		return nil, nil, err
+	}
+	return result, nil, nil
+}
+
+func (p *simulationPlanner) UpdateEval(eval *structs.Evaluation) error {
+	p.updates = append(p.updates, eval)
+	return nil
+}
+
+// CreateEval drops the follow-up evaluations of the simulated evaluations,
+// which aren't processed.
+func (p *simulationPlanner) CreateEval(*structs.Evaluation) error {
+	return nil
+}
+
+func (p *simulationPlanner) ReblockEval(*structs.Evaluation) error {
+	return nil
+}
+
+func (p *simulationPlanner) ServersMeetMinimumVersion(*version.Version, bool) bool {
+	return true
+}
+
+// simulateAddNodes adds copies of the template nodes and returns the
+// evaluations the server would create for them: one for every system and
+// sysbatch job, and the currently blocked evaluations that may now fit.
+func simulateAddNodes(p *simulationPlanner, add []*structs.SimulatedNodes) ([]*structs.Evaluation, error) {
+	if len(add) == 0 {
+		return nil, nil
+	}
+
+	for _, nodes := range add {
+		template, err := simulateNodeByPrefix(p.state, nodes.NodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := 0; i < nodes.Count; i++ {
+			node := template.Copy()
+			node.ID = uuid.Generate()
+			node.SecretID = uuid.Generate()
+			node.Name = fmt.Sprintf("%s-simulated-%d", template.Name, i+1)
+			node.Status = structs.NodeStatusReady
+			node.SchedulingEligibility = structs.NodeSchedulingEligible
+			node.DrainStrategy = nil
+			node.LastDrain = nil
+			node.Events = nil
+			if err := node.ComputeClass(); err != nil {
+				return nil, fmt.Errorf("failed to compute class of node %q: %v", node.Name, err)
+			}
+			if err := p.state.UpsertNode(structs.MsgTypeTestSetup, p.NextIndex(), node); err != nil {
+				return nil, fmt.Errorf("failed to add node %q: %v", node.Name, err)
+			}
+		}
+	}
+
+	var evals []*structs.Evaluation
+	now := time.Now().UTC().UnixNano()
+	for _, schedType := range []string{structs.JobTypeSystem, structs.JobTypeSysBatch} {
+		iter, err := p.state.JobsByScheduler(nil, schedType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s jobs: %v", schedType, err)
+		}
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			job := raw.(*structs.Job)
+			if job.Stopped() {
+				continue
+			}
+			evals = append(evals, &structs.Evaluation{
+				ID:             uuid.Generate(),
+				Namespace:      job.Namespace,
+				Priority:       job.Priority,
+				Type:           job.Type,
+				TriggeredBy:    structs.EvalTriggerNodeUpdate,
+				JobID:          job.ID,
+				JobModifyIndex: job.ModifyIndex,
+				Status:         structs.EvalStatusPending,
+				CreateTime:     now,
+				ModifyTime:     now,
+			})
+		}
+	}
+
+	iter, err := p.state.Evals(nil, state.SortDefault)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get evaluations: %v", err)
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		eval := raw.(*structs.Evaluation)
+		if eval.Status != structs.EvalStatusBlocked {
+			continue
+		}
+		eval = eval.Copy()
+		eval.Status = structs.EvalStatusPending
+		eval.ModifyTime = now
+		evals = append(evals, eval)
+	}
+
+	return evals, nil
+}
+
+// simulateNodeByPrefix returns the node with the given ID or unique ID prefix.
+func simulateNodeByPrefix(store *state.StateStore, prefix string) (*structs.Node, error) {
+	iter, err := store.NodesByIDPrefix(nil, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node %q: %v", prefix, err)
+	}
+
+	var node *structs.Node
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		if node != nil {
+			return nil, fmt.Errorf("node ID prefix %q matches multiple nodes", prefix)
+		}
+		node = raw.(*structs.Node)
+	}
+	if node == nil {
+		return nil, fmt.Errorf("node %q not found", prefix)
+	}
+	return node, nil
+}
+
+// simulateDrainNodePools drains every node of the node pools and marks their
+// allocations for migration, returning an evaluation for each job with
+// allocations to migrate.
+func simulateDrainNodePools(p *simulationPlanner, pools []string) ([]*structs.Evaluation, error) {
+	now := time.Now().UTC().UnixNano()
+	transitions := make(map[string]*structs.DesiredTransition)
+	jobs := make(map[structs.NamespacedID]struct{})
+	var evals []*structs.Evaluation
+
+	for _, name := range pools {
+		pool, err := p.state.NodePoolByName(nil, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get node pool %q: %v", name, err)
+		}
+		if pool == nil {
+			return nil, fmt.Errorf("node pool %q not found", name)
+		}
+
+		iter, err := p.state.NodesByNodePool(nil, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get nodes of node pool %q: %v", name, err)
+		}
+		var nodes []*structs.Node
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			nodes = append(nodes, raw.(*structs.Node))
+		}
+
+		for _, node := range nodes {
+			drain := &structs.DrainStrategy{
+				DrainSpec: structs.DrainSpec{Deadline: -1},
+				StartedAt: time.Now().UTC(),
+			}
+			if err := p.state.UpdateNodeDrain(structs.MsgTypeTestSetup, p.NextIndex(),
+				node.ID, drain, false, now, nil, nil, ""); err != nil {
+				return nil, fmt.Errorf("failed to drain node %q: %v", node.ID, err)
+			}
+
+			allocs, err := p.state.AllocsByNodeTerminal(nil, node.ID, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get allocs for node %q: %v", node.ID, err)
+			}
+			for _, alloc := range allocs {
+				if alloc.Job == nil ||
+					(alloc.Job.Type != structs.JobTypeService && alloc.Job.Type != structs.JobTypeBatch) {
+					continue
+				}
+				transitions[alloc.ID] = &structs.DesiredTransition{Migrate: pointer.Of(true)}
+
+				id := structs.NewNamespacedID(alloc.JobID, alloc.Namespace)
+				if _, ok := jobs[id]; ok {
+					continue
+				}
+				eval := &structs.Evaluation{
+					ID:          uuid.Generate(),
+					Namespace:   alloc.Namespace,
+					Priority:    alloc.Job.Priority,
+					Type:        alloc.Job.Type,
+					TriggeredBy: structs.EvalTriggerNodeDrain,
+					JobID:       alloc.JobID,
+					Status:      structs.EvalStatusPending,
+					CreateTime:  now,
+					ModifyTime:  now,
+				}
+				jobs[id] = struct{}{}
+				evals = append(evals, eval)
+			}
+		}
+	}
+
+	if len(transitions) > 0 {
+		if err := p.state.UpdateAllocsDesiredTransitions(structs.MsgTypeTestSetup, p.NextIndex(), transitions, nil); err != nil {
+			return nil, fmt.Errorf("failed to migrate allocs: %v", err)
+		}
+	}
+	return evals, nil
+}
+
+// simulateRegisterJobs registers the jobs and returns their evaluations.
+// Periodic and parameterized jobs are registered without an evaluation, as
+// they are by the job register endpoint.
+func simulateRegisterJobs(p *simulationPlanner, jobs []*structs.Job) ([]*structs.Evaluation, error) {
+	now := time.Now().UTC().UnixNano()
+	var evals []*structs.Evaluation
+	for _, job := range jobs {
+		if err := p.state.UpsertJob(structs.MsgTypeTestSetup, p.NextIndex(), nil, job); err != nil {
+			return nil, fmt.Errorf("failed to register job %q: %v", job.ID, err)
+		}
+		if job.IsPeriodic() || job.IsParameterized() {
+			continue
+		}
+
+		registered, err := p.state.JobByID(nil, job.Namespace, job.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get job %q: %v", job.ID, err)
+		}
+		evals = append(evals, &structs.Evaluation{
+			ID:             uuid.Generate(),
+			Namespace:      job.Namespace,
+			Priority:       job.Priority,
+			Type:           job.Type,
+			TriggeredBy:    structs.EvalTriggerJobRegister,
+			JobID:          job.ID,
+			JobModifyIndex: registered.JobModifyIndex,
+			Status:         structs.EvalStatusPending,
+			CreateTime:     now,
+			ModifyTime:     now,
+		})
+	}
+	return evals, nil
+}
+
+// simulateEval runs the scheduler for the evaluation against the current
+// state and applies the resulting plans.
+func simulateEval(logger log.Logger, p *simulationPlanner, eval *structs.Evaluation) (*structs.SimulatedEvaluation, error) {
+	eval = eval.Copy()
+	eval.AnnotatePlan = true
+
+	p.plans, p.updates = nil, nil
+	snap, err := p.state.Snapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot state: %v", err)
+	}
+	sched, err := NewScheduler(eval.Type, logger, nil, snap, p)
+	if err != nil {
+		return nil, err
+	}
+	if err := sched.Process(eval); err != nil {
+		return nil, fmt.Errorf("failed to process evaluation for job %q: %v", eval.JobID, err)
+	}
+
+	simulated := &structs.SimulatedEvaluation{
+		Namespace:   eval.Namespace,
+		JobID:       eval.JobID,
+		TriggeredBy: eval.TriggeredBy,
+		Status:      eval.Status,
+	}
+	for _, plan := range p.plans {
+		if plan.Annotations != nil {
+			simulated.DesiredTGUpdates = plan.Annotations.DesiredTGUpdates
+		}
+		for _, preempted := range plan.NodePreemptions {
+			simulated.PreemptedAllocs += len(preempted)
+		}
+	}
+	if len(p.updates) > 0 {
+		update := p.updates[len(p.updates)-1]
+		simulated.Status = update.Status
+		simulated.FailedTGAllocs = update.FailedTGAllocs
+	}
+	return simulated, nil
+}
+
+// nodePoolUtilization returns the capacity and allocated resources of the
+// schedulable nodes of each node pool.
+func nodePoolUtilization(store *state.StateStore) (map[string]*structs.NodePoolUtilization, error) {
+	iter, err := store.Nodes(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nodes: %v", err)
+	}
+
+	pools := make(map[string]*structs.NodePoolUtilization)
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		utilization, ok := pools[node.NodePool]
+		if !ok {
+			utilization = &structs.NodePoolUtilization{}
+			pools[node.NodePool] = utilization
+		}
+		if !node.Ready() {
+			continue
+		}
+
+		capacity := node.NodeResources.Comparable()
+		if reserved := node.ReservedResources.Comparable(); reserved != nil {
+			capacity.Subtract(reserved)
+		}
+		utilization.Nodes++
+		utilization.CPUShares += capacity.Flattened.Cpu.CpuShares
+		utilization.MemoryMB += capacity.Flattened.Memory.MemoryMB
+
+		allocs, err := store.AllocsByNodeTerminal(nil, node.ID, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get allocs for node %q: %v", node.ID, err)
+		}
+		for _, alloc := range allocs {
+			resources := alloc.AllocatedResources.Comparable()
+			if resources == nil {
+				continue
+			}
+			utilization.CPUSharesUsed += resources.Flattened.Cpu.CpuShares
+			utilization.MemoryMBUsed += resources.Flattened.Memory.MemoryMB
+		}
+	}
+	return pools, nil
+}
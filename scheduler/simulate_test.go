@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestSimulate(t *testing.T) {
+	ci.Parallel(t)
+
+	// setup creates a cluster with a single node running one allocation of
+	// a service job.
+	setup := func(t *testing.T) (*Harness, *structs.Node, *structs.Job) {
+		h := NewHarness(t)
+		node := mock.Node()
+		must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+		job := mock.Job()
+		job.TaskGroups[0].Count = 1
+		must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+		alloc := mock.AllocForNode(node)
+		alloc.Job = job
+		alloc.JobID = job.ID
+		must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Allocation{alloc}))
+		return h, node, job
+	}
+
+	t.Run("add nodes", func(t *testing.T) {
+		h, node, _ := setup(t)
+
+		// The job needs more memory than the existing node has left
+		job := mock.Job()
+		job.TaskGroups[0].Count = 3
+		job.TaskGroups[0].Tasks[0].Resources.MemoryMB = 4096
+		job.Canonicalize()
+
+		result, err := Simulate(testlog.HCLogger(t), h.State, &structs.SchedulerSimulation{
+			Jobs: []*structs.Job{job},
+		})
+		must.NoError(t, err)
+		must.Len(t, 1, result.Evaluations)
+		must.Eq(t, structs.EvalStatusComplete, result.Evaluations[0].Status)
+		must.MapContainsKey(t, result.Evaluations[0].FailedTGAllocs, "web")
+
+		h, node, _ = setup(t)
+		result, err = Simulate(testlog.HCLogger(t), h.State, &structs.SchedulerSimulation{
+			AddNodes: []*structs.SimulatedNodes{{NodeID: node.ID[:8], Count: 2}},
+			Jobs:     []*structs.Job{job},
+		})
+		must.NoError(t, err)
+		must.Len(t, 1, result.Evaluations)
+		must.MapEmpty(t, result.Evaluations[0].FailedTGAllocs)
+		must.Eq(t, 3, result.Evaluations[0].DesiredTGUpdates["web"].Place)
+
+		must.Len(t, 1, result.NodePools)
+		pool := result.NodePools[0]
+		must.Eq(t, structs.NodePoolDefault, pool.Name)
+		must.Eq(t, 1, pool.Before.Nodes)
+		must.Eq(t, 3, pool.After.Nodes)
+		must.Eq(t, pool.Before.MemoryMBUsed+3*4096, pool.After.MemoryMBUsed)
+	})
+
+	t.Run("drain node pool", func(t *testing.T) {
+		h, _, job := setup(t)
+
+		result, err := Simulate(testlog.HCLogger(t), h.State, &structs.SchedulerSimulation{
+			DrainNodePools: []string{structs.NodePoolDefault},
+		})
+		must.NoError(t, err)
+		must.Len(t, 1, result.Evaluations)
+
+		eval := result.Evaluations[0]
+		must.Eq(t, job.ID, eval.JobID)
+		must.Eq(t, structs.EvalTriggerNodeDrain, eval.TriggeredBy)
+		must.Eq(t, 1, eval.DesiredTGUpdates["web"].Migrate)
+		must.MapContainsKey(t, eval.FailedTGAllocs, "web")
+		must.Eq(t, 0, result.NodePools[0].After.Nodes)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		h, _, _ := setup(t)
+
+		_, err := Simulate(testlog.HCLogger(t), h.State, &structs.SchedulerSimulation{})
+		must.ErrorContains(t, err, "must add nodes")
+
+		_, err = Simulate(testlog.HCLogger(t), h.State, &structs.SchedulerSimulation{
+			AddNodes: []*structs.SimulatedNodes{{NodeID: "ffffffff", Count: 1}},
+		})
+		must.ErrorContains(t, err, "not found")
+
+		_, err = Simulate(testlog.HCLogger(t), h.State, &structs.SchedulerSimulation{
+			DrainNodePools: []string{"missing"},
+		})
+		must.ErrorContains(t, err, `node pool "missing" not found`)
+	})
+}
@@ -67,10 +67,12 @@ type GenericStack struct {
 	binPack                    *BinPackIterator
 	jobAntiAff                 *JobAntiAffinityIterator
 	nodeReschedulingPenalty    *NodeReschedulingPenaltyIterator
+	nodeCost                   *NodeCostIterator
 	limit                      *LimitIterator
 	maxScore                   *MaxScoreIterator
 	nodeAffinity               *NodeAffinityIterator
 	spread                     *SpreadIterator
+	rankIterators              []RankIterator
 	scoreNorm                  *ScoreNormalizationIterator
 }
 
@@ -81,6 +83,7 @@ func (s *GenericStack) SetNodes(baseNodes []*structs.Node) {
 
 	// Update the set of base nodes
 	s.source.SetNodes(baseNodes)
+	s.nodeCost.SetNodes(baseNodes)
 
 	// Apply a limit function. This is to avoid scanning *every* possible node.
 	// For batch jobs we only need to evaluate 2 options and depend on the
@@ -114,6 +117,7 @@ func (s *GenericStack) SetJob(job *structs.Job) {
 	s.jobAntiAff.SetJob(job)
 	s.nodeAffinity.SetJob(job)
 	s.spread.SetJob(job)
+	setRankIteratorsJob(s.rankIterators, job)
 	s.ctx.Eligibility().SetJob(job)
 	s.taskGroupCSIVolumes.SetNamespace(job.Namespace)
 	s.taskGroupCSIVolumes.SetJobID(job.ID)
@@ -128,6 +132,8 @@ func (s *GenericStack) SetJob(job *structs.Job) {
 // on the node pool being used.
 func (s *GenericStack) SetSchedulerConfiguration(schedConfig *structs.SchedulerConfiguration) {
 	s.binPack.SetSchedulerConfiguration(schedConfig)
+	s.nodeCost.SetSchedulerConfiguration(schedConfig)
+	setRankIteratorsSchedulerConfiguration(s.rankIterators, schedConfig)
 }
 
 func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *RankedNode {
@@ -177,12 +183,14 @@ func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ra
 	}
 	s.nodeAffinity.SetTaskGroup(tg)
 	s.spread.SetTaskGroup(tg)
+	setRankIteratorsTaskGroup(s.rankIterators, tg)
 
-	if s.nodeAffinity.hasAffinities() || s.spread.hasSpreads() {
+	if s.nodeAffinity.hasAffinities() || s.spread.hasSpreads() || s.nodeCost.hasCosts() {
 		// scoring spread across all nodes has quadratic behavior, so
 		// we need to consider a subset of nodes to keep evaluaton times
 		// reasonable but enough to ensure spread is correct. this
-		// value was empirically determined.
+		// value was empirically determined. the same applies to finding
+		// the cheapest nodes.
 		s.limit.SetLimit(tg.Count)
 		if tg.Count < 100 {
 			s.limit.SetLimit(100)
@@ -221,6 +229,7 @@ type SystemStack struct {
 
 	distinctPropertyConstraint *DistinctPropertyIterator
 	binPack                    *BinPackIterator
+	rankIterators              []RankIterator
 	scoreNorm                  *ScoreNormalizationIterator
 }
 
@@ -309,8 +318,13 @@ func NewSystemStack(sysbatch bool, ctx Context) *SystemStack {
 	// Create binpack iterator
 	s.binPack = NewBinPackIterator(ctx, rankSource, enablePreemption, 0)
 
+	// Apply any additional rank iterators enabled in the scheduler
+	// configuration
+	var rankIter RankIterator
+	rankIter, s.rankIterators = newConfiguredRankIterators(ctx, s.binPack, schedConfig)
+
 	// Apply score normalization
-	s.scoreNorm = NewScoreNormalizationIterator(ctx, s.binPack)
+	s.scoreNorm = NewScoreNormalizationIterator(ctx, rankIter)
 	return s
 }
 
@@ -325,6 +339,7 @@ func (s *SystemStack) SetJob(job *structs.Job) {
 	s.jobConstraint.SetConstraints(job.Constraints)
 	s.distinctPropertyConstraint.SetJob(job)
 	s.binPack.SetJob(job)
+	setRankIteratorsJob(s.rankIterators, job)
 	s.ctx.Eligibility().SetJob(job)
 	s.taskGroupCSIVolumes.SetNamespace(job.Namespace)
 	s.taskGroupCSIVolumes.SetJobID(job.ID)
@@ -339,6 +354,7 @@ func (s *SystemStack) SetJob(job *structs.Job) {
 // on the node pool being used.
 func (s *SystemStack) SetSchedulerConfiguration(schedConfig *structs.SchedulerConfiguration) {
 	s.binPack.SetSchedulerConfiguration(schedConfig)
+	setRankIteratorsSchedulerConfiguration(s.rankIterators, schedConfig)
 }
 
 func (s *SystemStack) Select(tg *structs.TaskGroup, options *SelectOptions) *RankedNode {
@@ -362,6 +378,7 @@ func (s *SystemStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ran
 	s.wrappedChecks.SetTaskGroup(tg.Name)
 	s.distinctPropertyConstraint.SetTaskGroup(tg)
 	s.binPack.SetTaskGroup(tg)
+	setRankIteratorsTaskGroup(s.rankIterators, tg)
 
 	if contextual, ok := s.quota.(ContextualIterator); ok {
 		contextual.SetTaskGroup(tg)
@@ -454,14 +471,24 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// node where the allocation failed previously
 	s.nodeReschedulingPenalty = NewNodeReschedulingPenaltyIterator(ctx, s.jobAntiAff)
 
+	// Apply scores based on the cost of nodes
+	s.nodeCost = NewNodeCostIterator(ctx, s.nodeReschedulingPenalty)
+
 	// Apply scores based on affinity block
-	s.nodeAffinity = NewNodeAffinityIterator(ctx, s.nodeReschedulingPenalty)
+	s.nodeAffinity = NewNodeAffinityIterator(ctx, s.nodeCost)
 
 	// Apply scores based on spread block
 	s.spread = NewSpreadIterator(ctx, s.nodeAffinity)
 
+	// Apply any additional rank iterators enabled in the scheduler
+	// configuration. Only values that can't be specified per node pool should
+	// be read from state here.
+	_, schedConfig, _ := s.ctx.State().SchedulerConfig()
+	var rankIter RankIterator
+	rankIter, s.rankIterators = newConfiguredRankIterators(ctx, s.spread, schedConfig)
+
 	// Add the preemption options scoring iterator
-	preemptionScorer := NewPreemptionScoringIterator(ctx, s.spread)
+	preemptionScorer := NewPreemptionScoringIterator(ctx, rankIter)
 
 	// Normalizes scores by averaging them across various scorers
 	s.scoreNorm = NewScoreNormalizationIterator(ctx, preemptionScorer)
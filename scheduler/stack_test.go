@@ -456,6 +456,68 @@ func TestServiceStack_Select_BinPack_Overflow(t *testing.T) {
 	must.Len(t, 1, met.ScoreMetaData)
 }
 
+func TestServiceStack_Select_NodeCost(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+	cheap, expensive := mock.Node(), mock.Node()
+	cheap.Meta[structs.NodeMetaCost] = "0.5"
+	expensive.Meta[structs.NodeMetaCost] = "2.5"
+	expensive.Datacenter = "dc2"
+
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 900, cheap))
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 901, expensive))
+
+	job := mock.Job()
+	job.Datacenters = []string{"*"}
+	tg := job.TaskGroups[0]
+	tg.Spreads = []*structs.Spread{{
+		Attribute: "${node.datacenter}",
+		Weight:    100,
+	}}
+
+	stack := NewGenericStack(false, ctx)
+	stack.SetSchedulerConfiguration(&structs.SchedulerConfiguration{
+		SchedulerAlgorithm: structs.SchedulerAlgorithmCost,
+	})
+	stack.SetNodes([]*structs.Node{expensive, cheap})
+	stack.SetJob(job)
+
+	node := stack.Select(tg, &SelectOptions{})
+	must.NotNil(t, node)
+	must.Eq(t, cheap.ID, node.Node.ID)
+
+	ctx.Metrics().PopulateScoreMetaData()
+	for _, meta := range ctx.Metrics().ScoreMetaData {
+		switch meta.NodeID {
+		case cheap.ID:
+			must.Eq(t, 1.0, meta.Scores["node-cost"])
+		case expensive.ID:
+			must.Eq(t, 0.0, meta.Scores["node-cost"])
+		}
+	}
+
+	// spread blocks are still respected once an allocation is placed on the
+	// cheap node
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.TaskGroup = tg.Name
+	alloc.NodeID = cheap.ID
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc}))
+
+	stack = NewGenericStack(false, ctx)
+	stack.SetSchedulerConfiguration(&structs.SchedulerConfiguration{
+		SchedulerAlgorithm: structs.SchedulerAlgorithmCost,
+	})
+	stack.SetNodes([]*structs.Node{expensive, cheap})
+	stack.SetJob(job)
+
+	node = stack.Select(tg, &SelectOptions{})
+	must.NotNil(t, node)
+	must.Eq(t, expensive.ID, node.Node.ID)
+}
+
 func TestSystemStack_SetNodes(t *testing.T) {
 	ci.Parallel(t)
 
@@ -215,6 +215,13 @@ var same = comparison{modified: false}
 // tasksUpdated creates a comparison between task groups to see if the tasks, their
 // drivers, environment variables or config have been modified.
 func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) comparison {
+	return tasksUpdatedOnNode(jobA, jobB, taskGroup, nil)
+}
+
+// tasksUpdatedOnNode is like tasksUpdated, except that changes to the cpu and
+// memory of tasks whose driver can resize running tasks on the node are not
+// destructive. The node may be nil.
+func tasksUpdatedOnNode(jobA, jobB *structs.Job, taskGroup string, node *structs.Node) comparison {
 	a := jobA.LookupTaskGroup(taskGroup)
 	b := jobB.LookupTaskGroup(taskGroup)
 
@@ -308,6 +315,9 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) comparison {
 		if c := nonNetworkResourcesUpdated(at.Resources, bt.Resources); c.modified {
 			return c
 		}
+		if c := resizeResourcesUpdated(at.Resources, bt.Resources); c.modified && !taskCanResize(node, at) {
+			return c
+		}
 
 		// Inspect Identities being exposed
 		if !at.Identity.Equal(bt.Identity) {
@@ -346,24 +356,98 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) comparison {
 func nonNetworkResourcesUpdated(a, b *structs.Resources) comparison {
 	// Inspect the non-network resources
 	switch {
-	case a.CPU != b.CPU:
-		return difference("task cpu", a.CPU, b.CPU)
 	case a.Cores != b.Cores:
 		return difference("task cores", a.Cores, b.Cores)
-	case a.MemoryMB != b.MemoryMB:
-		return difference("task memory", a.MemoryMB, b.MemoryMB)
-	case a.MemoryMaxMB != b.MemoryMaxMB:
-		return difference("task memory max", a.MemoryMaxMB, b.MemoryMaxMB)
 	case !a.Devices.Equal(&b.Devices):
 		return difference("task devices", a.Devices, b.Devices)
 	case !a.NUMA.Equal(b.NUMA):
 		return difference("numa", a.NUMA, b.NUMA)
 	case a.SecretsMB != b.SecretsMB:
 		return difference("task secrets", a.SecretsMB, b.SecretsMB)
+	case a.IOWeight != b.IOWeight:
+		return difference("task io weight", a.IOWeight, b.IOWeight)
+	case !a.IOMax.Equal(b.IOMax):
+		return difference("task io max", a.IOMax, b.IOMax)
+	}
+	return same
+}
+
+// resizeResourcesUpdated returns whether the cpu or memory of a task changed.
+// These changes can be done in-place on nodes where the task driver can resize
+// running tasks.
+func resizeResourcesUpdated(a, b *structs.Resources) comparison {
+	switch {
+	case a.CPU != b.CPU:
+		return difference("task cpu", a.CPU, b.CPU)
+	case a.MemoryMB != b.MemoryMB:
+		return difference("task memory", a.MemoryMB, b.MemoryMB)
+	case a.MemoryMaxMB != b.MemoryMaxMB:
+		return difference("task memory max", a.MemoryMaxMB, b.MemoryMaxMB)
 	}
 	return same
 }
 
+// driverCanResize returns whether the driver on the node can resize running
+// tasks. The node may be nil.
+func driverCanResize(node *structs.Node, driver string) bool {
+	if node == nil {
+		return false
+	}
+	info, ok := node.Drivers[driver]
+	return ok && info.Attributes[structs.DriverResizeAttr(driver)] == "true"
+}
+
+// taskCanResize returns whether the task can be resized on the node. Tasks
+// running in a custom cgroup, set by the cgroup_v1_override or
+// cgroup_v2_override options of the raw_exec driver, can't be resized since
+// their cgroup isn't managed by Nomad. The node may be nil.
+func taskCanResize(node *structs.Node, task *structs.Task) bool {
+	if !driverCanResize(node, task.Driver) {
+		return false
+	}
+	for _, key := range []string{"cgroup_v1_override", "cgroup_v2_override"} {
+		if v, ok := task.Config[key]; ok && v != nil && v != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// tasksResizable returns whether the only destructive changes to the tasks of
+// an existing allocation are to the cpu and memory of tasks whose driver can
+// resize them on the node of the allocation.
+func tasksResizable(ctx Context, job *structs.Job, existing *structs.Allocation, taskGroup string) bool {
+	node, err := ctx.State().NodeByID(nil, existing.NodeID)
+	if err != nil || node == nil {
+		return false
+	}
+	return !tasksUpdatedOnNode(job, existing.Job, taskGroup, node).modified
+}
+
+// tasksResized returns whether the cpu or memory of any task of the group
+// differs from the job of an existing allocation. It is used to report the
+// in-place updates that resize running tasks.
+func tasksResized(existing *structs.Job, group *structs.TaskGroup) bool {
+	if existing == nil {
+		return false
+	}
+	tg := existing.LookupTaskGroup(group.Name)
+	if tg == nil {
+		return false
+	}
+	for _, task := range group.Tasks {
+		prev := tg.LookupTask(task.Name)
+		if prev == nil || prev.Resources == nil || task.Resources == nil {
+			continue
+		}
+		a, b := prev.Resources, task.Resources
+		if a.CPU != b.CPU || a.MemoryMB != b.MemoryMB || a.MemoryMaxMB != b.MemoryMaxMB {
+			return true
+		}
+	}
+	return false
+}
+
 // consulUpdated returns true if the Consul namespace or cluster in the task
 // group has been changed.
 //
@@ -526,6 +610,10 @@ func networkUpdated(netA, netB []*structs.NetworkResource) comparison {
 			return difference("network mbits", an.MBits, bn.MBits)
 		}
 
+		if an.EgressMBits != bn.EgressMBits {
+			return difference("network egress mbits", an.EgressMBits, bn.EgressMBits)
+		}
+
 		if an.Hostname != bn.Hostname {
 			return difference("network hostname", an.Hostname, bn.Hostname)
 		}
@@ -634,9 +722,12 @@ func inplaceUpdate(ctx Context, eval *structs.Evaluation, job *structs.Job,
 		update := updates[i]
 
 		// Check if the task drivers or config has changed, requires
-		// a rolling upgrade since that cannot be done in-place.
+		// a rolling upgrade since that cannot be done in-place. Tasks
+		// are only resized in-place on nodes where their driver supports
+		// it.
 		existing := update.Alloc.Job
-		if c := tasksUpdated(job, existing, update.TaskGroup.Name); c.modified {
+		if c := tasksUpdated(job, existing, update.TaskGroup.Name); c.modified &&
+			!tasksResizable(ctx, job, update.Alloc, update.TaskGroup.Name) {
 			continue
 		}
 
@@ -800,6 +891,9 @@ func desiredUpdates(diff *diffResult, inplaceUpdates,
 		}
 
 		des.InPlaceUpdate++
+		if tuple.Alloc != nil && tasksResized(tuple.Alloc.Job, tuple.TaskGroup) {
+			des.InPlaceResize++
+		}
 	}
 
 	for _, tuple := range destructiveUpdates {
@@ -883,8 +977,11 @@ func genericAllocUpdateFn(ctx Context, stack Stack, evalID string) allocUpdateTy
 		}
 
 		// Check if the task drivers or config has changed, requires
-		// a destructive upgrade since that cannot be done in-place.
-		if c := tasksUpdated(newJob, existing.Job, newTG.Name); c.modified {
+		// a destructive upgrade since that cannot be done in-place. Tasks
+		// are only resized in-place on nodes where their driver supports
+		// it.
+		if c := tasksUpdated(newJob, existing.Job, newTG.Name); c.modified &&
+			!tasksResizable(ctx, newJob, existing, newTG.Name) {
 			return false, true, nil
 		}
 
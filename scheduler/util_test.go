@@ -1107,6 +1107,62 @@ func TestTasksUpdated_NUMA(t *testing.T) {
 	must.True(t, tasksUpdated(j1, j2, name).modified)
 }
 
+func TestTasksUpdatedOnNode_Resize(t *testing.T) {
+	ci.Parallel(t)
+
+	j1 := mock.Job()
+	name := j1.TaskGroups[0].Name
+
+	j2 := j1.Copy()
+	j2.TaskGroups[0].Tasks[0].Resources.CPU = 1337
+	j2.TaskGroups[0].Tasks[0].Resources.MemoryMB = 1337
+	j2.TaskGroups[0].Tasks[0].Resources.MemoryMaxMB = 2048
+
+	node := mock.Node()
+	must.True(t, tasksUpdatedOnNode(j1, j2, name, node).modified)
+
+	// cpu and memory changes are in-place if the driver can resize tasks
+	node.Drivers["exec"].Attributes = map[string]string{
+		structs.DriverResizeAttr("exec"): "true",
+	}
+	must.False(t, tasksUpdatedOnNode(j1, j2, name, node).modified)
+	must.True(t, tasksUpdated(j1, j2, name).modified)
+
+	// but not if anything else changed
+	j3 := j2.Copy()
+	j3.TaskGroups[0].Tasks[0].Resources.Cores = 2
+	must.True(t, tasksUpdatedOnNode(j1, j3, name, node).modified)
+
+	// nor if the task runs in a custom cgroup
+	j1.TaskGroups[0].Tasks[0].Config["cgroup_v2_override"] = "custom.slice/app.scope"
+	j2.TaskGroups[0].Tasks[0].Config["cgroup_v2_override"] = "custom.slice/app.scope"
+	must.True(t, tasksUpdatedOnNode(j1, j2, name, node).modified)
+}
+
+func TestTasksUpdated_IO(t *testing.T) {
+	ci.Parallel(t)
+
+	j1 := mock.Job()
+	name := j1.TaskGroups[0].Name
+	j1.TaskGroups[0].Tasks[0].Resources.IOMax = structs.IOLimits{
+		{Device: "sda", ReadBps: 1048576},
+	}
+
+	j2 := j1.Copy()
+	must.False(t, tasksUpdated(j1, j2, name).modified)
+
+	j2.TaskGroups[0].Tasks[0].Resources.IOWeight = 500
+	must.True(t, tasksUpdated(j1, j2, name).modified)
+
+	j3 := j1.Copy()
+	j3.TaskGroups[0].Tasks[0].Resources.IOMax[0].ReadBps = 2097152
+	must.True(t, tasksUpdated(j1, j3, name).modified)
+
+	j4 := j1.Copy()
+	j4.TaskGroups[0].Networks[0].EgressMBits = 100
+	must.True(t, tasksUpdated(j1, j4, name).modified)
+}
+
 func TestTaskGroupConstraints(t *testing.T) {
 	ci.Parallel(t)
 